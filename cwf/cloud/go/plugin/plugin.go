@@ -16,6 +16,7 @@ import (
 	"magma/orc8r/cloud/go/serde"
 	srvconfig "magma/orc8r/cloud/go/service/config"
 	"magma/orc8r/cloud/go/service/serviceregistry"
+	"magma/orc8r/cloud/go/services/dispatcher/gateway_registry"
 	"magma/orc8r/cloud/go/services/metricsd"
 	"magma/orc8r/cloud/go/services/streamer/mconfig/factory"
 	"magma/orc8r/cloud/go/services/streamer/providers"
@@ -55,3 +56,7 @@ func (*CwfOrchestratorPlugin) GetObsidianHandlers(metricsConfig *srvconfig.Confi
 func (*CwfOrchestratorPlugin) GetStreamerProviders() []providers.StreamProvider {
 	return []providers.StreamProvider{}
 }
+
+func (*CwfOrchestratorPlugin) GetGatewayServices() []gateway_registry.GwServiceType {
+	return []gateway_registry.GwServiceType{}
+}
@@ -21,6 +21,7 @@ import (
 	"magma/orc8r/cloud/go/serde"
 	srvconfig "magma/orc8r/cloud/go/service/config"
 	"magma/orc8r/cloud/go/service/serviceregistry"
+	"magma/orc8r/cloud/go/services/dispatcher/gateway_registry"
 	"magma/orc8r/cloud/go/services/metricsd"
 	"magma/orc8r/cloud/go/services/streamer/mconfig/factory"
 	"magma/orc8r/cloud/go/services/streamer/providers"
@@ -68,3 +69,7 @@ func (*FegOrchestratorPlugin) GetObsidianHandlers(metricsConfig *srvconfig.Confi
 func (*FegOrchestratorPlugin) GetStreamerProviders() []providers.StreamProvider {
 	return []providers.StreamProvider{}
 }
+
+func (*FegOrchestratorPlugin) GetGatewayServices() []gateway_registry.GwServiceType {
+	return []gateway_registry.GwServiceType{}
+}
@@ -39,6 +39,10 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to initialize datastore: %s", err)
 	}
+	err = healthDatastore.ApplyMigrations()
+	if err != nil {
+		log.Fatalf("Failed to migrate datastore: %s", err)
+	}
 
 	healthStore, err := storage.NewHealthStore(healthDatastore)
 	if err != nil {
@@ -24,6 +24,7 @@ import (
 	"magma/orc8r/cloud/go/serde"
 	srvconfig "magma/orc8r/cloud/go/service/config"
 	"magma/orc8r/cloud/go/service/serviceregistry"
+	"magma/orc8r/cloud/go/services/dispatcher/gateway_registry"
 	"magma/orc8r/cloud/go/services/metricsd"
 	"magma/orc8r/cloud/go/services/streamer/mconfig/factory"
 	"magma/orc8r/cloud/go/services/streamer/providers"
@@ -73,9 +74,23 @@ func (*LteOrchestratorPlugin) GetObsidianHandlers(metricsConfig *srvconfig.Confi
 }
 
 func (*LteOrchestratorPlugin) GetStreamerProviders() []providers.StreamProvider {
+	// Subscriber and policy streams are large, so gateways can request
+	// incremental updates for them
 	return []providers.StreamProvider{
-		&subscriberdbstreamer.SubscribersProvider{},
-		&policydbstreamer.PoliciesProvider{},
-		&policydbstreamer.BaseNamesProvider{},
+		providers.NewDigestDeltaProvider(&subscriberdbstreamer.SubscribersProvider{}),
+		providers.NewDigestDeltaProvider(&policydbstreamer.PoliciesProvider{}),
+		providers.NewDigestDeltaProvider(&policydbstreamer.BaseNamesProvider{}),
+	}
+}
+
+func (*LteOrchestratorPlugin) GetGatewayServices() []gateway_registry.GwServiceType {
+	return []gateway_registry.GwServiceType{
+		gateway_registry.GwEnodebd,
+		gateway_registry.GwMobilityd,
+		gateway_registry.GwPipelined,
+		gateway_registry.GwS6aService,
+		gateway_registry.GwSessiondService,
+		gateway_registry.GwSgsService,
+		gateway_registry.GwSubscriberDB,
 	}
 }
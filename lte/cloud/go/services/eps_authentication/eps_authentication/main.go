@@ -33,6 +33,10 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to initialize datastore: %s", err)
 	}
+	err = store.ApplyMigrations()
+	if err != nil {
+		log.Fatalf("Failed to migrate datastore: %s", err)
+	}
 
 	subscriberDBStore, err := storage.NewSubscriberDBStorage(store)
 	if err != nil {
@@ -33,6 +33,10 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to initialize datastore: %s", err)
 	}
+	err = store.ApplyMigrations()
+	if err != nil {
+		log.Fatalf("Failed to migrate datastore: %s", err)
+	}
 
 	// Add servicers to the service
 	servicer := servicers.NewPolicyDBServer(store)
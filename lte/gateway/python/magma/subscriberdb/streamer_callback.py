@@ -16,6 +16,7 @@ from lte.protos.subscriberdb_pb2 import SubscriberData
 
 from magma.common.service_registry import ServiceRegistry
 from magma.common.streamer import StreamerClient
+from magma.subscriberdb.store.base import SubscriberNotFoundError
 
 
 class SubscriberDBStreamerCallback(StreamerClient.Callback):
@@ -30,6 +31,9 @@ class SubscriberDBStreamerCallback(StreamerClient.Callback):
     def get_request_args(self, stream_name: str) -> Any:
         return None
 
+    def supports_deltas(self, stream_name: str) -> bool:
+        return True
+
     def process_update(self, stream_name, updates, resync):
 
         logging.info("Processing %d subscriber updates (resync=%s)",
@@ -50,8 +54,27 @@ class SubscriberDBStreamerCallback(StreamerClient.Callback):
             logging.debug("Resync with subscribers: %s", ','.join(keys))
             self._store.resync(subscribers)
         else:
-            # TODO: implement updates
-            pass
+            for update in updates:
+                sub = SubscriberData()
+                sub.ParseFromString(update.value)
+                self._upsert_subscriber(update.key, sub)
+
+    def process_deletes(self, stream_name, deleted_keys):
+        logging.info("Processing %d subscriber deletions", len(deleted_keys))
+        for sub_id in deleted_keys:
+            self._store.delete_subscriber(sub_id)
+        self.detach_deleted_subscribers(deleted_keys, [])
+
+    def _upsert_subscriber(self, sub_id, sub):
+        """
+        Add the subscriber, or update it while keeping its current state
+        """
+        try:
+            with self._store.edit_subscriber(sub_id) as current:
+                sub.state.CopyFrom(current.state)
+                current.CopyFrom(sub)
+        except SubscriberNotFoundError:
+            self._store.add_subscriber(sub)
 
     def detach_deleted_subscribers(self, old_sub_ids, new_sub_ids):
         """
@@ -14,6 +14,8 @@ import unittest
 import unittest.mock
 
 from lte.protos.s6a_service_pb2 import DeleteSubscriberRequest
+from lte.protos.subscriberdb_pb2 import SubscriberData
+from magma.subscriberdb.sid import SIDUtils
 from magma.subscriberdb.store.sqlite import SqliteStore
 from magma.subscriberdb.streamer_callback import SubscriberDBStreamerCallback
 
@@ -45,6 +47,7 @@ class SubscriberDBStreamerCallbackTests(unittest.TestCase):
 
     def setUp(self):
         store = SqliteStore('file::memory:')
+        self._store = store
         self._streamer_callback = \
             SubscriberDBStreamerCallback(store, loop=asyncio.new_event_loop())
         ServiceRegistry.add_service('test', '0.0.0.0', 0)
@@ -85,6 +88,43 @@ class SubscriberDBStreamerCallbackTests(unittest.TestCase):
                 imsi_list=["IMSI101", "IMSI303"]
             ))
 
+    @unittest.mock.patch('magma.subscriberdb.streamer_callback.S6aServiceStub')
+    def test_process_delta(self, s6a_service_mock_stub):
+        """
+        Test if incremental updates are applied to the store, keeping the
+        state of updated subscribers.
+        """
+        mock = unittest.mock.Mock()
+        s6a_service_mock_stub.side_effect = [mock]
+
+        def subscriber(sid, opc):
+            sub = SubscriberData(sid=SIDUtils.to_pb(sid))
+            sub.lte.auth_opc = opc
+            return sub
+
+        sub1 = subscriber('IMSI101', b'1')
+        sub1.state.lte_auth_next_seq = 5
+        self._store.add_subscriber(sub1)
+        self._store.add_subscriber(subscriber('IMSI202', b'2'))
+
+        update = unittest.mock.Mock()
+        update.key = 'IMSI101'
+        update.value = subscriber('IMSI101', b'3').SerializeToString()
+        added = unittest.mock.Mock()
+        added.key = 'IMSI303'
+        added.value = subscriber('IMSI303', b'4').SerializeToString()
+        self._streamer_callback.process_update(
+            'subscriberdb', [update, added], False)
+        self._streamer_callback.process_deletes('subscriberdb', ['IMSI202'])
+
+        self.assertEqual(sorted(self._store.list_subscribers()),
+                         ['IMSI101', 'IMSI303'])
+        sub1 = self._store.get_subscriber_data('IMSI101')
+        self.assertEqual(sub1.lte.auth_opc, b'3')
+        self.assertEqual(sub1.state.lte_auth_next_seq, 5)
+        mock.DeleteSubscriber.future.assert_called_once_with(
+            DeleteSubscriberRequest(imsi_list=['IMSI202']))
+
 
 if __name__ == "__main__":
     unittest.main()
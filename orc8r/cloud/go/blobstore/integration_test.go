@@ -199,4 +199,87 @@ func integration(t *testing.T, fact blobstore.BlobStorageFactory) {
 	})
 	assert.NoError(t, err)
 	assert.Equal(t, []blobstore.Blob{{Type: "t3", Key: "k3", Value: []byte("v5"), Version: 0}}, getManyActual)
+
+	// Search by type and key prefix
+	searchActual, err := store.Search("network1", blobstore.SearchFilter{}, nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]blobstore.Blob{
+			{Type: "t1", Key: "k2", Value: []byte("v2"), Version: 0},
+			{Type: "t2", Key: "k1", Value: []byte("v3"), Version: 0},
+			{Type: "t9", Key: "k9", Value: []byte("world"), Version: 0},
+		},
+		searchActual,
+	)
+	searchActual, err = store.Search("network2", blobstore.SearchFilter{Type: strPtr("t3"), KeyPrefix: strPtr("k4")}, nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []blobstore.Blob{{Type: "t3", Key: "k4", Value: []byte("v6"), Version: 0}}, searchActual)
+	searchActual, err = store.Search("network2", blobstore.SearchFilter{Type: strPtr("t1")}, nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []blobstore.Blob{}, searchActual)
+
+	// Search by owner
+	err = store.CreateOrUpdate("network3", []blobstore.Blob{
+		{Type: "t1", Key: "k1", Value: []byte("v7"), Owner: "o1"},
+		{Type: "t1", Key: "k2", Value: []byte("v8"), Owner: "o2"},
+	})
+	assert.NoError(t, err)
+	searchActual, err = store.Search("network3", blobstore.SearchFilter{Owner: strPtr("o2")}, nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []blobstore.Blob{{Type: "t1", Key: "k2", Value: []byte("v8"), Version: 0, Owner: "o2"}}, searchActual)
+
+	// Search one page at a time
+	searchActual, err = store.Search("network1", blobstore.SearchFilter{}, nil, 2)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]blobstore.Blob{
+			{Type: "t1", Key: "k2", Value: []byte("v2"), Version: 0},
+			{Type: "t2", Key: "k1", Value: []byte("v3"), Version: 0},
+		},
+		searchActual,
+	)
+	searchActual, err = store.Search("network1", blobstore.SearchFilter{}, &storage.TypeAndKey{Type: "t2", Key: "k1"}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []blobstore.Blob{{Type: "t9", Key: "k9", Value: []byte("world"), Version: 0}}, searchActual)
+	searchActual, err = store.Search("network1", blobstore.SearchFilter{Type: strPtr("t1")}, &storage.TypeAndKey{Type: "t1", Key: "k2"}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []blobstore.Blob{}, searchActual)
+	assert.NoError(t, store.Commit())
+
+	// Compare-and-swap
+	store, err = fact.StartTransaction()
+	assert.NoError(t, err)
+
+	blob, err := store.GetForUpdate("network2", storage.TypeAndKey{Type: "t3", Key: "k3"})
+	assert.NoError(t, err)
+	err = store.CreateOrUpdateIfVersion("network2", blobstore.Blob{Type: "t3", Key: "k3", Value: []byte("cas")}, &blob.Version)
+	assert.NoError(t, err)
+	getActual, err = store.Get("network2", storage.TypeAndKey{Type: "t3", Key: "k3"})
+	assert.NoError(t, err)
+	assert.Equal(t, blobstore.Blob{Type: "t3", Key: "k3", Value: []byte("cas"), Version: 1}, getActual)
+
+	// Stale version
+	err = store.CreateOrUpdateIfVersion("network2", blobstore.Blob{Type: "t3", Key: "k3", Value: []byte("stale")}, &blob.Version)
+	assert.True(t, blobstore.IsVersionConflict(err))
+	assert.EqualError(t, err, "version conflict on blob t3-k3 in network network2: expected version 0")
+
+	// Create-only
+	err = store.CreateOrUpdateIfVersion("network2", blobstore.Blob{Type: "t3", Key: "k4", Value: []byte("dup")}, nil)
+	assert.True(t, blobstore.IsVersionConflict(err))
+	err = store.CreateOrUpdateIfVersion("network2", blobstore.Blob{Type: "t3", Key: "k5", Value: []byte("new")}, nil)
+	assert.NoError(t, err)
+	getActual, err = store.Get("network2", storage.TypeAndKey{Type: "t3", Key: "k5"})
+	assert.NoError(t, err)
+	assert.Equal(t, blobstore.Blob{Type: "t3", Key: "k5", Value: []byte("new"), Version: 0}, getActual)
+
+	// Missing blob with an expected version
+	err = store.CreateOrUpdateIfVersion("network2", blobstore.Blob{Type: "t3", Key: "k6", Value: []byte("missing")}, &blob.Version)
+	assert.True(t, blobstore.IsVersionConflict(err))
+	assert.NoError(t, store.Commit())
+}
+
+func strPtr(s string) *string {
+	return &s
 }
@@ -12,7 +12,9 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	magmaerrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/storage"
@@ -48,6 +50,10 @@ type memoryBlobStorage struct {
 	transactionExists bool
 	// changes stores changes during a transaction
 	changes transactionTable
+	// sharedVersions stores the shared version of each blob which was
+	// conditionally written during a transaction, nil if the blob didn't
+	// exist. Commit fails if any of them changed in the meantime.
+	sharedVersions map[tNetworkID]map[storage.TypeAndKey]*uint64
 
 	// stores everything needed to access the shared map
 	shared sharedMemoryBlobTables
@@ -73,7 +79,8 @@ func (fact *memoryBlobStoreFactory) StartTransaction() (TransactionalBlobStorage
 	return &memoryBlobStorage{
 		shared:            sharedMemoryBlobTables{RWMutex: &fact.RWMutex, table: fact.table},
 		transactionExists: true,
-		changes:           transactionTable{}}, nil
+		changes:           transactionTable{},
+		sharedVersions:    map[tNetworkID]map[storage.TypeAndKey]*uint64{}}, nil
 }
 
 func (fact *memoryBlobStoreFactory) InitializeFactory() error {
@@ -89,11 +96,14 @@ func (store *memoryBlobStorage) Commit() error {
 	}
 
 	store.shared.Lock()
-	store.applyChangesToShared()
+	err := store.checkSharedVersionsUnsafe()
+	if err == nil {
+		store.applyChangesToShared()
+	}
 	store.shared.Unlock()
 
 	store.resetTransaction()
-	return nil
+	return err
 }
 
 func (store *memoryBlobStorage) Rollback() error {
@@ -152,14 +162,147 @@ func (store *memoryBlobStorage) GetMany(networkID string, ids []storage.TypeAndK
 	return store.updateBlobsWithLocalChangesUnsafe(networkID, ids, sharedBlobs)
 }
 
+func (store *memoryBlobStorage) GetForUpdate(networkID string, id storage.TypeAndKey) (Blob, error) {
+	return store.Get(networkID, id)
+}
+
+// Search grabs all blobs in the network from the shared map, updates the
+// blobs with changes from the ongoing transaction, then filters them
+func (store *memoryBlobStorage) Search(networkID string, filter SearchFilter, startAfter *storage.TypeAndKey, limit uint64) ([]Blob, error) {
+	allBlobs, err := store.getAll()
+	if err != nil {
+		return nil, err
+	}
+	ret := []Blob{}
+	for _, blob := range allBlobs[networkID] {
+		if limit > 0 && uint64(len(ret)) >= limit {
+			break
+		}
+		if filter.Type != nil && blob.Type != *filter.Type {
+			continue
+		}
+		if filter.KeyPrefix != nil && !strings.HasPrefix(blob.Key, *filter.KeyPrefix) {
+			continue
+		}
+		if filter.Owner != nil && blob.Owner != *filter.Owner {
+			continue
+		}
+		if startAfter != nil && (blob.Type < startAfter.Type || (blob.Type == startAfter.Type && blob.Key <= startAfter.Key)) {
+			continue
+		}
+		ret = append(ret, blob)
+	}
+	return ret, nil
+}
+
+// getAll grabs all blobs from the shared map, then updates the blobs with
+// changes from the ongoing transaction. Blobs are keyed by network ID and
+// ordered by type, then key.
+func (store *memoryBlobStorage) getAll() (map[string][]Blob, error) {
+	store.RLock()
+	defer store.RUnlock()
+
+	if err := store.validateTx(); err != nil {
+		return nil, err
+	}
+
+	allBlobs := blobTable{}
+	store.shared.RLock()
+	for networkID, sharedBlobs := range store.shared.table {
+		allBlobs.initializeNetworkTable(networkID)
+		for id, blob := range sharedBlobs {
+			allBlobs[networkID][id] = blob
+		}
+	}
+	store.shared.RUnlock()
+
+	for networkID, perNetworkChangeMap := range store.changes {
+		allBlobs.initializeNetworkTable(networkID)
+		for id, change := range perNetworkChangeMap {
+			switch change.cType {
+			case Delete:
+				delete(allBlobs[networkID], id)
+			case CreateOrUpdate:
+				allBlobs[networkID][id] = change.blob
+			default:
+				return nil, fmt.Errorf("This transcaction contains ill-formatted changes.")
+			}
+		}
+	}
+
+	ret := map[string][]Blob{}
+	for networkID, blobs := range allBlobs {
+		if len(blobs) == 0 {
+			continue
+		}
+		blobList := blobs.toBlobList()
+		sort.Slice(blobList, func(i, j int) bool {
+			if blobList[i].Type != blobList[j].Type {
+				return blobList[i].Type < blobList[j].Type
+			}
+			return blobList[i].Key < blobList[j].Key
+		})
+		ret[networkID] = blobList
+	}
+	return ret, nil
+}
+
 func (store *memoryBlobStorage) CreateOrUpdate(networkID string, blobs []Blob) error {
 	store.Lock()
 	defer store.Unlock()
 
+	if err := store.validateTx(); err != nil {
+		return err
+	}
+	store.createOrUpdateUnsafe(networkID, blobs)
+	return nil
+}
+
+// CreateOrUpdateIfVersion checks the version and records the write under
+// the same lock, and remembers the shared version it checked against so that
+// Commit can detect a conflicting write from another transaction.
+func (store *memoryBlobStorage) CreateOrUpdateIfVersion(networkID string, blob Blob, expectedVersion *uint64) error {
+	store.Lock()
+	defer store.Unlock()
+
 	if err := store.validateTx(); err != nil {
 		return err
 	}
 
+	id := blob.toID()
+	ids := []storage.TypeAndKey{id}
+	store.shared.RLock()
+	sharedBlobs := store.getManyFromShared(networkID, ids)
+	store.shared.RUnlock()
+
+	if _, ok := store.sharedVersions[networkID]; !ok {
+		store.sharedVersions[networkID] = map[storage.TypeAndKey]*uint64{}
+	}
+	if _, ok := store.sharedVersions[networkID][id]; !ok {
+		var sharedVersion *uint64
+		if sharedBlob, ok := sharedBlobs[id]; ok {
+			sharedVersion = &sharedBlob.Version
+		}
+		store.sharedVersions[networkID][id] = sharedVersion
+	}
+
+	existingBlobs, err := store.updateBlobsWithLocalChangesUnsafe(networkID, ids, sharedBlobs)
+	if err != nil {
+		return err
+	}
+	conflictErr := &VersionConflictError{NetworkID: networkID, ID: id, ExpectedVersion: expectedVersion}
+	switch {
+	case expectedVersion == nil && len(existingBlobs) > 0:
+		return conflictErr
+	case expectedVersion != nil && (len(existingBlobs) == 0 || existingBlobs[0].Version != *expectedVersion):
+		return conflictErr
+	}
+	store.createOrUpdateUnsafe(networkID, []Blob{blob})
+	return nil
+}
+
+// Must be called with write lock on change map.
+func (store *memoryBlobStorage) createOrUpdateUnsafe(networkID string, blobs []Blob) {
 	ids := blobsToIDs(blobs)
 	store.shared.RLock()
 	sharedBlobSet := store.getManyFromShared(networkID, ids)
@@ -184,7 +327,6 @@ func (store *memoryBlobStorage) CreateOrUpdate(networkID string, blobs []Blob) e
 		}
 		perNetworkLocalMap[id] = change{cType: CreateOrUpdate, blob: blob}
 	}
-	return nil
 }
 
 func (store *memoryBlobStorage) Delete(networkID string, ids []storage.TypeAndKey) error {
@@ -202,6 +344,30 @@ func (store *memoryBlobStorage) Delete(networkID string, ids []storage.TypeAndKe
 	return nil
 }
 
+// DeleteExpired records the deletion of every expired blob, including blobs
+// written in the ongoing transaction
+func (store *memoryBlobStorage) DeleteExpired(now time.Time) (int64, error) {
+	allBlobs, err := store.getAll()
+	if err != nil {
+		return 0, err
+	}
+
+	store.Lock()
+	defer store.Unlock()
+	var deleted int64
+	for networkID, blobs := range allBlobs {
+		for _, blob := range blobs {
+			if !blob.IsExpired(now) {
+				continue
+			}
+			store.changes.initializeNetworkTable(networkID)
+			store.changes[networkID][blob.toID()] = change{cType: Delete}
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
 // Must be called with read lock on change map.
 func (store *memoryBlobStorage) validateTx() error {
 	if store.transactionExists == false {
@@ -210,6 +376,25 @@ func (store *memoryBlobStorage) validateTx() error {
 	return nil
 }
 
+// Returns a *VersionConflictError if a blob conditionally written in this
+// transaction has changed in the shared map since it was checked. Must be
+// called with read lock on both local and shared maps.
+func (store *memoryBlobStorage) checkSharedVersionsUnsafe() error {
+	for networkID, versionsByID := range store.sharedVersions {
+		for id, expectedVersion := range versionsByID {
+			sharedBlob, exists := store.shared.table[networkID][id]
+			switch {
+			case expectedVersion == nil && !exists:
+				continue
+			case expectedVersion != nil && exists && sharedBlob.Version == *expectedVersion:
+				continue
+			}
+			return &VersionConflictError{NetworkID: networkID, ID: id, ExpectedVersion: expectedVersion}
+		}
+	}
+	return nil
+}
+
 // Traverse through the changes from the transaction and put them into the
 // shared map. Must be called with write lock on both local and shared maps.
 func (store *memoryBlobStorage) applyChangesToShared() error {
@@ -234,6 +419,7 @@ func (store *memoryBlobStorage) applyChangesToShared() error {
 func (store *memoryBlobStorage) resetTransaction() {
 	store.transactionExists = false
 	store.changes = nil
+	store.sharedVersions = nil
 }
 
 // Given a networkID and a type this function looks in the shared map and
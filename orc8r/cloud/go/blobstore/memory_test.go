@@ -211,6 +211,43 @@ func TestMemoryBlobStorage_ListKeys(t *testing.T) {
 	assert.Equal(t, []string{key2}, keys)
 }
 
+func TestMemoryBlobStorage_CreateOrUpdateIfVersion(t *testing.T) {
+	factory := blobstore.NewMemoryBlobStorageFactory()
+	network1 := "network1"
+	id := storage.TypeAndKey{Type: "type1", Key: "key1"}
+
+	// Two transactions both create the blob, only the first commits
+	store1, err := factory.StartTransaction()
+	assert.NoError(t, err)
+	store2, err := factory.StartTransaction()
+	assert.NoError(t, err)
+	assert.NoError(t, store1.CreateOrUpdateIfVersion(network1, blobstore.Blob{Type: "type1", Key: "key1", Value: []byte("v1")}, nil))
+	assert.NoError(t, store2.CreateOrUpdateIfVersion(network1, blobstore.Blob{Type: "type1", Key: "key1", Value: []byte("v2")}, nil))
+	assert.NoError(t, store1.Commit())
+	err = store2.Commit()
+	assert.True(t, blobstore.IsVersionConflict(err))
+
+	// Two transactions both update from the same version
+	store1, err = factory.StartTransaction()
+	assert.NoError(t, err)
+	store2, err = factory.StartTransaction()
+	assert.NoError(t, err)
+	blob, err := store1.GetForUpdate(network1, id)
+	assert.NoError(t, err)
+	assert.NoError(t, store1.CreateOrUpdateIfVersion(network1, blobstore.Blob{Type: "type1", Key: "key1", Value: []byte("v3")}, &blob.Version))
+	assert.NoError(t, store2.CreateOrUpdateIfVersion(network1, blobstore.Blob{Type: "type1", Key: "key1", Value: []byte("v4")}, &blob.Version))
+	assert.NoError(t, store1.Commit())
+	err = store2.Commit()
+	assert.True(t, blobstore.IsVersionConflict(err))
+
+	store1, err = factory.StartTransaction()
+	assert.NoError(t, err)
+	blob, err = store1.Get(network1, id)
+	assert.NoError(t, err)
+	assert.Equal(t, blobstore.Blob{Type: "type1", Key: "key1", Value: []byte("v3"), Version: 1}, blob)
+	assert.NoError(t, store1.Commit())
+}
+
 func TestMemoryBlobStorage_Integration(t *testing.T) {
 	fact := blobstore.NewMemoryBlobStorageFactory()
 	integration(t, fact)
@@ -13,6 +13,7 @@ package mocks
 import blobstore "magma/orc8r/cloud/go/blobstore"
 import mock "github.com/stretchr/testify/mock"
 import storage "magma/orc8r/cloud/go/storage"
+import time "time"
 
 // TransactionalBlobStorage is an autogenerated mock type for the TransactionalBlobStorage type
 type TransactionalBlobStorage struct {
@@ -47,6 +48,20 @@ func (_m *TransactionalBlobStorage) CreateOrUpdate(networkID string, blobs []blo
 	return r0
 }
 
+// CreateOrUpdateIfVersion provides a mock function with given fields: networkID, blob, expectedVersion
+func (_m *TransactionalBlobStorage) CreateOrUpdateIfVersion(networkID string, blob blobstore.Blob, expectedVersion *uint64) error {
+	ret := _m.Called(networkID, blob, expectedVersion)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, blobstore.Blob, *uint64) error); ok {
+		r0 = rf(networkID, blob, expectedVersion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: networkID, ids
 func (_m *TransactionalBlobStorage) Delete(networkID string, ids []storage.TypeAndKey) error {
 	ret := _m.Called(networkID, ids)
@@ -61,6 +76,27 @@ func (_m *TransactionalBlobStorage) Delete(networkID string, ids []storage.TypeA
 	return r0
 }
 
+// DeleteExpired provides a mock function with given fields: now
+func (_m *TransactionalBlobStorage) DeleteExpired(now time.Time) (int64, error) {
+	ret := _m.Called(now)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: networkID, id
 func (_m *TransactionalBlobStorage) Get(networkID string, id storage.TypeAndKey) (blobstore.Blob, error) {
 	ret := _m.Called(networkID, id)
@@ -82,6 +118,27 @@ func (_m *TransactionalBlobStorage) Get(networkID string, id storage.TypeAndKey)
 	return r0, r1
 }
 
+// GetForUpdate provides a mock function with given fields: networkID, id
+func (_m *TransactionalBlobStorage) GetForUpdate(networkID string, id storage.TypeAndKey) (blobstore.Blob, error) {
+	ret := _m.Called(networkID, id)
+
+	var r0 blobstore.Blob
+	if rf, ok := ret.Get(0).(func(string, storage.TypeAndKey) blobstore.Blob); ok {
+		r0 = rf(networkID, id)
+	} else {
+		r0 = ret.Get(0).(blobstore.Blob)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, storage.TypeAndKey) error); ok {
+		r1 = rf(networkID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMany provides a mock function with given fields: networkID, ids
 func (_m *TransactionalBlobStorage) GetMany(networkID string, ids []storage.TypeAndKey) ([]blobstore.Blob, error) {
 	ret := _m.Called(networkID, ids)
@@ -141,3 +198,26 @@ func (_m *TransactionalBlobStorage) Rollback() error {
 
 	return r0
 }
+
+// Search provides a mock function with given fields: networkID, filter, startAfter, limit
+func (_m *TransactionalBlobStorage) Search(networkID string, filter blobstore.SearchFilter, startAfter *storage.TypeAndKey, limit uint64) ([]blobstore.Blob, error) {
+	ret := _m.Called(networkID, filter, startAfter, limit)
+
+	var r0 []blobstore.Blob
+	if rf, ok := ret.Get(0).(func(string, blobstore.SearchFilter, *storage.TypeAndKey, uint64) []blobstore.Blob); ok {
+		r0 = rf(networkID, filter, startAfter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]blobstore.Blob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, blobstore.SearchFilter, *storage.TypeAndKey, uint64) error); ok {
+		r1 = rf(networkID, filter, startAfter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
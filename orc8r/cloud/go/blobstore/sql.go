@@ -12,6 +12,7 @@ import (
 	"database/sql"
 	"fmt"
 	"sort"
+	"time"
 
 	magmaerrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/sqorc"
@@ -28,18 +29,21 @@ const (
 	keyCol  = "\"key\""
 	valCol  = "value"
 	verCol  = "version"
+	expCol  = "expires_at"
+	ownCol  = "owner"
 )
 
 // NewSQLBlobStorageFactory returns a BlobStorageFactory implementation which
 // will return storage APIs backed by SQL.
 func NewSQLBlobStorageFactory(tableName string, db *sql.DB, sqlBuilder sqorc.StatementBuilder) BlobStorageFactory {
-	return &sqlBlobStoreFactory{tableName: tableName, db: db, builder: sqlBuilder}
+	return &sqlBlobStoreFactory{tableName: tableName, db: db, builder: sqlBuilder, lockRows: sqorc.SupportsRowLocking(db)}
 }
 
 type sqlBlobStoreFactory struct {
 	tableName string
 	db        *sql.DB
 	builder   sqorc.StatementBuilder
+	lockRows  bool
 }
 
 func (fact *sqlBlobStoreFactory) StartTransaction() (TransactionalBlobStorage, error) {
@@ -47,7 +51,7 @@ func (fact *sqlBlobStoreFactory) StartTransaction() (TransactionalBlobStorage, e
 	if err != nil {
 		return nil, err
 	}
-	return &sqlBlobStorage{tableName: fact.tableName, tx: tx, builder: fact.builder}, nil
+	return &sqlBlobStorage{tableName: fact.tableName, tx: tx, builder: fact.builder, lockRows: fact.lockRows}, nil
 }
 
 func (fact *sqlBlobStoreFactory) InitializeFactory() error {
@@ -55,7 +59,7 @@ func (fact *sqlBlobStoreFactory) InitializeFactory() error {
 	if err != nil {
 		return err
 	}
-	err = fact.initTable(tx, fact.tableName)
+	err = initTable(tx, fact.builder, fact.tableName)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			glog.Errorf("error rolling back transaction initializing blobstore factory: %s", err)
@@ -66,14 +70,57 @@ func (fact *sqlBlobStoreFactory) InitializeFactory() error {
 	return tx.Commit()
 }
 
-func (fact *sqlBlobStoreFactory) initTable(tx *sql.Tx, tableName string) error {
-	_, err := fact.builder.CreateTable(tableName).
+// NewSQLBlobStorageMigrations returns the migrations which create and update
+// a service's blobstore table, for services to register with sqorc.
+func NewSQLBlobStorageMigrations(service string, tableName string) []sqorc.Migration {
+	return []sqorc.Migration{
+		{
+			Service:     service,
+			Version:     1,
+			Description: fmt.Sprintf("Create blobstore table %s", tableName),
+			Up: func(tx *sql.Tx, builder sqorc.StatementBuilder) error {
+				return initTable(tx, builder, tableName)
+			},
+		},
+		{
+			Service:     service,
+			Version:     2,
+			Description: fmt.Sprintf("Add expiration time to blobstore table %s", tableName),
+			Up: func(tx *sql.Tx, builder sqorc.StatementBuilder) error {
+				// Tables created by the first migration after the column was
+				// added already have it
+				_, err := tx.Exec(fmt.Sprintf(
+					"ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s BIGINT NOT NULL DEFAULT 0",
+					tableName, expCol,
+				))
+				return err
+			},
+		},
+		{
+			Service:     service,
+			Version:     3,
+			Description: fmt.Sprintf("Add owner to blobstore table %s", tableName),
+			Up: func(tx *sql.Tx, builder sqorc.StatementBuilder) error {
+				_, err := tx.Exec(fmt.Sprintf(
+					"ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s TEXT NOT NULL DEFAULT ''",
+					tableName, ownCol,
+				))
+				return err
+			},
+		},
+	}
+}
+
+func initTable(tx *sql.Tx, builder sqorc.StatementBuilder, tableName string) error {
+	_, err := builder.CreateTable(tableName).
 		IfNotExists().
 		Column(nidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
 		Column(typeCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
 		Column(keyCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
 		Column(valCol).Type(sqorc.ColumnTypeBytes).EndColumn().
 		Column(verCol).Type(sqorc.ColumnTypeInt).NotNull().Default(0).EndColumn().
+		Column(expCol).Type(sqorc.ColumnTypeBigInt).NotNull().Default(0).EndColumn().
+		Column(ownCol).Type(sqorc.ColumnTypeText).NotNull().Default("''").EndColumn().
 		PrimaryKey(nidCol, typeCol, keyCol).
 		RunWith(tx).
 		Exec()
@@ -84,6 +131,8 @@ type sqlBlobStorage struct {
 	tableName string
 	tx        *sql.Tx
 	builder   sqorc.StatementBuilder
+	// lockRows is false if the DB doesn't support SELECT ... FOR UPDATE
+	lockRows bool
 }
 
 func (store *sqlBlobStorage) Commit() error {
@@ -144,34 +193,57 @@ func (store *sqlBlobStorage) Get(networkID string, id storage.TypeAndKey) (Blob,
 }
 
 func (store *sqlBlobStorage) GetMany(networkID string, ids []storage.TypeAndKey) ([]Blob, error) {
+	return store.getMany(networkID, ids, false)
+}
+
+// GetForUpdate reads the blob with SELECT ... FOR UPDATE, so concurrent
+// transactions block on the row until this one ends.
+func (store *sqlBlobStorage) GetForUpdate(networkID string, id storage.TypeAndKey) (Blob, error) {
+	multiRet, err := store.getMany(networkID, []storage.TypeAndKey{id}, store.lockRows)
+	if err != nil {
+		return Blob{}, err
+	}
+	if len(multiRet) == 0 {
+		return Blob{}, magmaerrors.ErrNotFound
+	}
+	return multiRet[0], nil
+}
+
+func (store *sqlBlobStorage) Search(networkID string, filter SearchFilter, startAfter *storage.TypeAndKey, limit uint64) ([]Blob, error) {
 	emptyRet := []Blob{}
 	if err := store.validateTx(); err != nil {
 		return emptyRet, err
 	}
 
-	whereCondition := getWhereCondition(networkID, ids)
-	rows, err := store.builder.Select(typeCol, keyCol, valCol, verCol).From(store.tableName).
+	// Use explicit sq.And to preserve ordering of WHERE clause items
+	whereCondition := sq.And{sq.Eq{nidCol: networkID}}
+	if filter.Type != nil {
+		whereCondition = append(whereCondition, sq.Eq{typeCol: *filter.Type})
+	}
+	if filter.KeyPrefix != nil {
+		whereCondition = append(whereCondition, sqorc.HasPrefix(keyCol, *filter.KeyPrefix))
+	}
+	if filter.Owner != nil {
+		whereCondition = append(whereCondition, sq.Eq{ownCol: *filter.Owner})
+	}
+	if startAfter != nil {
+		whereCondition = append(whereCondition, sq.Or{
+			sq.Gt{typeCol: startAfter.Type},
+			sq.And{sq.Eq{typeCol: startAfter.Type}, sq.Gt{keyCol: startAfter.Key}},
+		})
+	}
+	selectBuilder := store.builder.Select(typeCol, keyCol, valCol, verCol, expCol, ownCol).From(store.tableName).
 		Where(whereCondition).
-		RunWith(store.tx).
-		Query()
+		OrderBy(typeCol, keyCol)
+	if limit > 0 {
+		selectBuilder = selectBuilder.Limit(limit)
+	}
+	rows, err := selectBuilder.RunWith(store.tx).Query()
 	if err != nil {
 		return emptyRet, err
 	}
-	defer sqorc.CloseRowsLogOnError(rows, "GetMany")
-
-	scannedRows := []Blob{}
-	for rows.Next() {
-		var t, k string
-		var val []byte
-		var version uint64
-
-		err = rows.Scan(&t, &k, &val, &version)
-		if err != nil {
-			return emptyRet, err
-		}
-		scannedRows = append(scannedRows, Blob{Type: t, Key: k, Value: val, Version: version})
-	}
-	return scannedRows, nil
+	defer sqorc.CloseRowsLogOnError(rows, "Search")
+	return scanBlobs(rows)
 }
 
 func (store *sqlBlobStorage) CreateOrUpdate(networkID string, blobs []Blob) error {
@@ -198,6 +270,64 @@ func (store *sqlBlobStorage) CreateOrUpdate(networkID string, blobs []Blob) erro
 	return nil
 }
 
+func (store *sqlBlobStorage) CreateOrUpdateIfVersion(networkID string, blob Blob, expectedVersion *uint64) error {
+	if err := store.validateTx(); err != nil {
+		return err
+	}
+	blobID := storage.TypeAndKey{Type: blob.Type, Key: blob.Key}
+	conflictErr := &VersionConflictError{NetworkID: networkID, ID: blobID, ExpectedVersion: expectedVersion}
+
+	if expectedVersion == nil {
+		// Insert-if-absent in one statement so that a concurrent create
+		// surfaces as a conflict rather than a primary key violation
+		res, err := store.builder.Insert(store.tableName).
+			Columns(nidCol, typeCol, keyCol, valCol, expCol, ownCol).
+			Values(networkID, blob.Type, blob.Key, blob.Value, blob.ExpirationTime, blob.Owner).
+			OnConflict(nil, nidCol, typeCol, keyCol).
+			RunWith(store.tx).
+			Exec()
+		if err != nil {
+			return fmt.Errorf("Error creating blob (%s, %s, %s): %s", networkID, blobID.Type, blobID.Key, err)
+		}
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("Error creating blob (%s, %s, %s): %s", networkID, blobID.Type, blobID.Key, err)
+		}
+		if rowsAffected == 0 {
+			return conflictErr
+		}
+		return nil
+	}
+
+	res, err := store.builder.Update(store.tableName).
+		Set(valCol, blob.Value).
+		Set(verCol, *expectedVersion+1).
+		Set(expCol, blob.ExpirationTime).
+		Set(ownCol, blob.Owner).
+		Where(
+			// Use explicit sq.And to preserve ordering of WHERE clause items
+			sq.And{
+				sq.Eq{nidCol: networkID},
+				sq.Eq{typeCol: blobID.Type},
+				sq.Eq{keyCol: blobID.Key},
+				sq.Eq{verCol: *expectedVersion},
+			},
+		).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("Error updating blob (%s, %s, %s): %s", networkID, blobID.Type, blobID.Key, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Error updating blob (%s, %s, %s): %s", networkID, blobID.Type, blobID.Key, err)
+	}
+	if rowsAffected == 0 {
+		return conflictErr
+	}
+	return nil
+}
+
 func (store *sqlBlobStorage) Delete(networkID string, ids []storage.TypeAndKey) error {
 	if err := store.validateTx(); err != nil {
 		return err
@@ -211,6 +341,28 @@ func (store *sqlBlobStorage) Delete(networkID string, ids []storage.TypeAndKey)
 	return err
 }
 
+func (store *sqlBlobStorage) DeleteExpired(now time.Time) (int64, error) {
+	if err := store.validateTx(); err != nil {
+		return 0, err
+	}
+
+	nowMs := uint64(now.UnixNano() / int64(time.Millisecond))
+	res, err := store.builder.Delete(store.tableName).
+		Where(
+			// Use explicit sq.And to preserve ordering of WHERE clause items
+			sq.And{
+				sq.Gt{expCol: 0},
+				sq.LtOrEq{expCol: nowMs},
+			},
+		).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return 0, errors.Wrap(err, "error deleting expired blobs")
+	}
+	return res.RowsAffected()
+}
+
 func (store *sqlBlobStorage) validateTx() error {
 	if store.tx == nil {
 		return errors.New("No transaction is available")
@@ -229,6 +381,8 @@ func (store *sqlBlobStorage) updateExistingBlobs(networkID string, blobsToChange
 		_, err := store.builder.Update(store.tableName).
 			Set(valCol, change.new.Value).
 			Set(verCol, change.old.Version+1).
+			Set(expCol, change.new.ExpirationTime).
+			Set(ownCol, change.new.Owner).
 			Where(
 				// Use explicit sq.And to preserve ordering of WHERE clause items
 				sq.And{
@@ -248,9 +402,9 @@ func (store *sqlBlobStorage) updateExistingBlobs(networkID string, blobsToChange
 
 func (store *sqlBlobStorage) insertNewBlobs(networkID string, blobs []Blob) error {
 	insertBuilder := store.builder.Insert(store.tableName).
-		Columns(nidCol, typeCol, keyCol, valCol)
+		Columns(nidCol, typeCol, keyCol, valCol, expCol, ownCol)
 	for _, blob := range blobs {
-		insertBuilder = insertBuilder.Values(networkID, blob.Type, blob.Key, blob.Value)
+		insertBuilder = insertBuilder.Values(networkID, blob.Type, blob.Key, blob.Value, blob.ExpirationTime, blob.Owner)
 	}
 	_, err := insertBuilder.RunWith(store.tx).Exec()
 	if err != nil {
@@ -259,6 +413,45 @@ func (store *sqlBlobStorage) insertNewBlobs(networkID string, blobs []Blob) erro
 	return nil
 }
 
+func (store *sqlBlobStorage) getMany(networkID string, ids []storage.TypeAndKey, forUpdate bool) ([]Blob, error) {
+	emptyRet := []Blob{}
+	if err := store.validateTx(); err != nil {
+		return emptyRet, err
+	}
+
+	whereCondition := getWhereCondition(networkID, ids)
+	selectBuilder := store.builder.Select(typeCol, keyCol, valCol, verCol, expCol, ownCol).From(store.tableName).
+		Where(whereCondition)
+	if forUpdate {
+		selectBuilder = selectBuilder.Suffix("FOR UPDATE")
+	}
+	rows, err := selectBuilder.RunWith(store.tx).Query()
+	if err != nil {
+		return emptyRet, err
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "GetMany")
+	return scanBlobs(rows)
+}
+
+func scanBlobs(rows *sql.Rows) ([]Blob, error) {
+	scannedRows := []Blob{}
+	for rows.Next() {
+		var t, k, owner string
+		var val []byte
+		var version, expirationTime uint64
+
+		err := rows.Scan(&t, &k, &val, &version, &expirationTime, &owner)
+		if err != nil {
+			return []Blob{}, err
+		}
+		scannedRows = append(scannedRows, Blob{Type: t, Key: k, Value: val, Version: version, ExpirationTime: expirationTime, Owner: owner})
+	}
+	if err := rows.Err(); err != nil {
+		return []Blob{}, err
+	}
+	return scannedRows, nil
+}
+
 func getWhereCondition(networkID string, ids []storage.TypeAndKey) sq.Or {
 	whereConditions := make(sq.Or, 0, len(ids))
 	for _, id := range ids {
@@ -12,6 +12,7 @@ import (
 	"database/sql/driver"
 	"errors"
 	"testing"
+	"time"
 
 	"magma/orc8r/cloud/go/blobstore"
 	magmaerrors "magma/orc8r/cloud/go/errors"
@@ -59,13 +60,13 @@ func TestSqlBlobStorage_Get(t *testing.T) {
 	happyPath := &testCase{
 		setup: func(mock sqlmock.Sqlmock) {
 			mock.ExpectQuery(
-				"SELECT type, \"key\", value, version FROM network_table "+
+				"SELECT type, \"key\", value, version, expires_at, owner FROM network_table "+
 					"WHERE \\(\\(network_id = \\$1 AND type = \\$2 AND \"key\" = \\$3\\)\\)",
 			).
 				WithArgs("network", "t1", "k1").
 				WillReturnRows(
-					sqlmock.NewRows([]string{"type", "key", "value", "version"}).
-						AddRow("t1", "k1", []byte("value1"), 42),
+					sqlmock.NewRows([]string{"type", "key", "value", "version", "expires_at", "owner"}).
+						AddRow("t1", "k1", []byte("value1"), 42, 0, ""),
 				)
 		},
 
@@ -79,12 +80,12 @@ func TestSqlBlobStorage_Get(t *testing.T) {
 	dneCase := &testCase{
 		setup: func(mock sqlmock.Sqlmock) {
 			mock.ExpectQuery(
-				"SELECT type, \"key\", value, version FROM network_table "+
+				"SELECT type, \"key\", value, version, expires_at, owner FROM network_table "+
 					"WHERE \\(\\(network_id = \\$1 AND type = \\$2 AND \"key\" = \\$3\\)\\)",
 			).
 				WithArgs("network", "t2", "k2").
 				WillReturnRows(
-					sqlmock.NewRows([]string{"type", "key", "value", "version"}),
+					sqlmock.NewRows([]string{"type", "key", "value", "version", "expires_at", "owner"}),
 				)
 		},
 
@@ -99,7 +100,7 @@ func TestSqlBlobStorage_Get(t *testing.T) {
 	queryError := &testCase{
 		setup: func(mock sqlmock.Sqlmock) {
 			mock.ExpectQuery(
-				"SELECT type, \"key\", value, version FROM network_table "+
+				"SELECT type, \"key\", value, version, expires_at, owner FROM network_table "+
 					"WHERE \\(\\(network_id = \\$1 AND type = \\$2 AND \"key\" = \\$3\\)\\)",
 			).
 				WithArgs("network", "t3", "k3").
@@ -122,15 +123,15 @@ func TestSqlBlobStorage_GetMany(t *testing.T) {
 	happyPath := &testCase{
 		setup: func(mock sqlmock.Sqlmock) {
 			mock.ExpectQuery(
-				"SELECT type, \"key\", value, version FROM network_table "+
+				"SELECT type, \"key\", value, version, expires_at, owner FROM network_table "+
 					"WHERE \\("+
 					"\\(network_id = \\$1 AND type = \\$2 AND \"key\" = \\$3\\) OR "+
 					"\\(network_id = \\$4 AND type = \\$5 AND \"key\" = \\$6\\)\\)").
 				WithArgs("network", "t1", "k1", "network", "t2", "k2").
 				WillReturnRows(
-					sqlmock.NewRows([]string{"type", "key", "value", "version"}).
-						AddRow("t1", "k1", []byte("value1"), 42).
-						AddRow("t2", "k2", []byte("value2"), 43),
+					sqlmock.NewRows([]string{"type", "key", "value", "version", "expires_at", "owner"}).
+						AddRow("t1", "k1", []byte("value1"), 42, 0, "").
+						AddRow("t2", "k2", []byte("value2"), 43, 0, ""),
 				)
 		},
 
@@ -147,7 +148,7 @@ func TestSqlBlobStorage_GetMany(t *testing.T) {
 
 	queryError := &testCase{
 		setup: func(mock sqlmock.Sqlmock) {
-			mock.ExpectQuery("SELECT type, \"key\", value, version FROM network_table").
+			mock.ExpectQuery("SELECT type, \"key\", value, version, expires_at, owner FROM network_table").
 				WithArgs("network", "t1", "k1", "network", "t2", "k2").
 				WillReturnError(errors.New("Mock query error"))
 		},
@@ -164,6 +165,78 @@ func TestSqlBlobStorage_GetMany(t *testing.T) {
 	runCase(t, queryError)
 }
 
+func TestSqlBlobStorage_GetForUpdate(t *testing.T) {
+	happyPath := &testCase{
+		setup: func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery(
+				"SELECT type, \"key\", value, version, expires_at, owner FROM network_table "+
+					"WHERE \\(\\(network_id = \\$1 AND type = \\$2 AND \"key\" = \\$3\\)\\) FOR UPDATE",
+			).
+				WithArgs("network", "t1", "k1").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"type", "key", "value", "version", "expires_at", "owner"}).
+						AddRow("t1", "k1", []byte("value1"), 42, 0, ""),
+				)
+		},
+
+		run: func(store blobstore.TransactionalBlobStorage) (interface{}, error) {
+			return store.GetForUpdate("network", storage.TypeAndKey{Type: "t1", Key: "k1"})
+		},
+
+		expectedError:  nil,
+		expectedResult: blobstore.Blob{Type: "t1", Key: "k1", Value: []byte("value1"), Version: 42},
+	}
+
+	runCase(t, happyPath)
+}
+
+func TestSqlBlobStorage_Search(t *testing.T) {
+	happyPath := &testCase{
+		setup: func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery(
+				"SELECT type, \"key\", value, version, expires_at, owner FROM network_table "+
+					"WHERE \\(network_id = \\$1 AND type = \\$2 AND "+
+					"\\(type > \\$3 OR \\(type = \\$4 AND \"key\" > \\$5\\)\\)\\) "+
+					"ORDER BY type, \"key\" LIMIT 2",
+			).
+				WithArgs("network", "t1", "t1", "t1", "k1").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"type", "key", "value", "version", "expires_at", "owner"}).
+						AddRow("t1", "k2", []byte("value2"), 42, 0, ""),
+				)
+		},
+
+		run: func(store blobstore.TransactionalBlobStorage) (interface{}, error) {
+			typeFilter := "t1"
+			return store.Search("network", blobstore.SearchFilter{Type: &typeFilter}, &storage.TypeAndKey{Type: "t1", Key: "k1"}, 2)
+		},
+
+		expectedError:  nil,
+		expectedResult: []blobstore.Blob{{Type: "t1", Key: "k2", Value: []byte("value2"), Version: 42}},
+	}
+
+	runCase(t, happyPath)
+}
+
+func TestSqlBlobStorage_CreateOrUpdateIfVersion(t *testing.T) {
+	createConflict := &testCase{
+		setup: func(mock sqlmock.Sqlmock) {
+			mock.ExpectExec("INSERT INTO network_table .* ON CONFLICT \\(network_id, type, \"key\"\\) DO NOTHING").
+				WithArgs("network", "t1", "k1", []byte("hello"), 0, "").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+		},
+
+		run: func(store blobstore.TransactionalBlobStorage) (interface{}, error) {
+			err := store.CreateOrUpdateIfVersion("network", blobstore.Blob{Type: "t1", Key: "k1", Value: []byte("hello")}, nil)
+			return nil, err
+		},
+
+		expectedError: &blobstore.VersionConflictError{NetworkID: "network", ID: storage.TypeAndKey{Type: "t1", Key: "k1"}},
+	}
+
+	runCase(t, createConflict)
+}
+
 func TestSqlBlobStorage_CreateOrUpdate(t *testing.T) {
 	// (t1, k1) exists, (t2, k2) does not
 	happyPath := &testCase{
@@ -178,12 +251,12 @@ func TestSqlBlobStorage_CreateOrUpdate(t *testing.T) {
 
 			updatePrepare := mock.ExpectPrepare("UPDATE network_table")
 			updatePrepare.ExpectExec().
-				WithArgs([]byte("goodbye"), 43, "network", "t1", "k1").
+				WithArgs([]byte("goodbye"), 43, 0, "", "network", "t1", "k1").
 				WillReturnResult(sqlmock.NewResult(1, 1))
 			updatePrepare.WillBeClosed()
 
 			mock.ExpectExec("INSERT INTO network_table").
-				WithArgs("network", "t2", "k2", []byte("world")).
+				WithArgs("network", "t2", "k2", []byte("world"), 0, "").
 				WillReturnResult(sqlmock.NewResult(1, 1))
 		},
 
@@ -215,10 +288,10 @@ func TestSqlBlobStorage_CreateOrUpdate(t *testing.T) {
 
 			updatePrepare := mock.ExpectPrepare("UPDATE network_table")
 			updatePrepare.ExpectExec().
-				WithArgs([]byte("goodbye"), 43, "network", "t1", "k1").
+				WithArgs([]byte("goodbye"), 43, 0, "", "network", "t1", "k1").
 				WillReturnResult(sqlmock.NewResult(1, 1))
 			updatePrepare.ExpectExec().
-				WithArgs([]byte("foo"), 44, "network", "t2", "k2").
+				WithArgs([]byte("foo"), 44, 0, "", "network", "t2", "k2").
 				WillReturnResult(sqlmock.NewResult(1, 1))
 			updatePrepare.WillBeClosed()
 		},
@@ -248,8 +321,8 @@ func TestSqlBlobStorage_CreateOrUpdate(t *testing.T) {
 
 			mock.ExpectExec("INSERT INTO network_table").
 				WithArgs(
-					"network", "t1", "k1", []byte("hello"),
-					"network", "t2", "k2", []byte("world"),
+					"network", "t1", "k1", []byte("hello"), 0, "",
+					"network", "t2", "k2", []byte("world"), 0, "",
 				).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 		},
@@ -281,7 +354,7 @@ func TestSqlBlobStorage_CreateOrUpdate(t *testing.T) {
 
 			updatePrepare := mock.ExpectPrepare("UPDATE network_table")
 			updatePrepare.ExpectExec().
-				WithArgs([]byte("goodbye"), 43, "network", "t1", "k1").
+				WithArgs([]byte("goodbye"), 43, 0, "", "network", "t1", "k1").
 				WillReturnError(errors.New("Mock query error"))
 			updatePrepare.WillBeClosed()
 		},
@@ -313,12 +386,12 @@ func TestSqlBlobStorage_CreateOrUpdate(t *testing.T) {
 
 			updatePrepare := mock.ExpectPrepare("UPDATE network_table")
 			updatePrepare.ExpectExec().
-				WithArgs([]byte("goodbye"), 43, "network", "t1", "k1").
+				WithArgs([]byte("goodbye"), 43, 0, "", "network", "t1", "k1").
 				WillReturnResult(sqlmock.NewResult(1, 1))
 			updatePrepare.WillBeClosed()
 
 			mock.ExpectExec("INSERT INTO network_table").
-				WithArgs("network", "t2", "k2", []byte("world")).
+				WithArgs("network", "t2", "k2", []byte("world"), 0, "").
 				WillReturnError(errors.New("Mock query error"))
 		},
 
@@ -381,6 +454,43 @@ func TestSqlBlobStorage_Delete(t *testing.T) {
 	runCase(t, queryError)
 }
 
+func TestSqlBlobStorage_DeleteExpired(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	happyPath := &testCase{
+		setup: func(mock sqlmock.Sqlmock) {
+			mock.ExpectExec("DELETE FROM network_table WHERE \\(expires_at > \\$1 AND expires_at <= \\$2\\)").
+				WithArgs(0, 1000000).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+		},
+
+		run: func(store blobstore.TransactionalBlobStorage) (interface{}, error) {
+			return store.DeleteExpired(now)
+		},
+
+		expectedError:  nil,
+		expectedResult: int64(2),
+	}
+
+	queryError := &testCase{
+		setup: func(mock sqlmock.Sqlmock) {
+			mock.ExpectExec("DELETE FROM network_table").
+				WithArgs(0, 1000000).
+				WillReturnError(errors.New("Mock query error"))
+		},
+
+		run: func(store blobstore.TransactionalBlobStorage) (interface{}, error) {
+			return store.DeleteExpired(now)
+		},
+
+		expectedError:  errors.New("error deleting expired blobs: Mock query error"),
+		expectedResult: nil,
+	}
+
+	runCase(t, happyPath)
+	runCase(t, queryError)
+}
+
 func TestSqlBlobStorage_Integration(t *testing.T) {
 	// Use an in-memory sqlite datastore
 	db, err := sqorc.Open("sqlite3", ":memory:")
@@ -443,12 +553,12 @@ func expectCreateTable(mock sqlmock.Sqlmock) {
 }
 
 func expectGetMany(mock sqlmock.Sqlmock, args []driver.Value, blobs []blobstore.Blob) {
-	rows := sqlmock.NewRows([]string{"type", "key", "value", "version"})
+	rows := sqlmock.NewRows([]string{"type", "key", "value", "version", "expires_at", "owner"})
 	for _, blob := range blobs {
-		rows.AddRow(blob.Type, blob.Key, blob.Value, blob.Version)
+		rows.AddRow(blob.Type, blob.Key, blob.Value, blob.Version, blob.ExpirationTime, blob.Owner)
 	}
 
-	mock.ExpectQuery("SELECT type, \"key\", value, version FROM network_table").
+	mock.ExpectQuery("SELECT type, \"key\", value, version, expires_at, owner FROM network_table").
 		WithArgs(args...).
 		WillReturnRows(rows)
 }
@@ -9,6 +9,9 @@
 package blobstore
 
 import (
+	"fmt"
+	"time"
+
 	"magma/orc8r/cloud/go/storage"
 )
 
@@ -18,6 +21,22 @@ type Blob struct {
 	Key     string
 	Value   []byte
 	Version uint64
+	// ExpirationTime is the unix time in milliseconds at which the blob
+	// expires, or 0 if it never expires. Expired blobs are still returned
+	// by reads until they're removed by DeleteExpired.
+	ExpirationTime uint64
+	// Owner optionally identifies the writer of the blob, e.g. the hardware
+	// ID of the gateway which reported a state, so that Search can filter
+	// on it.
+	Owner string
+}
+
+// SearchFilter restricts the blobs loaded by Search. Nil fields match all
+// blobs.
+type SearchFilter struct {
+	Type      *string
+	KeyPrefix *string
+	Owner     *string
 }
 
 // BlobStorageFactory is an API to create a storage API bound to a transaction.
@@ -54,14 +73,45 @@ type TransactionalBlobStorage interface {
 	// will not have a corresponding Blob.
 	GetMany(networkID string, ids []storage.TypeAndKey) ([]Blob, error)
 
+	// GetForUpdate loads a specific blob from storage and locks it until
+	// the transaction ends, to be conditionally written back with
+	// CreateOrUpdateIfVersion. Pass the returned blob's Version as the
+	// expected version.
+	// If there is no blob matching the given ID, ErrNotFound from
+	// magma/orc8r/cloud/go/errors will be returned.
+	GetForUpdate(networkID string, id storage.TypeAndKey) (Blob, error)
+
+	// Search loads blobs in a network matching the filter, ordered by type,
+	// then key.
+	// If startAfter is non-nil, only blobs ordered after that ID are
+	// returned. If limit is non-zero, at most limit blobs are returned.
+	Search(networkID string, filter SearchFilter, startAfter *storage.TypeAndKey, limit uint64) ([]Blob, error)
+
 	// CreateOrUpdate writes blobs to the storage. Blobs are either updated
 	// in-place or created. The Version field of Blobs passed in here is
 	// ignored - all version incrementation is done internally inside the
 	// storage implementation.
 	CreateOrUpdate(networkID string, blobs []Blob) error
 
+	// CreateOrUpdateIfVersion writes a blob to storage only if the stored
+	// version of the blob is expectedVersion. A nil expectedVersion means
+	// that the blob must not exist yet. The Version field of the blob
+	// passed in is ignored.
+	// A *VersionConflictError is returned if the stored version doesn't
+	// match, or if the blob is created concurrently.
+	CreateOrUpdateIfVersion(networkID string, blob Blob, expectedVersion *uint64) error
+
 	// Delete deletes specified blobs from storage.
 	Delete(networkID string, ids []storage.TypeAndKey) error
+
+	// DeleteExpired deletes every blob which has expired at the given time,
+	// across all networks, and returns the number of blobs deleted.
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+// IsExpired returns true if the blob has expired at the given time
+func (blob Blob) IsExpired(now time.Time) bool {
+	return blob.ExpirationTime != 0 && blob.ExpirationTime <= uint64(now.UnixNano()/int64(time.Millisecond))
 }
 
 // GetBlobsByTypeAndKey returns a computed view of a list of blobs as a map of
@@ -73,3 +123,25 @@ func GetBlobsByTypeAndKey(blobs []Blob) map[storage.TypeAndKey]Blob {
 	}
 	return ret
 }
+
+// VersionConflictError is returned from a conditional write when the stored
+// version of a blob doesn't match the expected version.
+type VersionConflictError struct {
+	NetworkID string
+	ID        storage.TypeAndKey
+	// ExpectedVersion is nil if the blob was expected not to exist
+	ExpectedVersion *uint64
+}
+
+func (err *VersionConflictError) Error() string {
+	if err.ExpectedVersion == nil {
+		return fmt.Sprintf("version conflict on blob %s in network %s: blob already exists", err.ID, err.NetworkID)
+	}
+	return fmt.Sprintf("version conflict on blob %s in network %s: expected version %d", err.ID, err.NetworkID, *err.ExpectedVersion)
+}
+
+// IsVersionConflict returns true if the error is a *VersionConflictError
+func IsVersionConflict(err error) bool {
+	_, ok := err.(*VersionConflictError)
+	return ok
+}
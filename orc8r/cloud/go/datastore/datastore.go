@@ -12,6 +12,7 @@ package datastore
 import (
 	"errors"
 	"strings"
+	"time"
 )
 
 type ValueWrapper struct {
@@ -31,12 +32,22 @@ func IsErrNotFound(err error) bool {
 
 type Api interface {
 	Put(table string, key string, value []byte) error
+	// PutWithTTL puts a value which expires after the given duration. Expired
+	// values are treated as missing by all reads.
+	PutWithTTL(table string, key string, value []byte, ttl time.Duration) error
 	PutMany(table string, valuesToPut map[string][]byte) (map[string]error, error)
 	Get(table string, key string) ([]byte, uint64, error)
 	GetMany(table string, keys []string) (map[string]ValueWrapper, error)
 	Delete(table string, key string) error
 	DeleteMany(table string, keys []string) (map[string]error, error)
 	ListKeys(table string) ([]string, error)
+	// ListKeysPaginated returns up to limit keys which sort after startAfter,
+	// in ascending order. An empty startAfter lists from the first key, and a
+	// limit of 0 returns all remaining keys.
+	ListKeysPaginated(table string, startAfter string, limit uint64) ([]string, error)
+	// GetRange returns the values of up to limit keys with the given prefix,
+	// taken in ascending key order. A limit of 0 returns all matching values.
+	GetRange(table string, prefix string, limit uint64) (map[string]ValueWrapper, error)
 	DeleteTable(table string) error
 	DoesKeyExist(table string, key string) (bool, error)
 }
@@ -0,0 +1,97 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package datastore
+
+import (
+	"database/sql"
+	"fmt"
+
+	"magma/orc8r/cloud/go/sqorc"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+// MigrationsServiceName identifies the migrations of the tables created by
+// SqlDb, which are shared by every service using the datastore
+const MigrationsServiceName = "datastore"
+
+func init() {
+	sqorc.MustRegisterMigrations(GetSQLMigrations()...)
+}
+
+// GetSQLMigrations returns the schema migrations of the datastore tables.
+// Datastore tables are created on demand, so migrations apply to every
+// table with the datastore's columns.
+func GetSQLMigrations() []sqorc.Migration {
+	return []sqorc.Migration{
+		{
+			Service:     MigrationsServiceName,
+			Version:     1,
+			Description: "Add expiration time to datastore tables",
+			Up:          addExpirationColumn,
+		},
+	}
+}
+
+func addExpirationColumn(tx *sql.Tx, builder sqorc.StatementBuilder) error {
+	tables, err := listDatastoreTables(tx, builder)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		// Tables created after expiration was supported already have it
+		_, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s BIGINT", table, expCol))
+		if err != nil {
+			return errors.Wrapf(err, "failed to add expiration column to table %s", table)
+		}
+	}
+	return nil
+}
+
+// listDatastoreTables returns the tables which have the columns of a table
+// created by SqlDb
+func listDatastoreTables(tx *sql.Tx, builder sqorc.StatementBuilder) ([]string, error) {
+	rows, err := builder.Select("table_name").
+		From("information_schema.columns").
+		Where(sq.And{
+			sq.Eq{"column_name": []string{genCol, deletedCol}},
+			sq.NotEq{"table_schema": []string{"information_schema", "pg_catalog", "mysql", "performance_schema"}},
+		}).
+		GroupBy("table_name").
+		Having("COUNT(DISTINCT column_name) = 2").
+		OrderBy("table_name").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list datastore tables")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "listDatastoreTables")
+
+	tables := []string{}
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, errors.Wrap(err, "failed to read datastore table")
+		}
+		tables = append(tables, table)
+	}
+	return tables, rows.Err()
+}
+
+// ApplyMigrations applies the pending datastore migrations. Services using
+// a SqlDb should call this on startup. Datastore tables are created on
+// demand with the latest schema, so nothing needs to be done for sqlite3.
+func (store *SqlDb) ApplyMigrations() error {
+	err := sqorc.ApplyServiceMigrations(store.db, store.builder, MigrationsServiceName)
+	if err == sqorc.ErrMigrationsUnsupported {
+		return nil
+	}
+	return err
+}
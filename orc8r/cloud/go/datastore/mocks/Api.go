@@ -13,6 +13,8 @@ LICENSE file in the root directory of this source tree.
 package mocks
 
 import (
+	"time"
+
 	"magma/orc8r/cloud/go/datastore"
 
 	"github.com/stretchr/testify/mock"
@@ -148,6 +150,29 @@ func (_m *Api) GetMany(table string, keys []string) (map[string]datastore.ValueW
 	return r0, r1
 }
 
+// GetRange provides a mock function with given fields: table, prefix, limit
+func (_m *Api) GetRange(table string, prefix string, limit uint64) (map[string]datastore.ValueWrapper, error) {
+	ret := _m.Called(table, prefix, limit)
+
+	var r0 map[string]datastore.ValueWrapper
+	if rf, ok := ret.Get(0).(func(string, string, uint64) map[string]datastore.ValueWrapper); ok {
+		r0 = rf(table, prefix, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]datastore.ValueWrapper)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, uint64) error); ok {
+		r1 = rf(table, prefix, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListKeys provides a mock function with given fields: table
 func (_m *Api) ListKeys(table string) ([]string, error) {
 	ret := _m.Called(table)
@@ -171,6 +196,29 @@ func (_m *Api) ListKeys(table string) ([]string, error) {
 	return r0, r1
 }
 
+// ListKeysPaginated provides a mock function with given fields: table, startAfter, limit
+func (_m *Api) ListKeysPaginated(table string, startAfter string, limit uint64) ([]string, error) {
+	ret := _m.Called(table, startAfter, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string, uint64) []string); ok {
+		r0 = rf(table, startAfter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, uint64) error); ok {
+		r1 = rf(table, startAfter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Put provides a mock function with given fields: table, key, value
 func (_m *Api) Put(table string, key string, value []byte) error {
 	ret := _m.Called(table, key, value)
@@ -207,3 +255,17 @@ func (_m *Api) PutMany(table string, valuesToPut map[string][]byte) (map[string]
 
 	return r0, r1
 }
+
+// PutWithTTL provides a mock function with given fields: table, key, value, ttl
+func (_m *Api) PutWithTTL(table string, key string, value []byte, ttl time.Duration) error {
+	ret := _m.Called(table, key, value, ttl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []byte, time.Duration) error); ok {
+		r0 = rf(table, key, value, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
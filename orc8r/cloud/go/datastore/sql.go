@@ -11,6 +11,8 @@ package datastore
 import (
 	"database/sql"
 	"fmt"
+	"sync"
+	"time"
 
 	"magma/orc8r/cloud/go/sqorc"
 
@@ -28,11 +30,23 @@ const (
 	valueCol   = "value"
 	genCol     = "generation_number"
 	deletedCol = "deleted"
+	// expCol is the unix time in ms at which the row expires, or NULL if the
+	// row never expires
+	expCol = "expires_at"
 )
 
+// ExpiredRowPurgeInterval is the minimum time between purges of the expired
+// rows of a table. Expired rows are purged by PutWithTTL, since only tables
+// written with a TTL have rows which expire.
+var ExpiredRowPurgeInterval = time.Minute
+
 type SqlDb struct {
 	db      *sql.DB
 	builder sqorc.StatementBuilder
+
+	// lastPurges tracks when the expired rows of each table were last purged
+	lastPurges     map[string]time.Time
+	lastPurgesLock sync.Mutex
 }
 
 func NewSqlDb(driver string, source string, sqlBuilder sqorc.StatementBuilder) (*SqlDb, error) {
@@ -42,8 +56,9 @@ func NewSqlDb(driver string, source string, sqlBuilder sqorc.StatementBuilder) (
 	}
 
 	return &SqlDb{
-		db:      db,
-		builder: sqlBuilder,
+		db:         db,
+		builder:    sqlBuilder,
+		lastPurges: map[string]time.Time{},
 	}, nil
 }
 
@@ -56,6 +71,7 @@ func (store *SqlDb) getInitFn(table string) func(*sql.Tx) error {
 			Column(valueCol).Type(sqorc.ColumnTypeBytes).EndColumn().
 			Column(genCol).Type(sqorc.ColumnTypeInt).NotNull().Default(0).EndColumn().
 			Column(deletedCol).Type(sqorc.ColumnTypeBool).NotNull().Default("FALSE").EndColumn().
+			Column(expCol).Type(sqorc.ColumnTypeBigInt).EndColumn().
 			RunWith(tx).
 			Exec()
 		if err != nil {
@@ -65,8 +81,34 @@ func (store *SqlDb) getInitFn(table string) func(*sql.Tx) error {
 	}
 }
 
+// execInTx initializes the table, then executes txFn inside a sql
+// transaction.
+func (store *SqlDb) execInTx(table string, txFn func(*sql.Tx) (interface{}, error)) (interface{}, error) {
+	return sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+}
+
 func (store *SqlDb) Put(table string, key string, value []byte) error {
+	return store.put(table, key, value, nil, false)
+}
+
+func (store *SqlDb) PutWithTTL(table string, key string, value []byte, ttl time.Duration) error {
+	now := time.Now()
+	expiration := getExpirationTime(now, ttl)
+	return store.put(table, key, value, &expiration, store.isPurgeDue(table, now))
+}
+
+func (store *SqlDb) put(table string, key string, value []byte, expiration *int64, purgeExpired bool) error {
 	txFn := func(tx *sql.Tx) (interface{}, error) {
+		if purgeExpired {
+			_, err := store.builder.Delete(table).
+				Where(sq.And{sq.NotEq{expCol: nil}, sq.LtOrEq{expCol: getExpirationTime(time.Now(), 0)}}).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to purge expired rows")
+			}
+		}
+
 		// Check if the data is already present and query for its generation number
 		var generationNumber uint64
 		err := store.builder.Select(genCol).
@@ -84,18 +126,19 @@ func (store *SqlDb) Put(table string, key string, value []byte) error {
 			return store.builder.Update(table).
 				Set(valueCol, value).
 				Set(genCol, generationNumber+1).
+				Set(expCol, expiration).
 				Where(sq.Eq{keyCol: key}).
 				RunWith(tx).
 				Exec()
 		} else {
 			return store.builder.Insert(table).
-				Columns(keyCol, valueCol).
-				Values(key, value).
+				Columns(keyCol, valueCol, expCol).
+				Values(key, value, expiration).
 				RunWith(tx).
 				Exec()
 		}
 	}
-	_, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	_, err := store.execInTx(table, txFn)
 	return err
 }
 
@@ -107,7 +150,8 @@ func (store *SqlDb) PutMany(table string, valuesToPut map[string][]byte) (map[st
 			rowKeys = append(rowKeys, k)
 		}
 
-		existingRows, err := store.getMany(tx, table, rowKeys)
+		// Expired rows still occupy their keys, so they're updated in place
+		existingRows, err := store.getMany(tx, table, rowKeys, false)
 		if err != nil {
 			return ret, errors.Wrap(err, "failed to query for existing rows")
 		}
@@ -131,6 +175,7 @@ func (store *SqlDb) PutMany(table string, valuesToPut map[string][]byte) (map[st
 			_, err := store.builder.Update(table).
 				Set(valueCol, row[0]).
 				Set(genCol, row[1]).
+				Set(expCol, nil).
 				Where(sq.Eq{keyCol: row[2]}).
 				RunWith(sc).
 				Exec()
@@ -159,7 +204,10 @@ func (store *SqlDb) PutMany(table string, valuesToPut map[string][]byte) (map[st
 		}
 	}
 
-	ret, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	ret, err := store.execInTx(table, txFn)
+	if ret == nil {
+		return map[string]error{}, err
+	}
 	return ret.(map[string]error), err
 }
 
@@ -169,7 +217,7 @@ func (store *SqlDb) Get(table string, key string) ([]byte, uint64, error) {
 		var generationNumber uint64
 		err := store.builder.Select(valueCol, genCol).
 			From(table).
-			Where(sq.Eq{keyCol: key}).
+			Where(sq.And{sq.Eq{keyCol: key}, notExpired(time.Now())}).
 			RunWith(tx).
 			QueryRow().Scan(&value, &generationNumber)
 		if err == sql.ErrNoRows {
@@ -178,7 +226,7 @@ func (store *SqlDb) Get(table string, key string) ([]byte, uint64, error) {
 		return ValueWrapper{Value: value, Generation: generationNumber}, err
 	}
 
-	ret, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	ret, err := store.execInTx(table, txFn)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -188,9 +236,12 @@ func (store *SqlDb) Get(table string, key string) ([]byte, uint64, error) {
 
 func (store *SqlDb) GetMany(table string, keys []string) (map[string]ValueWrapper, error) {
 	txFn := func(tx *sql.Tx) (interface{}, error) {
-		return store.getMany(tx, table, keys)
+		return store.getMany(tx, table, keys, true)
+	}
+	ret, err := store.execInTx(table, txFn)
+	if ret == nil {
+		return map[string]ValueWrapper{}, err
 	}
-	ret, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
 	return ret.(map[string]ValueWrapper), err
 }
 
@@ -198,7 +249,7 @@ func (store *SqlDb) Delete(table string, key string) error {
 	txFn := func(tx *sql.Tx) (interface{}, error) {
 		return store.builder.Delete(table).Where(sq.Eq{keyCol: key}).RunWith(tx).Exec()
 	}
-	_, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	_, err := store.execInTx(table, txFn)
 	return err
 }
 
@@ -206,33 +257,66 @@ func (store *SqlDb) DeleteMany(table string, keys []string) (map[string]error, e
 	txFn := func(tx *sql.Tx) (interface{}, error) {
 		return store.builder.Delete(table).Where(sq.Eq{keyCol: keys}).RunWith(tx).Exec()
 	}
-	_, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	_, err := store.execInTx(table, txFn)
 	return map[string]error{}, err
 }
 
 func (store *SqlDb) ListKeys(table string) ([]string, error) {
 	txFn := func(tx *sql.Tx) (interface{}, error) {
-		rows, err := store.builder.Select(keyCol).From(table).RunWith(tx).Query()
-		if err != nil {
-			return []string{}, errors.Wrap(err, "failed to query for keys")
-		}
-		defer sqorc.CloseRowsLogOnError(rows, "ListKeys")
+		return store.listKeys(tx, store.builder.Select(keyCol).From(table).Where(notExpired(time.Now())))
+	}
 
-		keys := []string{}
-		for rows.Next() {
-			var key string
-			if err = rows.Scan(&key); err != nil {
-				return []string{}, errors.Wrap(err, "failed to read key")
-			}
-			keys = append(keys, key)
+	ret, err := store.execInTx(table, txFn)
+	if ret == nil {
+		return []string{}, err
+	}
+	return ret.([]string), err
+}
+
+func (store *SqlDb) ListKeysPaginated(table string, startAfter string, limit uint64) ([]string, error) {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		query := store.builder.Select(keyCol).From(table).Where(notExpired(time.Now()))
+		if startAfter != "" {
+			query = query.Where(sq.Gt{keyCol: startAfter})
+		}
+		query = query.OrderBy(keyCol)
+		if limit > 0 {
+			query = query.Limit(limit)
 		}
-		return keys, nil
+		return store.listKeys(tx, query)
 	}
 
-	ret, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	ret, err := store.execInTx(table, txFn)
+	if ret == nil {
+		return []string{}, err
+	}
 	return ret.([]string), err
 }
 
+func (store *SqlDb) GetRange(table string, prefix string, limit uint64) (map[string]ValueWrapper, error) {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		query := store.builder.Select(keyCol, valueCol, genCol).
+			From(table).
+			Where(sq.And{sqorc.HasPrefix(keyCol, prefix), notExpired(time.Now())}).
+			OrderBy(keyCol)
+		if limit > 0 {
+			query = query.Limit(limit)
+		}
+		rows, err := query.RunWith(tx).Query()
+		if err != nil {
+			return map[string]ValueWrapper{}, errors.Wrap(err, "failed to query for range")
+		}
+		defer sqorc.CloseRowsLogOnError(rows, "GetRange")
+		return getSqlRowsAsMap(rows)
+	}
+
+	ret, err := store.execInTx(table, txFn)
+	if ret == nil {
+		return map[string]ValueWrapper{}, err
+	}
+	return ret.(map[string]ValueWrapper), err
+}
+
 func (store *SqlDb) DeleteTable(table string) error {
 	txFn := func(tx *sql.Tx) (interface{}, error) {
 		return tx.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s", table))
@@ -246,7 +330,7 @@ func (store *SqlDb) DoesKeyExist(table string, key string) (bool, error) {
 	txFn := func(tx *sql.Tx) (interface{}, error) {
 		var placeHolder uint64
 		err := store.builder.Select("1").From(table).
-			Where(sq.Eq{keyCol: key}).
+			Where(sq.And{sq.Eq{keyCol: key}, notExpired(time.Now())}).
 			Limit(1).
 			RunWith(tx).
 			QueryRow().Scan(&placeHolder)
@@ -258,19 +342,26 @@ func (store *SqlDb) DoesKeyExist(table string, key string) (bool, error) {
 		}
 		return true, nil
 	}
-	ret, err := sqorc.ExecInTx(store.db, store.getInitFn(table), txFn)
+	ret, err := store.execInTx(table, txFn)
+	if ret == nil {
+		return false, err
+	}
 	return ret.(bool), err
 }
 
-func (store *SqlDb) getMany(tx *sql.Tx, table string, keys []string) (map[string]ValueWrapper, error) {
+func (store *SqlDb) getMany(tx *sql.Tx, table string, keys []string, filterExpired bool) (map[string]ValueWrapper, error) {
 	valuesByKey := make(map[string]ValueWrapper)
 	if len(keys) == 0 {
 		return valuesByKey, nil
 	}
 
+	whereCondition := sq.And{sq.Eq{keyCol: keys}}
+	if filterExpired {
+		whereCondition = append(whereCondition, notExpired(time.Now()))
+	}
 	rows, err := store.builder.Select(keyCol, valueCol, genCol).
 		From(table).
-		Where(sq.Eq{keyCol: keys}).
+		Where(whereCondition).
 		RunWith(tx).
 		Query()
 	if err != nil {
@@ -280,6 +371,49 @@ func (store *SqlDb) getMany(tx *sql.Tx, table string, keys []string) (map[string
 	return getSqlRowsAsMap(rows)
 }
 
+func (store *SqlDb) listKeys(tx *sql.Tx, query sq.SelectBuilder) ([]string, error) {
+	rows, err := query.RunWith(tx).Query()
+	if err != nil {
+		return []string{}, errors.Wrap(err, "failed to query for keys")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "listKeys")
+
+	keys := []string{}
+	for rows.Next() {
+		var key string
+		if err = rows.Scan(&key); err != nil {
+			return []string{}, errors.Wrap(err, "failed to read key")
+		}
+		keys = append(keys, key)
+	}
+	if err = rows.Err(); err != nil {
+		return []string{}, errors.Wrap(err, "failed to read keys")
+	}
+	return keys, nil
+}
+
+// isPurgeDue returns true if the expired rows of the table are due to be
+// purged, and if so, records the purge
+func (store *SqlDb) isPurgeDue(table string, now time.Time) bool {
+	store.lastPurgesLock.Lock()
+	defer store.lastPurgesLock.Unlock()
+	if now.Sub(store.lastPurges[table]) < ExpiredRowPurgeInterval {
+		return false
+	}
+	store.lastPurges[table] = now
+	return true
+}
+
+// notExpired matches rows which haven't expired at the given time
+func notExpired(now time.Time) sq.Sqlizer {
+	return sq.Or{sq.Eq{expCol: nil}, sq.Gt{expCol: getExpirationTime(now, 0)}}
+}
+
+// getExpirationTime returns the unix time in ms after the given duration
+func getExpirationTime(now time.Time, ttl time.Duration) int64 {
+	return now.Add(ttl).UnixNano() / int64(time.Millisecond)
+}
+
 func getSqlRowsAsMap(rows *sql.Rows) (map[string]ValueWrapper, error) {
 	var valuesByKey = make(map[string]ValueWrapper)
 
@@ -298,6 +432,9 @@ func getSqlRowsAsMap(rows *sql.Rows) (map[string]ValueWrapper, error) {
 			Generation: generationNumber,
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return map[string]ValueWrapper{}, err
+	}
 
 	return valuesByKey, nil
 }
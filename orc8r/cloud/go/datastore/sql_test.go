@@ -10,12 +10,14 @@ package datastore_test
 
 import (
 	"testing"
+	"time"
 
 	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/sqorc"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
 )
 
 func TestDatastoreBasics(t *testing.T) {
@@ -148,3 +150,128 @@ func TestDatastoreBulkOperations(t *testing.T) {
 	assert.Equal(t, expectedDbRows, dbRows)
 
 }
+
+func TestDatastorePagination(t *testing.T) {
+	ds, err := datastore.NewSqlDb("sqlite3", ":memory:", sqorc.GetSqlBuilder())
+	assert.NoError(t, err)
+
+	_, err = ds.PutMany("test", map[string][]byte{
+		"a1": []byte("v1"),
+		"a2": []byte("v2"),
+		"b1": []byte("v3"),
+		"a_": []byte("v4"),
+	})
+	assert.NoError(t, err)
+
+	keys, err := ds.ListKeysPaginated("test", "", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a1", "a2"}, keys)
+	keys, err = ds.ListKeysPaginated("test", "a2", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_", "b1"}, keys)
+	keys, err = ds.ListKeysPaginated("test", "b1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, keys)
+	keys, err = ds.ListKeysPaginated("test", "a1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a2", "a_", "b1"}, keys)
+
+	// Prefixes are matched literally
+	rows, err := ds.GetRange("test", "a", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]datastore.ValueWrapper{
+		"a1": {Value: []byte("v1")},
+		"a2": {Value: []byte("v2")},
+		"a_": {Value: []byte("v4")},
+	}, rows)
+	rows, err = ds.GetRange("test", "a_", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]datastore.ValueWrapper{"a_": {Value: []byte("v4")}}, rows)
+	rows, err = ds.GetRange("test", "a", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]datastore.ValueWrapper{"a1": {Value: []byte("v1")}}, rows)
+}
+
+func TestDatastoreTTL(t *testing.T) {
+	ds, err := datastore.NewSqlDb("sqlite3", ":memory:", sqorc.GetSqlBuilder())
+	assert.NoError(t, err)
+
+	assert.NoError(t, ds.PutWithTTL("test", "live", []byte("v1"), time.Hour))
+	assert.NoError(t, ds.PutWithTTL("test", "expired", []byte("v2"), -time.Second))
+	assert.NoError(t, ds.Put("test", "forever", []byte("v3")))
+
+	_, _, err = ds.Get("test", "expired")
+	assert.Equal(t, datastore.ErrNotFound, err)
+	val, _, err := ds.Get("test", "live")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v1"), val)
+	exists, err := ds.DoesKeyExist("test", "expired")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	keys, err := ds.ListKeys("test")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"live", "forever"}, keys)
+	keys, err = ds.ListKeysPaginated("test", "", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"forever", "live"}, keys)
+	rows, err := ds.GetMany("test", []string{"live", "expired"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]datastore.ValueWrapper{"live": {Value: []byte("v1")}}, rows)
+
+	// Overwriting an expired key clears its expiration
+	failedKeys, err := ds.PutMany("test", map[string][]byte{"expired": []byte("v4")})
+	assert.NoError(t, err)
+	assert.Empty(t, failedKeys)
+	val, gen, err := ds.Get("test", "expired")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v4"), val)
+	assert.Equal(t, uint64(1), gen)
+	assert.NoError(t, ds.PutWithTTL("test", "forever", []byte("v5"), -time.Second))
+	assert.NoError(t, ds.Put("test", "forever", []byte("v6")))
+	val, _, err = ds.Get("test", "forever")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v6"), val)
+}
+
+func TestDatastorePurgesExpiredRows(t *testing.T) {
+	datastore.ExpiredRowPurgeInterval = 0
+	defer func() { datastore.ExpiredRowPurgeInterval = time.Minute }()
+	ds, err := datastore.NewSqlDb("sqlite3", ":memory:", sqorc.GetSqlBuilder())
+	assert.NoError(t, err)
+
+	assert.NoError(t, ds.PutWithTTL("test", "k1", []byte("v1"), -time.Second))
+	assert.NoError(t, ds.PutWithTTL("test", "k2", []byte("v2"), time.Hour))
+
+	// The expired row was deleted rather than overwritten in place
+	assert.NoError(t, ds.Put("test", "k1", []byte("v3")))
+	val, gen, err := ds.Get("test", "k1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v3"), val)
+	assert.Equal(t, uint64(0), gen)
+}
+
+func TestDatastoreMigrations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT table_name FROM information_schema.columns").
+		WithArgs("generation_number", "deleted", "information_schema", "pg_catalog", "mysql", "performance_schema").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name"}).AddRow("t1").AddRow("t2"))
+	mock.ExpectExec("ALTER TABLE t1 ADD COLUMN IF NOT EXISTS expires_at BIGINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE t2 ADD COLUMN IF NOT EXISTS expires_at BIGINT").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	migrations := datastore.GetSQLMigrations()
+	assert.Len(t, migrations, 1)
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	assert.NoError(t, migrations[0].Up(tx, sqorc.GetSqlBuilder()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Tables are created with the latest schema on sqlite3, so there is
+	// nothing to apply
+	store, err := datastore.NewSqlDb("sqlite3", ":memory:", sqorc.GetSqlBuilder())
+	assert.NoError(t, err)
+	assert.NoError(t, store.ApplyMigrations())
+}
@@ -8,7 +8,10 @@ LICENSE file in the root directory of this source tree.
 
 package datastore
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
 
 type SyncStore struct {
 	store Api
@@ -25,6 +28,12 @@ func (s *SyncStore) Put(table string, key string, value []byte) error {
 	return s.store.Put(table, key, value)
 }
 
+func (s *SyncStore) PutWithTTL(table string, key string, value []byte, ttl time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.store.PutWithTTL(table, key, value, ttl)
+}
+
 func (s *SyncStore) PutMany(table string, valuesToPut map[string][]byte) (map[string]error, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -61,6 +70,18 @@ func (s *SyncStore) ListKeys(table string) ([]string, error) {
 	return s.store.ListKeys(table)
 }
 
+func (s *SyncStore) ListKeysPaginated(table string, startAfter string, limit uint64) ([]string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.store.ListKeysPaginated(table, startAfter, limit)
+}
+
+func (s *SyncStore) GetRange(table string, prefix string, limit uint64) (map[string]ValueWrapper, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.store.GetRange(table, prefix, limit)
+}
+
 func (s *SyncStore) DeleteTable(table string) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -17,6 +17,11 @@ import (
 // in a get/read does not exist.
 var ErrNotFound = errors.New("Not found")
 
+// Client APIs should raise ErrVersionConflict to indicate that an update
+// conditioned on a resource version was rejected because the resource was
+// modified concurrently.
+var ErrVersionConflict = errors.New("Version conflict")
+
 func NewInitError(err error, service string) error {
 	return ClientInitError{Err: err, Service: service}
 }
@@ -53,6 +53,9 @@ func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
 				http.StatusUnauthorized,
 				"Missing Client Credentials")
 		}
+		// Handlers pass the request's context to cloud services, which
+		// enforce the operator's ACLs on its behalf
+		c.SetRequest(c.Request().WithContext(oper.NewContextWithIdentity(c.Request().Context())))
 
 		// Bypass farther identity Checks for static docs GET and Channels GET,
 		// having an operator cert should be enough
@@ -15,6 +15,8 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"magma/orc8r/cloud/go/obsidian/config"
 	"magma/orc8r/cloud/go/util"
@@ -72,6 +74,11 @@ const (
 	NETWORK_WILDCARD = "N*"
 )
 
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
 var registries = map[HttpMethod]handlerRegistry{
 	GET:    {},
 	POST:   {},
@@ -244,3 +251,23 @@ func GetConfigType(c echo.Context) (string, *echo.HTTPError) {
 func NetworkIdHttpErr() *echo.HTTPError {
 	return HttpError(fmt.Errorf("Missing Network ID"), http.StatusBadRequest)
 }
+
+// SetETag sets the ETag header of the response to the given resource version.
+func SetETag(c echo.Context, version uint64) {
+	c.Response().Header().Set(HeaderETag, strconv.Quote(strconv.FormatUint(version, 10)))
+}
+
+// GetIfMatchVersion parses the If-Match header of the request into an
+// expected resource version. A nil version is returned if the header is
+// absent or is the "*" wildcard.
+func GetIfMatchVersion(c echo.Context) (*uint64, *echo.HTTPError) {
+	ifMatch := strings.TrimSpace(c.Request().Header.Get(HeaderIfMatch))
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, nil
+	}
+	version, err := strconv.ParseUint(strings.Trim(ifMatch, `"`), 10, 64)
+	if err != nil {
+		return nil, HttpError(fmt.Errorf("invalid If-Match header %s", ifMatch), http.StatusBadRequest)
+	}
+	return &version, nil
+}
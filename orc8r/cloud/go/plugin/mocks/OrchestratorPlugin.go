@@ -7,6 +7,7 @@ import (
 	goregistry "magma/orc8r/cloud/go/registry"
 	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/service/config"
+	"magma/orc8r/cloud/go/services/dispatcher/gateway_registry"
 	"magma/orc8r/cloud/go/services/metricsd"
 	"magma/orc8r/cloud/go/services/streamer/mconfig/factory"
 	"magma/orc8r/cloud/go/services/streamer/providers"
@@ -19,6 +20,22 @@ type OrchestratorPlugin struct {
 	mock.Mock
 }
 
+// GetGatewayServices provides a mock function with given fields:
+func (_m *OrchestratorPlugin) GetGatewayServices() []gateway_registry.GwServiceType {
+	ret := _m.Called()
+
+	var r0 []gateway_registry.GwServiceType
+	if rf, ok := ret.Get(0).(func() []gateway_registry.GwServiceType); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gateway_registry.GwServiceType)
+		}
+	}
+
+	return r0
+}
+
 // GetMconfigBuilders provides a mock function with given fields:
 func (_m *OrchestratorPlugin) GetMconfigBuilders() []factory.MconfigBuilder {
 	ret := _m.Called()
@@ -21,6 +21,7 @@ import (
 	"magma/orc8r/cloud/go/registry"
 	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/service/config"
+	"magma/orc8r/cloud/go/services/dispatcher/gateway_registry"
 	"magma/orc8r/cloud/go/services/metricsd"
 	"magma/orc8r/cloud/go/services/streamer/mconfig/factory"
 	"magma/orc8r/cloud/go/services/streamer/providers"
@@ -68,6 +69,11 @@ type OrchestratorPlugin interface {
 	// These stream providers are the primary mechanism by which gateways
 	// receive data from the orchestrator (e.g. configuration).
 	GetStreamerProviders() []providers.StreamProvider
+
+	// GetGatewayServices returns the gateway services which this module runs
+	// on gateways and which orchestrator services can call over SyncRPC.
+	// Requests to gateway services which no plugin returns are rejected.
+	GetGatewayServices() []gateway_registry.GwServiceType
 }
 
 // LoadAllPluginsFatalOnError loads and registers all orchestrator plugins
@@ -178,6 +184,9 @@ func registerPlugin(orc8rPlugin OrchestratorPlugin, metricsConfig *config.Config
 	if err := providers.RegisterStreamProviders(orc8rPlugin.GetStreamerProviders()...); err != nil {
 		return err
 	}
+	if err := gateway_registry.RegisterGwServices(orc8rPlugin.GetGatewayServices()...); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -17,6 +17,7 @@ import (
 	"magma/orc8r/cloud/go/plugin/mocks"
 	"magma/orc8r/cloud/go/registry"
 	"magma/orc8r/cloud/go/serde"
+	"magma/orc8r/cloud/go/services/dispatcher/gateway_registry"
 	"magma/orc8r/cloud/go/services/metricsd"
 	"magma/orc8r/cloud/go/services/streamer/mconfig/factory"
 	"magma/orc8r/cloud/go/services/streamer/providers"
@@ -47,6 +48,7 @@ func TestLoadAllPlugins(t *testing.T) {
 	mockPlugin.On("GetMetricsProfiles").Times(1).Return([]metricsd.MetricsProfile{})
 	mockPlugin.On("GetObsidianHandlers").Return([]handlers.Handler{})
 	mockPlugin.On("GetStreamerProviders").Return([]providers.StreamProvider{})
+	mockPlugin.On("GetGatewayServices").Return([]gateway_registry.GwServiceType{})
 	err := plugin.LoadAllPlugins(mockLoader{ret: mockPlugin})
 	assert.NoError(t, err)
 	mockPlugin.AssertNumberOfCalls(t, "GetServices", 1)
@@ -55,6 +57,7 @@ func TestLoadAllPlugins(t *testing.T) {
 	mockPlugin.AssertNumberOfCalls(t, "GetMetricsProfiles", 1)
 	mockPlugin.AssertNumberOfCalls(t, "GetObsidianHandlers", 1)
 	mockPlugin.AssertNumberOfCalls(t, "GetStreamerProviders", 1)
+	mockPlugin.AssertNumberOfCalls(t, "GetGatewayServices", 1)
 	mockPlugin.AssertExpectations(t)
 
 	// Error in the middle of registration - duplicate metrics profile
@@ -18,9 +18,11 @@ import (
 	"magma/orc8r/cloud/go/service/config"
 	"magma/orc8r/cloud/go/service/serviceregistry"
 	accessdh "magma/orc8r/cloud/go/services/accessd/obsidian/handlers"
+	bootstrapperh "magma/orc8r/cloud/go/services/bootstrapper/obsidian/handlers"
 	checkinh "magma/orc8r/cloud/go/services/checkind/obsidian/handlers"
 	checkindserde "magma/orc8r/cloud/go/services/checkind/serde"
 	configuratorh "magma/orc8r/cloud/go/services/configurator/obsidian/handlers"
+	"magma/orc8r/cloud/go/services/dispatcher/gateway_registry"
 	dnsdconfig "magma/orc8r/cloud/go/services/dnsd/config"
 	dnsdh "magma/orc8r/cloud/go/services/dnsd/obsidian/handlers"
 	magmadconfig "magma/orc8r/cloud/go/services/magmad/config"
@@ -90,6 +92,7 @@ func (*BaseOrchestratorPlugin) GetMetricsProfiles(metricsConfig *config.ConfigMa
 func (*BaseOrchestratorPlugin) GetObsidianHandlers(metricsConfig *config.ConfigMap) []obsidianh.Handler {
 	return plugin.FlattenHandlerLists(
 		accessdh.GetObsidianHandlers(),
+		bootstrapperh.GetObsidianHandlers(),
 		checkinh.GetObsidianHandlers(),
 		dnsdh.GetObsidianHandlers(),
 		magmadh.GetObsidianHandlers(),
@@ -108,6 +111,10 @@ func (*BaseOrchestratorPlugin) GetStreamerProviders() []providers.StreamProvider
 	}
 }
 
+func (*BaseOrchestratorPlugin) GetGatewayServices() []gateway_registry.GwServiceType {
+	return []gateway_registry.GwServiceType{gateway_registry.GwMagmad}
+}
+
 const (
 	ProfileNamePrometheus = "prometheus"
 	ProfileNameGraphite   = "graphite"
@@ -46,7 +46,7 @@ func (x ChallengeKey_KeyType) String() string {
 	return proto.EnumName(ChallengeKey_KeyType_name, int32(x))
 }
 func (ChallengeKey_KeyType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_bootstrapper_6370f262c5f2dbf0, []int{1, 0}
+	return fileDescriptor_bootstrapper_da351280fe34fea5, []int{1, 0}
 }
 
 type Challenge struct {
@@ -61,7 +61,7 @@ func (m *Challenge) Reset()         { *m = Challenge{} }
 func (m *Challenge) String() string { return proto.CompactTextString(m) }
 func (*Challenge) ProtoMessage()    {}
 func (*Challenge) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bootstrapper_6370f262c5f2dbf0, []int{0}
+	return fileDescriptor_bootstrapper_da351280fe34fea5, []int{0}
 }
 func (m *Challenge) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Challenge.Unmarshal(m, b)
@@ -111,7 +111,7 @@ func (m *ChallengeKey) Reset()         { *m = ChallengeKey{} }
 func (m *ChallengeKey) String() string { return proto.CompactTextString(m) }
 func (*ChallengeKey) ProtoMessage()    {}
 func (*ChallengeKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bootstrapper_6370f262c5f2dbf0, []int{1}
+	return fileDescriptor_bootstrapper_da351280fe34fea5, []int{1}
 }
 func (m *ChallengeKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChallengeKey.Unmarshal(m, b)
@@ -163,7 +163,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bootstrapper_6370f262c5f2dbf0, []int{2}
+	return fileDescriptor_bootstrapper_da351280fe34fea5, []int{2}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Response.Unmarshal(m, b)
@@ -358,7 +358,7 @@ func (m *Response_Echo) Reset()         { *m = Response_Echo{} }
 func (m *Response_Echo) String() string { return proto.CompactTextString(m) }
 func (*Response_Echo) ProtoMessage()    {}
 func (*Response_Echo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bootstrapper_6370f262c5f2dbf0, []int{2, 0}
+	return fileDescriptor_bootstrapper_da351280fe34fea5, []int{2, 0}
 }
 func (m *Response_Echo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Response_Echo.Unmarshal(m, b)
@@ -396,7 +396,7 @@ func (m *Response_RSA) Reset()         { *m = Response_RSA{} }
 func (m *Response_RSA) String() string { return proto.CompactTextString(m) }
 func (*Response_RSA) ProtoMessage()    {}
 func (*Response_RSA) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bootstrapper_6370f262c5f2dbf0, []int{2, 1}
+	return fileDescriptor_bootstrapper_da351280fe34fea5, []int{2, 1}
 }
 func (m *Response_RSA) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Response_RSA.Unmarshal(m, b)
@@ -435,7 +435,7 @@ func (m *Response_ECDSA) Reset()         { *m = Response_ECDSA{} }
 func (m *Response_ECDSA) String() string { return proto.CompactTextString(m) }
 func (*Response_ECDSA) ProtoMessage()    {}
 func (*Response_ECDSA) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bootstrapper_6370f262c5f2dbf0, []int{2, 2}
+	return fileDescriptor_bootstrapper_da351280fe34fea5, []int{2, 2}
 }
 func (m *Response_ECDSA) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Response_ECDSA.Unmarshal(m, b)
@@ -469,6 +469,208 @@ func (m *Response_ECDSA) GetS() []byte {
 	return nil
 }
 
+type CreateRegistrationTokenRequest struct {
+	NetworkId string `protobuf:"bytes,1,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	// Upgrade tier of the gateway registered with the token
+	TierId string `protobuf:"bytes,2,opt,name=tier_id,json=tierId,proto3" json:"tier_id,omitempty"`
+	// Time until the token expires
+	TtlSec int64 `protobuf:"varint,3,opt,name=ttl_sec,json=ttlSec,proto3" json:"ttl_sec,omitempty"`
+	// Name of the gateway registered with the token. Defaults to its hardware ID.
+	GatewayName          string   `protobuf:"bytes,4,opt,name=gateway_name,json=gatewayName,proto3" json:"gateway_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRegistrationTokenRequest) Reset()         { *m = CreateRegistrationTokenRequest{} }
+func (m *CreateRegistrationTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRegistrationTokenRequest) ProtoMessage()    {}
+func (*CreateRegistrationTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bootstrapper_da351280fe34fea5, []int{3}
+}
+func (m *CreateRegistrationTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRegistrationTokenRequest.Unmarshal(m, b)
+}
+func (m *CreateRegistrationTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRegistrationTokenRequest.Marshal(b, m, deterministic)
+}
+func (dst *CreateRegistrationTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRegistrationTokenRequest.Merge(dst, src)
+}
+func (m *CreateRegistrationTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRegistrationTokenRequest.Size(m)
+}
+func (m *CreateRegistrationTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRegistrationTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRegistrationTokenRequest proto.InternalMessageInfo
+
+func (m *CreateRegistrationTokenRequest) GetNetworkId() string {
+	if m != nil {
+		return m.NetworkId
+	}
+	return ""
+}
+
+func (m *CreateRegistrationTokenRequest) GetTierId() string {
+	if m != nil {
+		return m.TierId
+	}
+	return ""
+}
+
+func (m *CreateRegistrationTokenRequest) GetTtlSec() int64 {
+	if m != nil {
+		return m.TtlSec
+	}
+	return 0
+}
+
+func (m *CreateRegistrationTokenRequest) GetGatewayName() string {
+	if m != nil {
+		return m.GatewayName
+	}
+	return ""
+}
+
+// RegistrationToken lets a single new gateway register itself into a network
+type RegistrationToken struct {
+	Token     string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	NetworkId string `protobuf:"bytes,2,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	TierId    string `protobuf:"bytes,3,opt,name=tier_id,json=tierId,proto3" json:"tier_id,omitempty"`
+	// Unix time in seconds after which the token can't be used
+	ExpiresAt            int64    `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegistrationToken) Reset()         { *m = RegistrationToken{} }
+func (m *RegistrationToken) String() string { return proto.CompactTextString(m) }
+func (*RegistrationToken) ProtoMessage()    {}
+func (*RegistrationToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bootstrapper_da351280fe34fea5, []int{4}
+}
+func (m *RegistrationToken) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegistrationToken.Unmarshal(m, b)
+}
+func (m *RegistrationToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegistrationToken.Marshal(b, m, deterministic)
+}
+func (dst *RegistrationToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegistrationToken.Merge(dst, src)
+}
+func (m *RegistrationToken) XXX_Size() int {
+	return xxx_messageInfo_RegistrationToken.Size(m)
+}
+func (m *RegistrationToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegistrationToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegistrationToken proto.InternalMessageInfo
+
+func (m *RegistrationToken) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *RegistrationToken) GetNetworkId() string {
+	if m != nil {
+		return m.NetworkId
+	}
+	return ""
+}
+
+func (m *RegistrationToken) GetTierId() string {
+	if m != nil {
+		return m.TierId
+	}
+	return ""
+}
+
+func (m *RegistrationToken) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type RegistrationRequest struct {
+	Token string           `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	HwId  *AccessGatewayID `protobuf:"bytes,2,opt,name=hw_id,json=hwId,proto3" json:"hw_id,omitempty"`
+	// Key of the gateway's challenge-response during later bootstraps
+	ChallengeKey *ChallengeKey `protobuf:"bytes,3,opt,name=challenge_key,json=challengeKey,proto3" json:"challenge_key,omitempty"`
+	// Proves possession of the challenge key by signing the token. Not needed
+	// for ECHO keys.
+	EcdsaResponse        *Response_ECDSA `protobuf:"bytes,4,opt,name=ecdsa_response,json=ecdsaResponse,proto3" json:"ecdsa_response,omitempty"`
+	Csr                  *CSR            `protobuf:"bytes,5,opt,name=csr,proto3" json:"csr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RegistrationRequest) Reset()         { *m = RegistrationRequest{} }
+func (m *RegistrationRequest) String() string { return proto.CompactTextString(m) }
+func (*RegistrationRequest) ProtoMessage()    {}
+func (*RegistrationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bootstrapper_da351280fe34fea5, []int{5}
+}
+func (m *RegistrationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegistrationRequest.Unmarshal(m, b)
+}
+func (m *RegistrationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegistrationRequest.Marshal(b, m, deterministic)
+}
+func (dst *RegistrationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegistrationRequest.Merge(dst, src)
+}
+func (m *RegistrationRequest) XXX_Size() int {
+	return xxx_messageInfo_RegistrationRequest.Size(m)
+}
+func (m *RegistrationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegistrationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegistrationRequest proto.InternalMessageInfo
+
+func (m *RegistrationRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *RegistrationRequest) GetHwId() *AccessGatewayID {
+	if m != nil {
+		return m.HwId
+	}
+	return nil
+}
+
+func (m *RegistrationRequest) GetChallengeKey() *ChallengeKey {
+	if m != nil {
+		return m.ChallengeKey
+	}
+	return nil
+}
+
+func (m *RegistrationRequest) GetEcdsaResponse() *Response_ECDSA {
+	if m != nil {
+		return m.EcdsaResponse
+	}
+	return nil
+}
+
+func (m *RegistrationRequest) GetCsr() *CSR {
+	if m != nil {
+		return m.Csr
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Challenge)(nil), "magma.orc8r.Challenge")
 	proto.RegisterType((*ChallengeKey)(nil), "magma.orc8r.ChallengeKey")
@@ -476,6 +678,9 @@ func init() {
 	proto.RegisterType((*Response_Echo)(nil), "magma.orc8r.Response.Echo")
 	proto.RegisterType((*Response_RSA)(nil), "magma.orc8r.Response.RSA")
 	proto.RegisterType((*Response_ECDSA)(nil), "magma.orc8r.Response.ECDSA")
+	proto.RegisterType((*CreateRegistrationTokenRequest)(nil), "magma.orc8r.CreateRegistrationTokenRequest")
+	proto.RegisterType((*RegistrationToken)(nil), "magma.orc8r.RegistrationToken")
+	proto.RegisterType((*RegistrationRequest)(nil), "magma.orc8r.RegistrationRequest")
 	proto.RegisterEnum("magma.orc8r.ChallengeKey_KeyType", ChallengeKey_KeyType_name, ChallengeKey_KeyType_value)
 }
 
@@ -496,6 +701,13 @@ type BootstrapperClient interface {
 	// send back response and csr for signing
 	// Returns signed certificate.
 	RequestSign(ctx context.Context, in *Response, opts ...grpc.CallOption) (*Certificate, error)
+	// Mint a single-use registration token for a network and tier. Only
+	// available to cloud services.
+	CreateRegistrationToken(ctx context.Context, in *CreateRegistrationTokenRequest, opts ...grpc.CallOption) (*RegistrationToken, error)
+	// Register a new gateway with a registration token, and sign its first
+	// certificate.
+	// Returns signed certificate.
+	RegisterWithToken(ctx context.Context, in *RegistrationRequest, opts ...grpc.CallOption) (*Certificate, error)
 }
 
 type bootstrapperClient struct {
@@ -524,6 +736,24 @@ func (c *bootstrapperClient) RequestSign(ctx context.Context, in *Response, opts
 	return out, nil
 }
 
+func (c *bootstrapperClient) CreateRegistrationToken(ctx context.Context, in *CreateRegistrationTokenRequest, opts ...grpc.CallOption) (*RegistrationToken, error) {
+	out := new(RegistrationToken)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.Bootstrapper/CreateRegistrationToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bootstrapperClient) RegisterWithToken(ctx context.Context, in *RegistrationRequest, opts ...grpc.CallOption) (*Certificate, error) {
+	out := new(Certificate)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.Bootstrapper/RegisterWithToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BootstrapperServer is the server API for Bootstrapper service.
 type BootstrapperServer interface {
 	// get the challange for gateway specified in hw_id (AccessGatewayID)
@@ -531,6 +761,13 @@ type BootstrapperServer interface {
 	// send back response and csr for signing
 	// Returns signed certificate.
 	RequestSign(context.Context, *Response) (*Certificate, error)
+	// Mint a single-use registration token for a network and tier. Only
+	// available to cloud services.
+	CreateRegistrationToken(context.Context, *CreateRegistrationTokenRequest) (*RegistrationToken, error)
+	// Register a new gateway with a registration token, and sign its first
+	// certificate.
+	// Returns signed certificate.
+	RegisterWithToken(context.Context, *RegistrationRequest) (*Certificate, error)
 }
 
 func RegisterBootstrapperServer(s *grpc.Server, srv BootstrapperServer) {
@@ -573,6 +810,42 @@ func _Bootstrapper_RequestSign_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Bootstrapper_CreateRegistrationToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRegistrationTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BootstrapperServer).CreateRegistrationToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.Bootstrapper/CreateRegistrationToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BootstrapperServer).CreateRegistrationToken(ctx, req.(*CreateRegistrationTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bootstrapper_RegisterWithToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BootstrapperServer).RegisterWithToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.Bootstrapper/RegisterWithToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BootstrapperServer).RegisterWithToken(ctx, req.(*RegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Bootstrapper_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.orc8r.Bootstrapper",
 	HandlerType: (*BootstrapperServer)(nil),
@@ -585,47 +858,69 @@ var _Bootstrapper_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RequestSign",
 			Handler:    _Bootstrapper_RequestSign_Handler,
 		},
+		{
+			MethodName: "CreateRegistrationToken",
+			Handler:    _Bootstrapper_CreateRegistrationToken_Handler,
+		},
+		{
+			MethodName: "RegisterWithToken",
+			Handler:    _Bootstrapper_RegisterWithToken_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "orc8r/protos/bootstrapper.proto",
 }
 
 func init() {
-	proto.RegisterFile("orc8r/protos/bootstrapper.proto", fileDescriptor_bootstrapper_6370f262c5f2dbf0)
-}
-
-var fileDescriptor_bootstrapper_6370f262c5f2dbf0 = []byte{
-	// 509 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0x5d, 0x6f, 0xda, 0x30,
-	0x14, 0x4d, 0x0a, 0x6d, 0xe9, 0x25, 0xad, 0x90, 0xa7, 0x6e, 0x34, 0x30, 0xad, 0x4b, 0x5f, 0xfa,
-	0x14, 0x34, 0xa6, 0x4d, 0x7b, 0x98, 0xa6, 0x85, 0x8f, 0x42, 0xd5, 0x87, 0x4a, 0x4e, 0xa5, 0x49,
-	0x7b, 0x41, 0xa9, 0xb9, 0x0b, 0x11, 0x14, 0x67, 0xb6, 0x11, 0xca, 0x3f, 0xd9, 0x3f, 0xd8, 0xff,
-	0xd8, 0x2f, 0x9b, 0x62, 0x42, 0x42, 0x24, 0xd6, 0x97, 0x3d, 0xc5, 0xf6, 0x39, 0xf7, 0x9c, 0x9b,
-	0x63, 0x5f, 0x78, 0xc3, 0x05, 0xfb, 0x24, 0x3a, 0xb1, 0xe0, 0x8a, 0xcb, 0xce, 0x23, 0xe7, 0x4a,
-	0x2a, 0x11, 0xc4, 0x31, 0x0a, 0x57, 0x9f, 0x91, 0xfa, 0x53, 0x10, 0x3e, 0x05, 0xae, 0xa6, 0xd9,
-	0xed, 0x12, 0x9b, 0xa1, 0x50, 0xd1, 0x8f, 0x68, 0x4b, 0xb5, 0x5b, 0x25, 0x34, 0x9a, 0xe2, 0x52,
-	0x45, 0x2a, 0xd9, 0x80, 0x4e, 0x08, 0x27, 0xfd, 0x59, 0xb0, 0x58, 0xe0, 0x32, 0x44, 0xf2, 0x19,
-	0x6a, 0x73, 0x4c, 0x26, 0x2a, 0x89, 0xb1, 0x69, 0x5e, 0x9a, 0xd7, 0x67, 0xdd, 0xb7, 0xee, 0x8e,
-	0x8f, 0x9b, 0x33, 0xef, 0x30, 0x71, 0xef, 0x30, 0x79, 0x48, 0x62, 0xa4, 0xc7, 0xf3, 0xcd, 0x82,
-	0xb4, 0xe1, 0x84, 0x6d, 0x09, 0xcd, 0x83, 0x4b, 0xf3, 0xda, 0xa2, 0xc5, 0x81, 0xf3, 0xdb, 0x04,
-	0x6b, 0xb7, 0xfe, 0x3f, 0xcd, 0x1a, 0x50, 0x99, 0x63, 0x92, 0xd9, 0xa4, 0x4b, 0x67, 0x04, 0xc7,
-	0x19, 0x8b, 0xd4, 0xa0, 0x3a, 0xec, 0x8f, 0xef, 0x1b, 0x06, 0x79, 0x05, 0x2f, 0xfc, 0xfb, 0x9b,
-	0x87, 0x6f, 0x1e, 0x1d, 0x4e, 0xa8, 0xef, 0x4d, 0xfc, 0xb1, 0xd7, 0xfd, 0xf0, 0xb1, 0x61, 0x92,
-	0x0b, 0x38, 0xcf, 0x81, 0x61, 0x7f, 0x50, 0x40, 0x07, 0xce, 0x9f, 0x0a, 0xd4, 0x28, 0xca, 0x98,
-	0x2f, 0x25, 0x92, 0x77, 0x70, 0x38, 0x5b, 0x4f, 0xa2, 0xa9, 0x6e, 0xb1, 0xde, 0x6d, 0x97, 0x5a,
-	0xf4, 0x18, 0x43, 0x29, 0x47, 0x81, 0xc2, 0x75, 0x90, 0xdc, 0x0e, 0x68, 0x75, 0xb6, 0xbe, 0x9d,
-	0x3e, 0x9f, 0x03, 0xf1, 0xe0, 0x14, 0xd9, 0x8c, 0x4f, 0x44, 0xe6, 0xd0, 0xac, 0x68, 0x61, 0xbb,
-	0x24, 0xbc, 0xb5, 0x77, 0x87, 0x6c, 0xc6, 0xc7, 0x06, 0xb5, 0xd2, 0x92, 0xbc, 0xa7, 0x2f, 0x60,
-	0x09, 0x19, 0x14, 0x0a, 0x55, 0xad, 0x70, 0xb1, 0x5f, 0x81, 0xfa, 0xde, 0xd8, 0xa0, 0x75, 0x21,
-	0x83, 0xbc, 0x7e, 0x00, 0x67, 0xc8, 0xa6, 0xbb, 0x0a, 0x87, 0x5a, 0xa1, 0xf5, 0x8f, 0x1e, 0xd2,
-	0x78, 0xc6, 0x06, 0x3d, 0xd5, 0x45, 0xb9, 0x8a, 0x03, 0x15, 0x26, 0x45, 0xf3, 0x48, 0x97, 0x36,
-	0xca, 0x57, 0xe7, 0x53, 0x9a, 0x82, 0xb6, 0x03, 0xd5, 0xf4, 0x0f, 0x88, 0x0d, 0xb5, 0xdc, 0xcb,
-	0xd4, 0x89, 0xe4, 0x7b, 0xfb, 0x0a, 0x2a, 0xd4, 0xf7, 0xd2, 0xd4, 0x64, 0x14, 0x2e, 0x03, 0xb5,
-	0x12, 0x5b, 0x4e, 0x71, 0x60, 0x5f, 0xc1, 0xa1, 0x6e, 0x83, 0x58, 0x60, 0x8a, 0x0c, 0x36, 0x45,
-	0xba, 0x93, 0x59, 0xc4, 0xa6, 0xec, 0x41, 0xe1, 0xd2, 0xfd, 0x65, 0x82, 0xd5, 0xdb, 0x19, 0x1b,
-	0x72, 0x03, 0xd6, 0x08, 0x55, 0xf1, 0xd6, 0x9f, 0xbd, 0x49, 0xfb, 0xe5, 0xfe, 0xa7, 0xe8, 0x18,
-	0xe4, 0x2b, 0xd4, 0x29, 0xfe, 0x5c, 0xa1, 0x54, 0x7e, 0x14, 0x2e, 0xc9, 0xf9, 0xde, 0xcc, 0xec,
-	0x66, 0xb9, 0x7e, 0x33, 0x91, 0x2c, 0x50, 0xe8, 0x18, 0xbd, 0xd7, 0xdf, 0x5b, 0x1a, 0xec, 0x6c,
-	0xe6, 0x92, 0x2d, 0xf8, 0x6a, 0xda, 0x09, 0x79, 0x36, 0xa0, 0x8f, 0x47, 0xfa, 0xfb, 0xfe, 0x6f,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x97, 0xaa, 0x0d, 0x91, 0x03, 0x04, 0x00, 0x00,
+	proto.RegisterFile("orc8r/protos/bootstrapper.proto", fileDescriptor_bootstrapper_da351280fe34fea5)
+}
+
+var fileDescriptor_bootstrapper_da351280fe34fea5 = []byte{
+	// 723 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xcb, 0x6e, 0xda, 0x40,
+	0x14, 0xc5, 0x18, 0x08, 0x5c, 0x9c, 0x88, 0x4e, 0x9a, 0x86, 0x38, 0x8f, 0x26, 0xce, 0x26, 0x52,
+	0x25, 0x50, 0xa9, 0x5a, 0x75, 0x51, 0x45, 0x35, 0x84, 0x84, 0x28, 0x52, 0xa3, 0x8e, 0x23, 0x45,
+	0xea, 0xc6, 0x72, 0xcc, 0xad, 0xb1, 0x00, 0x9b, 0x8e, 0x27, 0xa2, 0xde, 0x75, 0xd5, 0x2f, 0xe8,
+	0x3f, 0xf4, 0x3f, 0xfa, 0x0f, 0xfd, 0x9f, 0xca, 0x4f, 0x70, 0x02, 0xb4, 0x52, 0x57, 0x9e, 0xfb,
+	0x3a, 0xf7, 0xcc, 0x9d, 0x33, 0x63, 0x78, 0xee, 0x32, 0xf3, 0x2d, 0x6b, 0x4e, 0x98, 0xcb, 0x5d,
+	0xaf, 0x79, 0xe7, 0xba, 0xdc, 0xe3, 0xcc, 0x98, 0x4c, 0x90, 0x35, 0x42, 0x1f, 0xa9, 0x8e, 0x0d,
+	0x6b, 0x6c, 0x34, 0xc2, 0x34, 0x79, 0x2f, 0x93, 0x6d, 0x22, 0xe3, 0xf6, 0x67, 0x3b, 0x49, 0x95,
+	0x77, 0x33, 0x51, 0xbb, 0x8f, 0x0e, 0xb7, 0xb9, 0x1f, 0x05, 0x15, 0x0b, 0x2a, 0x9d, 0x81, 0x31,
+	0x1a, 0xa1, 0x63, 0x21, 0x79, 0x07, 0xe5, 0x21, 0xfa, 0x3a, 0xf7, 0x27, 0x58, 0x17, 0x0e, 0x85,
+	0x93, 0x8d, 0xd6, 0x51, 0x63, 0xae, 0x4f, 0x23, 0xcd, 0xbc, 0x42, 0xbf, 0x71, 0x85, 0xfe, 0x8d,
+	0x3f, 0x41, 0xba, 0x36, 0x8c, 0x16, 0x64, 0x0f, 0x2a, 0x66, 0x92, 0x50, 0xcf, 0x1f, 0x0a, 0x27,
+	0x12, 0x9d, 0x39, 0x94, 0x9f, 0x02, 0x48, 0xf3, 0xf5, 0xff, 0xd9, 0xac, 0x06, 0xe2, 0x10, 0xfd,
+	0xb8, 0x4d, 0xb0, 0x54, 0x2e, 0x60, 0x2d, 0xce, 0x22, 0x65, 0x28, 0x74, 0x3b, 0xbd, 0xeb, 0x5a,
+	0x8e, 0x6c, 0xc3, 0xa6, 0x76, 0x7d, 0x7e, 0x73, 0xab, 0xd2, 0xae, 0x4e, 0x35, 0x55, 0xd7, 0x7a,
+	0x6a, 0xeb, 0xf5, 0x9b, 0x9a, 0x40, 0x76, 0x60, 0x2b, 0x0d, 0x74, 0x3b, 0x67, 0xb3, 0x50, 0x5e,
+	0xf9, 0x25, 0x42, 0x99, 0xa2, 0x37, 0x71, 0x1d, 0x0f, 0xc9, 0x4b, 0x28, 0x0e, 0xa6, 0xba, 0xdd,
+	0x0f, 0x29, 0x56, 0x5b, 0x7b, 0x19, 0x8a, 0xaa, 0x69, 0xa2, 0xe7, 0x5d, 0x18, 0x1c, 0xa7, 0x86,
+	0x7f, 0x79, 0x46, 0x0b, 0x83, 0xe9, 0x65, 0x7f, 0xf5, 0x1c, 0x88, 0x0a, 0xeb, 0x68, 0x0e, 0x5c,
+	0x9d, 0xc5, 0x1d, 0xea, 0x62, 0x08, 0x2c, 0x67, 0x80, 0x93, 0xf6, 0x8d, 0xae, 0x39, 0x70, 0x7b,
+	0x39, 0x2a, 0x05, 0x25, 0x29, 0xa7, 0x53, 0x90, 0x98, 0x67, 0xcc, 0x10, 0x0a, 0x21, 0xc2, 0xce,
+	0x62, 0x04, 0xaa, 0xa9, 0xbd, 0x1c, 0xad, 0x32, 0xcf, 0x48, 0xeb, 0xcf, 0x60, 0x03, 0xcd, 0xfe,
+	0x3c, 0x42, 0x31, 0x44, 0xd8, 0x5d, 0xc2, 0x21, 0x18, 0x4f, 0x2f, 0x47, 0xd7, 0xc3, 0xa2, 0x14,
+	0x45, 0x01, 0xd1, 0xf4, 0x58, 0xbd, 0x14, 0x96, 0xd6, 0xb2, 0x47, 0xa7, 0x51, 0x1a, 0x04, 0x65,
+	0x05, 0x0a, 0xc1, 0x0e, 0x88, 0x0c, 0xe5, 0xb4, 0x97, 0x10, 0x4e, 0x24, 0xb5, 0xe5, 0x63, 0x10,
+	0xa9, 0xa6, 0x06, 0x53, 0xf3, 0x6c, 0xcb, 0x31, 0xf8, 0x3d, 0x4b, 0x72, 0x66, 0x0e, 0xf9, 0x18,
+	0x8a, 0x21, 0x0d, 0x22, 0x81, 0xc0, 0xe2, 0xb0, 0xc0, 0x02, 0xcb, 0x8b, 0x47, 0x2c, 0x78, 0x6d,
+	0x98, 0x75, 0x51, 0x7e, 0x08, 0x70, 0xd0, 0x61, 0x68, 0x70, 0xa4, 0x68, 0xd9, 0xc1, 0xe5, 0xe1,
+	0xb6, 0xeb, 0xdc, 0xb8, 0x43, 0x74, 0x28, 0x7e, 0xb9, 0x47, 0x8f, 0x93, 0x7d, 0x00, 0x07, 0xf9,
+	0xd4, 0x65, 0xc3, 0xe4, 0x7c, 0x2b, 0xb4, 0x12, 0x7b, 0x2e, 0xfb, 0x64, 0x1b, 0xd6, 0xb8, 0x8d,
+	0x2c, 0x88, 0xe5, 0xc3, 0x58, 0x29, 0x30, 0xe3, 0x00, 0x1f, 0xe9, 0x1e, 0x9a, 0xe1, 0xd9, 0x89,
+	0xb4, 0xc4, 0xf9, 0x48, 0x43, 0x93, 0x1c, 0x81, 0x64, 0x45, 0x5a, 0xd0, 0x1d, 0x63, 0x1c, 0x9d,
+	0x4b, 0x85, 0x56, 0x63, 0xdf, 0x07, 0x63, 0x8c, 0xca, 0x37, 0x01, 0x9e, 0x3c, 0x22, 0x44, 0x9e,
+	0x42, 0x91, 0x07, 0x8b, 0x98, 0x44, 0x64, 0x3c, 0xe0, 0x97, 0x5f, 0xc1, 0x4f, 0xcc, 0xf0, 0xdb,
+	0x07, 0xc0, 0xaf, 0x13, 0x9b, 0xa1, 0xa7, 0x1b, 0x3c, 0x24, 0x21, 0xd2, 0x4a, 0xec, 0x51, 0xb9,
+	0xf2, 0x3d, 0x0f, 0x9b, 0xf3, 0x14, 0x92, 0x71, 0x2c, 0x26, 0x91, 0xea, 0x3f, 0xff, 0xcf, 0xfa,
+	0x3f, 0x85, 0xf5, 0x54, 0xee, 0x7a, 0x70, 0x49, 0xc5, 0x05, 0xfa, 0x9c, 0xbf, 0xdd, 0x54, 0x32,
+	0xe7, 0x2c, 0xd2, 0x7e, 0x24, 0xcf, 0xc2, 0x5f, 0xe5, 0xb9, 0x44, 0x9c, 0xc5, 0x15, 0xe2, 0x6c,
+	0xfd, 0xce, 0x83, 0xd4, 0x9e, 0x7b, 0x59, 0xc9, 0x39, 0x48, 0x17, 0xc8, 0x67, 0xcf, 0xe1, 0xca,
+	0xcd, 0xca, 0xcf, 0x16, 0xef, 0x47, 0xc9, 0x91, 0xf7, 0x50, 0x8d, 0x87, 0xaa, 0xd9, 0x96, 0x43,
+	0xb6, 0x16, 0xf2, 0x96, 0xeb, 0xd9, 0xfa, 0xe8, 0xd1, 0x36, 0x0d, 0x1e, 0x20, 0x0c, 0x60, 0x7b,
+	0x89, 0x78, 0xc9, 0x8b, 0x6c, 0xd9, 0x4a, 0x89, 0xcb, 0x07, 0x0f, 0x5a, 0x3f, 0x48, 0x53, 0x72,
+	0xe4, 0x63, 0xa2, 0x47, 0x64, 0xb7, 0x36, 0x1f, 0x44, 0x3d, 0x0e, 0x97, 0x96, 0x25, 0xc0, 0x2b,
+	0xc8, 0xb7, 0xf7, 0x3f, 0xed, 0x86, 0xc1, 0x66, 0xf4, 0xdf, 0x31, 0x47, 0xee, 0x7d, 0xbf, 0x69,
+	0xb9, 0xf1, 0x0f, 0xe8, 0xae, 0x14, 0x7e, 0x5f, 0xfd, 0x19, 0x00, 0x37, 0x47, 0x29, 0xc7, 0xe3,
+	0x06, 0x00, 0x00,
 }
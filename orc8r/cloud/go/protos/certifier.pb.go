@@ -40,7 +40,7 @@ func (x CertType) String() string {
 	return proto.EnumName(CertType_name, int32(x))
 }
 func (CertType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_certifier_843fba4d086aefbc, []int{0}
+	return fileDescriptor_certifier_b6b648c48407f8bf, []int{0}
 }
 
 type CSR struct {
@@ -57,7 +57,7 @@ func (m *CSR) Reset()         { *m = CSR{} }
 func (m *CSR) String() string { return proto.CompactTextString(m) }
 func (*CSR) ProtoMessage()    {}
 func (*CSR) Descriptor() ([]byte, []int) {
-	return fileDescriptor_certifier_843fba4d086aefbc, []int{0}
+	return fileDescriptor_certifier_b6b648c48407f8bf, []int{0}
 }
 func (m *CSR) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CSR.Unmarshal(m, b)
@@ -119,7 +119,7 @@ func (m *Certificate) Reset()         { *m = Certificate{} }
 func (m *Certificate) String() string { return proto.CompactTextString(m) }
 func (*Certificate) ProtoMessage()    {}
 func (*Certificate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_certifier_843fba4d086aefbc, []int{1}
+	return fileDescriptor_certifier_b6b648c48407f8bf, []int{1}
 }
 func (m *Certificate) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Certificate.Unmarshal(m, b)
@@ -178,7 +178,7 @@ func (m *Certificate_SN) Reset()         { *m = Certificate_SN{} }
 func (m *Certificate_SN) String() string { return proto.CompactTextString(m) }
 func (*Certificate_SN) ProtoMessage()    {}
 func (*Certificate_SN) Descriptor() ([]byte, []int) {
-	return fileDescriptor_certifier_843fba4d086aefbc, []int{1, 0}
+	return fileDescriptor_certifier_b6b648c48407f8bf, []int{1, 0}
 }
 func (m *Certificate_SN) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Certificate_SN.Unmarshal(m, b)
@@ -216,7 +216,7 @@ func (m *CACert) Reset()         { *m = CACert{} }
 func (m *CACert) String() string { return proto.CompactTextString(m) }
 func (*CACert) ProtoMessage()    {}
 func (*CACert) Descriptor() ([]byte, []int) {
-	return fileDescriptor_certifier_843fba4d086aefbc, []int{2}
+	return fileDescriptor_certifier_b6b648c48407f8bf, []int{2}
 }
 func (m *CACert) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CACert.Unmarshal(m, b)
@@ -243,43 +243,123 @@ func (m *CACert) GetCert() []byte {
 	return nil
 }
 
+// RenewalAdvice tells a gateway when it should renew its certificate
+type RenewalAdvice struct {
+	// renew_at is after the configured fraction of the certificate's lifetime
+	RenewAt  *timestamp.Timestamp `protobuf:"bytes,1,opt,name=renew_at,json=renewAt,proto3" json:"renew_at,omitempty"`
+	RenewNow bool                 `protobuf:"varint,2,opt,name=renew_now,json=renewNow,proto3" json:"renew_now,omitempty"`
+	NotAfter *timestamp.Timestamp `protobuf:"bytes,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	// superseded is set if a newer certificate has been issued to the same
+	// identity. Superseded certificates are revoked at revoke_at.
+	Superseded           bool                 `protobuf:"varint,4,opt,name=superseded,proto3" json:"superseded,omitempty"`
+	RevokeAt             *timestamp.Timestamp `protobuf:"bytes,5,opt,name=revoke_at,json=revokeAt,proto3" json:"revoke_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RenewalAdvice) Reset()         { *m = RenewalAdvice{} }
+func (m *RenewalAdvice) String() string { return proto.CompactTextString(m) }
+func (*RenewalAdvice) ProtoMessage()    {}
+func (*RenewalAdvice) Descriptor() ([]byte, []int) {
+	return fileDescriptor_certifier_b6b648c48407f8bf, []int{3}
+}
+func (m *RenewalAdvice) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenewalAdvice.Unmarshal(m, b)
+}
+func (m *RenewalAdvice) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenewalAdvice.Marshal(b, m, deterministic)
+}
+func (dst *RenewalAdvice) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenewalAdvice.Merge(dst, src)
+}
+func (m *RenewalAdvice) XXX_Size() int {
+	return xxx_messageInfo_RenewalAdvice.Size(m)
+}
+func (m *RenewalAdvice) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenewalAdvice.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenewalAdvice proto.InternalMessageInfo
+
+func (m *RenewalAdvice) GetRenewAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.RenewAt
+	}
+	return nil
+}
+
+func (m *RenewalAdvice) GetRenewNow() bool {
+	if m != nil {
+		return m.RenewNow
+	}
+	return false
+}
+
+func (m *RenewalAdvice) GetNotAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.NotAfter
+	}
+	return nil
+}
+
+func (m *RenewalAdvice) GetSuperseded() bool {
+	if m != nil {
+		return m.Superseded
+	}
+	return false
+}
+
+func (m *RenewalAdvice) GetRevokeAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.RevokeAt
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*CSR)(nil), "magma.orc8r.CSR")
 	proto.RegisterType((*Certificate)(nil), "magma.orc8r.Certificate")
 	proto.RegisterType((*Certificate_SN)(nil), "magma.orc8r.Certificate.SN")
 	proto.RegisterType((*CACert)(nil), "magma.orc8r.CACert")
+	proto.RegisterType((*RenewalAdvice)(nil), "magma.orc8r.RenewalAdvice")
 	proto.RegisterEnum("magma.orc8r.CertType", CertType_name, CertType_value)
 }
 
 func init() {
-	proto.RegisterFile("orc8r/protos/certifier.proto", fileDescriptor_certifier_843fba4d086aefbc)
-}
-
-var fileDescriptor_certifier_843fba4d086aefbc = []byte{
-	// 399 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0x5d, 0xab, 0xd3, 0x30,
-	0x18, 0xc7, 0x6d, 0x37, 0xd6, 0xf5, 0xe9, 0xe1, 0x70, 0x08, 0x8a, 0x3d, 0xdd, 0x51, 0xcb, 0x40,
-	0x18, 0x0a, 0x29, 0x1c, 0x2f, 0x3c, 0x5e, 0x6e, 0xad, 0x82, 0x20, 0x43, 0xb2, 0xea, 0x85, 0x37,
-	0xa5, 0x6b, 0xd3, 0x12, 0x58, 0x9b, 0x92, 0x66, 0xc2, 0x3e, 0x97, 0x1f, 0xc8, 0xaf, 0x22, 0x49,
-	0x53, 0xd1, 0x0d, 0xce, 0x55, 0x93, 0x3e, 0xbf, 0xf0, 0x7f, 0xe1, 0x81, 0x3b, 0x2e, 0x8a, 0x07,
-	0x11, 0x75, 0x82, 0x4b, 0xde, 0x47, 0x05, 0x15, 0x92, 0x55, 0x8c, 0x0a, 0xac, 0x7f, 0x20, 0xaf,
-	0xc9, 0xeb, 0x26, 0xc7, 0x9a, 0x09, 0x16, 0xff, 0xa1, 0xac, 0xa4, 0xad, 0x64, 0xf2, 0x34, 0x90,
-	0xc1, 0xab, 0x9a, 0xf3, 0xfa, 0x40, 0x87, 0xe9, 0xfe, 0x58, 0x45, 0x92, 0x35, 0xb4, 0x97, 0x79,
-	0xd3, 0x19, 0xe0, 0xe5, 0x39, 0x50, 0x1e, 0x45, 0x2e, 0x19, 0x6f, 0x87, 0xf9, 0xf2, 0x97, 0x05,
-	0x93, 0x78, 0x47, 0xd0, 0x6b, 0xb0, 0x59, 0xe9, 0x5b, 0xa1, 0xb5, 0xf2, 0xee, 0x9f, 0xe1, 0x7f,
-	0xf4, 0xf1, 0x67, 0xa3, 0x48, 0x6c, 0x56, 0xa2, 0x07, 0x80, 0x9f, 0xf9, 0x81, 0x95, 0x99, 0xd2,
-	0xf1, 0x6d, 0x8d, 0xdf, 0xe2, 0x41, 0x03, 0x8f, 0x1a, 0x38, 0x31, 0x1a, 0xc4, 0xd5, 0x70, 0xca,
-	0x1a, 0x8a, 0x9e, 0x83, 0x53, 0xf4, 0x22, 0x2b, 0xa9, 0xf0, 0x27, 0xa1, 0xb5, 0xba, 0x22, 0xb3,
-	0xa2, 0x17, 0x09, 0x15, 0xe8, 0x1e, 0x5c, 0x95, 0x3f, 0x93, 0xa7, 0x8e, 0xfa, 0xd3, 0xd0, 0x5a,
-	0x5d, 0x9f, 0x19, 0x88, 0xa9, 0x90, 0xe9, 0xa9, 0xa3, 0x64, 0x5e, 0x98, 0xd3, 0xf2, 0xb7, 0x05,
-	0x5e, 0x3c, 0x94, 0x56, 0xe4, 0x92, 0xa2, 0xb7, 0x60, 0xf7, 0xad, 0x71, 0xbf, 0xb8, 0x78, 0x6c,
-	0x28, 0xbc, 0xdb, 0x12, 0xbb, 0x6f, 0xd1, 0x07, 0x80, 0x96, 0xcb, 0x6c, 0x4f, 0x2b, 0x2e, 0xc6,
-	0x0c, 0xc1, 0x45, 0x86, 0x74, 0x2c, 0x92, 0xb8, 0x2d, 0x97, 0x1b, 0x0d, 0xa3, 0xf7, 0xa0, 0x2e,
-	0x59, 0x5e, 0x49, 0x13, 0xe3, 0xf1, 0x97, 0xf3, 0x96, 0xcb, 0xb5, 0x62, 0xd1, 0x2d, 0x68, 0xf3,
-	0x3a, 0xfe, 0x54, 0xc7, 0x77, 0xd4, 0x3d, 0xa1, 0x22, 0x78, 0x0a, 0xf6, 0x6e, 0x8b, 0xae, 0xff,
-	0x26, 0x70, 0x95, 0xc9, 0xe5, 0x1d, 0xcc, 0xe2, 0xb5, 0x32, 0x8f, 0x10, 0x4c, 0x15, 0xaa, 0x67,
-	0x57, 0x44, 0x9f, 0xdf, 0x84, 0x30, 0x1f, 0x5b, 0x41, 0x1e, 0x38, 0xc9, 0xc7, 0x4f, 0xeb, 0x6f,
-	0x5f, 0xd2, 0x9b, 0x27, 0xc8, 0x81, 0xc9, 0xf7, 0xaf, 0xdb, 0x1b, 0x6b, 0xf3, 0xe2, 0xc7, 0x42,
-	0xd7, 0x10, 0x0d, 0xdb, 0x53, 0x1c, 0xf8, 0xb1, 0x8c, 0x6a, 0x6e, 0xd6, 0x68, 0x3f, 0xd3, 0xdf,
-	0x77, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xf5, 0xa8, 0xb0, 0xa6, 0x88, 0x02, 0x00, 0x00,
+	proto.RegisterFile("orc8r/protos/certifier.proto", fileDescriptor_certifier_b6b648c48407f8bf)
+}
+
+var fileDescriptor_certifier_b6b648c48407f8bf = []byte{
+	// 477 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0xdf, 0x8a, 0xd3, 0x40,
+	0x14, 0xc6, 0x4d, 0x5a, 0x9b, 0xe4, 0x74, 0x5d, 0x96, 0x41, 0x31, 0xdb, 0xae, 0x6b, 0x29, 0x08,
+	0x45, 0x21, 0x81, 0x15, 0x71, 0xbd, 0xcc, 0xb6, 0x0a, 0x82, 0x14, 0x99, 0x56, 0x2f, 0xbc, 0x09,
+	0x69, 0xe6, 0xb4, 0x0c, 0x36, 0x99, 0x30, 0x99, 0xb6, 0xf4, 0xb9, 0x7c, 0x20, 0x9f, 0xc2, 0x7b,
+	0x99, 0x99, 0x54, 0xd6, 0x5d, 0xb0, 0xe0, 0x55, 0xe7, 0xcf, 0xef, 0x9c, 0xef, 0xfb, 0x4e, 0x33,
+	0x70, 0x21, 0x64, 0x7e, 0x2d, 0xe3, 0x4a, 0x0a, 0x25, 0xea, 0x38, 0x47, 0xa9, 0xf8, 0x92, 0xa3,
+	0x8c, 0xcc, 0x01, 0xe9, 0x16, 0xd9, 0xaa, 0xc8, 0x22, 0xc3, 0xf4, 0xfa, 0x7f, 0xa1, 0x9c, 0x61,
+	0xa9, 0xb8, 0xda, 0x5b, 0xb2, 0xf7, 0x7c, 0x25, 0xc4, 0x6a, 0x8d, 0xf6, 0x76, 0xb1, 0x59, 0xc6,
+	0x8a, 0x17, 0x58, 0xab, 0xac, 0xa8, 0x1a, 0xe0, 0xf2, 0x2e, 0xc0, 0x36, 0x32, 0x53, 0x5c, 0x94,
+	0xf6, 0x7e, 0xf8, 0xc3, 0x81, 0xd6, 0x78, 0x46, 0xc9, 0x0b, 0x70, 0x39, 0x0b, 0x9d, 0x81, 0x33,
+	0xea, 0x5e, 0x3d, 0x89, 0x6e, 0xe9, 0x47, 0x1f, 0x1b, 0x45, 0xea, 0x72, 0x46, 0xae, 0x01, 0xb6,
+	0xd9, 0x9a, 0xb3, 0x54, 0xeb, 0x84, 0xae, 0xc1, 0xcf, 0x23, 0xab, 0x11, 0x1d, 0x34, 0xa2, 0x49,
+	0xa3, 0x41, 0x03, 0x03, 0xcf, 0x79, 0x81, 0xe4, 0x29, 0x78, 0x79, 0x2d, 0x53, 0x86, 0x32, 0x6c,
+	0x0d, 0x9c, 0xd1, 0x09, 0xed, 0xe4, 0xb5, 0x9c, 0xa0, 0x24, 0x57, 0x10, 0xe8, 0xfc, 0xa9, 0xda,
+	0x57, 0x18, 0xb6, 0x07, 0xce, 0xe8, 0xf4, 0x8e, 0x81, 0x31, 0x4a, 0x35, 0xdf, 0x57, 0x48, 0xfd,
+	0xbc, 0x59, 0x0d, 0x7f, 0x3a, 0xd0, 0x1d, 0xdb, 0xa1, 0xe5, 0x99, 0x42, 0xf2, 0x0a, 0xdc, 0xba,
+	0x6c, 0xdc, 0xf7, 0xef, 0x15, 0x37, 0x54, 0x34, 0x9b, 0x52, 0xb7, 0x2e, 0xc9, 0x3b, 0x80, 0x52,
+	0xa8, 0x74, 0x81, 0x4b, 0x21, 0x0f, 0x19, 0x7a, 0xf7, 0x32, 0xcc, 0x0f, 0x83, 0xa4, 0x41, 0x29,
+	0xd4, 0x8d, 0x81, 0xc9, 0x5b, 0xd0, 0x9b, 0x34, 0x5b, 0xaa, 0x26, 0xc6, 0xbf, 0x2b, 0xfd, 0x52,
+	0xa8, 0x44, 0xb3, 0xe4, 0x1c, 0x8c, 0x79, 0x13, 0xbf, 0x6d, 0xe2, 0x7b, 0x7a, 0x3f, 0x41, 0xd9,
+	0x7b, 0x0c, 0xee, 0x6c, 0x4a, 0x4e, 0xff, 0x24, 0x08, 0xb4, 0xc9, 0xe1, 0x05, 0x74, 0xc6, 0x89,
+	0x36, 0x4f, 0x08, 0xb4, 0x35, 0x6a, 0xee, 0x4e, 0xa8, 0x59, 0x0f, 0x7f, 0x39, 0xf0, 0x88, 0x62,
+	0x89, 0xbb, 0x6c, 0x9d, 0xb0, 0x2d, 0xcf, 0x91, 0xbc, 0x01, 0x5f, 0xea, 0x83, 0x34, 0x53, 0xa1,
+	0x73, 0xd4, 0x98, 0x67, 0xd8, 0x44, 0x91, 0x3e, 0x04, 0xb6, 0xac, 0x14, 0x3b, 0x33, 0x0a, 0x9f,
+	0xda, 0x3e, 0x53, 0xb1, 0xfb, 0xff, 0xb4, 0x97, 0x00, 0xf5, 0xa6, 0x42, 0x59, 0x23, 0x43, 0x66,
+	0xf2, 0xfa, 0xf4, 0xd6, 0x89, 0x6e, 0x2c, 0x71, 0x2b, 0xbe, 0xa3, 0x76, 0xfb, 0xf0, 0x78, 0x63,
+	0x0b, 0x27, 0xea, 0xe5, 0x00, 0xfc, 0xc3, 0xd7, 0x40, 0xba, 0xe0, 0x4d, 0xde, 0x7f, 0x48, 0xbe,
+	0x7c, 0x9a, 0x9f, 0x3d, 0x20, 0x1e, 0xb4, 0xbe, 0x7e, 0x9e, 0x9e, 0x39, 0x37, 0xcf, 0xbe, 0xf5,
+	0xcd, 0xdf, 0x1f, 0xdb, 0x57, 0x93, 0xaf, 0xc5, 0x86, 0xc5, 0x2b, 0xd1, 0x3c, 0x9f, 0x45, 0xc7,
+	0xfc, 0xbe, 0xfe, 0x3d, 0x00, 0xbf, 0x1e, 0xd5, 0x4d, 0x80, 0x03, 0x00, 0x00,
 }
@@ -16,8 +16,14 @@ import (
 	"reflect"
 
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
 )
 
+// OperatorMetadataKey is the gRPC metadata key which carries the ID of the
+// operator on whose behalf a local cloud service (such as Obsidian) calls
+// another cloud service
+const OperatorMetadataKey = "x-magma-operator-id"
+
 // Identity type names table. Every Identity type should add a unique type name
 // into identityTypeNameTable below. The names must be unique and should only
 // include alphanumeric ASCII characters
@@ -228,6 +234,18 @@ func (id *Identity) NewContextWithIdentity(ctx context.Context) context.Context
 	return context.WithValue(ctx, clientIdentityKey{}, id)
 }
 
+// NewOutgoingContextWithOperator returns a new Context which carries the ID of
+// the operator Identity in ctx (if any) as outgoing GRPC metadata, so the
+// callee can act on the operator's behalf. If ctx doesn't carry an operator
+// Identity, it's returned unmodified
+func NewOutgoingContextWithOperator(ctx context.Context) context.Context {
+	operatorId := GetClientIdentity(ctx).GetOperator()
+	if len(operatorId) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, OperatorMetadataKey, operatorId)
+}
+
 // TBD (not currently implemented/enabled):
 //
 // Identity (such as a registered Gateway) may exist in a scope of another
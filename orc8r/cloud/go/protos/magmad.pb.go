@@ -47,7 +47,7 @@ func (x NetworkInterface_Status) String() string {
 	return proto.EnumName(NetworkInterface_Status_name, int32(x))
 }
 func (NetworkInterface_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{19, 0}
+	return fileDescriptor_magmad_e785115ef4250697, []int{19, 0}
 }
 
 // RequestedAction is an emergency/last resort operation request for an
@@ -76,7 +76,7 @@ func (x CheckinResponse_RequestedAction) String() string {
 	return proto.EnumName(CheckinResponse_RequestedAction_name, int32(x))
 }
 func (CheckinResponse_RequestedAction) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{25, 0}
+	return fileDescriptor_magmad_e785115ef4250697, []int{25, 0}
 }
 
 type PingParams struct {
@@ -91,7 +91,7 @@ func (m *PingParams) Reset()         { *m = PingParams{} }
 func (m *PingParams) String() string { return proto.CompactTextString(m) }
 func (*PingParams) ProtoMessage()    {}
 func (*PingParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{0}
+	return fileDescriptor_magmad_e785115ef4250697, []int{0}
 }
 func (m *PingParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingParams.Unmarshal(m, b)
@@ -138,7 +138,7 @@ func (m *TracerouteParams) Reset()         { *m = TracerouteParams{} }
 func (m *TracerouteParams) String() string { return proto.CompactTextString(m) }
 func (*TracerouteParams) ProtoMessage()    {}
 func (*TracerouteParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{1}
+	return fileDescriptor_magmad_e785115ef4250697, []int{1}
 }
 func (m *TracerouteParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TracerouteParams.Unmarshal(m, b)
@@ -191,7 +191,7 @@ func (m *NetworkTestRequest) Reset()         { *m = NetworkTestRequest{} }
 func (m *NetworkTestRequest) String() string { return proto.CompactTextString(m) }
 func (*NetworkTestRequest) ProtoMessage()    {}
 func (*NetworkTestRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{2}
+	return fileDescriptor_magmad_e785115ef4250697, []int{2}
 }
 func (m *NetworkTestRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkTestRequest.Unmarshal(m, b)
@@ -241,7 +241,7 @@ func (m *PingResult) Reset()         { *m = PingResult{} }
 func (m *PingResult) String() string { return proto.CompactTextString(m) }
 func (*PingResult) ProtoMessage()    {}
 func (*PingResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{3}
+	return fileDescriptor_magmad_e785115ef4250697, []int{3}
 }
 func (m *PingResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingResult.Unmarshal(m, b)
@@ -316,7 +316,7 @@ func (m *TracerouteProbe) Reset()         { *m = TracerouteProbe{} }
 func (m *TracerouteProbe) String() string { return proto.CompactTextString(m) }
 func (*TracerouteProbe) ProtoMessage()    {}
 func (*TracerouteProbe) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{4}
+	return fileDescriptor_magmad_e785115ef4250697, []int{4}
 }
 func (m *TracerouteProbe) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TracerouteProbe.Unmarshal(m, b)
@@ -369,7 +369,7 @@ func (m *TracerouteHop) Reset()         { *m = TracerouteHop{} }
 func (m *TracerouteHop) String() string { return proto.CompactTextString(m) }
 func (*TracerouteHop) ProtoMessage()    {}
 func (*TracerouteHop) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{5}
+	return fileDescriptor_magmad_e785115ef4250697, []int{5}
 }
 func (m *TracerouteHop) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TracerouteHop.Unmarshal(m, b)
@@ -416,7 +416,7 @@ func (m *TracerouteResult) Reset()         { *m = TracerouteResult{} }
 func (m *TracerouteResult) String() string { return proto.CompactTextString(m) }
 func (*TracerouteResult) ProtoMessage()    {}
 func (*TracerouteResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{6}
+	return fileDescriptor_magmad_e785115ef4250697, []int{6}
 }
 func (m *TracerouteResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TracerouteResult.Unmarshal(m, b)
@@ -469,7 +469,7 @@ func (m *NetworkTestResponse) Reset()         { *m = NetworkTestResponse{} }
 func (m *NetworkTestResponse) String() string { return proto.CompactTextString(m) }
 func (*NetworkTestResponse) ProtoMessage()    {}
 func (*NetworkTestResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{7}
+	return fileDescriptor_magmad_e785115ef4250697, []int{7}
 }
 func (m *NetworkTestResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkTestResponse.Unmarshal(m, b)
@@ -514,7 +514,7 @@ func (m *GetGatewayIdResponse) Reset()         { *m = GetGatewayIdResponse{} }
 func (m *GetGatewayIdResponse) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayIdResponse) ProtoMessage()    {}
 func (*GetGatewayIdResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{8}
+	return fileDescriptor_magmad_e785115ef4250697, []int{8}
 }
 func (m *GetGatewayIdResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetGatewayIdResponse.Unmarshal(m, b)
@@ -552,7 +552,7 @@ func (m *RestartServicesRequest) Reset()         { *m = RestartServicesRequest{}
 func (m *RestartServicesRequest) String() string { return proto.CompactTextString(m) }
 func (*RestartServicesRequest) ProtoMessage()    {}
 func (*RestartServicesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{9}
+	return fileDescriptor_magmad_e785115ef4250697, []int{9}
 }
 func (m *RestartServicesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RestartServicesRequest.Unmarshal(m, b)
@@ -591,7 +591,7 @@ func (m *GenericCommandParams) Reset()         { *m = GenericCommandParams{} }
 func (m *GenericCommandParams) String() string { return proto.CompactTextString(m) }
 func (*GenericCommandParams) ProtoMessage()    {}
 func (*GenericCommandParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{10}
+	return fileDescriptor_magmad_e785115ef4250697, []int{10}
 }
 func (m *GenericCommandParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GenericCommandParams.Unmarshal(m, b)
@@ -636,7 +636,7 @@ func (m *GenericCommandResponse) Reset()         { *m = GenericCommandResponse{}
 func (m *GenericCommandResponse) String() string { return proto.CompactTextString(m) }
 func (*GenericCommandResponse) ProtoMessage()    {}
 func (*GenericCommandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{11}
+	return fileDescriptor_magmad_e785115ef4250697, []int{11}
 }
 func (m *GenericCommandResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GenericCommandResponse.Unmarshal(m, b)
@@ -674,7 +674,7 @@ func (m *TailLogsRequest) Reset()         { *m = TailLogsRequest{} }
 func (m *TailLogsRequest) String() string { return proto.CompactTextString(m) }
 func (*TailLogsRequest) ProtoMessage()    {}
 func (*TailLogsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{12}
+	return fileDescriptor_magmad_e785115ef4250697, []int{12}
 }
 func (m *TailLogsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TailLogsRequest.Unmarshal(m, b)
@@ -712,7 +712,7 @@ func (m *LogLine) Reset()         { *m = LogLine{} }
 func (m *LogLine) String() string { return proto.CompactTextString(m) }
 func (*LogLine) ProtoMessage()    {}
 func (*LogLine) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{13}
+	return fileDescriptor_magmad_e785115ef4250697, []int{13}
 }
 func (m *LogLine) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogLine.Unmarshal(m, b)
@@ -755,7 +755,7 @@ func (m *DiskPartition) Reset()         { *m = DiskPartition{} }
 func (m *DiskPartition) String() string { return proto.CompactTextString(m) }
 func (*DiskPartition) ProtoMessage()    {}
 func (*DiskPartition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{14}
+	return fileDescriptor_magmad_e785115ef4250697, []int{14}
 }
 func (m *DiskPartition) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiskPartition.Unmarshal(m, b)
@@ -834,7 +834,7 @@ func (m *SystemStatus) Reset()         { *m = SystemStatus{} }
 func (m *SystemStatus) String() string { return proto.CompactTextString(m) }
 func (*SystemStatus) ProtoMessage()    {}
 func (*SystemStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{15}
+	return fileDescriptor_magmad_e785115ef4250697, []int{15}
 }
 func (m *SystemStatus) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SystemStatus.Unmarshal(m, b)
@@ -959,7 +959,7 @@ func (m *Package) Reset()         { *m = Package{} }
 func (m *Package) String() string { return proto.CompactTextString(m) }
 func (*Package) ProtoMessage()    {}
 func (*Package) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{16}
+	return fileDescriptor_magmad_e785115ef4250697, []int{16}
 }
 func (m *Package) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Package.Unmarshal(m, b)
@@ -1005,7 +1005,7 @@ func (m *ConfigInfo) Reset()         { *m = ConfigInfo{} }
 func (m *ConfigInfo) String() string { return proto.CompactTextString(m) }
 func (*ConfigInfo) ProtoMessage()    {}
 func (*ConfigInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{17}
+	return fileDescriptor_magmad_e785115ef4250697, []int{17}
 }
 func (m *ConfigInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigInfo.Unmarshal(m, b)
@@ -1052,7 +1052,7 @@ func (m *PlatformInfo) Reset()         { *m = PlatformInfo{} }
 func (m *PlatformInfo) String() string { return proto.CompactTextString(m) }
 func (*PlatformInfo) ProtoMessage()    {}
 func (*PlatformInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{18}
+	return fileDescriptor_magmad_e785115ef4250697, []int{18}
 }
 func (m *PlatformInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlatformInfo.Unmarshal(m, b)
@@ -1127,7 +1127,7 @@ func (m *NetworkInterface) Reset()         { *m = NetworkInterface{} }
 func (m *NetworkInterface) String() string { return proto.CompactTextString(m) }
 func (*NetworkInterface) ProtoMessage()    {}
 func (*NetworkInterface) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{19}
+	return fileDescriptor_magmad_e785115ef4250697, []int{19}
 }
 func (m *NetworkInterface) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkInterface.Unmarshal(m, b)
@@ -1200,7 +1200,7 @@ func (m *Route) Reset()         { *m = Route{} }
 func (m *Route) String() string { return proto.CompactTextString(m) }
 func (*Route) ProtoMessage()    {}
 func (*Route) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{20}
+	return fileDescriptor_magmad_e785115ef4250697, []int{20}
 }
 func (m *Route) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Route.Unmarshal(m, b)
@@ -1262,7 +1262,7 @@ func (m *NetworkInfo) Reset()         { *m = NetworkInfo{} }
 func (m *NetworkInfo) String() string { return proto.CompactTextString(m) }
 func (*NetworkInfo) ProtoMessage()    {}
 func (*NetworkInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{21}
+	return fileDescriptor_magmad_e785115ef4250697, []int{21}
 }
 func (m *NetworkInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkInfo.Unmarshal(m, b)
@@ -1314,7 +1314,7 @@ func (m *CPUInfo) Reset()         { *m = CPUInfo{} }
 func (m *CPUInfo) String() string { return proto.CompactTextString(m) }
 func (*CPUInfo) ProtoMessage()    {}
 func (*CPUInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{22}
+	return fileDescriptor_magmad_e785115ef4250697, []int{22}
 }
 func (m *CPUInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CPUInfo.Unmarshal(m, b)
@@ -1376,7 +1376,7 @@ func (m *MachineInfo) Reset()         { *m = MachineInfo{} }
 func (m *MachineInfo) String() string { return proto.CompactTextString(m) }
 func (*MachineInfo) ProtoMessage()    {}
 func (*MachineInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{23}
+	return fileDescriptor_magmad_e785115ef4250697, []int{23}
 }
 func (m *MachineInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MachineInfo.Unmarshal(m, b)
@@ -1442,7 +1442,7 @@ func (m *CheckinRequest) Reset()         { *m = CheckinRequest{} }
 func (m *CheckinRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckinRequest) ProtoMessage()    {}
 func (*CheckinRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{24}
+	return fileDescriptor_magmad_e785115ef4250697, []int{24}
 }
 func (m *CheckinRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckinRequest.Unmarshal(m, b)
@@ -1541,7 +1541,7 @@ func (m *CheckinResponse) Reset()         { *m = CheckinResponse{} }
 func (m *CheckinResponse) String() string { return proto.CompactTextString(m) }
 func (*CheckinResponse) ProtoMessage()    {}
 func (*CheckinResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{25}
+	return fileDescriptor_magmad_e785115ef4250697, []int{25}
 }
 func (m *CheckinResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckinResponse.Unmarshal(m, b)
@@ -1591,7 +1591,7 @@ func (m *GatewayStatus) Reset()         { *m = GatewayStatus{} }
 func (m *GatewayStatus) String() string { return proto.CompactTextString(m) }
 func (*GatewayStatus) ProtoMessage()    {}
 func (*GatewayStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{26}
+	return fileDescriptor_magmad_e785115ef4250697, []int{26}
 }
 func (m *GatewayStatus) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GatewayStatus.Unmarshal(m, b)
@@ -1646,7 +1646,7 @@ func (m *GatewayStatusRequest) Reset()         { *m = GatewayStatusRequest{} }
 func (m *GatewayStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*GatewayStatusRequest) ProtoMessage()    {}
 func (*GatewayStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_magmad_2d8fc06d9226d13d, []int{27}
+	return fileDescriptor_magmad_e785115ef4250697, []int{27}
 }
 func (m *GatewayStatusRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GatewayStatusRequest.Unmarshal(m, b)
@@ -2145,6 +2145,9 @@ type CheckindClient interface {
 	// Returns a list of all logical gateway IDs for the given network which have
 	// status stored in the service DB
 	List(ctx context.Context, in *NetworkID, opts ...grpc.CallOption) (*IDList, error)
+	// Returns when the calling gateway should renew the certificate it called
+	// with
+	GetRenewalAdvice(ctx context.Context, in *Void, opts ...grpc.CallOption) (*RenewalAdvice, error)
 }
 
 type checkindClient struct {
@@ -2200,6 +2203,15 @@ func (c *checkindClient) List(ctx context.Context, in *NetworkID, opts ...grpc.C
 	return out, nil
 }
 
+func (c *checkindClient) GetRenewalAdvice(ctx context.Context, in *Void, opts ...grpc.CallOption) (*RenewalAdvice, error) {
+	out := new(RenewalAdvice)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.Checkind/GetRenewalAdvice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CheckindServer is the server API for Checkind service.
 type CheckindServer interface {
 	// Gateway periodic checkin - records given GW status to the GW's network table
@@ -2213,6 +2225,9 @@ type CheckindServer interface {
 	// Returns a list of all logical gateway IDs for the given network which have
 	// status stored in the service DB
 	List(context.Context, *NetworkID) (*IDList, error)
+	// Returns when the calling gateway should renew the certificate it called
+	// with
+	GetRenewalAdvice(context.Context, *Void) (*RenewalAdvice, error)
 }
 
 func RegisterCheckindServer(s *grpc.Server, srv CheckindServer) {
@@ -2309,6 +2324,24 @@ func _Checkind_List_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Checkind_GetRenewalAdvice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckindServer).GetRenewalAdvice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.Checkind/GetRenewalAdvice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckindServer).GetRenewalAdvice(ctx, req.(*Void))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Checkind_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.orc8r.Checkind",
 	HandlerType: (*CheckindServer)(nil),
@@ -2333,141 +2366,147 @@ var _Checkind_serviceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _Checkind_List_Handler,
 		},
+		{
+			MethodName: "GetRenewalAdvice",
+			Handler:    _Checkind_GetRenewalAdvice_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "orc8r/protos/magmad.proto",
 }
 
-func init() { proto.RegisterFile("orc8r/protos/magmad.proto", fileDescriptor_magmad_2d8fc06d9226d13d) }
-
-var fileDescriptor_magmad_2d8fc06d9226d13d = []byte{
-	// 2046 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0xcd, 0x6e, 0x1b, 0xc9,
-	0x11, 0xd6, 0x50, 0xfc, 0x11, 0x8b, 0x3f, 0xa2, 0xdb, 0x5a, 0x2f, 0x4d, 0x5b, 0xb0, 0x3d, 0xce,
-	0x8f, 0x17, 0xd9, 0x48, 0x86, 0xbc, 0xde, 0x5d, 0xec, 0x2e, 0x1c, 0xc8, 0x92, 0x6c, 0x13, 0x96,
-	0x64, 0xa2, 0x49, 0x7b, 0x91, 0xbd, 0x0c, 0x5a, 0x33, 0x4d, 0x6a, 0x20, 0xce, 0xf4, 0xa4, 0xbb,
-	0x49, 0xdb, 0xc8, 0x35, 0xc9, 0x29, 0xc7, 0x24, 0x40, 0x5e, 0x22, 0x87, 0x9c, 0xf2, 0x24, 0x79,
-	0x82, 0xe4, 0x0d, 0xf2, 0x00, 0x41, 0xff, 0xcc, 0x70, 0x86, 0xa6, 0x14, 0x27, 0x39, 0x71, 0xba,
-	0xfe, 0xba, 0xaa, 0xba, 0xea, 0xab, 0x6e, 0xc2, 0x4d, 0xc6, 0xfd, 0xaf, 0xf9, 0x6e, 0xc2, 0x99,
-	0x64, 0x62, 0x37, 0x22, 0x93, 0x88, 0x04, 0x3b, 0x7a, 0x85, 0x1a, 0x7a, 0xb5, 0xa3, 0x05, 0x7a,
-	0x45, 0x39, 0x9f, 0x45, 0x11, 0x8b, 0x8d, 0x5c, 0xaf, 0x57, 0x34, 0xe1, 0xb3, 0x78, 0x1c, 0x4e,
-	0x2c, 0x6f, 0xbb, 0xc0, 0x13, 0x94, 0xcf, 0x43, 0x9f, 0x3e, 0x7a, 0xf8, 0xc8, 0xb2, 0x6f, 0x4f,
-	0x18, 0x9b, 0x4c, 0xa9, 0xe1, 0x9f, 0xcd, 0xc6, 0xbb, 0x42, 0xf2, 0x99, 0x2f, 0x0d, 0xd7, 0x7d,
-	0x09, 0x30, 0x08, 0xe3, 0xc9, 0x80, 0x70, 0x12, 0x09, 0x74, 0x1b, 0xe0, 0x9c, 0x09, 0xe9, 0x31,
-	0xee, 0x85, 0x49, 0xd7, 0xb9, 0xeb, 0x3c, 0xa8, 0xe3, 0x0d, 0x45, 0x79, 0xc5, 0xfb, 0x09, 0xba,
-	0x03, 0x8d, 0x78, 0x16, 0x79, 0x09, 0xf1, 0x2f, 0xa8, 0x14, 0xdd, 0xd2, 0x5d, 0xe7, 0x41, 0x05,
-	0x43, 0x3c, 0x8b, 0x06, 0x86, 0xe2, 0xce, 0xa0, 0x33, 0xe2, 0xc4, 0xa7, 0x9c, 0xcd, 0x24, 0xfd,
-	0x28, 0x93, 0x37, 0x61, 0x23, 0x22, 0xef, 0xbc, 0x73, 0x96, 0xa4, 0xf6, 0x6a, 0x11, 0x79, 0xf7,
-	0x82, 0x25, 0x02, 0x3d, 0x80, 0xce, 0xd9, 0x7b, 0x49, 0x85, 0x97, 0x50, 0x6e, 0xf7, 0xec, 0xae,
-	0x6b, 0x91, 0xb6, 0xa6, 0x0f, 0x28, 0x37, 0xfb, 0xba, 0xbf, 0x71, 0x00, 0x9d, 0x52, 0xf9, 0x96,
-	0xf1, 0x8b, 0x11, 0x15, 0x12, 0xd3, 0x5f, 0xcd, 0xa8, 0x90, 0xe8, 0xe7, 0x50, 0x49, 0xc2, 0x78,
-	0x22, 0xba, 0xce, 0xdd, 0xf5, 0x07, 0x8d, 0xbd, 0x4f, 0x77, 0x72, 0xb9, 0xde, 0x59, 0x04, 0x8d,
-	0x8d, 0x14, 0xfa, 0x05, 0x34, 0x64, 0xe6, 0xbc, 0xf2, 0x46, 0x29, 0x6d, 0x17, 0x94, 0x96, 0x83,
-	0xc3, 0x79, 0x0d, 0xf7, 0x9f, 0x8e, 0xc9, 0x25, 0xa6, 0x62, 0x36, 0x95, 0xff, 0x67, 0x2e, 0xd1,
-	0x16, 0x54, 0x28, 0xe7, 0x8c, 0xeb, 0x98, 0xeb, 0xd8, 0x2c, 0xd0, 0x2e, 0x5c, 0xb7, 0x2a, 0x9e,
-	0xe4, 0x24, 0x16, 0x51, 0x28, 0x25, 0x0d, 0xba, 0x65, 0xad, 0x8e, 0x2c, 0x6b, 0xb4, 0xe0, 0xa0,
-	0xcf, 0xa0, 0x93, 0x2a, 0x70, 0xea, 0xd3, 0x70, 0x4e, 0x83, 0x6e, 0x45, 0x4b, 0x6f, 0x5a, 0x3a,
-	0xb6, 0x64, 0xf4, 0x13, 0xd8, 0x24, 0xf3, 0x89, 0xc7, 0xa9, 0x48, 0x58, 0x2c, 0xa8, 0x17, 0x89,
-	0x6e, 0xf5, 0xae, 0xf3, 0xa0, 0x84, 0x5b, 0x64, 0xae, 0x82, 0xd2, 0xd4, 0x13, 0xe1, 0x8e, 0x60,
-	0x33, 0x97, 0x08, 0xce, 0xce, 0x28, 0xea, 0x81, 0x8e, 0x2c, 0x26, 0x11, 0xcd, 0x47, 0xaa, 0xd6,
-	0xa8, 0x0d, 0xa5, 0x30, 0xd1, 0x01, 0xd6, 0x71, 0x29, 0x4c, 0xd0, 0x27, 0x50, 0xe5, 0x52, 0x2a,
-	0xeb, 0xeb, 0xda, 0x7a, 0x85, 0x4b, 0x79, 0x22, 0xdc, 0xef, 0xa1, 0xb5, 0xb0, 0xfa, 0x82, 0x25,
-	0xa8, 0x03, 0xeb, 0x61, 0xf0, 0x4e, 0x9b, 0xab, 0x60, 0xf5, 0x89, 0xbe, 0x80, 0x6a, 0xa2, 0xb6,
-	0x4b, 0x0f, 0xe7, 0xf6, 0x65, 0x87, 0xa3, 0x84, 0xb0, 0x95, 0x75, 0xe7, 0xf9, 0xa2, 0xb4, 0x67,
-	0x93, 0x25, 0xd7, 0xc9, 0x27, 0xb7, 0x78, 0x62, 0xa5, 0xa5, 0x13, 0xdb, 0x81, 0xb2, 0x2e, 0xd3,
-	0x75, 0xbd, 0x77, 0xef, 0x92, 0xbd, 0x5f, 0xb0, 0x04, 0x6b, 0x39, 0xf7, 0xb7, 0x0e, 0x5c, 0x2f,
-	0x54, 0xa5, 0x49, 0xe0, 0x7f, 0x2e, 0x4b, 0xe3, 0xe3, 0xff, 0x54, 0x96, 0x56, 0xb5, 0x50, 0x96,
-	0x8f, 0x61, 0xeb, 0x39, 0x95, 0xcf, 0x89, 0xa4, 0x6f, 0xc9, 0xfb, 0x7e, 0x90, 0xf9, 0xb1, 0x0d,
-	0x30, 0x31, 0x44, 0x2f, 0x0c, 0x6c, 0x22, 0xea, 0x93, 0x54, 0xcc, 0xfd, 0x02, 0x6e, 0x60, 0x2a,
-	0x24, 0xe1, 0x72, 0x68, 0x10, 0x45, 0xa4, 0x7d, 0xd5, 0x83, 0x0d, 0x0b, 0x32, 0x26, 0x86, 0x3a,
-	0xce, 0xd6, 0x2e, 0x51, 0x9b, 0xc5, 0x94, 0x87, 0xfe, 0x01, 0x8b, 0x22, 0x12, 0x07, 0x16, 0x05,
-	0xba, 0x50, 0xf3, 0x0d, 0xc1, 0xee, 0x94, 0x2e, 0xd1, 0x2e, 0x54, 0x13, 0x2d, 0xa3, 0x13, 0xae,
-	0xf2, 0x61, 0xf0, 0x6a, 0x27, 0xc5, 0xab, 0x9d, 0xa1, 0xc6, 0x2b, 0x6c, 0xc5, 0xdc, 0x13, 0xb8,
-	0x51, 0xdc, 0x22, 0x8b, 0xe8, 0x11, 0x6c, 0xa4, 0xc5, 0xab, 0x77, 0xb9, 0xc2, 0x58, 0x26, 0xe8,
-	0xfe, 0x0c, 0x36, 0x47, 0x24, 0x9c, 0x1e, 0xb3, 0x49, 0x16, 0x60, 0x17, 0x6a, 0x36, 0xa0, 0xd4,
-	0x59, 0xbb, 0x74, 0xb7, 0xa1, 0x76, 0xcc, 0x26, 0xc7, 0x61, 0x4c, 0x11, 0x82, 0xf2, 0x34, 0x8c,
-	0x53, 0x09, 0xfd, 0xed, 0xfe, 0xce, 0x81, 0xd6, 0x61, 0x28, 0x2e, 0x06, 0x84, 0xcb, 0x50, 0x86,
-	0x2c, 0x46, 0x37, 0xa0, 0x1a, 0xd0, 0x9c, 0x25, 0xbb, 0x52, 0xed, 0x1f, 0xb1, 0x59, 0x2c, 0xbd,
-	0x84, 0x85, 0xb1, 0xb4, 0xb5, 0x06, 0x9a, 0x34, 0x50, 0x14, 0x55, 0xa1, 0x92, 0x49, 0x32, 0xd5,
-	0x4d, 0x52, 0xc6, 0x66, 0xa1, 0x36, 0x9d, 0x09, 0xdb, 0xef, 0x65, 0xac, 0xbf, 0x15, 0x6d, 0xcc,
-	0x29, 0xd5, 0x5d, 0x5d, 0xc6, 0xfa, 0xdb, 0xfd, 0xcb, 0x3a, 0x34, 0x87, 0xef, 0x85, 0xa4, 0xd1,
-	0x50, 0x12, 0x39, 0x13, 0x4a, 0x48, 0x86, 0xb6, 0x39, 0xcb, 0x58, 0x7f, 0x2b, 0xec, 0xf5, 0x93,
-	0x99, 0x37, 0x13, 0x94, 0x5b, 0xe5, 0x9a, 0x9f, 0xcc, 0x5e, 0x0b, 0xca, 0x55, 0x6d, 0x28, 0x96,
-	0xd0, 0x26, 0x34, 0x0a, 0x94, 0x71, 0xdd, 0x4f, 0x66, 0xc6, 0x66, 0xaa, 0x19, 0x06, 0x53, 0xda,
-	0xad, 0x65, 0x9a, 0xfd, 0x60, 0x4a, 0xd1, 0x2d, 0xa8, 0x47, 0x34, 0xf2, 0x8c, 0xef, 0xa0, 0x79,
-	0x1b, 0x11, 0x8d, 0x46, 0xda, 0xfd, 0xfb, 0xd0, 0x52, 0x4c, 0x32, 0x27, 0xe1, 0x94, 0x9c, 0x4d,
-	0x69, 0xb7, 0xa1, 0x05, 0x9a, 0x11, 0x8d, 0xf6, 0x53, 0x9a, 0x1e, 0x09, 0x34, 0xf2, 0x74, 0x9c,
-	0x4d, 0x63, 0x3c, 0xa2, 0xd1, 0x6b, 0x15, 0xaa, 0x65, 0xe9, 0x70, 0x5b, 0x19, 0xeb, 0x19, 0xa7,
-	0xba, 0x9a, 0xc5, 0x5b, 0x92, 0xd8, 0x8d, 0x37, 0x8d, 0xc7, 0x8a, 0x62, 0x76, 0xbe, 0x05, 0x7a,
-	0x61, 0xac, 0x76, 0x8c, 0x5b, 0x8a, 0xa0, 0xcd, 0xa6, 0x4c, 0x6d, 0xf7, 0xda, 0x82, 0xa9, 0x0d,
-	0xdf, 0x81, 0xc6, 0x2c, 0x51, 0xf9, 0xf2, 0x04, 0xf5, 0x45, 0xb7, 0xad, 0xd9, 0x60, 0x48, 0x43,
-	0xea, 0x0b, 0x74, 0x00, 0x9b, 0x41, 0x28, 0x2e, 0xbc, 0x24, 0x3d, 0x74, 0xd1, 0x45, 0x2b, 0x20,
-	0xa2, 0x50, 0x17, 0xb8, 0x1d, 0xe4, 0x97, 0xc2, 0xfd, 0x0a, 0x6a, 0x0a, 0xf8, 0xc9, 0x44, 0x17,
-	0x56, 0x0e, 0x47, 0xf5, 0xb7, 0xaa, 0xc8, 0x39, 0xe5, 0x22, 0x64, 0xb1, 0x2d, 0x95, 0x74, 0xe9,
-	0x7e, 0x03, 0x70, 0xa0, 0x2f, 0x03, 0xfd, 0x78, 0xcc, 0xd0, 0xe7, 0x80, 0xec, 0xdd, 0xc0, 0xf3,
-	0x39, 0x25, 0x92, 0x06, 0x1e, 0x91, 0xf6, 0xd0, 0x3b, 0x96, 0x73, 0x60, 0x18, 0xfb, 0xd2, 0xfd,
-	0x97, 0x03, 0xcd, 0xc1, 0x94, 0xc8, 0x31, 0xe3, 0x91, 0x56, 0xff, 0x04, 0xaa, 0xf3, 0x24, 0x5e,
-	0x8c, 0xab, 0xca, 0x3c, 0x89, 0xfb, 0x09, 0x7a, 0x08, 0x1b, 0x89, 0x71, 0x2e, 0xc5, 0x9f, 0xad,
-	0x22, 0x68, 0x19, 0x26, 0xce, 0xa4, 0xd0, 0x8f, 0xa1, 0x7d, 0x41, 0x79, 0x4c, 0xa7, 0x5e, 0xea,
-	0xb6, 0x99, 0x62, 0x2d, 0x43, 0x7d, 0x63, 0x88, 0xe8, 0x1b, 0xb8, 0x59, 0x14, 0x13, 0x5e, 0x18,
-	0x0b, 0x49, 0xa6, 0x53, 0x5d, 0xe3, 0x0a, 0x5a, 0x3e, 0x2d, 0x68, 0x88, 0x7e, 0xca, 0x46, 0x5f,
-	0x43, 0xc3, 0x46, 0x1a, 0xc6, 0x63, 0xa6, 0x0b, 0x78, 0x19, 0x4c, 0x17, 0x89, 0xc1, 0xe0, 0x67,
-	0xdf, 0xee, 0x9f, 0x4a, 0xd0, 0xb1, 0xc0, 0xdc, 0x8f, 0x25, 0xe5, 0x63, 0xe2, 0x53, 0xf4, 0x10,
-	0xb6, 0x62, 0x43, 0xf3, 0xc2, 0x94, 0xb8, 0xc0, 0x45, 0x14, 0x2f, 0xc9, 0xf7, 0x03, 0xf4, 0x1d,
-	0x54, 0x85, 0x6e, 0x2e, 0x7d, 0x24, 0xed, 0xbd, 0x1f, 0x15, 0xf6, 0x5e, 0xde, 0x60, 0xc7, 0x34,
-	0x22, 0xb6, 0x3a, 0x1a, 0x00, 0x88, 0xef, 0x91, 0x20, 0xe0, 0x54, 0x08, 0x9b, 0x1e, 0x88, 0x88,
-	0xbf, 0x6f, 0x28, 0xe8, 0x1e, 0x34, 0xc3, 0x24, 0xe5, 0x53, 0x61, 0xd3, 0xd1, 0x08, 0x93, 0xfd,
-	0x94, 0xa4, 0xb2, 0x1c, 0x26, 0xf3, 0x2f, 0x73, 0x42, 0x15, 0x2d, 0xd4, 0x52, 0xd4, 0x4c, 0xcc,
-	0xfd, 0x29, 0x54, 0x2d, 0x0a, 0x34, 0xa0, 0xf6, 0xfa, 0xf4, 0xe5, 0xe9, 0xab, 0xef, 0x4f, 0x3b,
-	0x6b, 0xa8, 0x0a, 0xa5, 0xd7, 0x83, 0x8e, 0x83, 0x36, 0xa0, 0x7c, 0xa8, 0x28, 0x25, 0xf7, 0xcf,
-	0x0e, 0x54, 0xb0, 0x1a, 0x1a, 0xca, 0x72, 0x40, 0x85, 0x0c, 0x63, 0xa2, 0xca, 0x73, 0x51, 0x10,
-	0xad, 0x1c, 0xb5, 0x9f, 0x14, 0x46, 0x48, 0x3a, 0x30, 0xb3, 0x11, 0x92, 0xa8, 0xaa, 0x9d, 0xd0,
-	0x38, 0x22, 0xe2, 0xc2, 0xc6, 0x97, 0x2e, 0x2f, 0xcd, 0x76, 0xf9, 0xb2, 0x6c, 0xbb, 0x7f, 0x70,
-	0xa0, 0x91, 0xe5, 0x74, 0xcc, 0xd0, 0x31, 0xa0, 0x0f, 0x2c, 0xa4, 0x23, 0x75, 0xfb, 0xca, 0x93,
-	0xc0, 0xd7, 0x96, 0xcd, 0x0b, 0xf4, 0x15, 0xb4, 0xd4, 0xb4, 0x0c, 0xe3, 0x89, 0x27, 0x35, 0x30,
-	0x99, 0x32, 0x47, 0x05, 0x43, 0x3a, 0x35, 0xb8, 0x69, 0x05, 0x47, 0x4a, 0xce, 0xfd, 0xa3, 0x03,
-	0xb5, 0x83, 0xc1, 0x6b, 0xed, 0x92, 0x02, 0x4d, 0xc6, 0xa9, 0xe7, 0x2b, 0x14, 0xb7, 0x4d, 0x57,
-	0x57, 0x94, 0x03, 0x45, 0x50, 0xf7, 0x59, 0x79, 0xce, 0x29, 0x09, 0xcc, 0x8d, 0x56, 0x31, 0x74,
-	0xca, 0xca, 0xb8, 0x6d, 0xe9, 0x03, 0xca, 0x0f, 0x18, 0xa7, 0xc8, 0x85, 0x26, 0xe1, 0xfe, 0x79,
-	0x28, 0xa9, 0x2f, 0x67, 0x9c, 0xda, 0xe4, 0x15, 0x68, 0x6a, 0xb3, 0x88, 0x05, 0x74, 0xea, 0x69,
-	0xac, 0x30, 0x79, 0xab, 0x6b, 0xca, 0x29, 0x89, 0xa8, 0xfb, 0x6b, 0x68, 0x9c, 0x10, 0xff, 0x3c,
-	0x8c, 0xa9, 0x76, 0x6d, 0xd7, 0x02, 0xb6, 0xea, 0x14, 0x33, 0x19, 0x8b, 0x1d, 0x6c, 0x43, 0x30,
-	0x30, 0xae, 0x14, 0xbe, 0x85, 0xe6, 0x22, 0xbd, 0x63, 0x66, 0x67, 0x73, 0x77, 0x75, 0x62, 0xc7,
-	0x0c, 0x37, 0xe2, 0xc5, 0xc2, 0xfd, 0xfb, 0x3a, 0xb4, 0x0f, 0xce, 0xa9, 0x7f, 0x11, 0xc6, 0xe9,
-	0x48, 0xbd, 0xfa, 0xb2, 0x81, 0xf6, 0xb2, 0x5e, 0x5a, 0xd7, 0x1b, 0x15, 0xa1, 0xd3, 0x5e, 0x40,
-	0x96, 0x3a, 0xe8, 0x09, 0xb4, 0xcc, 0x7c, 0xf2, 0xac, 0x6a, 0x59, 0xab, 0xde, 0x2c, 0xaa, 0xe6,
-	0x86, 0x20, 0x6e, 0x8a, 0xfc, 0x48, 0x7c, 0x02, 0xad, 0xc4, 0x82, 0x9f, 0x89, 0x71, 0x63, 0x85,
-	0x7e, 0x1e, 0x1e, 0x71, 0x33, 0xc9, 0x83, 0xe5, 0xb7, 0xd0, 0x8c, 0x4c, 0x8a, 0x8d, 0x7a, 0x7d,
-	0x45, 0x8a, 0x72, 0x67, 0x80, 0x1b, 0x51, 0xee, 0x40, 0x76, 0xe0, 0x9a, 0x96, 0xf3, 0x92, 0x8b,
-	0x89, 0x57, 0x80, 0xf6, 0xa7, 0xa5, 0xae, 0x83, 0x37, 0x35, 0x73, 0x70, 0x31, 0x49, 0x91, 0xf2,
-	0x66, 0x86, 0xcc, 0x95, 0x4c, 0xc8, 0xa2, 0xf3, 0x67, 0x1f, 0x60, 0x6d, 0x35, 0x13, 0x59, 0xc2,
-	0xdb, 0x27, 0x57, 0xe1, 0x6d, 0x4d, 0x61, 0x87, 0xd6, 0xba, 0x0c, 0x73, 0xdd, 0xbf, 0x3a, 0xb0,
-	0x99, 0x1d, 0xac, 0xbd, 0x74, 0x1d, 0x42, 0x95, 0xf8, 0x0a, 0x0f, 0xf4, 0xa9, 0xb6, 0xf7, 0x3e,
-	0x2f, 0x16, 0x56, 0x51, 0x7a, 0xc7, 0xd6, 0x03, 0x0d, 0xf6, 0xb5, 0x0e, 0xb6, 0xba, 0xd9, 0xfd,
-	0xa4, 0xb4, 0xb8, 0x9f, 0xb8, 0x47, 0xb0, 0xb9, 0x24, 0xae, 0xb0, 0xea, 0xf4, 0xd5, 0xe9, 0x51,
-	0x67, 0x0d, 0x6d, 0x41, 0x07, 0x1f, 0x0d, 0x47, 0xfb, 0x78, 0xe4, 0x0d, 0x8f, 0xf0, 0x9b, 0xfe,
-	0xc1, 0xd1, 0xb0, 0xe3, 0x20, 0x04, 0xed, 0x8c, 0xfa, 0xcb, 0xe1, 0xe8, 0xe8, 0xa4, 0x53, 0x72,
-	0x7f, 0xef, 0x40, 0xcb, 0xde, 0x7e, 0xaf, 0xb8, 0x0c, 0x3d, 0x86, 0x9a, 0x6f, 0x7c, 0xb5, 0xb5,
-	0x7e, 0x6b, 0x75, 0x1c, 0xda, 0x1f, 0x9c, 0xca, 0x2a, 0x20, 0xf3, 0x29, 0x97, 0x1e, 0x7d, 0x97,
-	0x84, 0xdc, 0x80, 0xa5, 0x36, 0xad, 0xca, 0x78, 0x1d, 0x23, 0xc5, 0x3b, 0xca, 0x58, 0x23, 0x15,
-	0xd5, 0x08, 0xb6, 0x0a, 0xde, 0xe4, 0x3a, 0x24, 0xeb, 0xb8, 0xac, 0x43, 0xd2, 0xae, 0x0a, 0x14,
-	0x7b, 0xca, 0x26, 0xa1, 0x4f, 0xa6, 0x8a, 0x6d, 0xa1, 0xd6, 0x52, 0xfa, 0xc1, 0xde, 0xdf, 0x2a,
-	0x50, 0x3d, 0xd1, 0x7f, 0x2c, 0x28, 0x2c, 0x1b, 0xe6, 0xaf, 0xed, 0xe8, 0x5a, 0x21, 0x92, 0x37,
-	0x2c, 0x0c, 0x7a, 0x1f, 0x92, 0xdc, 0x35, 0xf4, 0x25, 0x34, 0x87, 0x92, 0x25, 0xff, 0xb5, 0xde,
-	0x43, 0xa8, 0x62, 0x7a, 0xc6, 0x98, 0xfc, 0x68, 0x8d, 0x97, 0xea, 0x64, 0x0b, 0x6f, 0x0b, 0x74,
-	0xbf, 0x08, 0xb5, 0x2b, 0x5f, 0x1e, 0xab, 0x8d, 0x3d, 0x01, 0x18, 0x52, 0x69, 0x66, 0xbd, 0x40,
-	0xc5, 0x63, 0xb3, 0x99, 0xb6, 0xcc, 0x4b, 0xf5, 0x9f, 0x2f, 0xf4, 0x57, 0x84, 0x70, 0x95, 0x49,
-	0x77, 0x0d, 0xbd, 0x81, 0x4d, 0x3c, 0x8b, 0x73, 0x2f, 0x3d, 0x81, 0xee, 0xac, 0xc2, 0xc9, 0xdc,
-	0x5f, 0x13, 0xbd, 0xbb, 0x97, 0x0b, 0xd8, 0x67, 0xc9, 0x1a, 0x7a, 0x06, 0xcd, 0xfc, 0xbb, 0x6d,
-	0x95, 0x67, 0xf7, 0x8a, 0x9e, 0xad, 0x78, 0xe5, 0xb9, 0x6b, 0xe8, 0x07, 0x68, 0x17, 0xdf, 0x4b,
-	0x68, 0x59, 0xed, 0xc3, 0xf7, 0x5a, 0xef, 0xfe, 0x15, 0x22, 0x39, 0xdb, 0x4f, 0x61, 0x23, 0x7d,
-	0x3c, 0xa1, 0xa5, 0xd7, 0x78, 0xf1, 0x4d, 0xd5, 0x2b, 0xce, 0x1b, 0xfb, 0x88, 0x72, 0xd7, 0x1e,
-	0x3a, 0x7b, 0xff, 0x28, 0xc1, 0x86, 0x6d, 0xaf, 0x00, 0x3d, 0x83, 0x9a, 0xfd, 0x46, 0x57, 0x35,
-	0x60, 0xef, 0xf6, 0x55, 0x28, 0xe3, 0xae, 0xa1, 0x63, 0xa8, 0x3f, 0xa7, 0xd2, 0xf6, 0xfb, 0xbd,
-	0x55, 0x07, 0x58, 0xe8, 0xbe, 0x5e, 0xef, 0x72, 0x11, 0x77, 0x0d, 0x9d, 0xc0, 0xf5, 0x43, 0x3a,
-	0xa5, 0x92, 0x16, 0x71, 0xe4, 0x23, 0xec, 0xae, 0xac, 0xb8, 0xef, 0xa0, 0x65, 0xcc, 0xd9, 0x83,
-	0x47, 0x37, 0x56, 0xce, 0xd5, 0xc3, 0xd5, 0xda, 0x8f, 0xa1, 0x7c, 0x1c, 0x0a, 0x79, 0xa9, 0xd2,
-	0xf5, 0x02, 0xbd, 0x7f, 0xa8, 0x84, 0xdd, 0xb5, 0xa7, 0xdb, 0x3f, 0xdc, 0xd2, 0xf4, 0x5d, 0xf3,
-	0x6f, 0xa1, 0x3f, 0x65, 0xb3, 0x60, 0x77, 0xc2, 0xec, 0xdf, 0x86, 0x67, 0x55, 0xfd, 0xfb, 0xe8,
-	0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x60, 0xa5, 0x1f, 0x1e, 0xac, 0x14, 0x00, 0x00,
+func init() { proto.RegisterFile("orc8r/protos/magmad.proto", fileDescriptor_magmad_e785115ef4250697) }
+
+var fileDescriptor_magmad_e785115ef4250697 = []byte{
+	// 2077 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0xcd, 0x72, 0x1b, 0xb9,
+	0xf1, 0xd7, 0x88, 0x5f, 0x62, 0xf3, 0xd3, 0xb0, 0xd6, 0x4b, 0xd3, 0x56, 0xad, 0x3c, 0xfe, 0xff,
+	0x13, 0x6f, 0x65, 0x23, 0xb9, 0xe4, 0xf5, 0xee, 0xd6, 0x66, 0xcb, 0x29, 0x59, 0x92, 0x6d, 0x96,
+	0x25, 0x99, 0x05, 0xd2, 0xde, 0xca, 0x5e, 0xa6, 0xa0, 0x19, 0x90, 0x9a, 0x12, 0x67, 0x30, 0x01,
+	0x40, 0xd9, 0xae, 0x5c, 0x93, 0x9c, 0x72, 0x4c, 0x52, 0x95, 0x47, 0xc8, 0x25, 0x87, 0x9c, 0xf2,
+	0x24, 0x79, 0x83, 0xbc, 0x41, 0x1e, 0x20, 0x85, 0x8f, 0x19, 0xce, 0xd0, 0x94, 0xe2, 0x24, 0x27,
+	0x0e, 0xba, 0x7f, 0xdd, 0x40, 0x37, 0xba, 0x7f, 0x00, 0x08, 0xb7, 0x19, 0xf7, 0xbf, 0xe1, 0xbb,
+	0x09, 0x67, 0x92, 0x89, 0xdd, 0x88, 0x4c, 0x23, 0x12, 0xec, 0xe8, 0x11, 0x6a, 0xe8, 0xd1, 0x8e,
+	0x06, 0xf4, 0xef, 0x16, 0x70, 0x3e, 0xe5, 0x32, 0x9c, 0x84, 0x94, 0x1b, 0x68, 0xbf, 0xe8, 0xc5,
+	0x67, 0x51, 0xc4, 0x62, 0xab, 0xea, 0x17, 0x27, 0xf0, 0x59, 0x3c, 0x09, 0xa7, 0x56, 0xb7, 0x55,
+	0xd0, 0x09, 0xca, 0x2f, 0x43, 0x9f, 0x3e, 0x7a, 0xf8, 0xc8, 0xaa, 0xef, 0x4e, 0x19, 0x9b, 0xce,
+	0xa8, 0xd1, 0x9f, 0xcd, 0x27, 0xbb, 0x42, 0xf2, 0xb9, 0x2f, 0x8d, 0xd6, 0x7d, 0x09, 0x30, 0x0c,
+	0xe3, 0xe9, 0x90, 0x70, 0x12, 0x09, 0x74, 0x17, 0xe0, 0x9c, 0x09, 0xe9, 0x31, 0xee, 0x85, 0x49,
+	0xcf, 0xd9, 0x76, 0x1e, 0xd4, 0xf1, 0x86, 0x92, 0xbc, 0xe2, 0x83, 0x04, 0x7d, 0x06, 0x8d, 0x78,
+	0x1e, 0x79, 0x09, 0xf1, 0x2f, 0xa8, 0x14, 0xbd, 0xf5, 0x6d, 0xe7, 0x41, 0x05, 0x43, 0x3c, 0x8f,
+	0x86, 0x46, 0xe2, 0xce, 0xa1, 0x3b, 0xe6, 0xc4, 0xa7, 0x9c, 0xcd, 0x25, 0xfd, 0x28, 0x97, 0xb7,
+	0x61, 0x23, 0x22, 0xef, 0xbc, 0x73, 0x96, 0xa4, 0xfe, 0x6a, 0x11, 0x79, 0xf7, 0x82, 0x25, 0x02,
+	0x3d, 0x80, 0xee, 0xd9, 0x7b, 0x49, 0x85, 0x97, 0x50, 0x6e, 0xe7, 0xec, 0x95, 0x34, 0xa4, 0xad,
+	0xe5, 0x43, 0xca, 0xcd, 0xbc, 0xee, 0xaf, 0x1d, 0x40, 0xa7, 0x54, 0xbe, 0x65, 0xfc, 0x62, 0x4c,
+	0x85, 0xc4, 0xf4, 0x97, 0x73, 0x2a, 0x24, 0xfa, 0x29, 0x54, 0x92, 0x30, 0x9e, 0x8a, 0x9e, 0xb3,
+	0x5d, 0x7a, 0xd0, 0xd8, 0xfb, 0x74, 0x27, 0xb7, 0x13, 0x3b, 0x8b, 0xa0, 0xb1, 0x41, 0xa1, 0x9f,
+	0x43, 0x43, 0x66, 0x8b, 0x57, 0xab, 0x51, 0x46, 0x5b, 0x05, 0xa3, 0xe5, 0xe0, 0x70, 0xde, 0xc2,
+	0xfd, 0x87, 0x63, 0x72, 0x89, 0xa9, 0x98, 0xcf, 0xe4, 0xff, 0x98, 0x4b, 0xb4, 0x09, 0x15, 0xca,
+	0x39, 0xe3, 0x3a, 0xe6, 0x3a, 0x36, 0x03, 0xb4, 0x0b, 0x37, 0xad, 0x89, 0x27, 0x39, 0x89, 0x45,
+	0x14, 0x4a, 0x49, 0x83, 0x5e, 0x59, 0x9b, 0x23, 0xab, 0x1a, 0x2f, 0x34, 0xe8, 0x73, 0xe8, 0xa6,
+	0x06, 0x9c, 0xfa, 0x34, 0xbc, 0xa4, 0x41, 0xaf, 0xa2, 0xd1, 0x1d, 0x2b, 0xc7, 0x56, 0x8c, 0x7e,
+	0x04, 0x1d, 0x72, 0x39, 0xf5, 0x38, 0x15, 0x09, 0x8b, 0x05, 0xf5, 0x22, 0xd1, 0xab, 0x6e, 0x3b,
+	0x0f, 0xd6, 0x71, 0x8b, 0x5c, 0x4e, 0xb1, 0x95, 0x9e, 0x08, 0x77, 0x0c, 0x9d, 0x5c, 0x22, 0x38,
+	0x3b, 0xa3, 0xa8, 0x0f, 0x3a, 0xb2, 0x98, 0x44, 0x34, 0x1f, 0xa9, 0x1a, 0xa3, 0x36, 0xac, 0x87,
+	0x89, 0x0e, 0xb0, 0x8e, 0xd7, 0xc3, 0x04, 0x7d, 0x02, 0x55, 0x2e, 0xa5, 0xf2, 0x5e, 0xd2, 0xde,
+	0x2b, 0x5c, 0xca, 0x13, 0xe1, 0x7e, 0x0f, 0xad, 0x85, 0xd7, 0x17, 0x2c, 0x41, 0x5d, 0x28, 0x85,
+	0xc1, 0x3b, 0xed, 0xae, 0x82, 0xd5, 0x27, 0xfa, 0x12, 0xaa, 0x89, 0x9a, 0x2e, 0xdd, 0x9c, 0xbb,
+	0x57, 0x6d, 0x8e, 0x02, 0x61, 0x8b, 0x75, 0x2f, 0xf3, 0x45, 0x69, 0xf7, 0x26, 0x4b, 0xae, 0x93,
+	0x4f, 0x6e, 0x71, 0xc7, 0xd6, 0x97, 0x76, 0x6c, 0x07, 0xca, 0xba, 0x4c, 0x4b, 0x7a, 0xee, 0xfe,
+	0x15, 0x73, 0xbf, 0x60, 0x09, 0xd6, 0x38, 0xf7, 0x37, 0x0e, 0xdc, 0x2c, 0x54, 0xa5, 0x49, 0xe0,
+	0xbf, 0x2f, 0x4b, 0xb3, 0xc6, 0xff, 0xaa, 0x2c, 0xad, 0x69, 0xa1, 0x2c, 0x1f, 0xc3, 0xe6, 0x73,
+	0x2a, 0x9f, 0x13, 0x49, 0xdf, 0x92, 0xf7, 0x83, 0x20, 0x5b, 0xc7, 0x16, 0xc0, 0xd4, 0x08, 0xbd,
+	0x30, 0xb0, 0x89, 0xa8, 0x4f, 0x53, 0x98, 0xfb, 0x25, 0xdc, 0xc2, 0x54, 0x48, 0xc2, 0xe5, 0xc8,
+	0x30, 0x8a, 0x48, 0xfb, 0xaa, 0x0f, 0x1b, 0x96, 0x64, 0x4c, 0x0c, 0x75, 0x9c, 0x8d, 0x5d, 0xa2,
+	0x26, 0x8b, 0x29, 0x0f, 0xfd, 0x03, 0x16, 0x45, 0x24, 0x0e, 0x2c, 0x0b, 0xf4, 0xa0, 0xe6, 0x1b,
+	0x81, 0x9d, 0x29, 0x1d, 0xa2, 0x5d, 0xa8, 0x26, 0x1a, 0xa3, 0x13, 0xae, 0xf2, 0x61, 0xf8, 0x6a,
+	0x27, 0xe5, 0xab, 0x9d, 0x91, 0xe6, 0x2b, 0x6c, 0x61, 0xee, 0x09, 0xdc, 0x2a, 0x4e, 0x91, 0x45,
+	0xf4, 0x08, 0x36, 0xd2, 0xe2, 0xed, 0x39, 0xd7, 0x3b, 0xcb, 0x80, 0xee, 0x4f, 0xa0, 0x33, 0x26,
+	0xe1, 0xec, 0x98, 0x4d, 0xb3, 0x00, 0x7b, 0x50, 0xb3, 0x01, 0xa5, 0x8b, 0xb5, 0x43, 0x77, 0x0b,
+	0x6a, 0xc7, 0x6c, 0x7a, 0x1c, 0xc6, 0x14, 0x21, 0x28, 0xcf, 0xc2, 0x38, 0x45, 0xe8, 0x6f, 0xf7,
+	0xb7, 0x0e, 0xb4, 0x0e, 0x43, 0x71, 0x31, 0x24, 0x5c, 0x86, 0x32, 0x64, 0x31, 0xba, 0x05, 0xd5,
+	0x80, 0xe6, 0x3c, 0xd9, 0x91, 0x6a, 0xff, 0x88, 0xcd, 0x63, 0xe9, 0x25, 0x2c, 0x8c, 0xa5, 0xad,
+	0x35, 0xd0, 0xa2, 0xa1, 0x92, 0xa8, 0x0a, 0x95, 0x4c, 0x92, 0x99, 0x6e, 0x92, 0x32, 0x36, 0x03,
+	0x35, 0xe9, 0x5c, 0xd8, 0x7e, 0x2f, 0x63, 0xfd, 0xad, 0x64, 0x13, 0x4e, 0xa9, 0xee, 0xea, 0x32,
+	0xd6, 0xdf, 0xee, 0x5f, 0x4a, 0xd0, 0x1c, 0xbd, 0x17, 0x92, 0x46, 0x23, 0x49, 0xe4, 0x5c, 0x28,
+	0x90, 0x0c, 0x6d, 0x73, 0x96, 0xb1, 0xfe, 0x56, 0xdc, 0xeb, 0x27, 0x73, 0x6f, 0x2e, 0x28, 0xb7,
+	0xc6, 0x35, 0x3f, 0x99, 0xbf, 0x16, 0x94, 0xab, 0xda, 0x50, 0x2a, 0xa1, 0x5d, 0x68, 0x16, 0x28,
+	0xe3, 0xba, 0x9f, 0xcc, 0x8d, 0xcf, 0xd4, 0x32, 0x0c, 0x66, 0xb4, 0x57, 0xcb, 0x2c, 0x07, 0xc1,
+	0x8c, 0xa2, 0x3b, 0x50, 0x8f, 0x68, 0xe4, 0x99, 0xb5, 0x83, 0xd6, 0x6d, 0x44, 0x34, 0x1a, 0xeb,
+	0xe5, 0xdf, 0x87, 0x96, 0x52, 0x92, 0x4b, 0x12, 0xce, 0xc8, 0xd9, 0x8c, 0xf6, 0x1a, 0x1a, 0xd0,
+	0x8c, 0x68, 0xb4, 0x9f, 0xca, 0xf4, 0x91, 0x40, 0x23, 0x4f, 0xc7, 0xd9, 0x34, 0xce, 0x23, 0x1a,
+	0xbd, 0x56, 0xa1, 0x5a, 0x95, 0x0e, 0xb7, 0x95, 0xa9, 0x9e, 0x71, 0xaa, 0xab, 0x59, 0xbc, 0x25,
+	0x89, 0x9d, 0xb8, 0x63, 0x56, 0xac, 0x24, 0x66, 0xe6, 0x3b, 0xa0, 0x07, 0xc6, 0x6b, 0xd7, 0x2c,
+	0x4b, 0x09, 0xb4, 0xdb, 0x54, 0xa9, 0xfd, 0xde, 0x58, 0x28, 0xb5, 0xe3, 0xcf, 0xa0, 0x31, 0x4f,
+	0x54, 0xbe, 0x3c, 0x41, 0x7d, 0xd1, 0x6b, 0x6b, 0x35, 0x18, 0xd1, 0x88, 0xfa, 0x02, 0x1d, 0x40,
+	0x27, 0x08, 0xc5, 0x85, 0x97, 0xa4, 0x9b, 0x2e, 0x7a, 0x68, 0x05, 0x45, 0x14, 0xea, 0x02, 0xb7,
+	0x83, 0xfc, 0x50, 0xb8, 0x5f, 0x43, 0x4d, 0x11, 0x3f, 0x99, 0xea, 0xc2, 0xca, 0xf1, 0xa8, 0xfe,
+	0x56, 0x15, 0x79, 0x49, 0xb9, 0x08, 0x59, 0x6c, 0x4b, 0x25, 0x1d, 0xba, 0xdf, 0x02, 0x1c, 0xe8,
+	0xcb, 0xc0, 0x20, 0x9e, 0x30, 0xf4, 0x05, 0x20, 0x7b, 0x37, 0xf0, 0x7c, 0x4e, 0x89, 0xa4, 0x81,
+	0x47, 0xa4, 0xdd, 0xf4, 0xae, 0xd5, 0x1c, 0x18, 0xc5, 0xbe, 0x74, 0xff, 0xe9, 0x40, 0x73, 0x38,
+	0x23, 0x72, 0xc2, 0x78, 0xa4, 0xcd, 0x3f, 0x81, 0xea, 0x65, 0x12, 0x2f, 0x8e, 0xab, 0xca, 0x65,
+	0x12, 0x0f, 0x12, 0xf4, 0x10, 0x36, 0x12, 0xb3, 0xb8, 0x94, 0x7f, 0x36, 0x8b, 0xa4, 0x65, 0x94,
+	0x38, 0x43, 0xa1, 0xff, 0x87, 0xf6, 0x05, 0xe5, 0x31, 0x9d, 0x79, 0xe9, 0xb2, 0xcd, 0x29, 0xd6,
+	0x32, 0xd2, 0x37, 0x46, 0x88, 0xbe, 0x85, 0xdb, 0x45, 0x98, 0xf0, 0xc2, 0x58, 0x48, 0x32, 0x9b,
+	0xe9, 0x1a, 0x57, 0xd4, 0xf2, 0x69, 0xc1, 0x42, 0x0c, 0x52, 0x35, 0xfa, 0x06, 0x1a, 0x36, 0xd2,
+	0x30, 0x9e, 0xb0, 0x5e, 0xc5, 0xf6, 0x7b, 0x7e, 0x5d, 0x8b, 0xc4, 0x60, 0xf0, 0xb3, 0x6f, 0xf7,
+	0x8f, 0xeb, 0xd0, 0xb5, 0xc4, 0x3c, 0x88, 0x25, 0xe5, 0x13, 0xe2, 0x53, 0xf4, 0x10, 0x36, 0x63,
+	0x23, 0xf3, 0xc2, 0x54, 0xb8, 0xe0, 0x45, 0x14, 0x2f, 0xe1, 0x07, 0x01, 0xfa, 0x0e, 0xaa, 0x42,
+	0x37, 0x97, 0xde, 0x92, 0xf6, 0xde, 0xff, 0x15, 0xe6, 0x5e, 0x9e, 0x60, 0xc7, 0x34, 0x22, 0xb6,
+	0x36, 0x9a, 0x00, 0x88, 0xef, 0x91, 0x20, 0xe0, 0x54, 0x08, 0x9b, 0x1e, 0x88, 0x88, 0xbf, 0x6f,
+	0x24, 0xe8, 0x1e, 0x34, 0xc3, 0x24, 0xd5, 0x53, 0x61, 0xd3, 0xd1, 0x08, 0x93, 0xfd, 0x54, 0xa4,
+	0xb2, 0x1c, 0x26, 0x97, 0x5f, 0xe5, 0x40, 0x15, 0x0d, 0x6a, 0x29, 0x69, 0x06, 0x73, 0x7f, 0x0c,
+	0x55, 0xcb, 0x02, 0x0d, 0xa8, 0xbd, 0x3e, 0x7d, 0x79, 0xfa, 0xea, 0xfb, 0xd3, 0xee, 0x1a, 0xaa,
+	0xc2, 0xfa, 0xeb, 0x61, 0xd7, 0x41, 0x1b, 0x50, 0x3e, 0x54, 0x92, 0x75, 0xf7, 0x4f, 0x0e, 0x54,
+	0xb0, 0x3a, 0x34, 0x94, 0xe7, 0x80, 0x0a, 0x19, 0xc6, 0x44, 0x95, 0xe7, 0xa2, 0x20, 0x5a, 0x39,
+	0xe9, 0x20, 0x29, 0x1c, 0x21, 0xe9, 0x81, 0x99, 0x1d, 0x21, 0x89, 0xaa, 0xda, 0x29, 0x8d, 0x23,
+	0x22, 0x2e, 0x6c, 0x7c, 0xe9, 0xf0, 0xca, 0x6c, 0x97, 0xaf, 0xca, 0xb6, 0xfb, 0x7b, 0x07, 0x1a,
+	0x59, 0x4e, 0x27, 0x0c, 0x1d, 0x03, 0xfa, 0xc0, 0x43, 0x7a, 0xa4, 0x6e, 0x5d, 0xbb, 0x13, 0xf8,
+	0xc6, 0xb2, 0x7b, 0x81, 0xbe, 0x86, 0x96, 0x3a, 0x2d, 0xc3, 0x78, 0xea, 0x49, 0x4d, 0x4c, 0xa6,
+	0xcc, 0x51, 0xc1, 0x91, 0x4e, 0x0d, 0x6e, 0x5a, 0xe0, 0x58, 0xe1, 0xdc, 0x3f, 0x38, 0x50, 0x3b,
+	0x18, 0xbe, 0xd6, 0x4b, 0x52, 0xa4, 0xc9, 0x38, 0xf5, 0x7c, 0xc5, 0xe2, 0xb6, 0xe9, 0xea, 0x4a,
+	0x72, 0xa0, 0x04, 0xea, 0x3e, 0x2b, 0xcf, 0x39, 0x25, 0x81, 0xb9, 0xd1, 0x2a, 0x85, 0x4e, 0x59,
+	0x19, 0xb7, 0xad, 0x7c, 0x48, 0xf9, 0x01, 0xe3, 0x14, 0xb9, 0xd0, 0x24, 0xdc, 0x3f, 0x0f, 0x25,
+	0xf5, 0xe5, 0x9c, 0x53, 0x9b, 0xbc, 0x82, 0x4c, 0x4d, 0x16, 0xb1, 0x80, 0xce, 0x3c, 0xcd, 0x15,
+	0x26, 0x6f, 0x75, 0x2d, 0x39, 0x25, 0x11, 0x75, 0x7f, 0x05, 0x8d, 0x13, 0xe2, 0x9f, 0x87, 0x31,
+	0xd5, 0x4b, 0xdb, 0xb5, 0x84, 0xad, 0x3a, 0xc5, 0x9c, 0x8c, 0xc5, 0x0e, 0xb6, 0x21, 0x18, 0x1a,
+	0x57, 0x06, 0x3f, 0x83, 0xe6, 0x22, 0xbd, 0x13, 0x66, 0xcf, 0xe6, 0xde, 0xea, 0xc4, 0x4e, 0x18,
+	0x6e, 0xc4, 0x8b, 0x81, 0xfb, 0xf7, 0x12, 0xb4, 0x0f, 0xce, 0xa9, 0x7f, 0x11, 0xc6, 0xe9, 0x91,
+	0x7a, 0xfd, 0x65, 0x03, 0xed, 0x65, 0xbd, 0x54, 0xda, 0x76, 0x3e, 0xa0, 0x4e, 0x7b, 0x01, 0x59,
+	0xea, 0xa0, 0x27, 0xd0, 0x32, 0xe7, 0x93, 0x67, 0x4d, 0xcb, 0xda, 0xf4, 0x76, 0xd1, 0x34, 0x77,
+	0x08, 0xe2, 0xa6, 0xc8, 0x8d, 0x94, 0x7d, 0x62, 0xc9, 0xcf, 0xc4, 0xb8, 0xb1, 0xc2, 0x3e, 0x4f,
+	0x8f, 0xb8, 0x99, 0xe4, 0x46, 0x2a, 0x45, 0x91, 0x49, 0xb1, 0x31, 0xaf, 0xaf, 0x48, 0x51, 0x6e,
+	0x0f, 0x70, 0x23, 0x5a, 0x0c, 0xd0, 0x0e, 0xdc, 0xd0, 0x38, 0x2f, 0xb9, 0x98, 0x7a, 0x05, 0x6a,
+	0x7f, 0xba, 0xde, 0x73, 0x70, 0x47, 0x2b, 0x87, 0x17, 0xd3, 0x94, 0x29, 0x6f, 0x67, 0xcc, 0x5c,
+	0xc9, 0x40, 0x96, 0x9d, 0x3f, 0xff, 0x80, 0x6b, 0xab, 0x19, 0x64, 0x89, 0x6f, 0x9f, 0x5c, 0xc7,
+	0xb7, 0xb5, 0xed, 0x92, 0xb5, 0xba, 0x8a, 0x73, 0xdd, 0xbf, 0x3a, 0xd0, 0xc9, 0x36, 0xd6, 0x5e,
+	0xba, 0x0e, 0xa1, 0x4a, 0x7c, 0xc5, 0x07, 0x7a, 0x57, 0xdb, 0x7b, 0x5f, 0x14, 0x0b, 0xab, 0x88,
+	0xde, 0xb1, 0xf5, 0x40, 0x83, 0x7d, 0x6d, 0x83, 0xad, 0x6d, 0x76, 0x3f, 0x59, 0x5f, 0xdc, 0x4f,
+	0xdc, 0x23, 0xe8, 0x2c, 0xc1, 0x15, 0x57, 0x9d, 0xbe, 0x3a, 0x3d, 0xea, 0xae, 0xa1, 0x4d, 0xe8,
+	0xe2, 0xa3, 0xd1, 0x78, 0x1f, 0x8f, 0xbd, 0xd1, 0x11, 0x7e, 0x33, 0x38, 0x38, 0x1a, 0x75, 0x1d,
+	0x84, 0xa0, 0x9d, 0x49, 0x7f, 0x31, 0x1a, 0x1f, 0x9d, 0x74, 0xd7, 0xdd, 0xdf, 0x39, 0xd0, 0xb2,
+	0xb7, 0xdf, 0x6b, 0x2e, 0x43, 0x8f, 0xa1, 0xe6, 0x9b, 0xb5, 0xda, 0x5a, 0xbf, 0xb3, 0x3a, 0x0e,
+	0xbd, 0x1e, 0x9c, 0x62, 0x15, 0x91, 0xf9, 0x94, 0x4b, 0x8f, 0xbe, 0x4b, 0x42, 0x6e, 0xc8, 0x52,
+	0xbb, 0x56, 0x65, 0x5c, 0xc2, 0x48, 0xe9, 0x8e, 0x32, 0xd5, 0x58, 0x45, 0x35, 0x86, 0xcd, 0xc2,
+	0x6a, 0x72, 0x1d, 0x92, 0x75, 0x5c, 0xd6, 0x21, 0x69, 0x57, 0x05, 0x4a, 0x3d, 0x63, 0xd3, 0xd0,
+	0x27, 0x33, 0xa5, 0xb6, 0x54, 0x6b, 0x25, 0x83, 0x60, 0xef, 0x6f, 0x15, 0xa8, 0x9e, 0xe8, 0xbf,
+	0x1d, 0x14, 0x97, 0x8d, 0xf2, 0xd7, 0x76, 0x74, 0xa3, 0x10, 0xc9, 0x1b, 0x16, 0x06, 0xfd, 0x0f,
+	0x45, 0xee, 0x1a, 0xfa, 0x0a, 0x9a, 0x23, 0xc9, 0x92, 0xff, 0xd8, 0xee, 0x21, 0x54, 0x31, 0x3d,
+	0x63, 0x4c, 0x7e, 0xb4, 0xc5, 0x4b, 0xe8, 0x2c, 0xbd, 0x2d, 0xd0, 0xfd, 0x02, 0x6e, 0xf5, 0xcb,
+	0x63, 0xb5, 0xb3, 0x27, 0x00, 0x23, 0x2a, 0xcd, 0x59, 0x2f, 0x50, 0x71, 0xdb, 0x6c, 0xa6, 0xad,
+	0xf2, 0x4a, 0xfb, 0xe7, 0x0b, 0xfb, 0x15, 0x21, 0x5c, 0xe7, 0xd2, 0x5d, 0x43, 0x6f, 0xa0, 0x83,
+	0xe7, 0x71, 0xee, 0xa5, 0x27, 0xd0, 0x67, 0xab, 0x78, 0x32, 0xf7, 0xd7, 0x44, 0x7f, 0xfb, 0x6a,
+	0x80, 0x7d, 0x96, 0xac, 0xa1, 0x67, 0xd0, 0xcc, 0xbf, 0xdb, 0x56, 0xad, 0xec, 0x5e, 0x71, 0x65,
+	0x2b, 0x5e, 0x79, 0xee, 0x1a, 0xfa, 0x01, 0xda, 0xc5, 0xf7, 0x12, 0x5a, 0x36, 0xfb, 0xf0, 0xbd,
+	0xd6, 0xbf, 0x7f, 0x0d, 0x24, 0xe7, 0xfb, 0x29, 0x6c, 0xa4, 0x8f, 0x27, 0xb4, 0xf4, 0x1a, 0x2f,
+	0xbe, 0xa9, 0xfa, 0xc5, 0xf3, 0xc6, 0x3e, 0xa2, 0xdc, 0xb5, 0x87, 0xce, 0xde, 0x9f, 0x4b, 0xb0,
+	0x61, 0xdb, 0x2b, 0x40, 0xcf, 0xa0, 0x66, 0xbf, 0xd1, 0x75, 0x0d, 0xd8, 0xbf, 0x7b, 0x1d, 0xcb,
+	0xb8, 0x6b, 0xe8, 0x18, 0xea, 0xcf, 0xa9, 0xb4, 0xfd, 0x7e, 0x6f, 0xd5, 0x06, 0x16, 0xba, 0xaf,
+	0xdf, 0xbf, 0x1a, 0xe2, 0xae, 0xa1, 0x13, 0xb8, 0x79, 0x48, 0x67, 0x54, 0xd2, 0x82, 0xe2, 0x63,
+	0xfc, 0xae, 0xac, 0xb8, 0xef, 0xa0, 0x65, 0xdc, 0xd9, 0x8d, 0x47, 0xb7, 0x56, 0x9e, 0xab, 0x87,
+	0xab, 0xad, 0x1f, 0x43, 0xf9, 0x38, 0x14, 0xf2, 0x4a, 0xa3, 0x9b, 0x05, 0xf9, 0xe0, 0x50, 0x81,
+	0xdd, 0x35, 0x74, 0x00, 0xdd, 0xe7, 0x54, 0x62, 0x1a, 0xd3, 0xb7, 0x64, 0xb6, 0x1f, 0xe8, 0x57,
+	0xe8, 0x8a, 0x92, 0xea, 0x2f, 0xf5, 0x61, 0x0e, 0xee, 0xae, 0x3d, 0xdd, 0xfa, 0xe1, 0x8e, 0x56,
+	0xef, 0x6a, 0xf5, 0xae, 0x3f, 0x63, 0xf3, 0x60, 0x77, 0xca, 0xec, 0x7f, 0x8f, 0x67, 0x55, 0xfd,
+	0xfb, 0xe8, 0x5f, 0x03, 0x00, 0x89, 0x33, 0x57, 0xad, 0x0f, 0x15, 0x00, 0x00,
 }
@@ -25,7 +25,8 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 // one AG service config
 // --------------------------------------------------------------------------
 // NOTE: a service config field name (control_proxy, enodebd, etc.) must match
-//       the corresponding gateway service's name exactly
+//
+//	the corresponding gateway service's name exactly
 type GatewayConfigs struct {
 	ConfigsByKey         map[string]*any.Any     `protobuf:"bytes,10,rep,name=configs_by_key,json=configsByKey,proto3" json:"configs_by_key,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	Metadata             *GatewayConfigsMetadata `protobuf:"bytes,11,opt,name=metadata,proto3" json:"metadata,omitempty"`
@@ -38,7 +39,7 @@ func (m *GatewayConfigs) Reset()         { *m = GatewayConfigs{} }
 func (m *GatewayConfigs) String() string { return proto.CompactTextString(m) }
 func (*GatewayConfigs) ProtoMessage()    {}
 func (*GatewayConfigs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mconfig_400e6a2df47d4ae6, []int{0}
+	return fileDescriptor_mconfig_db9c5b2932c55511, []int{0}
 }
 func (m *GatewayConfigs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GatewayConfigs.Unmarshal(m, b)
@@ -75,17 +76,20 @@ func (m *GatewayConfigs) GetMetadata() *GatewayConfigsMetadata {
 // Metadata about the configs.
 type GatewayConfigsMetadata struct {
 	// Unix timestamp of Cloud at the time of config generation.
-	CreatedAt            uint64   `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	CreatedAt uint64 `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Digest of configs_by_key. Unlike created_at, the digest only changes
+	// when the content of the configs changes.
+	Digest               *GatewayConfigsDigest `protobuf:"bytes,12,opt,name=digest,proto3" json:"digest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *GatewayConfigsMetadata) Reset()         { *m = GatewayConfigsMetadata{} }
 func (m *GatewayConfigsMetadata) String() string { return proto.CompactTextString(m) }
 func (*GatewayConfigsMetadata) ProtoMessage()    {}
 func (*GatewayConfigsMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mconfig_400e6a2df47d4ae6, []int{1}
+	return fileDescriptor_mconfig_db9c5b2932c55511, []int{1}
 }
 func (m *GatewayConfigsMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GatewayConfigsMetadata.Unmarshal(m, b)
@@ -112,6 +116,55 @@ func (m *GatewayConfigsMetadata) GetCreatedAt() uint64 {
 	return 0
 }
 
+func (m *GatewayConfigsMetadata) GetDigest() *GatewayConfigsDigest {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+// Stable content digest of a gateway's configs. Also passed as extra args to
+// the mconfig streamer by gateways which already have configs, in which case
+// no update is streamed if the configs haven't changed.
+type GatewayConfigsDigest struct {
+	// Hex-encoded SHA-256 digest
+	HexDigest            string   `protobuf:"bytes,1,opt,name=hex_digest,json=hexDigest,proto3" json:"hex_digest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GatewayConfigsDigest) Reset()         { *m = GatewayConfigsDigest{} }
+func (m *GatewayConfigsDigest) String() string { return proto.CompactTextString(m) }
+func (*GatewayConfigsDigest) ProtoMessage()    {}
+func (*GatewayConfigsDigest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mconfig_db9c5b2932c55511, []int{2}
+}
+func (m *GatewayConfigsDigest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayConfigsDigest.Unmarshal(m, b)
+}
+func (m *GatewayConfigsDigest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayConfigsDigest.Marshal(b, m, deterministic)
+}
+func (dst *GatewayConfigsDigest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayConfigsDigest.Merge(dst, src)
+}
+func (m *GatewayConfigsDigest) XXX_Size() int {
+	return xxx_messageInfo_GatewayConfigsDigest.Size(m)
+}
+func (m *GatewayConfigsDigest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayConfigsDigest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayConfigsDigest proto.InternalMessageInfo
+
+func (m *GatewayConfigsDigest) GetHexDigest() string {
+	if m != nil {
+		return m.HexDigest
+	}
+	return ""
+}
+
 // Wraps a gateway config and a stream offset that the config was computed
 // from
 type OffsetGatewayConfigs struct {
@@ -126,7 +179,7 @@ func (m *OffsetGatewayConfigs) Reset()         { *m = OffsetGatewayConfigs{} }
 func (m *OffsetGatewayConfigs) String() string { return proto.CompactTextString(m) }
 func (*OffsetGatewayConfigs) ProtoMessage()    {}
 func (*OffsetGatewayConfigs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mconfig_400e6a2df47d4ae6, []int{2}
+	return fileDescriptor_mconfig_db9c5b2932c55511, []int{3}
 }
 func (m *OffsetGatewayConfigs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_OffsetGatewayConfigs.Unmarshal(m, b)
@@ -174,7 +227,7 @@ func (m *MconfigStreamRequest) Reset()         { *m = MconfigStreamRequest{} }
 func (m *MconfigStreamRequest) String() string { return proto.CompactTextString(m) }
 func (*MconfigStreamRequest) ProtoMessage()    {}
 func (*MconfigStreamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mconfig_400e6a2df47d4ae6, []int{3}
+	return fileDescriptor_mconfig_db9c5b2932c55511, []int{4}
 }
 func (m *MconfigStreamRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MconfigStreamRequest.Unmarshal(m, b)
@@ -205,33 +258,39 @@ func init() {
 	proto.RegisterType((*GatewayConfigs)(nil), "magma.orc8r.GatewayConfigs")
 	proto.RegisterMapType((map[string]*any.Any)(nil), "magma.orc8r.GatewayConfigs.ConfigsByKeyEntry")
 	proto.RegisterType((*GatewayConfigsMetadata)(nil), "magma.orc8r.GatewayConfigsMetadata")
+	proto.RegisterType((*GatewayConfigsDigest)(nil), "magma.orc8r.GatewayConfigsDigest")
 	proto.RegisterType((*OffsetGatewayConfigs)(nil), "magma.orc8r.OffsetGatewayConfigs")
 	proto.RegisterType((*MconfigStreamRequest)(nil), "magma.orc8r.MconfigStreamRequest")
 }
 
-func init() { proto.RegisterFile("orc8r/protos/mconfig.proto", fileDescriptor_mconfig_400e6a2df47d4ae6) }
-
-var fileDescriptor_mconfig_400e6a2df47d4ae6 = []byte{
-	// 327 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xcf, 0x4b, 0xc3, 0x30,
-	0x14, 0xc7, 0xe9, 0xa6, 0xd3, 0xbd, 0xca, 0xd0, 0x30, 0xc6, 0xdc, 0x18, 0x8c, 0x7a, 0x19, 0x82,
-	0x29, 0x4c, 0xc4, 0xe1, 0x45, 0x36, 0x11, 0x0f, 0x32, 0x84, 0x0c, 0x2f, 0x5e, 0xc6, 0x5b, 0xf7,
-	0x5a, 0xc4, 0xb5, 0xd1, 0x36, 0x55, 0xf2, 0xc7, 0x0b, 0xb2, 0xa4, 0x93, 0xfa, 0x83, 0x9d, 0xd2,
-	0x84, 0xcf, 0xfb, 0xfe, 0x28, 0x0f, 0x3a, 0x32, 0x0d, 0x46, 0xa9, 0xff, 0x9a, 0x4a, 0x25, 0x33,
-	0x3f, 0x0e, 0x64, 0x12, 0x3e, 0x47, 0xdc, 0x5c, 0x99, 0x1b, 0x63, 0x14, 0x23, 0x37, 0x44, 0xe7,
-	0x38, 0x92, 0x32, 0x5a, 0x91, 0x25, 0x17, 0x79, 0xe8, 0x63, 0xa2, 0x2d, 0xe7, 0x7d, 0x3a, 0xd0,
-	0xb8, 0x43, 0x45, 0x1f, 0xa8, 0x6f, 0xcc, 0x7c, 0xc6, 0x66, 0xd0, 0xb0, 0x52, 0xd9, 0x7c, 0xa1,
-	0xe7, 0x2f, 0xa4, 0xdb, 0xd0, 0xaf, 0x0e, 0xdc, 0xe1, 0x19, 0x2f, 0x69, 0xf2, 0x9f, 0x43, 0xbc,
-	0x38, 0x27, 0xfa, 0x9e, 0xf4, 0x6d, 0xa2, 0x52, 0x2d, 0x0e, 0x82, 0xd2, 0x13, 0xbb, 0x86, 0xfd,
-	0x98, 0x14, 0x2e, 0x51, 0x61, 0xdb, 0xed, 0x3b, 0x03, 0x77, 0x78, 0xb2, 0x45, 0x6e, 0x5a, 0xa0,
-	0xe2, 0x7b, 0xa8, 0xf3, 0x08, 0x47, 0x7f, 0x3c, 0xd8, 0x21, 0x54, 0xd7, 0xf9, 0x9c, 0xbe, 0x33,
-	0xa8, 0x8b, 0xf5, 0x27, 0x3b, 0x85, 0xdd, 0x77, 0x5c, 0xe5, 0xd4, 0xae, 0x18, 0x93, 0x26, 0xb7,
-	0xd5, 0xf9, 0xa6, 0x3a, 0x1f, 0x27, 0x5a, 0x58, 0xe4, 0xaa, 0x32, 0x72, 0xbc, 0x4b, 0x68, 0xfd,
-	0x6f, 0xcd, 0x7a, 0x00, 0x41, 0x4a, 0xa8, 0x68, 0x39, 0x47, 0x65, 0x32, 0xef, 0x88, 0x7a, 0xf1,
-	0x32, 0x56, 0x1e, 0x41, 0xf3, 0x21, 0x0c, 0x33, 0x52, 0xbf, 0xfe, 0xde, 0x05, 0xec, 0x15, 0xc5,
-	0x4d, 0x2c, 0x77, 0xd8, 0xdd, 0xd2, 0x53, 0x6c, 0x58, 0xd6, 0x82, 0x9a, 0x34, 0x72, 0x26, 0x78,
-	0x55, 0x14, 0x37, 0x8f, 0x43, 0x73, 0x6a, 0x99, 0x99, 0x4a, 0x09, 0x63, 0x41, 0x6f, 0x39, 0x65,
-	0xaa, 0xc4, 0x3b, 0x65, 0x7e, 0xd2, 0x7b, 0xea, 0x1a, 0x3b, 0xdf, 0xee, 0x46, 0xb0, 0x92, 0xf9,
-	0xd2, 0x8f, 0x64, 0xb1, 0x24, 0x8b, 0x9a, 0x39, 0xcf, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0xbf,
-	0xb0, 0x68, 0x96, 0x3b, 0x02, 0x00, 0x00,
+func init() {
+	proto.RegisterFile("orc8r/protos/mconfig.proto", fileDescriptor_mconfig_db9c5b2932c55511)
+}
+
+var fileDescriptor_mconfig_db9c5b2932c55511 = []byte{
+	// 369 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xc1, 0x6b, 0xdb, 0x30,
+	0x14, 0xc6, 0x71, 0xb2, 0x65, 0xcb, 0x73, 0x08, 0x9b, 0x30, 0xc1, 0x4b, 0x08, 0x64, 0xde, 0x25,
+	0x0c, 0x26, 0x43, 0x46, 0x20, 0xdb, 0xa5, 0x24, 0x6d, 0xe9, 0xa1, 0x84, 0x82, 0x42, 0x2f, 0xbd,
+	0x18, 0xc5, 0x96, 0x9d, 0xd0, 0xd8, 0x6a, 0x6d, 0xb9, 0x8d, 0xfe, 0xf8, 0x42, 0xb1, 0xa4, 0xb4,
+	0x69, 0x1b, 0x72, 0x92, 0x25, 0xfd, 0xbe, 0xef, 0x7b, 0x4f, 0x7e, 0xd0, 0xe5, 0x79, 0x38, 0xc9,
+	0xfd, 0xbb, 0x9c, 0x0b, 0x5e, 0xf8, 0x69, 0xc8, 0xb3, 0x78, 0x9d, 0x60, 0xb5, 0x45, 0x76, 0x4a,
+	0x93, 0x94, 0x62, 0x45, 0x74, 0x7f, 0x24, 0x9c, 0x27, 0x1b, 0xa6, 0xc9, 0x65, 0x19, 0xfb, 0x34,
+	0x93, 0x9a, 0xf3, 0x9e, 0x2c, 0x68, 0x5f, 0x50, 0xc1, 0x1e, 0xa9, 0x3c, 0x55, 0xfa, 0x02, 0x2d,
+	0xa0, 0xad, 0xad, 0x8a, 0x60, 0x29, 0x83, 0x5b, 0x26, 0x5d, 0x18, 0xd4, 0x87, 0xf6, 0xe8, 0x0f,
+	0xde, 0xf3, 0xc4, 0x6f, 0x45, 0xd8, 0xac, 0x33, 0x79, 0xc9, 0xe4, 0x79, 0x26, 0x72, 0x49, 0x5a,
+	0xe1, 0xde, 0x11, 0x3a, 0x81, 0xaf, 0x29, 0x13, 0x34, 0xa2, 0x82, 0xba, 0xf6, 0xc0, 0x1a, 0xda,
+	0xa3, 0x5f, 0x47, 0xec, 0xe6, 0x06, 0x25, 0x2f, 0xa2, 0xee, 0x35, 0x7c, 0xff, 0x90, 0x81, 0xbe,
+	0x41, 0xbd, 0xaa, 0xcf, 0x1a, 0x58, 0xc3, 0x26, 0xa9, 0x3e, 0xd1, 0x6f, 0xf8, 0xfc, 0x40, 0x37,
+	0x25, 0x73, 0x6b, 0x2a, 0xc4, 0xc1, 0xba, 0x75, 0xbc, 0x6b, 0x1d, 0x4f, 0x33, 0x49, 0x34, 0xf2,
+	0xbf, 0x36, 0xb1, 0xbc, 0x1c, 0x3a, 0x87, 0xa3, 0x51, 0x1f, 0x20, 0xcc, 0x19, 0x15, 0x2c, 0x0a,
+	0xa8, 0x50, 0x35, 0x7f, 0x22, 0x4d, 0x73, 0x32, 0x15, 0xe8, 0x1f, 0x34, 0xa2, 0x75, 0xc2, 0x0a,
+	0xe1, 0xb6, 0x54, 0xd2, 0xcf, 0x23, 0xed, 0x9c, 0x29, 0x90, 0x18, 0x81, 0x37, 0x06, 0xe7, 0xd0,
+	0x7d, 0x95, 0xb8, 0x62, 0xdb, 0xc0, 0xd8, 0xea, 0xa6, 0x9a, 0x2b, 0xb6, 0xd5, 0xd7, 0x1e, 0x03,
+	0xe7, 0x2a, 0x8e, 0x0b, 0x26, 0xde, 0xfd, 0xaf, 0x31, 0x7c, 0x31, 0x4f, 0xad, 0x34, 0xf6, 0xa8,
+	0x77, 0xa4, 0x14, 0xb2, 0x63, 0x51, 0x07, 0x1a, 0x5c, 0xd9, 0xa9, 0xa7, 0xaa, 0x13, 0xb3, 0xf3,
+	0x30, 0x38, 0x73, 0xcd, 0x2c, 0x44, 0xce, 0x68, 0x4a, 0xd8, 0x7d, 0x59, 0x55, 0xf7, 0xca, 0x5b,
+	0xfb, 0xfc, 0xac, 0x7f, 0xd3, 0x53, 0x71, 0xbe, 0x9e, 0xc6, 0x70, 0xc3, 0xcb, 0xc8, 0x4f, 0xb8,
+	0x19, 0xcb, 0x65, 0x43, 0xad, 0x7f, 0x9f, 0x07, 0x00, 0x81, 0xc1, 0x37, 0x6f, 0xad, 0x02, 0x00,
+	0x00,
 }
@@ -53,7 +53,7 @@ func (x ServiceInfo_ServiceState) String() string {
 	return proto.EnumName(ServiceInfo_ServiceState_name, int32(x))
 }
 func (ServiceInfo_ServiceState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_service303_950751da80ee2d66, []int{2, 0}
+	return fileDescriptor_service303_c393b7378ffe3f7e, []int{2, 0}
 }
 
 // Gives information about whether the application is usable. Though the
@@ -82,7 +82,7 @@ func (x ServiceInfo_ApplicationHealth) String() string {
 	return proto.EnumName(ServiceInfo_ApplicationHealth_name, int32(x))
 }
 func (ServiceInfo_ApplicationHealth) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_service303_950751da80ee2d66, []int{2, 1}
+	return fileDescriptor_service303_c393b7378ffe3f7e, []int{2, 1}
 }
 
 type ReloadConfigResponse_ReloadConfigResult int32
@@ -111,7 +111,7 @@ func (x ReloadConfigResponse_ReloadConfigResult) String() string {
 	return proto.EnumName(ReloadConfigResponse_ReloadConfigResult_name, int32(x))
 }
 func (ReloadConfigResponse_ReloadConfigResult) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_service303_950751da80ee2d66, []int{5, 0}
+	return fileDescriptor_service303_c393b7378ffe3f7e, []int{5, 0}
 }
 
 type EnodebdStatus struct {
@@ -134,7 +134,7 @@ func (m *EnodebdStatus) Reset()         { *m = EnodebdStatus{} }
 func (m *EnodebdStatus) String() string { return proto.CompactTextString(m) }
 func (*EnodebdStatus) ProtoMessage()    {}
 func (*EnodebdStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_service303_950751da80ee2d66, []int{0}
+	return fileDescriptor_service303_c393b7378ffe3f7e, []int{0}
 }
 func (m *EnodebdStatus) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EnodebdStatus.Unmarshal(m, b)
@@ -229,7 +229,7 @@ func (m *ServiceStatus) Reset()         { *m = ServiceStatus{} }
 func (m *ServiceStatus) String() string { return proto.CompactTextString(m) }
 func (*ServiceStatus) ProtoMessage()    {}
 func (*ServiceStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_service303_950751da80ee2d66, []int{1}
+	return fileDescriptor_service303_c393b7378ffe3f7e, []int{1}
 }
 func (m *ServiceStatus) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ServiceStatus.Unmarshal(m, b)
@@ -273,7 +273,7 @@ func (m *ServiceInfo) Reset()         { *m = ServiceInfo{} }
 func (m *ServiceInfo) String() string { return proto.CompactTextString(m) }
 func (*ServiceInfo) ProtoMessage()    {}
 func (*ServiceInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_service303_950751da80ee2d66, []int{2}
+	return fileDescriptor_service303_c393b7378ffe3f7e, []int{2}
 }
 func (m *ServiceInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ServiceInfo.Unmarshal(m, b)
@@ -346,7 +346,7 @@ func (m *LogLevelMessage) Reset()         { *m = LogLevelMessage{} }
 func (m *LogLevelMessage) String() string { return proto.CompactTextString(m) }
 func (*LogLevelMessage) ProtoMessage()    {}
 func (*LogLevelMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_service303_950751da80ee2d66, []int{3}
+	return fileDescriptor_service303_c393b7378ffe3f7e, []int{3}
 }
 func (m *LogLevelMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogLevelMessage.Unmarshal(m, b)
@@ -384,7 +384,7 @@ func (m *LogVerbosity) Reset()         { *m = LogVerbosity{} }
 func (m *LogVerbosity) String() string { return proto.CompactTextString(m) }
 func (*LogVerbosity) ProtoMessage()    {}
 func (*LogVerbosity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_service303_950751da80ee2d66, []int{4}
+	return fileDescriptor_service303_c393b7378ffe3f7e, []int{4}
 }
 func (m *LogVerbosity) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogVerbosity.Unmarshal(m, b)
@@ -422,7 +422,7 @@ func (m *ReloadConfigResponse) Reset()         { *m = ReloadConfigResponse{} }
 func (m *ReloadConfigResponse) String() string { return proto.CompactTextString(m) }
 func (*ReloadConfigResponse) ProtoMessage()    {}
 func (*ReloadConfigResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_service303_950751da80ee2d66, []int{5}
+	return fileDescriptor_service303_c393b7378ffe3f7e, []int{5}
 }
 func (m *ReloadConfigResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReloadConfigResponse.Unmarshal(m, b)
@@ -454,7 +454,10 @@ type State struct {
 	Type     string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	DeviceID string `protobuf:"bytes,2,opt,name=deviceID,proto3" json:"deviceID,omitempty"`
 	// Value contains the operational state json-serialized.
-	Value                []byte   `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Value []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// TTL of the state in seconds, after which the cloud considers the state
+	// expired. If 0, the default TTL for the state type applies.
+	TtlSec               uint64   `protobuf:"varint,4,opt,name=ttlSec,proto3" json:"ttlSec,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -464,7 +467,7 @@ func (m *State) Reset()         { *m = State{} }
 func (m *State) String() string { return proto.CompactTextString(m) }
 func (*State) ProtoMessage()    {}
 func (*State) Descriptor() ([]byte, []int) {
-	return fileDescriptor_service303_950751da80ee2d66, []int{6}
+	return fileDescriptor_service303_c393b7378ffe3f7e, []int{6}
 }
 func (m *State) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_State.Unmarshal(m, b)
@@ -505,6 +508,13 @@ func (m *State) GetValue() []byte {
 	return nil
 }
 
+func (m *State) GetTtlSec() uint64 {
+	if m != nil {
+		return m.TtlSec
+	}
+	return 0
+}
+
 type GetOperationalStatesResponse struct {
 	States               []*State `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -516,7 +526,7 @@ func (m *GetOperationalStatesResponse) Reset()         { *m = GetOperationalStat
 func (m *GetOperationalStatesResponse) String() string { return proto.CompactTextString(m) }
 func (*GetOperationalStatesResponse) ProtoMessage()    {}
 func (*GetOperationalStatesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_service303_950751da80ee2d66, []int{7}
+	return fileDescriptor_service303_c393b7378ffe3f7e, []int{7}
 }
 func (m *GetOperationalStatesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetOperationalStatesResponse.Unmarshal(m, b)
@@ -847,71 +857,71 @@ var _Service303_serviceDesc = grpc.ServiceDesc{
 }
 
 func init() {
-	proto.RegisterFile("orc8r/protos/service303.proto", fileDescriptor_service303_950751da80ee2d66)
-}
-
-var fileDescriptor_service303_950751da80ee2d66 = []byte{
-	// 977 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0xed, 0x6e, 0xe3, 0x44,
-	0x14, 0x6d, 0x9a, 0x8f, 0x6d, 0x6e, 0x9a, 0x8f, 0x0e, 0x05, 0x65, 0xd3, 0x16, 0x2d, 0x16, 0xa0,
-	0x65, 0x41, 0x29, 0x4a, 0x17, 0x51, 0x81, 0xb4, 0xdd, 0xb4, 0xf5, 0xb6, 0x05, 0xb7, 0x89, 0xec,
-	0xb4, 0x08, 0xfe, 0x44, 0xae, 0x7d, 0xeb, 0x5a, 0xd8, 0x1e, 0xcb, 0x33, 0x09, 0xdb, 0xbf, 0xbc,
-	0x12, 0xef, 0xc1, 0x2b, 0xf0, 0x0e, 0x3c, 0x01, 0xf2, 0xcc, 0xe4, 0xc3, 0x5b, 0x97, 0xfc, 0xca,
-	0xcc, 0xb9, 0xf7, 0x9c, 0xb9, 0x33, 0xf7, 0x5c, 0xc5, 0xb0, 0x47, 0x13, 0xe7, 0x30, 0xd9, 0x8f,
-	0x13, 0xca, 0x29, 0xdb, 0x67, 0x98, 0x4c, 0x7d, 0x07, 0x0f, 0xbe, 0x3d, 0xe8, 0x0a, 0x84, 0xd4,
-	0x42, 0xdb, 0x0b, 0xed, 0xae, 0x48, 0xea, 0x3c, 0xcf, 0xe4, 0x3a, 0x34, 0x0c, 0x69, 0x24, 0xf3,
-	0x3a, 0x3b, 0x99, 0x50, 0x88, 0x3c, 0xf1, 0x1d, 0xe6, 0xaa, 0xe0, 0xa7, 0x1e, 0xa5, 0x5e, 0x80,
-	0x32, 0x7a, 0x3b, 0xb9, 0xdb, 0xff, 0x23, 0xb1, 0xe3, 0x18, 0x13, 0x26, 0xe3, 0xda, 0x3f, 0x25,
-	0xa8, 0xeb, 0x11, 0x75, 0xf1, 0xd6, 0xb5, 0xb8, 0xcd, 0x27, 0x8c, 0xe8, 0xd0, 0x42, 0x01, 0x8c,
+	proto.RegisterFile("orc8r/protos/service303.proto", fileDescriptor_service303_c393b7378ffe3f7e)
+}
+
+var fileDescriptor_service303_c393b7378ffe3f7e = []byte{
+	// 986 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0xed, 0x4e, 0xe3, 0x46,
+	0x14, 0x25, 0xe4, 0x03, 0x72, 0x43, 0x3e, 0x98, 0xd2, 0x55, 0x36, 0x40, 0xb5, 0xb5, 0xda, 0x6a,
+	0xbb, 0xad, 0x42, 0x15, 0xb6, 0x2a, 0x6a, 0xa5, 0x65, 0x03, 0x78, 0x81, 0xd6, 0x90, 0xc8, 0x0e,
+	0x54, 0xed, 0x9f, 0xc8, 0xd8, 0x17, 0x63, 0xd5, 0xf6, 0x58, 0x9e, 0x09, 0x5d, 0xfe, 0xf6, 0x95,
+	0xfa, 0x1e, 0x7d, 0x85, 0xbe, 0x43, 0x9f, 0xa0, 0xf2, 0xcc, 0xe4, 0xc3, 0x8b, 0xd9, 0xfc, 0x8a,
+	0xef, 0xb9, 0xf7, 0x9c, 0xb9, 0xe3, 0x7b, 0xae, 0x62, 0xd8, 0xa5, 0x89, 0x73, 0x90, 0xec, 0xc5,
+	0x09, 0xe5, 0x94, 0xed, 0x31, 0x4c, 0xee, 0x7d, 0x07, 0xf7, 0xbf, 0xdb, 0xef, 0x0a, 0x84, 0xd4,
+	0x42, 0xdb, 0x0b, 0xed, 0xae, 0x28, 0xea, 0x3c, 0xcf, 0xd4, 0x3a, 0x34, 0x0c, 0x69, 0x24, 0xeb,
+	0x3a, 0xdb, 0x99, 0x54, 0x88, 0x3c, 0xf1, 0x1d, 0xe6, 0xaa, 0xe4, 0x67, 0x1e, 0xa5, 0x5e, 0x80,
+	0x32, 0x7b, 0x33, 0xb9, 0xdd, 0xfb, 0x33, 0xb1, 0xe3, 0x18, 0x13, 0x26, 0xf3, 0xda, 0xbf, 0x25,
+	0xa8, 0xeb, 0x11, 0x75, 0xf1, 0xc6, 0xb5, 0xb8, 0xcd, 0x27, 0x8c, 0xe8, 0xd0, 0x42, 0x01, 0x8c,
 	0x1d, 0x1a, 0x45, 0xe8, 0x70, 0x74, 0xdb, 0x85, 0x17, 0x85, 0x97, 0xb5, 0x5e, 0xa7, 0x2b, 0xc5,
-	0xba, 0x33, 0xb1, 0xee, 0x31, 0xa5, 0xc1, 0x8d, 0x1d, 0x4c, 0xd0, 0x6c, 0x4a, 0xce, 0xc9, 0x8c,
-	0x42, 0x4e, 0xa0, 0x49, 0x63, 0xc6, 0x6d, 0x8e, 0x63, 0x8c, 0xec, 0xdb, 0x00, 0xdd, 0xf6, 0xfa,
-	0x4a, 0x95, 0x86, 0xa2, 0xe8, 0x92, 0x41, 0x5e, 0xc3, 0x46, 0x72, 0x37, 0xe6, 0xef, 0xc7, 0x34,
-	0x6a, 0x17, 0x57, 0xb2, 0x2b, 0xc9, 0xdd, 0xe8, 0xfd, 0x20, 0x22, 0x47, 0x50, 0xf7, 0x62, 0xb6,
-	0x54, 0x7e, 0x69, 0x25, 0x75, 0xd3, 0x8b, 0xd9, 0xa2, 0xf6, 0x23, 0xa8, 0xc7, 0x3c, 0x5e, 0x12,
-	0x28, 0xaf, 0x16, 0x88, 0x79, 0x9c, 0x11, 0x08, 0x43, 0x5c, 0x12, 0xa8, 0xac, 0x16, 0x08, 0x43,
-	0x5c, 0x08, 0x9c, 0xc1, 0xd6, 0xa2, 0x09, 0x77, 0xbe, 0x37, 0x49, 0xd0, 0x6d, 0x3f, 0x5b, 0x29,
-	0xd2, 0x9a, 0x77, 0x41, 0x71, 0xc8, 0x1b, 0x48, 0xaf, 0x36, 0x0e, 0x6c, 0xee, 0xf3, 0x89, 0x8b,
-	0xed, 0x0d, 0xa1, 0xb1, 0xf3, 0x48, 0xe3, 0x5d, 0x40, 0x6d, 0x2e, 0x45, 0x6a, 0x5e, 0xcc, 0x0c,
-	0x95, 0x4f, 0xde, 0xca, 0xb7, 0x0c, 0x68, 0xe4, 0x49, 0x81, 0xea, 0x6a, 0x81, 0xf4, 0x44, 0x63,
-	0x46, 0xd0, 0xfe, 0x2c, 0x40, 0xdd, 0x92, 0xde, 0x56, 0x0e, 0x3b, 0x84, 0x52, 0x88, 0xdc, 0x6e,
-	0xaf, 0xbf, 0x28, 0xbe, 0xac, 0xf5, 0x3e, 0xef, 0x2e, 0xf9, 0xbc, 0x9b, 0xc9, 0xec, 0x5e, 0x22,
-	0xb7, 0xf5, 0x88, 0x27, 0x0f, 0xa6, 0x60, 0x74, 0xbe, 0x87, 0xea, 0x1c, 0x22, 0x2d, 0x28, 0xfe,
-	0x8e, 0x0f, 0xc2, 0x9b, 0x55, 0x33, 0x5d, 0x92, 0x6d, 0x28, 0x4f, 0xd3, 0x0a, 0x84, 0xd3, 0xaa,
-	0xa6, 0xdc, 0xfc, 0xb0, 0x7e, 0x58, 0xd0, 0xfe, 0x2a, 0x42, 0x4d, 0x49, 0x5f, 0x44, 0x77, 0x94,
-	0x10, 0x28, 0x45, 0x76, 0x88, 0x8a, 0x2c, 0xd6, 0xa4, 0x0d, 0xcf, 0xa6, 0x98, 0x30, 0x9f, 0x46,
-	0x8a, 0x3f, 0xdb, 0x92, 0x1f, 0xa1, 0x2c, 0x6c, 0x29, 0x3c, 0xd8, 0xe8, 0x7d, 0x91, 0x57, 0x71,
-	0x2a, 0xbb, 0x5c, 0x3d, 0x9a, 0x92, 0x43, 0x7a, 0x50, 0x61, 0xe2, 0x36, 0x73, 0x1b, 0x3e, 0x79,
-	0x5f, 0x53, 0x65, 0x92, 0x63, 0xa8, 0xdc, 0xa3, 0x1d, 0xf0, 0x7b, 0xe1, 0xbc, 0x46, 0xef, 0xd5,
-	0x93, 0x27, 0xf6, 0xe3, 0x38, 0xf0, 0x1d, 0x9b, 0xfb, 0x34, 0x3a, 0x17, 0x0c, 0x53, 0x31, 0xc9,
-	0x97, 0xd0, 0x64, 0xdc, 0x4e, 0xf8, 0x98, 0xfb, 0x21, 0x8e, 0x19, 0x3a, 0x4c, 0xb8, 0xb0, 0x64,
-	0xd6, 0x05, 0x3c, 0xf2, 0x43, 0xb4, 0xd0, 0x61, 0xda, 0x00, 0x36, 0x97, 0xcb, 0x26, 0x35, 0x78,
-	0x76, 0x7d, 0xf5, 0xf3, 0xd5, 0xe0, 0x97, 0xab, 0xd6, 0x1a, 0xd9, 0x84, 0x0d, 0x6b, 0xd4, 0x37,
-	0x47, 0x17, 0x57, 0x67, 0xad, 0x02, 0xa9, 0x42, 0xb9, 0x6f, 0x5c, 0xdc, 0xe8, 0xad, 0x75, 0x19,
-	0x18, 0x0c, 0x87, 0x69, 0xa0, 0x98, 0x72, 0xc4, 0x4e, 0x3f, 0x6d, 0x95, 0xb4, 0x73, 0xd8, 0x7a,
-	0x54, 0x15, 0x69, 0x42, 0xad, 0x3f, 0x1c, 0x8e, 0x17, 0xca, 0x5b, 0x50, 0x97, 0xc0, 0xb9, 0xde,
-	0x37, 0x46, 0xe7, 0xbf, 0xb6, 0x0a, 0xb3, 0x9c, 0x19, 0xb0, 0xae, 0xbd, 0x81, 0xa6, 0x41, 0x3d,
-	0x03, 0xa7, 0x18, 0x5c, 0x22, 0x63, 0xb6, 0x87, 0xe4, 0x6b, 0x28, 0x07, 0xe9, 0x5e, 0x74, 0xae,
-	0xd1, 0xfb, 0x38, 0xf3, 0x30, 0xb3, 0x64, 0x53, 0xe6, 0x68, 0xdf, 0xc0, 0xa6, 0x41, 0xbd, 0x1b,
-	0x4c, 0x6e, 0x29, 0xf3, 0xf9, 0x03, 0xd9, 0x85, 0xea, 0x74, 0xb6, 0x11, 0x02, 0x65, 0x73, 0x01,
-	0x68, 0x7f, 0x17, 0x60, 0xdb, 0xc4, 0x80, 0xda, 0xae, 0x9c, 0x1f, 0x13, 0x59, 0x4c, 0x23, 0x86,
+	0xba, 0x53, 0xb1, 0xee, 0x11, 0xa5, 0xc1, 0xb5, 0x1d, 0x4c, 0xd0, 0x6c, 0x4a, 0xce, 0xf1, 0x94,
+	0x42, 0x8e, 0xa1, 0x49, 0x63, 0xc6, 0x6d, 0x8e, 0x63, 0x8c, 0xec, 0x9b, 0x00, 0xdd, 0xf6, 0xea,
+	0x52, 0x95, 0x86, 0xa2, 0xe8, 0x92, 0x41, 0x5e, 0xc3, 0x7a, 0x72, 0x3b, 0xe6, 0xef, 0xc7, 0x34,
+	0x6a, 0x17, 0x97, 0xb2, 0x2b, 0xc9, 0xed, 0xe8, 0xfd, 0x20, 0x22, 0x87, 0x50, 0xf7, 0x62, 0xb6,
+	0xd0, 0x7e, 0x69, 0x29, 0x75, 0xc3, 0x8b, 0xd9, 0xbc, 0xf7, 0x43, 0xa8, 0xc7, 0x3c, 0x5e, 0x10,
+	0x28, 0x2f, 0x17, 0x88, 0x79, 0x9c, 0x11, 0x08, 0x43, 0x5c, 0x10, 0xa8, 0x2c, 0x17, 0x08, 0x43,
+	0x9c, 0x0b, 0x9c, 0xc2, 0xe6, 0x7c, 0x08, 0xb7, 0xbe, 0x37, 0x49, 0xd0, 0x6d, 0xaf, 0x2d, 0x15,
+	0x69, 0xcd, 0xa6, 0xa0, 0x38, 0xe4, 0x0d, 0xa4, 0x57, 0x1b, 0x07, 0x36, 0xf7, 0xf9, 0xc4, 0xc5,
+	0xf6, 0xba, 0xd0, 0xd8, 0x7e, 0xa4, 0xf1, 0x2e, 0xa0, 0x36, 0x97, 0x22, 0x35, 0x2f, 0x66, 0x86,
+	0xaa, 0x27, 0x6f, 0xe5, 0xbb, 0x0c, 0x68, 0xe4, 0x49, 0x81, 0xea, 0x72, 0x81, 0xf4, 0x44, 0x63,
+	0x4a, 0xd0, 0xfe, 0x2a, 0x40, 0xdd, 0x92, 0xde, 0x56, 0x0e, 0x3b, 0x80, 0x52, 0x88, 0xdc, 0x6e,
+	0xaf, 0xbe, 0x28, 0xbe, 0xac, 0xf5, 0xbe, 0xe8, 0x2e, 0xf8, 0xbc, 0x9b, 0xa9, 0xec, 0x5e, 0x20,
+	0xb7, 0xf5, 0x88, 0x27, 0x0f, 0xa6, 0x60, 0x74, 0x7e, 0x80, 0xea, 0x0c, 0x22, 0x2d, 0x28, 0xfe,
+	0x81, 0x0f, 0xc2, 0x9b, 0x55, 0x33, 0x7d, 0x24, 0x5b, 0x50, 0xbe, 0x4f, 0x3b, 0x10, 0x4e, 0xab,
+	0x9a, 0x32, 0xf8, 0x71, 0xf5, 0xa0, 0xa0, 0xfd, 0x5d, 0x84, 0x9a, 0x92, 0x3e, 0x8f, 0x6e, 0x29,
+	0x21, 0x50, 0x8a, 0xec, 0x10, 0x15, 0x59, 0x3c, 0x93, 0x36, 0xac, 0xdd, 0x63, 0xc2, 0x7c, 0x1a,
+	0x29, 0xfe, 0x34, 0x24, 0x3f, 0x41, 0x59, 0xd8, 0x52, 0x78, 0xb0, 0xd1, 0xfb, 0x32, 0xaf, 0xe3,
+	0x54, 0x76, 0xb1, 0x7b, 0x34, 0x25, 0x87, 0xf4, 0xa0, 0xc2, 0xc4, 0x6d, 0x66, 0x36, 0x7c, 0xf2,
+	0xbe, 0xa6, 0xaa, 0x24, 0x47, 0x50, 0xb9, 0x43, 0x3b, 0xe0, 0x77, 0xc2, 0x79, 0x8d, 0xde, 0xab,
+	0x27, 0x4f, 0xec, 0xc7, 0x71, 0xe0, 0x3b, 0x36, 0xf7, 0x69, 0x74, 0x26, 0x18, 0xa6, 0x62, 0x92,
+	0xaf, 0xa0, 0xc9, 0xb8, 0x9d, 0xf0, 0x31, 0xf7, 0x43, 0x1c, 0x33, 0x74, 0x98, 0x70, 0x61, 0xc9,
+	0xac, 0x0b, 0x78, 0xe4, 0x87, 0x68, 0xa1, 0xc3, 0xb4, 0x01, 0x6c, 0x2c, 0xb6, 0x4d, 0x6a, 0xb0,
+	0x76, 0x75, 0xf9, 0xcb, 0xe5, 0xe0, 0xd7, 0xcb, 0xd6, 0x0a, 0xd9, 0x80, 0x75, 0x6b, 0xd4, 0x37,
+	0x47, 0xe7, 0x97, 0xa7, 0xad, 0x02, 0xa9, 0x42, 0xb9, 0x6f, 0x9c, 0x5f, 0xeb, 0xad, 0x55, 0x99,
+	0x18, 0x0c, 0x87, 0x69, 0xa2, 0x98, 0x72, 0x44, 0xa4, 0x9f, 0xb4, 0x4a, 0xda, 0x19, 0x6c, 0x3e,
+	0xea, 0x8a, 0x34, 0xa1, 0xd6, 0x1f, 0x0e, 0xc7, 0x73, 0xe5, 0x4d, 0xa8, 0x4b, 0xe0, 0x4c, 0xef,
+	0x1b, 0xa3, 0xb3, 0xdf, 0x5a, 0x85, 0x69, 0xcd, 0x14, 0x58, 0xd5, 0xde, 0x40, 0xd3, 0xa0, 0x9e,
+	0x81, 0xf7, 0x18, 0x5c, 0x20, 0x63, 0xb6, 0x87, 0xe4, 0x1b, 0x28, 0x07, 0x69, 0x2c, 0x26, 0xd7,
+	0xe8, 0x7d, 0x9a, 0x79, 0x31, 0xd3, 0x62, 0x53, 0xd6, 0x68, 0xdf, 0xc2, 0x86, 0x41, 0xbd, 0x6b,
+	0x4c, 0x6e, 0x28, 0xf3, 0xf9, 0x03, 0xd9, 0x81, 0xea, 0xfd, 0x34, 0x10, 0x02, 0x65, 0x73, 0x0e,
+	0x68, 0xff, 0x14, 0x60, 0xcb, 0xc4, 0x80, 0xda, 0xae, 0xdc, 0x1f, 0x13, 0x59, 0x4c, 0x23, 0x86,
 	0xc4, 0x80, 0x4a, 0x82, 0x6c, 0x12, 0x70, 0x75, 0xe8, 0xeb, 0xcc, 0xa1, 0x79, 0x94, 0x0f, 0xc1,
-	0x49, 0xc0, 0x4d, 0xa5, 0xa1, 0xdd, 0x03, 0x79, 0x1c, 0x25, 0x04, 0x1a, 0xa6, 0x6e, 0x0c, 0xfa,
-	0xa7, 0x4b, 0x4f, 0xb4, 0xc0, 0xac, 0xeb, 0x93, 0x13, 0xdd, 0xb2, 0x5a, 0x85, 0x25, 0xec, 0x5d,
-	0xff, 0xc2, 0xb8, 0x36, 0xd3, 0x5e, 0x7c, 0x02, 0x64, 0xce, 0xb5, 0xae, 0x87, 0xc3, 0x81, 0x39,
-	0xd2, 0x4f, 0x5b, 0x45, 0xed, 0x12, 0xca, 0xb2, 0xa5, 0x04, 0x4a, 0xfc, 0x21, 0x9e, 0xbb, 0x3d,
-	0x5d, 0x93, 0x0e, 0x6c, 0xb8, 0x28, 0x6c, 0x74, 0xaa, 0xec, 0x3e, 0xdf, 0x2f, 0xe6, 0x28, 0xf5,
-	0xfb, 0xa6, 0x9a, 0x23, 0xed, 0x27, 0xd8, 0x3d, 0x43, 0x3e, 0x88, 0x31, 0x11, 0x8d, 0xb5, 0x03,
-	0x21, 0xce, 0xe6, 0xcf, 0xf4, 0x4a, 0x1a, 0x1d, 0x59, 0xbb, 0x20, 0x06, 0x9b, 0x64, 0x4d, 0x2b,
-	0x66, 0x42, 0x65, 0xf4, 0xfe, 0x2d, 0x02, 0x58, 0xf3, 0x3f, 0x3c, 0x72, 0x04, 0x8d, 0x33, 0xe4,
-	0xcb, 0x03, 0xba, 0x95, 0x21, 0xdf, 0x50, 0xdf, 0xed, 0xb4, 0x9f, 0x1a, 0x02, 0x6d, 0x8d, 0x7c,
-	0x07, 0x35, 0x8b, 0xd3, 0x58, 0x81, 0x79, 0xec, 0xc7, 0x90, 0xb6, 0x46, 0xde, 0x02, 0x9c, 0x21,
-	0xbf, 0x94, 0x7f, 0x99, 0x79, 0xac, 0xbd, 0x0c, 0xa4, 0x12, 0x4f, 0x68, 0xc4, 0x6d, 0x3f, 0xc2,
-	0x44, 0x28, 0xd4, 0x2c, 0xe4, 0x33, 0xe3, 0x91, 0xdd, 0x5c, 0x3f, 0x2a, 0xf3, 0xe6, 0xd7, 0xd0,
-	0x87, 0xa6, 0x54, 0x58, 0xf8, 0xf4, 0xf9, 0x87, 0x2a, 0xf3, 0x50, 0xbe, 0xc4, 0x25, 0x7c, 0x24,
-	0x2d, 0xa5, 0xee, 0x2f, 0x9d, 0x95, 0x77, 0x9f, 0xcf, 0x56, 0x5a, 0x57, 0x5b, 0x23, 0x37, 0xb0,
-	0x9d, 0xd7, 0xe8, 0x3c, 0xbd, 0xaf, 0x32, 0xd0, 0xff, 0xd9, 0x43, 0x5b, 0x3b, 0xde, 0xfb, 0x6d,
-	0x47, 0x64, 0xef, 0xcb, 0x0f, 0x16, 0x27, 0xa0, 0x13, 0x77, 0xdf, 0xa3, 0xea, 0xcb, 0xe5, 0xb6,
-	0x22, 0x7e, 0x0f, 0xfe, 0x0b, 0x00, 0x00, 0xff, 0xff, 0xde, 0x4c, 0x45, 0xb1, 0x17, 0x09, 0x00,
-	0x00,
+	0x49, 0xc0, 0x4d, 0xa5, 0xa1, 0xdd, 0x01, 0x79, 0x9c, 0x25, 0x04, 0x1a, 0xa6, 0x6e, 0x0c, 0xfa,
+	0x27, 0x0b, 0xaf, 0x68, 0x8e, 0x59, 0x57, 0xc7, 0xc7, 0xba, 0x65, 0xb5, 0x0a, 0x0b, 0xd8, 0xbb,
+	0xfe, 0xb9, 0x71, 0x65, 0xa6, 0xb3, 0x78, 0x06, 0x64, 0xc6, 0xb5, 0xae, 0x86, 0xc3, 0x81, 0x39,
+	0xd2, 0x4f, 0x5a, 0x45, 0x0d, 0xa1, 0x2c, 0x47, 0x4a, 0xa0, 0xc4, 0x1f, 0xe2, 0x99, 0xdb, 0xd3,
+	0x67, 0xd2, 0x81, 0x75, 0x17, 0x85, 0x8d, 0x4e, 0x94, 0xdd, 0x67, 0xf1, 0x7c, 0x8f, 0x52, 0xbf,
+	0x6f, 0xa8, 0x3d, 0x22, 0xcf, 0xa0, 0xc2, 0x79, 0x60, 0xa1, 0x23, 0x8c, 0x5c, 0x32, 0x55, 0xa4,
+	0xfd, 0x0c, 0x3b, 0xa7, 0xc8, 0x07, 0x31, 0x26, 0x62, 0xe0, 0x76, 0x20, 0x0e, 0x65, 0xb3, 0xd7,
+	0xf7, 0x4a, 0x2e, 0x00, 0xb2, 0x76, 0x41, 0x2c, 0x3c, 0xc9, 0x9a, 0x59, 0xec, 0x8a, 0xaa, 0xe8,
+	0xfd, 0x57, 0x04, 0xb0, 0x66, 0x7f, 0x84, 0xe4, 0x10, 0x1a, 0xa7, 0xc8, 0x17, 0x17, 0x77, 0x33,
+	0x43, 0xbe, 0xa6, 0xbe, 0xdb, 0x69, 0x3f, 0xb5, 0x1c, 0xda, 0x0a, 0xf9, 0x1e, 0x6a, 0x16, 0xa7,
+	0xb1, 0x02, 0xf3, 0xd8, 0x8f, 0x21, 0x6d, 0x85, 0xbc, 0x05, 0x38, 0x45, 0x7e, 0x21, 0xff, 0x4a,
+	0xf3, 0x58, 0xbb, 0x19, 0x48, 0x15, 0x1e, 0xd3, 0x88, 0xdb, 0x7e, 0x84, 0x89, 0x50, 0xa8, 0x59,
+	0xc8, 0xa7, 0x86, 0x24, 0x3b, 0xb9, 0x3e, 0x55, 0xa6, 0xce, 0xef, 0xa1, 0x0f, 0x4d, 0xa9, 0x30,
+	0xf7, 0xef, 0xf3, 0x0f, 0x55, 0x66, 0xa9, 0x7c, 0x89, 0x0b, 0xf8, 0x44, 0x5a, 0x4d, 0xdd, 0x5f,
+	0x3a, 0x2e, 0xef, 0x3e, 0x9f, 0x2f, 0xb5, 0xb4, 0xb6, 0x42, 0xae, 0x61, 0x2b, 0x6f, 0xd0, 0x79,
+	0x7a, 0x5f, 0x67, 0xa0, 0x8f, 0xd9, 0x43, 0x5b, 0x39, 0xda, 0xfd, 0x7d, 0x5b, 0x54, 0xef, 0xc9,
+	0x0f, 0x19, 0x27, 0xa0, 0x13, 0x77, 0xcf, 0xa3, 0xea, 0x8b, 0xe6, 0xa6, 0x22, 0x7e, 0xf7, 0xff,
+	0x1f, 0x00, 0x58, 0x79, 0x45, 0x04, 0x2f, 0x09, 0x00, 0x00,
 }
@@ -35,7 +35,7 @@ func (m *StateID) Reset()         { *m = StateID{} }
 func (m *StateID) String() string { return proto.CompactTextString(m) }
 func (*StateID) ProtoMessage()    {}
 func (*StateID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_state_40967c0a9a3f0140, []int{0}
+	return fileDescriptor_state_9dc3d7cf24a35f47, []int{0}
 }
 func (m *StateID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateID.Unmarshal(m, b)
@@ -81,7 +81,7 @@ func (m *GetStatesRequest) Reset()         { *m = GetStatesRequest{} }
 func (m *GetStatesRequest) String() string { return proto.CompactTextString(m) }
 func (*GetStatesRequest) ProtoMessage()    {}
 func (*GetStatesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_state_40967c0a9a3f0140, []int{1}
+	return fileDescriptor_state_9dc3d7cf24a35f47, []int{1}
 }
 func (m *GetStatesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStatesRequest.Unmarshal(m, b)
@@ -126,7 +126,7 @@ func (m *GetStatesResponse) Reset()         { *m = GetStatesResponse{} }
 func (m *GetStatesResponse) String() string { return proto.CompactTextString(m) }
 func (*GetStatesResponse) ProtoMessage()    {}
 func (*GetStatesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_state_40967c0a9a3f0140, []int{2}
+	return fileDescriptor_state_9dc3d7cf24a35f47, []int{2}
 }
 func (m *GetStatesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStatesResponse.Unmarshal(m, b)
@@ -164,7 +164,7 @@ func (m *ReportStatesRequest) Reset()         { *m = ReportStatesRequest{} }
 func (m *ReportStatesRequest) String() string { return proto.CompactTextString(m) }
 func (*ReportStatesRequest) ProtoMessage()    {}
 func (*ReportStatesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_state_40967c0a9a3f0140, []int{3}
+	return fileDescriptor_state_9dc3d7cf24a35f47, []int{3}
 }
 func (m *ReportStatesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReportStatesRequest.Unmarshal(m, b)
@@ -191,6 +191,224 @@ func (m *ReportStatesRequest) GetStates() []*State {
 	return nil
 }
 
+type ReportStatesResponse struct {
+	// States which were rejected, e.g. because their type is unknown or
+	// their value is malformed. All other states in the request were saved.
+	UnreportedStates     []*IDAndError `protobuf:"bytes,1,rep,name=unreportedStates,proto3" json:"unreportedStates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ReportStatesResponse) Reset()         { *m = ReportStatesResponse{} }
+func (m *ReportStatesResponse) String() string { return proto.CompactTextString(m) }
+func (*ReportStatesResponse) ProtoMessage()    {}
+func (*ReportStatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_state_9dc3d7cf24a35f47, []int{4}
+}
+func (m *ReportStatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportStatesResponse.Unmarshal(m, b)
+}
+func (m *ReportStatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportStatesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ReportStatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportStatesResponse.Merge(dst, src)
+}
+func (m *ReportStatesResponse) XXX_Size() int {
+	return xxx_messageInfo_ReportStatesResponse.Size(m)
+}
+func (m *ReportStatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportStatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportStatesResponse proto.InternalMessageInfo
+
+func (m *ReportStatesResponse) GetUnreportedStates() []*IDAndError {
+	if m != nil {
+		return m.UnreportedStates
+	}
+	return nil
+}
+
+type IDAndError struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	DeviceID             string   `protobuf:"bytes,2,opt,name=deviceID,proto3" json:"deviceID,omitempty"`
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IDAndError) Reset()         { *m = IDAndError{} }
+func (m *IDAndError) String() string { return proto.CompactTextString(m) }
+func (*IDAndError) ProtoMessage()    {}
+func (*IDAndError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_state_9dc3d7cf24a35f47, []int{5}
+}
+func (m *IDAndError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IDAndError.Unmarshal(m, b)
+}
+func (m *IDAndError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IDAndError.Marshal(b, m, deterministic)
+}
+func (dst *IDAndError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IDAndError.Merge(dst, src)
+}
+func (m *IDAndError) XXX_Size() int {
+	return xxx_messageInfo_IDAndError.Size(m)
+}
+func (m *IDAndError) XXX_DiscardUnknown() {
+	xxx_messageInfo_IDAndError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IDAndError proto.InternalMessageInfo
+
+func (m *IDAndError) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *IDAndError) GetDeviceID() string {
+	if m != nil {
+		return m.DeviceID
+	}
+	return ""
+}
+
+func (m *IDAndError) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ListStatesRequest struct {
+	NetworkID string `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	// Type of the states to list
+	TypeFilter string `protobuf:"bytes,2,opt,name=typeFilter,proto3" json:"typeFilter,omitempty"`
+	// If non-empty, only states reported by this reporter are returned
+	ReporterFilter string `protobuf:"bytes,3,opt,name=reporterFilter,proto3" json:"reporterFilter,omitempty"`
+	// Maximum number of states to return. If 0, a default page size is used.
+	PageSize uint32 `protobuf:"varint,4,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// Token returned by a previous ListStates call to continue listing from.
+	// Empty to list from the beginning.
+	PageToken            string   `protobuf:"bytes,5,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListStatesRequest) Reset()         { *m = ListStatesRequest{} }
+func (m *ListStatesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListStatesRequest) ProtoMessage()    {}
+func (*ListStatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_state_9dc3d7cf24a35f47, []int{6}
+}
+func (m *ListStatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListStatesRequest.Unmarshal(m, b)
+}
+func (m *ListStatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListStatesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListStatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListStatesRequest.Merge(dst, src)
+}
+func (m *ListStatesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListStatesRequest.Size(m)
+}
+func (m *ListStatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListStatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListStatesRequest proto.InternalMessageInfo
+
+func (m *ListStatesRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *ListStatesRequest) GetTypeFilter() string {
+	if m != nil {
+		return m.TypeFilter
+	}
+	return ""
+}
+
+func (m *ListStatesRequest) GetReporterFilter() string {
+	if m != nil {
+		return m.ReporterFilter
+	}
+	return ""
+}
+
+func (m *ListStatesRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListStatesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListStatesResponse struct {
+	// States are ordered by device ID, excluding expired states
+	States []*State `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	// Token to pass to the next ListStates call, empty if there are no more
+	// states to list
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListStatesResponse) Reset()         { *m = ListStatesResponse{} }
+func (m *ListStatesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListStatesResponse) ProtoMessage()    {}
+func (*ListStatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_state_9dc3d7cf24a35f47, []int{7}
+}
+func (m *ListStatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListStatesResponse.Unmarshal(m, b)
+}
+func (m *ListStatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListStatesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListStatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListStatesResponse.Merge(dst, src)
+}
+func (m *ListStatesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListStatesResponse.Size(m)
+}
+func (m *ListStatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListStatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListStatesResponse proto.InternalMessageInfo
+
+func (m *ListStatesResponse) GetStates() []*State {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+func (m *ListStatesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 type DeleteStatesRequest struct {
 	NetworkID            string     `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
 	Ids                  []*StateID `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`
@@ -203,7 +421,7 @@ func (m *DeleteStatesRequest) Reset()         { *m = DeleteStatesRequest{} }
 func (m *DeleteStatesRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteStatesRequest) ProtoMessage()    {}
 func (*DeleteStatesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_state_40967c0a9a3f0140, []int{4}
+	return fileDescriptor_state_9dc3d7cf24a35f47, []int{8}
 }
 func (m *DeleteStatesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteStatesRequest.Unmarshal(m, b)
@@ -242,6 +460,10 @@ func init() {
 	proto.RegisterType((*GetStatesRequest)(nil), "magma.orc8r.GetStatesRequest")
 	proto.RegisterType((*GetStatesResponse)(nil), "magma.orc8r.GetStatesResponse")
 	proto.RegisterType((*ReportStatesRequest)(nil), "magma.orc8r.ReportStatesRequest")
+	proto.RegisterType((*ReportStatesResponse)(nil), "magma.orc8r.ReportStatesResponse")
+	proto.RegisterType((*IDAndError)(nil), "magma.orc8r.IDAndError")
+	proto.RegisterType((*ListStatesRequest)(nil), "magma.orc8r.ListStatesRequest")
+	proto.RegisterType((*ListStatesResponse)(nil), "magma.orc8r.ListStatesResponse")
 	proto.RegisterType((*DeleteStatesRequest)(nil), "magma.orc8r.DeleteStatesRequest")
 }
 
@@ -258,7 +480,8 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type StateServiceClient interface {
 	GetStates(ctx context.Context, in *GetStatesRequest, opts ...grpc.CallOption) (*GetStatesResponse, error)
-	ReportStates(ctx context.Context, in *ReportStatesRequest, opts ...grpc.CallOption) (*Void, error)
+	ReportStates(ctx context.Context, in *ReportStatesRequest, opts ...grpc.CallOption) (*ReportStatesResponse, error)
+	ListStates(ctx context.Context, in *ListStatesRequest, opts ...grpc.CallOption) (*ListStatesResponse, error)
 	DeleteStates(ctx context.Context, in *DeleteStatesRequest, opts ...grpc.CallOption) (*Void, error)
 }
 
@@ -279,8 +502,8 @@ func (c *stateServiceClient) GetStates(ctx context.Context, in *GetStatesRequest
 	return out, nil
 }
 
-func (c *stateServiceClient) ReportStates(ctx context.Context, in *ReportStatesRequest, opts ...grpc.CallOption) (*Void, error) {
-	out := new(Void)
+func (c *stateServiceClient) ReportStates(ctx context.Context, in *ReportStatesRequest, opts ...grpc.CallOption) (*ReportStatesResponse, error) {
+	out := new(ReportStatesResponse)
 	err := c.cc.Invoke(ctx, "/magma.orc8r.StateService/ReportStates", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -288,6 +511,15 @@ func (c *stateServiceClient) ReportStates(ctx context.Context, in *ReportStatesR
 	return out, nil
 }
 
+func (c *stateServiceClient) ListStates(ctx context.Context, in *ListStatesRequest, opts ...grpc.CallOption) (*ListStatesResponse, error) {
+	out := new(ListStatesResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.StateService/ListStates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *stateServiceClient) DeleteStates(ctx context.Context, in *DeleteStatesRequest, opts ...grpc.CallOption) (*Void, error) {
 	out := new(Void)
 	err := c.cc.Invoke(ctx, "/magma.orc8r.StateService/DeleteStates", in, out, opts...)
@@ -300,7 +532,8 @@ func (c *stateServiceClient) DeleteStates(ctx context.Context, in *DeleteStatesR
 // StateServiceServer is the server API for StateService service.
 type StateServiceServer interface {
 	GetStates(context.Context, *GetStatesRequest) (*GetStatesResponse, error)
-	ReportStates(context.Context, *ReportStatesRequest) (*Void, error)
+	ReportStates(context.Context, *ReportStatesRequest) (*ReportStatesResponse, error)
+	ListStates(context.Context, *ListStatesRequest) (*ListStatesResponse, error)
 	DeleteStates(context.Context, *DeleteStatesRequest) (*Void, error)
 }
 
@@ -344,6 +577,24 @@ func _StateService_ReportStates_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StateService_ListStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServiceServer).ListStates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.StateService/ListStates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServiceServer).ListStates(ctx, req.(*ListStatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StateService_DeleteStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteStatesRequest)
 	if err := dec(in); err != nil {
@@ -374,6 +625,10 @@ var _StateService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReportStates",
 			Handler:    _StateService_ReportStates_Handler,
 		},
+		{
+			MethodName: "ListStates",
+			Handler:    _StateService_ListStates_Handler,
+		},
 		{
 			MethodName: "DeleteStates",
 			Handler:    _StateService_DeleteStates_Handler,
@@ -383,29 +638,39 @@ var _StateService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "orc8r/protos/state.proto",
 }
 
-func init() { proto.RegisterFile("orc8r/protos/state.proto", fileDescriptor_state_40967c0a9a3f0140) }
-
-var fileDescriptor_state_40967c0a9a3f0140 = []byte{
-	// 322 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x52, 0xc1, 0x4e, 0x83, 0x40,
-	0x14, 0x94, 0xd6, 0x54, 0xfb, 0xda, 0x83, 0x7d, 0xf5, 0x80, 0x68, 0x4d, 0xc3, 0xc1, 0x34, 0x1e,
-	0xc0, 0xb4, 0x17, 0x3d, 0x19, 0x0d, 0xc6, 0x34, 0xf1, 0x44, 0x13, 0x63, 0xf4, 0x84, 0xf0, 0xd2,
-	0x10, 0x0b, 0x8b, 0xbb, 0x5b, 0x8d, 0xbf, 0xec, 0x57, 0x18, 0x76, 0x49, 0x05, 0xc4, 0x83, 0x07,
-	0x4f, 0xb0, 0xf3, 0x66, 0x26, 0x3b, 0xb3, 0x0f, 0x4c, 0xc6, 0xc3, 0x73, 0xee, 0x66, 0x9c, 0x49,
-	0x26, 0x5c, 0x21, 0x03, 0x49, 0x8e, 0x3a, 0x60, 0x2f, 0x09, 0x96, 0x49, 0xe0, 0xa8, 0xb9, 0x75,
-	0x50, 0xa1, 0x85, 0x2c, 0x49, 0x58, 0xaa, 0x79, 0xd6, 0xa8, 0xea, 0x40, 0xfc, 0x2d, 0x0e, 0x69,
-	0x76, 0x36, 0xd3, 0x63, 0xfb, 0x02, 0x76, 0x16, 0xb9, 0xeb, 0xdc, 0x43, 0x84, 0x6d, 0xf9, 0x91,
-	0x91, 0x69, 0x8c, 0x8d, 0x49, 0xd7, 0x57, 0xff, 0x68, 0xc1, 0x6e, 0x44, 0xb9, 0x62, 0xee, 0x99,
-	0x2d, 0x85, 0x6f, 0xce, 0xf6, 0x03, 0xec, 0xdd, 0x92, 0x54, 0x6a, 0xe1, 0xd3, 0xeb, 0x9a, 0x84,
-	0xc4, 0x23, 0xe8, 0xa6, 0x24, 0xdf, 0x19, 0x7f, 0x99, 0x7b, 0x85, 0xd1, 0x37, 0x80, 0x27, 0xd0,
-	0x8e, 0x23, 0x61, 0xb6, 0xc6, 0xed, 0x49, 0x6f, 0xba, 0xef, 0x94, 0x12, 0x38, 0xc5, 0x25, 0xfc,
-	0x9c, 0x60, 0x5f, 0xc2, 0xa0, 0xe4, 0x2c, 0x32, 0x96, 0x0a, 0xc2, 0x53, 0xe8, 0xa8, 0xfc, 0xc2,
-	0x34, 0x94, 0x1e, 0x7f, 0xea, 0xfd, 0x82, 0x61, 0x5f, 0xc1, 0xd0, 0xa7, 0x8c, 0xf1, 0xda, 0xed,
-	0xfe, 0x62, 0xf1, 0x04, 0x43, 0x8f, 0x56, 0x24, 0xe9, 0x1f, 0x02, 0x4e, 0x3f, 0x0d, 0xe8, 0x2b,
-	0x60, 0xa1, 0xdf, 0x03, 0xef, 0xa0, 0xbb, 0x49, 0x8c, 0xa3, 0x8a, 0xb0, 0xde, 0xb1, 0x75, 0xfc,
-	0xdb, 0x58, 0x17, 0x65, 0x6f, 0xe1, 0x0d, 0xf4, 0xcb, 0xf1, 0x71, 0x5c, 0x51, 0x34, 0x34, 0x63,
-	0x0d, 0x2a, 0x8c, 0x7b, 0x16, 0x47, 0xda, 0xa6, 0x5c, 0x41, 0xcd, 0xa6, 0xa1, 0x9d, 0x46, 0x9b,
-	0xeb, 0xd1, 0xe3, 0xa1, 0x42, 0x5d, 0xbd, 0x89, 0xe1, 0x8a, 0xad, 0x23, 0x77, 0xc9, 0x8a, 0x95,
-	0x7c, 0xee, 0xa8, 0xef, 0xec, 0x2b, 0x00, 0x00, 0xff, 0xff, 0x0c, 0xd7, 0xb9, 0x90, 0xeb, 0x02,
-	0x00, 0x00,
+func init() { proto.RegisterFile("orc8r/protos/state.proto", fileDescriptor_state_9dc3d7cf24a35f47) }
+
+var fileDescriptor_state_9dc3d7cf24a35f47 = []byte{
+	// 483 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0xad, 0x93, 0xb6, 0x90, 0x69, 0x8a, 0x9a, 0x69, 0x24, 0x8c, 0x21, 0x25, 0xac, 0x50, 0x15,
+	0x71, 0x48, 0x50, 0x73, 0x81, 0x13, 0x2a, 0xb8, 0xa0, 0x48, 0x95, 0x40, 0x0e, 0x20, 0x44, 0x4f,
+	0x21, 0x1e, 0x22, 0xab, 0x89, 0xd7, 0xec, 0x6e, 0xf8, 0xfa, 0x5b, 0xfc, 0x0c, 0xfe, 0x14, 0xf2,
+	0xee, 0xca, 0xf1, 0x26, 0x2d, 0x92, 0x0f, 0x3d, 0xd9, 0xf3, 0x66, 0xde, 0x9b, 0xb7, 0xe3, 0xf1,
+	0x82, 0xcf, 0xc5, 0xf4, 0x99, 0x18, 0x64, 0x82, 0x2b, 0x2e, 0x07, 0x52, 0x4d, 0x14, 0xf5, 0x75,
+	0x80, 0x7b, 0x8b, 0xc9, 0x6c, 0x31, 0xe9, 0xeb, 0x7c, 0x70, 0xcf, 0x29, 0x9b, 0xf2, 0xc5, 0x82,
+	0xa7, 0xa6, 0x2e, 0xe8, 0xb8, 0x0a, 0x24, 0xbe, 0x27, 0x53, 0x1a, 0x3e, 0x1d, 0x9a, 0x34, 0x7b,
+	0x0e, 0xb7, 0xc6, 0xb9, 0xea, 0x28, 0x44, 0x84, 0x6d, 0xf5, 0x2b, 0x23, 0xdf, 0xeb, 0x7a, 0xbd,
+	0x46, 0xa4, 0xdf, 0x31, 0x80, 0xdb, 0x31, 0xe5, 0x8c, 0x51, 0xe8, 0xd7, 0x34, 0x5e, 0xc4, 0xec,
+	0x13, 0x1c, 0xbc, 0x21, 0xa5, 0xd9, 0x32, 0xa2, 0x6f, 0x4b, 0x92, 0x0a, 0x1f, 0x40, 0x23, 0x25,
+	0xf5, 0x83, 0x8b, 0xcb, 0x51, 0x68, 0x85, 0x56, 0x00, 0x1e, 0x43, 0x3d, 0x89, 0xa5, 0x5f, 0xeb,
+	0xd6, 0x7b, 0x7b, 0x27, 0xed, 0x7e, 0xe9, 0x04, 0x7d, 0x6b, 0x22, 0xca, 0x0b, 0xd8, 0x0b, 0x68,
+	0x95, 0x94, 0x65, 0xc6, 0x53, 0x49, 0xf8, 0x04, 0x76, 0xf5, 0xf9, 0xa5, 0xef, 0x69, 0x3e, 0x6e,
+	0xf2, 0x23, 0x5b, 0xc1, 0x4e, 0xe1, 0x30, 0xa2, 0x8c, 0x8b, 0x35, 0x77, 0x55, 0x24, 0x2e, 0xa0,
+	0xed, 0x4a, 0x58, 0x1b, 0xaf, 0xe0, 0x60, 0x99, 0x0a, 0x9d, 0xa1, 0x78, 0x5c, 0x56, 0xbb, 0xeb,
+	0xa8, 0x8d, 0xc2, 0xd3, 0x34, 0x3e, 0x13, 0x82, 0x8b, 0x68, 0x83, 0xc0, 0x22, 0x80, 0x55, 0xbe,
+	0xea, 0xe0, 0xb1, 0x0d, 0x3b, 0x94, 0x13, 0xfd, 0xba, 0x4e, 0x98, 0x80, 0xfd, 0xf1, 0xa0, 0x75,
+	0x9e, 0xc8, 0x4a, 0x1f, 0xe4, 0x08, 0x20, 0xef, 0xf6, 0x3a, 0x99, 0x2b, 0x12, 0xb6, 0x4f, 0x09,
+	0xc1, 0x63, 0xb8, 0x63, 0x9d, 0x0b, 0x5b, 0x63, 0x5a, 0xae, 0xa1, 0xb9, 0xdb, 0x6c, 0x32, 0xa3,
+	0x71, 0xf2, 0x9b, 0xfc, 0xed, 0xae, 0xd7, 0xdb, 0x8f, 0x8a, 0x38, 0x77, 0x90, 0xbf, 0xbf, 0xe7,
+	0x97, 0x94, 0xfa, 0x3b, 0xc6, 0x41, 0x01, 0xb0, 0xaf, 0x80, 0x65, 0xd3, 0xd5, 0xbf, 0x35, 0x3e,
+	0x86, 0xfd, 0x94, 0x7e, 0xaa, 0x77, 0x45, 0x0f, 0x73, 0x0c, 0x17, 0x64, 0x17, 0x70, 0x18, 0xd2,
+	0x9c, 0x14, 0xdd, 0xc0, 0xbe, 0x9e, 0xfc, 0xad, 0x41, 0x53, 0x03, 0x63, 0xf3, 0x7b, 0xe1, 0x39,
+	0x34, 0x8a, 0x05, 0xc6, 0x8e, 0x43, 0x5c, 0xff, 0x65, 0x82, 0xa3, 0xeb, 0xd2, 0x66, 0x16, 0x6c,
+	0x0b, 0x3f, 0x40, 0xb3, 0xbc, 0x8a, 0xd8, 0x75, 0x18, 0x57, 0x2c, 0x7a, 0xf0, 0xe8, 0x3f, 0x15,
+	0x85, 0xec, 0x5b, 0x80, 0xd5, 0xe8, 0xd1, 0xb5, 0xb1, 0xb1, 0x48, 0xc1, 0xc3, 0x6b, 0xf3, 0x85,
+	0xe0, 0x19, 0x34, 0xcb, 0x33, 0x5e, 0xf3, 0x79, 0xc5, 0xf8, 0x83, 0x96, 0x53, 0xf1, 0x91, 0x27,
+	0x31, 0xdb, 0x7a, 0xd9, 0xf9, 0x7c, 0x5f, 0xa3, 0x03, 0x73, 0x73, 0x4d, 0xe7, 0x7c, 0x19, 0x0f,
+	0x66, 0xdc, 0x5e, 0x61, 0x5f, 0x76, 0xf5, 0x73, 0xf8, 0x6f, 0x00, 0xcf, 0xc6, 0xa9, 0x18, 0x1b,
+	0x05, 0x00, 0x00,
 }
@@ -32,14 +32,19 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 // between the cloud and the gateway while abstracting the details of how
 // its implemented in the cloud and what the gateway does with the updates.
 //
-// - The gateways call the GetUpdates() streaming API with a StreamRequest
-//   indicating the stream name and the offset to continue streaming from.
-// - The cloud sends a stream of DataUpdateBatch containing a batch of updates.
-// - If resync is true, then the gateway can cleanup all its data and add
-//   all the keys (the batch is guaranteed to contain only unique keys).
-// - If resync is false, then the gateway can update the keys, or add new
-//   ones if the key is not already present.
-// - Key deletions are not yet supported (#15109350)
+//   - The gateways call the GetUpdates() streaming API with a StreamRequest
+//     indicating the stream name and the offset to continue streaming from.
+//   - The cloud sends a stream of DataUpdateBatch containing a batch of updates.
+//   - If resync is true, then the gateway can cleanup all its data and add
+//     all the keys (the batch is guaranteed to contain only unique keys).
+//   - If resync is false, then the gateway can update the keys, or add new
+//     ones if the key is not already present.
+//   - Key deletions are only streamed to gateways which request incremental
+//     updates by sending a DeltaStreamArgs in the StreamRequest. Deleted keys
+//     are listed in deleted_keys.
+//   - Incremental update streams are kept open, and the cloud sends a new
+//     batch whenever the data changes.
+//
 // --------------------------------------------------------------------------
 type StreamRequest struct {
 	GatewayId string `protobuf:"bytes,1,opt,name=gatewayId,proto3" json:"gatewayId,omitempty"`
@@ -57,7 +62,7 @@ func (m *StreamRequest) Reset()         { *m = StreamRequest{} }
 func (m *StreamRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamRequest) ProtoMessage()    {}
 func (*StreamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_streamer_875df42a7991f84c, []int{0}
+	return fileDescriptor_streamer_d094a9df2374e684, []int{0}
 }
 func (m *StreamRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StreamRequest.Unmarshal(m, b)
@@ -113,7 +118,7 @@ func (m *DataUpdate) Reset()         { *m = DataUpdate{} }
 func (m *DataUpdate) String() string { return proto.CompactTextString(m) }
 func (*DataUpdate) ProtoMessage()    {}
 func (*DataUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_streamer_875df42a7991f84c, []int{1}
+	return fileDescriptor_streamer_d094a9df2374e684, []int{1}
 }
 func (m *DataUpdate) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataUpdate.Unmarshal(m, b)
@@ -151,7 +156,12 @@ type DataUpdateBatch struct {
 	Updates []*DataUpdate `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"`
 	// If resync is true, the updates would be a snapshot of all the
 	// contents in the cloud.
-	Resync               bool     `protobuf:"varint,2,opt,name=resync,proto3" json:"resync,omitempty"`
+	Resync bool `protobuf:"varint,2,opt,name=resync,proto3" json:"resync,omitempty"`
+	// Version of the data after applying this batch, for incremental update
+	// streams. The gateway sends this back in DeltaStreamArgs on reconnect.
+	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Keys deleted since the previous batch, for incremental update streams
+	DeletedKeys          []string `protobuf:"bytes,4,rep,name=deleted_keys,json=deletedKeys,proto3" json:"deleted_keys,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -161,7 +171,7 @@ func (m *DataUpdateBatch) Reset()         { *m = DataUpdateBatch{} }
 func (m *DataUpdateBatch) String() string { return proto.CompactTextString(m) }
 func (*DataUpdateBatch) ProtoMessage()    {}
 func (*DataUpdateBatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_streamer_875df42a7991f84c, []int{2}
+	return fileDescriptor_streamer_d094a9df2374e684, []int{2}
 }
 func (m *DataUpdateBatch) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataUpdateBatch.Unmarshal(m, b)
@@ -195,10 +205,77 @@ func (m *DataUpdateBatch) GetResync() bool {
 	return false
 }
 
+func (m *DataUpdateBatch) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *DataUpdateBatch) GetDeletedKeys() []string {
+	if m != nil {
+		return m.DeletedKeys
+	}
+	return nil
+}
+
+// DeltaStreamArgs is sent in StreamRequest.extra_args by gateways which want
+// incremental updates rather than a full snapshot on every request.
+type DeltaStreamArgs struct {
+	// Version of the last batch applied by the gateway. Empty if the gateway
+	// has no data yet.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Stream-specific extra args, which would otherwise be sent directly in
+	// StreamRequest.extra_args
+	ExtraArgs            *any.Any `protobuf:"bytes,2,opt,name=extra_args,json=extraArgs,proto3" json:"extra_args,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeltaStreamArgs) Reset()         { *m = DeltaStreamArgs{} }
+func (m *DeltaStreamArgs) String() string { return proto.CompactTextString(m) }
+func (*DeltaStreamArgs) ProtoMessage()    {}
+func (*DeltaStreamArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_streamer_d094a9df2374e684, []int{3}
+}
+func (m *DeltaStreamArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeltaStreamArgs.Unmarshal(m, b)
+}
+func (m *DeltaStreamArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeltaStreamArgs.Marshal(b, m, deterministic)
+}
+func (dst *DeltaStreamArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeltaStreamArgs.Merge(dst, src)
+}
+func (m *DeltaStreamArgs) XXX_Size() int {
+	return xxx_messageInfo_DeltaStreamArgs.Size(m)
+}
+func (m *DeltaStreamArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeltaStreamArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeltaStreamArgs proto.InternalMessageInfo
+
+func (m *DeltaStreamArgs) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *DeltaStreamArgs) GetExtraArgs() *any.Any {
+	if m != nil {
+		return m.ExtraArgs
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*StreamRequest)(nil), "magma.orc8r.StreamRequest")
 	proto.RegisterType((*DataUpdate)(nil), "magma.orc8r.DataUpdate")
 	proto.RegisterType((*DataUpdateBatch)(nil), "magma.orc8r.DataUpdateBatch")
+	proto.RegisterType((*DeltaStreamArgs)(nil), "magma.orc8r.DeltaStreamArgs")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -305,29 +382,33 @@ var _Streamer_serviceDesc = grpc.ServiceDesc{
 }
 
 func init() {
-	proto.RegisterFile("orc8r/protos/streamer.proto", fileDescriptor_streamer_875df42a7991f84c)
-}
-
-var fileDescriptor_streamer_875df42a7991f84c = []byte{
-	// 312 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xc1, 0x4f, 0xf2, 0x40,
-	0x10, 0xc5, 0xbf, 0x7e, 0x44, 0x84, 0xa9, 0x46, 0xb3, 0x21, 0x5a, 0x01, 0x23, 0xe9, 0x89, 0xd3,
-	0x56, 0xc1, 0x83, 0x57, 0x88, 0x89, 0xd1, 0x83, 0x87, 0x25, 0x78, 0x30, 0x26, 0x64, 0x28, 0xe3,
-	0x9a, 0x48, 0xbb, 0xb8, 0xbb, 0x55, 0x7b, 0xf6, 0x1f, 0x37, 0xee, 0x96, 0x20, 0x07, 0x4f, 0xed,
-	0x9b, 0xfd, 0xcd, 0xbc, 0xc9, 0x1b, 0xe8, 0x28, 0x9d, 0x5e, 0xe9, 0x64, 0xa5, 0x95, 0x55, 0x26,
-	0x31, 0x56, 0x13, 0x66, 0xa4, 0xb9, 0xd3, 0x2c, 0xcc, 0x50, 0x66, 0xc8, 0x1d, 0xd2, 0x3e, 0x91,
-	0x4a, 0xc9, 0x25, 0x79, 0x74, 0x5e, 0x3c, 0x27, 0x98, 0x97, 0x9e, 0x8b, 0xbf, 0x02, 0xd8, 0x9f,
-	0xb8, 0x56, 0x41, 0x6f, 0x05, 0x19, 0xcb, 0xba, 0xd0, 0x94, 0x68, 0xe9, 0x03, 0xcb, 0xdb, 0x45,
-	0x14, 0xf4, 0x82, 0x7e, 0x53, 0x6c, 0x0a, 0xec, 0x0c, 0x42, 0xef, 0x34, 0xcb, 0x31, 0xa3, 0xe8,
-	0xbf, 0x7b, 0x07, 0x5f, 0xba, 0xc7, 0x8c, 0xd8, 0x10, 0x80, 0x3e, 0xad, 0xc6, 0x19, 0x6a, 0x69,
-	0xa2, 0x5a, 0x2f, 0xe8, 0x87, 0x83, 0x16, 0xf7, 0x0b, 0xf0, 0xf5, 0x02, 0x7c, 0x94, 0x97, 0xa2,
-	0xe9, 0xb8, 0x91, 0x96, 0x26, 0xbe, 0x04, 0xb8, 0x46, 0x8b, 0xd3, 0xd5, 0x02, 0x2d, 0xb1, 0x43,
-	0xa8, 0xbd, 0x52, 0x59, 0x79, 0xff, 0xfc, 0xb2, 0x16, 0xec, 0xbc, 0xe3, 0xb2, 0xf0, 0x7e, 0x7b,
-	0xc2, 0x8b, 0xf8, 0x09, 0x0e, 0x36, 0x5d, 0x63, 0xb4, 0xe9, 0x0b, 0xbb, 0x80, 0xdd, 0xc2, 0x49,
-	0x13, 0x05, 0xbd, 0x5a, 0x3f, 0x1c, 0x1c, 0xf3, 0x5f, 0x41, 0xf0, 0x0d, 0x2e, 0xd6, 0x1c, 0x3b,
-	0x82, 0xba, 0x26, 0x53, 0xe6, 0xa9, 0x1b, 0xde, 0x10, 0x95, 0x1a, 0x3c, 0x40, 0x63, 0x52, 0x65,
-	0xca, 0xee, 0x00, 0x6e, 0xc8, 0x4e, 0xab, 0x8e, 0xf6, 0xd6, 0xcc, 0xad, 0xf4, 0xda, 0xdd, 0x3f,
-	0xfc, 0xdc, 0x7a, 0xf1, 0xbf, 0xf3, 0x60, 0x7c, 0xfa, 0xd8, 0x71, 0x48, 0xe2, 0xcf, 0x97, 0x2e,
-	0x55, 0xb1, 0x48, 0xa4, 0xaa, 0xee, 0x38, 0xaf, 0xbb, 0xef, 0xf0, 0x3b, 0x00, 0x00, 0xff, 0xff,
-	0x75, 0x62, 0xfb, 0xc6, 0xde, 0x01, 0x00, 0x00,
+	proto.RegisterFile("orc8r/protos/streamer.proto", fileDescriptor_streamer_d094a9df2374e684)
+}
+
+var fileDescriptor_streamer_d094a9df2374e684 = []byte{
+	// 374 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0x3d, 0x8f, 0xd3, 0x40,
+	0x10, 0x65, 0xcf, 0x70, 0x77, 0x1e, 0x1f, 0x3a, 0xb4, 0x3a, 0x81, 0xc9, 0x1d, 0xc2, 0xb8, 0x72,
+	0x65, 0x43, 0x42, 0x41, 0x9b, 0x28, 0x12, 0x02, 0x24, 0x8a, 0x8d, 0x42, 0x41, 0x13, 0x26, 0xf6,
+	0x60, 0x50, 0x6c, 0x6f, 0xd8, 0x5d, 0x07, 0x5c, 0xf3, 0x2b, 0xf8, 0xb7, 0x28, 0xbb, 0x8e, 0x12,
+	0x17, 0x48, 0x54, 0xf6, 0x9b, 0x7d, 0xf3, 0xf1, 0xde, 0x0c, 0xdc, 0x4a, 0x95, 0xbf, 0x51, 0xd9,
+	0x56, 0x49, 0x23, 0x75, 0xa6, 0x8d, 0x22, 0xac, 0x49, 0xa5, 0x16, 0xf3, 0xa0, 0xc6, 0xb2, 0xc6,
+	0xd4, 0x52, 0x46, 0x4f, 0x4b, 0x29, 0xcb, 0x8a, 0x1c, 0x75, 0xdd, 0x7e, 0xcd, 0xb0, 0xe9, 0x1c,
+	0x2f, 0xfe, 0xcd, 0xe0, 0xe1, 0xc2, 0xa6, 0x0a, 0xfa, 0xd1, 0x92, 0x36, 0xfc, 0x0e, 0xfc, 0x12,
+	0x0d, 0xfd, 0xc4, 0xee, 0x5d, 0x11, 0xb2, 0x88, 0x25, 0xbe, 0x38, 0x06, 0xf8, 0x73, 0x08, 0x5c,
+	0xa7, 0x55, 0x83, 0x35, 0x85, 0x67, 0xf6, 0x1d, 0x5c, 0xe8, 0x23, 0xd6, 0xc4, 0x27, 0x00, 0xf4,
+	0xcb, 0x28, 0x5c, 0xa1, 0x2a, 0x75, 0xe8, 0x45, 0x2c, 0x09, 0xc6, 0x37, 0xa9, 0x1b, 0x20, 0x3d,
+	0x0c, 0x90, 0x4e, 0x9b, 0x4e, 0xf8, 0x96, 0x37, 0x55, 0xa5, 0x8e, 0x5f, 0x03, 0xcc, 0xd1, 0xe0,
+	0x72, 0x5b, 0xa0, 0x21, 0xfe, 0x08, 0xbc, 0x0d, 0x75, 0x7d, 0xef, 0xfd, 0x2f, 0xbf, 0x81, 0x07,
+	0x3b, 0xac, 0x5a, 0xd7, 0xef, 0x4a, 0x38, 0x10, 0xff, 0x61, 0x70, 0x7d, 0x4c, 0x9b, 0xa1, 0xc9,
+	0xbf, 0xf1, 0x57, 0x70, 0xd1, 0x5a, 0xa8, 0x43, 0x16, 0x79, 0x49, 0x30, 0x7e, 0x92, 0x9e, 0x38,
+	0x91, 0x1e, 0xe9, 0xe2, 0xc0, 0xe3, 0x8f, 0xe1, 0x5c, 0x91, 0xee, 0x9a, 0xdc, 0x56, 0xbf, 0x14,
+	0x3d, 0xe2, 0x21, 0x5c, 0xec, 0x48, 0xe9, 0xef, 0xb2, 0xb1, 0x32, 0x7c, 0x71, 0x80, 0xfc, 0x05,
+	0x5c, 0x15, 0x54, 0x91, 0xa1, 0x62, 0xb5, 0xa1, 0x4e, 0x87, 0xf7, 0x23, 0x2f, 0xf1, 0x45, 0xd0,
+	0xc7, 0x3e, 0x50, 0xa7, 0xe3, 0x2f, 0x70, 0x3d, 0xa7, 0xca, 0xa0, 0xf3, 0x76, 0x2f, 0xf2, 0xb4,
+	0x1e, 0x1b, 0xd6, 0x1b, 0x7a, 0x76, 0xf6, 0x5f, 0x9e, 0x8d, 0x3f, 0xc1, 0xe5, 0xa2, 0xdf, 0x39,
+	0x7f, 0x0f, 0xf0, 0x96, 0xcc, 0xb2, 0x17, 0x34, 0x1a, 0x48, 0x1e, 0x6c, 0x77, 0x74, 0xf7, 0x0f,
+	0x3b, 0xac, 0x7b, 0xf1, 0xbd, 0x97, 0x6c, 0xf6, 0xec, 0xf3, 0xad, 0xa5, 0x64, 0xee, 0xbc, 0xf2,
+	0x4a, 0xb6, 0x45, 0x56, 0xca, 0xfe, 0xce, 0xd6, 0xe7, 0xf6, 0x3b, 0xf9, 0x3b, 0x00, 0xc6, 0x06,
+	0xd2, 0xe6, 0x7e, 0x02, 0x00, 0x00,
 }
@@ -43,7 +43,7 @@ func (m *GatewayRequest) Reset()         { *m = GatewayRequest{} }
 func (m *GatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*GatewayRequest) ProtoMessage()    {}
 func (*GatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sync_rpc_service_2f3453598dcde6bc, []int{0}
+	return fileDescriptor_sync_rpc_service_ed93a68a7ef21a6f, []int{0}
 }
 func (m *GatewayRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GatewayRequest.Unmarshal(m, b)
@@ -119,7 +119,7 @@ func (m *GatewayResponse) Reset()         { *m = GatewayResponse{} }
 func (m *GatewayResponse) String() string { return proto.CompactTextString(m) }
 func (*GatewayResponse) ProtoMessage()    {}
 func (*GatewayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sync_rpc_service_2f3453598dcde6bc, []int{1}
+	return fileDescriptor_sync_rpc_service_ed93a68a7ef21a6f, []int{1}
 }
 func (m *GatewayResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GatewayResponse.Unmarshal(m, b)
@@ -183,7 +183,19 @@ type SyncRPCRequest struct {
 	// down to the gateway
 	HeartBeat bool `protobuf:"varint,3,opt,name=heartBeat,proto3" json:"heartBeat,omitempty"`
 	// connClosed is set to true when the client closes the connection
-	ConnClosed           bool     `protobuf:"varint,4,opt,name=connClosed,proto3" json:"connClosed,omitempty"`
+	ConnClosed bool `protobuf:"varint,4,opt,name=connClosed,proto3" json:"connClosed,omitempty"`
+	// seq numbers the messages of a streamed request, starting at 1. The
+	// first message carries the authority, path and headers, and later
+	// messages only carry payload. Requests with seq 0 are sent in a single
+	// message.
+	Seq uint32 `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
+	// endOfStream is set on the last message of a streamed request
+	EndOfStream bool `protobuf:"varint,6,opt,name=endOfStream,proto3" json:"endOfStream,omitempty"`
+	// windowUpdate allows the gateway to send this many more response
+	// messages for reqId. If the first message of a request has no window,
+	// the responses aren't flow controlled. A message with a windowUpdate and
+	// no seq only carries the window update.
+	WindowUpdate         uint32   `protobuf:"varint,7,opt,name=windowUpdate,proto3" json:"windowUpdate,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -193,7 +205,7 @@ func (m *SyncRPCRequest) Reset()         { *m = SyncRPCRequest{} }
 func (m *SyncRPCRequest) String() string { return proto.CompactTextString(m) }
 func (*SyncRPCRequest) ProtoMessage()    {}
 func (*SyncRPCRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sync_rpc_service_2f3453598dcde6bc, []int{2}
+	return fileDescriptor_sync_rpc_service_ed93a68a7ef21a6f, []int{2}
 }
 func (m *SyncRPCRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SyncRPCRequest.Unmarshal(m, b)
@@ -241,13 +253,44 @@ func (m *SyncRPCRequest) GetConnClosed() bool {
 	return false
 }
 
+func (m *SyncRPCRequest) GetSeq() uint32 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *SyncRPCRequest) GetEndOfStream() bool {
+	if m != nil {
+		return m.EndOfStream
+	}
+	return false
+}
+
+func (m *SyncRPCRequest) GetWindowUpdate() uint32 {
+	if m != nil {
+		return m.WindowUpdate
+	}
+	return 0
+}
+
 // SyncRPCResponse is sent from gateway to cloud
 type SyncRPCResponse struct {
 	ReqId    uint32           `protobuf:"varint,1,opt,name=reqId,proto3" json:"reqId,omitempty"`
 	RespBody *GatewayResponse `protobuf:"bytes,2,opt,name=respBody,proto3" json:"respBody,omitempty"`
 	// gateway will send a heartBeat if it hasn't received SyncRPCRequests from cloud for a while.
 	// If it's a heartbeat, reqId and respBody will be ignored.
-	HeartBeat            bool     `protobuf:"varint,3,opt,name=heartBeat,proto3" json:"heartBeat,omitempty"`
+	HeartBeat bool `protobuf:"varint,3,opt,name=heartBeat,proto3" json:"heartBeat,omitempty"`
+	// seq numbers the response messages of a request, starting at 1.
+	// keepConnActive responses and responses from gateways which don't
+	// support streaming have seq 0.
+	Seq uint32 `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
+	// endOfStream is set on the last response message of a request
+	EndOfStream bool `protobuf:"varint,5,opt,name=endOfStream,proto3" json:"endOfStream,omitempty"`
+	// windowUpdate allows the cloud to send this many more request messages
+	// for reqId. A response with a windowUpdate and no respBody only carries
+	// the window update.
+	WindowUpdate         uint32   `protobuf:"varint,6,opt,name=windowUpdate,proto3" json:"windowUpdate,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -257,7 +300,7 @@ func (m *SyncRPCResponse) Reset()         { *m = SyncRPCResponse{} }
 func (m *SyncRPCResponse) String() string { return proto.CompactTextString(m) }
 func (*SyncRPCResponse) ProtoMessage()    {}
 func (*SyncRPCResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sync_rpc_service_2f3453598dcde6bc, []int{3}
+	return fileDescriptor_sync_rpc_service_ed93a68a7ef21a6f, []int{3}
 }
 func (m *SyncRPCResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SyncRPCResponse.Unmarshal(m, b)
@@ -298,6 +341,27 @@ func (m *SyncRPCResponse) GetHeartBeat() bool {
 	return false
 }
 
+func (m *SyncRPCResponse) GetSeq() uint32 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *SyncRPCResponse) GetEndOfStream() bool {
+	if m != nil {
+		return m.EndOfStream
+	}
+	return false
+}
+
+func (m *SyncRPCResponse) GetWindowUpdate() uint32 {
+	if m != nil {
+		return m.WindowUpdate
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*GatewayRequest)(nil), "magma.orc8r.GatewayRequest")
 	proto.RegisterMapType((map[string]string)(nil), "magma.orc8r.GatewayRequest.HeadersEntry")
@@ -485,39 +549,42 @@ var _SyncRPCService_serviceDesc = grpc.ServiceDesc{
 }
 
 func init() {
-	proto.RegisterFile("orc8r/protos/sync_rpc_service.proto", fileDescriptor_sync_rpc_service_2f3453598dcde6bc)
-}
-
-var fileDescriptor_sync_rpc_service_2f3453598dcde6bc = []byte{
-	// 476 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xcb, 0x6a, 0xdb, 0x40,
-	0x14, 0xed, 0xd8, 0xf1, 0x23, 0xd7, 0xa9, 0x53, 0x86, 0x10, 0x44, 0x9c, 0x16, 0xe3, 0x42, 0x51,
-	0x37, 0x72, 0x71, 0x29, 0x98, 0xec, 0x6a, 0x13, 0xfa, 0x58, 0x95, 0xc9, 0xaa, 0xdd, 0x84, 0x89,
-	0x74, 0xb1, 0x4c, 0x64, 0x8d, 0x7c, 0x67, 0xe4, 0xa0, 0x55, 0x3f, 0xa4, 0x1f, 0xd2, 0xdf, 0xea,
-	0x17, 0x94, 0xa2, 0x91, 0xe4, 0x47, 0x30, 0xee, 0x22, 0x2b, 0xcf, 0x3d, 0x73, 0x1f, 0xe7, 0xdc,
-	0x63, 0x0d, 0xbc, 0x56, 0xe4, 0x8f, 0x69, 0x98, 0x90, 0x32, 0x4a, 0x0f, 0x75, 0x16, 0xfb, 0xb7,
-	0x94, 0xf8, 0xb7, 0x1a, 0x69, 0x35, 0xf7, 0xd1, 0xb3, 0x38, 0xef, 0x2c, 0xe4, 0x6c, 0x21, 0x3d,
-	0x9b, 0x3a, 0xf8, 0xc3, 0xa0, 0xfb, 0x49, 0x1a, 0x7c, 0x90, 0x99, 0xc0, 0x65, 0x8a, 0xda, 0x70,
-	0x0e, 0x47, 0xb3, 0x87, 0x2f, 0x81, 0xc3, 0xfa, 0xcc, 0x3d, 0x16, 0xf6, 0xcc, 0x2f, 0xe1, 0x58,
-	0xa6, 0x26, 0x54, 0x34, 0x37, 0x99, 0x53, 0xb3, 0x17, 0x1b, 0x20, 0xaf, 0x48, 0xa4, 0x09, 0x9d,
-	0x7a, 0x51, 0x91, 0x9f, 0xf9, 0x04, 0x5a, 0x21, 0xca, 0x00, 0x49, 0x3b, 0x8d, 0x7e, 0xdd, 0xed,
-	0x8c, 0x5c, 0x6f, 0x6b, 0xae, 0xb7, 0x3b, 0xd3, 0xfb, 0x5c, 0xa4, 0x5e, 0xc7, 0x86, 0x32, 0x51,
-	0x15, 0x72, 0x07, 0x5a, 0x89, 0xcc, 0x22, 0x25, 0x03, 0xa7, 0xd9, 0x67, 0xee, 0x89, 0xa8, 0xc2,
-	0x8b, 0x2b, 0x38, 0xd9, 0x2e, 0xe1, 0x2f, 0xa0, 0x7e, 0x8f, 0x59, 0x49, 0x39, 0x3f, 0xf2, 0x33,
-	0x68, 0xac, 0x64, 0x94, 0x62, 0xc9, 0xb6, 0x08, 0xae, 0x6a, 0x63, 0x36, 0xf8, 0xcb, 0xe0, 0x74,
-	0x3d, 0x5e, 0x27, 0x2a, 0xd6, 0xc8, 0xcf, 0xa1, 0xa9, 0x8d, 0x34, 0xa9, 0x2e, 0x5b, 0x94, 0x11,
-	0x9f, 0x6e, 0x54, 0xd4, 0xac, 0x8a, 0xb7, 0xfb, 0x55, 0x14, 0x6d, 0xfe, 0x2f, 0xa3, 0xbe, 0x23,
-	0x23, 0xa7, 0x8d, 0x44, 0xce, 0x51, 0x41, 0x1b, 0x89, 0xf8, 0x1b, 0xe8, 0xde, 0x23, 0x26, 0x53,
-	0x15, 0xc7, 0x1f, 0x7d, 0x33, 0x5f, 0xa1, 0xd3, 0xe8, 0x33, 0xb7, 0x2d, 0x1e, 0xa1, 0x4f, 0x5a,
-	0xc0, 0x2f, 0x06, 0xdd, 0x9b, 0x2c, 0xf6, 0xc5, 0xb7, 0x69, 0xe5, 0xf9, 0x19, 0x34, 0x08, 0x97,
-	0xa5, 0xe9, 0xcf, 0x45, 0x11, 0xf0, 0x0f, 0xd0, 0x22, 0x5c, 0x4e, 0x54, 0x50, 0x78, 0xde, 0x19,
-	0xf5, 0x0e, 0x78, 0x28, 0xaa, 0xdc, 0xfc, 0xcf, 0x12, 0xa2, 0x24, 0x33, 0x41, 0x69, 0xac, 0xe2,
-	0xb6, 0xd8, 0x00, 0xfc, 0x15, 0x80, 0xaf, 0xe2, 0x78, 0x1a, 0x29, 0x8d, 0x81, 0x95, 0xde, 0x16,
-	0x5b, 0xc8, 0xe0, 0x27, 0x9c, 0xae, 0xc9, 0x95, 0xee, 0xec, 0x67, 0x37, 0x86, 0x36, 0xa1, 0x4e,
-	0xb6, 0xe8, 0x5d, 0x1e, 0x32, 0x47, 0xac, 0xb3, 0x0f, 0x13, 0x1c, 0xfd, 0xde, 0xac, 0xe7, 0xa6,
-	0xf8, 0x70, 0xf8, 0x77, 0x38, 0xbf, 0xd6, 0x46, 0xde, 0x45, 0x73, 0x1d, 0x56, 0x57, 0x86, 0x50,
-	0x2e, 0xf8, 0xee, 0xc8, 0x47, 0xc4, 0x2f, 0x7a, 0xfb, 0x6f, 0xed, 0xbe, 0x06, 0xcf, 0x5c, 0xf6,
-	0x8e, 0xf1, 0xaf, 0xd0, 0x2a, 0xf1, 0x27, 0xf7, 0x9a, 0xbc, 0xfc, 0xd1, 0xb3, 0x39, 0xc3, 0xe2,
-	0x19, 0xf0, 0x23, 0x95, 0x06, 0xc3, 0x99, 0x2a, 0xdf, 0x83, 0xbb, 0xa6, 0xfd, 0x7d, 0xff, 0x2f,
-	0x00, 0x00, 0xff, 0xff, 0xc3, 0x69, 0x82, 0xd4, 0x26, 0x04, 0x00, 0x00,
+	proto.RegisterFile("orc8r/protos/sync_rpc_service.proto", fileDescriptor_sync_rpc_service_ed93a68a7ef21a6f)
+}
+
+var fileDescriptor_sync_rpc_service_ed93a68a7ef21a6f = []byte{
+	// 526 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0xcd, 0x6e, 0x1a, 0x31,
+	0x10, 0xc7, 0x6b, 0xbe, 0x33, 0x10, 0x52, 0x59, 0x51, 0xb4, 0x0a, 0x69, 0x85, 0xa8, 0x54, 0x6d,
+	0x2f, 0x50, 0x51, 0x55, 0x42, 0xb9, 0x15, 0x14, 0xf5, 0xe3, 0xd2, 0xca, 0xa8, 0x87, 0xf6, 0x12,
+	0x39, 0xbb, 0x53, 0x40, 0x01, 0x7b, 0xb1, 0x0d, 0x68, 0x9f, 0xac, 0x8f, 0xd2, 0xd7, 0xa8, 0xd4,
+	0x7b, 0x55, 0xad, 0xbd, 0xc0, 0x92, 0x92, 0x70, 0xc8, 0x89, 0x99, 0xbf, 0x67, 0xc6, 0xf3, 0xdb,
+	0xff, 0xb2, 0xf0, 0x42, 0xaa, 0xa0, 0xa7, 0x3a, 0x91, 0x92, 0x46, 0xea, 0x8e, 0x8e, 0x45, 0x70,
+	0xad, 0xa2, 0xe0, 0x5a, 0xa3, 0x5a, 0x4e, 0x02, 0x6c, 0x5b, 0x9d, 0x56, 0x67, 0x7c, 0x34, 0xe3,
+	0x6d, 0x5b, 0xda, 0xfa, 0x4d, 0xa0, 0xfe, 0x9e, 0x1b, 0x5c, 0xf1, 0x98, 0xe1, 0x7c, 0x81, 0xda,
+	0x50, 0x0a, 0x85, 0xd1, 0xea, 0x63, 0xe8, 0x91, 0x26, 0xf1, 0x8f, 0x98, 0x8d, 0xe9, 0x05, 0x1c,
+	0xf1, 0x85, 0x19, 0x4b, 0x35, 0x31, 0xb1, 0x97, 0xb3, 0x07, 0x5b, 0x21, 0xe9, 0x88, 0xb8, 0x19,
+	0x7b, 0x79, 0xd7, 0x91, 0xc4, 0xb4, 0x0f, 0xe5, 0x31, 0xf2, 0x10, 0x95, 0xf6, 0x8a, 0xcd, 0xbc,
+	0x5f, 0xed, 0xfa, 0xed, 0xcc, 0xbd, 0xed, 0xdd, 0x3b, 0xdb, 0x1f, 0x5c, 0xe9, 0x95, 0x30, 0x2a,
+	0x66, 0xeb, 0x46, 0xea, 0x41, 0x39, 0xe2, 0xf1, 0x54, 0xf2, 0xd0, 0x2b, 0x35, 0x89, 0x5f, 0x63,
+	0xeb, 0xf4, 0xfc, 0x12, 0x6a, 0xd9, 0x16, 0xfa, 0x14, 0xf2, 0xb7, 0x18, 0xa7, 0x2b, 0x27, 0x21,
+	0x3d, 0x85, 0xe2, 0x92, 0x4f, 0x17, 0x98, 0x6e, 0xeb, 0x92, 0xcb, 0x5c, 0x8f, 0xb4, 0xfe, 0x12,
+	0x38, 0xd9, 0x5c, 0xaf, 0x23, 0x29, 0x34, 0xd2, 0x33, 0x28, 0x69, 0xc3, 0xcd, 0x42, 0xa7, 0x23,
+	0xd2, 0x8c, 0x0e, 0xb6, 0x14, 0x39, 0x4b, 0xf1, 0x6a, 0x3f, 0x85, 0x1b, 0x73, 0x18, 0x23, 0xbf,
+	0x83, 0x91, 0xac, 0x8d, 0x4a, 0x79, 0x05, 0xb7, 0x36, 0x2a, 0x45, 0x5f, 0x42, 0xfd, 0x16, 0x31,
+	0x1a, 0x48, 0x21, 0xde, 0x05, 0x66, 0xb2, 0x44, 0xaf, 0xd8, 0x24, 0x7e, 0x85, 0xdd, 0x51, 0x1f,
+	0xf5, 0x00, 0xfe, 0x10, 0xa8, 0x0f, 0x63, 0x11, 0xb0, 0x2f, 0x83, 0xb5, 0xe7, 0xa7, 0x50, 0x54,
+	0x38, 0x4f, 0x4d, 0x3f, 0x66, 0x2e, 0xa1, 0x6f, 0xa1, 0xac, 0x70, 0xde, 0x97, 0xa1, 0xf3, 0xbc,
+	0xda, 0x6d, 0x3c, 0xe0, 0x21, 0x5b, 0xd7, 0x26, 0x2f, 0xcb, 0x18, 0xb9, 0x32, 0x7d, 0xe4, 0xc6,
+	0x12, 0x57, 0xd8, 0x56, 0xa0, 0xcf, 0x01, 0x02, 0x29, 0xc4, 0x60, 0x2a, 0x35, 0x86, 0x16, 0xbd,
+	0xc2, 0x32, 0x4a, 0x42, 0xa2, 0x71, 0x6e, 0xb1, 0x8f, 0x59, 0x12, 0xd2, 0x26, 0x54, 0x51, 0x84,
+	0x9f, 0x7f, 0x0c, 0x8d, 0x42, 0x3e, 0xb3, 0xaf, 0x42, 0x85, 0x65, 0x25, 0xda, 0x82, 0xda, 0x6a,
+	0x22, 0x42, 0xb9, 0xfa, 0x1a, 0x85, 0xdc, 0xa0, 0x57, 0xb6, 0xcd, 0x3b, 0x5a, 0xeb, 0x17, 0x81,
+	0x93, 0x0d, 0x75, 0x6a, 0xfb, 0x7e, 0xec, 0x1e, 0x54, 0x14, 0xea, 0x28, 0xc3, 0x7d, 0xf1, 0x90,
+	0xeb, 0x6c, 0x53, 0x7d, 0x80, 0x3c, 0x25, 0x2b, 0xdc, 0x4b, 0x56, 0x3c, 0x4c, 0x56, 0xfa, 0x9f,
+	0xac, 0xfb, 0x73, 0xeb, 0xe7, 0xd0, 0xfd, 0xd3, 0xe9, 0x37, 0x38, 0xbb, 0xd2, 0x86, 0xdf, 0x4c,
+	0x27, 0x7a, 0xbc, 0x3e, 0x72, 0x03, 0x77, 0x51, 0xee, 0x3c, 0x90, 0xf3, 0xc6, 0xfe, 0x53, 0x6b,
+	0x70, 0xeb, 0x89, 0x4f, 0x5e, 0x13, 0xfa, 0x09, 0xca, 0xa9, 0xfe, 0xe8, 0x59, 0xfd, 0x67, 0xdf,
+	0x1b, 0xb6, 0xa6, 0xe3, 0xbe, 0x5b, 0xc1, 0x54, 0x2e, 0xc2, 0xce, 0x48, 0xa6, 0x1f, 0xb0, 0x9b,
+	0x92, 0xfd, 0x7d, 0xf3, 0x6f, 0x00, 0xeb, 0xa6, 0xf4, 0x00, 0xd7, 0x04, 0x00, 0x00,
 }
@@ -12,7 +12,9 @@ package cert
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
 	"math/big"
 	"strings"
 )
@@ -36,6 +38,24 @@ func LoadCertAndPrivKey(certFile, keyFile string) (
 	return
 }
 
+// LoadCert loads a PEM encoded certificate, e.g. of a CA whose private key
+// is kept by an external signer
+func LoadCert(certFile string) (*x509.Certificate, error) {
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load certificate (%s): %s", certFile, err)
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("No PEM encoded certificate in %s", certFile)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse cert (%s): %s", certFile, err)
+	}
+	return cert, nil
+}
+
 // SerialToString converts big.Int to hexadecimal string with uppercace letters
 // (A,B,C,D,E,F), without base prefix ("0x") and without leading zeros
 func SerialToString(certSerialNumber *big.Int) string {
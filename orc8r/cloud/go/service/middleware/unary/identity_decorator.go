@@ -162,11 +162,30 @@ func SetIdentityFromContext(ctx context.Context, _ interface{}, info *grpc.Unary
 			}
 			log.Printf(
 				"Empty CTX Metadata from non-local %s client: %v", rpc, err)
+		} else {
+			// Local services (such as Obsidian) may call on behalf of an
+			// operator, only trust the operator ID from local callers
+			newCtx, err = getLocalOperatorContext(ctx, ctxMetadata)
 		}
 	}
 	return newCtx, newReq, resp, err
 }
 
+// getLocalOperatorContext returns a new Context carrying the Identity of the
+// operator a local caller is calling on behalf of, or nil if the call isn't
+// on behalf of an operator
+func getLocalOperatorContext(ctx context.Context, md metadata.MD) (context.Context, error) {
+	operatorIds, ok := md[protos.OperatorMetadataKey]
+	if !ok {
+		return nil, nil
+	}
+	if len(operatorIds) != 1 || len(operatorIds[0]) == 0 {
+		log.Printf("Invalid operator IDs found in metadata: %+v", md)
+		return nil, status.Error(codes.Unauthenticated, "Invalid Operator ID")
+	}
+	return identity.NewOperator(operatorIds[0]).NewContextWithIdentity(ctx), nil
+}
+
 // findGatewayIdentity returns 'decorated' Gateway Identity corresponding to the
 // given certificate serialNumber and it's certificate expiration time in Unix time seconds
 // The Identity is 'decorated' with all information that can be gathered about
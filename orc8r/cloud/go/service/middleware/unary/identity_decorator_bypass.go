@@ -19,6 +19,7 @@ var identityDecoratorBypassList = map[string]struct{}{
 	"/magma.Bootstrapper/GetChallenge": {},
 	"/magma.Bootstrapper/RequestSign":  {},
 
-	"/magma.orc8r.Bootstrapper/GetChallenge": {},
-	"/magma.orc8r.Bootstrapper/RequestSign":  {},
+	"/magma.orc8r.Bootstrapper/GetChallenge":      {},
+	"/magma.orc8r.Bootstrapper/RequestSign":       {},
+	"/magma.orc8r.Bootstrapper/RegisterWithToken": {},
 }
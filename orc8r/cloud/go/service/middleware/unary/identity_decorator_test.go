@@ -89,6 +89,15 @@ func (srv *testCheckindServer) List(
 	return new(protos.IDList), nil
 }
 
+// Returns when the gateway should renew its certificate
+func (srv *testCheckindServer) GetRenewalAdvice(
+	ctx context.Context, void *protos.Void) (*protos.RenewalAdvice, error) {
+
+	srv.lastClientIdentity =
+		proto.Clone(protos.GetClientIdentity(ctx)).(*protos.Identity)
+	return new(protos.RenewalAdvice), nil
+}
+
 func TestIdentityInjector(t *testing.T) {
 	magmad_test_init.StartTestService(t)
 	// Make sure to "share" in memory magmad DBs with interceptors
@@ -165,6 +174,21 @@ func TestIdentityInjector(t *testing.T) {
 	assert.Equal(t, gwid.NetworkId, testNetworkId)
 	assert.Equal(t, gwid.LogicalId, logicalId)
 
+	// Test local calls on behalf of an operator
+	ctx = protos.NewOutgoingContextWithOperator(
+		protos.NewOperatorIdentity("test_operator").NewContextWithIdentity(context.Background()))
+	_, err = magmaCheckindClient.Checkin(ctx, &request)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_operator", checkindServer.lastClientIdentity.GetOperator())
+
+	// The operator ID is ignored for calls from gateways
+	ctx = metadata.NewOutgoingContext(
+		context.Background(),
+		metadata.Pairs("x-magma-client-cert-serial", csn[0], protos.OperatorMetadataKey, "test_operator"))
+	_, err = magmaCheckindClient.Checkin(ctx, &request)
+	assert.NoError(t, err)
+	assert.Equal(t, testAgHwId, checkindServer.lastClientIdentity.GetGateway().GetHardwareId())
+
 	// Test CTX without any Identification related headers (Identity should
 	// not be injected by the middleware)
 	_, err = magmaCheckindClient.Checkin(context.Background(), &request)
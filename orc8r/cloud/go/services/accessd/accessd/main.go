@@ -35,6 +35,10 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to initialize datastore: %s", err)
 	}
+	err = ds.ApplyMigrations()
+	if err != nil {
+		log.Fatalf("Failed to migrate datastore: %s", err)
+	}
 
 	// Add servicers to the service
 	accessdServer := servicers.NewAccessdServer(ds)
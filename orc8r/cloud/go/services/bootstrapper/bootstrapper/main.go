@@ -12,17 +12,32 @@ import (
 	"crypto/rsa"
 	"flag"
 	"log"
+	"net"
+	"strings"
+	"time"
 
+	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/security/key"
 	"magma/orc8r/cloud/go/service"
 	"magma/orc8r/cloud/go/services/bootstrapper"
+	"magma/orc8r/cloud/go/services/bootstrapper/challenges"
+	"magma/orc8r/cloud/go/services/bootstrapper/ratelimit"
 	"magma/orc8r/cloud/go/services/bootstrapper/servicers"
+	"magma/orc8r/cloud/go/services/bootstrapper/tokens"
+	"magma/orc8r/cloud/go/sqorc"
 )
 
 var (
 	keyFile = flag.String("cak", "bootstrapper.key.pem", "Bootstrapper's Private Key file")
+
+	// Rate limits are enforced separately by each bootstrapper instance
+	hwIdRateLimit      = flag.Float64("hwid-rate-limit", 6, "Failed RequestSign attempts per minute allowed per hardware ID, 0 to disable the limit")
+	hwIdBurstLimit     = flag.Int("hwid-burst-limit", 10, "Burst of failed RequestSign attempts allowed per hardware ID")
+	sourceIpRateLimit  = flag.Float64("source-ip-rate-limit", 60, "Requests per minute to each RPC allowed per source IP, 0 to disable the limit")
+	sourceIpBurstLimit = flag.Int("source-ip-burst-limit", 100, "Burst of requests to each RPC allowed per source IP")
+	trustedProxies     = flag.String("trusted-proxies", "127.0.0.1/32,::1/128", "Comma-separated CIDRs of the proxies whose X-Forwarded-For header is trusted")
 )
 
 func main() {
@@ -37,16 +52,70 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to read private key: %s", err)
 	}
-	servicer, err := servicers.NewBootstrapperServer(privKey.(*rsa.PrivateKey))
+	db, err := sqorc.Open(datastore.SQL_DRIVER, datastore.DATABASE_SOURCE)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %s", err)
+	}
+	tokenStore := tokens.NewSQLStore(db, sqorc.GetSqlBuilder())
+	challengeStore := challenges.NewSQLStore(db, sqorc.GetSqlBuilder())
+	err = sqorc.ApplyServiceMigrations(db, sqorc.GetSqlBuilder(), bootstrapper.ServiceName)
+	if err == sqorc.ErrMigrationsUnsupported {
+		err = tokenStore.Initialize()
+		if err == nil {
+			err = challengeStore.Initialize()
+		}
+	}
+	if err != nil {
+		log.Fatalf("Failed to initialize bootstrapper database: %s", err)
+	}
+	limiters := servicers.RateLimiters{}
+	limiters.TrustedProxies, err = parseCIDRs(*trustedProxies)
+	if err != nil {
+		log.Fatalf("Invalid trusted proxies: %s", err)
+	}
+	if *hwIdRateLimit > 0 {
+		limiters.HwId = ratelimit.NewLimiter(*hwIdRateLimit, *hwIdBurstLimit)
+	}
+	if *sourceIpRateLimit > 0 {
+		limiters.SourceIp = ratelimit.NewLimiter(*sourceIpRateLimit, *sourceIpBurstLimit)
+	}
+	servicer, err := servicers.NewBootstrapperServer(privKey.(*rsa.PrivateKey), tokenStore, challengeStore, limiters)
 	if err != nil {
 		log.Fatalf("Failed to create bootstrapper servicer: %s", err)
 	}
 	protos.RegisterBootstrapperServer(srv.GrpcServer, servicer)
 	srv.GrpcServer.RegisterService(protos.GetLegacyBootstrapperDesc(), servicer)
 
+	// Start Expired Challenge Cleanup Ticker
+	cleanup := time.Tick(servicers.ChallengeExpireTime)
+	go func() {
+		for now := range cleanup {
+			err := challengeStore.DeleteExpired(now)
+			if err != nil {
+				log.Printf("Failed to delete expired challenges: %s", err)
+			}
+		}
+	}()
+
 	// Run the service
 	err = srv.Run()
 	if err != nil {
 		log.Fatalf("Error running service: %s", err)
 	}
 }
+
+func parseCIDRs(cidrs string) ([]*net.IPNet, error) {
+	var ret []*net.IPNet
+	for _, cidr := range strings.Split(cidrs, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if len(cidr) == 0 {
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, network)
+	}
+	return ret, nil
+}
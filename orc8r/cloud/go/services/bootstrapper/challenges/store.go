@@ -0,0 +1,135 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package challenges stores the challenges issued by the bootstrapper, so
+// that each challenge can only be answered once, by the gateway it was issued
+// to, across all bootstrapper instances.
+package challenges
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"magma/orc8r/cloud/go/sqorc"
+
+	sq "github.com/Masterminds/squirrel"
+	pkgerrors "github.com/pkg/errors"
+)
+
+const (
+	challengeTable = "bootstrapper_challenges"
+
+	hashCol      = "challenge_hash"
+	hwIdCol      = "hw_id"
+	expiresAtCol = "expires_at"
+)
+
+// ErrUnknownChallenge is returned when consuming a challenge which wasn't
+// issued to the gateway, has expired or has already been answered
+var ErrUnknownChallenge = errors.New("unknown challenge")
+
+// Store stores issued challenges by their hash
+type Store interface {
+	// Add records a challenge issued to a gateway, which can be answered
+	// until expiresAt (unix time in seconds)
+	Add(challengeHash string, hwId string, expiresAt int64) error
+	// Consume removes an unexpired challenge issued to a gateway.
+	// ErrUnknownChallenge is returned if there is no such challenge.
+	Consume(challengeHash string, hwId string, now time.Time) error
+	// DeleteExpired deletes challenges which expired before the given time
+	DeleteExpired(before time.Time) error
+}
+
+// Hash returns the hash a challenge is stored by
+func Hash(challenge []byte) string {
+	hash := sha256.Sum256(challenge)
+	return hex.EncodeToString(hash[:])
+}
+
+// SQLStore implements Store on a SQL database
+type SQLStore struct {
+	db      *sql.DB
+	builder sqorc.StatementBuilder
+}
+
+func NewSQLStore(db *sql.DB, builder sqorc.StatementBuilder) *SQLStore {
+	return &SQLStore{db: db, builder: builder}
+}
+
+// Initialize creates the challenge table if it doesn't exist
+func (store *SQLStore) Initialize() error {
+	_, err := sqorc.ExecInTx(
+		store.db,
+		func(*sql.Tx) error { return nil },
+		func(tx *sql.Tx) (interface{}, error) { return nil, CreateTables(tx, store.builder) },
+	)
+	return err
+}
+
+// CreateTables creates the challenge table if it doesn't exist
+func CreateTables(tx *sql.Tx, builder sqorc.StatementBuilder) error {
+	_, err := builder.CreateTable(challengeTable).
+		IfNotExists().
+		Column(hashCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+		Column(hwIdCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(expiresAtCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to create challenge table")
+	}
+	_, err = builder.CreateIndex("bootstrapper_challenges_expiry_idx").
+		IfNotExists().
+		On(challengeTable).
+		Columns(expiresAtCol).
+		RunWith(tx).
+		Exec()
+	return pkgerrors.Wrap(err, "failed to create challenge expiry index")
+}
+
+func (store *SQLStore) Add(challengeHash string, hwId string, expiresAt int64) error {
+	_, err := store.builder.Insert(challengeTable).
+		Columns(hashCol, hwIdCol, expiresAtCol).
+		Values(challengeHash, hwId, expiresAt).
+		RunWith(store.db).
+		Exec()
+	return pkgerrors.Wrap(err, "failed to insert challenge")
+}
+
+func (store *SQLStore) Consume(challengeHash string, hwId string, now time.Time) error {
+	// Concurrent answers to the same challenge can't both delete it
+	res, err := store.builder.Delete(challengeTable).
+		Where(sq.And{
+			sq.Eq{hashCol: challengeHash, hwIdCol: hwId},
+			sq.Gt{expiresAtCol: now.Unix()},
+		}).
+		RunWith(store.db).
+		Exec()
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to consume challenge")
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to get rows affected for consuming challenge")
+	}
+	if rowsAffected != 1 {
+		return ErrUnknownChallenge
+	}
+	return nil
+}
+
+func (store *SQLStore) DeleteExpired(before time.Time) error {
+	_, err := store.builder.Delete(challengeTable).
+		Where(sq.Lt{expiresAtCol: before.Unix()}).
+		RunWith(store.db).
+		Exec()
+	return pkgerrors.Wrap(err, "failed to delete expired challenges")
+}
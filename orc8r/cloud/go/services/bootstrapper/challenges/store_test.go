@@ -0,0 +1,51 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package challenges_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/services/bootstrapper/challenges"
+	"magma/orc8r/cloud/go/sqorc"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLStore(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	// Each connection to an in-memory sqlite DB gets its own database
+	db.SetMaxOpenConns(1)
+	store := challenges.NewSQLStore(db, sqorc.GetSqlBuilder())
+	assert.NoError(t, store.Initialize())
+	now := time.Now()
+
+	hash1 := challenges.Hash([]byte("challenge1"))
+	hash2 := challenges.Hash([]byte("challenge2"))
+	assert.NotEqual(t, hash1, hash2)
+	assert.NoError(t, store.Add(hash1, "hw1", now.Add(time.Minute).Unix()))
+	assert.NoError(t, store.Add(hash2, "hw2", now.Unix()))
+
+	// Challenges can only be answered by the gateway they were issued to
+	assert.Equal(t, challenges.ErrUnknownChallenge, store.Consume(hash1, "hw2", now))
+	// Challenges can only be answered once
+	assert.NoError(t, store.Consume(hash1, "hw1", now))
+	assert.Equal(t, challenges.ErrUnknownChallenge, store.Consume(hash1, "hw1", now))
+	// Expired challenges can't be answered
+	assert.Equal(t, challenges.ErrUnknownChallenge, store.Consume(hash2, "hw2", now))
+
+	// Deleting expired challenges leaves unexpired ones
+	hash3 := challenges.Hash([]byte("challenge3"))
+	assert.NoError(t, store.Add(hash3, "hw3", now.Add(time.Minute).Unix()))
+	assert.NoError(t, store.DeleteExpired(now.Add(time.Second)))
+	assert.Equal(t, challenges.ErrUnknownChallenge, store.Consume(hash2, "hw2", now.Add(-time.Hour)))
+	assert.NoError(t, store.Consume(hash3, "hw3", now))
+}
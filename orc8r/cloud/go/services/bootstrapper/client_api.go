@@ -0,0 +1,36 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package bootstrapper
+
+import (
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/registry"
+
+	"golang.org/x/net/context"
+)
+
+// Utility function to get a RPC connection to the bootstrapper service
+func getBootstrapperClient() (protos.BootstrapperClient, error) {
+	conn, err := registry.GetConnection(ServiceName)
+	if err != nil {
+		return nil, merrors.NewInitError(err, ServiceName)
+	}
+	return protos.NewBootstrapperClient(conn), err
+}
+
+// CreateRegistrationToken mints a single-use token which a new gateway can
+// register itself into a network & tier with
+func CreateRegistrationToken(req *protos.CreateRegistrationTokenRequest) (*protos.RegistrationToken, error) {
+	client, err := getBootstrapperClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.CreateRegistrationToken(context.Background(), req)
+}
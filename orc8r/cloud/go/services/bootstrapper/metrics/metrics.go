@@ -0,0 +1,38 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package metrics contains the bootstrapper's metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reasons for rejecting bootstrap attempts
+const (
+	ReasonHwIdRateLimit     = "hw_id_rate_limit"
+	ReasonSourceIpRateLimit = "source_ip_rate_limit"
+	ReasonReplayedChallenge = "replayed_challenge"
+)
+
+var (
+	// RejectedAttempts counts the bootstrap requests rejected for being rate
+	// limited or answering a challenge which wasn't issued or was answered
+	// already
+	RejectedAttempts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bootstrapper_rejected_attempts",
+			Help: "Number of rejected bootstrap requests",
+		},
+		[]string{"rpc", "reason"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(RejectedAttempts)
+}
@@ -0,0 +1,29 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package bootstrapper
+
+import (
+	"magma/orc8r/cloud/go/services/bootstrapper/challenges"
+	"magma/orc8r/cloud/go/services/bootstrapper/tokens"
+	"magma/orc8r/cloud/go/sqorc"
+)
+
+func init() {
+	sqorc.MustRegisterMigrations(sqorc.Migration{
+		Service:     ServiceName,
+		Version:     1,
+		Description: "create registration token table",
+		Up:          tokens.CreateTables,
+	}, sqorc.Migration{
+		Service:     ServiceName,
+		Version:     2,
+		Description: "create challenge table",
+		Up:          challenges.CreateTables,
+	})
+}
@@ -0,0 +1,70 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package handlers
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"magma/orc8r/cloud/go/obsidian/handlers"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/bootstrapper"
+
+	"github.com/labstack/echo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	RegistrationTokens = handlers.NETWORKS_ROOT + "/:network_id/registration_tokens"
+)
+
+// GetObsidianHandlers returns all obsidian handlers for bootstrapper
+func GetObsidianHandlers() []handlers.Handler {
+	return []handlers.Handler{
+		{Path: RegistrationTokens, Methods: handlers.POST, HandlerFunc: createRegistrationToken},
+	}
+}
+
+// createRegistrationToken mints a single-use token which a new gateway can
+// register itself into the network with. The body holds the tier_id of the
+// gateway, and optionally the token's ttl_sec and the gateway_name.
+func createRegistrationToken(c echo.Context) error {
+	networkId, nerr := handlers.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+	body, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return handlers.HttpError(err, http.StatusBadRequest)
+	}
+	req := &protos.CreateRegistrationTokenRequest{}
+	err = protos.Unmarshal(body, req)
+	if err != nil {
+		return handlers.HttpError(err, http.StatusBadRequest)
+	}
+	req.NetworkId = networkId
+
+	token, err := bootstrapper.CreateRegistrationToken(req)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			return handlers.HttpError(err, http.StatusBadRequest)
+		case codes.NotFound:
+			return handlers.HttpError(err, http.StatusNotFound)
+		default:
+			return handlers.HttpError(err, http.StatusInternalServerError)
+		}
+	}
+	marshaledToken, err := protos.Marshal(token)
+	if err != nil {
+		return handlers.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.JSONBlob(http.StatusCreated, marshaledToken)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package ratelimit limits the rate of requests per key, e.g. per client
+// address, with an in-memory token bucket for each key. Buckets aren't shared
+// between processes, so each replica of a service enforces its own limits.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// pruneInterval is how often the buckets which are full again are dropped
+const pruneInterval = time.Minute
+
+// Limiter allows bursts of up to burst requests per key, refilled at
+// perMinute requests per minute. A nil Limiter allows all requests.
+type Limiter struct {
+	perMinute float64
+	burst     float64
+
+	sync.Mutex
+	buckets   map[string]*bucket
+	lastPrune time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func NewLimiter(perMinute float64, burst int) *Limiter {
+	return &Limiter{
+		perMinute: perMinute,
+		burst:     float64(burst),
+		buckets:   map[string]*bucket{},
+	}
+}
+
+// Allow takes a token from the bucket of the key at the given time, and
+// returns whether there was one to take.
+func (l *Limiter) Allow(key string, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	l.Lock()
+	defer l.Unlock()
+
+	if now.Sub(l.lastPrune) >= pruneInterval {
+		l.prune(now)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Check returns whether the bucket of the key has a token at the given time,
+// without taking it.
+func (l *Limiter) Check(key string, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	l.Lock()
+	defer l.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		return true
+	}
+	return l.refill(b, now) >= 1
+}
+
+// refill returns the tokens in a bucket at the given time
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	elapsed := now.Sub(b.last)
+	if elapsed <= 0 {
+		return b.tokens
+	}
+	tokens := b.tokens + elapsed.Minutes()*l.perMinute
+	if tokens > l.burst {
+		return l.burst
+	}
+	return tokens
+}
+
+// prune drops the buckets which are full again, since they're equivalent to
+// new buckets
+func (l *Limiter) prune(now time.Time) {
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastPrune = now
+}
@@ -0,0 +1,52 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/services/bootstrapper/ratelimit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	limiter := ratelimit.NewLimiter(6, 2)
+	now := time.Now()
+
+	// Bursts are allowed up to the burst size
+	assert.True(t, limiter.Allow("key1", now))
+	assert.True(t, limiter.Allow("key1", now))
+	assert.False(t, limiter.Allow("key1", now))
+	// Keys have separate buckets
+	assert.True(t, limiter.Allow("key2", now))
+
+	// Buckets are refilled at the rate, up to the burst size
+	assert.False(t, limiter.Allow("key1", now.Add(5*time.Second)))
+	assert.True(t, limiter.Allow("key1", now.Add(10*time.Second)))
+	assert.False(t, limiter.Allow("key1", now.Add(10*time.Second)))
+	later := now.Add(time.Hour)
+	assert.True(t, limiter.Allow("key1", later))
+	assert.True(t, limiter.Allow("key1", later))
+	assert.False(t, limiter.Allow("key1", later))
+
+	// Checking a bucket doesn't take a token
+	assert.True(t, limiter.Check("key3", now))
+	assert.True(t, limiter.Allow("key3", now))
+	assert.True(t, limiter.Check("key3", now))
+	assert.True(t, limiter.Allow("key3", now))
+	assert.False(t, limiter.Check("key3", now))
+	assert.True(t, limiter.Check("key3", now.Add(10*time.Second)))
+
+	// A nil limiter allows everything
+	var nilLimiter *ratelimit.Limiter
+	assert.True(t, nilLimiter.Allow("key1", now))
+	assert.True(t, nilLimiter.Check("key1", now))
+}
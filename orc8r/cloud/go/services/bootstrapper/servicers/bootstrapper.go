@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/bootstrapper/challenges"
+	"magma/orc8r/cloud/go/services/bootstrapper/tokens"
 	"magma/orc8r/cloud/go/services/certifier"
 	"magma/orc8r/cloud/go/services/magmad"
 
@@ -40,20 +42,40 @@ const GatewayCertificateDuration = time.Hour * 97 // 4 days, lifetime of GW Cert
 
 type BootstrapperServer struct {
 	privKey *rsa.PrivateKey
+	// tokenStore stores registration tokens. Registration with tokens is
+	// disabled if it's nil.
+	tokenStore tokens.Store
+	// challengeStore stores issued challenges, so that each can only be
+	// answered once. Replayed challenges aren't detected if it's nil.
+	challengeStore challenges.Store
+	limiters       RateLimiters
 }
 
-func NewBootstrapperServer(privKey *rsa.PrivateKey) (*BootstrapperServer, error) {
+func NewBootstrapperServer(
+	privKey *rsa.PrivateKey,
+	tokenStore tokens.Store,
+	challengeStore challenges.Store,
+	limiters RateLimiters,
+) (*BootstrapperServer, error) {
 	srv := new(BootstrapperServer)
 	if privKey.N.BitLen() < MinKeyLength {
 		return nil, errorLogger(fmt.Errorf("Private key is too short"))
 	}
 	srv.privKey = privKey
+	srv.tokenStore = tokenStore
+	srv.challengeStore = challengeStore
+	srv.limiters = limiters
 	return srv, nil
 }
 
 // generate challenge in the format of [randomText : timestamp : signature]
 // the format is designed mainly for demo/interface design, subjects to change in the future
 func (srv *BootstrapperServer) GetChallenge(ctx context.Context, hwId *protos.AccessGatewayID) (*protos.Challenge, error) {
+	err := srv.checkSourceIpRateLimit(ctx, "GetChallenge")
+	if err != nil {
+		return nil, errorLogger(err)
+	}
+
 	// retrieve the challenge key type
 	gatewayRecord, err := magmad.FindGatewayRecordWithHwId(hwId.Id)
 	if err != nil {
@@ -73,8 +95,9 @@ func (srv *BootstrapperServer) GetChallenge(ctx context.Context, hwId *protos.Ac
 	}
 
 	// generate timestamp
+	issueTime := time.Now().UTC()
 	timeBytes := make([]byte, TimeLength)
-	binary.BigEndian.PutUint64(timeBytes, uint64(time.Now().UTC().Unix()))
+	binary.BigEndian.PutUint64(timeBytes, uint64(issueTime.Unix()))
 
 	// generate challenge
 	challenge := append(randText, timeBytes...)
@@ -85,6 +108,15 @@ func (srv *BootstrapperServer) GetChallenge(ctx context.Context, hwId *protos.Ac
 	}
 	challenge = append(challenge, signature...)
 
+	// record the challenge, so that it can only be answered once
+	if srv.challengeStore != nil {
+		expiresAt := issueTime.Add(ChallengeExpireTime).Unix()
+		err = srv.challengeStore.Add(challenges.Hash(challenge), hwId.Id, expiresAt)
+		if err != nil {
+			return nil, errorLogger(status.Errorf(codes.Internal, "Failed to store the challenge: %s", err))
+		}
+	}
+
 	return &protos.Challenge{KeyType: gatewayRecord.Key.KeyType, Challenge: challenge}, nil
 }
 
@@ -92,17 +124,57 @@ func (srv *BootstrapperServer) GetChallenge(ctx context.Context, hwId *protos.Ac
 func (srv *BootstrapperServer) RequestSign(
 	ctx context.Context, resp *protos.Response) (*protos.Certificate, error) {
 
-	hwId := resp.HwId.Id
+	hwId := resp.GetHwId().GetId()
+	err := srv.checkSourceIpRateLimit(ctx, "RequestSign")
+	if err != nil {
+		return nil, errorLogger(err)
+	}
+	err = srv.checkHwIdRateLimit(hwId)
+	if err != nil {
+		return nil, errorLogger(err)
+	}
+	err = srv.verifyResponse(hwId, resp)
+	if err != nil {
+		// Internal errors aren't the gateway's fault
+		if status.Code(err) != codes.Internal {
+			srv.chargeFailedAttempt(hwId)
+		}
+		return nil, errorLogger(err)
+	}
+
+	// Ignore requested cert duration & overwrite it with our own
+	if resp.Csr != nil {
+		resp.Csr.ValidTime = ptypes.DurationProto(GatewayCertificateDuration)
+		// Record the gateway's network in the certificate's identity, so
+		// that the certifier can group gateway certificates by network
+		if gwId := resp.Csr.Id.GetGateway(); gwId != nil && len(gwId.NetworkId) == 0 {
+			networkId, err := magmad.FindGatewayNetworkId(hwId)
+			if err != nil {
+				log.Printf("Failed to find network of gateway %s: %s", hwId, err)
+			} else {
+				gwId.NetworkId = networkId
+			}
+		}
+	}
+	cert, err := certifier.SignCSR(resp.Csr)
+	if err != nil {
+		return nil, errorLogger(status.Errorf(codes.Aborted, "Failed to sign csr: %s", err))
+	}
+	return cert, nil
+}
+
+// verifyResponse verifies that the response answers a challenge issued to the
+// gateway. The challenge is only consumed once the response is verified, so
+// that a bad response doesn't use up the gateway's challenge.
+func (srv *BootstrapperServer) verifyResponse(hwId string, resp *protos.Response) error {
 	gatewayRecord, err := magmad.FindGatewayRecordWithHwId(hwId)
 	if err != nil {
-		return nil, errorLogger(status.Errorf(
-			codes.NotFound, "Failed to find gateway record: %s", err))
+		return status.Errorf(codes.NotFound, "Failed to find gateway record: %s", err)
 	}
 
 	err = srv.verifyChallenge(resp.Challenge)
 	if err != nil {
-		return nil, errorLogger(status.Errorf(
-			codes.Aborted, "Failed to verify challenge: %s", err))
+		return status.Errorf(codes.Aborted, "Failed to verify challenge: %s", err)
 	}
 
 	// verify authentication / real response
@@ -117,19 +189,9 @@ func (srv *BootstrapperServer) RequestSign(
 		err = fmt.Errorf("Unsupported key type: %s", gatewayRecord.Key.KeyType)
 	}
 	if err != nil {
-		return nil, errorLogger(status.Errorf(
-			codes.Aborted, "Failed to verify response: %s", err))
-	}
-
-	// Ignore requested cert duration & overwrite it with our own
-	if resp.Csr != nil {
-		resp.Csr.ValidTime = ptypes.DurationProto(GatewayCertificateDuration)
-	}
-	cert, err := certifier.SignCSR(resp.Csr)
-	if err != nil {
-		return nil, errorLogger(status.Errorf(codes.Aborted, "Failed to sign csr: %s", err))
+		return status.Errorf(codes.Aborted, "Failed to verify response: %s", err)
 	}
-	return cert, nil
+	return srv.consumeChallenge(resp.Challenge, hwId)
 }
 
 // return the length of signature (number of bytes)
@@ -265,13 +265,13 @@ func TestBootstrapperServer(t *testing.T) {
 	// create bootstrapper with short key
 	privateKey, err := key.GenerateKey("", 512)
 	assert.NoError(t, err)
-	_, err = servicers.NewBootstrapperServer(privateKey.(*rsa.PrivateKey))
+	_, err = servicers.NewBootstrapperServer(privateKey.(*rsa.PrivateKey), nil, nil, servicers.RateLimiters{})
 	assert.Error(t, err)
 
 	// create bootstrapper server
 	privateKey, err = key.GenerateKey("", 2048)
 	assert.NoError(t, err)
-	srv, err := servicers.NewBootstrapperServer(privateKey.(*rsa.PrivateKey))
+	srv, err := servicers.NewBootstrapperServer(privateKey.(*rsa.PrivateKey), nil, nil, servicers.RateLimiters{})
 
 	// for signing csr
 	certifier_test_init.StartTestService(t)
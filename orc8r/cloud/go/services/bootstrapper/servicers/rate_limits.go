@@ -0,0 +1,146 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"magma/orc8r/cloud/go/services/bootstrapper/challenges"
+	"magma/orc8r/cloud/go/services/bootstrapper/metrics"
+	"magma/orc8r/cloud/go/services/bootstrapper/ratelimit"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// forwardedForHeader is set by the proxies in front of the bootstrapper to
+// the addresses of the client and of each proxy the request went through,
+// except the last
+const forwardedForHeader = "x-forwarded-for"
+
+// RateLimiters limit the rate of bootstrapper requests per source IP, and of
+// failed RequestSign attempts per hardware ID. Nil limiters don't limit.
+//
+// Limits are tracked in memory by each bootstrapper instance, so with
+// multiple replicas a client can make up to the limit to each replica.
+type RateLimiters struct {
+	HwId     *ratelimit.Limiter
+	SourceIp *ratelimit.Limiter
+	// TrustedProxies are the networks of the proxies in front of the
+	// bootstrapper. The forwarded-for header is only read from requests
+	// sent by a trusted proxy, since any client can set it.
+	TrustedProxies []*net.IPNet
+}
+
+// checkSourceIpRateLimit takes a request to the RPC from the source IP of the
+// request out of its rate limit.
+func (srv *BootstrapperServer) checkSourceIpRateLimit(ctx context.Context, rpc string) error {
+	sourceIp := srv.getSourceIp(ctx)
+	if len(sourceIp) > 0 && !srv.limiters.SourceIp.Allow(rpc+"/"+sourceIp, time.Now()) {
+		metrics.RejectedAttempts.WithLabelValues(rpc, metrics.ReasonSourceIpRateLimit).Inc()
+		return status.Errorf(codes.ResourceExhausted, "Too many requests from %s", sourceIp)
+	}
+	return nil
+}
+
+// checkHwIdRateLimit rejects a RequestSign attempt for the hardware ID if
+// it has used up its failed attempts. Only failed attempts are charged,
+// since anyone can request a challenge for any hardware ID.
+func (srv *BootstrapperServer) checkHwIdRateLimit(hwId string) error {
+	if !srv.limiters.HwId.Check(hwId, time.Now()) {
+		metrics.RejectedAttempts.WithLabelValues("RequestSign", metrics.ReasonHwIdRateLimit).Inc()
+		return status.Errorf(codes.ResourceExhausted, "Too many failed attempts for gateway %s", hwId)
+	}
+	return nil
+}
+
+// chargeFailedAttempt takes a failed RequestSign attempt for the hardware ID
+// out of its rate limit
+func (srv *BootstrapperServer) chargeFailedAttempt(hwId string) {
+	srv.limiters.HwId.Allow(hwId, time.Now())
+}
+
+// consumeChallenge makes sure that the challenge was issued to the gateway
+// and hasn't been answered before
+func (srv *BootstrapperServer) consumeChallenge(challenge []byte, hwId string) error {
+	if srv.challengeStore == nil {
+		return nil
+	}
+	err := srv.challengeStore.Consume(challenges.Hash(challenge), hwId, time.Now())
+	if err == challenges.ErrUnknownChallenge {
+		metrics.RejectedAttempts.WithLabelValues("RequestSign", metrics.ReasonReplayedChallenge).Inc()
+		return status.Error(codes.Aborted, "Challenge was not issued to the gateway or was already answered")
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to consume the challenge: %s", err)
+	}
+	return nil
+}
+
+// getSourceIp returns the address of the client which sent the request. If
+// the request was sent by a trusted proxy, it is the last address in the
+// forwarded-for header which isn't a trusted proxy. Otherwise it is the
+// peer's address.
+func (srv *BootstrapperServer) getSourceIp(ctx context.Context) string {
+	peerIp := getPeerIp(ctx)
+	if len(peerIp) == 0 || !srv.isTrustedProxy(peerIp) {
+		return peerIp
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return peerIp
+	}
+	var forwardedFor []string
+	for _, header := range md.Get(forwardedForHeader) {
+		for _, addr := range strings.Split(header, ",") {
+			if addr = strings.TrimSpace(addr); len(addr) > 0 {
+				forwardedFor = append(forwardedFor, addr)
+			}
+		}
+	}
+	for i := len(forwardedFor) - 1; i >= 0; i-- {
+		if !srv.isTrustedProxy(forwardedFor[i]) {
+			return forwardedFor[i]
+		}
+	}
+	if len(forwardedFor) > 0 {
+		return forwardedFor[0]
+	}
+	return peerIp
+}
+
+func (srv *BootstrapperServer) isTrustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, network := range srv.limiters.TrustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func getPeerIp(ctx context.Context) string {
+	caller, ok := peer.FromContext(ctx)
+	if !ok || caller == nil || caller.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(caller.Addr.String())
+	if err != nil {
+		return caller.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,174 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers_test
+
+import (
+	"crypto/rsa"
+	"net"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/security/key"
+	"magma/orc8r/cloud/go/services/bootstrapper/challenges"
+	"magma/orc8r/cloud/go/services/bootstrapper/ratelimit"
+	"magma/orc8r/cloud/go/services/bootstrapper/servicers"
+	certifier_test_init "magma/orc8r/cloud/go/services/certifier/test_init"
+	certifier_test_utils "magma/orc8r/cloud/go/services/certifier/test_utils"
+	"magma/orc8r/cloud/go/services/magmad"
+	magmad_protos "magma/orc8r/cloud/go/services/magmad/protos"
+	magmad_test_init "magma/orc8r/cloud/go/services/magmad/test_init"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestBootstrapperServer_ReplayProtection(t *testing.T) {
+	magmad_test_init.StartTestService(t)
+	certifier_test_init.StartTestService(t)
+	networkId, err := magmad.RegisterNetwork(
+		&magmad_protos.MagmadNetworkRecord{Name: "Test Network Name"},
+		"replay_test_network")
+	assert.NoError(t, err)
+	registerEchoGateway(t, networkId, "test_ag_replay")
+	registerEchoGateway(t, networkId, "test_ag_replay2")
+
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	challengeStore := challenges.NewSQLStore(db, sqorc.GetSqlBuilder())
+	assert.NoError(t, challengeStore.Initialize())
+	srv := newTestBootstrapperServer(t, challengeStore, servicers.RateLimiters{})
+	ctx := context.Background()
+
+	challenge, err := srv.GetChallenge(ctx, &protos.AccessGatewayID{Id: "test_ag_replay"})
+	assert.NoError(t, err)
+
+	// Challenges can only be answered by the gateway they were issued to
+	_, err = srv.RequestSign(ctx, newEchoResponse(t, "test_ag_replay2", challenge))
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	cert, err := srv.RequestSign(ctx, newEchoResponse(t, "test_ag_replay", challenge))
+	assert.NoError(t, err)
+	assert.NotNil(t, cert)
+
+	// Bad responses don't use up the challenge
+	challenge, err = srv.GetChallenge(ctx, &protos.AccessGatewayID{Id: "test_ag_replay"})
+	assert.NoError(t, err)
+	badResp := newEchoResponse(t, "test_ag_replay", challenge)
+	badResp.Response = &protos.Response_EchoResponse{EchoResponse: &protos.Response_Echo{Response: []byte("bad")}}
+	_, err = srv.RequestSign(ctx, badResp)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	cert, err = srv.RequestSign(ctx, newEchoResponse(t, "test_ag_replay", challenge))
+	assert.NoError(t, err)
+	assert.NotNil(t, cert)
+
+	// Challenges can only be answered once
+	_, err = srv.RequestSign(ctx, newEchoResponse(t, "test_ag_replay", challenge))
+	assert.Equal(t, codes.Aborted, status.Code(err))
+}
+
+func TestBootstrapperServer_RateLimits(t *testing.T) {
+	magmad_test_init.StartTestService(t)
+	networkId, err := magmad.RegisterNetwork(
+		&magmad_protos.MagmadNetworkRecord{Name: "Test Network Name"},
+		"rate_limit_test_network")
+	assert.NoError(t, err)
+	registerEchoGateway(t, networkId, "test_ag_limit")
+	registerEchoGateway(t, networkId, "test_ag_limit2")
+	registerEchoGateway(t, networkId, "test_ag_limit3")
+
+	// Limits failed attempts per hardware ID
+	srv := newTestBootstrapperServer(t, nil, servicers.RateLimiters{HwId: ratelimit.NewLimiter(1, 2)})
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, err = srv.GetChallenge(ctx, &protos.AccessGatewayID{Id: "test_ag_limit"})
+		assert.NoError(t, err)
+	}
+	for i := 0; i < 2; i++ {
+		_, err = srv.RequestSign(ctx, &protos.Response{HwId: &protos.AccessGatewayID{Id: "test_ag_limit"}})
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	}
+	_, err = srv.RequestSign(ctx, &protos.Response{HwId: &protos.AccessGatewayID{Id: "test_ag_limit"}})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, err = srv.RequestSign(ctx, &protos.Response{HwId: &protos.AccessGatewayID{Id: "test_ag_limit2"}})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	// Challenges can still be requested
+	_, err = srv.GetChallenge(ctx, &protos.AccessGatewayID{Id: "test_ag_limit"})
+	assert.NoError(t, err)
+
+	// Limits per source IP, forwarded by a trusted proxy
+	_, loopback, err := net.ParseCIDR("127.0.0.1/32")
+	assert.NoError(t, err)
+	srv = newTestBootstrapperServer(t, nil, servicers.RateLimiters{
+		SourceIp:       ratelimit.NewLimiter(1, 1),
+		TrustedProxies: []*net.IPNet{loopback},
+	})
+	proxyCtx := newPeerContext(ctx, "127.0.0.1")
+	ctx1 := metadata.NewIncomingContext(proxyCtx, metadata.Pairs("x-forwarded-for", "192.168.0.1"))
+	ctx2 := metadata.NewIncomingContext(proxyCtx, metadata.Pairs("x-forwarded-for", "192.168.0.2"))
+	_, err = srv.GetChallenge(ctx1, &protos.AccessGatewayID{Id: "test_ag_limit"})
+	assert.NoError(t, err)
+	_, err = srv.GetChallenge(ctx1, &protos.AccessGatewayID{Id: "test_ag_limit2"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, err = srv.GetChallenge(ctx2, &protos.AccessGatewayID{Id: "test_ag_limit3"})
+	assert.NoError(t, err)
+	// Addresses prepended by the client are ignored
+	spoofedCtx := metadata.NewIncomingContext(proxyCtx, metadata.Pairs("x-forwarded-for", "10.0.0.1, 192.168.0.1"))
+	_, err = srv.GetChallenge(spoofedCtx, &protos.AccessGatewayID{Id: "test_ag_limit3"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Forwarded addresses from untrusted peers are ignored
+	untrustedCtx := newPeerContext(ctx, "192.168.0.3")
+	_, err = srv.GetChallenge(metadata.NewIncomingContext(untrustedCtx, metadata.Pairs("x-forwarded-for", "192.168.0.4")), &protos.AccessGatewayID{Id: "test_ag_limit"})
+	assert.NoError(t, err)
+	_, err = srv.GetChallenge(metadata.NewIncomingContext(untrustedCtx, metadata.Pairs("x-forwarded-for", "192.168.0.5")), &protos.AccessGatewayID{Id: "test_ag_limit"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func newTestBootstrapperServer(t *testing.T, challengeStore challenges.Store, limiters servicers.RateLimiters) *servicers.BootstrapperServer {
+	privateKey, err := key.GenerateKey("", 2048)
+	assert.NoError(t, err)
+	srv, err := servicers.NewBootstrapperServer(privateKey.(*rsa.PrivateKey), nil, challengeStore, limiters)
+	assert.NoError(t, err)
+	return srv
+}
+
+func newPeerContext(ctx context.Context, ip string) context.Context {
+	return peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234}})
+}
+
+func registerEchoGateway(t *testing.T, networkId string, hwId string) {
+	_, err := magmad.RegisterGateway(
+		networkId,
+		&magmad_protos.AccessGatewayRecord{
+			HwId: &protos.AccessGatewayID{Id: hwId},
+			Name: hwId,
+			Key:  &protos.ChallengeKey{KeyType: protos.ChallengeKey_ECHO},
+		})
+	assert.NoError(t, err)
+}
+
+func newEchoResponse(t *testing.T, hwId string, challenge *protos.Challenge) *protos.Response {
+	csr, err := certifier_test_utils.CreateCSR(time.Hour*24*10, hwId, hwId)
+	assert.NoError(t, err)
+	return &protos.Response{
+		HwId:      &protos.AccessGatewayID{Id: hwId},
+		Challenge: challenge.Challenge,
+		Response: &protos.Response_EchoResponse{
+			EchoResponse: &protos.Response_Echo{Response: challenge.Challenge},
+		},
+		Csr: csr,
+	}
+}
@@ -0,0 +1,388 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"time"
+
+	"magma/orc8r/cloud/go/identity"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/serde"
+	"magma/orc8r/cloud/go/services/bootstrapper/tokens"
+	"magma/orc8r/cloud/go/services/certifier"
+	"magma/orc8r/cloud/go/services/config"
+	"magma/orc8r/cloud/go/services/configurator"
+	configurator_utils "magma/orc8r/cloud/go/services/configurator/obsidian/handler_utils"
+	configuratorprotos "magma/orc8r/cloud/go/services/configurator/protos"
+	"magma/orc8r/cloud/go/services/device"
+	deviceprotos "magma/orc8r/cloud/go/services/device/protos"
+	"magma/orc8r/cloud/go/services/magmad"
+	magmadconfig "magma/orc8r/cloud/go/services/magmad/config"
+	magmad_models "magma/orc8r/cloud/go/services/magmad/obsidian/models"
+	magmadprotos "magma/orc8r/cloud/go/services/magmad/protos"
+	"magma/orc8r/cloud/go/services/upgrade"
+
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DefaultRegistrationTokenTTL = time.Hour * 24
+	MaxRegistrationTokenTTL     = time.Hour * 24 * 30
+)
+
+// Defaults of the magmad config of gateways registered with tokens
+const (
+	defaultCheckinInterval         = 60
+	defaultCheckinTimeout          = 10
+	defaultAutoupgradePollInterval = 300
+)
+
+// CreateRegistrationToken mints a single-use token which a new gateway can
+// register itself into a network & tier with. Tokens can only be minted by
+// cloud services, since the bootstrapper is exposed to gateways through the
+// open proxy.
+func (srv *BootstrapperServer) CreateRegistrationToken(
+	ctx context.Context, req *protos.CreateRegistrationTokenRequest) (*protos.RegistrationToken, error) {
+
+	if srv.tokenStore == nil {
+		return nil, status.Error(codes.Unimplemented, "Registration tokens are not enabled")
+	}
+	err := ensureInternalCaller(ctx)
+	if err != nil {
+		return nil, errorLogger(err)
+	}
+	ttl := time.Duration(req.TtlSec) * time.Second
+	if ttl == 0 {
+		ttl = DefaultRegistrationTokenTTL
+	}
+	if ttl < 0 || ttl > MaxRegistrationTokenTTL {
+		return nil, status.Errorf(codes.InvalidArgument, "TTL must be between 0 and %v", MaxRegistrationTokenTTL)
+	}
+	if len(req.NetworkId) == 0 || len(req.TierId) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Network and tier must be specified")
+	}
+	_, err = magmad.GetNetwork(req.NetworkId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Failed to find network %s: %s", req.NetworkId, err)
+	}
+	tiers, err := upgrade.GetTiers(req.NetworkId, []string{req.TierId})
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Failed to find tier %s: %s", req.TierId, err)
+	}
+	if _, ok := tiers[req.TierId]; !ok {
+		return nil, status.Errorf(codes.NotFound, "Tier %s does not exist", req.TierId)
+	}
+
+	now := time.Now()
+	err = srv.tokenStore.DeleteExpired(now)
+	if err != nil {
+		log.Printf("Failed to delete expired registration tokens: %s", err)
+	}
+	token, err := tokens.NewToken()
+	if err != nil {
+		return nil, errorLogger(status.Errorf(codes.Internal, "Failed to generate token: %s", err))
+	}
+	storedToken := tokens.Token{
+		NetworkId:   req.NetworkId,
+		TierId:      req.TierId,
+		GatewayName: req.GatewayName,
+		ExpiresAt:   now.Add(ttl).Unix(),
+	}
+	err = srv.tokenStore.Create(tokens.Hash(token), storedToken)
+	if err != nil {
+		return nil, errorLogger(status.Errorf(codes.Internal, "Failed to store token: %s", err))
+	}
+	return &protos.RegistrationToken{
+		Token:     token,
+		NetworkId: storedToken.NetworkId,
+		TierId:    storedToken.TierId,
+		ExpiresAt: storedToken.ExpiresAt,
+	}, nil
+}
+
+// RegisterWithToken uses a registration token to register a new gateway
+// into the token's network & tier, and signs the gateway's first certificate.
+//
+// The gateway's records live in several services, so registration isn't
+// atomic. The token is claimed by the gateway's hardware ID first, and only
+// marked as used once all of the records are created and the certificate is
+// signed. If a step fails, the records created so far are rolled back and the
+// certificate is revoked on a best effort basis, and the token is released.
+// If the bootstrapper dies mid-registration, the token stays claimed by the
+// gateway, which resumes the registration on its next attempt: existing
+// records are then reused instead of created.
+func (srv *BootstrapperServer) RegisterWithToken(
+	ctx context.Context, req *protos.RegistrationRequest) (*protos.Certificate, error) {
+
+	if srv.tokenStore == nil {
+		return nil, status.Error(codes.Unimplemented, "Registration tokens are not enabled")
+	}
+	hwId := req.GetHwId().GetId()
+	if len(hwId) == 0 || req.ChallengeKey == nil || req.Csr == nil {
+		return nil, status.Error(codes.InvalidArgument, "Hardware ID, challenge key and CSR must be specified")
+	}
+	// Check the proof of possession before claiming the token, so that a
+	// bad request doesn't hold it
+	err := verifyRegistrationKey(req)
+	if err != nil {
+		return nil, errorLogger(status.Errorf(codes.Aborted, "Failed to verify challenge key: %s", err))
+	}
+
+	tokenHash := tokens.Hash(req.Token)
+	token, resumed, err := srv.tokenStore.Claim(tokenHash, hwId, time.Now())
+	if err == tokens.ErrInvalidToken {
+		return nil, errorLogger(status.Error(codes.PermissionDenied, "Invalid or expired registration token"))
+	}
+	if err != nil {
+		return nil, errorLogger(status.Errorf(codes.Internal, "Failed to claim registration token: %s", err))
+	}
+
+	reg := &gatewayRegistration{hwId: hwId, networkId: token.NetworkId, resumed: resumed}
+	cert, err := reg.register(token, req)
+	if err == nil {
+		// Marking the token as used is the last step, so that the gateway
+		// can retry the registration if it fails
+		err = srv.tokenStore.Complete(tokenHash, hwId, time.Now())
+		if err != nil {
+			err = status.Errorf(codes.Internal, "Failed to complete registration token: %s", err)
+		}
+	}
+	if err != nil {
+		reg.rollback()
+		releaseErr := srv.tokenStore.Release(tokenHash, hwId)
+		if releaseErr != nil {
+			log.Printf("Failed to release registration token of gateway %s: %s", hwId, releaseErr)
+		}
+		return nil, errorLogger(err)
+	}
+	return cert, nil
+}
+
+// gatewayRegistration tracks the records created for a gateway, so that they
+// can be rolled back if its registration fails
+type gatewayRegistration struct {
+	hwId      string
+	networkId string
+	// resumed is true if the gateway's token was claimed by an interrupted
+	// registration of the same gateway, whose records are reused
+	resumed bool
+
+	gatewayId        string
+	createdEntities  bool
+	createdDevice    bool
+	registeredMagmad bool
+	signedCert       *protos.Certificate
+}
+
+func (reg *gatewayRegistration) register(token tokens.Token, req *protos.RegistrationRequest) (*protos.Certificate, error) {
+	name := token.GatewayName
+	if len(name) == 0 {
+		name = reg.hwId
+	}
+	record := &magmadprotos.AccessGatewayRecord{
+		HwId: &protos.AccessGatewayID{Id: reg.hwId},
+		Name: name,
+		Key:  req.ChallengeKey,
+	}
+	err := reg.registerMagmad(record)
+	if err != nil {
+		return nil, err
+	}
+	gatewayId := reg.gatewayId
+
+	gatewayConfig := &magmadprotos.MagmadGatewayConfig{
+		CheckinInterval:         defaultCheckinInterval,
+		CheckinTimeout:          defaultCheckinTimeout,
+		AutoupgradeEnabled:      true,
+		AutoupgradePollInterval: defaultAutoupgradePollInterval,
+		Tier:                    token.TierId,
+	}
+	err = reg.createConfig(gatewayConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// The gateway and its config are created in a single configurator
+	// transaction
+	serializedConfig, err := serde.Serialize(configurator.SerdeDomain, magmadconfig.MagmadGatewayType, gatewayConfig)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to serialize gateway config: %s", err)
+	}
+	err = configurator_utils.CreateNetworkIfNotExists(reg.networkId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create configurator network: %s", err)
+	}
+	exists, err := configurator.DoesEntityExist(reg.networkId, configurator.GatewayEntityType, gatewayId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to load configurator entities: %s", err)
+	}
+	if exists && reg.resumed {
+		reg.createdEntities = true
+	} else {
+		entities := []*configuratorprotos.NetworkEntity{
+			{Type: configurator.GatewayEntityType, Id: gatewayId, Name: name, PhysicalId: reg.hwId},
+			{Type: magmadconfig.MagmadGatewayType, Id: gatewayId, Config: serializedConfig},
+		}
+		_, err = configurator.CreateEntities(context.Background(), reg.networkId, entities)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to create configurator entities: %s", err)
+		}
+		reg.createdEntities = true
+	}
+
+	deviceRecord := &magmad_models.AccessGatewayRecord{Key: &magmad_models.ChallengeKey{}}
+	err = deviceRecord.FromMconfig(record)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to convert gateway record: %s", err)
+	}
+	err = device.CreateOrUpdate(reg.networkId, device.GatewayInfoType, reg.hwId, deviceRecord)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create gateway device: %s", err)
+	}
+	reg.createdDevice = true
+
+	// Ignore the requested identity & cert duration, and overwrite them with
+	// the registered gateway's
+	req.Csr.Id = protos.NewGatewayIdentity(reg.hwId, reg.networkId, gatewayId)
+	req.Csr.ValidTime = ptypes.DurationProto(GatewayCertificateDuration)
+	cert, err := certifier.SignCSR(req.Csr)
+	if err != nil {
+		return nil, status.Errorf(codes.Aborted, "Failed to sign csr: %s", err)
+	}
+	reg.signedCert = cert
+	return cert, nil
+}
+
+// registerMagmad registers the gateway with magmad, or updates the record
+// created by an interrupted registration of the gateway
+func (reg *gatewayRegistration) registerMagmad(record *magmadprotos.AccessGatewayRecord) error {
+	if reg.resumed {
+		gatewayId, err := magmad.FindGatewayId(reg.networkId, reg.hwId)
+		if err == nil && len(gatewayId) > 0 {
+			reg.gatewayId = gatewayId
+			// The records of the interrupted registration are rolled back
+			// like the ones created by this one
+			reg.registeredMagmad = true
+			err = magmad.UpdateGatewayRecord(reg.networkId, gatewayId, record)
+			if err != nil {
+				return status.Errorf(codes.Internal, "Failed to update gateway record: %s", err)
+			}
+			return nil
+		}
+	}
+	if device.DoesDeviceExist(reg.networkId, device.GatewayInfoType, reg.hwId) {
+		return status.Errorf(codes.AlreadyExists, "Hardware ID %s is already registered", reg.hwId)
+	}
+	gatewayId, err := magmad.RegisterGateway(reg.networkId, record)
+	if status.Code(err) == codes.AlreadyExists {
+		return status.Errorf(codes.AlreadyExists, "Failed to register gateway: %s", err)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to register gateway: %s", err)
+	}
+	reg.gatewayId = gatewayId
+	reg.registeredMagmad = true
+	return nil
+}
+
+// createConfig creates the magmad config of the gateway, unless an
+// interrupted registration of the gateway already did
+func (reg *gatewayRegistration) createConfig(gatewayConfig *magmadprotos.MagmadGatewayConfig) error {
+	if reg.resumed {
+		existingConfig, err := config.GetConfig(reg.networkId, magmadconfig.MagmadGatewayType, reg.gatewayId)
+		if err == nil && existingConfig != nil {
+			return nil
+		}
+	}
+	err := config.CreateConfig(reg.networkId, magmadconfig.MagmadGatewayType, reg.gatewayId, gatewayConfig)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to create gateway config: %s", err)
+	}
+	return nil
+}
+
+// rollback deletes the records created by a failed registration on a best
+// effort basis
+func (reg *gatewayRegistration) rollback() {
+	if reg.signedCert != nil {
+		err := certifier.RevokeCertificate(reg.signedCert.Sn)
+		if err != nil {
+			log.Printf("Failed to revoke certificate of gateway %s: %s", reg.hwId, err)
+		}
+	}
+	if reg.createdDevice {
+		err := device.DeleteDevices(reg.networkId, []*deviceprotos.DeviceID{{Type: device.GatewayInfoType, DeviceID: reg.hwId}})
+		if err != nil {
+			log.Printf("Failed to roll back device of gateway %s: %s", reg.hwId, err)
+		}
+	}
+	if reg.createdEntities {
+		err := configurator.DeleteEntities(context.Background(), reg.networkId, []*configuratorprotos.EntityID{
+			{Type: configurator.GatewayEntityType, Id: reg.gatewayId},
+			{Type: magmadconfig.MagmadGatewayType, Id: reg.gatewayId},
+		})
+		if err != nil {
+			log.Printf("Failed to roll back configurator entities of gateway %s: %s", reg.hwId, err)
+		}
+	}
+	if reg.registeredMagmad {
+		// Also deletes the gateway's configs
+		err := magmad.RemoveGateway(reg.networkId, reg.gatewayId)
+		if err != nil {
+			log.Printf("Failed to roll back registration of gateway %s: %s", reg.hwId, err)
+		}
+	}
+}
+
+// ensureInternalCaller rejects requests which were forwarded by the proxy or
+// sent by gateways, so that only cloud services can mint registration tokens
+func ensureInternalCaller(ctx context.Context) error {
+	if protos.GetClientGateway(ctx) != nil {
+		return status.Error(codes.PermissionDenied, "Gateways can't mint registration tokens")
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok && (len(md.Get(forwardedForHeader)) > 0 || len(md.Get(identity.CLIENT_CERT_SN_KEY)) > 0) {
+		return status.Error(codes.PermissionDenied, "Registration tokens can't be minted through the proxy")
+	}
+	return nil
+}
+
+// verifyRegistrationKey checks that the challenge key of a registration
+// request is valid, and that the gateway holds its private key by verifying
+// its signature of the token. ECHO keys are rejected since they can't prove
+// that the gateway holds anything.
+func verifyRegistrationKey(req *protos.RegistrationRequest) error {
+	key := req.ChallengeKey
+	switch key.KeyType {
+	case protos.ChallengeKey_SOFTWARE_ECDSA_SHA256:
+		publicKey, err := x509.ParsePKIXPublicKey(key.Key)
+		if err != nil {
+			return fmt.Errorf("Failed to parse ECDSA public key: %s", err)
+		}
+		if _, ok := publicKey.(*ecdsa.PublicKey); !ok {
+			return fmt.Errorf("Expected ECDSA public key, got %T", publicKey)
+		}
+		// The signature of the token is checked like a response to a challenge
+		resp := &protos.Response{
+			Challenge: []byte(req.Token),
+			Response:  &protos.Response_EcdsaResponse{EcdsaResponse: req.EcdsaResponse},
+		}
+		return verifySoftwareECDSASHA256(resp, key.Key)
+	default:
+		return fmt.Errorf("Unsupported key type: %s", key.KeyType)
+	}
+}
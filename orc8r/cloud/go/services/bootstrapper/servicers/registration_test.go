@@ -0,0 +1,222 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/plugin"
+	"magma/orc8r/cloud/go/pluginimpl"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/security/key"
+	"magma/orc8r/cloud/go/services/bootstrapper/servicers"
+	"magma/orc8r/cloud/go/services/bootstrapper/tokens"
+	certifier_test_init "magma/orc8r/cloud/go/services/certifier/test_init"
+	certifier_test_utils "magma/orc8r/cloud/go/services/certifier/test_utils"
+	"magma/orc8r/cloud/go/services/config"
+	config_test_init "magma/orc8r/cloud/go/services/config/test_init"
+	"magma/orc8r/cloud/go/services/configurator"
+	configurator_test_init "magma/orc8r/cloud/go/services/configurator/test_init"
+	"magma/orc8r/cloud/go/services/device"
+	device_test_init "magma/orc8r/cloud/go/services/device/test_init"
+	"magma/orc8r/cloud/go/services/magmad"
+	magmadconfig "magma/orc8r/cloud/go/services/magmad/config"
+	magmad_protos "magma/orc8r/cloud/go/services/magmad/protos"
+	magmad_test_init "magma/orc8r/cloud/go/services/magmad/test_init"
+	"magma/orc8r/cloud/go/services/upgrade"
+	upgrade_protos "magma/orc8r/cloud/go/services/upgrade/protos"
+	upgrade_test_init "magma/orc8r/cloud/go/services/upgrade/test_init"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestBootstrapperServer_RegistrationToken(t *testing.T) {
+	plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+	magmad_test_init.StartTestService(t)
+	configurator_test_init.StartTestService(t)
+	config_test_init.StartTestService(t)
+	device_test_init.StartTestService(t)
+	upgrade_test_init.StartTestService(t)
+	certifier_test_init.StartTestService(t)
+
+	networkId, err := magmad.RegisterNetwork(
+		&magmad_protos.MagmadNetworkRecord{Name: "Test Network Name"},
+		"registration_test_network")
+	assert.NoError(t, err)
+	err = upgrade.CreateTier(networkId, "tier1", &upgrade_protos.TierInfo{Name: "tier1", Version: "1.0.0"})
+	assert.NoError(t, err)
+
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	tokenStore := tokens.NewSQLStore(db, sqorc.GetSqlBuilder())
+	assert.NoError(t, tokenStore.Initialize())
+	privateKey, err := key.GenerateKey("", 2048)
+	assert.NoError(t, err)
+	srv, err := servicers.NewBootstrapperServer(privateKey.(*rsa.PrivateKey), tokenStore, nil, servicers.RateLimiters{})
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	// Tokens can't be minted through the proxy
+	proxiedCtx := metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "192.168.0.1"))
+	_, err = srv.CreateRegistrationToken(proxiedCtx, &protos.CreateRegistrationTokenRequest{NetworkId: networkId, TierId: "tier1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Tokens are scoped to existing tiers
+	_, err = srv.CreateRegistrationToken(ctx, &protos.CreateRegistrationTokenRequest{NetworkId: networkId, TierId: "tier2"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = srv.CreateRegistrationToken(ctx, &protos.CreateRegistrationTokenRequest{NetworkId: networkId, TierId: "tier1", TtlSec: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	token, err := srv.CreateRegistrationToken(ctx, &protos.CreateRegistrationTokenRequest{
+		NetworkId:   networkId,
+		TierId:      "tier1",
+		TtlSec:      60,
+		GatewayName: "Test GW",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, networkId, token.NetworkId)
+	assert.Equal(t, "tier1", token.TierId)
+	assert.True(t, token.ExpiresAt > time.Now().Unix())
+
+	// A bad signature doesn't consume the token
+	req := newRegistrationRequest(t, token.Token, "test_ag_token")
+	badReq := newRegistrationRequest(t, "foo", "test_ag_token")
+	badReq.Token = token.Token
+	_, err = srv.RegisterWithToken(ctx, badReq)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	echoReq := newRegistrationRequest(t, token.Token, "test_ag_token")
+	echoReq.ChallengeKey = &protos.ChallengeKey{KeyType: protos.ChallengeKey_ECHO}
+	_, err = srv.RegisterWithToken(ctx, echoReq)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	cert, err := srv.RegisterWithToken(ctx, req)
+	assert.NoError(t, err)
+	assert.NotNil(t, cert)
+	x509Cert, err := x509.ParseCertificate(cert.CertDer)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_ag_token", x509Cert.Subject.CommonName)
+
+	// All of the gateway's records were created
+	gatewayId, err := magmad.FindGatewayId(networkId, "test_ag_token")
+	assert.NoError(t, err)
+	record, err := magmad.FindGatewayRecord(networkId, gatewayId)
+	assert.NoError(t, err)
+	assert.Equal(t, "Test GW", record.Name)
+	assert.Equal(t, req.ChallengeKey.Key, record.Key.Key)
+	gatewayConfig, err := config.GetConfig(networkId, magmadconfig.MagmadGatewayType, gatewayId)
+	assert.NoError(t, err)
+	assert.Equal(t, "tier1", gatewayConfig.(*magmad_protos.MagmadGatewayConfig).Tier)
+	physicalId, err := configurator.GetPhysicalIDOfEntity(networkId, configurator.GatewayEntityType, gatewayId)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_ag_token", physicalId)
+	assert.True(t, device.DoesDeviceExist(networkId, device.GatewayInfoType, "test_ag_token"))
+
+	// Tokens can only be used once
+	_, err = srv.RegisterWithToken(ctx, newRegistrationRequest(t, token.Token, "test_ag_token2"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Conflicting registrations release the token
+	token, err = srv.CreateRegistrationToken(ctx, &protos.CreateRegistrationTokenRequest{NetworkId: networkId, TierId: "tier1"})
+	assert.NoError(t, err)
+	_, err = srv.RegisterWithToken(ctx, newRegistrationRequest(t, token.Token, "test_ag_token"))
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	cert, err = srv.RegisterWithToken(ctx, newRegistrationRequest(t, token.Token, "test_ag_token2"))
+	assert.NoError(t, err)
+	assert.NotNil(t, cert)
+	gatewayId, err = magmad.FindGatewayId(networkId, "test_ag_token2")
+	assert.NoError(t, err)
+	record, err = magmad.FindGatewayRecord(networkId, gatewayId)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_ag_token2", record.Name)
+
+	// Registrations which fail after the gateway's records were created roll
+	// them back and release the token
+	token, err = srv.CreateRegistrationToken(ctx, &protos.CreateRegistrationTokenRequest{NetworkId: networkId, TierId: "tier1"})
+	assert.NoError(t, err)
+	req = newRegistrationRequest(t, token.Token, "test_ag_token3")
+	req.Csr.CsrDer = []byte("invalid csr")
+	_, err = srv.RegisterWithToken(ctx, req)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	_, err = magmad.FindGatewayId(networkId, "test_ag_token3")
+	assert.Error(t, err)
+	gatewayConfig, err = config.GetConfig(networkId, magmadconfig.MagmadGatewayType, "test_ag_token3")
+	assert.NoError(t, err)
+	assert.Nil(t, gatewayConfig)
+	exists, err := configurator.DoesEntityExist(networkId, configurator.GatewayEntityType, "test_ag_token3")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	assert.False(t, device.DoesDeviceExist(networkId, device.GatewayInfoType, "test_ag_token3"))
+	cert, err = srv.RegisterWithToken(ctx, newRegistrationRequest(t, token.Token, "test_ag_token3"))
+	assert.NoError(t, err)
+	assert.NotNil(t, cert)
+
+	// Interrupted registrations can only be resumed by the same gateway,
+	// which reuses the records created before the interruption
+	token, err = srv.CreateRegistrationToken(ctx, &protos.CreateRegistrationTokenRequest{NetworkId: networkId, TierId: "tier1"})
+	assert.NoError(t, err)
+	_, _, err = tokenStore.Claim(tokens.Hash(token.Token), "test_ag_token4", time.Now())
+	assert.NoError(t, err)
+	interruptedGatewayId, err := magmad.RegisterGateway(networkId, &magmad_protos.AccessGatewayRecord{
+		HwId: &protos.AccessGatewayID{Id: "test_ag_token4"},
+		Name: "test_ag_token4",
+		Key:  &protos.ChallengeKey{KeyType: protos.ChallengeKey_ECHO},
+	})
+	assert.NoError(t, err)
+	_, err = srv.RegisterWithToken(ctx, newRegistrationRequest(t, token.Token, "test_ag_token5"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	req = newRegistrationRequest(t, token.Token, "test_ag_token4")
+	cert, err = srv.RegisterWithToken(ctx, req)
+	assert.NoError(t, err)
+	assert.NotNil(t, cert)
+	gatewayId, err = magmad.FindGatewayId(networkId, "test_ag_token4")
+	assert.NoError(t, err)
+	assert.Equal(t, interruptedGatewayId, gatewayId)
+	record, err = magmad.FindGatewayRecord(networkId, gatewayId)
+	assert.NoError(t, err)
+	assert.Equal(t, req.ChallengeKey.Key, record.Key.Key)
+	assert.True(t, device.DoesDeviceExist(networkId, device.GatewayInfoType, "test_ag_token4"))
+	_, err = srv.RegisterWithToken(ctx, req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+// newRegistrationRequest creates the registration request of a gateway with
+// a new ECDSA challenge key, which signs the token
+func newRegistrationRequest(t *testing.T, token string, hwId string) *protos.RegistrationRequest {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	marshaledPubKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	assert.NoError(t, err)
+	hashed := sha256.Sum256([]byte(token))
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, hashed[:])
+	assert.NoError(t, err)
+	csr, err := certifier_test_utils.CreateCSR(time.Hour*24*10, hwId, hwId)
+	assert.NoError(t, err)
+	return &protos.RegistrationRequest{
+		Token: token,
+		HwId:  &protos.AccessGatewayID{Id: hwId},
+		ChallengeKey: &protos.ChallengeKey{
+			KeyType: protos.ChallengeKey_SOFTWARE_ECDSA_SHA256,
+			Key:     marshaledPubKey,
+		},
+		EcdsaResponse: &protos.Response_ECDSA{R: r.Bytes(), S: s.Bytes()},
+		Csr:           csr,
+	}
+}
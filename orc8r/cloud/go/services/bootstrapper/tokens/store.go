@@ -0,0 +1,233 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package tokens stores the single-use registration tokens which new gateways
+// present to the bootstrapper to register themselves into a network.
+//
+// Only the SHA-256 hashes of tokens are stored, so that the token table
+// can't be used to register gateways.
+package tokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"magma/orc8r/cloud/go/sqorc"
+
+	sq "github.com/Masterminds/squirrel"
+	pkgerrors "github.com/pkg/errors"
+)
+
+const (
+	tokenTable = "bootstrapper_registration_tokens"
+
+	hashCol        = "token_hash"
+	networkIdCol   = "network_id"
+	tierIdCol      = "tier_id"
+	gatewayNameCol = "gateway_name"
+	expiresAtCol   = "expires_at"
+	// usedAtCol is the time a token's registration completed at, or 0
+	usedAtCol = "used_at"
+	// usedByCol is the hardware ID of the gateway which claimed a token
+	usedByCol = "used_by"
+
+	// tokenLength is the number of random bytes in a token
+	tokenLength = 32
+)
+
+// ErrInvalidToken is returned when claiming a token which doesn't exist, has
+// expired, has already been used or is claimed by another gateway
+var ErrInvalidToken = errors.New("invalid registration token")
+
+// Token is the registration a token was minted for
+type Token struct {
+	NetworkId   string
+	TierId      string
+	GatewayName string
+	// ExpiresAt is the unix time in seconds after which the token can't be
+	// claimed
+	ExpiresAt int64
+}
+
+// Store stores registration tokens by their hash
+type Store interface {
+	// Create stores a new unused token
+	Create(tokenHash string, token Token) error
+	// Claim reserves an unused, unexpired token for the registration of a
+	// gateway and returns it. A token which is already claimed by the same
+	// gateway can be claimed again, so that the gateway can retry a
+	// registration which was interrupted; resumed is true in that case.
+	// ErrInvalidToken is returned if there is no such token, or if it is
+	// claimed by another gateway.
+	Claim(tokenHash string, hwId string, now time.Time) (token Token, resumed bool, err error)
+	// Complete marks a token claimed by a gateway as used, once its
+	// registration succeeded. Completed tokens can't be claimed again.
+	Complete(tokenHash string, hwId string, now time.Time) error
+	// Release makes an unused token claimed by a gateway usable by any
+	// gateway again, after its registration failed
+	Release(tokenHash string, hwId string) error
+	// DeleteExpired deletes tokens which expired before the given time
+	DeleteExpired(before time.Time) error
+}
+
+// NewToken generates a random token
+func NewToken() (string, error) {
+	b := make([]byte, tokenLength)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Hash returns the hash a token is stored by
+func Hash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// SQLStore implements Store on a SQL database
+type SQLStore struct {
+	db      *sql.DB
+	builder sqorc.StatementBuilder
+}
+
+func NewSQLStore(db *sql.DB, builder sqorc.StatementBuilder) *SQLStore {
+	return &SQLStore{db: db, builder: builder}
+}
+
+// Initialize creates the token table if it doesn't exist
+func (store *SQLStore) Initialize() error {
+	_, err := sqorc.ExecInTx(
+		store.db,
+		func(*sql.Tx) error { return nil },
+		func(tx *sql.Tx) (interface{}, error) { return nil, CreateTables(tx, store.builder) },
+	)
+	return err
+}
+
+// CreateTables creates the registration token table if it doesn't exist
+func CreateTables(tx *sql.Tx, builder sqorc.StatementBuilder) error {
+	_, err := builder.CreateTable(tokenTable).
+		IfNotExists().
+		Column(hashCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+		Column(networkIdCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(tierIdCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(gatewayNameCol).Type(sqorc.ColumnTypeText).NotNull().Default("''").EndColumn().
+		Column(expiresAtCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+		Column(usedAtCol).Type(sqorc.ColumnTypeBigInt).NotNull().Default(0).EndColumn().
+		Column(usedByCol).Type(sqorc.ColumnTypeText).NotNull().Default("''").EndColumn().
+		RunWith(tx).
+		Exec()
+	return pkgerrors.Wrap(err, "failed to create registration token table")
+}
+
+func (store *SQLStore) Create(tokenHash string, token Token) error {
+	_, err := store.builder.Insert(tokenTable).
+		Columns(hashCol, networkIdCol, tierIdCol, gatewayNameCol, expiresAtCol).
+		Values(tokenHash, token.NetworkId, token.TierId, token.GatewayName, token.ExpiresAt).
+		RunWith(store.db).
+		Exec()
+	return pkgerrors.Wrap(err, "failed to insert registration token")
+}
+
+func (store *SQLStore) Claim(tokenHash string, hwId string, now time.Time) (Token, bool, error) {
+	ret, err := sqorc.ExecInTx(
+		store.db,
+		func(*sql.Tx) error { return nil },
+		func(tx *sql.Tx) (interface{}, error) {
+			var claimedBy string
+			token := Token{}
+			err := store.builder.Select(networkIdCol, tierIdCol, gatewayNameCol, expiresAtCol, usedByCol).
+				From(tokenTable).
+				Where(sq.Eq{hashCol: tokenHash}).
+				RunWith(tx).
+				QueryRow().
+				Scan(&token.NetworkId, &token.TierId, &token.GatewayName, &token.ExpiresAt, &claimedBy)
+			if err == sql.ErrNoRows {
+				return nil, ErrInvalidToken
+			}
+			if err != nil {
+				return nil, pkgerrors.Wrap(err, "failed to load registration token")
+			}
+
+			// The conditional update makes sure that concurrent registrations
+			// of different gateways can't both claim the token
+			res, err := store.builder.Update(tokenTable).
+				Set(usedByCol, hwId).
+				Where(sq.And{
+					sq.Eq{hashCol: tokenHash, usedAtCol: 0, usedByCol: []string{"", hwId}},
+					sq.Gt{expiresAtCol: now.Unix()},
+				}).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, pkgerrors.Wrap(err, "failed to claim registration token")
+			}
+			rowsAffected, err := res.RowsAffected()
+			if err != nil {
+				return nil, pkgerrors.Wrap(err, "failed to get rows affected for claiming registration token")
+			}
+			if rowsAffected != 1 {
+				return nil, ErrInvalidToken
+			}
+			return claimResult{token: token, resumed: claimedBy == hwId}, nil
+		},
+	)
+	if err != nil {
+		return Token{}, false, err
+	}
+	result := ret.(claimResult)
+	return result.token, result.resumed, nil
+}
+
+type claimResult struct {
+	token   Token
+	resumed bool
+}
+
+func (store *SQLStore) Complete(tokenHash string, hwId string, now time.Time) error {
+	res, err := store.builder.Update(tokenTable).
+		Set(usedAtCol, now.Unix()).
+		Where(sq.Eq{hashCol: tokenHash, usedByCol: hwId, usedAtCol: 0}).
+		RunWith(store.db).
+		Exec()
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to complete registration token")
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to get rows affected for completing registration token")
+	}
+	if rowsAffected != 1 {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+func (store *SQLStore) Release(tokenHash string, hwId string) error {
+	_, err := store.builder.Update(tokenTable).
+		Set(usedByCol, "").
+		Where(sq.Eq{hashCol: tokenHash, usedByCol: hwId, usedAtCol: 0}).
+		RunWith(store.db).
+		Exec()
+	return pkgerrors.Wrap(err, "failed to release registration token")
+}
+
+func (store *SQLStore) DeleteExpired(before time.Time) error {
+	_, err := store.builder.Delete(tokenTable).
+		Where(sq.Lt{expiresAtCol: before.Unix()}).
+		RunWith(store.db).
+		Exec()
+	return pkgerrors.Wrap(err, "failed to delete expired registration tokens")
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package tokens_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/services/bootstrapper/tokens"
+	"magma/orc8r/cloud/go/sqorc"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStore(t *testing.T) *tokens.SQLStore {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	// Each connection to an in-memory sqlite DB gets its own database
+	db.SetMaxOpenConns(1)
+	store := tokens.NewSQLStore(db, sqorc.GetSqlBuilder())
+	assert.NoError(t, store.Initialize())
+	return store
+}
+
+func TestSQLStore(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now()
+
+	token, err := tokens.NewToken()
+	assert.NoError(t, err)
+	hash := tokens.Hash(token)
+	assert.NotEqual(t, token, hash)
+	expected := tokens.Token{NetworkId: "network1", TierId: "tier1", GatewayName: "gw1", ExpiresAt: now.Add(time.Hour).Unix()}
+	assert.NoError(t, store.Create(hash, expected))
+
+	// Unknown token
+	_, _, err = store.Claim(tokens.Hash("foo"), "hw1", now)
+	assert.Equal(t, tokens.ErrInvalidToken, err)
+
+	// Claimed tokens can only be claimed again by the same gateway
+	actual, resumed, err := store.Claim(hash, "hw1", now)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	assert.False(t, resumed)
+	_, _, err = store.Claim(hash, "hw2", now)
+	assert.Equal(t, tokens.ErrInvalidToken, err)
+	actual, resumed, err = store.Claim(hash, "hw1", now)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	assert.True(t, resumed)
+
+	// Only the gateway which claimed a token can release or complete it
+	assert.NoError(t, store.Release(hash, "hw2"))
+	assert.Equal(t, tokens.ErrInvalidToken, store.Complete(hash, "hw2", now))
+	_, _, err = store.Claim(hash, "hw2", now)
+	assert.Equal(t, tokens.ErrInvalidToken, err)
+	assert.NoError(t, store.Release(hash, "hw1"))
+	actual, resumed, err = store.Claim(hash, "hw2", now)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	assert.False(t, resumed)
+
+	// Completed tokens can't be claimed or released
+	assert.NoError(t, store.Complete(hash, "hw2", now))
+	assert.Equal(t, tokens.ErrInvalidToken, store.Complete(hash, "hw2", now))
+	_, _, err = store.Claim(hash, "hw2", now)
+	assert.Equal(t, tokens.ErrInvalidToken, err)
+	assert.NoError(t, store.Release(hash, "hw2"))
+	_, _, err = store.Claim(hash, "hw3", now)
+	assert.Equal(t, tokens.ErrInvalidToken, err)
+
+	// Expired tokens can't be claimed
+	expiredToken, err := tokens.NewToken()
+	assert.NoError(t, err)
+	expiredHash := tokens.Hash(expiredToken)
+	assert.NoError(t, store.Create(expiredHash, tokens.Token{NetworkId: "network1", TierId: "tier1", ExpiresAt: now.Unix()}))
+	_, _, err = store.Claim(expiredHash, "hw3", now)
+	assert.Equal(t, tokens.ErrInvalidToken, err)
+
+	// Deleting expired tokens leaves unexpired ones
+	unexpiredToken, err := tokens.NewToken()
+	assert.NoError(t, err)
+	unexpiredHash := tokens.Hash(unexpiredToken)
+	assert.NoError(t, store.Create(unexpiredHash, expected))
+	assert.NoError(t, store.DeleteExpired(now.Add(time.Second)))
+	_, _, err = store.Claim(unexpiredHash, "hw3", now)
+	assert.NoError(t, err)
+	_, _, err = store.Claim(expiredHash, "hw3", now.Add(-time.Hour))
+	assert.Equal(t, tokens.ErrInvalidToken, err)
+}
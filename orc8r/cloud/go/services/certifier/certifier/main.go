@@ -9,8 +9,14 @@ LICENSE file in the root directory of this source tree.
 package main
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
+	"fmt"
+	"io/ioutil"
 	"log"
+	"net/http"
+	"strings"
 	"time"
 
 	"magma/orc8r/cloud/go/datastore"
@@ -18,15 +24,24 @@ import (
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/security/cert"
 	"magma/orc8r/cloud/go/service"
+	"magma/orc8r/cloud/go/service/config"
 	"magma/orc8r/cloud/go/services/certifier"
 	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
 	"magma/orc8r/cloud/go/services/certifier/servicers"
+	"magma/orc8r/cloud/go/services/certifier/signer"
 	"magma/orc8r/cloud/go/sqorc"
 
 	"github.com/golang/glog"
 	"golang.org/x/net/context"
 )
 
+const (
+	signersParam            = "signers"
+	inMemoryBackend         = "memory"
+	httpBackend             = "http"
+	defaultSignerTimeoutSec = 10
+)
+
 var (
 	bootstrapCACertFile = flag.String("cac", "server_cert.pem", "Signer CA's Certificate file")
 	bootstrapCAKeyFile  = flag.String("cak", "server_cert.key.pem", "Signer CA's Private Key file")
@@ -35,6 +50,12 @@ var (
 	vpnKeyFile  = flag.String("vpnk", "vpn_ca.key", "VPN CA's Private Key file")
 
 	gcHours = flag.Int64("gc-hours", 12, "Garbage Collection time interval (in hours)")
+
+	renewalFraction       = flag.Float64("renewal-fraction", servicers.RenewalFraction, "Fraction of a gateway certificate's lifetime after which it should be renewed")
+	supersededGracePeriod = flag.Duration("superseded-grace-period", servicers.SupersededCertGracePeriod, "Time after which superseded gateway certificates are revoked")
+	rotationInterval      = flag.Duration("rotation-interval", 5*time.Minute, "Certificate rotation time interval")
+
+	httpPort = flag.Int("http-port", 9186, "Port of the CRL & OCSP HTTP endpoints, 0 to disable them")
 )
 
 func main() {
@@ -49,25 +70,36 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to initialize datastore: %s", err)
 	}
+	err = store.ApplyMigrations()
+	if err != nil {
+		log.Fatalf("Failed to migrate datastore: %s", err)
+	}
 	caMap := map[protos.CertType]*servicers.CAInfo{}
 
+	if *renewalFraction <= 0 || *renewalFraction > 1 {
+		log.Fatalf("Invalid renewal fraction %v, must be in (0, 1]", *renewalFraction)
+	}
+	servicers.RenewalFraction = *renewalFraction
+	servicers.SupersededCertGracePeriod = *supersededGracePeriod
+
 	// Add servicers to the service
-	bootstrapCert, bootstrapPrivKey, err := cert.LoadCertAndPrivKey(*bootstrapCACertFile, *bootstrapCAKeyFile)
+	signerConfigs := getSignerConfigs(srv.Config)
+	bootstrapCA, err := loadCA(*bootstrapCACertFile, *bootstrapCAKeyFile, signerConfigs[protos.CertType_DEFAULT])
 	if err != nil {
-		log.Printf("ERROR: Failed to load bootstrap CA cert and key: %v", err)
+		log.Printf("ERROR: Failed to load bootstrap CA: %v", err)
 	} else {
-		caMap[protos.CertType_DEFAULT] = &servicers.CAInfo{Cert: bootstrapCert, PrivKey: bootstrapPrivKey}
+		caMap[protos.CertType_DEFAULT] = bootstrapCA
 	}
-	vpnCert, vpnPrivKey, vpnErr := cert.LoadCertAndPrivKey(*vpnCertFile, *vpnKeyFile)
+	vpnCA, vpnErr := loadCA(*vpnCertFile, *vpnKeyFile, signerConfigs[protos.CertType_VPN])
 	if vpnErr != nil {
-		fmtstr := "ERROR: Failed to load VPN cert and key: %v"
+		fmtstr := "ERROR: Failed to load VPN CA: %v"
 		if err != nil {
 			log.Fatalf(fmtstr, vpnErr)
 		} else {
 			log.Printf(fmtstr, vpnErr)
 		}
 	} else {
-		caMap[protos.CertType_VPN] = &servicers.CAInfo{Cert: vpnCert, PrivKey: vpnPrivKey}
+		caMap[protos.CertType_VPN] = vpnCA
 	}
 	servicer, err := servicers.NewCertifierServer(store, caMap)
 	if err != nil {
@@ -87,9 +119,177 @@ func main() {
 		}
 	}()
 
+	// Start Certificate Rotation Ticker
+	rotation := time.Tick(*rotationInterval)
+	go func() {
+		for range rotation {
+			err := servicer.RotateCertificates()
+			if err != nil {
+				glog.Errorf("error rotating certificates: %s", err)
+			}
+		}
+	}()
+
+	// Publish CRLs & answer OCSP requests over HTTP
+	if *httpPort != 0 {
+		go func() {
+			addr := fmt.Sprintf(":%d", *httpPort)
+			err := http.ListenAndServe(addr, servicers.NewHTTPHandler(servicer))
+			if err != nil {
+				glog.Errorf("CRL & OCSP HTTP server on %s stopped: %s", addr, err)
+			}
+		}()
+	}
+
 	// Run the service
 	err = srv.Run()
 	if err != nil {
 		log.Fatalf("Error running service: %s", err)
 	}
 }
+
+// signerConfig is the signing backend of a CA in the service config
+type signerConfig struct {
+	backend string
+	url     string
+	keyId   string
+	timeout time.Duration
+
+	// The certifier authenticates to an external CA with a client
+	// certificate, a bearer token read from a file, or both
+	clientCertFile string
+	clientKeyFile  string
+	tokenFile      string
+	// serverCAFile verifies the external CA's server certificate instead of
+	// the system roots, if set
+	serverCAFile string
+}
+
+// getSignerConfigs reads the signing backends of the CAs by cert type from
+// the service config. CAs sign with in-memory keys by default.
+func getSignerConfigs(serviceConfig *config.ConfigMap) map[protos.CertType]signerConfig {
+	ret := map[protos.CertType]signerConfig{}
+	if serviceConfig == nil {
+		return ret
+	}
+	signers, ok := serviceConfig.RawMap[signersParam].(map[interface{}]interface{})
+	if !ok {
+		return ret
+	}
+	for certTypeName, rawSignerConfig := range signers {
+		certTypeStr, typeOK := certTypeName.(string)
+		signerConfigMap, configOK := rawSignerConfig.(map[interface{}]interface{})
+		if !typeOK || !configOK {
+			log.Fatalf("Invalid signer config %v: %v", certTypeName, rawSignerConfig)
+		}
+		certType, ok := protos.CertType_value[strings.ToUpper(certTypeStr)]
+		if !ok {
+			log.Fatalf("Invalid cert type in signer config: %s", certTypeStr)
+		}
+		cfg := config.NewConfigMap(signerConfigMap)
+		ret[protos.CertType(certType)] = signerConfig{
+			backend: getOptionalStringParam(cfg, "backend"),
+			url:     getOptionalStringParam(cfg, "url"),
+			keyId:   getOptionalStringParam(cfg, "key_id"),
+			timeout: time.Duration(getOptionalIntParam(cfg, "timeout_sec", defaultSignerTimeoutSec)) * time.Second,
+
+			clientCertFile: getOptionalStringParam(cfg, "client_cert"),
+			clientKeyFile:  getOptionalStringParam(cfg, "client_key"),
+			tokenFile:      getOptionalStringParam(cfg, "token_file"),
+			serverCAFile:   getOptionalStringParam(cfg, "server_ca"),
+		}
+	}
+	return ret
+}
+
+// loadCA loads the certificate of a CA, and creates its signing backend
+func loadCA(certFile string, keyFile string, cfg signerConfig) (*servicers.CAInfo, error) {
+	switch cfg.backend {
+	case "", inMemoryBackend:
+		caCert, caPrivKey, err := cert.LoadCertAndPrivKey(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		caSigner, err := signer.NewInMemorySigner(caPrivKey)
+		if err != nil {
+			return nil, err
+		}
+		return &servicers.CAInfo{Cert: caCert, Signer: caSigner}, nil
+	case httpBackend:
+		caCert, err := cert.LoadCert(certFile)
+		if err != nil {
+			return nil, err
+		}
+		httpSignerConfig, err := getHTTPSignerConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		caSigner, err := signer.NewHTTPSigner(httpSignerConfig, caCert.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		return &servicers.CAInfo{Cert: caCert, Signer: caSigner}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signer backend: %s", cfg.backend)
+	}
+}
+
+// getHTTPSignerConfig creates the client which authenticates the certifier
+// to an external CA. External CAs sign anything they're sent, so they must
+// authenticate the certifier with a client certificate or a token.
+func getHTTPSignerConfig(cfg signerConfig) (signer.HTTPSignerConfig, error) {
+	ret := signer.HTTPSignerConfig{URL: cfg.url, KeyId: cfg.keyId, Timeout: cfg.timeout}
+	hasClientCert := len(cfg.clientCertFile) > 0 || len(cfg.clientKeyFile) > 0
+	if !hasClientCert && len(cfg.tokenFile) == 0 {
+		return ret, fmt.Errorf("external CA requires a client certificate or a token file")
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if hasClientCert {
+		clientCert, err := tls.LoadX509KeyPair(cfg.clientCertFile, cfg.clientKeyFile)
+		if err != nil {
+			return ret, fmt.Errorf("failed to load client certificate for external CA: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	if len(cfg.serverCAFile) > 0 {
+		serverCAs, err := ioutil.ReadFile(cfg.serverCAFile)
+		if err != nil {
+			return ret, fmt.Errorf("failed to read server CA of external CA: %s", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(serverCAs) {
+			return ret, fmt.Errorf("no certificates in server CA file %s", cfg.serverCAFile)
+		}
+	}
+	if len(cfg.tokenFile) > 0 {
+		token, err := ioutil.ReadFile(cfg.tokenFile)
+		if err != nil {
+			return ret, fmt.Errorf("failed to read token for external CA: %s", err)
+		}
+		ret.Token = strings.TrimSpace(string(token))
+	}
+	ret.Client = &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}
+	return ret, nil
+}
+
+func getOptionalStringParam(cfg *config.ConfigMap, key string) string {
+	value, err := cfg.GetStringParam(key)
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+func getOptionalIntParam(cfg *config.ConfigMap, key string, defaultValue int) int {
+	value, err := cfg.GetIntParam(key)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
@@ -188,6 +188,36 @@ func RevokeCertificateSN(sn string) error {
 	return RevokeCertificate(&protos.Certificate_SN{Sn: sn})
 }
 
+// GetCRL returns the DER encoded certificate revocation list of the CA of
+// the given cert type
+func GetCRL(certType protos.CertType) ([]byte, error) {
+	client, err := getCertifierClient()
+	if err != nil {
+		return nil, err
+	}
+	crl, err := client.GetCRL(context.Background(), &certifierprotos.GetCRLRequest{CertType: certType})
+	if err != nil {
+		glog.Errorf("Failed to get CRL of %s CA: %s", certType.String(), err)
+		return nil, err
+	}
+	return crl.GetCrlDer(), nil
+}
+
+// GetRenewalAdvice returns when the certificate with the given SN should be
+// renewed
+func GetRenewalAdvice(sn string) (*protos.RenewalAdvice, error) {
+	client, err := getCertifierClient()
+	if err != nil {
+		return nil, err
+	}
+	advice, err := client.GetRenewalAdvice(context.Background(), &protos.Certificate_SN{Sn: sn})
+	if err != nil {
+		glog.Errorf("Failed to get renewal advice for SN: %s, %s", sn, err)
+		return nil, err
+	}
+	return advice, nil
+}
+
 // Let certifier to remove expired certificates
 func CollectGarbage() error {
 	client, err := getCertifierClient()
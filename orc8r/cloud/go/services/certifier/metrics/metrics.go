@@ -0,0 +1,52 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package metrics contains the certifier's certificate rotation metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// CertsNearingExpiry is the number of active gateway certificates which
+	// are past their renewal time, but haven't expired yet
+	CertsNearingExpiry = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "certifier_gateway_certs_nearing_expiry",
+			Help: "Number of active gateway certificates past their renewal time",
+		},
+		[]string{"networkId"},
+	)
+	// SupersededCertsRevoked counts the gateway certificates revoked after
+	// being superseded for the grace period
+	SupersededCertsRevoked = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "certifier_superseded_certs_revoked",
+			Help: "Number of superseded gateway certificates revoked",
+		},
+		[]string{"networkId"},
+	)
+	// TrackingFailures counts the gateway certificates which were signed, but
+	// couldn't be tracked for rotation, so that the certificates they
+	// supersede won't be revoked
+	TrackingFailures = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "certifier_gateway_cert_tracking_failures",
+			Help: "Number of gateway certificates which failed to be tracked for rotation",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		CertsNearingExpiry,
+		SupersededCertsRevoked,
+		TrackingFailures,
+	)
+}
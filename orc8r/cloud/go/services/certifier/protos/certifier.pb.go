@@ -39,7 +39,7 @@ func (m *CertificateInfo) Reset()         { *m = CertificateInfo{} }
 func (m *CertificateInfo) String() string { return proto.CompactTextString(m) }
 func (*CertificateInfo) ProtoMessage()    {}
 func (*CertificateInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_certifier_ebcdf080e50839d6, []int{0}
+	return fileDescriptor_certifier_48e7a144d9ea9c53, []int{0}
 }
 func (m *CertificateInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CertificateInfo.Unmarshal(m, b)
@@ -98,7 +98,7 @@ func (m *CertificateInfoMap) Reset()         { *m = CertificateInfoMap{} }
 func (m *CertificateInfoMap) String() string { return proto.CompactTextString(m) }
 func (*CertificateInfoMap) ProtoMessage()    {}
 func (*CertificateInfoMap) Descriptor() ([]byte, []int) {
-	return fileDescriptor_certifier_ebcdf080e50839d6, []int{1}
+	return fileDescriptor_certifier_48e7a144d9ea9c53, []int{1}
 }
 func (m *CertificateInfoMap) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CertificateInfoMap.Unmarshal(m, b)
@@ -138,7 +138,7 @@ func (m *AddCertRequest) Reset()         { *m = AddCertRequest{} }
 func (m *AddCertRequest) String() string { return proto.CompactTextString(m) }
 func (*AddCertRequest) ProtoMessage()    {}
 func (*AddCertRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_certifier_ebcdf080e50839d6, []int{2}
+	return fileDescriptor_certifier_48e7a144d9ea9c53, []int{2}
 }
 func (m *AddCertRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AddCertRequest.Unmarshal(m, b)
@@ -190,7 +190,7 @@ func (m *SerialNumbers) Reset()         { *m = SerialNumbers{} }
 func (m *SerialNumbers) String() string { return proto.CompactTextString(m) }
 func (*SerialNumbers) ProtoMessage()    {}
 func (*SerialNumbers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_certifier_ebcdf080e50839d6, []int{3}
+	return fileDescriptor_certifier_48e7a144d9ea9c53, []int{3}
 }
 func (m *SerialNumbers) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SerialNumbers.Unmarshal(m, b)
@@ -228,7 +228,7 @@ func (m *GetCARequest) Reset()         { *m = GetCARequest{} }
 func (m *GetCARequest) String() string { return proto.CompactTextString(m) }
 func (*GetCARequest) ProtoMessage()    {}
 func (*GetCARequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_certifier_ebcdf080e50839d6, []int{4}
+	return fileDescriptor_certifier_48e7a144d9ea9c53, []int{4}
 }
 func (m *GetCARequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetCARequest.Unmarshal(m, b)
@@ -255,6 +255,195 @@ func (m *GetCARequest) GetCertType() protos.CertType {
 	return protos.CertType_DEFAULT
 }
 
+type GetCRLRequest struct {
+	CertType             protos.CertType `protobuf:"varint,1,opt,name=cert_type,json=certType,proto3,enum=magma.orc8r.CertType" json:"cert_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetCRLRequest) Reset()         { *m = GetCRLRequest{} }
+func (m *GetCRLRequest) String() string { return proto.CompactTextString(m) }
+func (*GetCRLRequest) ProtoMessage()    {}
+func (*GetCRLRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_certifier_48e7a144d9ea9c53, []int{5}
+}
+func (m *GetCRLRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCRLRequest.Unmarshal(m, b)
+}
+func (m *GetCRLRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCRLRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetCRLRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCRLRequest.Merge(dst, src)
+}
+func (m *GetCRLRequest) XXX_Size() int {
+	return xxx_messageInfo_GetCRLRequest.Size(m)
+}
+func (m *GetCRLRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCRLRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCRLRequest proto.InternalMessageInfo
+
+func (m *GetCRLRequest) GetCertType() protos.CertType {
+	if m != nil {
+		return m.CertType
+	}
+	return protos.CertType_DEFAULT
+}
+
+type CRL struct {
+	CrlDer               []byte   `protobuf:"bytes,1,opt,name=crl_der,json=crlDer,proto3" json:"crl_der,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CRL) Reset()         { *m = CRL{} }
+func (m *CRL) String() string { return proto.CompactTextString(m) }
+func (*CRL) ProtoMessage()    {}
+func (*CRL) Descriptor() ([]byte, []int) {
+	return fileDescriptor_certifier_48e7a144d9ea9c53, []int{6}
+}
+func (m *CRL) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CRL.Unmarshal(m, b)
+}
+func (m *CRL) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CRL.Marshal(b, m, deterministic)
+}
+func (dst *CRL) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CRL.Merge(dst, src)
+}
+func (m *CRL) XXX_Size() int {
+	return xxx_messageInfo_CRL.Size(m)
+}
+func (m *CRL) XXX_DiscardUnknown() {
+	xxx_messageInfo_CRL.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CRL proto.InternalMessageInfo
+
+func (m *CRL) GetCrlDer() []byte {
+	if m != nil {
+		return m.CrlDer
+	}
+	return nil
+}
+
+// RevokedCertificate records the revocation of a certificate until it expires
+type RevokedCertificate struct {
+	CertType             protos.CertType      `protobuf:"varint,1,opt,name=cert_type,json=certType,proto3,enum=magma.orc8r.CertType" json:"cert_type,omitempty"`
+	RevokedAt            *timestamp.Timestamp `protobuf:"bytes,2,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	NotAfter             *timestamp.Timestamp `protobuf:"bytes,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RevokedCertificate) Reset()         { *m = RevokedCertificate{} }
+func (m *RevokedCertificate) String() string { return proto.CompactTextString(m) }
+func (*RevokedCertificate) ProtoMessage()    {}
+func (*RevokedCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_certifier_48e7a144d9ea9c53, []int{7}
+}
+func (m *RevokedCertificate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokedCertificate.Unmarshal(m, b)
+}
+func (m *RevokedCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokedCertificate.Marshal(b, m, deterministic)
+}
+func (dst *RevokedCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokedCertificate.Merge(dst, src)
+}
+func (m *RevokedCertificate) XXX_Size() int {
+	return xxx_messageInfo_RevokedCertificate.Size(m)
+}
+func (m *RevokedCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokedCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokedCertificate proto.InternalMessageInfo
+
+func (m *RevokedCertificate) GetCertType() protos.CertType {
+	if m != nil {
+		return m.CertType
+	}
+	return protos.CertType_DEFAULT
+}
+
+func (m *RevokedCertificate) GetRevokedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.RevokedAt
+	}
+	return nil
+}
+
+func (m *RevokedCertificate) GetNotAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.NotAfter
+	}
+	return nil
+}
+
+// IdentityCertificates tracks the active certificate of a gateway, and the
+// certificates it superseded which haven't been revoked yet. Gateways are
+// tracked by hardware ID.
+type IdentityCertificates struct {
+	Id       *protos.Identity `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActiveSn string           `protobuf:"bytes,2,opt,name=active_sn,json=activeSn,proto3" json:"active_sn,omitempty"`
+	// serial number -> time the certificate was superseded
+	Superseded           map[string]*timestamp.Timestamp `protobuf:"bytes,3,rep,name=superseded,proto3" json:"superseded,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *IdentityCertificates) Reset()         { *m = IdentityCertificates{} }
+func (m *IdentityCertificates) String() string { return proto.CompactTextString(m) }
+func (*IdentityCertificates) ProtoMessage()    {}
+func (*IdentityCertificates) Descriptor() ([]byte, []int) {
+	return fileDescriptor_certifier_48e7a144d9ea9c53, []int{8}
+}
+func (m *IdentityCertificates) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IdentityCertificates.Unmarshal(m, b)
+}
+func (m *IdentityCertificates) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IdentityCertificates.Marshal(b, m, deterministic)
+}
+func (dst *IdentityCertificates) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IdentityCertificates.Merge(dst, src)
+}
+func (m *IdentityCertificates) XXX_Size() int {
+	return xxx_messageInfo_IdentityCertificates.Size(m)
+}
+func (m *IdentityCertificates) XXX_DiscardUnknown() {
+	xxx_messageInfo_IdentityCertificates.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IdentityCertificates proto.InternalMessageInfo
+
+func (m *IdentityCertificates) GetId() *protos.Identity {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *IdentityCertificates) GetActiveSn() string {
+	if m != nil {
+		return m.ActiveSn
+	}
+	return ""
+}
+
+func (m *IdentityCertificates) GetSuperseded() map[string]*timestamp.Timestamp {
+	if m != nil {
+		return m.Superseded
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*CertificateInfo)(nil), "magma.orc8r.certifier.CertificateInfo")
 	proto.RegisterType((*CertificateInfoMap)(nil), "magma.orc8r.certifier.CertificateInfoMap")
@@ -262,6 +451,11 @@ func init() {
 	proto.RegisterType((*AddCertRequest)(nil), "magma.orc8r.certifier.AddCertRequest")
 	proto.RegisterType((*SerialNumbers)(nil), "magma.orc8r.certifier.SerialNumbers")
 	proto.RegisterType((*GetCARequest)(nil), "magma.orc8r.certifier.GetCARequest")
+	proto.RegisterType((*GetCRLRequest)(nil), "magma.orc8r.certifier.GetCRLRequest")
+	proto.RegisterType((*CRL)(nil), "magma.orc8r.certifier.CRL")
+	proto.RegisterType((*RevokedCertificate)(nil), "magma.orc8r.certifier.RevokedCertificate")
+	proto.RegisterType((*IdentityCertificates)(nil), "magma.orc8r.certifier.IdentityCertificates")
+	proto.RegisterMapType((map[string]*timestamp.Timestamp)(nil), "magma.orc8r.certifier.IdentityCertificates.SupersededEntry")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -288,8 +482,18 @@ type CertifierClient interface {
 	GetIdentity(ctx context.Context, in *protos.Certificate_SN, opts ...grpc.CallOption) (*CertificateInfo, error)
 	// Revoke an existing certificate.
 	// If the certificate does not exist or is expired, this request is ignored.
+	// The certificate is listed in the CRL of its CA until it expires.
 	//
 	RevokeCertificate(ctx context.Context, in *protos.Certificate_SN, opts ...grpc.CallOption) (*protos.Void, error)
+	// Returns the certificate revocation list of the requested CA, signed by
+	// the CA.
+	//
+	GetCRL(ctx context.Context, in *GetCRLRequest, opts ...grpc.CallOption) (*CRL, error)
+	// Returns when the certificate should be renewed, according to the
+	// certifier's rotation policy.
+	// Throws NOT_FOUND if the certificate is missing.
+	//
+	GetRenewalAdvice(ctx context.Context, in *protos.Certificate_SN, opts ...grpc.CallOption) (*protos.RenewalAdvice, error)
 	// Add provided Certificate (AddCertRequest.cert_der) into Certifier table and
 	// associates its Serial Number with given Identity (AddCertRequest.id)
 	AddCertificate(ctx context.Context, in *AddCertRequest, opts ...grpc.CallOption) (*protos.Void, error)
@@ -349,6 +553,24 @@ func (c *certifierClient) RevokeCertificate(ctx context.Context, in *protos.Cert
 	return out, nil
 }
 
+func (c *certifierClient) GetCRL(ctx context.Context, in *GetCRLRequest, opts ...grpc.CallOption) (*CRL, error) {
+	out := new(CRL)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/GetCRL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certifierClient) GetRenewalAdvice(ctx context.Context, in *protos.Certificate_SN, opts ...grpc.CallOption) (*protos.RenewalAdvice, error) {
+	out := new(protos.RenewalAdvice)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/GetRenewalAdvice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *certifierClient) AddCertificate(ctx context.Context, in *AddCertRequest, opts ...grpc.CallOption) (*protos.Void, error) {
 	out := new(protos.Void)
 	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/AddCertificate", in, out, opts...)
@@ -408,8 +630,18 @@ type CertifierServer interface {
 	GetIdentity(context.Context, *protos.Certificate_SN) (*CertificateInfo, error)
 	// Revoke an existing certificate.
 	// If the certificate does not exist or is expired, this request is ignored.
+	// The certificate is listed in the CRL of its CA until it expires.
 	//
 	RevokeCertificate(context.Context, *protos.Certificate_SN) (*protos.Void, error)
+	// Returns the certificate revocation list of the requested CA, signed by
+	// the CA.
+	//
+	GetCRL(context.Context, *GetCRLRequest) (*CRL, error)
+	// Returns when the certificate should be renewed, according to the
+	// certifier's rotation policy.
+	// Throws NOT_FOUND if the certificate is missing.
+	//
+	GetRenewalAdvice(context.Context, *protos.Certificate_SN) (*protos.RenewalAdvice, error)
 	// Add provided Certificate (AddCertRequest.cert_der) into Certifier table and
 	// associates its Serial Number with given Identity (AddCertRequest.id)
 	AddCertificate(context.Context, *AddCertRequest) (*protos.Void, error)
@@ -501,6 +733,42 @@ func _Certifier_RevokeCertificate_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Certifier_GetCRL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCRLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertifierServer).GetCRL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.certifier.Certifier/GetCRL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertifierServer).GetCRL(ctx, req.(*GetCRLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Certifier_GetRenewalAdvice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.Certificate_SN)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertifierServer).GetRenewalAdvice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.certifier.Certifier/GetRenewalAdvice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertifierServer).GetRenewalAdvice(ctx, req.(*protos.Certificate_SN))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Certifier_AddCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddCertRequest)
 	if err := dec(in); err != nil {
@@ -611,6 +879,14 @@ var _Certifier_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RevokeCertificate",
 			Handler:    _Certifier_RevokeCertificate_Handler,
 		},
+		{
+			MethodName: "GetCRL",
+			Handler:    _Certifier_GetCRL_Handler,
+		},
+		{
+			MethodName: "GetRenewalAdvice",
+			Handler:    _Certifier_GetRenewalAdvice_Handler,
+		},
 		{
 			MethodName: "AddCertificate",
 			Handler:    _Certifier_AddCertificate_Handler,
@@ -636,45 +912,55 @@ var _Certifier_serviceDesc = grpc.ServiceDesc{
 	Metadata: "certifier.proto",
 }
 
-func init() { proto.RegisterFile("certifier.proto", fileDescriptor_certifier_ebcdf080e50839d6) }
-
-var fileDescriptor_certifier_ebcdf080e50839d6 = []byte{
-	// 579 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xdf, 0x8e, 0xd2, 0x40,
-	0x14, 0xc6, 0x5b, 0x90, 0x15, 0x0e, 0xc8, 0xc2, 0x98, 0x4d, 0xba, 0x5d, 0x13, 0xb1, 0xba, 0x06,
-	0x6f, 0x4a, 0x82, 0x17, 0xe2, 0x9f, 0x1b, 0xc0, 0x15, 0x37, 0xd9, 0xdd, 0xc4, 0x42, 0xbc, 0xf0,
-	0x86, 0x94, 0xf6, 0xd0, 0x4c, 0xb6, 0xed, 0xe0, 0x74, 0xd8, 0x84, 0x17, 0xf0, 0x4d, 0x7c, 0x2d,
-	0xe3, 0xa3, 0x98, 0xfe, 0x5b, 0x29, 0x14, 0xed, 0x15, 0x33, 0x73, 0xbe, 0xf9, 0x7d, 0x67, 0xbe,
-	0x19, 0x0a, 0xc7, 0x16, 0x72, 0x41, 0x97, 0x14, 0xb9, 0xbe, 0xe2, 0x4c, 0x30, 0x72, 0xe2, 0x99,
-	0x8e, 0x67, 0xea, 0x8c, 0x5b, 0x03, 0xae, 0xdf, 0x17, 0xd5, 0x27, 0xd1, 0x42, 0x2f, 0xd2, 0x04,
-	0xbd, 0x9d, 0x4d, 0xea, 0x69, 0xb6, 0xca, 0x3c, 0x8f, 0xf9, 0x49, 0xe9, 0x2c, 0x53, 0xa2, 0x36,
-	0xfa, 0x82, 0x8a, 0x4d, 0x52, 0x7c, 0xea, 0x30, 0xe6, 0xb8, 0x18, 0x57, 0x17, 0xeb, 0x65, 0x4f,
-	0x50, 0x0f, 0x03, 0x61, 0x7a, 0xab, 0x58, 0xa0, 0xfd, 0x96, 0xe1, 0x78, 0x1c, 0x9b, 0x59, 0xa6,
-	0xc0, 0x4b, 0x7f, 0xc9, 0xc8, 0x39, 0x94, 0xa8, 0xad, 0xc8, 0x1d, 0xb9, 0x5b, 0xef, 0x9f, 0xe8,
-	0xdb, 0xed, 0x5e, 0x26, 0x74, 0xa3, 0x44, 0x6d, 0xf2, 0x16, 0xc0, 0x67, 0x62, 0xbe, 0xc0, 0x25,
-	0xe3, 0xa8, 0x94, 0x22, 0xb9, 0xaa, 0xc7, 0x86, 0x7a, 0x6a, 0xa8, 0xcf, 0x52, 0x43, 0xa3, 0xe6,
-	0x33, 0x31, 0x8a, 0xc4, 0xe4, 0x0d, 0x84, 0x93, 0xb9, 0xb9, 0x14, 0xc8, 0x95, 0xf2, 0x7f, 0x77,
-	0x56, 0x7d, 0x26, 0x86, 0xa1, 0x96, 0xf4, 0xa1, 0x16, 0x46, 0x33, 0x17, 0x9b, 0x15, 0x2a, 0x0f,
-	0x3a, 0x72, 0xb7, 0xb9, 0xd3, 0x61, 0x78, 0x96, 0xd9, 0x66, 0x85, 0x46, 0xd5, 0x4a, 0x46, 0xda,
-	0x2f, 0x19, 0xc8, 0xce, 0x11, 0xaf, 0xcd, 0x15, 0x99, 0x43, 0xc3, 0xfa, 0xbb, 0x1a, 0x28, 0x72,
-	0xa7, 0xdc, 0xad, 0xf7, 0xdf, 0xeb, 0xb9, 0xd7, 0xa3, 0xef, 0x03, 0xb6, 0x97, 0x82, 0x0b, 0x5f,
-	0xf0, 0x8d, 0x91, 0x01, 0xaa, 0x0e, 0xb4, 0xf7, 0x24, 0xa4, 0x05, 0xe5, 0x5b, 0xdc, 0x44, 0xe1,
-	0xd6, 0x8c, 0x70, 0x48, 0x3e, 0x40, 0xe5, 0xce, 0x74, 0xd7, 0x69, 0x82, 0x2f, 0x8b, 0x35, 0x60,
-	0xc4, 0x9b, 0xde, 0x95, 0x06, 0xb2, 0xf6, 0x43, 0x86, 0xe6, 0xd0, 0xb6, 0x43, 0x85, 0x81, 0xdf,
-	0xd7, 0x18, 0x88, 0xa2, 0x57, 0x78, 0x0a, 0x51, 0x4c, 0x73, 0x1b, 0x79, 0x64, 0xdf, 0x30, 0x1e,
-	0x86, 0xf3, 0x8f, 0xbb, 0x49, 0x97, 0x8b, 0x25, 0xfd, 0x0c, 0x1e, 0x4d, 0x91, 0x53, 0xd3, 0xbd,
-	0x59, 0x7b, 0x0b, 0xe4, 0x41, 0x78, 0xda, 0xc0, 0x8f, 0xa3, 0xad, 0x19, 0xe1, 0x50, 0x1b, 0x41,
-	0x63, 0x82, 0x62, 0x3c, 0x4c, 0x1b, 0xcd, 0xd8, 0xc8, 0x85, 0x6c, 0xfa, 0x3f, 0x2b, 0x50, 0x1b,
-	0xa7, 0xc1, 0x90, 0x31, 0x54, 0x22, 0x22, 0x79, 0x7e, 0x20, 0xb9, 0x6d, 0x3f, 0xf5, 0x71, 0x16,
-	0x3e, 0x0c, 0x39, 0x9a, 0x44, 0x46, 0x40, 0xa6, 0xd4, 0xf1, 0x93, 0x14, 0x93, 0x9c, 0x49, 0x2b,
-	0x2b, 0x9e, 0x1a, 0xaa, 0xb2, 0xd7, 0x5b, 0xa2, 0xd5, 0x24, 0x32, 0x83, 0xfa, 0x04, 0x45, 0x9a,
-	0x2f, 0x39, 0x3b, 0x24, 0xd5, 0xa7, 0x37, 0x6a, 0xc1, 0x5b, 0xd6, 0x24, 0x72, 0x01, 0x6d, 0x03,
-	0xef, 0xd8, 0x2d, 0x6e, 0x37, 0xf6, 0x4f, 0x76, 0x3b, 0x53, 0xfc, 0xca, 0xa8, 0xad, 0x49, 0xe4,
-	0xea, 0xfe, 0x89, 0xa4, 0x8c, 0xf3, 0x03, 0x2d, 0x64, 0x5f, 0x52, 0x3e, 0xed, 0x0b, 0xb4, 0x3e,
-	0x51, 0x7f, 0x1b, 0x17, 0x90, 0xfc, 0x67, 0xa6, 0xbe, 0x38, 0x60, 0x93, 0x79, 0x28, 0x9a, 0x44,
-	0xae, 0xa1, 0x75, 0x45, 0x03, 0x91, 0x41, 0xee, 0x7b, 0x17, 0xc6, 0x7d, 0x86, 0xa3, 0x09, 0x8a,
-	0xa1, 0xeb, 0xe6, 0x41, 0x5e, 0x15, 0xfe, 0x93, 0x6b, 0x12, 0x19, 0x40, 0x73, 0xcc, 0x5c, 0x17,
-	0x2d, 0x31, 0x31, 0xf9, 0xc2, 0x74, 0x30, 0x8f, 0x98, 0x97, 0xd2, 0xa8, 0xfa, 0xed, 0x28, 0xfe,
-	0x2a, 0x2f, 0xe2, 0xdf, 0xd7, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xfb, 0xa9, 0xfc, 0xb5, 0x0d,
-	0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("certifier.proto", fileDescriptor_certifier_48e7a144d9ea9c53) }
+
+var fileDescriptor_certifier_48e7a144d9ea9c53 = []byte{
+	// 746 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xdd, 0x72, 0xd2, 0x40,
+	0x14, 0x26, 0x60, 0x29, 0x9c, 0xb6, 0x94, 0xae, 0x76, 0xa4, 0xa9, 0xa3, 0x35, 0x5a, 0xa7, 0xde,
+	0xa4, 0x0e, 0x5e, 0x58, 0x7f, 0x6e, 0x80, 0x56, 0xec, 0x0c, 0xed, 0x8c, 0x4b, 0xc7, 0x19, 0xf5,
+	0x82, 0x09, 0xc9, 0x81, 0xc9, 0x34, 0x64, 0x71, 0xb3, 0xe0, 0xf0, 0x02, 0x3e, 0x80, 0xcf, 0xe3,
+	0x95, 0x0f, 0xe2, 0xf8, 0x28, 0x4e, 0x12, 0xd2, 0x66, 0x21, 0xd0, 0x4c, 0xbd, 0x6a, 0xb2, 0xe7,
+	0xdb, 0xef, 0x7c, 0xfb, 0xed, 0xf9, 0x52, 0x60, 0xd3, 0x44, 0x2e, 0xec, 0x9e, 0x8d, 0x5c, 0x1f,
+	0x72, 0x26, 0x18, 0xd9, 0x1e, 0x18, 0xfd, 0x81, 0xa1, 0x33, 0x6e, 0x1e, 0x71, 0xfd, 0xaa, 0xa8,
+	0x3e, 0x08, 0x16, 0x0e, 0x03, 0x8c, 0x77, 0x38, 0xb3, 0x49, 0xdd, 0x91, 0xab, 0x6c, 0x30, 0x60,
+	0xee, 0xb4, 0xb4, 0x2b, 0x95, 0x6c, 0x0b, 0x5d, 0x61, 0x8b, 0xc9, 0xb4, 0xf8, 0xa8, 0xcf, 0x58,
+	0xdf, 0xc1, 0xb0, 0xda, 0x1d, 0xf5, 0x0e, 0x85, 0x3d, 0x40, 0x4f, 0x18, 0x83, 0x61, 0x08, 0xd0,
+	0xfe, 0x2a, 0xb0, 0xd9, 0x08, 0x9b, 0x99, 0x86, 0xc0, 0x53, 0xb7, 0xc7, 0xc8, 0x3e, 0x64, 0x6d,
+	0xab, 0xa2, 0xec, 0x29, 0x07, 0x6b, 0xd5, 0x6d, 0x3d, 0x2e, 0xf7, 0x74, 0xca, 0x4e, 0xb3, 0xb6,
+	0x45, 0x5e, 0x03, 0xb8, 0x4c, 0x74, 0xba, 0xd8, 0x63, 0x1c, 0x2b, 0xd9, 0x00, 0xae, 0xea, 0x61,
+	0x43, 0x3d, 0x6a, 0xa8, 0x5f, 0x44, 0x0d, 0x69, 0xd1, 0x65, 0xa2, 0x1e, 0x80, 0xc9, 0x2b, 0xf0,
+	0x5f, 0x3a, 0x46, 0x4f, 0x20, 0xaf, 0xe4, 0x6e, 0xdc, 0x59, 0x70, 0x99, 0xa8, 0xf9, 0x58, 0x52,
+	0x85, 0xa2, 0x6f, 0x4d, 0x47, 0x4c, 0x86, 0x58, 0xb9, 0xb3, 0xa7, 0x1c, 0x94, 0x66, 0x14, 0xfa,
+	0x67, 0xb9, 0x98, 0x0c, 0x91, 0x16, 0xcc, 0xe9, 0x93, 0xf6, 0x47, 0x01, 0x32, 0x73, 0xc4, 0x33,
+	0x63, 0x48, 0x3a, 0xb0, 0x6e, 0x5e, 0xaf, 0x7a, 0x15, 0x65, 0x2f, 0x77, 0xb0, 0x56, 0x7d, 0xab,
+	0x27, 0x5e, 0x8f, 0x3e, 0x4f, 0x10, 0x5f, 0xf2, 0x4e, 0x5c, 0xc1, 0x27, 0x54, 0x22, 0x54, 0xfb,
+	0xb0, 0x35, 0x07, 0x21, 0x65, 0xc8, 0x5d, 0xe2, 0x24, 0x30, 0xb7, 0x48, 0xfd, 0x47, 0xf2, 0x0e,
+	0x56, 0xc6, 0x86, 0x33, 0x8a, 0x1c, 0x7c, 0x96, 0x4e, 0x00, 0x0d, 0x37, 0xbd, 0xc9, 0x1e, 0x29,
+	0xda, 0x0f, 0x05, 0x4a, 0x35, 0xcb, 0xf2, 0x11, 0x14, 0xbf, 0x8d, 0xd0, 0x13, 0x69, 0xaf, 0x70,
+	0x07, 0x02, 0x9b, 0x3a, 0x16, 0xf2, 0xa0, 0xfd, 0x3a, 0x5d, 0xf5, 0xdf, 0x8f, 0x67, 0x9d, 0xce,
+	0xa5, 0x73, 0xfa, 0x31, 0x6c, 0xb4, 0x91, 0xdb, 0x86, 0x73, 0x3e, 0x1a, 0x74, 0x91, 0x7b, 0xfe,
+	0x69, 0x3d, 0x37, 0xb4, 0xb6, 0x48, 0xfd, 0x47, 0xad, 0x0e, 0xeb, 0x4d, 0x14, 0x8d, 0x5a, 0x24,
+	0x54, 0x6a, 0xa3, 0xa4, 0x6b, 0xd3, 0x80, 0x0d, 0x9f, 0x83, 0xb6, 0xfe, 0x87, 0xe4, 0x21, 0xe4,
+	0x1a, 0xb4, 0x45, 0xee, 0xc3, 0xaa, 0xc9, 0x9d, 0xc0, 0x00, 0x25, 0x30, 0x20, 0x6f, 0x72, 0xe7,
+	0x18, 0xb9, 0xf6, 0x4b, 0x01, 0x42, 0x71, 0xcc, 0x2e, 0xd1, 0x8a, 0x59, 0x7f, 0x9b, 0x56, 0x7e,
+	0x50, 0x78, 0xc8, 0xd4, 0x31, 0x44, 0x9a, 0xa0, 0x4c, 0xd1, 0x35, 0x71, 0xeb, 0xa0, 0x68, 0x3f,
+	0xb3, 0x70, 0x2f, 0xba, 0xea, 0xf8, 0x14, 0xa6, 0x9d, 0x8c, 0x5d, 0x28, 0x1a, 0xa6, 0xb0, 0xc7,
+	0xd8, 0xf1, 0xdc, 0x40, 0x72, 0x91, 0x16, 0xc2, 0x85, 0xb6, 0x4b, 0xbe, 0x02, 0x78, 0xa3, 0x21,
+	0x72, 0x0f, 0x2d, 0xb4, 0x2a, 0xb9, 0xa5, 0xc1, 0x49, 0x12, 0xa1, 0xb7, 0xaf, 0x76, 0x87, 0xc1,
+	0x89, 0xd1, 0xa9, 0x9f, 0x61, 0x73, 0xa6, 0x9c, 0x10, 0x9a, 0x17, 0x72, 0x68, 0x96, 0x79, 0x72,
+	0x1d, 0x94, 0xea, 0xef, 0x3c, 0x14, 0x1b, 0x91, 0x32, 0xd2, 0x80, 0x95, 0x60, 0x14, 0xc9, 0x93,
+	0x05, 0xd2, 0xe3, 0x83, 0xaa, 0xde, 0x95, 0x6f, 0xb9, 0xe6, 0xf3, 0x68, 0x19, 0x52, 0x07, 0xd2,
+	0xb6, 0xfb, 0xee, 0x34, 0x7e, 0xd1, 0x94, 0x94, 0x65, 0x70, 0x9b, 0xaa, 0x95, 0xb9, 0x21, 0x99,
+	0x62, 0xb5, 0x0c, 0xb9, 0x80, 0xb5, 0x26, 0x8a, 0xc8, 0x28, 0xb2, 0xbb, 0x08, 0xaa, 0xb7, 0xcf,
+	0xd5, 0x94, 0x9f, 0x07, 0x2d, 0x43, 0x4e, 0x60, 0x2b, 0x9c, 0xdf, 0xb8, 0xb0, 0xa5, 0xdc, 0x5b,
+	0x52, 0xf1, 0x13, 0xb3, 0x2d, 0x2d, 0x43, 0x5a, 0x90, 0x0f, 0xc3, 0x46, 0x9e, 0x2e, 0xb1, 0xe9,
+	0x2a, 0x8b, 0xaa, 0xba, 0x48, 0x20, 0x6d, 0x69, 0x19, 0x72, 0x06, 0xe5, 0x26, 0x0a, 0x8a, 0x2e,
+	0x7e, 0x37, 0x9c, 0x9a, 0x35, 0xb6, 0xcd, 0x1b, 0x34, 0xc9, 0x74, 0xd2, 0xc6, 0x40, 0x5c, 0x69,
+	0xc6, 0xf9, 0xfd, 0x05, 0xed, 0xe5, 0xef, 0x63, 0xf2, 0x51, 0x3f, 0x42, 0xf9, 0xbd, 0xed, 0x5a,
+	0x52, 0x5c, 0x92, 0x23, 0xa2, 0x2e, 0xf2, 0x42, 0xfa, 0xfc, 0x85, 0xe7, 0x6d, 0xd9, 0x9e, 0x90,
+	0x28, 0xe7, 0x7b, 0xa7, 0xa6, 0xfb, 0x10, 0x5c, 0x46, 0xcd, 0x71, 0x92, 0x48, 0x9e, 0xa7, 0xfe,
+	0xd7, 0xa5, 0x65, 0xc8, 0x11, 0x94, 0x1a, 0xcc, 0x71, 0xd0, 0x14, 0x4d, 0x83, 0x77, 0x8d, 0x3e,
+	0x26, 0x31, 0x26, 0xb9, 0x54, 0x2f, 0x7c, 0xc9, 0x87, 0xbf, 0x35, 0xba, 0xe1, 0xdf, 0x97, 0xff,
+	0x06, 0x00, 0xd9, 0xd7, 0xc0, 0xdf, 0xe3, 0x08, 0x00, 0x00,
 }
@@ -14,13 +14,16 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/identity"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/security/cert"
+	"magma/orc8r/cloud/go/services/certifier/metrics"
 	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
+	"magma/orc8r/cloud/go/services/certifier/signer"
 
 	"github.com/golang/glog"
 	"github.com/golang/protobuf/proto"
@@ -41,13 +44,18 @@ func init() {
 }
 
 type CAInfo struct {
-	Cert    *x509.Certificate
-	PrivKey interface{}
+	Cert   *x509.Certificate
+	Signer signer.Signer
 }
 
 type CertifierServer struct {
 	store datastore.Api
 	CAs   map[protos.CertType]*CAInfo
+
+	crlLock sync.Mutex
+	crls    map[protos.CertType]cachedCRL
+
+	rotationLock sync.Mutex
 }
 
 func NewCertifierServer(store datastore.Api, CAs map[protos.CertType]*CAInfo) (srv *CertifierServer, err error) {
@@ -59,7 +67,16 @@ func NewCertifierServer(store datastore.Api, CAs map[protos.CertType]*CAInfo) (s
 	if len(CAs) == 0 {
 		return nil, fmt.Errorf("No Certificates are provided to certifier")
 	}
+	for certType, ca := range CAs {
+		if ca == nil || ca.Cert == nil || ca.Signer == nil {
+			return nil, fmt.Errorf("Incomplete CA info for cert type: %s", certType.String())
+		}
+		if ca.Cert.KeyUsage&x509.KeyUsageCRLSign == 0 {
+			glog.Warningf("%s CA certificate doesn't allow CRL signing, its CRL can't be generated", certType.String())
+		}
+	}
 	srv.CAs = CAs
+	srv.crls = map[protos.CertType]cachedCRL{}
 	return srv, nil
 }
 
@@ -108,7 +125,6 @@ func (srv *CertifierServer) signCSR(
 		return nil, time.Time{}, time.Time{}, fmt.Errorf("No CA found for given cert type: %s", certType.String())
 	}
 	signingCert := ca.Cert
-	signingKey := ca.PrivKey
 
 	now := time.Now().UTC()
 	// Provide a cert from an hour ago to account for clock skews
@@ -129,7 +145,7 @@ func (srv *CertifierServer) signCSR(
 	}
 
 	clientCertDER, err := x509.CreateCertificate(
-		rand.Reader, &template, signingCert, csr.PublicKey, signingKey)
+		rand.Reader, &template, signingCert, csr.PublicKey, ca.Signer)
 	if err != nil {
 		return nil, time.Time{}, time.Time{}, fmt.Errorf("Failed to sign csr: %s", err)
 	}
@@ -267,6 +283,13 @@ func (srv *CertifierServer) SignAddCertificate(ctx context.Context, csrMsg *prot
 	if err != nil {
 		return nil, status.Errorf(codes.Aborted, "Error adding CertificateInfo: %s", err)
 	}
+	err = srv.trackGatewayCertificate(snString, &certInfo)
+	if err != nil {
+		// The previous certificate won't be revoked automatically, but the
+		// new certificate is still valid
+		glog.Errorf("Failed to track certificate %s for rotation: %s", snString, err)
+		metrics.TrackingFailures.Inc()
+	}
 
 	// create Certificate
 	certMsg := protos.Certificate{
@@ -315,15 +338,37 @@ func (srv *CertifierServer) RevokeCertificate(
 	if snMsg != nil {
 		certSN = strings.TrimLeft(snMsg.Sn, "0")
 	}
-	_, _, err := srv.store.Get(CERTIFICATE_INFO_TABLE, certSN)
+	certInfo, err := srv.revokeCertificate(certSN)
+	if err != nil {
+		return nil, err
+	}
+	// The revocation is recorded either way, and will be published when the
+	// cached CRL expires
+	_, err = srv.regenerateCRL(certInfo.CertType)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Revoked certificate %s, but failed to regenerate CRL: %s", certSN, err)
+	}
+	return &protos.Void{}, nil
+}
+
+// revokeCertificate records the revocation of a certificate and deletes it,
+// without regenerating the CRL of its CA
+func (srv *CertifierServer) revokeCertificate(certSN string) (*certprotos.CertificateInfo, error) {
+	certInfo, err := srv.getCertInfo(certSN)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "Cannot find certificate with SN: %s", certSN)
 	}
+	// Record the revocation before deleting the certificate, so that a
+	// revoked certificate is never reported as unknown
+	err = srv.addRevokedCertificate(certSN, certInfo)
+	if err != nil {
+		return nil, status.Errorf(codes.Aborted, "Failed to revoke certificate: %s", err)
+	}
 	err = srv.store.Delete(CERTIFICATE_INFO_TABLE, certSN)
 	if err != nil {
 		return nil, status.Errorf(codes.Aborted, "Failed to delete certificate: %s", err)
 	}
-	return &protos.Void{}, nil
+	return certInfo, nil
 }
 
 func (srv *CertifierServer) AddCertificate(ctx context.Context, req *certprotos.AddCertRequest) (*protos.Void, error) {
@@ -445,6 +490,7 @@ func (srv *CertifierServer) CollectGarbage(ctx context.Context, void *protos.Voi
 		err error
 	}{}
 	count := 0
+	liveCertInfos := map[string]*certprotos.CertificateInfo{}
 	for _, sn := range snList.Sns {
 		certInfo, err := srv.getCertInfo(sn)
 		if err != nil {
@@ -462,11 +508,21 @@ func (srv *CertifierServer) CollectGarbage(ctx context.Context, void *protos.Voi
 			} else {
 				count += 1
 			}
+		} else {
+			liveCertInfos[sn] = certInfo
 		}
 	}
 	if count > 0 {
 		glog.V(2).Infof("Removed %d stale certificates", count)
 	}
+	err = srv.collectRevokedCertificates()
+	if err != nil {
+		glog.Errorf("Failed to remove stale revoked certificates: %s", err)
+	}
+	err = srv.supersedeUntrackedCertificates(liveCertInfos)
+	if err != nil {
+		glog.Errorf("Failed to track certificates for rotation: %s", err)
+	}
 	if len(errorList) > 0 {
 		msg := "Failed to delete certificate[s]:"
 		for _, e := range errorList {
@@ -9,19 +9,26 @@ LICENSE file in the root directory of this source tree.
 package servicers_test
 
 import (
+	"crypto"
 	"crypto/x509"
 	"testing"
 	"time"
 
 	"magma/orc8r/cloud/go/protos"
+	security_cert "magma/orc8r/cloud/go/security/cert"
+	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
 	"magma/orc8r/cloud/go/services/certifier/servicers"
+	"magma/orc8r/cloud/go/services/certifier/signer"
 	certifier_test_utils "magma/orc8r/cloud/go/services/certifier/test_utils"
 	"magma/orc8r/cloud/go/test_utils"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ocsp"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestCertifier(t *testing.T) {
@@ -34,7 +41,7 @@ func TestCertifier(t *testing.T) {
 
 	// just test with default
 	caMap := map[protos.CertType]*servicers.CAInfo{
-		protos.CertType_DEFAULT: {caCert, caKey},
+		protos.CertType_DEFAULT: {Cert: caCert, Signer: caKey.(signer.Signer)},
 	}
 	srv, err := servicers.NewCertifierServer(ds, caMap)
 	assert.NoError(t, err)
@@ -116,3 +123,218 @@ func TestCertifier(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, cert.Subject.CommonName, *csrMsg.Id.ToCommonName())
 }
+
+func TestCertifier_Revocation(t *testing.T) {
+	ds := test_utils.NewMockDatastore()
+	ctx := context.Background()
+
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(
+		time.Duration(time.Hour * 24 * 10))
+	assert.NoError(t, err)
+	caMap := map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {Cert: caCert, Signer: caKey.(signer.Signer)},
+	}
+	srv, err := servicers.NewCertifierServer(ds, caMap)
+	assert.NoError(t, err)
+
+	signCert := func() *x509.Certificate {
+		csrMsg, err := certifier_test_utils.CreateCSR(time.Duration(time.Hour*24), "cn", "cn")
+		assert.NoError(t, err)
+		certMsg, err := srv.SignAddCertificate(ctx, csrMsg)
+		assert.NoError(t, err)
+		cert, err := x509.ParseCertificate(certMsg.CertDer)
+		assert.NoError(t, err)
+		return cert
+	}
+	goodCert := signCert()
+	revokedCert := signCert()
+	_, err = srv.RevokeCertificate(ctx, &protos.Certificate_SN{Sn: security_cert.SerialToString(revokedCert.SerialNumber)})
+	assert.NoError(t, err)
+
+	// the CRL is signed by the CA and lists the revoked certificate only
+	crlMsg, err := srv.GetCRL(ctx, &certprotos.GetCRLRequest{CertType: protos.CertType_DEFAULT})
+	assert.NoError(t, err)
+	crl, err := x509.ParseRevocationList(crlMsg.CrlDer)
+	assert.NoError(t, err)
+	assert.NoError(t, crl.CheckSignatureFrom(caCert))
+	assert.Equal(t, 1, len(crl.RevokedCertificateEntries))
+	if len(crl.RevokedCertificateEntries) == 1 {
+		assert.Equal(t, 0, crl.RevokedCertificateEntries[0].SerialNumber.Cmp(revokedCert.SerialNumber))
+	}
+
+	// there is no CA for VPN certificates
+	_, err = srv.GetCRL(ctx, &certprotos.GetCRLRequest{CertType: protos.CertType_VPN})
+	assert.Error(t, err)
+
+	// OCSP
+	getOCSPStatus := func(cert *x509.Certificate) int {
+		reqDER, err := ocsp.CreateRequest(cert, caCert, &ocsp.RequestOptions{Hash: crypto.SHA1})
+		assert.NoError(t, err)
+		respDER, err := srv.CreateOCSPResponse(reqDER)
+		assert.NoError(t, err)
+		resp, err := ocsp.ParseResponseForCert(respDER, cert, caCert)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, resp.SerialNumber.Cmp(cert.SerialNumber))
+		return resp.Status
+	}
+	assert.Equal(t, ocsp.Good, getOCSPStatus(goodCert))
+	assert.Equal(t, ocsp.Revoked, getOCSPStatus(revokedCert))
+	unknownCert := *goodCert
+	unknownCert.SerialNumber = unknownCert.SerialNumber.Lsh(unknownCert.SerialNumber, 1)
+	assert.Equal(t, ocsp.Unknown, getOCSPStatus(&unknownCert))
+
+	// requests about certificates of unknown CAs are unauthorized
+	otherCACert, _, err := certifier_test_utils.CreateSignedCertAndPrivKey(time.Hour)
+	assert.NoError(t, err)
+	reqDER, err := ocsp.CreateRequest(goodCert, otherCACert, nil)
+	assert.NoError(t, err)
+	respDER, err := srv.CreateOCSPResponse(reqDER)
+	assert.NoError(t, err)
+	assert.Equal(t, ocsp.UnauthorizedErrorResponse, respDER)
+
+	// CRLs can't be signed by CAs without the cRLSign key usage, but the
+	// revocation is still recorded
+	noCRLSignCACert := *caCert
+	noCRLSignCACert.KeyUsage = x509.KeyUsageCertSign
+	srv, err = servicers.NewCertifierServer(ds, map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {Cert: &noCRLSignCACert, Signer: caKey.(signer.Signer)},
+	})
+	assert.NoError(t, err)
+	revokedCert = signCert()
+	_, err = srv.RevokeCertificate(ctx, &protos.Certificate_SN{Sn: security_cert.SerialToString(revokedCert.SerialNumber)})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, ocsp.Revoked, getOCSPStatus(revokedCert))
+}
+
+func TestCertifier_Rotation(t *testing.T) {
+	ds := test_utils.NewMockDatastore()
+	ctx := context.Background()
+
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(
+		time.Duration(time.Hour * 24 * 10))
+	assert.NoError(t, err)
+	caMap := map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {Cert: caCert, Signer: caKey.(signer.Signer)},
+	}
+	srv, err := servicers.NewCertifierServer(ds, caMap)
+	assert.NoError(t, err)
+
+	gwId := &protos.Identity{Value: &protos.Identity_Gateway_{Gateway: &protos.Identity_Gateway{
+		HardwareId: "hw1",
+		NetworkId:  "network1",
+	}}}
+	signCert := func() *protos.Certificate_SN {
+		csrMsg, err := certifier_test_utils.CreateCSRForId(time.Duration(time.Hour*24), gwId)
+		assert.NoError(t, err)
+		certMsg, err := srv.SignAddCertificate(ctx, csrMsg)
+		assert.NoError(t, err)
+		return certMsg.Sn
+	}
+
+	// a new certificate doesn't need to be renewed yet
+	oldSn := signCert()
+	advice, err := srv.GetRenewalAdvice(ctx, oldSn)
+	assert.NoError(t, err)
+	assert.False(t, advice.RenewNow)
+	assert.False(t, advice.Superseded)
+	renewAt, _ := ptypes.Timestamp(advice.RenewAt)
+	// certificates are valid from an hour before they're signed
+	assert.WithinDuration(t, time.Now().Add(-time.Hour+25*time.Hour*3/4), renewAt, time.Minute)
+
+	// past the renewal fraction, the gateway is advised to renew
+	servicers.RenewalFraction = 0
+	defer func() { servicers.RenewalFraction = 0.75 }()
+	advice, err = srv.GetRenewalAdvice(ctx, oldSn)
+	assert.NoError(t, err)
+	assert.True(t, advice.RenewNow)
+
+	// the renewed certificate supersedes the old one
+	newSn := signCert()
+	advice, err = srv.GetRenewalAdvice(ctx, oldSn)
+	assert.NoError(t, err)
+	assert.False(t, advice.RenewNow)
+	assert.True(t, advice.Superseded)
+	advice, err = srv.GetRenewalAdvice(ctx, newSn)
+	assert.NoError(t, err)
+	assert.False(t, advice.Superseded)
+
+	// superseded certificates are kept during the grace period
+	assert.NoError(t, srv.RotateCertificates())
+	_, err = srv.GetIdentity(ctx, oldSn)
+	assert.NoError(t, err)
+
+	// and revoked after it
+	servicers.SupersededCertGracePeriod = 0
+	defer func() { servicers.SupersededCertGracePeriod = time.Hour }()
+	assert.NoError(t, srv.RotateCertificates())
+	_, err = srv.GetIdentity(ctx, oldSn)
+	assert.Error(t, err)
+	_, err = srv.GetIdentity(ctx, newSn)
+	assert.NoError(t, err)
+	crlMsg, err := srv.GetCRL(ctx, &certprotos.GetCRLRequest{CertType: protos.CertType_DEFAULT})
+	assert.NoError(t, err)
+	crl, err := x509.ParseRevocationList(crlMsg.CrlDer)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(crl.RevokedCertificateEntries))
+
+	// once all certificates of the gateway are gone, it isn't tracked anymore
+	_, err = srv.RevokeCertificate(ctx, newSn)
+	assert.NoError(t, err)
+	assert.NoError(t, srv.RotateCertificates())
+	keys, err := ds.ListKeys(servicers.IDENTITY_CERTIFICATES_TABLE)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestCertifier_RotationTracksHardwareId(t *testing.T) {
+	ds := test_utils.NewMockDatastore()
+	ctx := context.Background()
+
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(
+		time.Duration(time.Hour * 24 * 10))
+	assert.NoError(t, err)
+	caMap := map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {Cert: caCert, Signer: caKey.(signer.Signer)},
+	}
+	srv, err := servicers.NewCertifierServer(ds, caMap)
+	assert.NoError(t, err)
+
+	signCert := func(networkId string) *protos.Certificate_SN {
+		gwId := &protos.Identity{Value: &protos.Identity_Gateway_{Gateway: &protos.Identity_Gateway{
+			HardwareId: "hw1",
+			NetworkId:  networkId,
+		}}}
+		csrMsg, err := certifier_test_utils.CreateCSRForId(time.Duration(time.Hour*24), gwId)
+		assert.NoError(t, err)
+		certMsg, err := srv.SignAddCertificate(ctx, csrMsg)
+		assert.NoError(t, err)
+		return certMsg.Sn
+	}
+
+	// a certificate signed after the gateway was registered to a network
+	// supersedes its bootstrap certificate
+	oldSn := signCert("")
+	newSn := signCert("network1")
+	advice, err := srv.GetRenewalAdvice(ctx, oldSn)
+	assert.NoError(t, err)
+	assert.True(t, advice.Superseded)
+	keys, err := ds.ListKeys(servicers.IDENTITY_CERTIFICATES_TABLE)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hw1"}, keys)
+
+	// certificates signed before rotation was enabled are superseded once
+	// collected
+	assert.NoError(t, ds.Delete(servicers.IDENTITY_CERTIFICATES_TABLE, "hw1"))
+	newerSn := signCert("network1")
+	advice, err = srv.GetRenewalAdvice(ctx, newSn)
+	assert.NoError(t, err)
+	assert.False(t, advice.Superseded)
+	_, err = srv.CollectGarbage(ctx, &protos.Void{})
+	assert.NoError(t, err)
+	advice, err = srv.GetRenewalAdvice(ctx, newSn)
+	assert.NoError(t, err)
+	assert.True(t, advice.Superseded)
+	advice, err = srv.GetRenewalAdvice(ctx, newerSn)
+	assert.NoError(t, err)
+	assert.False(t, advice.Superseded)
+}
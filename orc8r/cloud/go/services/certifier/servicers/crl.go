@@ -0,0 +1,209 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"strings"
+	"time"
+
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/protos"
+	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// CRLValidity is the time between the thisUpdate and nextUpdate of
+	// generated CRLs
+	CRLValidity = 24 * time.Hour
+	// CRLMaxAge is how long a generated CRL is served before it's regenerated,
+	// so that revocations through other certifier instances get published
+	CRLMaxAge = time.Minute
+)
+
+type cachedCRL struct {
+	der         []byte
+	generatedAt time.Time
+}
+
+// GetCRL returns the certificate revocation list of the requested CA.
+func (srv *CertifierServer) GetCRL(ctx context.Context, req *certprotos.GetCRLRequest) (*certprotos.CRL, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid CRL request")
+	}
+	crlDER, err := srv.getCRL(req.CertType)
+	if err != nil {
+		return nil, err
+	}
+	return &certprotos.CRL{CrlDer: crlDER}, nil
+}
+
+// getCRL returns the cached CRL of a CA, and regenerates it if it's older
+// than CRLMaxAge.
+func (srv *CertifierServer) getCRL(certType protos.CertType) ([]byte, error) {
+	srv.crlLock.Lock()
+	cached, ok := srv.crls[certType]
+	srv.crlLock.Unlock()
+	if ok && time.Since(cached.generatedAt) < CRLMaxAge {
+		return cached.der, nil
+	}
+	return srv.regenerateCRL(certType)
+}
+
+// regenerateCRL creates a CRL of the revoked certificates of a CA which
+// haven't expired yet, signed by the CA.
+func (srv *CertifierServer) regenerateCRL(certType protos.CertType) ([]byte, error) {
+	ca, ok := srv.CAs[certType]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "No CA found for given cert type: %s", certType.String())
+	}
+	revoked, err := srv.listRevokedCertificates(certType)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	template := &x509.RevocationList{
+		RevokedCertificateEntries: revoked,
+		// Derive the CRL number from the time, so that it increases across
+		// certifier instances
+		Number:     big.NewInt(now.UnixNano()),
+		ThisUpdate: now,
+		NextUpdate: now.Add(CRLValidity),
+	}
+	// Fails unless the CA certificate has the cRLSign key usage
+	crlDER, err := x509.CreateRevocationList(rand.Reader, template, ca.Cert, ca.Signer)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create CRL of %s CA: %s", certType.String(), err)
+	}
+
+	srv.crlLock.Lock()
+	defer srv.crlLock.Unlock()
+	if cached, ok := srv.crls[certType]; !ok || cached.generatedAt.Before(now) {
+		srv.crls[certType] = cachedCRL{der: crlDER, generatedAt: now}
+	}
+	return crlDER, nil
+}
+
+// revokedCertificatesTable returns the table which the revoked certificates
+// of a CA are stored in, so that a CA's CRL doesn't load the revoked
+// certificates of the other CAs.
+func revokedCertificatesTable(certType protos.CertType) string {
+	return REVOKED_CERTIFICATES_TABLE + "_" + strings.ToLower(certType.String())
+}
+
+func (srv *CertifierServer) addRevokedCertificate(sn string, certInfo *certprotos.CertificateInfo) error {
+	revokedCert := &certprotos.RevokedCertificate{
+		CertType:  certInfo.CertType,
+		RevokedAt: ptypes.TimestampNow(),
+		NotAfter:  certInfo.NotAfter,
+	}
+	marshaledRevokedCert, err := proto.Marshal(revokedCert)
+	if err != nil {
+		return err
+	}
+	return srv.store.Put(revokedCertificatesTable(certInfo.CertType), sn, marshaledRevokedCert)
+}
+
+// getRevokedCertificate returns the revocation record of a certificate signed
+// by a CA, or nil if the certificate isn't revoked.
+func (srv *CertifierServer) getRevokedCertificate(certType protos.CertType, sn string) (*certprotos.RevokedCertificate, error) {
+	marshaledRevokedCert, _, err := srv.store.Get(revokedCertificatesTable(certType), sn)
+	if err == datastore.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	revokedCert := &certprotos.RevokedCertificate{}
+	err = proto.Unmarshal(marshaledRevokedCert, revokedCert)
+	return revokedCert, err
+}
+
+func (srv *CertifierServer) listRevokedCertificates(certType protos.CertType) ([]x509.RevocationListEntry, error) {
+	table := revokedCertificatesTable(certType)
+	snList, err := srv.store.ListKeys(table)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list revoked certificates: %s", err)
+	}
+	values, err := srv.store.GetMany(table, snList)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to load revoked certificates: %s", err)
+	}
+
+	now := time.Now().UTC()
+	ret := []x509.RevocationListEntry{}
+	for sn, value := range values {
+		revokedCert := &certprotos.RevokedCertificate{}
+		err = proto.Unmarshal(value.Value, revokedCert)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to unmarshal revoked certificate %s: %s", sn, err)
+		}
+		// Expired certificates don't need to be listed
+		notAfter, _ := ptypes.Timestamp(revokedCert.NotAfter)
+		if now.After(notAfter) {
+			continue
+		}
+		serialNumber, ok := new(big.Int).SetString(sn, 16)
+		if !ok {
+			return nil, status.Errorf(codes.Internal, "Invalid serial number of revoked certificate: %s", sn)
+		}
+		revokedAt, _ := ptypes.Timestamp(revokedCert.RevokedAt)
+		ret = append(ret, x509.RevocationListEntry{SerialNumber: serialNumber, RevocationTime: revokedAt})
+	}
+	return ret, nil
+}
+
+// collectRevokedCertificates removes the revocation records of certificates
+// which have been expired for CollectGarbageAfter.
+func (srv *CertifierServer) collectRevokedCertificates() error {
+	for certType := range srv.CAs {
+		err := srv.collectRevokedCertificatesOfType(certType)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (srv *CertifierServer) collectRevokedCertificatesOfType(certType protos.CertType) error {
+	table := revokedCertificatesTable(certType)
+	snList, err := srv.store.ListKeys(table)
+	if err != nil {
+		return err
+	}
+	values, err := srv.store.GetMany(table, snList)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	for sn, value := range values {
+		revokedCert := &certprotos.RevokedCertificate{}
+		err = proto.Unmarshal(value.Value, revokedCert)
+		if err != nil {
+			return err
+		}
+		notAfter, _ := ptypes.Timestamp(revokedCert.NotAfter)
+		if now.After(notAfter.Add(CollectGarbageAfter)) {
+			err = srv.store.Delete(table, sn)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -9,5 +9,7 @@ LICENSE file in the root directory of this source tree.
 package servicers
 
 const (
-	CERTIFICATE_INFO_TABLE = "certificate_info_db"
+	CERTIFICATE_INFO_TABLE      = "certificate_info_db"
+	REVOKED_CERTIFICATES_TABLE  = "revoked_certificates_db"
+	IDENTITY_CERTIFICATES_TABLE = "identity_certificates_db"
 )
@@ -0,0 +1,95 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"magma/orc8r/cloud/go/protos"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// CRLPathPrefix is followed by the lowercase cert type of the CA, e.g.
+	// /crl/default
+	CRLPathPrefix = "/crl/"
+	OCSPPath      = "/ocsp"
+
+	maxOCSPRequestSize = 4096
+)
+
+// NewHTTPHandler returns the handler of the certifier's HTTP endpoints, which
+// publish the revocation status of certificates to TLS terminators:
+//   - GET /crl/<cert type> returns the DER encoded CRL of a CA
+//   - POST /ocsp and GET /ocsp/<base64 request> answer OCSP requests
+func NewHTTPHandler(srv *CertifierServer) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(CRLPathPrefix, srv.handleCRL)
+	mux.HandleFunc(OCSPPath, srv.handleOCSP)
+	mux.HandleFunc(OCSPPath+"/", srv.handleOCSP)
+	return mux
+}
+
+func (srv *CertifierServer) handleCRL(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	certTypeName := strings.ToUpper(strings.TrimPrefix(req.URL.Path, CRLPathPrefix))
+	certType, ok := protos.CertType_value[certTypeName]
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	crlDER, err := srv.getCRL(protos.CertType(certType))
+	if err != nil {
+		glog.Errorf("Failed to get CRL of %s CA: %s", certTypeName, err)
+		http.Error(w, "failed to get CRL", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/pkix-crl")
+	w.Write(crlDER)
+}
+
+func (srv *CertifierServer) handleOCSP(w http.ResponseWriter, req *http.Request) {
+	var reqDER []byte
+	var err error
+	switch req.Method {
+	case http.MethodPost:
+		reqDER, err = ioutil.ReadAll(http.MaxBytesReader(w, req.Body, maxOCSPRequestSize))
+	case http.MethodGet:
+		// See RFC 6960 appendix A.1
+		var encoded string
+		encoded, err = url.PathUnescape(strings.TrimPrefix(req.URL.Path, OCSPPath+"/"))
+		if err == nil {
+			reqDER, err = base64.StdEncoding.DecodeString(encoded)
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to read OCSP request", http.StatusBadRequest)
+		return
+	}
+
+	respDER, err := srv.CreateOCSPResponse(reqDER)
+	if err != nil {
+		glog.Errorf("Failed to create OCSP response: %s", err)
+		http.Error(w, "failed to create OCSP response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/ocsp-response")
+	w.Write(respDER)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"time"
+
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/security/cert"
+
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/crypto/ocsp"
+)
+
+// OCSPValidity is the time between the thisUpdate and nextUpdate of OCSP
+// responses
+var OCSPValidity = time.Hour
+
+// CreateOCSPResponse answers a DER encoded OCSP request about a certificate
+// signed by one of the certifier's CAs. Responses are signed by the CA which
+// signed the certificate. Certificates which are neither in the certificate
+// table nor revoked are reported as unknown.
+func (srv *CertifierServer) CreateOCSPResponse(reqDER []byte) ([]byte, error) {
+	req, err := ocsp.ParseRequest(reqDER)
+	if err != nil {
+		return ocsp.MalformedRequestErrorResponse, nil
+	}
+	certType, ca, ok := srv.findOCSPIssuer(req)
+	if !ok {
+		return ocsp.UnauthorizedErrorResponse, nil
+	}
+
+	now := time.Now().UTC()
+	template := ocsp.Response{
+		Status:       ocsp.Unknown,
+		SerialNumber: req.SerialNumber,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(OCSPValidity),
+	}
+	sn := cert.SerialToString(req.SerialNumber)
+	revokedCert, err := srv.getRevokedCertificate(certType, sn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load revoked certificate %s: %s", sn, err)
+	}
+	if revokedCert != nil {
+		template.Status = ocsp.Revoked
+		template.RevokedAt, _ = ptypes.Timestamp(revokedCert.RevokedAt)
+		template.RevocationReason = ocsp.Unspecified
+	} else {
+		certInfo, err := srv.getCertInfo(sn)
+		if err == nil && certInfo.CertType == certType {
+			template.Status = ocsp.Good
+		} else if err != nil && !datastore.IsErrNotFound(err) {
+			return nil, fmt.Errorf("failed to load certificate %s: %s", sn, err)
+		}
+	}
+	return ocsp.CreateResponse(ca.Cert, ca.Cert, template, ca.Signer)
+}
+
+// findOCSPIssuer finds the CA with the public key hash of an OCSP request.
+func (srv *CertifierServer) findOCSPIssuer(req *ocsp.Request) (protos.CertType, *CAInfo, bool) {
+	if !req.HashAlgorithm.Available() {
+		return 0, nil, false
+	}
+	for certType, ca := range srv.CAs {
+		var publicKeyInfo struct {
+			Algorithm pkix.AlgorithmIdentifier
+			PublicKey asn1.BitString
+		}
+		_, err := asn1.Unmarshal(ca.Cert.RawSubjectPublicKeyInfo, &publicKeyInfo)
+		if err != nil {
+			continue
+		}
+		hash := req.HashAlgorithm.New()
+		hash.Write(publicKeyInfo.PublicKey.RightAlign())
+		if bytes.Equal(hash.Sum(nil), req.IssuerKeyHash) {
+			return certType, ca, true
+		}
+	}
+	return 0, nil, false
+}
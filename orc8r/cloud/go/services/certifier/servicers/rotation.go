@@ -0,0 +1,315 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/certifier/metrics"
+	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// RenewalFraction is the fraction of a gateway certificate's lifetime
+	// after which the gateway is advised to renew it
+	RenewalFraction = 0.75
+	// SupersededCertGracePeriod is how long a gateway certificate stays valid
+	// after a newer certificate was issued to the same gateway
+	SupersededCertGracePeriod = time.Hour
+)
+
+// GetRenewalAdvice returns when the certificate should be renewed.
+func (srv *CertifierServer) GetRenewalAdvice(ctx context.Context, snMsg *protos.Certificate_SN) (*protos.RenewalAdvice, error) {
+	var certSN string
+	if snMsg != nil {
+		certSN = strings.TrimLeft(snMsg.Sn, "0")
+	}
+	certInfo, err := srv.getCertInfo(certSN)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Cannot find certificate with SN: %s", certSN)
+	}
+	renewAt := getRenewalTime(certInfo)
+	renewAtProto, _ := ptypes.TimestampProto(renewAt)
+	advice := &protos.RenewalAdvice{
+		RenewAt:  renewAtProto,
+		RenewNow: !time.Now().Before(renewAt),
+		NotAfter: certInfo.NotAfter,
+	}
+
+	if !isTrackedCertificate(certInfo) {
+		return advice, nil
+	}
+	hwId := certInfo.Id.GetGateway().GetHardwareId()
+	idCerts, err := srv.getGatewayCertificates(hwId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to load certificates of gateway %s: %s", hwId, err)
+	}
+	if supersededAt, ok := idCerts.Superseded[certSN]; ok {
+		// The gateway already has a newer certificate
+		supersededTime, _ := ptypes.Timestamp(supersededAt)
+		advice.RenewNow = false
+		advice.Superseded = true
+		advice.RevokeAt, _ = ptypes.TimestampProto(supersededTime.Add(SupersededCertGracePeriod))
+	}
+	return advice, nil
+}
+
+// supersededCertificate is a superseded certificate of a gateway which is
+// due for revocation
+type supersededCertificate struct {
+	hwId      string
+	networkId string
+	sn        string
+}
+
+// RotateCertificates revokes gateway certificates which have been superseded
+// for SupersededCertGracePeriod, and updates the metrics of certificates
+// nearing expiry.
+// The rotation lock is only held while the tracked certificates are read and
+// updated, so that signing isn't blocked by the revocations, and the CRL of
+// each CA is regenerated once per pass.
+func (srv *CertifierServer) RotateCertificates() error {
+	toRevoke, certsNearingExpiry, err := srv.findCertificatesToRevoke(time.Now())
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	revokedCertTypes := map[protos.CertType]bool{}
+	untracked := map[string][]string{}
+	for _, superseded := range toRevoke {
+		certInfo, err := srv.revokeCertificate(superseded.sn)
+		if err == nil {
+			glog.V(2).Infof("Revoked superseded certificate %s of gateway %s", superseded.sn, superseded.hwId)
+			metrics.SupersededCertsRevoked.WithLabelValues(superseded.networkId).Inc()
+			revokedCertTypes[certInfo.CertType] = true
+		} else if status.Code(err) != codes.NotFound {
+			errs = append(errs, fmt.Sprintf("%s -> %s", superseded.sn, err))
+			continue
+		}
+		untracked[superseded.hwId] = append(untracked[superseded.hwId], superseded.sn)
+	}
+	for certType := range revokedCertTypes {
+		_, err = srv.regenerateCRL(certType)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("CRL of %s CA -> %s", certType.String(), err))
+		}
+	}
+	errs = append(errs, srv.untrackCertificates(untracked)...)
+
+	metrics.CertsNearingExpiry.Reset()
+	for networkId, count := range certsNearingExpiry {
+		metrics.CertsNearingExpiry.WithLabelValues(networkId).Set(float64(count))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to rotate certificates: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// findCertificatesToRevoke returns the superseded certificates which are past
+// their grace period, and the number of active certificates nearing expiry
+// per network. Gateways without certificates aren't tracked anymore.
+func (srv *CertifierServer) findCertificatesToRevoke(now time.Time) ([]supersededCertificate, map[string]int, error) {
+	srv.rotationLock.Lock()
+	defer srv.rotationLock.Unlock()
+
+	keys, err := srv.store.ListKeys(IDENTITY_CERTIFICATES_TABLE)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list identity certificates: %s", err)
+	}
+	values, err := srv.store.GetMany(IDENTITY_CERTIFICATES_TABLE, keys)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load identity certificates: %s", err)
+	}
+
+	var toRevoke []supersededCertificate
+	certsNearingExpiry := map[string]int{}
+	for hwId, value := range values {
+		idCerts := &certprotos.IdentityCertificates{}
+		err = proto.Unmarshal(value.Value, idCerts)
+		if err != nil {
+			glog.Errorf("Failed to unmarshal certificates of gateway %s: %s", hwId, err)
+			continue
+		}
+		networkId := idCerts.Id.GetGateway().GetNetworkId()
+		for sn, supersededAt := range idCerts.Superseded {
+			supersededTime, _ := ptypes.Timestamp(supersededAt)
+			if !now.Before(supersededTime.Add(SupersededCertGracePeriod)) {
+				toRevoke = append(toRevoke, supersededCertificate{hwId: hwId, networkId: networkId, sn: sn})
+			}
+		}
+
+		certInfo, err := srv.getCertInfo(idCerts.ActiveSn)
+		if err == nil {
+			notAfter, _ := ptypes.Timestamp(certInfo.NotAfter)
+			if !now.Before(getRenewalTime(certInfo)) && now.Before(notAfter) {
+				certsNearingExpiry[networkId]++
+			}
+		} else if datastore.IsErrNotFound(err) && len(idCerts.Superseded) == 0 {
+			// All certificates of the gateway are gone
+			err = srv.store.Delete(IDENTITY_CERTIFICATES_TABLE, hwId)
+			if err != nil {
+				glog.Errorf("Failed to delete certificates of gateway %s: %s", hwId, err)
+			}
+		}
+	}
+	return toRevoke, certsNearingExpiry, nil
+}
+
+// untrackCertificates removes revoked certificates from the superseded
+// certificates of their gateways
+func (srv *CertifierServer) untrackCertificates(snsByHwId map[string][]string) []string {
+	srv.rotationLock.Lock()
+	defer srv.rotationLock.Unlock()
+
+	var errs []string
+	for hwId, sns := range snsByHwId {
+		idCerts, err := srv.getGatewayCertificates(hwId)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s -> %s", hwId, err))
+			continue
+		}
+		for _, sn := range sns {
+			delete(idCerts.Superseded, sn)
+		}
+		err = srv.putGatewayCertificates(hwId, idCerts)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s -> %s", hwId, err))
+		}
+	}
+	return errs
+}
+
+// trackGatewayCertificate makes the certificate the active certificate of its
+// gateway, and marks the previously active certificate as superseded.
+// Certificates are tracked by the gateway's hardware ID, so that they are
+// superseded across changes of the gateway's network.
+func (srv *CertifierServer) trackGatewayCertificate(sn string, certInfo *certprotos.CertificateInfo) error {
+	if !isTrackedCertificate(certInfo) {
+		return nil
+	}
+	hwId := certInfo.Id.GetGateway().GetHardwareId()
+	srv.rotationLock.Lock()
+	defer srv.rotationLock.Unlock()
+
+	idCerts, err := srv.getGatewayCertificates(hwId)
+	if err != nil {
+		return err
+	}
+	if idCerts.ActiveSn != "" && idCerts.ActiveSn != sn {
+		if idCerts.Superseded == nil {
+			idCerts.Superseded = map[string]*timestamp.Timestamp{}
+		}
+		idCerts.Superseded[idCerts.ActiveSn] = ptypes.TimestampNow()
+	}
+	idCerts.Id = certInfo.Id
+	idCerts.ActiveSn = sn
+	return srv.putGatewayCertificates(hwId, idCerts)
+}
+
+// supersedeUntrackedCertificates marks the certificates of tracked gateways
+// which were signed before their active certificate, but were never tracked,
+// e.g. because they were signed before rotation was enabled, as superseded.
+func (srv *CertifierServer) supersedeUntrackedCertificates(certInfos map[string]*certprotos.CertificateInfo) error {
+	snsByHwId := map[string][]string{}
+	for sn, certInfo := range certInfos {
+		if isTrackedCertificate(certInfo) {
+			hwId := certInfo.Id.GetGateway().GetHardwareId()
+			snsByHwId[hwId] = append(snsByHwId[hwId], sn)
+		}
+	}
+
+	srv.rotationLock.Lock()
+	defer srv.rotationLock.Unlock()
+	var errs []string
+	for hwId, sns := range snsByHwId {
+		idCerts, err := srv.getGatewayCertificates(hwId)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s -> %s", hwId, err))
+			continue
+		}
+		activeCertInfo, ok := certInfos[idCerts.ActiveSn]
+		if !ok {
+			continue
+		}
+		activeNotBefore, _ := ptypes.Timestamp(activeCertInfo.NotBefore)
+		changed := false
+		for _, sn := range sns {
+			if _, ok := idCerts.Superseded[sn]; ok || sn == idCerts.ActiveSn {
+				continue
+			}
+			notBefore, _ := ptypes.Timestamp(certInfos[sn].NotBefore)
+			if !notBefore.Before(activeNotBefore) {
+				continue
+			}
+			if idCerts.Superseded == nil {
+				idCerts.Superseded = map[string]*timestamp.Timestamp{}
+			}
+			idCerts.Superseded[sn] = ptypes.TimestampNow()
+			changed = true
+		}
+		if changed {
+			err = srv.putGatewayCertificates(hwId, idCerts)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s -> %s", hwId, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to supersede untracked certificates: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// isTrackedCertificate returns true for the bootstrap certificates of
+// gateways, which are the certificates subject to rotation
+func isTrackedCertificate(certInfo *certprotos.CertificateInfo) bool {
+	return certInfo.CertType == protos.CertType_DEFAULT && len(certInfo.Id.GetGateway().GetHardwareId()) > 0
+}
+
+func (srv *CertifierServer) getGatewayCertificates(hwId string) (*certprotos.IdentityCertificates, error) {
+	idCerts := &certprotos.IdentityCertificates{}
+	marshaledIdCerts, _, err := srv.store.Get(IDENTITY_CERTIFICATES_TABLE, hwId)
+	if err == datastore.ErrNotFound {
+		return idCerts, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = proto.Unmarshal(marshaledIdCerts, idCerts)
+	return idCerts, err
+}
+
+func (srv *CertifierServer) putGatewayCertificates(hwId string, idCerts *certprotos.IdentityCertificates) error {
+	marshaledIdCerts, err := proto.Marshal(idCerts)
+	if err != nil {
+		return err
+	}
+	return srv.store.Put(IDENTITY_CERTIFICATES_TABLE, hwId, marshaledIdCerts)
+}
+
+func getRenewalTime(certInfo *certprotos.CertificateInfo) time.Time {
+	notBefore, _ := ptypes.Timestamp(certInfo.NotBefore)
+	notAfter, _ := ptypes.Timestamp(certInfo.NotAfter)
+	lifetime := notAfter.Sub(notBefore)
+	return notBefore.Add(time.Duration(float64(lifetime) * RenewalFraction))
+}
@@ -0,0 +1,188 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const maxSignResponseSize = 64 * 1024
+
+// SignRequest is the JSON body POSTed to an external CA. Digest is base64
+// encoded, and Hash is the name of the hash function which produced it,
+// e.g. SHA-256, or empty if the digest is the whole message.
+type SignRequest struct {
+	KeyId  string `json:"key_id"`
+	Hash   string `json:"hash"`
+	Digest []byte `json:"digest"`
+}
+
+// SignResponse is the JSON body returned by an external CA. Signature is
+// base64 encoded.
+type SignResponse struct {
+	Signature []byte `json:"signature"`
+}
+
+// supportedHashes are the hash functions used to sign certificates, CRLs and
+// OCSP responses
+var supportedHashes = []crypto.Hash{crypto.SHA1, crypto.SHA256, crypto.SHA384, crypto.SHA512}
+
+// HTTPSignerConfig configures how an HTTPSigner reaches an external CA
+type HTTPSignerConfig struct {
+	// URL receives the SignRequests
+	URL string
+	// KeyId selects the key of the external CA
+	KeyId string
+	// Client sends the requests. Its transport should authenticate the
+	// certifier to the external CA, e.g. with a client certificate.
+	Client *http.Client
+	// Token is sent as a bearer token if set. Tokens are only sent over
+	// HTTPS.
+	Token string
+	// Timeout bounds each request to the external CA
+	Timeout time.Duration
+}
+
+// HTTPSigner delegates signing to an external CA over HTTP. The external CA
+// keeps the private key, and the certifier only needs the CA's public key.
+type HTTPSigner struct {
+	cfg       HTTPSignerConfig
+	publicKey crypto.PublicKey
+}
+
+// NewHTTPSigner returns a Signer which POSTs SignRequests to the external CA
+// configured by cfg, whose public key is publicKey.
+func NewHTTPSigner(cfg HTTPSignerConfig, publicKey crypto.PublicKey) (*HTTPSigner, error) {
+	if len(cfg.URL) == 0 {
+		return nil, fmt.Errorf("missing URL of external CA")
+	}
+	if cfg.Client == nil {
+		return nil, fmt.Errorf("missing HTTP client for external CA")
+	}
+	if cfg.Timeout <= 0 {
+		return nil, fmt.Errorf("timeout of external CA must be positive")
+	}
+	if len(cfg.Token) > 0 {
+		parsedURL, err := url.Parse(cfg.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URL of external CA: %s", err)
+		}
+		if parsedURL.Scheme != "https" {
+			return nil, fmt.Errorf("tokens are only sent to external CAs over HTTPS")
+		}
+	}
+	return &HTTPSigner{cfg: cfg, publicKey: publicKey}, nil
+}
+
+func (s *HTTPSigner) Public() crypto.PublicKey {
+	return s.publicKey
+}
+
+func (s *HTTPSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if _, ok := opts.(*rsa.PSSOptions); ok {
+		return nil, fmt.Errorf("RSA-PSS signatures are not supported by external CAs")
+	}
+	signReq := SignRequest{KeyId: s.cfg.KeyId, Digest: digest}
+	if opts.HashFunc() != 0 {
+		signReq.Hash = opts.HashFunc().String()
+	}
+	body, err := json.Marshal(signReq)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.cfg.Token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.Token)
+	}
+	resp, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach external CA: %s", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxSignResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read external CA response: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("external CA returned %s: %s", resp.Status, respBody)
+	}
+	signResp := SignResponse{}
+	err = json.Unmarshal(respBody, &signResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal external CA response: %s", err)
+	}
+	if len(signResp.Signature) == 0 {
+		return nil, fmt.Errorf("external CA returned an empty signature")
+	}
+	return signResp.Signature, nil
+}
+
+// NewHTTPSignerHandler returns the external CA side of the HTTPSigner
+// protocol, signing with the given signers by key ID. It can be used as a
+// local stand-in for an external CA.
+func NewHTTPSignerHandler(signers map[string]Signer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		signReq := SignRequest{}
+		err := json.NewDecoder(req.Body).Decode(&signReq)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to decode request: %s", err), http.StatusBadRequest)
+			return
+		}
+		signer, ok := signers[signReq.KeyId]
+		if !ok {
+			http.Error(w, fmt.Sprintf("unknown key ID: %s", signReq.KeyId), http.StatusNotFound)
+			return
+		}
+		hash, err := getHash(signReq.Hash)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		signature, err := signer.Sign(rand.Reader, signReq.Digest, hash)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to sign: %s", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SignResponse{Signature: signature})
+	})
+}
+
+func getHash(name string) (crypto.Hash, error) {
+	if len(name) == 0 {
+		return 0, nil
+	}
+	for _, hash := range supportedHashes {
+		if hash.String() == name {
+			return hash, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported hash: %s", name)
+}
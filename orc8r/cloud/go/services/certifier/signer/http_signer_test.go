@@ -0,0 +1,135 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package signer_test
+
+import (
+	"crypto"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
+	"magma/orc8r/cloud/go/services/certifier/servicers"
+	"magma/orc8r/cloud/go/services/certifier/signer"
+	certifier_test_utils "magma/orc8r/cloud/go/services/certifier/test_utils"
+	"magma/orc8r/cloud/go/test_utils"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestHTTPSigner(t *testing.T) {
+	ctx := context.Background()
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(time.Hour * 24)
+	assert.NoError(t, err)
+	inMemorySigner, err := signer.NewInMemorySigner(caKey)
+	assert.NoError(t, err)
+
+	// Stand-in for an external CA which keeps the key and authenticates the
+	// certifier with a token
+	handler := signer.NewHTTPSignerHandler(map[string]signer.Signer{"ca1": inMemorySigner})
+	externalCA := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer token1" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, req)
+	}))
+	defer externalCA.Close()
+
+	cfg := signer.HTTPSignerConfig{URL: externalCA.URL, KeyId: "ca1", Client: externalCA.Client(), Token: "token1", Timeout: time.Second}
+	httpSigner, err := signer.NewHTTPSigner(cfg, caCert.PublicKey)
+	assert.NoError(t, err)
+	caMap := map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {Cert: caCert, Signer: httpSigner},
+	}
+	srv, err := servicers.NewCertifierServer(test_utils.NewMockDatastore(), caMap)
+	assert.NoError(t, err)
+
+	// Certificates and CRLs are signed by the external CA
+	csrMsg, err := certifier_test_utils.CreateCSR(time.Hour, "cn", "cn")
+	assert.NoError(t, err)
+	certMsg, err := srv.SignAddCertificate(ctx, csrMsg)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(certMsg.CertDer)
+	assert.NoError(t, err)
+	assert.NoError(t, cert.CheckSignatureFrom(caCert))
+
+	crlMsg, err := srv.GetCRL(ctx, &certprotos.GetCRLRequest{CertType: protos.CertType_DEFAULT})
+	assert.NoError(t, err)
+	crl, err := x509.ParseRevocationList(crlMsg.CrlDer)
+	assert.NoError(t, err)
+	assert.NoError(t, crl.CheckSignatureFrom(caCert))
+
+	// Signing fails with keys unknown to the external CA
+	ca2Cfg := cfg
+	ca2Cfg.KeyId = "ca2"
+	caMap[protos.CertType_DEFAULT].Signer, err = signer.NewHTTPSigner(ca2Cfg, caCert.PublicKey)
+	assert.NoError(t, err)
+	_, err = srv.SignAddCertificate(ctx, csrMsg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown key ID: ca2")
+
+	// and without the token
+	noTokenCfg := cfg
+	noTokenCfg.Token = ""
+	caMap[protos.CertType_DEFAULT].Signer, err = signer.NewHTTPSigner(noTokenCfg, caCert.PublicKey)
+	assert.NoError(t, err)
+	_, err = srv.SignAddCertificate(ctx, csrMsg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "401 Unauthorized")
+
+	// and with unreachable external CAs
+	externalCA.Close()
+	caMap[protos.CertType_DEFAULT].Signer = httpSigner
+	_, err = srv.SignAddCertificate(ctx, csrMsg)
+	assert.Error(t, err)
+}
+
+func TestNewHTTPSigner(t *testing.T) {
+	cfg := signer.HTTPSignerConfig{URL: "https://ca.example.com/sign", KeyId: "ca1", Client: &http.Client{}, Token: "token1", Timeout: time.Second}
+	_, err := signer.NewHTTPSigner(cfg, nil)
+	assert.NoError(t, err)
+
+	// Clients aren't defaulted
+	noClientCfg := cfg
+	noClientCfg.Client = nil
+	_, err = signer.NewHTTPSigner(noClientCfg, nil)
+	assert.EqualError(t, err, "missing HTTP client for external CA")
+
+	// Tokens aren't sent in plaintext
+	plaintextCfg := cfg
+	plaintextCfg.URL = "http://ca.example.com/sign"
+	_, err = signer.NewHTTPSigner(plaintextCfg, nil)
+	assert.EqualError(t, err, "tokens are only sent to external CAs over HTTPS")
+
+	noTimeoutCfg := cfg
+	noTimeoutCfg.Timeout = 0
+	_, err = signer.NewHTTPSigner(noTimeoutCfg, nil)
+	assert.EqualError(t, err, "timeout of external CA must be positive")
+}
+
+func TestHTTPSigner_Timeout(t *testing.T) {
+	done := make(chan struct{})
+	externalCA := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-done
+	}))
+	defer externalCA.Close()
+	defer close(done)
+
+	cfg := signer.HTTPSignerConfig{URL: externalCA.URL, Client: externalCA.Client(), Timeout: 50 * time.Millisecond}
+	httpSigner, err := signer.NewHTTPSigner(cfg, nil)
+	assert.NoError(t, err)
+	_, err = httpSigner.Sign(nil, []byte("digest"), crypto.SHA256)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "context deadline exceeded")
+}
@@ -0,0 +1,32 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package signer contains the backends which sign certificates, CRLs and
+// OCSP responses on behalf of the certifier's CAs.
+package signer
+
+import (
+	"crypto"
+	"fmt"
+)
+
+// Signer signs digests with the private key of a CA. Implementations may
+// keep the key outside of the certifier's process.
+type Signer interface {
+	crypto.Signer
+}
+
+// NewInMemorySigner returns a Signer which signs with a private key loaded
+// into the certifier's process.
+func NewInMemorySigner(privKey crypto.PrivateKey) (Signer, error) {
+	signer, ok := privKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", privKey)
+	}
+	return signer, nil
+}
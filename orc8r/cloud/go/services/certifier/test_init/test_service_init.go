@@ -17,6 +17,7 @@ import (
 	"magma/orc8r/cloud/go/services/certifier"
 	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
 	"magma/orc8r/cloud/go/services/certifier/servicers"
+	"magma/orc8r/cloud/go/services/certifier/signer"
 	certifier_test_utils "magma/orc8r/cloud/go/services/certifier/test_utils"
 	"magma/orc8r/cloud/go/test_utils"
 )
@@ -29,7 +30,7 @@ func StartTestService(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create bootstrap certifier certificate: %s", err)
 	} else {
-		caMap[protos.CertType_DEFAULT] = &servicers.CAInfo{bootstrapCert, bootstrapKey}
+		caMap[protos.CertType_DEFAULT] = &servicers.CAInfo{Cert: bootstrapCert, Signer: bootstrapKey.(signer.Signer)}
 	}
 
 	vpnCert, vpnKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(
@@ -37,7 +38,7 @@ func StartTestService(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create VPN certifier certificate: %s", err)
 	} else {
-		caMap[protos.CertType_VPN] = &servicers.CAInfo{vpnCert, vpnKey}
+		caMap[protos.CertType_VPN] = &servicers.CAInfo{Cert: vpnCert, Signer: vpnKey.(signer.Signer)}
 	}
 	certServer, err := servicers.NewCertifierServer(test_utils.GetMockDatastoreInstance(), caMap)
 	if err != nil {
@@ -24,9 +24,12 @@ import (
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"magma/orc8r/cloud/go/identity"
 	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/certifier"
 	"magma/orc8r/cloud/go/services/checkind/store"
 )
 
@@ -128,3 +131,22 @@ func (srv *checkindServer) List(ctx context.Context, networkId *protos.NetworkID
 	}
 	return list, err
 }
+
+// GetRenewalAdvice returns when the calling Gateway should renew the
+// certificate it called with, according to certifier's rotation policy.
+// Certifier isn't reachable by Gateways, so checkind relays the advice for
+// the certificate the proxy authenticated the Gateway with.
+func (srv *checkindServer) GetRenewalAdvice(ctx context.Context, void *protos.Void) (*protos.RenewalAdvice, error) {
+	gw := protos.GetClientGateway(ctx)
+	if gw == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "Missing Gateway Identity")
+	}
+	if !gw.Registered() {
+		return nil, status.Errorf(codes.PermissionDenied, "Gateway is not registered")
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(identity.CLIENT_CERT_SN_KEY)) != 1 {
+		return nil, status.Errorf(codes.PermissionDenied, "Missing Gateway Certificate")
+	}
+	return certifier.GetRenewalAdvice(md.Get(identity.CLIENT_CERT_SN_KEY)[0])
+}
@@ -14,6 +14,7 @@ import (
 
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/registry"
+	unary_test_utils "magma/orc8r/cloud/go/service/middleware/unary/test_utils"
 	"magma/orc8r/cloud/go/services/checkind"
 	checkind_test_init "magma/orc8r/cloud/go/services/checkind/test_init"
 	"magma/orc8r/cloud/go/services/checkind/test_utils"
@@ -25,6 +26,9 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const testAgHwId = "Test-AGW-Hw-Id"
@@ -133,3 +137,35 @@ func TestCheckind(t *testing.T) {
 	assert.True(t, (lids.Ids[0] == logicalId || lids.Ids[1] == logicalId))
 	assert.True(t, (lids.Ids[0] == logicalId2 || lids.Ids[1] == logicalId2))
 }
+
+func TestCheckind_GetRenewalAdvice(t *testing.T) {
+	magmad_test_init.StartTestService(t)
+	checkind_test_init.StartTestService(t)
+
+	testNetworkId, err := magmad.RegisterNetwork(
+		&magmad_protos.MagmadNetworkRecord{Name: "Test Network Name"},
+		"checkind_renewal_test_network")
+	assert.NoError(t, err)
+	hwId := protos.AccessGatewayID{Id: testAgHwId}
+	_, err = magmad.RegisterGateway(testNetworkId,
+		&magmad_protos.AccessGatewayRecord{HwId: &hwId, Name: "Test GW Name"})
+	assert.NoError(t, err)
+	csn := unary_test_utils.StartMockGwAccessControl(t, []string{testAgHwId})
+
+	conn, err := registry.GetConnection(checkind.ServiceName)
+	assert.NoError(t, err)
+	magmaCheckindClient := protos.NewCheckindClient(conn)
+
+	// The advice is for the certificate the gateway called with
+	ctx := metadata.NewOutgoingContext(
+		context.Background(),
+		metadata.Pairs("x-magma-client-cert-serial", csn[0]))
+	advice, err := magmaCheckindClient.GetRenewalAdvice(ctx, &protos.Void{})
+	assert.NoError(t, err)
+	assert.False(t, advice.RenewNow)
+	assert.NotNil(t, advice.RenewAt)
+
+	// Only gateways can get renewal advice
+	_, err = magmaCheckindClient.GetRenewalAdvice(context.Background(), &protos.Void{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
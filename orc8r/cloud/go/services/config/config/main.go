@@ -19,6 +19,11 @@ import (
 	"magma/orc8r/cloud/go/services/config/servicers"
 	"magma/orc8r/cloud/go/services/config/storage"
 	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/tools/migrations"
+
+	// Legacy configs are migrated to the config service on startup
+	_ "magma/orc8r/cloud/go/tools/migrations/m001_config_service/migration"
+	_ "magma/orc8r/cloud/go/tools/migrations/m002_cleanup_legacy_configs/migration"
 )
 
 func main() {
@@ -31,6 +36,12 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %s", err)
 	}
+	// Config tables are created on demand, so there is nothing to migrate on
+	// sqlite3
+	err = sqorc.ApplyServiceMigrations(db, sqorc.GetSqlBuilder(), migrations.LegacyConfigsServiceName)
+	if err != nil && err != sqorc.ErrMigrationsUnsupported {
+		log.Fatalf("Failed to migrate legacy configs: %s", err)
+	}
 	store := storage.NewSqlConfigurationStorage(db, sqorc.GetSqlBuilder())
 
 	servicer := servicers.NewConfigService(store)
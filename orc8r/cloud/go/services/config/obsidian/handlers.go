@@ -10,6 +10,7 @@ LICENSE file in the root directory of this source tree.
 package obsidian
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -271,7 +272,7 @@ func handleCreateConfig(c echo.Context, networkId string, configType string, con
 		return handlers.HttpError(fmt.Errorf("Error creating config: %s", err), http.StatusInternalServerError)
 	}
 
-	err = multiplexCreateOrUpdateConfigIntoConfigurator(networkId, configType, configKey, iConfig)
+	err = multiplexCreateOrUpdateConfigIntoConfigurator(c.Request().Context(), networkId, configType, configKey, iConfig)
 	if err != nil {
 		return handlers.HttpError(fmt.Errorf("Success creating config, but failed to multiplex into configurator: %s", err), http.StatusInternalServerError)
 	}
@@ -294,12 +295,12 @@ func getConfigTypeForConfigurator(configType string) ConfigType {
 }
 
 // case on configType and propagate create/update into configurator
-func multiplexCreateOrUpdateConfigIntoConfigurator(networkID, configType string, configKey string, iConfig interface{}) error {
+func multiplexCreateOrUpdateConfigIntoConfigurator(ctx context.Context, networkID, configType string, configKey string, iConfig interface{}) error {
 	switch getConfigTypeForConfigurator(configType) {
 	case NETWORK:
 		return multiplexCreateOrUpdateNetworkConfig(networkID, configType, iConfig)
 	case NETWORK_ENTITY:
-		return multiplexCreateOrUpdateEntityConfig(networkID, configType, configKey, iConfig)
+		return multiplexCreateOrUpdateEntityConfig(ctx, networkID, configType, configKey, iConfig)
 	default:
 		return fmt.Errorf("Unexpected config type : %s", configType)
 	}
@@ -319,12 +320,12 @@ func multiplexCreateOrUpdateNetworkConfig(networkID, configType string, config i
 	return nil
 }
 
-func multiplexCreateOrUpdateEntityConfig(networkID, entityType, entityKey string, config interface{}) error {
-	err := configurator_utils.CreateNetworkEntityIfNotExists(networkID, entityType, entityKey)
+func multiplexCreateOrUpdateEntityConfig(ctx context.Context, networkID, entityType, entityKey string, config interface{}) error {
+	err := configurator_utils.CreateNetworkEntityIfNotExists(ctx, networkID, entityType, entityKey)
 	if err != nil {
 		return err
 	}
-	err = configurator.UpdateEntityConfig(networkID, entityType, entityKey, config)
+	err = configurator.UpdateEntityConfig(ctx, networkID, entityType, entityKey, config)
 	if err != nil {
 		return fmt.Errorf(
 			"Failed to multiplex create network entity config %s:%s:%s into configurator: %v", networkID, entityType, entityKey, err)
@@ -395,7 +396,7 @@ func handleConfigUpdate(c echo.Context, networkId string, configType string, con
 		return handlers.HttpError(fmt.Errorf("Error updating config: %s", err), http.StatusInternalServerError)
 	}
 
-	err = multiplexCreateOrUpdateConfigIntoConfigurator(networkId, configType, configKey, iConfig)
+	err = multiplexCreateOrUpdateConfigIntoConfigurator(c.Request().Context(), networkId, configType, configKey, iConfig)
 	if err != nil {
 		return handlers.HttpError(fmt.Errorf("Success updating config, but failed to multiplex into configurator: %s", err), http.StatusInternalServerError)
 	}
@@ -447,7 +448,7 @@ func handleConfigDelete(c echo.Context, networkId string, configType string, con
 		return handlers.HttpError(fmt.Errorf("Error deleting config: %s", err), http.StatusInternalServerError)
 	}
 
-	err := multiplexDeleteConfigIntoConfigurator(networkId, configType, configKey)
+	err := multiplexDeleteConfigIntoConfigurator(c.Request().Context(), networkId, configType, configKey)
 	if err != nil {
 		glog.Errorf("Success deleting config, but failed to multiplex into configurator: %s", err)
 	}
@@ -456,12 +457,12 @@ func handleConfigDelete(c echo.Context, networkId string, configType string, con
 }
 
 // case on configType and propagate delete into configurator
-func multiplexDeleteConfigIntoConfigurator(networkID, configType, configKey string) error {
+func multiplexDeleteConfigIntoConfigurator(ctx context.Context, networkID, configType, configKey string) error {
 	switch getConfigTypeForConfigurator(configType) {
 	case NETWORK:
 		return multiplexDeleteNetworkConfig(networkID, configType)
 	case NETWORK_ENTITY:
-		return multiplexDeleteEntityConfig(networkID, configType, configKey)
+		return multiplexDeleteEntityConfig(ctx, networkID, configType, configKey)
 	default:
 		return fmt.Errorf("Unexpected config type : %s", configType)
 	}
@@ -481,12 +482,12 @@ func multiplexDeleteNetworkConfig(networkID, configType string) error {
 	return nil
 }
 
-func multiplexDeleteEntityConfig(networkID, configType, configKey string) error {
-	err := configurator_utils.CreateNetworkEntityIfNotExists(networkID, configType, configKey)
+func multiplexDeleteEntityConfig(ctx context.Context, networkID, configType, configKey string) error {
+	err := configurator_utils.CreateNetworkEntityIfNotExists(ctx, networkID, configType, configKey)
 	if err != nil {
 		return err
 	}
-	err = configurator.DeleteEntityConfig(networkID, configType, configKey)
+	err = configurator.DeleteEntityConfig(ctx, networkID, configType, configKey)
 	if err != nil {
 		return fmt.Errorf(
 			"Failed to multiplex delete network entity config %s:%s:%s into configurator: %v", networkID, configType, configKey, err)
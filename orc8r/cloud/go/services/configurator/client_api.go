@@ -20,8 +20,15 @@ import (
 	"magma/orc8r/cloud/go/services/configurator/storage"
 
 	"github.com/golang/glog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// defaultLoadPageSize is the page size used when loading all entities of a
+// network through paginated loads.
+const defaultLoadPageSize = 500
+
 func getNBConfiguratorClient() (protos.NorthboundConfiguratorClient, error) {
 	conn, err := registry.GetConnection(ServiceName)
 	if err != nil {
@@ -57,7 +64,8 @@ func DoesNetworkExist(networkID string) (bool, error) {
 	return true, nil
 }
 
-// CreateNetworks registers the given list of Networks and returns the created networks
+// CreateNetworks registers the given list of Networks and returns the created networks.
+// If any network config is invalid, a *ConfigValidationError is returned.
 func CreateNetworks(networks []*protos.Network) ([]*protos.Network, error) {
 	client, err := getNBConfiguratorClient()
 	if err != nil {
@@ -66,12 +74,16 @@ func CreateNetworks(networks []*protos.Network) ([]*protos.Network, error) {
 	request := &protos.CreateNetworksRequest{Networks: networks}
 	result, err := client.CreateNetworks(context.Background(), request)
 	if err != nil {
-		return nil, err
+		return nil, mapConfigValidationError(err)
 	}
 	return result.CreatedNetworks, err
 }
 
-// UpdateNetworks updates the specified networks and returns the updated networks
+// UpdateNetworks updates the specified networks and returns the updated networks.
+// If any update specifies an expected version which does not match the
+// stored network, errors.ErrVersionConflict is returned and no updates are
+// applied. If any network config is invalid, a *ConfigValidationError is
+// returned.
 func UpdateNetworks(updates []*protos.NetworkUpdateCriteria) error {
 	client, err := getNBConfiguratorClient()
 	if err != nil {
@@ -79,7 +91,7 @@ func UpdateNetworks(updates []*protos.NetworkUpdateCriteria) error {
 	}
 	request := &protos.UpdateNetworksRequest{Updates: updates}
 	_, err = client.UpdateNetworks(context.Background(), request)
-	return err
+	return mapConfigValidationError(mapVersionConflict(err))
 }
 
 // DeleteNetwork deletes the network specified by networkID
@@ -151,17 +163,19 @@ func GetNetworkConfigsByType(networkID string, configType string) (interface{},
 	return model, nil
 }
 
-// CreateEntities registers the given entities and returns the created network entities
-func CreateEntities(networkID string, entities []*protos.NetworkEntity) ([]*protos.NetworkEntity, error) {
+// CreateEntities registers the given entities and returns the created network entities.
+// If any entity config is invalid, a *ConfigValidationError is returned.
+// If ctx carries an operator identity, the operator's ACLs are enforced.
+func CreateEntities(ctx context.Context, networkID string, entities []*protos.NetworkEntity) ([]*protos.NetworkEntity, error) {
 	client, err := getNBConfiguratorClient()
 	if err != nil {
 		return nil, err
 	}
 
 	request := &protos.CreateEntitiesRequest{NetworkID: networkID, Entities: entities}
-	response, err := client.CreateEntities(context.Background(), request)
+	response, err := client.CreateEntities(commonProtos.NewOutgoingContextWithOperator(ctx), request)
 	if err != nil {
-		return nil, err
+		return nil, mapConfigValidationError(err)
 	}
 	return response.CreatedEntities, err
 }
@@ -169,20 +183,26 @@ func CreateEntities(networkID string, entities []*protos.NetworkEntity) ([]*prot
 // CreateInternalEntity is a loose wrapper around CreateEntities to create an
 // entity in the internal network structure
 func CreateInternalEntities(entities []*protos.NetworkEntity) ([]*protos.NetworkEntity, error) {
-	return CreateEntities(storage.InternalNetworkID, entities)
+	return CreateEntities(context.Background(), storage.InternalNetworkID, entities)
 }
 
-// UpdateEntities updates the registered entities and returns the updated entities
-func UpdateEntities(networkID string, updates []*protos.EntityUpdateCriteria) (map[string]*protos.NetworkEntity, error) {
+// UpdateEntities updates the registered entities and returns the updated entities.
+// If any update specifies an expected version which does not match the
+// stored entity, errors.ErrVersionConflict is returned and no updates are
+// applied. If any entity config is invalid, a *ConfigValidationError is
+// returned. If ctx carries an operator identity, the operator's ACLs are
+// enforced. Updates with DeleteEntity set delete the entity instead, and
+// deleted entities aren't returned.
+func UpdateEntities(ctx context.Context, networkID string, updates []*protos.EntityUpdateCriteria) (map[string]*protos.NetworkEntity, error) {
 	client, err := getNBConfiguratorClient()
 	if err != nil {
 		return nil, err
 	}
 
 	request := &protos.UpdateEntitiesRequest{NetworkID: networkID, Updates: updates}
-	response, err := client.UpdateEntities(context.Background(), request)
+	response, err := client.UpdateEntities(commonProtos.NewOutgoingContextWithOperator(ctx), request)
 	if err != nil {
-		return nil, err
+		return nil, mapConfigValidationError(mapVersionConflict(err))
 	}
 	return response.UpdatedEntities, err
 }
@@ -190,9 +210,9 @@ func UpdateEntities(networkID string, updates []*protos.EntityUpdateCriteria) (m
 // UpdateInternalEntity is a loose wrapper around UpdateEntities to update an
 // entity in the internal network structure
 func UpdateInternalEntity(updates []*protos.EntityUpdateCriteria) (map[string]*protos.NetworkEntity, error) {
-	return UpdateEntities(storage.InternalNetworkID, updates)
+	return UpdateEntities(context.Background(), storage.InternalNetworkID, updates)
 }
-func UpdateEntityConfig(networkID string, entityType string, entityKey string, config interface{}) error {
+func UpdateEntityConfig(ctx context.Context, networkID string, entityType string, entityKey string, config interface{}) error {
 	serializedConfig, err := serde.Serialize(SerdeDomain, entityType, config)
 	if err != nil {
 		return err
@@ -202,28 +222,29 @@ func UpdateEntityConfig(networkID string, entityType string, entityKey string, c
 		Type:      entityType,
 		NewConfig: protos.GetBytesWrapper(serializedConfig),
 	}
-	_, err = UpdateEntities(networkID, []*protos.EntityUpdateCriteria{updateCriteria})
+	_, err = UpdateEntities(ctx, networkID, []*protos.EntityUpdateCriteria{updateCriteria})
 	return err
 }
 
-func DeleteEntityConfig(networkID, entityType, entityKey string) error {
+func DeleteEntityConfig(ctx context.Context, networkID, entityType, entityKey string) error {
 	updateCriteria := &protos.EntityUpdateCriteria{
 		Key:       entityKey,
 		Type:      entityType,
 		NewConfig: protos.GetBytesWrapper([]byte("")),
 	}
-	_, err := UpdateEntities(networkID, []*protos.EntityUpdateCriteria{updateCriteria})
+	_, err := UpdateEntities(ctx, networkID, []*protos.EntityUpdateCriteria{updateCriteria})
 	return err
 }
 
-// DeleteEntity deletes the entity specified by networkID, type, key
-func DeleteEntities(networkID string, ids []*protos.EntityID) error {
+// DeleteEntity deletes the entity specified by networkID, type, key.
+// If ctx carries an operator identity, the operator's ACLs are enforced.
+func DeleteEntities(ctx context.Context, networkID string, ids []*protos.EntityID) error {
 	client, err := getNBConfiguratorClient()
 	if err != nil {
 		return err
 	}
 	_, err = client.DeleteEntities(
-		context.Background(),
+		commonProtos.NewOutgoingContextWithOperator(ctx),
 		&protos.DeleteEntitiesRequest{
 			NetworkID: networkID,
 			ID:        ids,
@@ -235,7 +256,7 @@ func DeleteEntities(networkID string, ids []*protos.EntityID) error {
 // DeleteInternalEntity is a loose wrapper around DeleteEntities to delete an
 // entity in the internal network structure
 func DeleteInternalEntities(ids []*protos.EntityID) error {
-	return DeleteEntities(storage.InternalNetworkID, ids)
+	return DeleteEntities(context.Background(), storage.InternalNetworkID, ids)
 }
 
 // GetPhysicalIDOfEntity gets the physicalID associated with the entity
@@ -306,25 +327,292 @@ func DoesEntityExist(networkID, entityType, entityKey string) (bool, error) {
 	return true, nil
 }
 
-// LoadAllEntitiesInNetwork fetches all entities of specified type in a network
-func LoadAllEntitiesInNetwork(networkID string, entityType string, criteria *protos.EntityLoadCriteria) ([]*protos.NetworkEntity, error) {
+// LoadEntitiesPage loads a single page of at most pageSize entities matching
+// the given filters, ordered by (type, key). Pass an empty pageToken to load
+// the first page. The returned token should be passed to load the next page,
+// and is empty once there are no more entities to load.
+func LoadEntitiesPage(networkID string, typeFilter *string, keyPrefix *string, physicalID *string,
+	criteria *protos.EntityLoadCriteria, pageSize uint32, pageToken string) ([]*protos.NetworkEntity, string, error) {
 	client, err := getNBConfiguratorClient()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	resp, err := client.LoadEntities(
 		context.Background(),
 		&protos.LoadEntitiesRequest{
 			NetworkID:  networkID,
-			TypeFilter: protos.GetStringWrapper(&entityType),
-			KeyFilter:  nil,
-			EntityIDs:  nil,
+			TypeFilter: protos.GetStringWrapper(typeFilter),
+			KeyPrefix:  protos.GetStringWrapper(keyPrefix),
+			PhysicalID: protos.GetStringWrapper(physicalID),
 			Criteria:   criteria,
+			PageSize:   pageSize,
+			PageToken:  pageToken,
+		},
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Entities, resp.NextPageToken, nil
+}
+
+// LoadAllEntitiesInNetwork fetches all entities of specified type in a network.
+// Entities are loaded in pages of defaultLoadPageSize to bound the size of
+// each response.
+func LoadAllEntitiesInNetwork(networkID string, entityType string, criteria *protos.EntityLoadCriteria) ([]*protos.NetworkEntity, error) {
+	ret := []*protos.NetworkEntity{}
+	pageToken := ""
+	for {
+		page, nextPageToken, err := LoadEntitiesPage(networkID, &entityType, nil, nil, criteria, defaultLoadPageSize, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, page...)
+		if nextPageToken == "" {
+			return ret, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+// WatchChanges streams the change log of a network to the handler in sequence
+// order, starting after sinceSeq. Once all existing changes are streamed, new
+// changes are streamed as they are written. WatchChanges blocks until ctx is
+// cancelled, the handler returns an error, or the stream fails.
+// Changes are only kept for the configurator's change log retention period,
+// so an OutOfRange status is returned if the changes after sinceSeq have
+// been purged. The watcher should then reload the network and watch from
+// GetLatestChangeSeq.
+func WatchChanges(ctx context.Context, networkID string, sinceSeq uint64, handler func(change *protos.Change) error) error {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.WatchChanges(ctx, &protos.WatchChangesRequest{NetworkID: networkID, SinceSeq: sinceSeq})
+	if err != nil {
+		return err
+	}
+	for {
+		change, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		err = handler(change)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// GetLatestChangeSeq returns the sequence number of the latest change to a
+// network, or 0 if the network has no changes. Any write to the network or its
+// entities increases the sequence number, so it can be used as a version of
+// the network's graph.
+func GetLatestChangeSeq(networkID string) (uint64, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return 0, err
+	}
+	res, err := client.GetLatestChangeSeq(context.Background(), &protos.GetLatestChangeSeqRequest{NetworkID: networkID})
+	if err != nil {
+		return 0, err
+	}
+	return res.Sequence, nil
+}
+
+// ExportNetwork exports a network with its configs and all of its entities,
+// including associations and ACLs, into a versioned document.
+// Returns errors.ErrNotFound if the network does not exist. If ctx carries an
+// operator identity, the operator must be able to read all of the entities.
+func ExportNetwork(ctx context.Context, networkID string) (*protos.NetworkExport, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, err
+	}
+	export, err := client.ExportNetwork(commonProtos.NewOutgoingContextWithOperator(ctx), &protos.ExportNetworkRequest{NetworkID: networkID})
+	if err != nil && status.Code(err) == codes.NotFound {
+		return nil, errors.ErrNotFound
+	}
+	return export, err
+}
+
+// ImportNetwork creates a network and all of its entities from an exported
+// document in a single transaction. If newNetworkID is non-empty, the network
+// is created under that ID instead of the exported one. If dryRun is true,
+// the import is validated and performed but not committed.
+// The created network and entities are returned. If ctx carries an operator
+// identity, the operator must be able to write all of the entities.
+func ImportNetwork(ctx context.Context, export *protos.NetworkExport, newNetworkID string, dryRun bool) (*protos.Network, []*protos.NetworkEntity, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := client.ImportNetwork(
+		commonProtos.NewOutgoingContextWithOperator(ctx),
+		&protos.ImportNetworkRequest{Export: export, NewNetworkID: newNetworkID, DryRun: dryRun},
+	)
+	if err != nil {
+		return nil, nil, mapConfigValidationError(err)
+	}
+	return resp.CreatedNetwork, resp.CreatedEntities, nil
+}
+
+// CheckPermissions evaluates an operator's ACLs against the given entities
+// and their ancestors in the entity graph. It returns the entities on which
+// the operator has the requested permission and the entities on which it
+// does not.
+func CheckPermissions(operatorID string, networkID string, permission protos.ACL_Permission, ids []*protos.EntityID) ([]*protos.EntityID, []*protos.EntityID, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := client.CheckPermissions(
+		context.Background(),
+		&protos.CheckPermissionsRequest{OperatorID: operatorID, NetworkID: networkID, Entities: ids, Permission: permission},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Allowed, resp.Denied, nil
+}
+
+// TraverseGraph follows associations from the start entity in the given
+// direction for up to maxDepth hops. If hopTypes is provided, hopTypes[i]
+// restricts the type of the entities reached on hop i+1. The reached entities
+// and the edges followed to reach them are returned.
+func TraverseGraph(
+	networkID string,
+	start *protos.EntityID,
+	direction protos.TraverseGraphRequest_Direction,
+	hopTypes []string,
+	maxDepth uint32,
+	criteria *protos.EntityLoadCriteria,
+) ([]*protos.NetworkEntity, []*protos.GraphEdge, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := client.TraverseGraph(
+		context.Background(),
+		&protos.TraverseGraphRequest{
+			NetworkID: networkID,
+			Start:     start,
+			Direction: direction,
+			HopTypes:  hopTypes,
+			MaxDepth:  maxDepth,
+			Criteria:  criteria,
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Entities, resp.Edges, nil
+}
+
+// LoadTombstones returns the tombstones of deleted entities in a network
+// which haven't been purged yet, optionally filtered by entity type.
+func LoadTombstones(networkID string, typeFilter *string) ([]*protos.Tombstone, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.LoadEntities(
+		context.Background(),
+		&protos.LoadEntitiesRequest{
+			NetworkID:      networkID,
+			TypeFilter:     protos.GetStringWrapper(typeFilter),
+			Criteria:       &protos.EntityLoadCriteria{},
+			LoadTombstones: true,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Tombstones, nil
+}
+
+// RestoreEntity recreates a deleted entity from its tombstone, along with its
+// permissions and its associations to and from entities which still exist.
+// The restored entity is returned.
+// Returns errors.ErrNotFound if there is no tombstone for the entity.
+func RestoreEntity(networkID string, id *protos.EntityID) (*protos.NetworkEntity, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := client.RestoreEntity(context.Background(), &protos.RestoreEntityRequest{NetworkID: networkID, Id: id})
+	if err != nil && status.Code(err) == codes.NotFound {
+		return nil, errors.ErrNotFound
+	}
+	return restored, err
+}
+
+// QueryAuditLog returns the audit log entries for writes to a network and its
+// entities, in the order in which the writes were made. The entity and actor
+// filters are optional. Entries are restricted to startTime <= timestamp <
+// endTime, where a zero bound is ignored. If limit is non-zero, at most limit
+// entries are returned.
+func QueryAuditLog(networkID string, entity *protos.EntityID, actor *string, startTime int64, endTime int64, limit uint32) ([]*protos.AuditEntry, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.QueryAuditLog(
+		context.Background(),
+		&protos.QueryAuditLogRequest{
+			NetworkID: networkID,
+			Entity:    entity,
+			Actor:     protos.GetStringWrapper(actor),
+			StartTime: startTime,
+			EndTime:   endTime,
+			Limit:     limit,
 		},
 	)
 	if err != nil {
 		return nil, err
 	}
-	return resp.Entities, err
+	return resp.Entries, nil
+}
+
+func mapVersionConflict(err error) error {
+	if err != nil && status.Code(err) == codes.Aborted {
+		return errors.ErrVersionConflict
+	}
+	return err
+}
+
+// mapConfigValidationError converts an InvalidArgument status with field
+// violation details back into a *ConfigValidationError.
+func mapConfigValidationError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		return err
+	}
+	validationErr := &ConfigValidationError{}
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, violation := range badRequest.FieldViolations {
+			validationErr.Violations = append(
+				validationErr.Violations,
+				FieldViolation{Field: violation.Field, Description: violation.Description},
+			)
+		}
+	}
+	if len(validationErr.Violations) == 0 {
+		return err
+	}
+	return validationErr
 }
@@ -8,15 +8,19 @@ LICENSE file in the root directory of this source tree.
 package configurator_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
+	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/services/configurator"
 	"magma/orc8r/cloud/go/services/configurator/protos"
 	"magma/orc8r/cloud/go/services/configurator/test_init"
 
+	openapiErrors "github.com/go-openapi/errors"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/stretchr/testify/assert"
 )
@@ -129,7 +133,7 @@ func TestConfiguratorService(t *testing.T) {
 	}
 
 	// Create, Load
-	_, err = configurator.CreateEntities(networkID1, []*protos.NetworkEntity{entity1, entity2})
+	_, err = configurator.CreateEntities(context.Background(), networkID1, []*protos.NetworkEntity{entity1, entity2})
 	assert.NoError(t, err)
 
 	entities, entitiesNotFound, err := configurator.LoadEntities(
@@ -160,7 +164,7 @@ func TestConfiguratorService(t *testing.T) {
 		AssociationsToAdd: []*protos.EntityID{entityID2},
 	}
 
-	_, err = configurator.UpdateEntities(networkID1, []*protos.EntityUpdateCriteria{entityUpdateCriteria})
+	_, err = configurator.UpdateEntities(context.Background(), networkID1, []*protos.EntityUpdateCriteria{entityUpdateCriteria})
 	assert.NoError(t, err)
 	entities, entitiesNotFound, err = configurator.LoadEntities(
 		networkID1,
@@ -179,7 +183,7 @@ func TestConfiguratorService(t *testing.T) {
 	assert.Equal(t, entityID2.Id, entities[0].Assocs[0].Id)
 
 	// Delete, Load
-	err = configurator.DeleteEntities(networkID1, []*protos.EntityID{entityID2})
+	err = configurator.DeleteEntities(context.Background(), networkID1, []*protos.EntityID{entityID2})
 	assert.NoError(t, err)
 	entities, entitiesNotFound, err = configurator.LoadEntities(
 		networkID1,
@@ -192,12 +196,203 @@ func TestConfiguratorService(t *testing.T) {
 	assert.Equal(t, 1, len(entities))
 	assert.Equal(t, 0, len(entitiesNotFound))
 	assert.Equal(t, "foobar", entities[0].Name)
+
+	// Watch changes: existing changes after the requested sequence are
+	// streamed first, then new changes as they are written
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	changes := make(chan *protos.Change)
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- configurator.WatchChanges(ctx, networkID1, 3, func(change *protos.Change) error {
+			changes <- change
+			return nil
+		})
+	}()
+	assert.Equal(t, &protos.Change{NetworkID: networkID1, Sequence: 4, Operation: protos.Change_UPDATE, Entity: &protos.EntityID{Type: "foo", Id: "bar"}}, <-changes)
+	assert.Equal(t, &protos.Change{NetworkID: networkID1, Sequence: 5, Operation: protos.Change_DELETE, Entity: &protos.EntityID{Type: "foo", Id: "boo"}}, <-changes)
+
+	_, err = configurator.CreateEntities(context.Background(), networkID1, []*protos.NetworkEntity{entity2})
+	assert.NoError(t, err)
+	assert.Equal(t, &protos.Change{NetworkID: networkID1, Sequence: 6, Operation: protos.Change_CREATE, Entity: &protos.EntityID{Type: "foo", Id: "boo"}}, <-changes)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-watchErr)
+}
+
+func TestConfiguratorService_ExportImport(t *testing.T) {
+	test_init.StartTestService(t)
+	serde.UnregisterSerdesForDomain(t, configurator.SerdeDomain)
+	err := serde.RegisterSerdes(&FooSerde{})
+	assert.NoError(t, err)
+
+	_, err = configurator.CreateNetworks([]*protos.Network{{Id: "n1", Name: "network 1", Configs: map[string][]byte{"foo": []byte("hello")}}})
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities(context.Background(), "n1", []*protos.NetworkEntity{
+		{Type: "foo", Id: "child", Config: []byte("child config")},
+		{
+			Type:   "foo",
+			Id:     "parent",
+			Assocs: []*protos.EntityID{{Type: "foo", Id: "child"}},
+			Permissions: []*protos.ACL{
+				{Scope: &protos.ACL_NetworkIds{NetworkIds: &protos.ACL_NetworkIDs{Ids: []string{"n1"}}}, Permission: protos.ACL_READ, Type: &protos.ACL_EntityType{EntityType: "foo"}},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	export, err := configurator.ExportNetwork(context.Background(), "n1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), export.FormatVersion)
+	assert.Equal(t, "n1", export.Network.Id)
+	assert.Equal(t, []byte("hello"), export.Network.Configs["foo"])
+	assert.Equal(t, 2, len(export.Entities))
+
+	_, err = configurator.ExportNetwork(context.Background(), "n2")
+	assert.Equal(t, merrors.ErrNotFound, err)
+
+	// Dry run should not create anything
+	network, entities, err := configurator.ImportNetwork(context.Background(), export, "n2", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "n2", network.Id)
+	assert.Equal(t, 2, len(entities))
+	exists, err := configurator.DoesNetworkExist("n2")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	network, _, err = configurator.ImportNetwork(context.Background(), export, "n2", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "n2", network.Id)
+	networks, _, err := configurator.LoadNetworks([]string{"n2"}, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "network 1", networks["n2"].Name)
+	assert.Equal(t, []byte("hello"), networks["n2"].Configs["foo"])
+
+	entities, _, err = configurator.LoadEntities(
+		"n2", nil, nil,
+		[]*protos.EntityID{{Type: "foo", Id: "child"}, {Type: "foo", Id: "parent"}},
+		&protos.EntityLoadCriteria{LoadConfig: true, LoadAssocsFrom: true, LoadPermissions: true},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(entities))
+	assert.Equal(t, []byte("child config"), entities[0].Config)
+	assert.Equal(t, "child", entities[1].Assocs[0].Id)
+	assert.Equal(t, []string{"n2"}, entities[1].Permissions[0].GetNetworkIds().Ids)
+
+	// Importing over an existing network fails
+	_, _, err = configurator.ImportNetwork(context.Background(), export, "", false)
+	assert.Error(t, err)
+
+	// Unsupported format version and bad configs are rejected
+	export.FormatVersion = 2
+	_, _, err = configurator.ImportNetwork(context.Background(), export, "n3", false)
+	assert.Error(t, err)
+	export.FormatVersion = 1
+	export.Entities[0].Type = "baz"
+	_, _, err = configurator.ImportNetwork(context.Background(), export, "n3", false)
+	assert.Error(t, err)
+	exists, err = configurator.DoesNetworkExist("n3")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestConfiguratorService_TraverseGraph(t *testing.T) {
+	test_init.StartTestService(t)
+
+	_, err := configurator.CreateNetworks([]*protos.Network{{Id: "n1"}})
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities(context.Background(), "n1", []*protos.NetworkEntity{
+		{Type: "enodeb", Id: "e1", Name: "enodeb 1"},
+		{Type: "subscriber", Id: "s1"},
+		{Type: "gateway", Id: "g1", Assocs: []*protos.EntityID{{Type: "enodeb", Id: "e1"}, {Type: "subscriber", Id: "s1"}}},
+	})
+	assert.NoError(t, err)
+
+	entities, edges, err := configurator.TraverseGraph(
+		"n1",
+		&protos.EntityID{Type: "gateway", Id: "g1"},
+		protos.TraverseGraphRequest_ASSOCS_FROM,
+		[]string{"enodeb"},
+		1,
+		&protos.EntityLoadCriteria{LoadMetadata: true},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entities))
+	assert.Equal(t, "e1", entities[0].Id)
+	assert.Equal(t, "enodeb 1", entities[0].Name)
+	assert.Equal(t, 1, len(edges))
+	assert.Equal(t, "g1", edges[0].From.Id)
+	assert.Equal(t, "e1", edges[0].To.Id)
+
+	entities, edges, err = configurator.TraverseGraph(
+		"n1",
+		&protos.EntityID{Type: "subscriber", Id: "s1"},
+		protos.TraverseGraphRequest_ASSOCS_TO,
+		nil,
+		5,
+		&protos.EntityLoadCriteria{},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entities))
+	assert.Equal(t, "g1", entities[0].Id)
+	assert.Equal(t, 1, len(edges))
+
+	_, _, err = configurator.TraverseGraph("n1", &protos.EntityID{Type: "gateway", Id: "g1"}, protos.TraverseGraphRequest_ASSOCS_FROM, nil, 0, nil)
+	assert.Error(t, err)
 }
 
 func strToStringValue(str string) *wrappers.StringValue {
 	return &wrappers.StringValue{Value: str}
 }
 
+func TestConfiguratorService_ConfigValidation(t *testing.T) {
+	test_init.StartTestService(t)
+	serde.UnregisterSerdesForDomain(t, configurator.SerdeDomain)
+	err := serde.RegisterSerdes(&BazSerde{})
+	assert.NoError(t, err)
+
+	validConfig := []byte(`{"name": "baz", "port": 80}`)
+	invalidConfig := []byte(`{"name": "", "port": -1}`)
+	expectedViolations := func(prefix string) []configurator.FieldViolation {
+		return []configurator.FieldViolation{
+			{Field: prefix + ".name", Description: "name in body is required"},
+			{Field: prefix + ".port", Description: "port in body should be greater than or equal to 0"},
+		}
+	}
+
+	// Network configs
+	_, err = configurator.CreateNetworks([]*protos.Network{{Id: "n1", Configs: map[string][]byte{"baz": invalidConfig}}})
+	assert.Equal(t, &configurator.ConfigValidationError{Violations: expectedViolations("configs.baz")}, err)
+	_, err = configurator.CreateNetworks([]*protos.Network{{Id: "n1", Configs: map[string][]byte{"baz": validConfig}}})
+	assert.NoError(t, err)
+	err = configurator.UpdateNetworks([]*protos.NetworkUpdateCriteria{{Id: "n1", ConfigsToAddOrUpdate: map[string][]byte{"baz": invalidConfig}}})
+	assert.Equal(t, &configurator.ConfigValidationError{Violations: expectedViolations("configs.baz")}, err)
+
+	// Entity configs
+	_, err = configurator.CreateEntities(context.Background(), "n1", []*protos.NetworkEntity{{Type: "baz", Id: "b1", Config: invalidConfig}})
+	assert.Equal(t, &configurator.ConfigValidationError{Violations: expectedViolations("config")}, err)
+	_, err = configurator.CreateEntities(context.Background(), "n1", []*protos.NetworkEntity{{Type: "baz", Id: "b1", Config: validConfig}})
+	assert.NoError(t, err)
+	_, err = configurator.UpdateEntities(context.Background(), "n1", []*protos.EntityUpdateCriteria{{Type: "baz", Key: "b1", NewConfig: &wrappers.BytesValue{Value: invalidConfig}}})
+	assert.Equal(t, &configurator.ConfigValidationError{Violations: expectedViolations("config")}, err)
+
+	// Configs which can't be deserialized
+	_, err = configurator.CreateEntities(context.Background(), "n1", []*protos.NetworkEntity{{Type: "baz", Id: "b2", Config: []byte("not json")}})
+	validationErr, ok := err.(*configurator.ConfigValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, 1, len(validationErr.Violations))
+	assert.Equal(t, "config", validationErr.Violations[0].Field)
+
+	// Nothing invalid was written
+	networks, _, err := configurator.LoadNetworks([]string{"n1"}, false, true)
+	assert.NoError(t, err)
+	assert.Equal(t, validConfig, networks["n1"].Configs["baz"])
+	entities, _, err := configurator.LoadEntities("n1", nil, nil, []*protos.EntityID{{Type: "baz", Id: "b1"}, {Type: "baz", Id: "b2"}}, &protos.EntityLoadCriteria{LoadConfig: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entities))
+	assert.Equal(t, validConfig, entities[0].Config)
+}
+
 func strPointer(str string) *string {
 	return &str
 }
@@ -251,3 +446,80 @@ func (*BarSerde) Deserialize(message []byte) (interface{}, error) {
 	err := json.Unmarshal(message, &res)
 	return res, err
 }
+
+func TestConfiguratorService_RestoreEntity(t *testing.T) {
+	test_init.StartTestService(t)
+
+	_, err := configurator.CreateNetworks([]*protos.Network{{Id: "n1"}})
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities(context.Background(), "n1", []*protos.NetworkEntity{
+		{Type: "enodeb", Id: "e1"},
+		{Type: "gateway", Id: "g1", Name: "gateway 1", Assocs: []*protos.EntityID{{Type: "enodeb", Id: "e1"}}},
+	})
+	assert.NoError(t, err)
+
+	g1 := &protos.EntityID{Type: "gateway", Id: "g1"}
+	err = configurator.DeleteEntities(context.Background(), "n1", []*protos.EntityID{g1})
+	assert.NoError(t, err)
+	exists, err := configurator.DoesEntityExist("n1", "gateway", "g1")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	tombstones, err := configurator.LoadTombstones("n1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(tombstones))
+	assert.Equal(t, "g1", tombstones[0].Entity.Id)
+	assert.Equal(t, "gateway 1", tombstones[0].Entity.Name)
+	assert.NotZero(t, tombstones[0].DeletedAt)
+
+	restored, err := configurator.RestoreEntity("n1", g1)
+	assert.NoError(t, err)
+	assert.Equal(t, "gateway 1", restored.Name)
+	assert.Equal(t, []*protos.EntityID{{Type: "enodeb", Id: "e1"}}, restored.Assocs)
+	exists, err = configurator.DoesEntityExist("n1", "gateway", "g1")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	_, err = configurator.RestoreEntity("n1", g1)
+	assert.Equal(t, merrors.ErrNotFound, err)
+}
+
+type Baz struct {
+	Name string `json:"name"`
+	Port int    `json:"port"`
+}
+
+func (baz *Baz) ValidateModel() error {
+	var res []error
+	if baz.Name == "" {
+		res = append(res, openapiErrors.Required("name", "body"))
+	}
+	if baz.Port < 0 {
+		res = append(res, openapiErrors.ExceedsMinimumInt("port", "body", 0, false))
+	}
+	if len(res) > 0 {
+		return openapiErrors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+type BazSerde struct {
+}
+
+func (*BazSerde) GetDomain() string {
+	return configurator.SerdeDomain
+}
+
+func (*BazSerde) GetType() string {
+	return "baz"
+}
+
+func (*BazSerde) Serialize(in interface{}) ([]byte, error) {
+	return json.Marshal(in)
+}
+
+func (*BazSerde) Deserialize(message []byte) (interface{}, error) {
+	res := &Baz{}
+	err := json.Unmarshal(message, res)
+	return res, err
+}
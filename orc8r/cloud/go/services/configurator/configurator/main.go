@@ -14,7 +14,10 @@ and meta data for the network and network entity structures.
 package main
 
 import (
+	"context"
 	"database/sql"
+	"flag"
+	"time"
 
 	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/orc8r"
@@ -28,6 +31,12 @@ import (
 	"github.com/golang/glog"
 )
 
+var (
+	tombstoneRetentionHours = flag.Int64("tombstone-retention-hours", 168, "How long deleted entities can be restored for (in hours)")
+	changeRetentionHours    = flag.Int64("change-retention-hours", 168, "How long change log entries are kept for (in hours)")
+	purgeHours              = flag.Int64("purge-hours", 1, "Tombstone and change log purge time interval (in hours)")
+)
+
 func main() {
 	// Create the service
 	srv, err := service.NewOrchestratorService(orc8r.ModuleName, configurator.ServiceName)
@@ -40,7 +49,13 @@ func main() {
 	}
 
 	factory := storage.NewSQLConfiguratorStorageFactory(db, &storage.DefaultIDGenerator{}, sqorc.GetSqlBuilder())
-	err = factory.InitializeServiceStorage()
+	err = sqorc.ApplyServiceMigrations(db, sqorc.GetSqlBuilder(), configurator.ServiceName)
+	if err == sqorc.ErrMigrationsUnsupported {
+		err = factory.InitializeServiceStorage()
+	}
+	if err != nil {
+		glog.Fatalf("Failed to initialize configurator database: %s", err)
+	}
 
 	nbServicer, err := servicers.NewNorthboundConfiguratorServicer(factory)
 	if err != nil {
@@ -54,8 +69,56 @@ func main() {
 	}
 	protos.RegisterSouthboundConfiguratorServer(srv.GrpcServer, sbServicer)
 
+	// Start tombstone and change log purge ticker
+	purge := time.Tick(time.Hour * time.Duration(*purgeHours))
+	go func() {
+		for now := range purge {
+			deletedBefore := now.Add(-time.Hour * time.Duration(*tombstoneRetentionHours))
+			purged, err := purgeTombstones(factory, deletedBefore)
+			if err != nil {
+				glog.Errorf("Failed to purge tombstones: %s", err)
+			} else {
+				glog.Infof("%v - Purged %d tombstones", now, purged)
+			}
+
+			createdBefore := now.Add(-time.Hour * time.Duration(*changeRetentionHours))
+			purged, err = purgeChanges(factory, createdBefore)
+			if err != nil {
+				glog.Errorf("Failed to purge changes: %s", err)
+			} else {
+				glog.Infof("%v - Purged %d changes", now, purged)
+			}
+		}
+	}()
+
 	err = srv.Run()
 	if err != nil {
 		glog.Fatalf("Failed to start configurator service: %v", err)
 	}
 }
+
+func purgeTombstones(factory storage.ConfiguratorStorageFactory, deletedBefore time.Time) (int64, error) {
+	store, err := factory.StartTransaction(context.Background(), &storage.TxOptions{ReadOnly: false})
+	if err != nil {
+		return 0, err
+	}
+	purged, err := store.PurgeTombstones(deletedBefore.Unix())
+	if err != nil {
+		store.Rollback()
+		return 0, err
+	}
+	return purged, store.Commit()
+}
+
+func purgeChanges(factory storage.ConfiguratorStorageFactory, createdBefore time.Time) (int64, error) {
+	store, err := factory.StartTransaction(context.Background(), &storage.TxOptions{ReadOnly: false})
+	if err != nil {
+		return 0, err
+	}
+	purged, err := store.PurgeChanges(createdBefore.Unix())
+	if err != nil {
+		store.Rollback()
+		return 0, err
+	}
+	return purged, store.Commit()
+}
@@ -16,4 +16,8 @@ const (
 	// SerdeDomain is the name of this service's serde domain
 	SerdeDomain       = "config_manager"
 	GatewayEntityType = "gateway"
+	// OperatorEntityType is the type of the entities in the internal network
+	// which represent operators. An operator's ACLs are the permissions of
+	// its entity.
+	OperatorEntityType = "operator"
 )
@@ -9,6 +9,8 @@
 package handler_utils
 
 import (
+	"context"
+
 	"magma/orc8r/cloud/go/services/configurator"
 	"magma/orc8r/cloud/go/services/configurator/protos"
 	"magma/orc8r/cloud/go/services/configurator/storage"
@@ -39,7 +41,8 @@ func CreateNetworkIfNotExists(networkID string) error {
 // Create an empty network and/or network entity if it doesn't exist already.
 // If the entity already exists, return its networkID and entityID. Otherwise,
 // return (networkID, entityID) that point to the newly created entity.
-func CreateNetworkEntityIfNotExists(networkID, entityType, entityID string) error {
+// If ctx carries an operator identity, the operator's ACLs are enforced.
+func CreateNetworkEntityIfNotExists(ctx context.Context, networkID, entityType, entityID string) error {
 	err := CreateNetworkIfNotExists(networkID)
 	if err != nil {
 		return err
@@ -58,7 +61,7 @@ func CreateNetworkEntityIfNotExists(networkID, entityType, entityID string) erro
 		Id:   entityID,
 		Type: entityType,
 	}
-	_, err = configurator.CreateEntities(networkID, []*protos.NetworkEntity{networkEntity})
+	_, err = configurator.CreateEntities(ctx, networkID, []*protos.NetworkEntity{networkEntity})
 	if err != nil {
 		return err
 	}
@@ -66,5 +69,5 @@ func CreateNetworkEntityIfNotExists(networkID, entityType, entityID string) erro
 }
 
 func CreateInternalNetworkEntityIfNotExists(entityType, entityID string) error {
-	return CreateNetworkEntityIfNotExists(storage.InternalNetworkID, entityType, entityID)
+	return CreateNetworkEntityIfNotExists(context.Background(), storage.InternalNetworkID, entityType, entityID)
 }
@@ -9,13 +9,16 @@ LICENSE file in the root directory of this source tree.
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"path"
 	"reflect"
 
+	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/obsidian/handlers"
 	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/configurator/protos"
 
 	"github.com/labstack/echo"
 )
@@ -57,10 +60,19 @@ func GetReadNetworkConfigHandler(path string) handlers.Handler {
 			if nerr != nil {
 				return nerr
 			}
-			iConfig, err := configurator.GetNetworkConfigsByType(networkID, configType)
+			networks, _, err := configurator.LoadNetworks([]string{networkID}, false, true)
 			if err != nil {
-				return err
+				return handlers.HttpError(err, http.StatusInternalServerError)
 			}
+			network, ok := networks[networkID]
+			if !ok {
+				return handlers.HttpError(fmt.Errorf("Network %s not found", networkID), http.StatusNotFound)
+			}
+			iConfig, err := serde.Deserialize(configurator.SerdeDomain, configType, network.Configs[configType])
+			if err != nil {
+				return handlers.HttpError(err, http.StatusInternalServerError)
+			}
+			handlers.SetETag(c, network.Version)
 			return c.JSON(http.StatusOK, iConfig)
 		},
 	}
@@ -109,11 +121,27 @@ func createOrUpdateNetworkConfig(c echo.Context, path string, serde serde.Serde)
 	if nerr != nil {
 		return nerr
 	}
+	expectedVersion, nerr := handlers.GetIfMatchVersion(c)
+	if nerr != nil {
+		return nerr
+	}
 	config, err := getConfigFromContext(c, serde)
 	if err != nil {
 		return handlers.HttpError(err, http.StatusBadRequest)
 	}
-	err = configurator.UpdateNetworkConfig(networkID, configType, config)
+	serializedConfig, err := serde.Serialize(config)
+	if err != nil {
+		return handlers.HttpError(err, http.StatusBadRequest)
+	}
+	updateCriteria := &protos.NetworkUpdateCriteria{
+		Id:                   networkID,
+		ConfigsToAddOrUpdate: map[string][]byte{configType: serializedConfig},
+		ExpectedVersion:      protos.GetUInt64Wrapper(expectedVersion),
+	}
+	err = configurator.UpdateNetworks([]*protos.NetworkUpdateCriteria{updateCriteria})
+	if err == merrors.ErrVersionConflict {
+		return handlers.HttpError(err, http.StatusConflict)
+	}
 	if err != nil {
 		return handlers.HttpError(err, http.StatusBadRequest)
 	}
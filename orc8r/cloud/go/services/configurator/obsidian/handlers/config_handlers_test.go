@@ -120,6 +120,54 @@ func TestGetNetworkConfigCRUDHandlers(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, rec.Code)
 
 	assertConfigDoesNotExist(t, networkID, fooSerdeType)
+
+	// Test optimistic concurrency with ETag/If-Match
+	post, err = json.Marshal(config)
+	assert.NoError(t, err)
+	req = httptest.NewRequest(echo.POST, "/", strings.NewReader(string(post)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	addParametersToContext(c, networkID, fooSerdeType)
+	err = configuratorh.GetCreateNetworkConfigHandler(url, &fooSerde).HandlerFunc(c)
+	assert.NoError(t, err)
+
+	req = httptest.NewRequest(echo.GET, "/", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	addParametersToContext(c, networkID, fooSerdeType)
+	err = configuratorh.GetReadNetworkConfigHandler(url).HandlerFunc(c)
+	assert.NoError(t, err)
+	etag := rec.Header().Get("ETag")
+	assert.Equal(t, `"4"`, etag)
+
+	// Update with the current ETag succeeds
+	post, err = json.Marshal(updatedConfig)
+	assert.NoError(t, err)
+	req = httptest.NewRequest(echo.PUT, "/", strings.NewReader(string(post)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", etag)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	addParametersToContext(c, networkID, fooSerdeType)
+	err = configuratorh.GetUpdateNetworkConfigHandler(url, &fooSerde).HandlerFunc(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assertConfigExists(t, networkID, fooSerdeType, updatedConfig)
+
+	// Update with a stale ETag is rejected with a conflict
+	post, err = json.Marshal(config)
+	assert.NoError(t, err)
+	req = httptest.NewRequest(echo.PUT, "/", strings.NewReader(string(post)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", etag)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	addParametersToContext(c, networkID, fooSerdeType)
+	err = configuratorh.GetUpdateNetworkConfigHandler(url, &fooSerde).HandlerFunc(c)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusConflict, err.(*echo.HTTPError).Code)
+	assertConfigExists(t, networkID, fooSerdeType, updatedConfig)
 }
 
 func addParametersToContext(c echo.Context, networkID string, configType string) echo.Context {
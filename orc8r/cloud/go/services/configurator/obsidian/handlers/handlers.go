@@ -21,5 +21,8 @@ func GetObsidianHandlers() []handlers.Handler {
 		{Path: ManageNetwork, Methods: handlers.GET, HandlerFunc: getNetwork},
 		{Path: ManageNetwork, Methods: handlers.PUT, HandlerFunc: updateNetwork},
 		{Path: ManageNetwork, Methods: handlers.DELETE, HandlerFunc: deleteNetwork},
+		{Path: ExportNetwork, Methods: handlers.GET, HandlerFunc: exportNetwork},
+		{Path: ImportNetwork, Methods: handlers.POST, HandlerFunc: importNetwork},
+		{Path: NetworkAuditLog, Methods: handlers.GET, HandlerFunc: queryAuditLog},
 	}
 }
@@ -10,16 +10,22 @@ package handlers
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"regexp"
+	"strconv"
 
+	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/obsidian/handlers"
+	commonProtos "magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/configurator"
 	configurator_models "magma/orc8r/cloud/go/services/configurator/obsidian/models"
 	"magma/orc8r/cloud/go/services/configurator/protos"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/labstack/echo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -28,6 +34,9 @@ const (
 	ListNetworks             = ConfiguratorNetworksRoot
 	RegisterNetwork          = ConfiguratorNetworksRoot
 	ManageNetwork            = ConfiguratorNetworksRoot + "/:network_id"
+	ExportNetwork            = ManageNetwork + "/export"
+	ImportNetwork            = ConfiguratorNetworksRoot + "/import"
+	NetworkAuditLog          = ManageNetwork + "/audit"
 )
 
 func listNetworks(c echo.Context) error {
@@ -86,6 +95,7 @@ func getNetwork(c echo.Context) error {
 		return handlers.HttpError(fmt.Errorf("Network ID %s not found", networkID), http.StatusBadRequest)
 	}
 	network := networks[networkID]
+	handlers.SetETag(c, network.Version)
 
 	swaggerRecord := &configurator_models.NetworkRecord{
 		Name:        network.Name,
@@ -106,12 +116,21 @@ func updateNetwork(c echo.Context) error {
 		return handlers.HttpError(err, http.StatusBadRequest)
 	}
 
+	expectedVersion, nerr := handlers.GetIfMatchVersion(c)
+	if nerr != nil {
+		return nerr
+	}
+
 	updateCriteria := &protos.NetworkUpdateCriteria{
-		Id:             networkID,
-		NewName:        inputStrToStrWrapper(swaggerNetwork.Name),
-		NewDescription: inputStrToStrWrapper(swaggerNetwork.Description),
+		Id:              networkID,
+		NewName:         inputStrToStrWrapper(swaggerNetwork.Name),
+		NewDescription:  inputStrToStrWrapper(swaggerNetwork.Description),
+		ExpectedVersion: protos.GetUInt64Wrapper(expectedVersion),
 	}
 	err = configurator.UpdateNetworks([]*protos.NetworkUpdateCriteria{updateCriteria})
+	if err == merrors.ErrVersionConflict {
+		return handlers.HttpError(err, http.StatusConflict)
+	}
 	if err != nil {
 		return handlers.HttpError(err, http.StatusBadRequest)
 	}
@@ -131,9 +150,147 @@ func deleteNetwork(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+func exportNetwork(c echo.Context) error {
+	networkID, nerr := handlers.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	export, err := configurator.ExportNetwork(c.Request().Context(), networkID)
+	if err == merrors.ErrNotFound {
+		return handlers.HttpError(fmt.Errorf("Network ID %s not found", networkID), http.StatusNotFound)
+	}
+	if err != nil {
+		return handlers.HttpError(err, merrors.GetHttpStatusCode(err))
+	}
+	marshaledExport, err := commonProtos.Marshal(export)
+	if err != nil {
+		return handlers.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.JSONBlob(http.StatusOK, marshaledExport)
+}
+
+func importNetwork(c echo.Context) error {
+	// Check for wildcard network access
+	nerr := handlers.CheckNetworkAccess(c, handlers.NETWORK_WILDCARD)
+	if nerr != nil {
+		return nerr
+	}
+
+	body, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return handlers.HttpError(err, http.StatusBadRequest)
+	}
+	export := &protos.NetworkExport{}
+	err = commonProtos.Unmarshal(body, export)
+	if err != nil {
+		return handlers.HttpError(err, http.StatusBadRequest)
+	}
+	// Without a new ID, the network is created under the ID in the export,
+	// so that ID has to be checked like a requested one
+	newNetworkID := c.QueryParam("new_network_id")
+	networkID := newNetworkID
+	if networkID == "" {
+		networkID = export.GetNetwork().GetId()
+	}
+	if networkID == "" {
+		return handlers.HttpError(fmt.Errorf("Network ID must be provided in the export or as new_network_id"), http.StatusBadRequest)
+	}
+	err = VerifyNetworkIDFormat(networkID)
+	if err != nil {
+		return err
+	}
+	dryRun := false
+	if dryRunParam := c.QueryParam("dry_run"); dryRunParam != "" {
+		dryRun, err = strconv.ParseBool(dryRunParam)
+		if err != nil {
+			return handlers.HttpError(fmt.Errorf("invalid dry_run parameter %s", dryRunParam), http.StatusBadRequest)
+		}
+	}
+
+	network, _, err := configurator.ImportNetwork(c.Request().Context(), export, newNetworkID, dryRun)
+	if status.Code(err) == codes.PermissionDenied {
+		return handlers.HttpError(err, http.StatusForbidden)
+	}
+	if err != nil {
+		return handlers.HttpError(err, http.StatusBadRequest)
+	}
+	if dryRun {
+		return c.JSON(http.StatusOK, network.Id)
+	}
+	return c.JSON(http.StatusCreated, network.Id)
+}
+
+// queryAuditLog returns the audit log of a network. Entries can be filtered
+// to an entity with the entity_type and entity_key query params, to the writes
+// of an operator with the operator param, and to a time range with the
+// start_time and end_time params (unix seconds). The limit param caps the
+// number of entries returned.
+func queryAuditLog(c echo.Context) error {
+	networkID, nerr := handlers.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	var entity *protos.EntityID
+	entityType, entityKey := c.QueryParam("entity_type"), c.QueryParam("entity_key")
+	if entityType != "" || entityKey != "" {
+		if entityType == "" || entityKey == "" {
+			return handlers.HttpError(fmt.Errorf("entity_type and entity_key must be provided together"), http.StatusBadRequest)
+		}
+		entity = &protos.EntityID{Type: entityType, Id: entityKey}
+	}
+	var actor *string
+	if operator := c.QueryParam("operator"); operator != "" {
+		actorID := commonProtos.NewOperatorIdentity(operator).HashString()
+		actor = &actorID
+	}
+	startTime, nerr := getInt64QueryParam(c, "start_time")
+	if nerr != nil {
+		return nerr
+	}
+	endTime, nerr := getInt64QueryParam(c, "end_time")
+	if nerr != nil {
+		return nerr
+	}
+	limit := uint64(0)
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		var err error
+		limit, err = strconv.ParseUint(limitParam, 10, 32)
+		if err != nil {
+			return handlers.HttpError(fmt.Errorf("invalid limit parameter %s", limitParam), http.StatusBadRequest)
+		}
+	}
+
+	entries, err := configurator.QueryAuditLog(networkID, entity, actor, startTime, endTime, uint32(limit))
+	if err != nil {
+		return handlers.HttpError(err, http.StatusInternalServerError)
+	}
+	marshaledEntries, err := commonProtos.Marshal(&protos.QueryAuditLogResponse{Entries: entries})
+	if err != nil {
+		return handlers.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.JSONBlob(http.StatusOK, marshaledEntries)
+}
+
+// getInt64QueryParam parses an optional integer query param, returning 0 if
+// the param is absent.
+func getInt64QueryParam(c echo.Context, name string) (int64, *echo.HTTPError) {
+	param := c.QueryParam(name)
+	if param == "" {
+		return 0, nil
+	}
+	ret, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return 0, handlers.HttpError(fmt.Errorf("invalid %s parameter %s", name, param), http.StatusBadRequest)
+	}
+	return ret, nil
+}
+
 func inputStrToStrWrapper(in string) *wrappers.StringValue {
 	return &wrappers.StringValue{Value: in}
 }
+
 func VerifyNetworkIDFormat(requestedID string) error {
 	if len(requestedID) > 0 {
 		r, _ := regexp.Compile("^[a-z_][0-9a-z_]+$")
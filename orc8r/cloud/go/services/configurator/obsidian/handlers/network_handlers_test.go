@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ */
+
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"magma/orc8r/cloud/go/obsidian/access"
+	access_tests "magma/orc8r/cloud/go/obsidian/access/tests"
+	"magma/orc8r/cloud/go/obsidian/handlers"
+	commonProtos "magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/configurator"
+	configuratorh "magma/orc8r/cloud/go/services/configurator/obsidian/handlers"
+	"magma/orc8r/cloud/go/services/configurator/protos"
+	"magma/orc8r/cloud/go/services/configurator/test_init"
+	"magma/orc8r/cloud/go/util"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+const aclTestOperatorID = "acl_test_operator"
+
+// Operator ACLs are enforced by configurator for requests which go through
+// obsidian's access control middleware
+func TestNetworkExportImportACLs(t *testing.T) {
+	test_init.StartTestService(t)
+	certSn := access_tests.StartMockAccessControl(t, aclTestOperatorID)
+	e := echo.New()
+	for _, handler := range configuratorh.GetObsidianHandlers() {
+		switch handler.Methods {
+		case handlers.GET:
+			e.GET(handler.Path, handler.HandlerFunc)
+		case handlers.POST:
+			e.POST(handler.Path, handler.HandlerFunc)
+		}
+	}
+	e.Use(access.Middleware)
+	srv := httptest.NewServer(e)
+	defer srv.Close()
+	urlRoot := srv.URL + configuratorh.ConfiguratorNetworksRoot
+	sendRequest := func(method string, url string, payload string) (int, string, error) {
+		return util.SendHttpRequest(method, url, payload, []string{access.CLIENT_CERT_SN_KEY, certSn})
+	}
+
+	_, err := configurator.CreateNetworks([]*protos.Network{{Id: "acl_network"}})
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities(context.Background(), "acl_network", []*protos.NetworkEntity{
+		{Type: "gateway", Id: "g1"},
+		{Type: "gateway", Id: "g2"},
+	})
+	assert.NoError(t, err)
+	exportUrl := urlRoot + "/acl_network/export"
+	importUrl := urlRoot + "/import?new_network_id=acl_network_copy"
+
+	// The test operator doesn't have an operator entity yet, so only its
+	// accessd ACLs apply
+	status, export, err := sendRequest("GET", exportUrl, "")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	// The operator can only read g1
+	_, err = configurator.CreateInternalEntities([]*protos.NetworkEntity{
+		{
+			Type: configurator.OperatorEntityType,
+			Id:   aclTestOperatorID,
+			Permissions: []*protos.ACL{
+				{
+					Scope:      &protos.ACL_NetworkIds{NetworkIds: &protos.ACL_NetworkIDs{Ids: []string{"acl_network"}}},
+					Permission: protos.ACL_READ,
+					Type:       &protos.ACL_EntityType{EntityType: "gateway"},
+					IdFilter:   []string{"g1"},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	status, _, err = sendRequest("GET", exportUrl, "")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, status)
+
+	// Without write permissions on the imported entities, imports are denied
+	status, _, err = sendRequest("POST", importUrl, export)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, status)
+	exists, err := configurator.DoesNetworkExist("acl_network_copy")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// Requests which don't go through obsidian aren't subject to ACLs
+	_, err = configurator.ExportNetwork(context.Background(), "acl_network")
+	assert.NoError(t, err)
+}
+
+func TestImportNetworkIDValidation(t *testing.T) {
+	test_init.StartTestService(t)
+	var importHandler echo.HandlerFunc
+	for _, handler := range configuratorh.GetObsidianHandlers() {
+		if handler.Path == configuratorh.ImportNetwork {
+			importHandler = handler.HandlerFunc
+		}
+	}
+	e := echo.New()
+	runImport := func(exportedID string, newNetworkID string) error {
+		export := &protos.NetworkExport{FormatVersion: 1, Network: &protos.Network{Id: exportedID}}
+		body, err := commonProtos.Marshal(export)
+		assert.NoError(t, err)
+		url := "/"
+		if newNetworkID != "" {
+			url += "?new_network_id=" + newNetworkID
+		}
+		req := httptest.NewRequest(echo.POST, url, strings.NewReader(string(body)))
+		return importHandler(e.NewContext(req, httptest.NewRecorder()))
+	}
+
+	// The exported ID is validated if no new ID is given
+	err := runImport("Bad-ID", "")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+	err = runImport("", "")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+	exists, err := configurator.DoesNetworkExist("Bad-ID")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	err = runImport("bad_id", "Bad-ID")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+
+	// A valid new ID replaces an invalid exported ID
+	err = runImport("Bad-ID", "imported_network")
+	assert.NoError(t, err)
+	exists, err = configurator.DoesNetworkExist("imported_network")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
@@ -46,7 +46,7 @@ func (x ACL_Permission) String() string {
 	return proto.EnumName(ACL_Permission_name, int32(x))
 }
 func (ACL_Permission) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_configurator_4e9048b38139a8bc, []int{4, 0}
+	return fileDescriptor_configurator_cc16500d6f6a797c, []int{4, 0}
 }
 
 type ACL_Wildcard int32
@@ -69,7 +69,7 @@ func (x ACL_Wildcard) String() string {
 	return proto.EnumName(ACL_Wildcard_name, int32(x))
 }
 func (ACL_Wildcard) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_configurator_4e9048b38139a8bc, []int{4, 1}
+	return fileDescriptor_configurator_cc16500d6f6a797c, []int{4, 1}
 }
 
 // Network is the core tenancy concept in configurator. A network can have
@@ -78,20 +78,22 @@ func (ACL_Wildcard) EnumDescriptor() ([]byte, []int) {
 // the hood into an internal-only network.
 type Network struct {
 	// Network ID is unique across all tenants
-	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string            `protobuf:"bytes,10,opt,name=name,proto3" json:"name,omitempty"`
-	Description          string            `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
-	Configs              map[string][]byte `protobuf:"bytes,20,rep,name=configs,proto3" json:"configs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Id          string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string            `protobuf:"bytes,10,opt,name=name,proto3" json:"name,omitempty"`
+	Description string            `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
+	Configs     map[string][]byte `protobuf:"bytes,20,rep,name=configs,proto3" json:"configs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// version is incremented on every update to the network
+	Version              uint64   `protobuf:"varint,30,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Network) Reset()         { *m = Network{} }
 func (m *Network) String() string { return proto.CompactTextString(m) }
 func (*Network) ProtoMessage()    {}
 func (*Network) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configurator_4e9048b38139a8bc, []int{0}
+	return fileDescriptor_configurator_cc16500d6f6a797c, []int{0}
 }
 func (m *Network) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Network.Unmarshal(m, b)
@@ -139,6 +141,13 @@ func (m *Network) GetConfigs() map[string][]byte {
 	return nil
 }
 
+func (m *Network) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 // The network entity is the core entity managed by configurator. A network
 // entity can correspond to a physical asset like an access gateway or radio,
 // in which case the physical_id field will be populated. A network entity can
@@ -163,19 +172,21 @@ type NetworkEntity struct {
 	Config  []byte `protobuf:"bytes,30,opt,name=config,proto3" json:"config,omitempty"`
 	GraphID string `protobuf:"bytes,40,opt,name=graphID,proto3" json:"graphID,omitempty"`
 	// assocs represents the related network entities as an adjacency list
-	Assocs               []*EntityID `protobuf:"bytes,50,rep,name=assocs,proto3" json:"assocs,omitempty"`
-	ParentAssocs         []*EntityID `protobuf:"bytes,60,rep,name=parent_assocs,json=parentAssocs,proto3" json:"parent_assocs,omitempty"`
-	Permissions          []*ACL      `protobuf:"bytes,70,rep,name=permissions,proto3" json:"permissions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Assocs       []*EntityID `protobuf:"bytes,50,rep,name=assocs,proto3" json:"assocs,omitempty"`
+	ParentAssocs []*EntityID `protobuf:"bytes,60,rep,name=parent_assocs,json=parentAssocs,proto3" json:"parent_assocs,omitempty"`
+	Permissions  []*ACL      `protobuf:"bytes,70,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	// version is incremented on every update to the entity
+	Version              uint64   `protobuf:"varint,80,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *NetworkEntity) Reset()         { *m = NetworkEntity{} }
 func (m *NetworkEntity) String() string { return proto.CompactTextString(m) }
 func (*NetworkEntity) ProtoMessage()    {}
 func (*NetworkEntity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configurator_4e9048b38139a8bc, []int{1}
+	return fileDescriptor_configurator_cc16500d6f6a797c, []int{1}
 }
 func (m *NetworkEntity) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkEntity.Unmarshal(m, b)
@@ -265,6 +276,13 @@ func (m *NetworkEntity) GetPermissions() []*ACL {
 	return nil
 }
 
+func (m *NetworkEntity) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type NetworkConfig struct {
 	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -277,7 +295,7 @@ func (m *NetworkConfig) Reset()         { *m = NetworkConfig{} }
 func (m *NetworkConfig) String() string { return proto.CompactTextString(m) }
 func (*NetworkConfig) ProtoMessage()    {}
 func (*NetworkConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configurator_4e9048b38139a8bc, []int{2}
+	return fileDescriptor_configurator_cc16500d6f6a797c, []int{2}
 }
 func (m *NetworkConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkConfig.Unmarshal(m, b)
@@ -323,7 +341,7 @@ func (m *EntityID) Reset()         { *m = EntityID{} }
 func (m *EntityID) String() string { return proto.CompactTextString(m) }
 func (*EntityID) ProtoMessage()    {}
 func (*EntityID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configurator_4e9048b38139a8bc, []int{3}
+	return fileDescriptor_configurator_cc16500d6f6a797c, []int{3}
 }
 func (m *EntityID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EntityID.Unmarshal(m, b)
@@ -391,7 +409,7 @@ func (m *ACL) Reset()         { *m = ACL{} }
 func (m *ACL) String() string { return proto.CompactTextString(m) }
 func (*ACL) ProtoMessage()    {}
 func (*ACL) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configurator_4e9048b38139a8bc, []int{4}
+	return fileDescriptor_configurator_cc16500d6f6a797c, []int{4}
 }
 func (m *ACL) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ACL.Unmarshal(m, b)
@@ -627,7 +645,7 @@ func (m *ACL_NetworkIDs) Reset()         { *m = ACL_NetworkIDs{} }
 func (m *ACL_NetworkIDs) String() string { return proto.CompactTextString(m) }
 func (*ACL_NetworkIDs) ProtoMessage()    {}
 func (*ACL_NetworkIDs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configurator_4e9048b38139a8bc, []int{4, 0}
+	return fileDescriptor_configurator_cc16500d6f6a797c, []int{4, 0}
 }
 func (m *ACL_NetworkIDs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ACL_NetworkIDs.Unmarshal(m, b)
@@ -666,48 +684,49 @@ func init() {
 	proto.RegisterEnum("magma.orc8r.configurator.ACL_Wildcard", ACL_Wildcard_name, ACL_Wildcard_value)
 }
 
-func init() { proto.RegisterFile("configurator.proto", fileDescriptor_configurator_4e9048b38139a8bc) }
-
-var fileDescriptor_configurator_4e9048b38139a8bc = []byte{
-	// 625 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xcd, 0x6a, 0xdb, 0x40,
-	0x10, 0xb6, 0xac, 0xc4, 0x3f, 0x23, 0xdb, 0x15, 0x4b, 0x28, 0xdb, 0x94, 0x26, 0xae, 0x0e, 0x45,
-	0x97, 0x2a, 0xe0, 0x1e, 0x9a, 0x86, 0x42, 0x71, 0x6c, 0xa7, 0x16, 0x75, 0xec, 0xb0, 0x04, 0x0c,
-	0xbd, 0x08, 0x45, 0x52, 0x9c, 0x25, 0xb6, 0x56, 0xec, 0x2a, 0x0d, 0xbe, 0xf6, 0x5d, 0xfa, 0x48,
-	0x7d, 0x9f, 0xa2, 0x5d, 0xc9, 0x16, 0x34, 0x49, 0x7f, 0x4e, 0x9e, 0xbf, 0x6f, 0xf6, 0x9b, 0x6f,
-	0xc6, 0x02, 0x14, 0xb0, 0xf8, 0x9a, 0x2e, 0xee, 0xb8, 0x9f, 0x32, 0xee, 0x24, 0x9c, 0xa5, 0x0c,
-	0xe1, 0x95, 0xbf, 0x58, 0xf9, 0x0e, 0xe3, 0xc1, 0x31, 0x77, 0xca, 0xf9, 0xfd, 0x17, 0x0b, 0xc6,
-	0x16, 0xcb, 0xe8, 0x48, 0xd6, 0x5d, 0xdd, 0x5d, 0x1f, 0xf9, 0xf1, 0x5a, 0x81, 0xac, 0x9f, 0x1a,
-	0xd4, 0xa7, 0x51, 0x7a, 0xcf, 0xf8, 0x2d, 0xea, 0x40, 0x95, 0x86, 0x58, 0xeb, 0x6a, 0x76, 0x93,
-	0x54, 0x69, 0x88, 0x10, 0xec, 0xc4, 0xfe, 0x2a, 0xc2, 0x20, 0x23, 0xd2, 0x46, 0x5d, 0x30, 0xc2,
-	0x48, 0x04, 0x9c, 0x26, 0x29, 0x65, 0x31, 0x36, 0x64, 0xaa, 0x1c, 0x42, 0x63, 0xa8, 0xab, 0xc7,
-	0x05, 0xde, 0xeb, 0xea, 0xb6, 0xd1, 0x73, 0x9c, 0xc7, 0x88, 0x39, 0xf9, 0xcb, 0xce, 0x40, 0x01,
-	0x46, 0x71, 0xca, 0xd7, 0xa4, 0x80, 0xef, 0x9f, 0x40, 0xab, 0x9c, 0x40, 0x26, 0xe8, 0xb7, 0xd1,
-	0x3a, 0x27, 0x98, 0x99, 0x68, 0x0f, 0x76, 0xbf, 0xf9, 0xcb, 0xbb, 0x08, 0x57, 0xbb, 0x9a, 0xdd,
-	0x22, 0xca, 0x39, 0xa9, 0x1e, 0x6b, 0xd6, 0x77, 0x1d, 0xda, 0x79, 0xf7, 0x51, 0x9c, 0xd2, 0x74,
-	0xfd, 0xd0, 0x74, 0xe9, 0x3a, 0x51, 0xd0, 0x26, 0x91, 0xf6, 0x7f, 0x4e, 0x7c, 0x08, 0x46, 0x72,
-	0xb3, 0x16, 0x34, 0xf0, 0x97, 0x1e, 0x0d, 0xf1, 0x9e, 0xac, 0x80, 0x22, 0xe4, 0x86, 0xe8, 0x39,
-	0xd4, 0xd4, 0x4c, 0xf8, 0x40, 0xf2, 0xcc, 0x3d, 0x84, 0xa1, 0xbe, 0xe0, 0x7e, 0x72, 0xe3, 0x0e,
-	0xb1, 0x2d, 0x41, 0x85, 0x8b, 0x4e, 0xa0, 0xe6, 0x0b, 0xc1, 0x02, 0x81, 0x7b, 0x52, 0x43, 0xeb,
-	0x71, 0x0d, 0xd5, 0x78, 0xee, 0x90, 0xe4, 0x08, 0xf4, 0x19, 0xda, 0x89, 0xcf, 0xa3, 0x38, 0xf5,
-	0xf2, 0x16, 0x1f, 0xff, 0xba, 0x45, 0x4b, 0x01, 0xfb, 0xaa, 0xd1, 0x27, 0x30, 0x92, 0x88, 0xaf,
-	0xa8, 0x10, 0x94, 0xc5, 0x02, 0x9f, 0xc9, 0x36, 0xaf, 0x1e, 0x6f, 0xd3, 0x1f, 0x4c, 0x48, 0x19,
-	0x61, 0x7d, 0xd8, 0xec, 0x40, 0xed, 0x71, 0xa3, 0xb9, 0x56, 0xd2, 0xfc, 0xc1, 0x1d, 0x5a, 0x0e,
-	0x34, 0x0a, 0x56, 0x0f, 0xa2, 0xd4, 0x36, 0xab, 0xc5, 0x36, 0xad, 0x1f, 0x3b, 0xa0, 0xf7, 0x07,
-	0x93, 0xdf, 0xb6, 0xfc, 0x05, 0x8c, 0x58, 0x51, 0xf0, 0x68, 0x28, 0xe4, 0x62, 0x8d, 0x9e, 0xfd,
-	0xe4, 0x0c, 0xc5, 0x55, 0xba, 0x43, 0x31, 0xae, 0x10, 0xc8, 0xe1, 0x6e, 0x28, 0xd0, 0x0c, 0x3a,
-	0x22, 0x60, 0x49, 0xe4, 0xdd, 0xd3, 0x65, 0x18, 0xf8, 0x3c, 0x94, 0xd7, 0xd0, 0xe9, 0xbd, 0x79,
-	0xba, 0xdf, 0x3c, 0xaf, 0x1e, 0x57, 0x48, 0x5b, 0xe2, 0x8b, 0x00, 0x1a, 0x03, 0x6c, 0xf5, 0x92,
-	0x87, 0xd3, 0xf9, 0x13, 0xb9, 0x8b, 0x4d, 0x3d, 0x29, 0x61, 0xd1, 0x6b, 0x30, 0x22, 0xa9, 0x97,
-	0x27, 0xa5, 0xca, 0xee, 0xac, 0x39, 0xd6, 0x08, 0xa8, 0xe0, 0x65, 0x26, 0xd9, 0x39, 0xb4, 0xb3,
-	0xdc, 0x96, 0xfc, 0xe1, 0x3f, 0x91, 0xd7, 0x48, 0x2b, 0x83, 0x6f, 0xb8, 0xbf, 0x84, 0x26, 0x0d,
-	0xbd, 0x6b, 0xba, 0x4c, 0x23, 0x8e, 0xed, 0xae, 0x6e, 0x37, 0x49, 0x83, 0x86, 0x67, 0xd2, 0xdf,
-	0x3f, 0x00, 0xd8, 0xaa, 0x98, 0xfd, 0x71, 0x33, 0xf1, 0x35, 0x59, 0x94, 0x99, 0xd6, 0x7b, 0x80,
-	0xed, 0x20, 0xc8, 0x80, 0xfa, 0x74, 0xe6, 0x5d, 0x8c, 0xc8, 0xb9, 0x59, 0x41, 0x0d, 0xd8, 0x21,
-	0xa3, 0xfe, 0xd0, 0xd4, 0x50, 0x13, 0x76, 0xe7, 0xc4, 0xbd, 0x1c, 0x99, 0x55, 0x54, 0x07, 0x7d,
-	0x36, 0x9f, 0x9a, 0xba, 0xf5, 0x16, 0x1a, 0x1b, 0x06, 0xcf, 0xc0, 0x98, 0xce, 0xbc, 0xb9, 0x3b,
-	0x19, 0x0e, 0xfa, 0x64, 0x68, 0x56, 0x90, 0x09, 0xad, 0xc2, 0xf3, 0xfa, 0x93, 0x89, 0xa9, 0x9d,
-	0xd6, 0x61, 0x57, 0x2a, 0x7e, 0x5a, 0x53, 0x37, 0x74, 0xda, 0xf8, 0x5a, 0x93, 0x1f, 0x3e, 0x71,
-	0xa5, 0x7e, 0xdf, 0xfd, 0x0a, 0x00, 0x00, 0xff, 0xff, 0xf7, 0x92, 0x8d, 0x69, 0x4b, 0x05, 0x00,
-	0x00,
+func init() { proto.RegisterFile("configurator.proto", fileDescriptor_configurator_cc16500d6f6a797c) }
+
+var fileDescriptor_configurator_cc16500d6f6a797c = []byte{
+	// 642 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x4d, 0x6f, 0xda, 0x40,
+	0x10, 0xc5, 0x98, 0xf0, 0x31, 0x06, 0x6a, 0xad, 0xa2, 0xca, 0x4d, 0xd5, 0x84, 0xfa, 0x50, 0xf9,
+	0x52, 0x47, 0xa2, 0x87, 0xa6, 0x51, 0xa5, 0x8a, 0x00, 0x29, 0x56, 0x09, 0x44, 0xab, 0x48, 0x48,
+	0xbd, 0x58, 0x8e, 0xbd, 0x21, 0xab, 0x80, 0x6d, 0xed, 0x3a, 0x89, 0xfc, 0x5f, 0x7a, 0xed, 0xef,
+	0xeb, 0x5f, 0xa8, 0xbc, 0x6b, 0x13, 0x57, 0x0d, 0xe9, 0xc7, 0x89, 0x9d, 0xd9, 0x79, 0xb3, 0x6f,
+	0xde, 0x1b, 0x0c, 0xc8, 0x8f, 0xc2, 0x2b, 0xba, 0xbc, 0x65, 0x5e, 0x12, 0x31, 0x3b, 0x66, 0x51,
+	0x12, 0x21, 0x63, 0xed, 0x2d, 0xd7, 0x9e, 0x1d, 0x31, 0xff, 0x88, 0xd9, 0xe5, 0xfb, 0xbd, 0x17,
+	0xcb, 0x28, 0x5a, 0xae, 0xc8, 0xa1, 0xa8, 0xbb, 0xbc, 0xbd, 0x3a, 0xf4, 0xc2, 0x54, 0x82, 0xcc,
+	0x1f, 0x0a, 0x34, 0x66, 0x24, 0xb9, 0x8f, 0xd8, 0x0d, 0xea, 0x42, 0x95, 0x06, 0x86, 0xd2, 0x53,
+	0xac, 0x16, 0xae, 0xd2, 0x00, 0x21, 0xa8, 0x85, 0xde, 0x9a, 0x18, 0x20, 0x32, 0xe2, 0x8c, 0x7a,
+	0xa0, 0x05, 0x84, 0xfb, 0x8c, 0xc6, 0x09, 0x8d, 0x42, 0x43, 0x13, 0x57, 0xe5, 0x14, 0x9a, 0x40,
+	0x43, 0x3e, 0xce, 0x8d, 0xdd, 0x9e, 0x6a, 0x69, 0x7d, 0xdb, 0xde, 0x46, 0xcc, 0xce, 0x5f, 0xb6,
+	0x87, 0x12, 0x30, 0x0e, 0x13, 0x96, 0xe2, 0x02, 0x8e, 0x0c, 0x68, 0xdc, 0x11, 0xc6, 0xb3, 0x77,
+	0xf6, 0x7b, 0x8a, 0x55, 0xc3, 0x45, 0xb8, 0x77, 0x0c, 0xed, 0x32, 0x04, 0xe9, 0xa0, 0xde, 0x90,
+	0x34, 0xa7, 0x9e, 0x1d, 0xd1, 0x2e, 0xec, 0xdc, 0x79, 0xab, 0x5b, 0x62, 0x54, 0x7b, 0x8a, 0xd5,
+	0xc6, 0x32, 0x38, 0xae, 0x1e, 0x29, 0xe6, 0x37, 0x15, 0x3a, 0xf9, 0xbb, 0xe3, 0x30, 0xa1, 0x49,
+	0xfa, 0xd8, 0xdc, 0x49, 0x1a, 0x4b, 0x68, 0x0b, 0x8b, 0xf3, 0x7f, 0x6a, 0x71, 0x00, 0x5a, 0x7c,
+	0x9d, 0x72, 0xea, 0x7b, 0x2b, 0x97, 0x06, 0xc6, 0xae, 0xa8, 0x80, 0x22, 0xe5, 0x04, 0xe8, 0x39,
+	0xd4, 0xe5, 0xb4, 0x62, 0xc2, 0x36, 0xce, 0xa3, 0x6c, 0xf4, 0x25, 0xf3, 0xe2, 0x6b, 0x67, 0x64,
+	0x58, 0x02, 0x54, 0x84, 0xe8, 0x18, 0xea, 0x1e, 0xe7, 0x91, 0xcf, 0x8d, 0xbe, 0x50, 0xd7, 0xdc,
+	0xae, 0xae, 0x1c, 0xcf, 0x19, 0xe1, 0x1c, 0x81, 0x3e, 0x43, 0x27, 0xf6, 0x18, 0x09, 0x13, 0x37,
+	0x6f, 0xf1, 0xf1, 0xaf, 0x5b, 0xb4, 0x25, 0x70, 0x20, 0x1b, 0x7d, 0x02, 0x2d, 0x26, 0x6c, 0x4d,
+	0x79, 0xe6, 0x06, 0x37, 0x4e, 0x45, 0x9b, 0x57, 0xdb, 0xdb, 0x0c, 0x86, 0x53, 0x5c, 0x46, 0x94,
+	0xad, 0x3d, 0xff, 0xc5, 0x5a, 0xf3, 0xc3, 0xc6, 0x1d, 0xe9, 0xf0, 0xc6, 0x0d, 0xa5, 0xe4, 0xc6,
+	0xa3, 0xee, 0x9a, 0x36, 0x34, 0x0b, 0xbe, 0x8f, 0xa2, 0xa4, 0xcf, 0xd5, 0xc2, 0x67, 0xf3, 0x7b,
+	0x0d, 0xd4, 0xc1, 0x70, 0xfa, 0x9b, 0xff, 0x5f, 0x40, 0x0b, 0x25, 0x05, 0x97, 0x06, 0x5c, 0x58,
+	0xae, 0xf5, 0xad, 0x27, 0xa7, 0x2b, 0x36, 0xd9, 0x19, 0xf1, 0x49, 0x05, 0x43, 0x0e, 0x77, 0x02,
+	0x8e, 0xe6, 0xd0, 0xe5, 0x7e, 0x14, 0x13, 0xf7, 0x9e, 0xae, 0x02, 0xdf, 0x63, 0x81, 0xd8, 0x93,
+	0x6e, 0xff, 0xcd, 0xd3, 0xfd, 0x16, 0x79, 0xf5, 0xa4, 0x82, 0x3b, 0x02, 0x5f, 0x24, 0xd0, 0x04,
+	0xe0, 0x41, 0x49, 0xb1, 0x52, 0xdd, 0x3f, 0x91, 0x3b, 0xdf, 0xd4, 0xe3, 0x12, 0x16, 0xbd, 0x06,
+	0x8d, 0x08, 0xbd, 0x5c, 0x21, 0x55, 0xb6, 0x81, 0xad, 0x89, 0x82, 0x41, 0x26, 0x2f, 0x32, 0xc9,
+	0xce, 0xa0, 0x93, 0xa4, 0x65, 0xf2, 0x07, 0xff, 0x44, 0x5e, 0xc1, 0xed, 0x0c, 0xbe, 0xe1, 0xfe,
+	0x12, 0x5a, 0x34, 0x70, 0xaf, 0xe8, 0x2a, 0x21, 0xcc, 0xb0, 0x7a, 0xaa, 0xd5, 0xc2, 0x4d, 0x1a,
+	0x9c, 0x8a, 0x78, 0x6f, 0x1f, 0xe0, 0x41, 0xc5, 0xec, 0x2f, 0x9d, 0x89, 0xaf, 0x88, 0xa2, 0xec,
+	0x68, 0xbe, 0x07, 0x78, 0x18, 0x04, 0x69, 0xd0, 0x98, 0xcd, 0xdd, 0xf3, 0x31, 0x3e, 0xd3, 0x2b,
+	0xa8, 0x09, 0x35, 0x3c, 0x1e, 0x8c, 0x74, 0x05, 0xb5, 0x60, 0x67, 0x81, 0x9d, 0x8b, 0xb1, 0x5e,
+	0x45, 0x0d, 0x50, 0xe7, 0x8b, 0x99, 0xae, 0x9a, 0x6f, 0xa1, 0xb9, 0x61, 0xf0, 0x0c, 0xb4, 0xd9,
+	0xdc, 0x5d, 0x38, 0xd3, 0xd1, 0x70, 0x80, 0x47, 0x7a, 0x05, 0xe9, 0xd0, 0x2e, 0x22, 0x77, 0x30,
+	0x9d, 0xea, 0xca, 0x49, 0x03, 0x76, 0x84, 0xe2, 0x27, 0x75, 0xb9, 0x43, 0x27, 0xcd, 0xaf, 0x75,
+	0xf1, 0xb1, 0xe4, 0x97, 0xf2, 0xf7, 0xdd, 0xcf, 0x01, 0x00, 0xc0, 0x5e, 0x50, 0xa8, 0x7f, 0x05,
+	0x00, 0x00,
 }
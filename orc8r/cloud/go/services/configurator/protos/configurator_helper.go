@@ -22,6 +22,7 @@ func (network *Network) ToNetwork() storage.Network {
 		Name:        network.Name,
 		Description: network.Description,
 		Configs:     network.Configs,
+		Version:     network.Version,
 	}
 }
 
@@ -38,6 +39,7 @@ func (entity *NetworkEntity) ToNetworkEntity() storage.NetworkEntity {
 		Associations:       ToTypeAndKeys(entity.Assocs),
 		ParentAssociations: ToTypeAndKeys(entity.ParentAssocs),
 		Permissions:        toStorageACLs(entity.Permissions),
+		Version:            entity.Version,
 	}
 }
 
@@ -78,15 +80,45 @@ func (criteria *NetworkLoadCriteria) ToNetworkLoadCriteria() storage.NetworkLoad
 }
 
 // ToEntityLoadFilter translates protobuf struct to corresponding storage struct
-func ToEntityLoadFilter(typeFilter *wrappers.StringValue, keyFilter *wrappers.StringValue, ids []*EntityID) storage.EntityLoadFilter {
+func (req *LoadEntitiesRequest) ToEntityLoadFilter() storage.EntityLoadFilter {
 	entityLoadFilter := storage.EntityLoadFilter{
-		TypeFilter: getStringPointer(typeFilter),
-		KeyFilter:  getStringPointer(keyFilter),
-		IDs:        ToTypeAndKeys(ids),
+		TypeFilter: getStringPointer(req.TypeFilter),
+		KeyFilter:  getStringPointer(req.KeyFilter),
+		KeyPrefix:  getStringPointer(req.KeyPrefix),
+		PhysicalID: getStringPointer(req.PhysicalID),
+		IDs:        ToTypeAndKeys(req.EntityIDs),
+		PageSize:   req.PageSize,
+		PageToken:  req.PageToken,
 	}
 	return entityLoadFilter
 }
 
+// ToAuditEntryFilter translates protobuf struct to corresponding storage struct
+func (req *QueryAuditLogRequest) ToAuditEntryFilter() storage.AuditEntryFilter {
+	filter := storage.AuditEntryFilter{
+		NetworkID: req.NetworkID,
+		Actor:     getStringPointer(req.Actor),
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Limit:     req.Limit,
+	}
+	if req.Entity != nil {
+		entityID := req.Entity.ToTypeAndKey()
+		filter.Entity = &entityID
+	}
+	return filter
+}
+
+// ToGraphTraversal translates protobuf struct to corresponding storage struct
+func (req *TraverseGraphRequest) ToGraphTraversal() storage.GraphTraversal {
+	return storage.GraphTraversal{
+		Start:     req.Start.ToTypeAndKey(),
+		Direction: storage.TraversalDirection(req.Direction),
+		HopTypes:  req.HopTypes,
+		MaxDepth:  req.MaxDepth,
+	}
+}
+
 // ToNetworkUpdateCriteria translates protobuf struct to corresponding storage struct
 func (criteria *NetworkUpdateCriteria) ToNetworkUpdateCriteria() storage.NetworkUpdateCriteria {
 	return storage.NetworkUpdateCriteria{
@@ -96,6 +128,7 @@ func (criteria *NetworkUpdateCriteria) ToNetworkUpdateCriteria() storage.Network
 		NewDescription:       getStringPointer(criteria.NewDescription),
 		ConfigsToAddOrUpdate: criteria.ConfigsToAddOrUpdate,
 		ConfigsToDelete:      criteria.ConfigsToDelete,
+		ExpectedVersion:      getUInt64Pointer(criteria.ExpectedVersion),
 	}
 }
 
@@ -104,6 +137,7 @@ func (criteria *EntityUpdateCriteria) ToEntityUpdateCriteria() storage.EntityUpd
 	return storage.EntityUpdateCriteria{
 		Type:                 criteria.Type,
 		Key:                  criteria.Key,
+		DeleteEntity:         criteria.DeleteEntity,
 		NewName:              getStringPointer(criteria.NewName),
 		NewDescription:       getStringPointer(criteria.NewDescription),
 		NewPhysicalID:        getStringPointer(criteria.NewPhysicalID),
@@ -113,6 +147,7 @@ func (criteria *EntityUpdateCriteria) ToEntityUpdateCriteria() storage.EntityUpd
 		PermissionsToCreate:  toStorageACLs(criteria.PermissionsToCreate),
 		PermissionsToUpdate:  toStorageACLs(criteria.PermissionsToUpdate),
 		PermissionsToDelete:  criteria.PermissionsToDelete,
+		ExpectedVersion:      getUInt64Pointer(criteria.ExpectedVersion),
 	}
 }
 
@@ -123,6 +158,7 @@ func FromStorageNetwork(network storage.Network) *Network {
 		Name:        network.Name,
 		Description: network.Description,
 		Configs:     network.Configs,
+		Version:     network.Version,
 	}
 }
 
@@ -148,6 +184,7 @@ func FromStorageNetworkEntity(entity storage.NetworkEntity) *NetworkEntity {
 		Assocs:       FromTKs(entity.Associations),
 		ParentAssocs: FromTKs(entity.ParentAssociations),
 		Permissions:  fromStorageACLs(entity.Permissions),
+		Version:      entity.Version,
 	}
 }
 
@@ -160,6 +197,66 @@ func FromStorageNetworkEntities(entities []storage.NetworkEntity) []*NetworkEnti
 	return pEntities
 }
 
+// FromStorageChange translates storage struct to corresponding protobuf struct
+func FromStorageChange(change storage.Change) *Change {
+	pChange := &Change{
+		NetworkID: change.NetworkID,
+		Sequence:  change.Sequence,
+		Operation: Change_Operation(change.Operation),
+	}
+	if change.Entity != nil {
+		pChange.Entity = &EntityID{Type: change.Entity.Type, Id: change.Entity.Key}
+	}
+	return pChange
+}
+
+// FromStorageGraphEdges translates storage struct to corresponding protobuf struct
+func FromStorageGraphEdges(edges []storage.GraphEdge) []*GraphEdge {
+	pEdges := []*GraphEdge{}
+	for _, edge := range edges {
+		pEdges = append(pEdges, &GraphEdge{
+			From: &EntityID{Type: edge.From.Type, Id: edge.From.Key},
+			To:   &EntityID{Type: edge.To.Type, Id: edge.To.Key},
+		})
+	}
+	return pEdges
+}
+
+// FromStorageTombstones translates storage struct to corresponding protobuf struct
+func FromStorageTombstones(tombstones []storage.Tombstone) []*Tombstone {
+	pTombstones := []*Tombstone{}
+	for _, tombstone := range tombstones {
+		pTombstones = append(pTombstones, &Tombstone{
+			Entity:    FromStorageNetworkEntity(tombstone.Entity),
+			DeletedAt: tombstone.DeletedAt,
+		})
+	}
+	return pTombstones
+}
+
+// FromStorageAuditEntries translates storage struct to corresponding protobuf struct
+func FromStorageAuditEntries(entries []storage.AuditEntry) []*AuditEntry {
+	pEntries := []*AuditEntry{}
+	for _, entry := range entries {
+		pEntry := &AuditEntry{
+			Id:        entry.ID,
+			NetworkID: entry.NetworkID,
+			Operation: Change_Operation(entry.Operation),
+			Actor:     entry.Actor,
+			Timestamp: entry.Timestamp,
+			Diffs:     []*AuditFieldDiff{},
+		}
+		if entry.Entity != nil {
+			pEntry.Entity = &EntityID{Type: entry.Entity.Type, Id: entry.Entity.Key}
+		}
+		for _, diff := range entry.Diffs {
+			pEntry.Diffs = append(pEntry.Diffs, &AuditFieldDiff{Field: diff.Field, Before: diff.Before, After: diff.After})
+		}
+		pEntries = append(pEntries, pEntry)
+	}
+	return pEntries
+}
+
 // GetStringWrapper wraps a pointer string value into protobuf StringValue
 func GetStringWrapper(pStr *string) *wrappers.StringValue {
 	if pStr == nil {
@@ -176,6 +273,14 @@ func GetBytesWrapper(bytes []byte) *wrappers.BytesValue {
 	return &wrappers.BytesValue{Value: bytes}
 }
 
+// GetUInt64Wrapper wraps a pointer uint64 value into protobuf UInt64Value
+func GetUInt64Wrapper(pVal *uint64) *wrappers.UInt64Value {
+	if pVal == nil {
+		return nil
+	}
+	return &wrappers.UInt64Value{Value: *pVal}
+}
+
 func (acl *ACL) toACL() storage.ACL {
 	return storage.ACL{
 		ID:         acl.Id,
@@ -252,3 +357,10 @@ func getBytesPointer(bytesWrapper *wrappers.BytesValue) *[]byte {
 	}
 	return nil
 }
+
+func getUInt64Pointer(uint64Wrapper *wrappers.UInt64Value) *uint64 {
+	if uint64Wrapper != nil {
+		return &(uint64Wrapper.Value)
+	}
+	return nil
+}
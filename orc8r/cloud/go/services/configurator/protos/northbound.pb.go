@@ -26,6 +26,57 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type Change_Operation int32
+
+const (
+	Change_CREATE Change_Operation = 0
+	Change_UPDATE Change_Operation = 1
+	Change_DELETE Change_Operation = 2
+)
+
+var Change_Operation_name = map[int32]string{
+	0: "CREATE",
+	1: "UPDATE",
+	2: "DELETE",
+}
+var Change_Operation_value = map[string]int32{
+	"CREATE": 0,
+	"UPDATE": 1,
+	"DELETE": 2,
+}
+
+func (x Change_Operation) String() string {
+	return proto.EnumName(Change_Operation_name, int32(x))
+}
+func (Change_Operation) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{21, 0}
+}
+
+type TraverseGraphRequest_Direction int32
+
+const (
+	// Follow associations from each visited entity
+	TraverseGraphRequest_ASSOCS_FROM TraverseGraphRequest_Direction = 0
+	// Follow associations to each visited entity
+	TraverseGraphRequest_ASSOCS_TO TraverseGraphRequest_Direction = 1
+)
+
+var TraverseGraphRequest_Direction_name = map[int32]string{
+	0: "ASSOCS_FROM",
+	1: "ASSOCS_TO",
+}
+var TraverseGraphRequest_Direction_value = map[string]int32{
+	"ASSOCS_FROM": 0,
+	"ASSOCS_TO":   1,
+}
+
+func (x TraverseGraphRequest_Direction) String() string {
+	return proto.EnumName(TraverseGraphRequest_Direction_name, int32(x))
+}
+func (TraverseGraphRequest_Direction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{30, 0}
+}
+
 type ListNetworkIDsResponse struct {
 	NetworkIDs           []string `protobuf:"bytes,1,rep,name=networkIDs,proto3" json:"networkIDs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -37,7 +88,7 @@ func (m *ListNetworkIDsResponse) Reset()         { *m = ListNetworkIDsResponse{}
 func (m *ListNetworkIDsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListNetworkIDsResponse) ProtoMessage()    {}
 func (*ListNetworkIDsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{0}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{0}
 }
 func (m *ListNetworkIDsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListNetworkIDsResponse.Unmarshal(m, b)
@@ -75,7 +126,7 @@ func (m *CreateNetworksRequest) Reset()         { *m = CreateNetworksRequest{} }
 func (m *CreateNetworksRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateNetworksRequest) ProtoMessage()    {}
 func (*CreateNetworksRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{1}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{1}
 }
 func (m *CreateNetworksRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateNetworksRequest.Unmarshal(m, b)
@@ -113,7 +164,7 @@ func (m *CreateNetworksResponse) Reset()         { *m = CreateNetworksResponse{}
 func (m *CreateNetworksResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateNetworksResponse) ProtoMessage()    {}
 func (*CreateNetworksResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{2}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{2}
 }
 func (m *CreateNetworksResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateNetworksResponse.Unmarshal(m, b)
@@ -147,6 +198,9 @@ type NetworkUpdateCriteria struct {
 	NewDescription       *wrappers.StringValue `protobuf:"bytes,11,opt,name=newDescription,proto3" json:"newDescription,omitempty"`
 	ConfigsToAddOrUpdate map[string][]byte     `protobuf:"bytes,20,rep,name=configsToAddOrUpdate,proto3" json:"configsToAddOrUpdate,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	ConfigsToDelete      []string              `protobuf:"bytes,21,rep,name=configsToDelete,proto3" json:"configsToDelete,omitempty"`
+	// If set, the update will only be applied if the network is still at
+	// this version
+	ExpectedVersion      *wrappers.UInt64Value `protobuf:"bytes,30,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -156,7 +210,7 @@ func (m *NetworkUpdateCriteria) Reset()         { *m = NetworkUpdateCriteria{} }
 func (m *NetworkUpdateCriteria) String() string { return proto.CompactTextString(m) }
 func (*NetworkUpdateCriteria) ProtoMessage()    {}
 func (*NetworkUpdateCriteria) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{3}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{3}
 }
 func (m *NetworkUpdateCriteria) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkUpdateCriteria.Unmarshal(m, b)
@@ -211,6 +265,13 @@ func (m *NetworkUpdateCriteria) GetConfigsToDelete() []string {
 	return nil
 }
 
+func (m *NetworkUpdateCriteria) GetExpectedVersion() *wrappers.UInt64Value {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return nil
+}
+
 type UpdateNetworksRequest struct {
 	Updates              []*NetworkUpdateCriteria `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
@@ -222,7 +283,7 @@ func (m *UpdateNetworksRequest) Reset()         { *m = UpdateNetworksRequest{} }
 func (m *UpdateNetworksRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateNetworksRequest) ProtoMessage()    {}
 func (*UpdateNetworksRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{4}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{4}
 }
 func (m *UpdateNetworksRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateNetworksRequest.Unmarshal(m, b)
@@ -261,7 +322,7 @@ func (m *NetworkLoadCriteria) Reset()         { *m = NetworkLoadCriteria{} }
 func (m *NetworkLoadCriteria) String() string { return proto.CompactTextString(m) }
 func (*NetworkLoadCriteria) ProtoMessage()    {}
 func (*NetworkLoadCriteria) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{5}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{5}
 }
 func (m *NetworkLoadCriteria) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkLoadCriteria.Unmarshal(m, b)
@@ -307,7 +368,7 @@ func (m *LoadNetworksRequest) Reset()         { *m = LoadNetworksRequest{} }
 func (m *LoadNetworksRequest) String() string { return proto.CompactTextString(m) }
 func (*LoadNetworksRequest) ProtoMessage()    {}
 func (*LoadNetworksRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{6}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{6}
 }
 func (m *LoadNetworksRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LoadNetworksRequest.Unmarshal(m, b)
@@ -353,7 +414,7 @@ func (m *LoadNetworksResponse) Reset()         { *m = LoadNetworksResponse{} }
 func (m *LoadNetworksResponse) String() string { return proto.CompactTextString(m) }
 func (*LoadNetworksResponse) ProtoMessage()    {}
 func (*LoadNetworksResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{7}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{7}
 }
 func (m *LoadNetworksResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LoadNetworksResponse.Unmarshal(m, b)
@@ -398,7 +459,7 @@ func (m *DeleteNetworksRequest) Reset()         { *m = DeleteNetworksRequest{} }
 func (m *DeleteNetworksRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteNetworksRequest) ProtoMessage()    {}
 func (*DeleteNetworksRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{8}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{8}
 }
 func (m *DeleteNetworksRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteNetworksRequest.Unmarshal(m, b)
@@ -437,7 +498,7 @@ func (m *CreateEntitiesRequest) Reset()         { *m = CreateEntitiesRequest{} }
 func (m *CreateEntitiesRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateEntitiesRequest) ProtoMessage()    {}
 func (*CreateEntitiesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{9}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{9}
 }
 func (m *CreateEntitiesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateEntitiesRequest.Unmarshal(m, b)
@@ -482,7 +543,7 @@ func (m *CreateEntitiesResponse) Reset()         { *m = CreateEntitiesResponse{}
 func (m *CreateEntitiesResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateEntitiesResponse) ProtoMessage()    {}
 func (*CreateEntitiesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{10}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{10}
 }
 func (m *CreateEntitiesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateEntitiesResponse.Unmarshal(m, b)
@@ -510,8 +571,11 @@ func (m *CreateEntitiesResponse) GetCreatedEntities() []*NetworkEntity {
 }
 
 type EntityUpdateCriteria struct {
-	Key                  string                `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Type                 string                `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Key  string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// If set, the entity is deleted and the other fields except
+	// expectedVersion are ignored
+	DeleteEntity         bool                  `protobuf:"varint,3,opt,name=deleteEntity,proto3" json:"deleteEntity,omitempty"`
 	NewName              *wrappers.StringValue `protobuf:"bytes,10,opt,name=newName,proto3" json:"newName,omitempty"`
 	NewDescription       *wrappers.StringValue `protobuf:"bytes,11,opt,name=newDescription,proto3" json:"newDescription,omitempty"`
 	NewPhysicalID        *wrappers.StringValue `protobuf:"bytes,12,opt,name=newPhysicalID,proto3" json:"newPhysicalID,omitempty"`
@@ -521,6 +585,9 @@ type EntityUpdateCriteria struct {
 	PermissionsToCreate  []*ACL                `protobuf:"bytes,30,rep,name=permissionsToCreate,proto3" json:"permissionsToCreate,omitempty"`
 	PermissionsToUpdate  []*ACL                `protobuf:"bytes,31,rep,name=permissionsToUpdate,proto3" json:"permissionsToUpdate,omitempty"`
 	PermissionsToDelete  []string              `protobuf:"bytes,32,rep,name=permissionsToDelete,proto3" json:"permissionsToDelete,omitempty"`
+	// If set, the update will only be applied if the entity is still at
+	// this version
+	ExpectedVersion      *wrappers.UInt64Value `protobuf:"bytes,40,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -530,7 +597,7 @@ func (m *EntityUpdateCriteria) Reset()         { *m = EntityUpdateCriteria{} }
 func (m *EntityUpdateCriteria) String() string { return proto.CompactTextString(m) }
 func (*EntityUpdateCriteria) ProtoMessage()    {}
 func (*EntityUpdateCriteria) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{11}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{11}
 }
 func (m *EntityUpdateCriteria) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EntityUpdateCriteria.Unmarshal(m, b)
@@ -564,6 +631,13 @@ func (m *EntityUpdateCriteria) GetType() string {
 	return ""
 }
 
+func (m *EntityUpdateCriteria) GetDeleteEntity() bool {
+	if m != nil {
+		return m.DeleteEntity
+	}
+	return false
+}
+
 func (m *EntityUpdateCriteria) GetNewName() *wrappers.StringValue {
 	if m != nil {
 		return m.NewName
@@ -627,6 +701,13 @@ func (m *EntityUpdateCriteria) GetPermissionsToDelete() []string {
 	return nil
 }
 
+func (m *EntityUpdateCriteria) GetExpectedVersion() *wrappers.UInt64Value {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return nil
+}
+
 type UpdateEntitiesRequest struct {
 	NetworkID            string                  `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
 	Updates              []*EntityUpdateCriteria `protobuf:"bytes,2,rep,name=updates,proto3" json:"updates,omitempty"`
@@ -639,7 +720,7 @@ func (m *UpdateEntitiesRequest) Reset()         { *m = UpdateEntitiesRequest{} }
 func (m *UpdateEntitiesRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateEntitiesRequest) ProtoMessage()    {}
 func (*UpdateEntitiesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{12}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{12}
 }
 func (m *UpdateEntitiesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateEntitiesRequest.Unmarshal(m, b)
@@ -684,7 +765,7 @@ func (m *UpdateEntitiesResponse) Reset()         { *m = UpdateEntitiesResponse{}
 func (m *UpdateEntitiesResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateEntitiesResponse) ProtoMessage()    {}
 func (*UpdateEntitiesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{13}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{13}
 }
 func (m *UpdateEntitiesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateEntitiesResponse.Unmarshal(m, b)
@@ -723,7 +804,7 @@ func (m *DeleteEntitiesRequest) Reset()         { *m = DeleteEntitiesRequest{} }
 func (m *DeleteEntitiesRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteEntitiesRequest) ProtoMessage()    {}
 func (*DeleteEntitiesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{14}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{14}
 }
 func (m *DeleteEntitiesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteEntitiesRequest.Unmarshal(m, b)
@@ -772,7 +853,7 @@ func (m *EntityLoadCriteria) Reset()         { *m = EntityLoadCriteria{} }
 func (m *EntityLoadCriteria) String() string { return proto.CompactTextString(m) }
 func (*EntityLoadCriteria) ProtoMessage()    {}
 func (*EntityLoadCriteria) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{15}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{15}
 }
 func (m *EntityLoadCriteria) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EntityLoadCriteria.Unmarshal(m, b)
@@ -828,21 +909,32 @@ func (m *EntityLoadCriteria) GetLoadPermissions() bool {
 }
 
 type LoadEntitiesRequest struct {
-	NetworkID            string                `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
-	TypeFilter           *wrappers.StringValue `protobuf:"bytes,2,opt,name=TypeFilter,proto3" json:"TypeFilter,omitempty"`
-	KeyFilter            *wrappers.StringValue `protobuf:"bytes,3,opt,name=KeyFilter,proto3" json:"KeyFilter,omitempty"`
-	EntityIDs            []*EntityID           `protobuf:"bytes,4,rep,name=entityIDs,proto3" json:"entityIDs,omitempty"`
-	Criteria             *EntityLoadCriteria   `protobuf:"bytes,5,opt,name=criteria,proto3" json:"criteria,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	NetworkID  string                `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	TypeFilter *wrappers.StringValue `protobuf:"bytes,2,opt,name=TypeFilter,proto3" json:"TypeFilter,omitempty"`
+	KeyFilter  *wrappers.StringValue `protobuf:"bytes,3,opt,name=KeyFilter,proto3" json:"KeyFilter,omitempty"`
+	EntityIDs  []*EntityID           `protobuf:"bytes,4,rep,name=entityIDs,proto3" json:"entityIDs,omitempty"`
+	Criteria   *EntityLoadCriteria   `protobuf:"bytes,5,opt,name=criteria,proto3" json:"criteria,omitempty"`
+	KeyPrefix  *wrappers.StringValue `protobuf:"bytes,6,opt,name=keyPrefix,proto3" json:"keyPrefix,omitempty"`
+	PhysicalID *wrappers.StringValue `protobuf:"bytes,7,opt,name=physicalID,proto3" json:"physicalID,omitempty"`
+	// If pageSize is non-zero, at most pageSize entities ordered by
+	// (type, key) will be returned. Ignored if entityIDs is provided.
+	PageSize uint32 `protobuf:"varint,8,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// nextPageToken from a previous response
+	PageToken string `protobuf:"bytes,9,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// If loadTombstones is true, the tombstones of deleted entities which
+	// match the type, key, key prefix, or ID filters are also returned.
+	// Tombstones are never paginated.
+	LoadTombstones       bool     `protobuf:"varint,10,opt,name=loadTombstones,proto3" json:"loadTombstones,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *LoadEntitiesRequest) Reset()         { *m = LoadEntitiesRequest{} }
 func (m *LoadEntitiesRequest) String() string { return proto.CompactTextString(m) }
 func (*LoadEntitiesRequest) ProtoMessage()    {}
 func (*LoadEntitiesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{16}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{16}
 }
 func (m *LoadEntitiesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LoadEntitiesRequest.Unmarshal(m, b)
@@ -897,19 +989,58 @@ func (m *LoadEntitiesRequest) GetCriteria() *EntityLoadCriteria {
 	return nil
 }
 
+func (m *LoadEntitiesRequest) GetKeyPrefix() *wrappers.StringValue {
+	if m != nil {
+		return m.KeyPrefix
+	}
+	return nil
+}
+
+func (m *LoadEntitiesRequest) GetPhysicalID() *wrappers.StringValue {
+	if m != nil {
+		return m.PhysicalID
+	}
+	return nil
+}
+
+func (m *LoadEntitiesRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *LoadEntitiesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *LoadEntitiesRequest) GetLoadTombstones() bool {
+	if m != nil {
+		return m.LoadTombstones
+	}
+	return false
+}
+
 type LoadEntitiesResponse struct {
-	Entities             []*NetworkEntity `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
-	NotFound             []*EntityID      `protobuf:"bytes,2,rep,name=notFound,proto3" json:"notFound,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Entities []*NetworkEntity `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	NotFound []*EntityID      `protobuf:"bytes,2,rep,name=notFound,proto3" json:"notFound,omitempty"`
+	// Empty if there are no more pages to load
+	NextPageToken string `protobuf:"bytes,3,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	// Only filled out if loadTombstones was set on the request
+	Tombstones           []*Tombstone `protobuf:"bytes,4,rep,name=tombstones,proto3" json:"tombstones,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *LoadEntitiesResponse) Reset()         { *m = LoadEntitiesResponse{} }
 func (m *LoadEntitiesResponse) String() string { return proto.CompactTextString(m) }
 func (*LoadEntitiesResponse) ProtoMessage()    {}
 func (*LoadEntitiesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_northbound_07d50f0696abd602, []int{17}
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{17}
 }
 func (m *LoadEntitiesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LoadEntitiesResponse.Unmarshal(m, b)
@@ -943,182 +1074,1401 @@ func (m *LoadEntitiesResponse) GetNotFound() []*EntityID {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*ListNetworkIDsResponse)(nil), "magma.orc8r.configurator.ListNetworkIDsResponse")
-	proto.RegisterType((*CreateNetworksRequest)(nil), "magma.orc8r.configurator.CreateNetworksRequest")
-	proto.RegisterType((*CreateNetworksResponse)(nil), "magma.orc8r.configurator.CreateNetworksResponse")
-	proto.RegisterType((*NetworkUpdateCriteria)(nil), "magma.orc8r.configurator.NetworkUpdateCriteria")
-	proto.RegisterMapType((map[string][]byte)(nil), "magma.orc8r.configurator.NetworkUpdateCriteria.ConfigsToAddOrUpdateEntry")
-	proto.RegisterType((*UpdateNetworksRequest)(nil), "magma.orc8r.configurator.UpdateNetworksRequest")
-	proto.RegisterType((*NetworkLoadCriteria)(nil), "magma.orc8r.configurator.NetworkLoadCriteria")
-	proto.RegisterType((*LoadNetworksRequest)(nil), "magma.orc8r.configurator.LoadNetworksRequest")
-	proto.RegisterType((*LoadNetworksResponse)(nil), "magma.orc8r.configurator.LoadNetworksResponse")
-	proto.RegisterMapType((map[string]*Network)(nil), "magma.orc8r.configurator.LoadNetworksResponse.NetworksEntry")
-	proto.RegisterType((*DeleteNetworksRequest)(nil), "magma.orc8r.configurator.DeleteNetworksRequest")
-	proto.RegisterType((*CreateEntitiesRequest)(nil), "magma.orc8r.configurator.CreateEntitiesRequest")
-	proto.RegisterType((*CreateEntitiesResponse)(nil), "magma.orc8r.configurator.CreateEntitiesResponse")
-	proto.RegisterType((*EntityUpdateCriteria)(nil), "magma.orc8r.configurator.EntityUpdateCriteria")
-	proto.RegisterType((*UpdateEntitiesRequest)(nil), "magma.orc8r.configurator.UpdateEntitiesRequest")
-	proto.RegisterType((*UpdateEntitiesResponse)(nil), "magma.orc8r.configurator.UpdateEntitiesResponse")
-	proto.RegisterMapType((map[string]*NetworkEntity)(nil), "magma.orc8r.configurator.UpdateEntitiesResponse.UpdatedEntitiesEntry")
-	proto.RegisterType((*DeleteEntitiesRequest)(nil), "magma.orc8r.configurator.DeleteEntitiesRequest")
-	proto.RegisterType((*EntityLoadCriteria)(nil), "magma.orc8r.configurator.EntityLoadCriteria")
-	proto.RegisterType((*LoadEntitiesRequest)(nil), "magma.orc8r.configurator.LoadEntitiesRequest")
-	proto.RegisterType((*LoadEntitiesResponse)(nil), "magma.orc8r.configurator.LoadEntitiesResponse")
+func (m *LoadEntitiesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// NorthboundConfiguratorClient is the client API for NorthboundConfigurator service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type NorthboundConfiguratorClient interface {
-	// ListNetworkIDs fetches the list of networkIDs registered
-	ListNetworkIDs(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*ListNetworkIDsResponse, error)
-	// CreateNetworks registers the given list of Networks and returns the created Networks
-	CreateNetworks(ctx context.Context, in *CreateNetworksRequest, opts ...grpc.CallOption) (*CreateNetworksResponse, error)
-	// UpdateNetworks updates the given list of registered Networks
-	UpdateNetworks(ctx context.Context, in *UpdateNetworksRequest, opts ...grpc.CallOption) (*protos.Void, error)
-	// DeleteNetworks deletes the given list of registered Networks
-	DeleteNetworks(ctx context.Context, in *DeleteNetworksRequest, opts ...grpc.CallOption) (*protos.Void, error)
-	// LoadNetworks fetches the set of Networks specified by the request
-	LoadNetworks(ctx context.Context, in *LoadNetworksRequest, opts ...grpc.CallOption) (*LoadNetworksResponse, error)
-	// CreateEntities registers the given list of Entities and returns the created Entities
-	CreateEntities(ctx context.Context, in *CreateEntitiesRequest, opts ...grpc.CallOption) (*CreateEntitiesResponse, error)
-	// UpdateEntities updates the given list of registered Entities and returns the updated Entities
-	UpdateEntities(ctx context.Context, in *UpdateEntitiesRequest, opts ...grpc.CallOption) (*UpdateEntitiesResponse, error)
-	// DeleteEntities deletes the set of Entities registered
-	DeleteEntities(ctx context.Context, in *DeleteEntitiesRequest, opts ...grpc.CallOption) (*protos.Void, error)
-	// LoadEntities fetches the set of Entities specified by the request
-	LoadEntities(ctx context.Context, in *LoadEntitiesRequest, opts ...grpc.CallOption) (*LoadEntitiesResponse, error)
+func (m *LoadEntitiesResponse) GetTombstones() []*Tombstone {
+	if m != nil {
+		return m.Tombstones
+	}
+	return nil
 }
 
-type northboundConfiguratorClient struct {
-	cc *grpc.ClientConn
+// Tombstone is the snapshot of a deleted entity, kept until the retention
+// window passes so that the entity can be restored
+type Tombstone struct {
+	// The full entity at deletion, including associations in both directions
+	// and permissions
+	Entity *NetworkEntity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	// Unix timestamp (in seconds) of the deletion
+	DeletedAt            int64    `protobuf:"varint,2,opt,name=deletedAt,proto3" json:"deletedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func NewNorthboundConfiguratorClient(cc *grpc.ClientConn) NorthboundConfiguratorClient {
-	return &northboundConfiguratorClient{cc}
+func (m *Tombstone) Reset()         { *m = Tombstone{} }
+func (m *Tombstone) String() string { return proto.CompactTextString(m) }
+func (*Tombstone) ProtoMessage()    {}
+func (*Tombstone) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{18}
+}
+func (m *Tombstone) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tombstone.Unmarshal(m, b)
+}
+func (m *Tombstone) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tombstone.Marshal(b, m, deterministic)
+}
+func (dst *Tombstone) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tombstone.Merge(dst, src)
+}
+func (m *Tombstone) XXX_Size() int {
+	return xxx_messageInfo_Tombstone.Size(m)
+}
+func (m *Tombstone) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tombstone.DiscardUnknown(m)
 }
 
-func (c *northboundConfiguratorClient) ListNetworkIDs(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*ListNetworkIDsResponse, error) {
-	out := new(ListNetworkIDsResponse)
-	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/ListNetworkIDs", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Tombstone proto.InternalMessageInfo
+
+func (m *Tombstone) GetEntity() *NetworkEntity {
+	if m != nil {
+		return m.Entity
 	}
-	return out, nil
+	return nil
 }
 
-func (c *northboundConfiguratorClient) CreateNetworks(ctx context.Context, in *CreateNetworksRequest, opts ...grpc.CallOption) (*CreateNetworksResponse, error) {
-	out := new(CreateNetworksResponse)
-	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/CreateNetworks", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Tombstone) GetDeletedAt() int64 {
+	if m != nil {
+		return m.DeletedAt
 	}
-	return out, nil
+	return 0
 }
 
-func (c *northboundConfiguratorClient) UpdateNetworks(ctx context.Context, in *UpdateNetworksRequest, opts ...grpc.CallOption) (*protos.Void, error) {
-	out := new(protos.Void)
-	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/UpdateNetworks", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type RestoreEntityRequest struct {
+	NetworkID            string    `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	Id                   *EntityID `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (c *northboundConfiguratorClient) DeleteNetworks(ctx context.Context, in *DeleteNetworksRequest, opts ...grpc.CallOption) (*protos.Void, error) {
-	out := new(protos.Void)
-	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/DeleteNetworks", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *RestoreEntityRequest) Reset()         { *m = RestoreEntityRequest{} }
+func (m *RestoreEntityRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreEntityRequest) ProtoMessage()    {}
+func (*RestoreEntityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{19}
+}
+func (m *RestoreEntityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreEntityRequest.Unmarshal(m, b)
+}
+func (m *RestoreEntityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreEntityRequest.Marshal(b, m, deterministic)
+}
+func (dst *RestoreEntityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreEntityRequest.Merge(dst, src)
+}
+func (m *RestoreEntityRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreEntityRequest.Size(m)
+}
+func (m *RestoreEntityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreEntityRequest.DiscardUnknown(m)
 }
 
-func (c *northboundConfiguratorClient) LoadNetworks(ctx context.Context, in *LoadNetworksRequest, opts ...grpc.CallOption) (*LoadNetworksResponse, error) {
-	out := new(LoadNetworksResponse)
-	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/LoadNetworks", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RestoreEntityRequest proto.InternalMessageInfo
+
+func (m *RestoreEntityRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
 	}
-	return out, nil
+	return ""
 }
 
-func (c *northboundConfiguratorClient) CreateEntities(ctx context.Context, in *CreateEntitiesRequest, opts ...grpc.CallOption) (*CreateEntitiesResponse, error) {
-	out := new(CreateEntitiesResponse)
-	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/CreateEntities", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *RestoreEntityRequest) GetId() *EntityID {
+	if m != nil {
+		return m.Id
 	}
-	return out, nil
+	return nil
 }
 
-func (c *northboundConfiguratorClient) UpdateEntities(ctx context.Context, in *UpdateEntitiesRequest, opts ...grpc.CallOption) (*UpdateEntitiesResponse, error) {
-	out := new(UpdateEntitiesResponse)
-	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/UpdateEntities", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type WatchChangesRequest struct {
+	NetworkID string `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	// Only changes with a sequence number greater than sinceSeq are streamed
+	SinceSeq             uint64   `protobuf:"varint,2,opt,name=sinceSeq,proto3" json:"sinceSeq,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *northboundConfiguratorClient) DeleteEntities(ctx context.Context, in *DeleteEntitiesRequest, opts ...grpc.CallOption) (*protos.Void, error) {
-	out := new(protos.Void)
-	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/DeleteEntities", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *WatchChangesRequest) Reset()         { *m = WatchChangesRequest{} }
+func (m *WatchChangesRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchChangesRequest) ProtoMessage()    {}
+func (*WatchChangesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{20}
+}
+func (m *WatchChangesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchChangesRequest.Unmarshal(m, b)
+}
+func (m *WatchChangesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchChangesRequest.Marshal(b, m, deterministic)
+}
+func (dst *WatchChangesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchChangesRequest.Merge(dst, src)
+}
+func (m *WatchChangesRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchChangesRequest.Size(m)
+}
+func (m *WatchChangesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchChangesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchChangesRequest proto.InternalMessageInfo
+
+func (m *WatchChangesRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
 	}
-	return out, nil
+	return ""
 }
 
-func (c *northboundConfiguratorClient) LoadEntities(ctx context.Context, in *LoadEntitiesRequest, opts ...grpc.CallOption) (*LoadEntitiesResponse, error) {
-	out := new(LoadEntitiesResponse)
-	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/LoadEntities", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *WatchChangesRequest) GetSinceSeq() uint64 {
+	if m != nil {
+		return m.SinceSeq
 	}
-	return out, nil
+	return 0
 }
 
-// NorthboundConfiguratorServer is the server API for NorthboundConfigurator service.
-type NorthboundConfiguratorServer interface {
-	// ListNetworkIDs fetches the list of networkIDs registered
-	ListNetworkIDs(context.Context, *protos.Void) (*ListNetworkIDsResponse, error)
-	// CreateNetworks registers the given list of Networks and returns the created Networks
-	CreateNetworks(context.Context, *CreateNetworksRequest) (*CreateNetworksResponse, error)
-	// UpdateNetworks updates the given list of registered Networks
-	UpdateNetworks(context.Context, *UpdateNetworksRequest) (*protos.Void, error)
-	// DeleteNetworks deletes the given list of registered Networks
-	DeleteNetworks(context.Context, *DeleteNetworksRequest) (*protos.Void, error)
-	// LoadNetworks fetches the set of Networks specified by the request
-	LoadNetworks(context.Context, *LoadNetworksRequest) (*LoadNetworksResponse, error)
-	// CreateEntities registers the given list of Entities and returns the created Entities
-	CreateEntities(context.Context, *CreateEntitiesRequest) (*CreateEntitiesResponse, error)
-	// UpdateEntities updates the given list of registered Entities and returns the updated Entities
-	UpdateEntities(context.Context, *UpdateEntitiesRequest) (*UpdateEntitiesResponse, error)
-	// DeleteEntities deletes the set of Entities registered
-	DeleteEntities(context.Context, *DeleteEntitiesRequest) (*protos.Void, error)
-	// LoadEntities fetches the set of Entities specified by the request
-	LoadEntities(context.Context, *LoadEntitiesRequest) (*LoadEntitiesResponse, error)
+// Change is an entry in a network's change log
+type Change struct {
+	NetworkID string `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	// Monotonically increasing per network
+	Sequence  uint64           `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Operation Change_Operation `protobuf:"varint,3,opt,name=operation,proto3,enum=magma.orc8r.configurator.Change_Operation" json:"operation,omitempty"`
+	// Unset if the change was to the network itself
+	Entity               *EntityID `protobuf:"bytes,4,opt,name=entity,proto3" json:"entity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func RegisterNorthboundConfiguratorServer(s *grpc.Server, srv NorthboundConfiguratorServer) {
-	s.RegisterService(&_NorthboundConfigurator_serviceDesc, srv)
+func (m *Change) Reset()         { *m = Change{} }
+func (m *Change) String() string { return proto.CompactTextString(m) }
+func (*Change) ProtoMessage()    {}
+func (*Change) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{21}
+}
+func (m *Change) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Change.Unmarshal(m, b)
+}
+func (m *Change) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Change.Marshal(b, m, deterministic)
+}
+func (dst *Change) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Change.Merge(dst, src)
+}
+func (m *Change) XXX_Size() int {
+	return xxx_messageInfo_Change.Size(m)
+}
+func (m *Change) XXX_DiscardUnknown() {
+	xxx_messageInfo_Change.DiscardUnknown(m)
 }
 
-func _NorthboundConfigurator_ListNetworkIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(protos.Void)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
+var xxx_messageInfo_Change proto.InternalMessageInfo
+
+func (m *Change) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *Change) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *Change) GetOperation() Change_Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return Change_CREATE
+}
+
+func (m *Change) GetEntity() *EntityID {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+type GetLatestChangeSeqRequest struct {
+	NetworkID            string   `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLatestChangeSeqRequest) Reset()         { *m = GetLatestChangeSeqRequest{} }
+func (m *GetLatestChangeSeqRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLatestChangeSeqRequest) ProtoMessage()    {}
+func (*GetLatestChangeSeqRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{22}
+}
+func (m *GetLatestChangeSeqRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLatestChangeSeqRequest.Unmarshal(m, b)
+}
+func (m *GetLatestChangeSeqRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLatestChangeSeqRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetLatestChangeSeqRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLatestChangeSeqRequest.Merge(dst, src)
+}
+func (m *GetLatestChangeSeqRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLatestChangeSeqRequest.Size(m)
+}
+func (m *GetLatestChangeSeqRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLatestChangeSeqRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLatestChangeSeqRequest proto.InternalMessageInfo
+
+func (m *GetLatestChangeSeqRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+type GetLatestChangeSeqResponse struct {
+	// 0 if the network has no changes
+	Sequence             uint64   `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLatestChangeSeqResponse) Reset()         { *m = GetLatestChangeSeqResponse{} }
+func (m *GetLatestChangeSeqResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLatestChangeSeqResponse) ProtoMessage()    {}
+func (*GetLatestChangeSeqResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{23}
+}
+func (m *GetLatestChangeSeqResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLatestChangeSeqResponse.Unmarshal(m, b)
+}
+func (m *GetLatestChangeSeqResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLatestChangeSeqResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetLatestChangeSeqResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLatestChangeSeqResponse.Merge(dst, src)
+}
+func (m *GetLatestChangeSeqResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLatestChangeSeqResponse.Size(m)
+}
+func (m *GetLatestChangeSeqResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLatestChangeSeqResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLatestChangeSeqResponse proto.InternalMessageInfo
+
+func (m *GetLatestChangeSeqResponse) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+// NetworkExport is a versioned document containing a network with its configs
+// and all of its entities, including their associations and ACLs.
+type NetworkExport struct {
+	// Version of the export document format
+	FormatVersion        uint32           `protobuf:"varint,1,opt,name=formatVersion,proto3" json:"formatVersion,omitempty"`
+	Network              *Network         `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	Entities             []*NetworkEntity `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *NetworkExport) Reset()         { *m = NetworkExport{} }
+func (m *NetworkExport) String() string { return proto.CompactTextString(m) }
+func (*NetworkExport) ProtoMessage()    {}
+func (*NetworkExport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{24}
+}
+func (m *NetworkExport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkExport.Unmarshal(m, b)
+}
+func (m *NetworkExport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkExport.Marshal(b, m, deterministic)
+}
+func (dst *NetworkExport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkExport.Merge(dst, src)
+}
+func (m *NetworkExport) XXX_Size() int {
+	return xxx_messageInfo_NetworkExport.Size(m)
+}
+func (m *NetworkExport) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkExport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkExport proto.InternalMessageInfo
+
+func (m *NetworkExport) GetFormatVersion() uint32 {
+	if m != nil {
+		return m.FormatVersion
+	}
+	return 0
+}
+
+func (m *NetworkExport) GetNetwork() *Network {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
+func (m *NetworkExport) GetEntities() []*NetworkEntity {
+	if m != nil {
+		return m.Entities
+	}
+	return nil
+}
+
+type ExportNetworkRequest struct {
+	NetworkID            string   `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportNetworkRequest) Reset()         { *m = ExportNetworkRequest{} }
+func (m *ExportNetworkRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportNetworkRequest) ProtoMessage()    {}
+func (*ExportNetworkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{25}
+}
+func (m *ExportNetworkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportNetworkRequest.Unmarshal(m, b)
+}
+func (m *ExportNetworkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportNetworkRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExportNetworkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportNetworkRequest.Merge(dst, src)
+}
+func (m *ExportNetworkRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportNetworkRequest.Size(m)
+}
+func (m *ExportNetworkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportNetworkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportNetworkRequest proto.InternalMessageInfo
+
+func (m *ExportNetworkRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+type ImportNetworkRequest struct {
+	Export *NetworkExport `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
+	// If set, the network is imported under this ID instead of the exported
+	// network's ID
+	NewNetworkID string `protobuf:"bytes,2,opt,name=newNetworkID,proto3" json:"newNetworkID,omitempty"`
+	// If true, the import is validated and performed but not committed
+	DryRun               bool     `protobuf:"varint,3,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportNetworkRequest) Reset()         { *m = ImportNetworkRequest{} }
+func (m *ImportNetworkRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportNetworkRequest) ProtoMessage()    {}
+func (*ImportNetworkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{26}
+}
+func (m *ImportNetworkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportNetworkRequest.Unmarshal(m, b)
+}
+func (m *ImportNetworkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportNetworkRequest.Marshal(b, m, deterministic)
+}
+func (dst *ImportNetworkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportNetworkRequest.Merge(dst, src)
+}
+func (m *ImportNetworkRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportNetworkRequest.Size(m)
+}
+func (m *ImportNetworkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportNetworkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportNetworkRequest proto.InternalMessageInfo
+
+func (m *ImportNetworkRequest) GetExport() *NetworkExport {
+	if m != nil {
+		return m.Export
+	}
+	return nil
+}
+
+func (m *ImportNetworkRequest) GetNewNetworkID() string {
+	if m != nil {
+		return m.NewNetworkID
+	}
+	return ""
+}
+
+func (m *ImportNetworkRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type ImportNetworkResponse struct {
+	CreatedNetwork       *Network         `protobuf:"bytes,1,opt,name=createdNetwork,proto3" json:"createdNetwork,omitempty"`
+	CreatedEntities      []*NetworkEntity `protobuf:"bytes,2,rep,name=createdEntities,proto3" json:"createdEntities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ImportNetworkResponse) Reset()         { *m = ImportNetworkResponse{} }
+func (m *ImportNetworkResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportNetworkResponse) ProtoMessage()    {}
+func (*ImportNetworkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{27}
+}
+func (m *ImportNetworkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportNetworkResponse.Unmarshal(m, b)
+}
+func (m *ImportNetworkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportNetworkResponse.Marshal(b, m, deterministic)
+}
+func (dst *ImportNetworkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportNetworkResponse.Merge(dst, src)
+}
+func (m *ImportNetworkResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportNetworkResponse.Size(m)
+}
+func (m *ImportNetworkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportNetworkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportNetworkResponse proto.InternalMessageInfo
+
+func (m *ImportNetworkResponse) GetCreatedNetwork() *Network {
+	if m != nil {
+		return m.CreatedNetwork
+	}
+	return nil
+}
+
+func (m *ImportNetworkResponse) GetCreatedEntities() []*NetworkEntity {
+	if m != nil {
+		return m.CreatedEntities
+	}
+	return nil
+}
+
+type CheckPermissionsRequest struct {
+	// ID of the operator whose ACLs are evaluated
+	OperatorID string      `protobuf:"bytes,1,opt,name=operatorID,proto3" json:"operatorID,omitempty"`
+	NetworkID  string      `protobuf:"bytes,2,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	Entities   []*EntityID `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`
+	// Permission which the operator must have on each entity
+	Permission           ACL_Permission `protobuf:"varint,4,opt,name=permission,proto3,enum=magma.orc8r.configurator.ACL_Permission" json:"permission,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CheckPermissionsRequest) Reset()         { *m = CheckPermissionsRequest{} }
+func (m *CheckPermissionsRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckPermissionsRequest) ProtoMessage()    {}
+func (*CheckPermissionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{28}
+}
+func (m *CheckPermissionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckPermissionsRequest.Unmarshal(m, b)
+}
+func (m *CheckPermissionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckPermissionsRequest.Marshal(b, m, deterministic)
+}
+func (dst *CheckPermissionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckPermissionsRequest.Merge(dst, src)
+}
+func (m *CheckPermissionsRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckPermissionsRequest.Size(m)
+}
+func (m *CheckPermissionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckPermissionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckPermissionsRequest proto.InternalMessageInfo
+
+func (m *CheckPermissionsRequest) GetOperatorID() string {
+	if m != nil {
+		return m.OperatorID
+	}
+	return ""
+}
+
+func (m *CheckPermissionsRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *CheckPermissionsRequest) GetEntities() []*EntityID {
+	if m != nil {
+		return m.Entities
+	}
+	return nil
+}
+
+func (m *CheckPermissionsRequest) GetPermission() ACL_Permission {
+	if m != nil {
+		return m.Permission
+	}
+	return ACL_NO_PERM
+}
+
+type CheckPermissionsResponse struct {
+	// Entities on which the operator has the requested permission
+	Allowed []*EntityID `protobuf:"bytes,1,rep,name=allowed,proto3" json:"allowed,omitempty"`
+	// Entities on which the operator does not have the requested permission
+	Denied               []*EntityID `protobuf:"bytes,2,rep,name=denied,proto3" json:"denied,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *CheckPermissionsResponse) Reset()         { *m = CheckPermissionsResponse{} }
+func (m *CheckPermissionsResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckPermissionsResponse) ProtoMessage()    {}
+func (*CheckPermissionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{29}
+}
+func (m *CheckPermissionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckPermissionsResponse.Unmarshal(m, b)
+}
+func (m *CheckPermissionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckPermissionsResponse.Marshal(b, m, deterministic)
+}
+func (dst *CheckPermissionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckPermissionsResponse.Merge(dst, src)
+}
+func (m *CheckPermissionsResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckPermissionsResponse.Size(m)
+}
+func (m *CheckPermissionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckPermissionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckPermissionsResponse proto.InternalMessageInfo
+
+func (m *CheckPermissionsResponse) GetAllowed() []*EntityID {
+	if m != nil {
+		return m.Allowed
+	}
+	return nil
+}
+
+func (m *CheckPermissionsResponse) GetDenied() []*EntityID {
+	if m != nil {
+		return m.Denied
+	}
+	return nil
+}
+
+type TraverseGraphRequest struct {
+	NetworkID string                         `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	Start     *EntityID                      `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	Direction TraverseGraphRequest_Direction `protobuf:"varint,3,opt,name=direction,proto3,enum=magma.orc8r.configurator.TraverseGraphRequest_Direction" json:"direction,omitempty"`
+	// hopTypes[i] restricts the type of the entities reached on hop i+1.
+	// An empty string or a hop past the end of the list matches any type.
+	HopTypes []string `protobuf:"bytes,4,rep,name=hopTypes,proto3" json:"hopTypes,omitempty"`
+	// Maximum number of hops to follow. Must be positive.
+	MaxDepth uint32 `protobuf:"varint,5,opt,name=maxDepth,proto3" json:"maxDepth,omitempty"`
+	// Association fields are ignored
+	Criteria             *EntityLoadCriteria `protobuf:"bytes,6,opt,name=criteria,proto3" json:"criteria,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *TraverseGraphRequest) Reset()         { *m = TraverseGraphRequest{} }
+func (m *TraverseGraphRequest) String() string { return proto.CompactTextString(m) }
+func (*TraverseGraphRequest) ProtoMessage()    {}
+func (*TraverseGraphRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{30}
+}
+func (m *TraverseGraphRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraverseGraphRequest.Unmarshal(m, b)
+}
+func (m *TraverseGraphRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraverseGraphRequest.Marshal(b, m, deterministic)
+}
+func (dst *TraverseGraphRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraverseGraphRequest.Merge(dst, src)
+}
+func (m *TraverseGraphRequest) XXX_Size() int {
+	return xxx_messageInfo_TraverseGraphRequest.Size(m)
+}
+func (m *TraverseGraphRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraverseGraphRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraverseGraphRequest proto.InternalMessageInfo
+
+func (m *TraverseGraphRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *TraverseGraphRequest) GetStart() *EntityID {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *TraverseGraphRequest) GetDirection() TraverseGraphRequest_Direction {
+	if m != nil {
+		return m.Direction
+	}
+	return TraverseGraphRequest_ASSOCS_FROM
+}
+
+func (m *TraverseGraphRequest) GetHopTypes() []string {
+	if m != nil {
+		return m.HopTypes
+	}
+	return nil
+}
+
+func (m *TraverseGraphRequest) GetMaxDepth() uint32 {
+	if m != nil {
+		return m.MaxDepth
+	}
+	return 0
+}
+
+func (m *TraverseGraphRequest) GetCriteria() *EntityLoadCriteria {
+	if m != nil {
+		return m.Criteria
+	}
+	return nil
+}
+
+type GraphEdge struct {
+	From                 *EntityID `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   *EntityID `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GraphEdge) Reset()         { *m = GraphEdge{} }
+func (m *GraphEdge) String() string { return proto.CompactTextString(m) }
+func (*GraphEdge) ProtoMessage()    {}
+func (*GraphEdge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{31}
+}
+func (m *GraphEdge) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GraphEdge.Unmarshal(m, b)
+}
+func (m *GraphEdge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GraphEdge.Marshal(b, m, deterministic)
+}
+func (dst *GraphEdge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GraphEdge.Merge(dst, src)
+}
+func (m *GraphEdge) XXX_Size() int {
+	return xxx_messageInfo_GraphEdge.Size(m)
+}
+func (m *GraphEdge) XXX_DiscardUnknown() {
+	xxx_messageInfo_GraphEdge.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GraphEdge proto.InternalMessageInfo
+
+func (m *GraphEdge) GetFrom() *EntityID {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *GraphEdge) GetTo() *EntityID {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+type TraverseGraphResponse struct {
+	// Entities reached by the traversal, excluding the start entity
+	Entities []*NetworkEntity `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	// Edges followed to reach the entities
+	Edges                []*GraphEdge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *TraverseGraphResponse) Reset()         { *m = TraverseGraphResponse{} }
+func (m *TraverseGraphResponse) String() string { return proto.CompactTextString(m) }
+func (*TraverseGraphResponse) ProtoMessage()    {}
+func (*TraverseGraphResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{32}
+}
+func (m *TraverseGraphResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraverseGraphResponse.Unmarshal(m, b)
+}
+func (m *TraverseGraphResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraverseGraphResponse.Marshal(b, m, deterministic)
+}
+func (dst *TraverseGraphResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraverseGraphResponse.Merge(dst, src)
+}
+func (m *TraverseGraphResponse) XXX_Size() int {
+	return xxx_messageInfo_TraverseGraphResponse.Size(m)
+}
+func (m *TraverseGraphResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraverseGraphResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraverseGraphResponse proto.InternalMessageInfo
+
+func (m *TraverseGraphResponse) GetEntities() []*NetworkEntity {
+	if m != nil {
+		return m.Entities
+	}
+	return nil
+}
+
+func (m *TraverseGraphResponse) GetEdges() []*GraphEdge {
+	if m != nil {
+		return m.Edges
+	}
+	return nil
+}
+
+type AuditFieldDiff struct {
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// Empty if the field was set by the write
+	Before string `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	// Empty if the field was cleared by the write
+	After                string   `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditFieldDiff) Reset()         { *m = AuditFieldDiff{} }
+func (m *AuditFieldDiff) String() string { return proto.CompactTextString(m) }
+func (*AuditFieldDiff) ProtoMessage()    {}
+func (*AuditFieldDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{33}
+}
+func (m *AuditFieldDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditFieldDiff.Unmarshal(m, b)
+}
+func (m *AuditFieldDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditFieldDiff.Marshal(b, m, deterministic)
+}
+func (dst *AuditFieldDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditFieldDiff.Merge(dst, src)
+}
+func (m *AuditFieldDiff) XXX_Size() int {
+	return xxx_messageInfo_AuditFieldDiff.Size(m)
+}
+func (m *AuditFieldDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditFieldDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditFieldDiff proto.InternalMessageInfo
+
+func (m *AuditFieldDiff) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *AuditFieldDiff) GetBefore() string {
+	if m != nil {
+		return m.Before
+	}
+	return ""
+}
+
+func (m *AuditFieldDiff) GetAfter() string {
+	if m != nil {
+		return m.After
+	}
+	return ""
+}
+
+// AuditEntry is a record of a write to a network or entity
+type AuditEntry struct {
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	NetworkID string `protobuf:"bytes,2,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	// Unset if the write was to the network itself
+	Entity    *EntityID        `protobuf:"bytes,3,opt,name=entity,proto3" json:"entity,omitempty"`
+	Operation Change_Operation `protobuf:"varint,4,opt,name=operation,proto3,enum=magma.orc8r.configurator.Change_Operation" json:"operation,omitempty"`
+	// Hash string of the identity of the caller which made the write. Empty
+	// if the write was made by another cloud service.
+	Actor string `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Unix timestamp (in seconds) of the write
+	Timestamp            int64             `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Diffs                []*AuditFieldDiff `protobuf:"bytes,7,rep,name=diffs,proto3" json:"diffs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *AuditEntry) Reset()         { *m = AuditEntry{} }
+func (m *AuditEntry) String() string { return proto.CompactTextString(m) }
+func (*AuditEntry) ProtoMessage()    {}
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{34}
+}
+func (m *AuditEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEntry.Unmarshal(m, b)
+}
+func (m *AuditEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEntry.Marshal(b, m, deterministic)
+}
+func (dst *AuditEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEntry.Merge(dst, src)
+}
+func (m *AuditEntry) XXX_Size() int {
+	return xxx_messageInfo_AuditEntry.Size(m)
+}
+func (m *AuditEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEntry proto.InternalMessageInfo
+
+func (m *AuditEntry) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetEntity() *EntityID {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+func (m *AuditEntry) GetOperation() Change_Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return Change_CREATE
+}
+
+func (m *AuditEntry) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *AuditEntry) GetDiffs() []*AuditFieldDiff {
+	if m != nil {
+		return m.Diffs
+	}
+	return nil
+}
+
+type QueryAuditLogRequest struct {
+	NetworkID string `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	// If set, only writes to this entity are returned
+	Entity *EntityID `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	// If set, only writes made by this actor are returned
+	Actor *wrappers.StringValue `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Only writes with startTime <= timestamp < endTime are returned. Zero
+	// values leave the range unbounded.
+	StartTime int64 `protobuf:"varint,4,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime   int64 `protobuf:"varint,5,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	// If non-zero, at most limit entries are returned
+	Limit                uint32   `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryAuditLogRequest) Reset()         { *m = QueryAuditLogRequest{} }
+func (m *QueryAuditLogRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryAuditLogRequest) ProtoMessage()    {}
+func (*QueryAuditLogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{35}
+}
+func (m *QueryAuditLogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryAuditLogRequest.Unmarshal(m, b)
+}
+func (m *QueryAuditLogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryAuditLogRequest.Marshal(b, m, deterministic)
+}
+func (dst *QueryAuditLogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryAuditLogRequest.Merge(dst, src)
+}
+func (m *QueryAuditLogRequest) XXX_Size() int {
+	return xxx_messageInfo_QueryAuditLogRequest.Size(m)
+}
+func (m *QueryAuditLogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryAuditLogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryAuditLogRequest proto.InternalMessageInfo
+
+func (m *QueryAuditLogRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *QueryAuditLogRequest) GetEntity() *EntityID {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+func (m *QueryAuditLogRequest) GetActor() *wrappers.StringValue {
+	if m != nil {
+		return m.Actor
+	}
+	return nil
+}
+
+func (m *QueryAuditLogRequest) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *QueryAuditLogRequest) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *QueryAuditLogRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type QueryAuditLogResponse struct {
+	// Ordered by timestamp
+	Entries              []*AuditEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *QueryAuditLogResponse) Reset()         { *m = QueryAuditLogResponse{} }
+func (m *QueryAuditLogResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryAuditLogResponse) ProtoMessage()    {}
+func (*QueryAuditLogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_northbound_f5a622a1465d5cc2, []int{36}
+}
+func (m *QueryAuditLogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryAuditLogResponse.Unmarshal(m, b)
+}
+func (m *QueryAuditLogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryAuditLogResponse.Marshal(b, m, deterministic)
+}
+func (dst *QueryAuditLogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryAuditLogResponse.Merge(dst, src)
+}
+func (m *QueryAuditLogResponse) XXX_Size() int {
+	return xxx_messageInfo_QueryAuditLogResponse.Size(m)
+}
+func (m *QueryAuditLogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryAuditLogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryAuditLogResponse proto.InternalMessageInfo
+
+func (m *QueryAuditLogResponse) GetEntries() []*AuditEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ListNetworkIDsResponse)(nil), "magma.orc8r.configurator.ListNetworkIDsResponse")
+	proto.RegisterType((*CreateNetworksRequest)(nil), "magma.orc8r.configurator.CreateNetworksRequest")
+	proto.RegisterType((*CreateNetworksResponse)(nil), "magma.orc8r.configurator.CreateNetworksResponse")
+	proto.RegisterType((*NetworkUpdateCriteria)(nil), "magma.orc8r.configurator.NetworkUpdateCriteria")
+	proto.RegisterMapType((map[string][]byte)(nil), "magma.orc8r.configurator.NetworkUpdateCriteria.ConfigsToAddOrUpdateEntry")
+	proto.RegisterType((*UpdateNetworksRequest)(nil), "magma.orc8r.configurator.UpdateNetworksRequest")
+	proto.RegisterType((*NetworkLoadCriteria)(nil), "magma.orc8r.configurator.NetworkLoadCriteria")
+	proto.RegisterType((*LoadNetworksRequest)(nil), "magma.orc8r.configurator.LoadNetworksRequest")
+	proto.RegisterType((*LoadNetworksResponse)(nil), "magma.orc8r.configurator.LoadNetworksResponse")
+	proto.RegisterMapType((map[string]*Network)(nil), "magma.orc8r.configurator.LoadNetworksResponse.NetworksEntry")
+	proto.RegisterType((*DeleteNetworksRequest)(nil), "magma.orc8r.configurator.DeleteNetworksRequest")
+	proto.RegisterType((*CreateEntitiesRequest)(nil), "magma.orc8r.configurator.CreateEntitiesRequest")
+	proto.RegisterType((*CreateEntitiesResponse)(nil), "magma.orc8r.configurator.CreateEntitiesResponse")
+	proto.RegisterType((*EntityUpdateCriteria)(nil), "magma.orc8r.configurator.EntityUpdateCriteria")
+	proto.RegisterType((*UpdateEntitiesRequest)(nil), "magma.orc8r.configurator.UpdateEntitiesRequest")
+	proto.RegisterType((*UpdateEntitiesResponse)(nil), "magma.orc8r.configurator.UpdateEntitiesResponse")
+	proto.RegisterMapType((map[string]*NetworkEntity)(nil), "magma.orc8r.configurator.UpdateEntitiesResponse.UpdatedEntitiesEntry")
+	proto.RegisterType((*DeleteEntitiesRequest)(nil), "magma.orc8r.configurator.DeleteEntitiesRequest")
+	proto.RegisterType((*EntityLoadCriteria)(nil), "magma.orc8r.configurator.EntityLoadCriteria")
+	proto.RegisterType((*LoadEntitiesRequest)(nil), "magma.orc8r.configurator.LoadEntitiesRequest")
+	proto.RegisterType((*LoadEntitiesResponse)(nil), "magma.orc8r.configurator.LoadEntitiesResponse")
+	proto.RegisterType((*Tombstone)(nil), "magma.orc8r.configurator.Tombstone")
+	proto.RegisterType((*RestoreEntityRequest)(nil), "magma.orc8r.configurator.RestoreEntityRequest")
+	proto.RegisterType((*WatchChangesRequest)(nil), "magma.orc8r.configurator.WatchChangesRequest")
+	proto.RegisterType((*Change)(nil), "magma.orc8r.configurator.Change")
+	proto.RegisterType((*GetLatestChangeSeqRequest)(nil), "magma.orc8r.configurator.GetLatestChangeSeqRequest")
+	proto.RegisterType((*GetLatestChangeSeqResponse)(nil), "magma.orc8r.configurator.GetLatestChangeSeqResponse")
+	proto.RegisterType((*NetworkExport)(nil), "magma.orc8r.configurator.NetworkExport")
+	proto.RegisterType((*ExportNetworkRequest)(nil), "magma.orc8r.configurator.ExportNetworkRequest")
+	proto.RegisterType((*ImportNetworkRequest)(nil), "magma.orc8r.configurator.ImportNetworkRequest")
+	proto.RegisterType((*ImportNetworkResponse)(nil), "magma.orc8r.configurator.ImportNetworkResponse")
+	proto.RegisterType((*CheckPermissionsRequest)(nil), "magma.orc8r.configurator.CheckPermissionsRequest")
+	proto.RegisterType((*CheckPermissionsResponse)(nil), "magma.orc8r.configurator.CheckPermissionsResponse")
+	proto.RegisterType((*TraverseGraphRequest)(nil), "magma.orc8r.configurator.TraverseGraphRequest")
+	proto.RegisterType((*GraphEdge)(nil), "magma.orc8r.configurator.GraphEdge")
+	proto.RegisterType((*TraverseGraphResponse)(nil), "magma.orc8r.configurator.TraverseGraphResponse")
+	proto.RegisterType((*AuditFieldDiff)(nil), "magma.orc8r.configurator.AuditFieldDiff")
+	proto.RegisterType((*AuditEntry)(nil), "magma.orc8r.configurator.AuditEntry")
+	proto.RegisterType((*QueryAuditLogRequest)(nil), "magma.orc8r.configurator.QueryAuditLogRequest")
+	proto.RegisterType((*QueryAuditLogResponse)(nil), "magma.orc8r.configurator.QueryAuditLogResponse")
+	proto.RegisterEnum("magma.orc8r.configurator.Change_Operation", Change_Operation_name, Change_Operation_value)
+	proto.RegisterEnum("magma.orc8r.configurator.TraverseGraphRequest_Direction", TraverseGraphRequest_Direction_name, TraverseGraphRequest_Direction_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// NorthboundConfiguratorClient is the client API for NorthboundConfigurator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NorthboundConfiguratorClient interface {
+	// ListNetworkIDs fetches the list of networkIDs registered
+	ListNetworkIDs(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*ListNetworkIDsResponse, error)
+	// CreateNetworks registers the given list of Networks and returns the created Networks
+	CreateNetworks(ctx context.Context, in *CreateNetworksRequest, opts ...grpc.CallOption) (*CreateNetworksResponse, error)
+	// UpdateNetworks updates the given list of registered Networks
+	UpdateNetworks(ctx context.Context, in *UpdateNetworksRequest, opts ...grpc.CallOption) (*protos.Void, error)
+	// DeleteNetworks deletes the given list of registered Networks
+	DeleteNetworks(ctx context.Context, in *DeleteNetworksRequest, opts ...grpc.CallOption) (*protos.Void, error)
+	// LoadNetworks fetches the set of Networks specified by the request
+	LoadNetworks(ctx context.Context, in *LoadNetworksRequest, opts ...grpc.CallOption) (*LoadNetworksResponse, error)
+	// CreateEntities registers the given list of Entities and returns the created Entities
+	CreateEntities(ctx context.Context, in *CreateEntitiesRequest, opts ...grpc.CallOption) (*CreateEntitiesResponse, error)
+	// UpdateEntities updates the given list of registered Entities and returns the updated Entities
+	UpdateEntities(ctx context.Context, in *UpdateEntitiesRequest, opts ...grpc.CallOption) (*UpdateEntitiesResponse, error)
+	// DeleteEntities deletes the set of Entities registered
+	DeleteEntities(ctx context.Context, in *DeleteEntitiesRequest, opts ...grpc.CallOption) (*protos.Void, error)
+	// LoadEntities fetches the set of Entities specified by the request
+	LoadEntities(ctx context.Context, in *LoadEntitiesRequest, opts ...grpc.CallOption) (*LoadEntitiesResponse, error)
+	// WatchChanges streams the change log of a network starting after the
+	// requested sequence number, then streams new changes as they are written
+	WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (NorthboundConfigurator_WatchChangesClient, error)
+	// GetLatestChangeSeq returns the sequence number of the latest change
+	// to a network, which can be used as a version of the network's graph
+	GetLatestChangeSeq(ctx context.Context, in *GetLatestChangeSeqRequest, opts ...grpc.CallOption) (*GetLatestChangeSeqResponse, error)
+	// ExportNetwork exports a network and its entity graph into a document
+	// which can be imported with ImportNetwork
+	ExportNetwork(ctx context.Context, in *ExportNetworkRequest, opts ...grpc.CallOption) (*NetworkExport, error)
+	// ImportNetwork creates a network and its entity graph from an exported
+	// document in a single transaction
+	ImportNetwork(ctx context.Context, in *ImportNetworkRequest, opts ...grpc.CallOption) (*ImportNetworkResponse, error)
+	// CheckPermissions evaluates an operator's ACLs against a set of entities
+	// and their graph ancestors
+	CheckPermissions(ctx context.Context, in *CheckPermissionsRequest, opts ...grpc.CallOption) (*CheckPermissionsResponse, error)
+	// TraverseGraph follows associations from an entity up to a max depth,
+	// optionally filtering the type of the entities reached on each hop
+	TraverseGraph(ctx context.Context, in *TraverseGraphRequest, opts ...grpc.CallOption) (*TraverseGraphResponse, error)
+	// RestoreEntity recreates a deleted entity from its tombstone along with
+	// its permissions and its associations to entities which still exist
+	RestoreEntity(ctx context.Context, in *RestoreEntityRequest, opts ...grpc.CallOption) (*NetworkEntity, error)
+	// QueryAuditLog returns the audit log entries of a network which match
+	// the request's filters
+	QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error)
+}
+
+type northboundConfiguratorClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNorthboundConfiguratorClient(cc *grpc.ClientConn) NorthboundConfiguratorClient {
+	return &northboundConfiguratorClient{cc}
+}
+
+func (c *northboundConfiguratorClient) ListNetworkIDs(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*ListNetworkIDsResponse, error) {
+	out := new(ListNetworkIDsResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/ListNetworkIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) CreateNetworks(ctx context.Context, in *CreateNetworksRequest, opts ...grpc.CallOption) (*CreateNetworksResponse, error) {
+	out := new(CreateNetworksResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/CreateNetworks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) UpdateNetworks(ctx context.Context, in *UpdateNetworksRequest, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/UpdateNetworks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) DeleteNetworks(ctx context.Context, in *DeleteNetworksRequest, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/DeleteNetworks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) LoadNetworks(ctx context.Context, in *LoadNetworksRequest, opts ...grpc.CallOption) (*LoadNetworksResponse, error) {
+	out := new(LoadNetworksResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/LoadNetworks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) CreateEntities(ctx context.Context, in *CreateEntitiesRequest, opts ...grpc.CallOption) (*CreateEntitiesResponse, error) {
+	out := new(CreateEntitiesResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/CreateEntities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) UpdateEntities(ctx context.Context, in *UpdateEntitiesRequest, opts ...grpc.CallOption) (*UpdateEntitiesResponse, error) {
+	out := new(UpdateEntitiesResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/UpdateEntities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) DeleteEntities(ctx context.Context, in *DeleteEntitiesRequest, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/DeleteEntities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) LoadEntities(ctx context.Context, in *LoadEntitiesRequest, opts ...grpc.CallOption) (*LoadEntitiesResponse, error) {
+	out := new(LoadEntitiesResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/LoadEntities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (NorthboundConfigurator_WatchChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NorthboundConfigurator_serviceDesc.Streams[0], "/magma.orc8r.configurator.NorthboundConfigurator/WatchChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &northboundConfiguratorWatchChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NorthboundConfigurator_WatchChangesClient interface {
+	Recv() (*Change, error)
+	grpc.ClientStream
+}
+
+type northboundConfiguratorWatchChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *northboundConfiguratorWatchChangesClient) Recv() (*Change, error) {
+	m := new(Change)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *northboundConfiguratorClient) GetLatestChangeSeq(ctx context.Context, in *GetLatestChangeSeqRequest, opts ...grpc.CallOption) (*GetLatestChangeSeqResponse, error) {
+	out := new(GetLatestChangeSeqResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/GetLatestChangeSeq", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) ExportNetwork(ctx context.Context, in *ExportNetworkRequest, opts ...grpc.CallOption) (*NetworkExport, error) {
+	out := new(NetworkExport)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/ExportNetwork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) ImportNetwork(ctx context.Context, in *ImportNetworkRequest, opts ...grpc.CallOption) (*ImportNetworkResponse, error) {
+	out := new(ImportNetworkResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/ImportNetwork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) CheckPermissions(ctx context.Context, in *CheckPermissionsRequest, opts ...grpc.CallOption) (*CheckPermissionsResponse, error) {
+	out := new(CheckPermissionsResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/CheckPermissions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) TraverseGraph(ctx context.Context, in *TraverseGraphRequest, opts ...grpc.CallOption) (*TraverseGraphResponse, error) {
+	out := new(TraverseGraphResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/TraverseGraph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) RestoreEntity(ctx context.Context, in *RestoreEntityRequest, opts ...grpc.CallOption) (*NetworkEntity, error) {
+	out := new(NetworkEntity)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/RestoreEntity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error) {
+	out := new(QueryAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/QueryAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NorthboundConfiguratorServer is the server API for NorthboundConfigurator service.
+type NorthboundConfiguratorServer interface {
+	// ListNetworkIDs fetches the list of networkIDs registered
+	ListNetworkIDs(context.Context, *protos.Void) (*ListNetworkIDsResponse, error)
+	// CreateNetworks registers the given list of Networks and returns the created Networks
+	CreateNetworks(context.Context, *CreateNetworksRequest) (*CreateNetworksResponse, error)
+	// UpdateNetworks updates the given list of registered Networks
+	UpdateNetworks(context.Context, *UpdateNetworksRequest) (*protos.Void, error)
+	// DeleteNetworks deletes the given list of registered Networks
+	DeleteNetworks(context.Context, *DeleteNetworksRequest) (*protos.Void, error)
+	// LoadNetworks fetches the set of Networks specified by the request
+	LoadNetworks(context.Context, *LoadNetworksRequest) (*LoadNetworksResponse, error)
+	// CreateEntities registers the given list of Entities and returns the created Entities
+	CreateEntities(context.Context, *CreateEntitiesRequest) (*CreateEntitiesResponse, error)
+	// UpdateEntities updates the given list of registered Entities and returns the updated Entities
+	UpdateEntities(context.Context, *UpdateEntitiesRequest) (*UpdateEntitiesResponse, error)
+	// DeleteEntities deletes the set of Entities registered
+	DeleteEntities(context.Context, *DeleteEntitiesRequest) (*protos.Void, error)
+	// LoadEntities fetches the set of Entities specified by the request
+	LoadEntities(context.Context, *LoadEntitiesRequest) (*LoadEntitiesResponse, error)
+	// WatchChanges streams the change log of a network starting after the
+	// requested sequence number, then streams new changes as they are written
+	WatchChanges(*WatchChangesRequest, NorthboundConfigurator_WatchChangesServer) error
+	// GetLatestChangeSeq returns the sequence number of the latest change
+	// to a network, which can be used as a version of the network's graph
+	GetLatestChangeSeq(context.Context, *GetLatestChangeSeqRequest) (*GetLatestChangeSeqResponse, error)
+	// ExportNetwork exports a network and its entity graph into a document
+	// which can be imported with ImportNetwork
+	ExportNetwork(context.Context, *ExportNetworkRequest) (*NetworkExport, error)
+	// ImportNetwork creates a network and its entity graph from an exported
+	// document in a single transaction
+	ImportNetwork(context.Context, *ImportNetworkRequest) (*ImportNetworkResponse, error)
+	// CheckPermissions evaluates an operator's ACLs against a set of entities
+	// and their graph ancestors
+	CheckPermissions(context.Context, *CheckPermissionsRequest) (*CheckPermissionsResponse, error)
+	// TraverseGraph follows associations from an entity up to a max depth,
+	// optionally filtering the type of the entities reached on each hop
+	TraverseGraph(context.Context, *TraverseGraphRequest) (*TraverseGraphResponse, error)
+	// RestoreEntity recreates a deleted entity from its tombstone along with
+	// its permissions and its associations to entities which still exist
+	RestoreEntity(context.Context, *RestoreEntityRequest) (*NetworkEntity, error)
+	// QueryAuditLog returns the audit log entries of a network which match
+	// the request's filters
+	QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error)
+}
+
+func RegisterNorthboundConfiguratorServer(s *grpc.Server, srv NorthboundConfiguratorServer) {
+	s.RegisterService(&_NorthboundConfigurator_serviceDesc, srv)
+}
+
+func _NorthboundConfigurator_ListNetworkIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
 	if interceptor == nil {
 		return srv.(NorthboundConfiguratorServer).ListNetworkIDs(ctx, in)
 	}
@@ -1276,6 +2626,153 @@ func _NorthboundConfigurator_LoadEntities_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NorthboundConfigurator_WatchChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NorthboundConfiguratorServer).WatchChanges(m, &northboundConfiguratorWatchChangesServer{stream})
+}
+
+type NorthboundConfigurator_WatchChangesServer interface {
+	Send(*Change) error
+	grpc.ServerStream
+}
+
+type northboundConfiguratorWatchChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *northboundConfiguratorWatchChangesServer) Send(m *Change) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NorthboundConfigurator_GetLatestChangeSeq_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLatestChangeSeqRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NorthboundConfiguratorServer).GetLatestChangeSeq(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.configurator.NorthboundConfigurator/GetLatestChangeSeq",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NorthboundConfiguratorServer).GetLatestChangeSeq(ctx, req.(*GetLatestChangeSeqRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NorthboundConfigurator_ExportNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NorthboundConfiguratorServer).ExportNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.configurator.NorthboundConfigurator/ExportNetwork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NorthboundConfiguratorServer).ExportNetwork(ctx, req.(*ExportNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NorthboundConfigurator_ImportNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NorthboundConfiguratorServer).ImportNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.configurator.NorthboundConfigurator/ImportNetwork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NorthboundConfiguratorServer).ImportNetwork(ctx, req.(*ImportNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NorthboundConfigurator_CheckPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NorthboundConfiguratorServer).CheckPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.configurator.NorthboundConfigurator/CheckPermissions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NorthboundConfiguratorServer).CheckPermissions(ctx, req.(*CheckPermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NorthboundConfigurator_TraverseGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TraverseGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NorthboundConfiguratorServer).TraverseGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.configurator.NorthboundConfigurator/TraverseGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NorthboundConfiguratorServer).TraverseGraph(ctx, req.(*TraverseGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NorthboundConfigurator_RestoreEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NorthboundConfiguratorServer).RestoreEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.configurator.NorthboundConfigurator/RestoreEntity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NorthboundConfiguratorServer).RestoreEntity(ctx, req.(*RestoreEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NorthboundConfigurator_QueryAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NorthboundConfiguratorServer).QueryAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.configurator.NorthboundConfigurator/QueryAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NorthboundConfiguratorServer).QueryAuditLog(ctx, req.(*QueryAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NorthboundConfigurator_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.orc8r.configurator.NorthboundConfigurator",
 	HandlerType: (*NorthboundConfiguratorServer)(nil),
@@ -1316,85 +2813,185 @@ var _NorthboundConfigurator_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LoadEntities",
 			Handler:    _NorthboundConfigurator_LoadEntities_Handler,
 		},
+		{
+			MethodName: "GetLatestChangeSeq",
+			Handler:    _NorthboundConfigurator_GetLatestChangeSeq_Handler,
+		},
+		{
+			MethodName: "ExportNetwork",
+			Handler:    _NorthboundConfigurator_ExportNetwork_Handler,
+		},
+		{
+			MethodName: "ImportNetwork",
+			Handler:    _NorthboundConfigurator_ImportNetwork_Handler,
+		},
+		{
+			MethodName: "CheckPermissions",
+			Handler:    _NorthboundConfigurator_CheckPermissions_Handler,
+		},
+		{
+			MethodName: "TraverseGraph",
+			Handler:    _NorthboundConfigurator_TraverseGraph_Handler,
+		},
+		{
+			MethodName: "RestoreEntity",
+			Handler:    _NorthboundConfigurator_RestoreEntity_Handler,
+		},
+		{
+			MethodName: "QueryAuditLog",
+			Handler:    _NorthboundConfigurator_QueryAuditLog_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchChanges",
+			Handler:       _NorthboundConfigurator_WatchChanges_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "northbound.proto",
 }
 
-func init() { proto.RegisterFile("northbound.proto", fileDescriptor_northbound_07d50f0696abd602) }
-
-var fileDescriptor_northbound_07d50f0696abd602 = []byte{
-	// 1150 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0x4f, 0x6f, 0xe3, 0x44,
-	0x14, 0x5f, 0x27, 0x2d, 0x4d, 0x5e, 0xda, 0x74, 0x77, 0x9a, 0x54, 0x6e, 0x58, 0x4a, 0xf0, 0x01,
-	0x72, 0x60, 0xdd, 0x55, 0x90, 0xd8, 0xb2, 0xda, 0x45, 0xb4, 0x49, 0xcb, 0x46, 0x5b, 0xba, 0xc5,
-	0x94, 0x80, 0x40, 0x42, 0x72, 0xe3, 0xd9, 0xd4, 0x6a, 0xe2, 0x09, 0x9e, 0x09, 0x51, 0x10, 0x88,
-	0x8f, 0xc1, 0x81, 0x0f, 0x82, 0xb8, 0xf1, 0x09, 0xf8, 0x24, 0x9c, 0x39, 0x23, 0x7b, 0xc6, 0xff,
-	0x26, 0x4e, 0xec, 0x70, 0xe1, 0xd4, 0xf8, 0xcd, 0xfb, 0xfd, 0xde, 0xbc, 0x79, 0xf3, 0xfe, 0x4c,
-	0xe1, 0xbe, 0x43, 0x5c, 0x76, 0x7b, 0x43, 0xa6, 0x8e, 0xa5, 0x4f, 0x5c, 0xc2, 0x08, 0x52, 0xc7,
-	0xe6, 0x70, 0x6c, 0xea, 0xc4, 0x1d, 0x1c, 0xbb, 0xfa, 0x80, 0x38, 0xaf, 0xed, 0xe1, 0xd4, 0x35,
-	0x19, 0x71, 0x1b, 0x07, 0x43, 0x42, 0x86, 0x23, 0x7c, 0xe4, 0xeb, 0xdd, 0x4c, 0x5f, 0x1f, 0x99,
-	0xce, 0x9c, 0x83, 0x1a, 0x07, 0xbe, 0x3a, 0x5f, 0xa1, 0x47, 0x03, 0x32, 0x1e, 0x13, 0x47, 0x2c,
-	0x1d, 0xca, 0xa8, 0x99, 0x6b, 0x4e, 0x26, 0xd8, 0xa5, 0x62, 0x1d, 0xc5, 0x6d, 0x70, 0x99, 0x76,
-	0x0c, 0xfb, 0x17, 0x36, 0x65, 0x97, 0x98, 0xcd, 0x88, 0x7b, 0xd7, 0xeb, 0x52, 0x03, 0xd3, 0x09,
-	0x71, 0x28, 0x46, 0x87, 0x00, 0x4e, 0x28, 0x55, 0x95, 0x66, 0xb1, 0x55, 0x36, 0x62, 0x12, 0xad,
-	0x0f, 0xf5, 0x8e, 0x8b, 0x4d, 0x86, 0x05, 0x96, 0x1a, 0xf8, 0xfb, 0x29, 0xa6, 0x0c, 0x3d, 0x87,
-	0x92, 0x50, 0xe3, 0xb0, 0x4a, 0xfb, 0x1d, 0x7d, 0x99, 0xa7, 0xba, 0x00, 0x1b, 0x21, 0x44, 0xc3,
-	0xb0, 0x2f, 0xf3, 0x8a, 0x1d, 0xbd, 0x84, 0xdd, 0x81, 0xbf, 0x62, 0x5d, 0xae, 0xcd, 0x2f, 0x23,
-	0xb5, 0x5f, 0x8b, 0x50, 0x17, 0x1f, 0x5f, 0x4e, 0x2c, 0x93, 0xe1, 0x8e, 0x6b, 0x33, 0xec, 0xda,
-	0x26, 0xaa, 0x42, 0xc1, 0xb6, 0x54, 0xa5, 0xa9, 0xb4, 0xca, 0x46, 0xc1, 0xb6, 0xd0, 0x87, 0xb0,
-	0xe5, 0xe0, 0xd9, 0xa5, 0x39, 0xc6, 0x2a, 0x34, 0x95, 0x56, 0xa5, 0xfd, 0x50, 0xe7, 0x07, 0xad,
-	0x07, 0x07, 0xad, 0x7f, 0xc1, 0x5c, 0xdb, 0x19, 0xf6, 0xcd, 0xd1, 0x14, 0x1b, 0x81, 0x32, 0xea,
-	0x42, 0xd5, 0xc1, 0xb3, 0x2e, 0xa6, 0x03, 0xd7, 0x9e, 0x30, 0x9b, 0x38, 0x6a, 0x25, 0x07, 0x5c,
-	0xc2, 0xa0, 0x9f, 0xa1, 0xc6, 0x1d, 0xa2, 0xd7, 0xe4, 0xc4, 0xb2, 0x5e, 0xb9, 0x7c, 0xb7, 0x6a,
-	0xcd, 0xf7, 0xbc, 0x97, 0xe9, 0x79, 0xd2, 0x39, 0xbd, 0x93, 0xc2, 0x75, 0xe6, 0x30, 0x77, 0x6e,
-	0xa4, 0x9a, 0x41, 0x2d, 0xd8, 0x0d, 0xe5, 0x5d, 0x3c, 0xc2, 0x0c, 0xab, 0x75, 0xff, 0x2a, 0xc8,
-	0xe2, 0xc6, 0xa7, 0x70, 0xb0, 0x94, 0x1c, 0xdd, 0x87, 0xe2, 0x1d, 0x9e, 0x8b, 0x43, 0xf5, 0x7e,
-	0xa2, 0x1a, 0x6c, 0xfe, 0xe0, 0x39, 0xac, 0x16, 0x9a, 0x4a, 0x6b, 0xdb, 0xe0, 0x1f, 0x4f, 0x0b,
-	0xc7, 0x8a, 0x76, 0x03, 0x75, 0x0e, 0x95, 0x2f, 0x56, 0x0f, 0xb6, 0xa6, 0xfe, 0x42, 0x10, 0xf7,
-	0xa3, 0x35, 0xbd, 0x37, 0x02, 0xbc, 0xf6, 0x2d, 0xec, 0x09, 0x8d, 0x0b, 0x62, 0x5a, 0x61, 0xe8,
-	0x35, 0xd8, 0x1e, 0x11, 0xd3, 0xfa, 0x0c, 0x33, 0xd3, 0x32, 0x99, 0xe9, 0xef, 0xb7, 0x64, 0x24,
-	0x64, 0xa8, 0x09, 0x15, 0xef, 0x5b, 0xf8, 0xea, 0x6f, 0xbf, 0x64, 0xc4, 0x45, 0xda, 0x4f, 0xb0,
-	0xe7, 0xb1, 0xca, 0xdb, 0x6f, 0x48, 0x79, 0x51, 0x8e, 0x2e, 0x3d, 0xea, 0x41, 0x69, 0x20, 0x36,
-	0xe1, 0x33, 0x56, 0xda, 0x8f, 0x32, 0x7d, 0x8b, 0xef, 0xdc, 0x08, 0xe1, 0xda, 0xdf, 0x0a, 0xd4,
-	0x92, 0xe6, 0x45, 0xfa, 0x7c, 0xbd, 0x90, 0x97, 0xcf, 0x96, 0xdb, 0x48, 0x63, 0x08, 0x0c, 0x53,
-	0x7e, 0x61, 0xa2, 0xdd, 0x7b, 0x9e, 0x11, 0x76, 0xee, 0x95, 0x36, 0xb5, 0x20, 0x3c, 0x13, 0xdf,
-	0x8d, 0xef, 0x60, 0x27, 0x01, 0x4b, 0xb9, 0x0a, 0x4f, 0xe2, 0x57, 0x21, 0x57, 0x36, 0xc7, 0x6e,
-	0xcb, 0x13, 0xa8, 0xf3, 0x0b, 0x28, 0x1f, 0x77, 0x56, 0xfd, 0xfa, 0x31, 0xa8, 0x5f, 0x67, 0x0e,
-	0xb3, 0x99, 0x8d, 0x43, 0xe0, 0x43, 0x28, 0x87, 0x6a, 0x62, 0x9b, 0x91, 0x00, 0x75, 0xa0, 0x84,
-	0x05, 0xc0, 0xf7, 0xb5, 0xd2, 0x7e, 0x2f, 0x73, 0xbf, 0xbe, 0x85, 0xb9, 0x11, 0x02, 0xb5, 0xbb,
-	0xa0, 0xc6, 0x45, 0xb6, 0x45, 0x90, 0x3e, 0x0f, 0x6b, 0x5c, 0xb0, 0x24, 0x62, 0x95, 0xdb, 0x8a,
-	0x8c, 0xd7, 0x7e, 0xdf, 0x84, 0x1a, 0x5f, 0x93, 0x0a, 0xdd, 0x62, 0x24, 0x10, 0x6c, 0xb0, 0xf9,
-	0x84, 0x07, 0xa2, 0x6c, 0xf8, 0xbf, 0xff, 0xe7, 0xf2, 0x77, 0x0a, 0x3b, 0x0e, 0x9e, 0x5d, 0xdd,
-	0xce, 0xa9, 0x3d, 0x30, 0x47, 0xbd, 0xae, 0xba, 0x9d, 0x83, 0x24, 0x09, 0x41, 0x1f, 0x79, 0x01,
-	0x9d, 0xf1, 0xec, 0x54, 0x77, 0x7c, 0xfc, 0x9b, 0x0b, 0xf8, 0xd3, 0x39, 0xc3, 0x94, 0xc3, 0x23,
-	0x6d, 0x74, 0x05, 0x0f, 0x4c, 0x4a, 0xc9, 0xc0, 0x36, 0xbd, 0xdd, 0xf0, 0xca, 0x26, 0x4a, 0xaf,
-	0xb6, 0x3c, 0x20, 0xfc, 0xb4, 0x7b, 0x5d, 0x63, 0x11, 0x8c, 0xfa, 0x50, 0x4b, 0x0a, 0x63, 0x55,
-	0x35, 0x1f, 0x69, 0x2a, 0x1e, 0xbd, 0x82, 0xbd, 0x09, 0x76, 0xc7, 0x36, 0xa5, 0x5c, 0xcc, 0xef,
-	0x97, 0x7a, 0xe8, 0xd3, 0xbe, 0xb5, 0x9c, 0xf6, 0xa4, 0x73, 0x61, 0xa4, 0x21, 0x17, 0x08, 0x45,
-	0xdf, 0x79, 0x7b, 0x7d, 0x42, 0xd1, 0x4a, 0x1e, 0x4b, 0x84, 0xc2, 0xf1, 0xa6, 0x9f, 0x99, 0x69,
-	0x4b, 0xda, 0x2f, 0x41, 0x27, 0x58, 0x2f, 0x45, 0x5f, 0x44, 0x7d, 0x82, 0x67, 0xa8, 0x9e, 0x75,
-	0xaa, 0xcb, 0xda, 0xc4, 0x3f, 0x0a, 0xec, 0xcb, 0x3b, 0x10, 0x89, 0x4a, 0x60, 0x97, 0x6b, 0xc9,
-	0x89, 0x7a, 0xb6, 0xdc, 0x58, 0x3a, 0x95, 0x10, 0x87, 0x3c, 0xbc, 0xba, 0xca, 0xec, 0x8d, 0x3b,
-	0xa8, 0xa5, 0x29, 0xa6, 0x64, 0xf1, 0xf3, 0x64, 0x3d, 0xcd, 0x5d, 0x39, 0x62, 0x55, 0xd5, 0x0e,
-	0xaa, 0xea, 0x7a, 0x27, 0xdf, 0x86, 0x42, 0xaf, 0x2b, 0x0e, 0x3d, 0xcf, 0x55, 0x2e, 0xf4, 0xba,
-	0xda, 0x5f, 0x0a, 0x20, 0x2e, 0x58, 0xbb, 0x15, 0x1f, 0x02, 0x44, 0x7d, 0x57, 0x74, 0xe2, 0x98,
-	0x24, 0xe0, 0x38, 0xf1, 0xf2, 0x85, 0x5e, 0x13, 0xb5, 0x18, 0x71, 0x04, 0x32, 0xf4, 0x2e, 0x54,
-	0xa3, 0xef, 0x73, 0x97, 0x8c, 0xd5, 0x0d, 0x5f, 0x4b, 0x92, 0x7a, 0x83, 0x90, 0x27, 0xb9, 0x8a,
-	0xae, 0xa9, 0xba, 0xe9, 0x2b, 0xca, 0x62, 0xed, 0x8f, 0x02, 0xef, 0xff, 0xeb, 0x1d, 0xdd, 0x33,
-	0x80, 0xeb, 0xf9, 0x04, 0x9f, 0xdb, 0x23, 0x86, 0x5d, 0x11, 0xb9, 0xd5, 0x55, 0x2e, 0xa6, 0x8f,
-	0x9e, 0x42, 0xf9, 0x25, 0x9e, 0x0b, 0x70, 0x31, 0x07, 0x38, 0x52, 0x47, 0x9f, 0x40, 0x19, 0x8b,
-	0x80, 0x50, 0x75, 0x23, 0x77, 0xec, 0x22, 0x10, 0x7a, 0x11, 0x9b, 0x5e, 0x36, 0x7d, 0xe3, 0xef,
-	0x67, 0x11, 0x2c, 0x19, 0x5e, 0x7e, 0x13, 0xc3, 0xcb, 0x42, 0xba, 0xc5, 0xdb, 0xae, 0xf2, 0x1f,
-	0xdb, 0x2e, 0xfa, 0x58, 0x9a, 0x53, 0xf2, 0x39, 0x1a, 0x62, 0xda, 0x7f, 0x6e, 0xc1, 0xfe, 0x65,
-	0xf8, 0x8a, 0xeb, 0xc4, 0xb4, 0xd1, 0x57, 0x50, 0x4d, 0xbe, 0xa3, 0xd0, 0x83, 0x04, 0x75, 0x9f,
-	0xd8, 0x56, 0xe3, 0xf1, 0x8a, 0x79, 0x2b, 0xf5, 0x11, 0xa6, 0xdd, 0x43, 0x53, 0xa8, 0x26, 0x9f,
-	0x43, 0x68, 0xc5, 0xd4, 0x9b, 0xfa, 0x20, 0x5b, 0x65, 0x36, 0xfd, 0xa5, 0xa5, 0xdd, 0x43, 0x7d,
-	0xa8, 0x26, 0x87, 0xf0, 0x55, 0x66, 0x53, 0xc7, 0xf5, 0xc6, 0xe2, 0x01, 0x70, 0xde, 0xe4, 0xb8,
-	0xb6, 0x8a, 0x37, 0x75, 0xb0, 0x4b, 0xe7, 0x25, 0xb0, 0x1d, 0x1f, 0x59, 0xd1, 0xa3, 0xbc, 0xa3,
-	0x2d, 0xe7, 0xd4, 0xd7, 0x9b, 0x84, 0xe3, 0x71, 0x09, 0xae, 0x6a, 0x76, 0x5c, 0xa4, 0x82, 0x90,
-	0x1d, 0x17, 0x39, 0x0b, 0xb8, 0xd9, 0x64, 0x17, 0xc9, 0x8e, 0xcb, 0x1a, 0x66, 0xd3, 0x1b, 0x54,
-	0x3c, 0x6c, 0x79, 0xcc, 0xa6, 0x76, 0x8e, 0x95, 0x61, 0x0b, 0x59, 0x33, 0xc2, 0x26, 0x73, 0xea,
-	0x79, 0xd5, 0x03, 0x47, 0x4e, 0x4b, 0xdf, 0xbc, 0xc1, 0xff, 0x75, 0x72, 0xc3, 0xff, 0x7e, 0xf0,
-	0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xa9, 0x2e, 0x36, 0xb7, 0x98, 0x11, 0x00, 0x00,
+func init() { proto.RegisterFile("northbound.proto", fileDescriptor_northbound_f5a622a1465d5cc2) }
+
+var fileDescriptor_northbound_f5a622a1465d5cc2 = []byte{
+	// 2208 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x5a, 0xdd, 0x6e, 0x23, 0x49,
+	0x15, 0x9e, 0xb6, 0xf3, 0xe7, 0x93, 0xd8, 0xc9, 0xd6, 0x38, 0xa1, 0xa7, 0x59, 0x42, 0x68, 0x56,
+	0x10, 0x01, 0xeb, 0x04, 0xef, 0x68, 0x36, 0x33, 0xcc, 0x0e, 0x64, 0x6c, 0x67, 0xc6, 0xda, 0xec,
+	0x24, 0xdb, 0xf1, 0x64, 0x10, 0x48, 0xa0, 0x8e, 0xbb, 0x6c, 0xf7, 0xc6, 0xee, 0xf2, 0x74, 0x97,
+	0x37, 0xf1, 0x02, 0x42, 0xdc, 0xf0, 0x02, 0x80, 0x78, 0x00, 0x5e, 0x01, 0xc4, 0x5b, 0xf0, 0x00,
+	0xdc, 0x20, 0xee, 0xb9, 0x42, 0x02, 0x89, 0x3b, 0x54, 0x3f, 0xfd, 0xeb, 0xb6, 0xdd, 0x9e, 0x41,
+	0xe2, 0x2a, 0xae, 0x53, 0xf5, 0x9d, 0x53, 0xe7, 0x9c, 0x3a, 0x3f, 0x55, 0x1d, 0xd8, 0x72, 0x88,
+	0x4b, 0x7b, 0x57, 0x64, 0xe4, 0x58, 0x95, 0xa1, 0x4b, 0x28, 0x41, 0xea, 0xc0, 0xec, 0x0e, 0xcc,
+	0x0a, 0x71, 0xdb, 0x47, 0x6e, 0xa5, 0x4d, 0x9c, 0x8e, 0xdd, 0x1d, 0xb9, 0x26, 0x25, 0xae, 0x76,
+	0xaf, 0x4b, 0x48, 0xb7, 0x8f, 0x0f, 0xf8, 0xba, 0xab, 0x51, 0xe7, 0xc0, 0x74, 0xc6, 0x02, 0xa4,
+	0xdd, 0xe3, 0xcb, 0xc5, 0x8c, 0x77, 0xd0, 0x26, 0x83, 0x01, 0x71, 0xe4, 0xd4, 0x6e, 0x12, 0x75,
+	0xe3, 0x9a, 0xc3, 0x21, 0x76, 0x3d, 0x39, 0x8f, 0xa2, 0x32, 0x04, 0x4d, 0x3f, 0x82, 0x9d, 0x53,
+	0xdb, 0xa3, 0x2f, 0x30, 0xbd, 0x21, 0xee, 0x75, 0xb3, 0xee, 0x19, 0xd8, 0x1b, 0x12, 0xc7, 0xc3,
+	0x68, 0x17, 0xc0, 0x09, 0xa8, 0xaa, 0xb2, 0x97, 0xdf, 0x2f, 0x18, 0x11, 0x8a, 0x7e, 0x09, 0xdb,
+	0x35, 0x17, 0x9b, 0x14, 0x4b, 0xac, 0x67, 0xe0, 0xd7, 0x23, 0xec, 0x51, 0xf4, 0x11, 0xac, 0xc9,
+	0x65, 0x02, 0xb6, 0x5e, 0xfd, 0x5a, 0x65, 0x9a, 0xa6, 0x15, 0x09, 0x36, 0x02, 0x88, 0x8e, 0x61,
+	0x27, 0xc9, 0x57, 0xee, 0xe8, 0x63, 0xd8, 0x6c, 0xf3, 0x19, 0xeb, 0xc5, 0xc2, 0xfc, 0x93, 0x48,
+	0xfd, 0x6f, 0x79, 0xd8, 0x96, 0x83, 0x97, 0x43, 0xcb, 0xa4, 0xb8, 0xe6, 0xda, 0x14, 0xbb, 0xb6,
+	0x89, 0x4a, 0x90, 0xb3, 0x2d, 0x55, 0xd9, 0x53, 0xf6, 0x0b, 0x46, 0xce, 0xb6, 0xd0, 0x03, 0x58,
+	0x75, 0xf0, 0xcd, 0x0b, 0x73, 0x80, 0x55, 0xd8, 0x53, 0xf6, 0xd7, 0xab, 0xef, 0x56, 0x84, 0xa1,
+	0x2b, 0xbe, 0xa1, 0x2b, 0x17, 0xd4, 0xb5, 0x9d, 0xee, 0xa5, 0xd9, 0x1f, 0x61, 0xc3, 0x5f, 0x8c,
+	0xea, 0x50, 0x72, 0xf0, 0x4d, 0x1d, 0x7b, 0x6d, 0xd7, 0x1e, 0x52, 0x9b, 0x38, 0xea, 0x7a, 0x06,
+	0x78, 0x02, 0x83, 0x7e, 0x01, 0x65, 0xa1, 0x90, 0xd7, 0x22, 0xc7, 0x96, 0x75, 0xe6, 0x8a, 0xdd,
+	0xaa, 0x65, 0xae, 0x79, 0x73, 0xae, 0xe6, 0x71, 0xe5, 0x2a, 0xb5, 0x14, 0x5e, 0x0d, 0x87, 0xba,
+	0x63, 0x23, 0x55, 0x0c, 0xda, 0x87, 0xcd, 0x80, 0x5e, 0xc7, 0x7d, 0x4c, 0xb1, 0xba, 0xcd, 0x8f,
+	0x42, 0x92, 0x8c, 0x4e, 0x60, 0x13, 0xdf, 0x0e, 0x71, 0x9b, 0x62, 0xeb, 0x12, 0xbb, 0x1e, 0xd3,
+	0x77, 0x77, 0x8a, 0xbe, 0x2f, 0x9b, 0x0e, 0x7d, 0x70, 0x5f, 0xe8, 0x9b, 0x04, 0x69, 0xcf, 0xe0,
+	0xde, 0xd4, 0x4d, 0xa2, 0x2d, 0xc8, 0x5f, 0xe3, 0xb1, 0x74, 0x0e, 0xfb, 0x89, 0xca, 0xb0, 0xfc,
+	0x39, 0x63, 0xa4, 0xe6, 0xf6, 0x94, 0xfd, 0x0d, 0x43, 0x0c, 0x1e, 0xe5, 0x8e, 0x14, 0xfd, 0x0a,
+	0xb6, 0x05, 0x34, 0x79, 0x40, 0x9b, 0xb0, 0x3a, 0xe2, 0x13, 0xfe, 0xf9, 0x39, 0x58, 0xd0, 0x8a,
+	0x86, 0x8f, 0xd7, 0x7f, 0x0c, 0x77, 0xe5, 0x8a, 0x53, 0x62, 0x5a, 0xc1, 0x11, 0xd2, 0x61, 0xa3,
+	0x4f, 0x4c, 0xeb, 0x13, 0x4c, 0x4d, 0xcb, 0xa4, 0x26, 0xdf, 0xef, 0x9a, 0x11, 0xa3, 0xa1, 0x3d,
+	0x58, 0x67, 0x63, 0xa9, 0x2b, 0xdf, 0xfe, 0x9a, 0x11, 0x25, 0xe9, 0x3f, 0x87, 0xbb, 0x8c, 0x6b,
+	0x72, 0xfb, 0x5a, 0x22, 0xbe, 0x0a, 0x61, 0xf0, 0xa0, 0x26, 0xac, 0xb5, 0xe5, 0x26, 0x38, 0xc7,
+	0xf5, 0xea, 0xfb, 0x73, 0x75, 0x8b, 0xee, 0xdc, 0x08, 0xe0, 0xfa, 0x3f, 0x14, 0x28, 0xc7, 0xc5,
+	0xcb, 0x30, 0xfc, 0xe1, 0x44, 0x7c, 0x3f, 0x9e, 0x2e, 0x23, 0x8d, 0x83, 0x2f, 0xd8, 0x13, 0x07,
+	0x2f, 0xdc, 0x3d, 0xd3, 0x8c, 0xd0, 0x13, 0x96, 0x22, 0xd5, 0x9c, 0xd4, 0x4c, 0x8e, 0xb5, 0x9f,
+	0x40, 0x31, 0x06, 0x4b, 0x39, 0x0a, 0x1f, 0x46, 0x8f, 0x42, 0xa6, 0xac, 0x10, 0x39, 0x2d, 0x1f,
+	0xc2, 0xb6, 0x38, 0xc8, 0x49, 0x73, 0xcf, 0xcb, 0x83, 0x5f, 0xf8, 0x79, 0xb0, 0xe1, 0x50, 0x9b,
+	0xda, 0x38, 0x00, 0xbe, 0x0b, 0x85, 0x60, 0x99, 0xdc, 0x66, 0x48, 0x40, 0x35, 0x58, 0xc3, 0x12,
+	0xc0, 0x75, 0x5d, 0xaf, 0x7e, 0x73, 0xee, 0x7e, 0xb9, 0x84, 0xb1, 0x11, 0x00, 0xf5, 0x6b, 0x3f,
+	0x57, 0x86, 0xb2, 0xa5, 0x93, 0x3e, 0x0d, 0x72, 0xa5, 0x3f, 0xa5, 0x2a, 0x8b, 0x49, 0x49, 0xe2,
+	0xf5, 0x3f, 0xac, 0x40, 0x59, 0xcc, 0x25, 0x12, 0xe6, 0xa4, 0x27, 0x10, 0x2c, 0xd1, 0xf1, 0x50,
+	0x38, 0xa2, 0x60, 0xf0, 0xdf, 0x2c, 0x26, 0x2c, 0x6e, 0x60, 0xc1, 0x43, 0xcd, 0x8b, 0x98, 0x88,
+	0xd2, 0xfe, 0xcf, 0xa9, 0xf6, 0x29, 0x14, 0x1d, 0x7c, 0x73, 0xde, 0x1b, 0x7b, 0x76, 0xdb, 0xec,
+	0x37, 0xeb, 0xea, 0x46, 0x06, 0x26, 0x71, 0x08, 0x7a, 0xc8, 0x9c, 0x7e, 0x23, 0x22, 0x58, 0x2d,
+	0x72, 0xfc, 0x97, 0x27, 0xf0, 0x4f, 0xc7, 0x14, 0x7b, 0x02, 0x1e, 0xae, 0x46, 0xe7, 0xf0, 0x8e,
+	0xe9, 0x79, 0xa4, 0x6d, 0x9b, 0x6c, 0x37, 0x22, 0xfb, 0xc9, 0x34, 0xaf, 0x4f, 0x77, 0x9a, 0xb0,
+	0x5c, 0xb3, 0x6e, 0x4c, 0x82, 0xd1, 0x25, 0x94, 0xe3, 0xc4, 0x48, 0x06, 0xcf, 0xc6, 0x34, 0x15,
+	0x8f, 0xce, 0xe0, 0xee, 0x10, 0xbb, 0x03, 0xdb, 0xf3, 0x04, 0x59, 0x9c, 0x41, 0x75, 0x97, 0xb3,
+	0xfd, 0xca, 0x74, 0xb6, 0xc7, 0xb5, 0x53, 0x23, 0x0d, 0x39, 0xc1, 0x50, 0xd6, 0xb8, 0xaf, 0x2e,
+	0xce, 0x50, 0x96, 0xad, 0xc3, 0x04, 0x43, 0xa9, 0xf8, 0x1e, 0x8f, 0xde, 0xb4, 0xa9, 0xb4, 0xf2,
+	0xb5, 0xff, 0x06, 0xe5, 0x4b, 0xff, 0xa5, 0x5f, 0x75, 0x16, 0x4b, 0x07, 0xcf, 0xc3, 0x9a, 0x24,
+	0xb2, 0x41, 0x65, 0x9e, 0x77, 0xa6, 0x95, 0xa4, 0x7f, 0x2b, 0xb0, 0x93, 0xdc, 0x81, 0x4c, 0x0a,
+	0x04, 0x36, 0xc5, 0xaa, 0x64, 0x52, 0x68, 0x4c, 0x17, 0x96, 0xce, 0xaa, 0xf2, 0x32, 0xce, 0x47,
+	0x64, 0xf2, 0x24, 0x77, 0xed, 0x1a, 0xca, 0x69, 0x0b, 0x53, 0x32, 0xc6, 0x47, 0xf1, 0xdc, 0x9d,
+	0x39, 0x4b, 0x45, 0x32, 0xb8, 0xed, 0x67, 0xf0, 0xc5, 0x2c, 0x5f, 0x85, 0x5c, 0xb3, 0xae, 0xe6,
+	0x32, 0x87, 0x44, 0xae, 0x59, 0xd7, 0xff, 0xa2, 0x00, 0x12, 0x84, 0x85, 0xcb, 0xfe, 0x2e, 0x40,
+	0x58, 0xe3, 0x65, 0xd5, 0x8f, 0x50, 0x7c, 0x1e, 0xc7, 0x2c, 0xee, 0xbc, 0x16, 0xf1, 0xd3, 0x64,
+	0x94, 0x86, 0xbe, 0x01, 0xa5, 0x70, 0x7c, 0xe2, 0x92, 0x81, 0xba, 0xc4, 0x57, 0x25, 0xa8, 0xac,
+	0x79, 0x63, 0x94, 0xf3, 0xf0, 0xb8, 0xab, 0xcb, 0x7c, 0x61, 0x92, 0xac, 0xff, 0x6e, 0x49, 0xf4,
+	0x1a, 0x8b, 0x99, 0xee, 0x31, 0x40, 0x6b, 0x3c, 0xc4, 0x27, 0x76, 0x9f, 0x62, 0x57, 0xcd, 0x4d,
+	0x09, 0x97, 0x68, 0xb6, 0x8c, 0xac, 0x47, 0x8f, 0xa0, 0xf0, 0x31, 0x1e, 0x4b, 0x70, 0x3e, 0x03,
+	0x38, 0x5c, 0x8e, 0x7e, 0x00, 0x05, 0x2c, 0x1d, 0xe2, 0xa9, 0x4b, 0x99, 0x7d, 0x17, 0x82, 0xd0,
+	0xf3, 0x48, 0xa7, 0xb4, 0xcc, 0x85, 0x7f, 0x67, 0x1e, 0x83, 0xf4, 0x46, 0x89, 0xe9, 0x71, 0x8d,
+	0xc7, 0xe7, 0x2e, 0xee, 0xd8, 0xb7, 0xea, 0x4a, 0x16, 0x3d, 0x82, 0xe5, 0xcc, 0x82, 0xc3, 0xb0,
+	0xde, 0xac, 0x66, 0xb1, 0x60, 0xb8, 0x9e, 0xf5, 0x4b, 0x43, 0xb3, 0x8b, 0x2f, 0xec, 0x2f, 0xb0,
+	0xba, 0xb6, 0xa7, 0xec, 0x17, 0x8d, 0x60, 0xcc, 0x3c, 0xc7, 0x7e, 0xb7, 0xc8, 0x35, 0x76, 0xd4,
+	0x82, 0xf0, 0x5c, 0x40, 0xf0, 0x4f, 0x50, 0x8b, 0x0c, 0xae, 0x3c, 0x4a, 0x1c, 0xec, 0xa9, 0x10,
+	0x9e, 0xa0, 0x90, 0xaa, 0xff, 0x3a, 0x27, 0x9a, 0xc0, 0x89, 0x54, 0x12, 0x6d, 0x5f, 0x94, 0x37,
+	0x6c, 0x5f, 0xd0, 0x93, 0x44, 0xbf, 0x97, 0xcd, 0x89, 0x01, 0x06, 0xbd, 0xc7, 0x0a, 0xf6, 0x2d,
+	0x3d, 0x0f, 0xf4, 0xcc, 0x73, 0x3d, 0xe3, 0x44, 0x54, 0x03, 0xa0, 0xa1, 0x9e, 0xe2, 0xb0, 0x7c,
+	0x7d, 0xba, 0x9c, 0x40, 0x7b, 0x23, 0x02, 0xd3, 0x3f, 0x83, 0x42, 0x30, 0x81, 0xbe, 0x0f, 0x2b,
+	0xe2, 0x20, 0xf1, 0x90, 0x58, 0x40, 0x75, 0x09, 0x63, 0xce, 0x11, 0x7d, 0x8f, 0x75, 0x4c, 0x79,
+	0xdc, 0xe4, 0x8d, 0x90, 0xa0, 0xf7, 0xa0, 0x6c, 0x60, 0x8f, 0x12, 0x57, 0xb6, 0x45, 0x99, 0xf3,
+	0x98, 0x6d, 0xc9, 0x20, 0xcc, 0x94, 0xc7, 0x6c, 0x4b, 0x3f, 0x83, 0xbb, 0xaf, 0x4c, 0xda, 0xee,
+	0xd5, 0x7a, 0xa6, 0xd3, 0xcd, 0x1a, 0xf5, 0x1a, 0xac, 0x79, 0xb6, 0xd3, 0xc6, 0x17, 0xf8, 0x35,
+	0x17, 0xb7, 0x64, 0x04, 0x63, 0xfd, 0x3f, 0x0a, 0xac, 0x08, 0x66, 0x19, 0x98, 0x30, 0x69, 0x4e,
+	0x1b, 0x07, 0x4c, 0xe4, 0x18, 0x3d, 0x87, 0x02, 0x19, 0x62, 0x97, 0x37, 0x1d, 0xdc, 0xa5, 0xa5,
+	0xea, 0xb7, 0xa6, 0x2b, 0x24, 0xc4, 0x55, 0xce, 0x7c, 0x84, 0x11, 0x82, 0xd1, 0xa3, 0xc0, 0x51,
+	0x4b, 0x99, 0xed, 0x22, 0x11, 0xfa, 0x01, 0x14, 0x02, 0x9e, 0x08, 0x60, 0xa5, 0x66, 0x34, 0x8e,
+	0x5b, 0x8d, 0xad, 0x3b, 0xec, 0xf7, 0xcb, 0xf3, 0x3a, 0xfb, 0xad, 0xb0, 0xdf, 0xf5, 0xc6, 0x69,
+	0xa3, 0xd5, 0xd8, 0xca, 0xe9, 0x0f, 0xe1, 0xde, 0x33, 0x4c, 0x4f, 0x59, 0x11, 0xa6, 0x62, 0x53,
+	0x17, 0xf8, 0x75, 0x26, 0x93, 0xea, 0x47, 0xa0, 0xa5, 0x41, 0x65, 0xac, 0x45, 0x6d, 0xa5, 0xc4,
+	0x6d, 0xa5, 0xff, 0x59, 0x09, 0xee, 0x45, 0x8d, 0xdb, 0x21, 0x71, 0x29, 0x0b, 0x8a, 0x0e, 0x71,
+	0x07, 0x26, 0xf5, 0xdb, 0x18, 0x85, 0x67, 0x86, 0x38, 0x11, 0x7d, 0x8f, 0x75, 0xda, 0x1c, 0x96,
+	0xfd, 0xb6, 0xe4, 0x23, 0x62, 0xc1, 0x9f, 0x7f, 0xd3, 0xbb, 0xcb, 0x7d, 0x28, 0x8b, 0x1d, 0xfb,
+	0xec, 0x33, 0x59, 0xea, 0x37, 0x0a, 0x94, 0x9b, 0x83, 0x14, 0x18, 0x8b, 0x49, 0xce, 0x2e, 0x7b,
+	0x4c, 0xf2, 0xe5, 0x86, 0x84, 0xb1, 0xc2, 0xcb, 0xae, 0x13, 0x81, 0x68, 0x71, 0x77, 0x89, 0xd1,
+	0xd0, 0x0e, 0xac, 0x58, 0xee, 0xd8, 0x18, 0x39, 0xb2, 0x2c, 0xcb, 0x91, 0xfe, 0x27, 0x05, 0xb6,
+	0x13, 0xbb, 0x92, 0xbe, 0x6b, 0x42, 0x29, 0xfe, 0xf2, 0xa4, 0x2a, 0x59, 0xcd, 0x9d, 0x00, 0xa6,
+	0x5d, 0xe9, 0x72, 0x6f, 0x79, 0xa5, 0xfb, 0xbb, 0x02, 0x5f, 0xaa, 0xf5, 0x70, 0xfb, 0x3a, 0xd2,
+	0x0b, 0x44, 0xee, 0xbd, 0x22, 0x90, 0x88, 0x1b, 0x38, 0x22, 0x42, 0x89, 0xfb, 0x29, 0x97, 0x8c,
+	0xef, 0x27, 0x13, 0x47, 0x24, 0x53, 0x6a, 0x0f, 0x4a, 0xc3, 0x73, 0x80, 0xb0, 0x4b, 0xe7, 0xd1,
+	0x5b, 0xaa, 0xee, 0xcf, 0xbc, 0x08, 0x54, 0x42, 0x1d, 0x8c, 0x08, 0x56, 0xff, 0xad, 0x02, 0xea,
+	0xa4, 0x8e, 0xd2, 0x3d, 0x8f, 0x61, 0xd5, 0xec, 0xf7, 0xc9, 0x0d, 0xb6, 0x54, 0x25, 0xf3, 0x2e,
+	0x7d, 0x08, 0x4b, 0x2f, 0x16, 0x76, 0x6c, 0xbc, 0x48, 0xf5, 0x92, 0x08, 0xfd, 0x5f, 0x39, 0x28,
+	0xb7, 0x5c, 0xf3, 0x73, 0xec, 0x7a, 0xf8, 0x99, 0x6b, 0x0e, 0x7b, 0xd9, 0x92, 0xef, 0x11, 0x2c,
+	0x7b, 0xd4, 0x74, 0xe9, 0x02, 0x89, 0x5e, 0x00, 0xd0, 0x25, 0x14, 0x2c, 0xdb, 0xc5, 0xed, 0x48,
+	0x56, 0x3d, 0x9a, 0x51, 0x05, 0x53, 0xb6, 0x56, 0xa9, 0xfb, 0x78, 0x23, 0x64, 0xc5, 0xb2, 0x53,
+	0x8f, 0x0c, 0x59, 0x5f, 0x27, 0x8a, 0x6b, 0xc1, 0x08, 0xc6, 0x6c, 0x6e, 0x60, 0xde, 0xd6, 0xf1,
+	0x90, 0xf6, 0x78, 0x93, 0x55, 0x34, 0x82, 0x71, 0xac, 0x01, 0x5b, 0x79, 0x9b, 0x06, 0x4c, 0xff,
+	0x36, 0x14, 0x82, 0x9d, 0xa1, 0x4d, 0x58, 0x3f, 0xbe, 0xb8, 0x38, 0xab, 0x5d, 0xfc, 0xf4, 0xc4,
+	0x38, 0xfb, 0x64, 0xeb, 0x0e, 0x2a, 0x42, 0x41, 0x12, 0x5a, 0x67, 0x5b, 0x8a, 0x7e, 0x03, 0x05,
+	0xae, 0x53, 0xc3, 0xea, 0x62, 0xf4, 0x00, 0x96, 0x3a, 0xac, 0x7d, 0x56, 0x32, 0x1b, 0x93, 0xaf,
+	0x67, 0xb5, 0x96, 0x92, 0x45, 0x6a, 0x2d, 0x25, 0xfa, 0xef, 0x15, 0xd8, 0x4e, 0x58, 0xf5, 0x7f,
+	0xd9, 0x4b, 0x3d, 0x84, 0x65, 0x6c, 0x75, 0x83, 0x9c, 0x30, 0xa3, 0xc1, 0x09, 0xd4, 0x37, 0x04,
+	0x42, 0x6f, 0x41, 0xe9, 0x78, 0x64, 0xd9, 0xf4, 0xc4, 0xc6, 0x7d, 0xab, 0x6e, 0x77, 0x3a, 0xec,
+	0x51, 0xb5, 0xc3, 0x06, 0xf2, 0xfc, 0x89, 0x01, 0xcb, 0x7e, 0x57, 0xb8, 0x43, 0x5c, 0xff, 0x5d,
+	0x47, 0x8e, 0xd8, 0x6a, 0xb3, 0xe3, 0x37, 0xf1, 0x05, 0x43, 0x0c, 0xf4, 0x3f, 0xe6, 0x00, 0x38,
+	0x5b, 0x71, 0xe5, 0x4b, 0xbe, 0xaa, 0xcf, 0x4e, 0x1f, 0x61, 0xe1, 0xce, 0x2f, 0x5a, 0xb8, 0xe3,
+	0xed, 0xc3, 0xd2, 0xdb, 0xb4, 0x0f, 0x4c, 0xb1, 0x36, 0x25, 0xae, 0xba, 0x2c, 0x15, 0x63, 0x03,
+	0xb6, 0x73, 0x6a, 0x0f, 0xb0, 0x47, 0xcd, 0xc1, 0x90, 0x9f, 0xdc, 0xbc, 0x11, 0x12, 0xd0, 0x13,
+	0x58, 0xb6, 0xec, 0x4e, 0xc7, 0x53, 0x57, 0xb9, 0x1f, 0x66, 0xe5, 0xac, 0x98, 0xcd, 0x0d, 0x01,
+	0xd3, 0xff, 0xa9, 0x40, 0xf9, 0xd3, 0x11, 0x76, 0xc7, 0x7c, 0xfa, 0x94, 0x74, 0xb3, 0xe5, 0x85,
+	0xd0, 0x60, 0xb9, 0x85, 0x0d, 0x56, 0xf5, 0xd5, 0xcc, 0x72, 0x09, 0x0b, 0x8d, 0xc0, 0xd3, 0x4a,
+	0xcb, 0x1e, 0x60, 0x6e, 0xe4, 0xbc, 0x11, 0x12, 0x90, 0x0a, 0xab, 0xd8, 0xb1, 0xf8, 0xdc, 0x32,
+	0x9f, 0xf3, 0x87, 0xcc, 0xa4, 0x7d, 0x7b, 0x60, 0x53, 0x6e, 0xb8, 0xa2, 0x21, 0x06, 0xfa, 0x2b,
+	0xd8, 0x4e, 0xe8, 0x2c, 0x43, 0xe3, 0x09, 0x63, 0x44, 0xdd, 0x30, 0x32, 0xde, 0x9b, 0x63, 0x4f,
+	0xf1, 0x10, 0xe1, 0x83, 0xaa, 0x7f, 0x2d, 0xc1, 0xce, 0x8b, 0xe0, 0xbb, 0x5b, 0x2d, 0xb2, 0x1c,
+	0xbd, 0x82, 0x52, 0xfc, 0xcb, 0x17, 0x7a, 0x27, 0xc6, 0xfb, 0x92, 0xd8, 0x96, 0x76, 0x38, 0xe3,
+	0x65, 0x3b, 0xf5, 0xb3, 0x99, 0x7e, 0x07, 0x8d, 0xa0, 0x14, 0xff, 0x80, 0x85, 0x66, 0x7c, 0x5f,
+	0x48, 0xfd, 0x84, 0xa6, 0x1d, 0x66, 0x07, 0x04, 0x62, 0x2f, 0xa1, 0x14, 0xff, 0xdc, 0x31, 0x4b,
+	0x6c, 0xea, 0x87, 0x11, 0x6d, 0xd2, 0x00, 0x82, 0x6f, 0xfc, 0x61, 0x7c, 0x16, 0xdf, 0xd4, 0x27,
+	0xf4, 0x74, 0xbe, 0x04, 0x36, 0xa2, 0x1f, 0x07, 0xd0, 0xfb, 0x59, 0x3f, 0x22, 0x08, 0x9e, 0x95,
+	0xc5, 0xbe, 0x39, 0x44, 0xfd, 0xe2, 0xb7, 0x3f, 0xf3, 0xfd, 0x92, 0x78, 0x0e, 0xd1, 0x0e, 0xb3,
+	0x03, 0xa2, 0x62, 0xe3, 0x6f, 0x68, 0xf3, 0xfd, 0xb2, 0x80, 0xd8, 0xf4, 0xe7, 0xb9, 0xa8, 0xdb,
+	0xb2, 0x88, 0x4d, 0x7d, 0x37, 0x9b, 0xe9, 0xb6, 0x80, 0xeb, 0x1c, 0xb7, 0x25, 0x79, 0x56, 0xb2,
+	0x2e, 0x0f, 0x14, 0x69, 0xc3, 0x46, 0xf4, 0x8e, 0x3a, 0x4b, 0x60, 0xca, 0x5d, 0x56, 0xdb, 0x9b,
+	0x97, 0xfa, 0xf5, 0x3b, 0x87, 0x0a, 0xfa, 0x95, 0x02, 0x68, 0xf2, 0x06, 0x86, 0x3e, 0x98, 0x51,
+	0x45, 0xa7, 0x5d, 0xf5, 0xb4, 0xfb, 0x8b, 0x81, 0x02, 0x45, 0x3f, 0x83, 0x62, 0xec, 0x42, 0x84,
+	0x66, 0x3d, 0x01, 0xa7, 0xdc, 0x9c, 0xb4, 0xac, 0x57, 0x1e, 0xfd, 0x0e, 0x72, 0xa1, 0xd8, 0x1c,
+	0x64, 0x94, 0x95, 0x76, 0xdd, 0xd2, 0x0e, 0x32, 0xaf, 0x0f, 0xf4, 0xfb, 0x19, 0x6c, 0x25, 0xfb,
+	0x70, 0xf4, 0xdd, 0x59, 0xde, 0x49, 0xbd, 0x97, 0x68, 0xd5, 0x45, 0x20, 0x81, 0x70, 0x17, 0x8a,
+	0xb1, 0xe6, 0x6b, 0x96, 0xc2, 0x69, 0xbd, 0xaf, 0x76, 0x90, 0x79, 0x7d, 0xd4, 0xa1, 0xb1, 0x77,
+	0x9c, 0x59, 0x32, 0xd3, 0x1e, 0x7c, 0xb4, 0xac, 0x6d, 0xa0, 0xd0, 0x2f, 0x56, 0x41, 0x67, 0xc9,
+	0x4a, 0x6b, 0x2f, 0xb4, 0x83, 0xcc, 0xeb, 0x7d, 0xfd, 0x9e, 0xae, 0xfd, 0x68, 0x45, 0xfc, 0x1b,
+	0xca, 0x95, 0xf8, 0xfb, 0xc1, 0x7f, 0x07, 0x00, 0xc6, 0xb1, 0x56, 0xe5, 0xe4, 0x22, 0x00, 0x00,
 }
@@ -0,0 +1,262 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	commonProtos "magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/configurator/protos"
+	"magma/orc8r/cloud/go/services/configurator/storage"
+	commonStorage "magma/orc8r/cloud/go/storage"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (srv *nbConfiguratorServicer) QueryAuditLog(context context.Context, req *protos.QueryAuditLogRequest) (*protos.QueryAuditLogResponse, error) {
+	emptyRes := &protos.QueryAuditLogResponse{}
+	if req.NetworkID == "" {
+		return emptyRes, status.Error(codes.InvalidArgument, "network ID must be provided")
+	}
+	store, err := srv.factory.StartTransaction(context, &storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return emptyRes, err
+	}
+
+	entries, err := store.LoadAuditEntries(req.ToAuditEntryFilter())
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	return &protos.QueryAuditLogResponse{Entries: protos.FromStorageAuditEntries(entries)}, store.Commit()
+}
+
+// auditLogger records the writes made within a transaction to the audit log,
+// attributed to the caller of the RPC. Writes are diffed by loading the
+// written network or entity before and after the write.
+type auditLogger struct {
+	store     storage.ConfiguratorStorage
+	actor     string
+	timestamp int64
+}
+
+func newAuditLogger(ctx context.Context, store storage.ConfiguratorStorage) *auditLogger {
+	actor := ""
+	if identity := commonProtos.GetClientIdentity(ctx); identity != nil {
+		actor = identity.HashString()
+	}
+	return &auditLogger{store: store, actor: actor, timestamp: time.Now().Unix()}
+}
+
+// loadEntity returns the full entity, or nil if it doesn't exist
+func (al *auditLogger) loadEntity(networkID string, entityID commonStorage.TypeAndKey) (*storage.NetworkEntity, error) {
+	loadResult, err := al.store.LoadEntities(
+		networkID,
+		storage.EntityLoadFilter{IDs: []commonStorage.TypeAndKey{entityID}},
+		storage.EntityLoadCriteria{LoadMetadata: true, LoadConfig: true, LoadAssocsFromThis: true, LoadPermissions: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(loadResult.Entities) == 0 {
+		return nil, nil
+	}
+	return &loadResult.Entities[0], nil
+}
+
+// loadNetwork returns the full network, or nil if it doesn't exist
+func (al *auditLogger) loadNetwork(networkID string) (*storage.Network, error) {
+	loadResult, err := al.store.LoadNetworks([]string{networkID}, storage.FullNetworkLoadCriteria)
+	if err != nil {
+		return nil, err
+	}
+	if len(loadResult.Networks) == 0 {
+		return nil, nil
+	}
+	return &loadResult.Networks[0], nil
+}
+
+// logEntityCreate records the creation of an entity
+func (al *auditLogger) logEntityCreate(networkID string, entityID commonStorage.TypeAndKey) error {
+	after, err := al.loadEntity(networkID, entityID)
+	if err != nil {
+		return err
+	}
+	return al.logEntityWrite(networkID, entityID, storage.ChangeCreate, nil, after)
+}
+
+// logEntityUpdate records an update or deletion of an entity, given the
+// entity as it was before the update
+func (al *auditLogger) logEntityUpdate(networkID string, update storage.EntityUpdateCriteria, before *storage.NetworkEntity) error {
+	if update.DeleteEntity {
+		return al.logEntityWrite(networkID, update.GetTypeAndKey(), storage.ChangeDelete, before, nil)
+	}
+	after, err := al.loadEntity(networkID, update.GetTypeAndKey())
+	if err != nil {
+		return err
+	}
+	return al.logEntityWrite(networkID, update.GetTypeAndKey(), storage.ChangeUpdate, before, after)
+}
+
+// logNetworkCreate records the creation of a network
+func (al *auditLogger) logNetworkCreate(networkID string) error {
+	after, err := al.loadNetwork(networkID)
+	if err != nil {
+		return err
+	}
+	return al.logNetworkWrite(networkID, storage.ChangeCreate, nil, after)
+}
+
+// loadNetworksForUpdates returns the networks targeted by a batch of network
+// updates, keyed by ID
+func (al *auditLogger) loadNetworksForUpdates(updates []storage.NetworkUpdateCriteria) (map[string]*storage.Network, error) {
+	ret := map[string]*storage.Network{}
+	for _, update := range updates {
+		network, err := al.loadNetwork(update.ID)
+		if err != nil {
+			return nil, err
+		}
+		ret[update.ID] = network
+	}
+	return ret, nil
+}
+
+// logNetworkUpdates records the updates and deletions made by a batch of
+// network updates, given the networks as they were before the updates
+func (al *auditLogger) logNetworkUpdates(updates []storage.NetworkUpdateCriteria, before map[string]*storage.Network) error {
+	for _, update := range updates {
+		if update.DeleteNetwork {
+			err := al.logNetworkWrite(update.ID, storage.ChangeDelete, before[update.ID], nil)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		after, err := al.loadNetwork(update.ID)
+		if err != nil {
+			return err
+		}
+		err = al.logNetworkWrite(update.ID, storage.ChangeUpdate, before[update.ID], after)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (al *auditLogger) logEntityWrite(networkID string, entityID commonStorage.TypeAndKey, op storage.ChangeOperation, before *storage.NetworkEntity, after *storage.NetworkEntity) error {
+	return al.store.WriteAuditEntry(storage.AuditEntry{
+		NetworkID: networkID,
+		Entity:    &entityID,
+		Operation: op,
+		Actor:     al.actor,
+		Timestamp: al.timestamp,
+		Diffs:     getEntityDiffs(before, after),
+	})
+}
+
+func (al *auditLogger) logNetworkWrite(networkID string, op storage.ChangeOperation, before *storage.Network, after *storage.Network) error {
+	return al.store.WriteAuditEntry(storage.AuditEntry{
+		NetworkID: networkID,
+		Operation: op,
+		Actor:     al.actor,
+		Timestamp: al.timestamp,
+		Diffs:     getNetworkDiffs(before, after),
+	})
+}
+
+func getEntityDiffs(before *storage.NetworkEntity, after *storage.NetworkEntity) []storage.AuditFieldDiff {
+	beforeFields, afterFields := getAuditedEntityFields(before), getAuditedEntityFields(after)
+	return getFieldDiffs(beforeFields, afterFields)
+}
+
+func getNetworkDiffs(before *storage.Network, after *storage.Network) []storage.AuditFieldDiff {
+	beforeFields, afterFields := getAuditedNetworkFields(before), getAuditedNetworkFields(after)
+	return getFieldDiffs(beforeFields, afterFields)
+}
+
+// getAuditedEntityFields renders the audited fields of an entity as strings.
+// Parent associations aren't audited since they're recorded as writes to the
+// parent entities.
+func getAuditedEntityFields(ent *storage.NetworkEntity) map[string]string {
+	ret := map[string]string{}
+	if ent == nil {
+		return ret
+	}
+	ret["name"] = ent.Name
+	ret["description"] = ent.Description
+	ret["physical_id"] = ent.PhysicalID
+	ret["config"] = string(ent.Config)
+
+	assocs := make([]string, 0, len(ent.Associations))
+	for _, assoc := range ent.Associations {
+		assocs = append(assocs, assoc.String())
+	}
+	sort.Strings(assocs)
+	ret["associations"] = strings.Join(assocs, ",")
+
+	// System-generated ACL fields change on every write, so they're excluded
+	if len(ent.Permissions) > 0 {
+		acls := make([]storage.ACL, 0, len(ent.Permissions))
+		for _, acl := range ent.Permissions {
+			acl.ID, acl.Version = "", 0
+			acls = append(acls, acl)
+		}
+		marshaledACLs, err := json.Marshal(acls)
+		if err == nil {
+			ret["permissions"] = string(marshaledACLs)
+		}
+	}
+	return ret
+}
+
+func getAuditedNetworkFields(network *storage.Network) map[string]string {
+	ret := map[string]string{}
+	if network == nil {
+		return ret
+	}
+	ret["name"] = network.Name
+	ret["description"] = network.Description
+	for configType, config := range network.Configs {
+		ret[fmt.Sprintf("configs.%s", configType)] = string(config)
+	}
+	return ret
+}
+
+// getFieldDiffs returns the fields which differ between the two sets of
+// field values, ordered by field name. A missing field is equivalent to an
+// empty one.
+func getFieldDiffs(before map[string]string, after map[string]string) []storage.AuditFieldDiff {
+	fields := map[string]bool{}
+	for field := range before {
+		fields[field] = true
+	}
+	for field := range after {
+		fields[field] = true
+	}
+	sortedFields := make([]string, 0, len(fields))
+	for field := range fields {
+		sortedFields = append(sortedFields, field)
+	}
+	sort.Strings(sortedFields)
+
+	ret := []storage.AuditFieldDiff{}
+	for _, field := range sortedFields {
+		if before[field] != after[field] {
+			ret = append(ret, storage.AuditFieldDiff{Field: field, Before: before[field], After: after[field]})
+		}
+	}
+	return ret
+}
@@ -0,0 +1,144 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers_test
+
+import (
+	"context"
+	"testing"
+
+	commonProtos "magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/configurator/protos"
+	"magma/orc8r/cloud/go/services/configurator/servicers"
+	"magma/orc8r/cloud/go/services/configurator/storage"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNorthboundConfiguratorServicer_AuditLog(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &storage.DefaultIDGenerator{}, sqorc.GetSqlBuilder())
+	assert.NoError(t, factory.InitializeServiceStorage())
+	srv, err := servicers.NewNorthboundConfiguratorServicer(factory)
+	assert.NoError(t, err)
+
+	internalCtx := context.Background()
+	_, err = srv.CreateNetworks(internalCtx, &protos.CreateNetworksRequest{Networks: []*protos.Network{{Id: "n1", Name: "network 1"}}})
+	assert.NoError(t, err)
+	_, err = srv.CreateEntities(internalCtx, &protos.CreateEntitiesRequest{
+		NetworkID: storage.InternalNetworkID,
+		Entities: []*protos.NetworkEntity{
+			{
+				Type: configurator.OperatorEntityType,
+				Id:   "op1",
+				Permissions: []*protos.ACL{
+					{
+						Scope:      &protos.ACL_ScopeWildcard{ScopeWildcard: protos.ACL_WILDCARD_ALL},
+						Permission: protos.ACL_OWN,
+						Type:       &protos.ACL_TypeWildcard{TypeWildcard: protos.ACL_WILDCARD_ALL},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	// op1 creates g1 and t1 -> g1, renames g1, then deletes t1
+	opCtx := commonProtos.NewOperatorIdentity("op1").NewContextWithIdentity(context.Background())
+	op1 := commonProtos.NewOperatorIdentity("op1").HashString()
+	_, err = srv.CreateEntities(opCtx, &protos.CreateEntitiesRequest{
+		NetworkID: "n1",
+		Entities: []*protos.NetworkEntity{
+			{Type: "gateway", Id: "g1", Name: "foo"},
+			{Type: "tier", Id: "t1", Assocs: []*protos.EntityID{{Type: "gateway", Id: "g1"}}},
+		},
+	})
+	assert.NoError(t, err)
+	_, err = srv.UpdateEntities(opCtx, &protos.UpdateEntitiesRequest{
+		NetworkID: "n1",
+		Updates:   []*protos.EntityUpdateCriteria{{Type: "gateway", Key: "g1", NewName: &wrappers.StringValue{Value: "bar"}}},
+	})
+	assert.NoError(t, err)
+	_, err = srv.DeleteEntities(opCtx, &protos.DeleteEntitiesRequest{NetworkID: "n1", ID: []*protos.EntityID{{Type: "tier", Id: "t1"}}})
+	assert.NoError(t, err)
+
+	res, err := srv.QueryAuditLog(internalCtx, &protos.QueryAuditLogRequest{NetworkID: "n1"})
+	assert.NoError(t, err)
+	assert.Len(t, res.Entries, 5)
+	for _, entry := range res.Entries {
+		assert.NotEmpty(t, entry.Id)
+		assert.NotZero(t, entry.Timestamp)
+		entry.Id, entry.Timestamp = "", 0
+	}
+	expected := []*protos.AuditEntry{
+		{
+			NetworkID: "n1",
+			Operation: protos.Change_CREATE,
+			Diffs:     []*protos.AuditFieldDiff{{Field: "name", After: "network 1"}},
+		},
+		{
+			NetworkID: "n1",
+			Entity:    &protos.EntityID{Type: "gateway", Id: "g1"},
+			Operation: protos.Change_CREATE,
+			Actor:     op1,
+			Diffs:     []*protos.AuditFieldDiff{{Field: "name", After: "foo"}},
+		},
+		{
+			NetworkID: "n1",
+			Entity:    &protos.EntityID{Type: "tier", Id: "t1"},
+			Operation: protos.Change_CREATE,
+			Actor:     op1,
+			Diffs:     []*protos.AuditFieldDiff{{Field: "associations", After: "gateway-g1"}},
+		},
+		{
+			NetworkID: "n1",
+			Entity:    &protos.EntityID{Type: "gateway", Id: "g1"},
+			Operation: protos.Change_UPDATE,
+			Actor:     op1,
+			Diffs:     []*protos.AuditFieldDiff{{Field: "name", Before: "foo", After: "bar"}},
+		},
+		{
+			NetworkID: "n1",
+			Entity:    &protos.EntityID{Type: "tier", Id: "t1"},
+			Operation: protos.Change_DELETE,
+			Actor:     op1,
+			Diffs:     []*protos.AuditFieldDiff{{Field: "associations", Before: "gateway-g1"}},
+		},
+	}
+	assert.Equal(t, expected, res.Entries)
+
+	// Filter by entity and actor
+	res, err = srv.QueryAuditLog(internalCtx, &protos.QueryAuditLogRequest{
+		NetworkID: "n1",
+		Entity:    &protos.EntityID{Type: "gateway", Id: "g1"},
+		Actor:     &wrappers.StringValue{Value: op1},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, res.Entries, 2)
+	res, err = srv.QueryAuditLog(internalCtx, &protos.QueryAuditLogRequest{
+		NetworkID: "n1",
+		Actor:     &wrappers.StringValue{Value: commonProtos.NewOperatorIdentity("op2").HashString()},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, res.Entries)
+
+	// Limit
+	res, err = srv.QueryAuditLog(internalCtx, &protos.QueryAuditLogRequest{NetworkID: "n1", Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, res.Entries, 1)
+
+	_, err = srv.QueryAuditLog(internalCtx, &protos.QueryAuditLogRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,285 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+
+	"magma/orc8r/cloud/go/services/configurator/protos"
+	"magma/orc8r/cloud/go/services/configurator/storage"
+	commonStorage "magma/orc8r/cloud/go/storage"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// networkExportFormatVersion is the current version of the NetworkExport
+// document format. Bump this if the document changes in a way that older
+// importers can't handle.
+const networkExportFormatVersion = 1
+
+func (srv *nbConfiguratorServicer) ExportNetwork(context context.Context, req *protos.ExportNetworkRequest) (*protos.NetworkExport, error) {
+	emptyRes := &protos.NetworkExport{}
+	store, err := srv.factory.StartTransaction(context, &storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return emptyRes, err
+	}
+
+	loadedNetworks, err := store.LoadNetworks([]string{req.NetworkID}, storage.FullNetworkLoadCriteria)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	if len(loadedNetworks.Networks) == 0 {
+		store.Rollback()
+		return emptyRes, status.Errorf(codes.NotFound, "network %s not found", req.NetworkID)
+	}
+
+	// Loading assocs in one direction is enough to recreate the graph
+	loadCriteria := storage.EntityLoadCriteria{LoadMetadata: true, LoadConfig: true, LoadAssocsFromThis: true, LoadPermissions: true}
+	loadedEntities, err := store.LoadEntities(req.NetworkID, storage.EntityLoadFilter{}, loadCriteria)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	// Operators can only export networks whose entities they can all read
+	checker, err := getOperatorPermissionChecker(context, store)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	if checker != nil {
+		exportedIDs := make([]commonStorage.TypeAndKey, 0, len(loadedEntities.Entities))
+		for _, ent := range loadedEntities.Entities {
+			exportedIDs = append(exportedIDs, ent.GetTypeAndKey())
+		}
+		err = checker.checkPermission(req.NetworkID, storage.ReadPermission, exportedIDs...)
+		if err != nil {
+			store.Rollback()
+			return emptyRes, err
+		}
+	}
+
+	// System-generated fields are cleared since they are regenerated on
+	// import
+	network := loadedNetworks.Networks[0]
+	network.Version = 0
+	ret := &protos.NetworkExport{
+		FormatVersion: networkExportFormatVersion,
+		Network:       protos.FromStorageNetwork(network),
+		Entities:      make([]*protos.NetworkEntity, 0, len(loadedEntities.Entities)),
+	}
+	for _, ent := range loadedEntities.Entities {
+		ent.GraphID, ent.Version = "", 0
+		for i := range ent.Permissions {
+			ent.Permissions[i].ID = ""
+		}
+		ret.Entities = append(ret.Entities, protos.FromStorageNetworkEntity(ent))
+	}
+	return ret, store.Commit()
+}
+
+func (srv *nbConfiguratorServicer) ImportNetwork(context context.Context, req *protos.ImportNetworkRequest) (*protos.ImportNetworkResponse, error) {
+	emptyRes := &protos.ImportNetworkResponse{}
+	network, entities, err := getNetworkToImport(req)
+	if err != nil {
+		// Config validation errors are already statuses with details
+		if _, isStatus := status.FromError(err); isStatus {
+			return emptyRes, err
+		}
+		return emptyRes, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	store, err := srv.factory.StartTransaction(context, &storage.TxOptions{ReadOnly: false})
+	if err != nil {
+		return emptyRes, err
+	}
+
+	// Like CreateEntities, only ACLs which match the imported entities
+	// directly apply since they don't exist yet
+	checker, err := getOperatorPermissionChecker(context, store)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	if checker != nil {
+		for _, ent := range entities {
+			err = checker.checkPermission(network.ID, storage.WritePermission, ent.GetTypeAndKey())
+			if err != nil {
+				store.Rollback()
+				return emptyRes, err
+			}
+		}
+	}
+
+	auditLog := newAuditLogger(context, store)
+	createdNetwork, err := store.CreateNetwork(network)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	err = auditLog.logNetworkCreate(createdNetwork.ID)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	createdEntities := make([]*protos.NetworkEntity, 0, len(entities))
+	for _, ent := range entities {
+		createdEntity, err := store.CreateEntity(network.ID, ent)
+		if err != nil {
+			store.Rollback()
+			return emptyRes, err
+		}
+		err = auditLog.logEntityCreate(network.ID, createdEntity.GetTypeAndKey())
+		if err != nil {
+			store.Rollback()
+			return emptyRes, err
+		}
+		createdEntities = append(createdEntities, protos.FromStorageNetworkEntity(createdEntity))
+	}
+
+	ret := &protos.ImportNetworkResponse{
+		CreatedNetwork:  protos.FromStorageNetwork(createdNetwork),
+		CreatedEntities: createdEntities,
+	}
+	if req.DryRun {
+		return ret, store.Rollback()
+	}
+	return ret, store.Commit()
+}
+
+// getNetworkToImport validates an import request and returns the network and
+// entities to create. Entities are ordered such that every entity comes after
+// all the entities that it has associations to.
+func getNetworkToImport(req *protos.ImportNetworkRequest) (storage.Network, []storage.NetworkEntity, error) {
+	export := req.Export
+	if export == nil || export.Network == nil {
+		return storage.Network{}, nil, fmt.Errorf("export must contain a network")
+	}
+	if export.FormatVersion != networkExportFormatVersion {
+		return storage.Network{}, nil, fmt.Errorf("unsupported export format version %d", export.FormatVersion)
+	}
+
+	network := export.Network.ToNetwork()
+	network.Version = 0
+	oldNetworkID := network.ID
+	if req.NewNetworkID != "" {
+		network.ID = req.NewNetworkID
+	}
+	if network.ID == "" {
+		return storage.Network{}, nil, fmt.Errorf("network ID must be provided")
+	}
+	if err := networkConfigsAreValid(network.Configs); err != nil {
+		return storage.Network{}, nil, err
+	}
+
+	entsByTk := map[commonStorage.TypeAndKey]storage.NetworkEntity{}
+	for _, pEnt := range export.Entities {
+		ent := pEnt.ToNetworkEntity()
+		if _, exists := entsByTk[ent.GetTypeAndKey()]; exists {
+			return storage.Network{}, nil, fmt.Errorf("duplicate entity %s", ent.GetTypeAndKey())
+		}
+		if err := entityConfigIsValid(ent.Type, ent.Config); err != nil {
+			return storage.Network{}, nil, err
+		}
+
+		// Parent associations are implied by the associations of the other
+		// entities in the export
+		ent.GraphID, ent.Version, ent.ParentAssociations = "", 0, nil
+		for i := range ent.Permissions {
+			ent.Permissions[i].ID = ""
+			ent.Permissions[i].Scope.NetworkIDs = replaceNetworkID(ent.Permissions[i].Scope.NetworkIDs, oldNetworkID, network.ID)
+		}
+		entsByTk[ent.GetTypeAndKey()] = ent
+	}
+
+	orderedEnts, err := orderEntitiesForCreation(entsByTk)
+	if err != nil {
+		return storage.Network{}, nil, err
+	}
+	return network, orderedEnts, nil
+}
+
+// orderEntitiesForCreation topologically sorts entities so that each entity
+// is created after all entities it has associations to.
+func orderEntitiesForCreation(entsByTk map[commonStorage.TypeAndKey]storage.NetworkEntity) ([]storage.NetworkEntity, error) {
+	remainingAssocs := map[commonStorage.TypeAndKey]int{}
+	parentsByTk := map[commonStorage.TypeAndKey][]commonStorage.TypeAndKey{}
+	ready := &tkHeap{}
+	for tk, ent := range entsByTk {
+		assocs := map[commonStorage.TypeAndKey]bool{}
+		for _, assoc := range ent.Associations {
+			if _, exists := entsByTk[assoc]; !exists {
+				return nil, fmt.Errorf("entity %s has an association to %s which is not in the export", tk, assoc)
+			}
+			if !assocs[assoc] {
+				assocs[assoc] = true
+				parentsByTk[assoc] = append(parentsByTk[assoc], tk)
+			}
+		}
+		remainingAssocs[tk] = len(assocs)
+		if len(assocs) == 0 {
+			*ready = append(*ready, tk)
+		}
+	}
+	heap.Init(ready)
+
+	// Ready entities are created in TypeAndKey order so the creation order is
+	// deterministic
+	ret := make([]storage.NetworkEntity, 0, len(entsByTk))
+	for ready.Len() > 0 {
+		tk := heap.Pop(ready).(commonStorage.TypeAndKey)
+		ret = append(ret, entsByTk[tk])
+
+		for _, parent := range parentsByTk[tk] {
+			remainingAssocs[parent]--
+			if remainingAssocs[parent] == 0 {
+				heap.Push(ready, parent)
+			}
+		}
+	}
+	if len(ret) != len(entsByTk) {
+		return nil, fmt.Errorf("entity associations in the export contain a cycle")
+	}
+	return ret, nil
+}
+
+// tkHeap is a min-heap of TypeAndKeys, implementing heap.Interface
+type tkHeap []commonStorage.TypeAndKey
+
+func (h tkHeap) Len() int           { return len(h) }
+func (h tkHeap) Less(i, j int) bool { return commonStorage.IsTKLessThan(h[i], h[j]) }
+func (h tkHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *tkHeap) Push(x interface{}) {
+	*h = append(*h, x.(commonStorage.TypeAndKey))
+}
+
+func (h *tkHeap) Pop() interface{} {
+	old := *h
+	tk := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return tk
+}
+
+func replaceNetworkID(networkIDs []string, oldID string, newID string) []string {
+	if networkIDs == nil {
+		return nil
+	}
+	ret := make([]string, 0, len(networkIDs))
+	for _, networkID := range networkIDs {
+		if networkID == oldID {
+			networkID = newID
+		}
+		ret = append(ret, networkID)
+	}
+	return ret
+}
@@ -11,12 +11,26 @@ package servicers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	commonProtos "magma/orc8r/cloud/go/protos"
-	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/services/configurator"
 	"magma/orc8r/cloud/go/services/configurator/protos"
 	"magma/orc8r/cloud/go/services/configurator/storage"
+	commonStorage "magma/orc8r/cloud/go/storage"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// watchChangesPollInterval is how often the change log is polled for a
+	// watcher which has caught up with all existing changes
+	watchChangesPollInterval = time.Second
+	// watchChangesBatchSize is the max number of changes loaded per poll
+	watchChangesBatchSize = 100
 )
 
 type nbConfiguratorServicer struct {
@@ -79,10 +93,12 @@ func (srv *nbConfiguratorServicer) CreateNetworks(context context.Context, req *
 		return emptyRes, err
 	}
 
+	auditLog := newAuditLogger(context, store)
 	createdNetworks := []*protos.Network{}
 	for _, network := range req.Networks {
 		err = networkConfigsAreValid(network.Configs)
 		if err != nil {
+			store.Rollback()
 			return emptyRes, err
 		}
 		createdNetwork, err := store.CreateNetwork(network.ToNetwork())
@@ -90,6 +106,11 @@ func (srv *nbConfiguratorServicer) CreateNetworks(context context.Context, req *
 			store.Rollback()
 			return emptyRes, err
 		}
+		err = auditLog.logNetworkCreate(createdNetwork.ID)
+		if err != nil {
+			store.Rollback()
+			return emptyRes, err
+		}
 		createdNetworks = append(createdNetworks, protos.FromStorageNetwork(createdNetwork))
 	}
 	return &protos.CreateNetworksResponse{CreatedNetworks: createdNetworks}, store.Commit()
@@ -106,11 +127,23 @@ func (srv *nbConfiguratorServicer) UpdateNetworks(context context.Context, req *
 	for _, pUpdate := range req.Updates {
 		err = networkConfigsAreValid(pUpdate.ConfigsToAddOrUpdate)
 		if err != nil {
+			store.Rollback()
 			return void, err
 		}
 		updates = append(updates, pUpdate.ToNetworkUpdateCriteria())
 	}
+	auditLog := newAuditLogger(context, store)
+	networksBefore, err := auditLog.loadNetworksForUpdates(updates)
+	if err != nil {
+		store.Rollback()
+		return void, err
+	}
 	err = store.UpdateNetworks(updates)
+	if err != nil {
+		store.Rollback()
+		return void, toUpdateStatusError(err)
+	}
+	err = auditLog.logNetworkUpdates(updates, networksBefore)
 	if err != nil {
 		store.Rollback()
 		return void, err
@@ -129,11 +162,22 @@ func (srv *nbConfiguratorServicer) DeleteNetworks(context context.Context, req *
 	for _, networkID := range req.NetworkIDs {
 		deleteRequests = append(deleteRequests, storage.NetworkUpdateCriteria{ID: networkID, DeleteNetwork: true})
 	}
+	auditLog := newAuditLogger(context, store)
+	networksBefore, err := auditLog.loadNetworksForUpdates(deleteRequests)
+	if err != nil {
+		store.Rollback()
+		return void, err
+	}
 	err = store.UpdateNetworks(deleteRequests)
 	if err != nil {
 		store.Rollback()
 		return void, err
 	}
+	err = auditLog.logNetworkUpdates(deleteRequests, networksBefore)
+	if err != nil {
+		store.Rollback()
+		return void, err
+	}
 	return void, store.Commit()
 }
 
@@ -144,16 +188,53 @@ func (srv *nbConfiguratorServicer) LoadEntities(context context.Context, req *pr
 		return emptyRes, err
 	}
 
-	loadFilter := protos.ToEntityLoadFilter(req.TypeFilter, req.KeyFilter, req.EntityIDs)
+	loadFilter := req.ToEntityLoadFilter()
 	loadResult, err := store.LoadEntities(req.NetworkID, loadFilter, req.Criteria.ToEntityLoadCriteria())
 	if err != nil {
 		store.Rollback()
 		return emptyRes, err
 	}
-	return &protos.LoadEntitiesResponse{
-		Entities: protos.FromStorageNetworkEntities(loadResult.Entities),
-		NotFound: protos.FromTKs(loadResult.EntitiesNotFound),
-	}, store.Commit()
+	checker, err := getOperatorPermissionChecker(context, store)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	if checker != nil {
+		loadedIDs := make([]commonStorage.TypeAndKey, 0, len(loadResult.Entities))
+		for _, ent := range loadResult.Entities {
+			loadedIDs = append(loadedIDs, ent.GetTypeAndKey())
+		}
+		err = checker.checkPermission(req.NetworkID, storage.ReadPermission, loadedIDs...)
+		if err != nil {
+			store.Rollback()
+			return emptyRes, err
+		}
+	}
+	res := &protos.LoadEntitiesResponse{
+		Entities:      protos.FromStorageNetworkEntities(loadResult.Entities),
+		NotFound:      protos.FromTKs(loadResult.EntitiesNotFound),
+		NextPageToken: loadResult.NextPageToken,
+	}
+	if req.LoadTombstones {
+		tombstones, err := store.LoadTombstones(req.NetworkID, loadFilter)
+		if err != nil {
+			store.Rollback()
+			return emptyRes, err
+		}
+		if checker != nil {
+			tombstonedIDs := make([]commonStorage.TypeAndKey, 0, len(tombstones))
+			for _, tombstone := range tombstones {
+				tombstonedIDs = append(tombstonedIDs, tombstone.Entity.GetTypeAndKey())
+			}
+			err = checker.checkPermission(req.NetworkID, storage.ReadPermission, tombstonedIDs...)
+			if err != nil {
+				store.Rollback()
+				return emptyRes, err
+			}
+		}
+		res.Tombstones = protos.FromStorageTombstones(tombstones)
+	}
+	return res, store.Commit()
 }
 
 func (srv *nbConfiguratorServicer) CreateEntities(context context.Context, req *protos.CreateEntitiesRequest) (*protos.CreateEntitiesResponse, error) {
@@ -163,16 +244,36 @@ func (srv *nbConfiguratorServicer) CreateEntities(context context.Context, req *
 		return emptyRes, err
 	}
 
+	checker, err := getOperatorPermissionChecker(context, store)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	auditLog := newAuditLogger(context, store)
 	createdEntities := []*protos.NetworkEntity{}
 	for _, entity := range req.Entities {
 		if err := entityConfigIsValid(entity.Type, entity.Config); err != nil {
+			store.Rollback()
 			return emptyRes, err
 		}
+		if checker != nil {
+			// The entity doesn't exist yet, so only ACLs which match it
+			// directly apply
+			if err := checker.checkPermission(req.NetworkID, storage.WritePermission, entity.ToNetworkEntity().GetTypeAndKey()); err != nil {
+				store.Rollback()
+				return emptyRes, err
+			}
+		}
 		createdEntity, err := store.CreateEntity(req.NetworkID, entity.ToNetworkEntity())
 		if err != nil {
 			store.Rollback()
 			return emptyRes, err
 		}
+		err = auditLog.logEntityCreate(req.NetworkID, createdEntity.GetTypeAndKey())
+		if err != nil {
+			store.Rollback()
+			return emptyRes, err
+		}
 		createdEntities = append(createdEntities, protos.FromStorageNetworkEntity(createdEntity))
 	}
 	return &protos.CreateEntitiesResponse{CreatedEntities: createdEntities}, store.Commit()
@@ -185,19 +286,46 @@ func (srv *nbConfiguratorServicer) UpdateEntities(context context.Context, req *
 		return emptyRes, err
 	}
 
+	checker, err := getOperatorPermissionChecker(context, store)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	auditLog := newAuditLogger(context, store)
 	updatedEntities := map[string]*protos.NetworkEntity{}
 	for _, update := range req.Updates {
 		if update.NewConfig != nil {
 			if err := entityConfigIsValid(update.Type, update.NewConfig.Value); err != nil {
+				store.Rollback()
+				return emptyRes, err
+			}
+		}
+		if checker != nil {
+			if err := checker.checkPermission(req.NetworkID, storage.WritePermission, commonStorage.TypeAndKey{Type: update.Type, Key: update.Key}); err != nil {
+				store.Rollback()
 				return emptyRes, err
 			}
 		}
 
-		updatedEntity, err := store.UpdateEntity(req.NetworkID, update.ToEntityUpdateCriteria())
+		entityBefore, err := auditLog.loadEntity(req.NetworkID, commonStorage.TypeAndKey{Type: update.Type, Key: update.Key})
 		if err != nil {
 			store.Rollback()
 			return emptyRes, err
 		}
+		storageUpdate := update.ToEntityUpdateCriteria()
+		updatedEntity, err := store.UpdateEntity(req.NetworkID, storageUpdate)
+		if err != nil {
+			store.Rollback()
+			return emptyRes, toUpdateStatusError(err)
+		}
+		err = auditLog.logEntityUpdate(req.NetworkID, storageUpdate, entityBefore)
+		if err != nil {
+			store.Rollback()
+			return emptyRes, err
+		}
+		if update.DeleteEntity {
+			continue
+		}
 		updatedEntities[update.Key] = protos.FromStorageNetworkEntity(updatedEntity)
 	}
 	return &protos.UpdateEntitiesResponse{UpdatedEntities: updatedEntities}, store.Commit()
@@ -210,35 +338,251 @@ func (srv *nbConfiguratorServicer) DeleteEntities(context context.Context, req *
 		return void, err
 	}
 
+	checker, err := getOperatorPermissionChecker(context, store)
+	if err != nil {
+		store.Rollback()
+		return void, err
+	}
+	if checker != nil {
+		err = checker.checkPermission(req.NetworkID, storage.WritePermission, protos.ToTypeAndKeys(req.ID)...)
+		if err != nil {
+			store.Rollback()
+			return void, err
+		}
+	}
+	auditLog := newAuditLogger(context, store)
 	for _, entityID := range req.ID {
 		request := storage.EntityUpdateCriteria{
 			Type:         entityID.Type,
 			Key:          entityID.Id,
 			DeleteEntity: true,
 		}
+		entityBefore, err := auditLog.loadEntity(req.NetworkID, request.GetTypeAndKey())
+		if err != nil {
+			store.Rollback()
+			return void, err
+		}
 		_, err = store.UpdateEntity(req.NetworkID, request)
 		if err != nil {
 			store.Rollback()
 			return void, err
 		}
+		err = auditLog.logEntityUpdate(req.NetworkID, request, entityBefore)
+		if err != nil {
+			store.Rollback()
+			return void, err
+		}
 	}
 	return void, store.Commit()
 }
 
-func networkConfigsAreValid(configs map[string][]byte) error {
-	for typeVal, config := range configs {
-		_, err := serde.Deserialize(configurator.SerdeDomain, typeVal, config)
+func (srv *nbConfiguratorServicer) TraverseGraph(context context.Context, req *protos.TraverseGraphRequest) (*protos.TraverseGraphResponse, error) {
+	emptyRes := &protos.TraverseGraphResponse{}
+	if req.Start == nil {
+		return emptyRes, status.Error(codes.InvalidArgument, "start entity must be provided")
+	}
+	if req.MaxDepth == 0 {
+		return emptyRes, status.Error(codes.InvalidArgument, "max depth must be positive")
+	}
+	if req.MaxDepth > storage.MaxTraversalDepth {
+		return emptyRes, status.Errorf(codes.InvalidArgument, "max depth must be at most %d", storage.MaxTraversalDepth)
+	}
+	store, err := srv.factory.StartTransaction(context, &storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return emptyRes, err
+	}
+
+	loadCriteria := storage.EntityLoadCriteria{}
+	if req.Criteria != nil {
+		loadCriteria = req.Criteria.ToEntityLoadCriteria()
+	}
+	result, err := store.TraverseGraph(req.NetworkID, req.ToGraphTraversal(), loadCriteria)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	checker, err := getOperatorPermissionChecker(context, store)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	if checker != nil {
+		reachedIDs := make([]commonStorage.TypeAndKey, 0, len(result.Entities)+1)
+		reachedIDs = append(reachedIDs, req.Start.ToTypeAndKey())
+		for _, ent := range result.Entities {
+			reachedIDs = append(reachedIDs, ent.GetTypeAndKey())
+		}
+		err = checker.checkPermission(req.NetworkID, storage.ReadPermission, reachedIDs...)
+		if err != nil {
+			store.Rollback()
+			return emptyRes, err
+		}
+	}
+	return &protos.TraverseGraphResponse{
+		Entities: protos.FromStorageNetworkEntities(result.Entities),
+		Edges:    protos.FromStorageGraphEdges(result.Edges),
+	}, store.Commit()
+}
+
+func (srv *nbConfiguratorServicer) RestoreEntity(context context.Context, req *protos.RestoreEntityRequest) (*protos.NetworkEntity, error) {
+	emptyRes := &protos.NetworkEntity{}
+	if req.Id == nil {
+		return emptyRes, status.Error(codes.InvalidArgument, "ID of entity to restore must be provided")
+	}
+	store, err := srv.factory.StartTransaction(context, &storage.TxOptions{ReadOnly: false})
+	if err != nil {
+		return emptyRes, err
+	}
+
+	checker, err := getOperatorPermissionChecker(context, store)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	if checker != nil {
+		err = checker.checkPermission(req.NetworkID, storage.WritePermission, req.Id.ToTypeAndKey())
+		if err != nil {
+			store.Rollback()
+			return emptyRes, err
+		}
+	}
+	restoredEntity, err := store.RestoreEntity(req.NetworkID, req.Id.ToTypeAndKey())
+	if err != nil {
+		store.Rollback()
+		if storage.IsNotFound(err) {
+			return emptyRes, status.Errorf(codes.NotFound, "no tombstone found for entity %s", req.Id.ToTypeAndKey())
+		}
+		return emptyRes, err
+	}
+	err = newAuditLogger(context, store).logEntityCreate(req.NetworkID, restoredEntity.GetTypeAndKey())
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	return protos.FromStorageNetworkEntity(restoredEntity), store.Commit()
+}
+
+func (srv *nbConfiguratorServicer) WatchChanges(req *protos.WatchChangesRequest, stream protos.NorthboundConfigurator_WatchChangesServer) error {
+	if req.NetworkID == "" {
+		return status.Error(codes.InvalidArgument, "network ID must be provided")
+	}
+
+	sinceSeq := req.SinceSeq
+	for {
+		changes, err := srv.loadChanges(stream.Context(), req.NetworkID, sinceSeq)
+		if storage.IsChangesPurged(err) {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
 		if err != nil {
 			return err
 		}
+		for _, change := range changes {
+			err = stream.Send(protos.FromStorageChange(change))
+			if err != nil {
+				return err
+			}
+			sinceSeq = change.Sequence
+		}
+
+		// A full batch means the watcher may still be behind, so don't wait
+		// before loading the next one
+		if len(changes) == watchChangesBatchSize {
+			continue
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-time.After(watchChangesPollInterval):
+		}
+	}
+}
+
+func (srv *nbConfiguratorServicer) loadChanges(ctx context.Context, networkID string, sinceSeq uint64) ([]storage.Change, error) {
+	store, err := srv.factory.StartTransaction(ctx, &storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	changes, err := store.LoadChanges(networkID, sinceSeq, watchChangesBatchSize)
+	if err != nil {
+		store.Rollback()
+		return nil, err
+	}
+	return changes, store.Commit()
+}
+
+func (srv *nbConfiguratorServicer) GetLatestChangeSeq(context context.Context, req *protos.GetLatestChangeSeqRequest) (*protos.GetLatestChangeSeqResponse, error) {
+	emptyRes := &protos.GetLatestChangeSeqResponse{}
+	if req.NetworkID == "" {
+		return emptyRes, status.Error(codes.InvalidArgument, "network ID must be provided")
+	}
+	store, err := srv.factory.StartTransaction(context, &storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return emptyRes, err
+	}
+	seq, err := store.GetLatestChangeSeq(req.NetworkID)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	return &protos.GetLatestChangeSeqResponse{Sequence: seq}, store.Commit()
+}
+
+func networkConfigsAreValid(configs map[string][]byte) error {
+	// Sort config types so the first invalid config is reported consistently
+	configTypes := make([]string, 0, len(configs))
+	for typeVal := range configs {
+		configTypes = append(configTypes, typeVal)
+	}
+	sort.Strings(configTypes)
+	for _, typeVal := range configTypes {
+		err := configurator.ValidateConfig(typeVal, configs[typeVal])
+		if err != nil {
+			return toConfigStatusError(err, fmt.Sprintf("configs.%s", typeVal))
+		}
 	}
 	return nil
 }
 
 func entityConfigIsValid(typeVal string, config []byte) error {
-	_, err := serde.Deserialize(configurator.SerdeDomain, typeVal, config)
+	err := configurator.ValidateConfig(typeVal, config)
 	if err != nil {
-		return err
+		return toConfigStatusError(err, "config")
 	}
 	return nil
 }
+
+// toConfigStatusError maps a config validation error to an InvalidArgument
+// status. The field violations are attached as BadRequest details, with
+// their paths prefixed by the path to the config in the request.
+func toConfigStatusError(err error, fieldPrefix string) error {
+	validationErr, ok := err.(*configurator.ConfigValidationError)
+	if !ok {
+		return err
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range validationErr.Violations {
+		field := fieldPrefix
+		if violation.Field != "" {
+			field = fmt.Sprintf("%s.%s", fieldPrefix, violation.Field)
+		}
+		badRequest.FieldViolations = append(
+			badRequest.FieldViolations,
+			&errdetails.BadRequest_FieldViolation{Field: field, Description: violation.Description},
+		)
+	}
+	st, detailsErr := status.New(codes.InvalidArgument, validationErr.Error()).WithDetails(badRequest)
+	if detailsErr != nil {
+		return status.Error(codes.InvalidArgument, validationErr.Error())
+	}
+	return st.Err()
+}
+
+// toUpdateStatusError maps version conflicts from storage to an Aborted
+// status so clients can distinguish them from other update failures.
+func toUpdateStatusError(err error) error {
+	if storage.IsVersionConflict(err) {
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return err
+}
@@ -0,0 +1,230 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"context"
+
+	commonProtos "magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/configurator/protos"
+	"magma/orc8r/cloud/go/services/configurator/storage"
+	commonStorage "magma/orc8r/cloud/go/storage"
+
+	"github.com/thoas/go-funk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (srv *nbConfiguratorServicer) CheckPermissions(context context.Context, req *protos.CheckPermissionsRequest) (*protos.CheckPermissionsResponse, error) {
+	emptyRes := &protos.CheckPermissionsResponse{}
+	if req.OperatorID == "" {
+		return emptyRes, status.Error(codes.InvalidArgument, "operator ID must be provided")
+	}
+	store, err := srv.factory.StartTransaction(context, &storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return emptyRes, err
+	}
+
+	checker, err := newPermissionChecker(store, req.OperatorID)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	res := &protos.CheckPermissionsResponse{Allowed: []*protos.EntityID{}, Denied: []*protos.EntityID{}}
+	requested := storage.ACLPermission(req.Permission)
+	entityTKs := make([]commonStorage.TypeAndKey, 0, len(req.Entities))
+	for _, entityID := range req.Entities {
+		entityTKs = append(entityTKs, entityID.ToTypeAndKey())
+	}
+	perms, err := checker.getPermissions(req.NetworkID, entityTKs)
+	if err != nil {
+		store.Rollback()
+		return emptyRes, err
+	}
+	for i, entityID := range req.Entities {
+		if hasPermission(perms[i], requested) {
+			res.Allowed = append(res.Allowed, entityID)
+		} else {
+			res.Denied = append(res.Denied, entityID)
+		}
+	}
+	return res, store.Commit()
+}
+
+// permissionChecker evaluates an operator's ACLs against entities within a
+// single transaction. An operator's permission on an entity is the union of
+// the permissions of all the operator's ACLs which match the entity or any
+// of its ancestors in the entity graph.
+type permissionChecker struct {
+	store storage.ConfiguratorStorage
+	acls  []storage.ACL
+	// isProvisioned is true if the operator has an entity in the internal
+	// network
+	isProvisioned bool
+
+	// Graphs are cached by graph ID since checks for a batch of entities
+	// will usually hit the same graph
+	graphsByID map[string]storage.EntityGraph
+}
+
+// newPermissionChecker loads the ACLs of an operator. An operator without an
+// entity in the internal network has no permissions.
+func newPermissionChecker(store storage.ConfiguratorStorage, operatorID string) (*permissionChecker, error) {
+	loadResult, err := store.LoadEntities(
+		storage.InternalNetworkID,
+		storage.EntityLoadFilter{IDs: []commonStorage.TypeAndKey{{Type: configurator.OperatorEntityType, Key: operatorID}}},
+		storage.EntityLoadCriteria{LoadPermissions: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	checker := &permissionChecker{
+		store:         store,
+		isProvisioned: len(loadResult.Entities) > 0,
+		graphsByID:    map[string]storage.EntityGraph{},
+	}
+	for _, ent := range loadResult.Entities {
+		checker.acls = append(checker.acls, ent.Permissions...)
+	}
+	return checker, nil
+}
+
+// getOperatorPermissionChecker returns a permission checker for the operator
+// making the request, or nil if the request isn't from an operator. Internal
+// service-to-service calls don't carry an operator identity and aren't
+// subject to ACLs. Obsidian forwards the identity of the operator it calls on
+// behalf of, which the identity middleware injects into the context.
+// Operators without an entity in the internal network haven't been migrated
+// to entity ACLs yet; their access is checked by Obsidian against accessd, so
+// they aren't subject to entity ACLs either.
+func getOperatorPermissionChecker(ctx context.Context, store storage.ConfiguratorStorage) (*permissionChecker, error) {
+	operatorID := commonProtos.GetClientIdentity(ctx).GetOperator()
+	if operatorID == "" {
+		return nil, nil
+	}
+	checker, err := newPermissionChecker(store, operatorID)
+	if err != nil || !checker.isProvisioned {
+		return nil, err
+	}
+	return checker, nil
+}
+
+// checkPermission returns a PermissionDenied error if the operator does not
+// have the requested permission on every one of the given entities.
+func (pc *permissionChecker) checkPermission(networkID string, requested storage.ACLPermission, entityIDs ...commonStorage.TypeAndKey) error {
+	perms, err := pc.getPermissions(networkID, entityIDs)
+	if err != nil {
+		return err
+	}
+	for i, entityID := range entityIDs {
+		if !hasPermission(perms[i], requested) {
+			return status.Errorf(codes.PermissionDenied, "unsatisfied permissions on %s, need %d, got %d", entityID, requested, perms[i])
+		}
+	}
+	return nil
+}
+
+// getPermissions returns the operator's permission on each of the entities,
+// taking into account the ACLs which match their ancestors. Entities which
+// don't exist only match ACLs on themselves. The entities are loaded in a
+// single query, and each of their graphs is loaded once.
+func (pc *permissionChecker) getPermissions(networkID string, entityIDs []commonStorage.TypeAndKey) ([]storage.ACLPermission, error) {
+	ret := make([]storage.ACLPermission, 0, len(entityIDs))
+	for _, entityID := range entityIDs {
+		ret = append(ret, pc.getACLPermission(networkID, entityID))
+	}
+	if len(pc.acls) == 0 || len(entityIDs) == 0 {
+		return ret, nil
+	}
+
+	loadResult, err := pc.store.LoadEntities(networkID, storage.EntityLoadFilter{IDs: entityIDs}, storage.EntityLoadCriteria{})
+	if err != nil {
+		return ret, err
+	}
+	graphIDsByTk := make(map[commonStorage.TypeAndKey]string, len(loadResult.Entities))
+	for _, ent := range loadResult.Entities {
+		graphIDsByTk[ent.GetTypeAndKey()] = ent.GraphID
+	}
+
+	for i, entityID := range entityIDs {
+		graphID, exists := graphIDsByTk[entityID]
+		if !exists {
+			continue
+		}
+		graph, cached := pc.graphsByID[graphID]
+		if !cached {
+			graph, err = pc.store.LoadGraphForEntity(networkID, entityID, storage.EntityLoadCriteria{})
+			if err != nil {
+				return ret, err
+			}
+			pc.graphsByID[graphID] = graph
+		}
+		for _, ancestor := range getAncestors(graph, entityID) {
+			ret[i] |= pc.getACLPermission(networkID, ancestor)
+		}
+	}
+	return ret, nil
+}
+
+// getACLPermission returns the union of the permissions of the operator's
+// ACLs which directly match the entity.
+func (pc *permissionChecker) getACLPermission(networkID string, entityID commonStorage.TypeAndKey) storage.ACLPermission {
+	perm := storage.NoPermissions
+	for _, acl := range pc.acls {
+		if aclMatches(acl, networkID, entityID) {
+			perm |= acl.Permission
+		}
+	}
+	return perm
+}
+
+func aclMatches(acl storage.ACL, networkID string, entityID commonStorage.TypeAndKey) bool {
+	if acl.Scope.Wildcard != storage.WildcardAll && !funk.ContainsString(acl.Scope.NetworkIDs, networkID) {
+		return false
+	}
+	if acl.Type.Wildcard != storage.WildcardAll && acl.Type.EntityType != entityID.Type {
+		return false
+	}
+	if !funk.IsEmpty(acl.IDFilter) && !funk.ContainsString(acl.IDFilter, entityID.Key) {
+		return false
+	}
+	return true
+}
+
+// getAncestors returns all entities in the graph which have a path to the
+// given entity.
+func getAncestors(graph storage.EntityGraph, entityID commonStorage.TypeAndKey) []commonStorage.TypeAndKey {
+	parentsByTk := map[commonStorage.TypeAndKey][]commonStorage.TypeAndKey{}
+	for _, edge := range graph.Edges {
+		parentsByTk[edge.To] = append(parentsByTk[edge.To], edge.From)
+	}
+
+	ret := []commonStorage.TypeAndKey{}
+	seen := map[commonStorage.TypeAndKey]bool{entityID: true}
+	queue := []commonStorage.TypeAndKey{entityID}
+	for len(queue) > 0 {
+		tk := queue[0]
+		queue = queue[1:]
+		for _, parent := range parentsByTk[tk] {
+			if !seen[parent] {
+				seen[parent] = true
+				ret = append(ret, parent)
+				queue = append(queue, parent)
+			}
+		}
+	}
+	return ret
+}
+
+// hasPermission returns true if granted includes requested. OWN is the
+// union of READ and WRITE.
+func hasPermission(granted storage.ACLPermission, requested storage.ACLPermission) bool {
+	return granted&requested == requested
+}
@@ -0,0 +1,199 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers_test
+
+import (
+	"context"
+	"testing"
+
+	commonProtos "magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/configurator/protos"
+	"magma/orc8r/cloud/go/services/configurator/servicers"
+	"magma/orc8r/cloud/go/services/configurator/storage"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNorthboundConfiguratorServicer_Permissions(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &storage.DefaultIDGenerator{}, sqorc.GetSqlBuilder())
+	assert.NoError(t, factory.InitializeServiceStorage())
+	srv, err := servicers.NewNorthboundConfiguratorServicer(factory)
+	assert.NoError(t, err)
+
+	// Requests without an operator identity are not subject to ACLs
+	internalCtx := context.Background()
+	_, err = srv.CreateNetworks(internalCtx, &protos.CreateNetworksRequest{Networks: []*protos.Network{{Id: "n1"}}})
+	assert.NoError(t, err)
+
+	// t1 -> g1, t1 -> g2, t2 -> g4, g3
+	_, err = srv.CreateEntities(internalCtx, &protos.CreateEntitiesRequest{
+		NetworkID: "n1",
+		Entities: []*protos.NetworkEntity{
+			{Type: "gateway", Id: "g1"},
+			{Type: "gateway", Id: "g2"},
+			{Type: "gateway", Id: "g3"},
+			{Type: "gateway", Id: "g4"},
+			{Type: "tier", Id: "t1", Assocs: []*protos.EntityID{{Type: "gateway", Id: "g1"}, {Type: "gateway", Id: "g2"}}},
+			{Type: "tier", Id: "t2", Assocs: []*protos.EntityID{{Type: "gateway", Id: "g4"}}},
+		},
+	})
+	assert.NoError(t, err)
+
+	// op1 owns tier t1 in n1 and can read g3 in any network
+	_, err = srv.CreateEntities(internalCtx, &protos.CreateEntitiesRequest{
+		NetworkID: storage.InternalNetworkID,
+		Entities: []*protos.NetworkEntity{
+			{
+				Type: configurator.OperatorEntityType,
+				Id:   "op1",
+				Permissions: []*protos.ACL{
+					{
+						Scope:      &protos.ACL_NetworkIds{NetworkIds: &protos.ACL_NetworkIDs{Ids: []string{"n1"}}},
+						Permission: protos.ACL_OWN,
+						Type:       &protos.ACL_EntityType{EntityType: "tier"},
+						IdFilter:   []string{"t1"},
+					},
+					{
+						Scope:      &protos.ACL_ScopeWildcard{ScopeWildcard: protos.ACL_WILDCARD_ALL},
+						Permission: protos.ACL_READ,
+						Type:       &protos.ACL_EntityType{EntityType: "gateway"},
+						IdFilter:   []string{"g3"},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	g1 := &protos.EntityID{Type: "gateway", Id: "g1"}
+	g3 := &protos.EntityID{Type: "gateway", Id: "g3"}
+	g4 := &protos.EntityID{Type: "gateway", Id: "g4"}
+	t1 := &protos.EntityID{Type: "tier", Id: "t1"}
+	t2 := &protos.EntityID{Type: "tier", Id: "t2"}
+
+	// CheckPermissions
+	res, err := srv.CheckPermissions(internalCtx, &protos.CheckPermissionsRequest{
+		OperatorID: "op1",
+		NetworkID:  "n1",
+		Entities:   []*protos.EntityID{g1, g3, g4, t1, t2},
+		Permission: protos.ACL_READ,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.EntityID{g1, g3, t1}, res.Allowed)
+	assert.Equal(t, []*protos.EntityID{g4, t2}, res.Denied)
+
+	res, err = srv.CheckPermissions(internalCtx, &protos.CheckPermissionsRequest{
+		OperatorID: "op1",
+		NetworkID:  "n1",
+		Entities:   []*protos.EntityID{g1, g3},
+		Permission: protos.ACL_WRITE,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.EntityID{g1}, res.Allowed)
+	assert.Equal(t, []*protos.EntityID{g3}, res.Denied)
+
+	// Unknown operators have no permissions
+	res, err = srv.CheckPermissions(internalCtx, &protos.CheckPermissionsRequest{
+		OperatorID: "op2",
+		NetworkID:  "n1",
+		Entities:   []*protos.EntityID{g1},
+		Permission: protos.ACL_READ,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, res.Allowed)
+	assert.Equal(t, []*protos.EntityID{g1}, res.Denied)
+
+	_, err = srv.CheckPermissions(internalCtx, &protos.CheckPermissionsRequest{NetworkID: "n1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Enforcement for requests from an operator
+	opCtx := commonProtos.NewOperatorIdentity("op1").NewContextWithIdentity(context.Background())
+
+	loadRes, err := srv.LoadEntities(opCtx, &protos.LoadEntitiesRequest{
+		NetworkID: "n1",
+		EntityIDs: []*protos.EntityID{g1, g3},
+		Criteria:  &protos.EntityLoadCriteria{},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, loadRes.Entities, 2)
+
+	_, err = srv.LoadEntities(opCtx, &protos.LoadEntitiesRequest{
+		NetworkID: "n1",
+		EntityIDs: []*protos.EntityID{g1, g4},
+		Criteria:  &protos.EntityLoadCriteria{},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = srv.UpdateEntities(opCtx, &protos.UpdateEntitiesRequest{
+		NetworkID: "n1",
+		Updates:   []*protos.EntityUpdateCriteria{{Type: "gateway", Key: "g1", NewName: &wrappers.StringValue{Value: "foo"}}},
+	})
+	assert.NoError(t, err)
+
+	_, err = srv.UpdateEntities(opCtx, &protos.UpdateEntitiesRequest{
+		NetworkID: "n1",
+		Updates:   []*protos.EntityUpdateCriteria{{Type: "gateway", Key: "g3", NewName: &wrappers.StringValue{Value: "foo"}}},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = srv.DeleteEntities(opCtx, &protos.DeleteEntitiesRequest{NetworkID: "n1", ID: []*protos.EntityID{t2}})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = srv.CreateEntities(opCtx, &protos.CreateEntitiesRequest{
+		NetworkID: "n1",
+		Entities:  []*protos.NetworkEntity{{Type: "gateway", Id: "g5"}},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Nothing was written by the denied requests
+	loadRes, err = srv.LoadEntities(internalCtx, &protos.LoadEntitiesRequest{
+		NetworkID: "n1",
+		EntityIDs: []*protos.EntityID{g3, t2, {Type: "gateway", Id: "g5"}},
+		Criteria:  &protos.EntityLoadCriteria{LoadMetadata: true},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, loadRes.Entities, 2)
+	for _, ent := range loadRes.Entities {
+		assert.Empty(t, ent.Name)
+	}
+
+	// Exports need read permissions on all entities of the network
+	_, err = srv.ExportNetwork(opCtx, &protos.ExportNetworkRequest{NetworkID: "n1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Imports need write permissions on all imported entities
+	export, err := srv.ExportNetwork(internalCtx, &protos.ExportNetworkRequest{NetworkID: "n1"})
+	assert.NoError(t, err)
+	_, err = srv.ImportNetwork(opCtx, &protos.ImportNetworkRequest{Export: export, NewNetworkID: "n2"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = srv.ImportNetwork(opCtx, &protos.ImportNetworkRequest{
+		Export:       &protos.NetworkExport{FormatVersion: export.FormatVersion, Network: export.Network},
+		NewNetworkID: "n2",
+	})
+	assert.NoError(t, err)
+
+	// Operators without an operator entity aren't subject to entity ACLs
+	unprovisionedCtx := commonProtos.NewOperatorIdentity("op2").NewContextWithIdentity(context.Background())
+	_, err = srv.UpdateEntities(unprovisionedCtx, &protos.UpdateEntitiesRequest{
+		NetworkID: "n1",
+		Updates:   []*protos.EntityUpdateCriteria{{Type: "gateway", Key: "g3", NewName: &wrappers.StringValue{Value: "foo"}}},
+	})
+	assert.NoError(t, err)
+
+	_, err = srv.DeleteEntities(opCtx, &protos.DeleteEntitiesRequest{NetworkID: "n1", ID: []*protos.EntityID{g1}})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package storage
+
+import (
+	"database/sql"
+
+	"magma/orc8r/cloud/go/sqorc"
+)
+
+// GetSQLMigrations returns the schema migrations of the SQL configurator
+// storage, owned by the given service.
+func GetSQLMigrations(service string) []sqorc.Migration {
+	return []sqorc.Migration{
+		{
+			Service:     service,
+			Version:     1,
+			Description: "Create configurator tables",
+			Up: func(tx *sql.Tx, builder sqorc.StatementBuilder) error {
+				return createTables(tx, builder)
+			},
+		},
+	}
+}
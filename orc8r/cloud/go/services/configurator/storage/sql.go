@@ -11,9 +11,12 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"sort"
+	"time"
 
+	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/sqorc"
 	"magma/orc8r/cloud/go/storage"
 
@@ -30,6 +33,13 @@ const (
 	entityTable      = "cfg_entities"
 	entityAssocTable = "cfg_assocs"
 	entityAclTable   = "cfg_acls"
+
+	changeSeqTable = "cfg_change_seqs"
+	changeTable    = "cfg_changes"
+
+	tombstoneTable = "cfg_tombstones"
+
+	auditTable = "cfg_audit_log"
 )
 
 const (
@@ -65,6 +75,32 @@ const (
 	aclTypeCol     = "type"
 	aclIdFilterCol = "id_filter"
 	aclVerCol      = "version"
+
+	chsNidCol = "network_id"
+	chsSeqCol = "seq"
+
+	chNidCol  = "network_id"
+	chSeqCol  = "seq"
+	chOpCol   = "operation"
+	chTypeCol = "type"
+	chKeyCol  = "\"key\""
+	chTimeCol = "created_at"
+
+	tsNidCol     = "network_id"
+	tsTypeCol    = "type"
+	tsKeyCol     = "\"key\""
+	tsDeletedCol = "deleted_at"
+	tsEntCol     = "entity"
+
+	auIDCol    = "id"
+	auNidCol   = "network_id"
+	auTypeCol  = "type"
+	auKeyCol   = "\"key\""
+	auOpCol    = "operation"
+	auActorCol = "actor"
+	auTimeCol  = "timestamp"
+	auSeqCol   = "seq"
+	auDiffsCol = "diffs"
 )
 
 type IDGenerator interface {
@@ -108,125 +144,7 @@ func (fact *sqlConfiguratorStorageFactory) InitializeServiceStorage() (err error
 		}
 	}()
 
-	// Named return values below so we can automatically decide tx commit/
-	// rollback in deferred function
-
-	_, err = fact.builder.CreateTable(networksTable).
-		IfNotExists().
-		Column(nwIDCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
-		Column(nwNameCol).Type(sqorc.ColumnTypeText).EndColumn().
-		Column(nwDescCol).Type(sqorc.ColumnTypeText).EndColumn().
-		Column(nwVerCol).Type(sqorc.ColumnTypeInt).NotNull().Default(0).EndColumn().
-		RunWith(tx).
-		Exec()
-	if err != nil {
-		err = errors.Wrap(err, "failed to create networks table")
-		return
-	}
-
-	_, err = fact.builder.CreateTable(networkConfigTable).
-		IfNotExists().
-		Column(nwcIDCol).Type(sqorc.ColumnTypeText).EndColumn().
-		Column(nwcTypeCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
-		Column(nwcValCol).Type(sqorc.ColumnTypeBytes).EndColumn().
-		PrimaryKey(nwcIDCol, nwcTypeCol).
-		ForeignKey(networksTable, map[string]string{nwcIDCol: nwIDCol}, sqorc.ColumnOnDeleteCascade).
-		RunWith(tx).
-		Exec()
-	if err != nil {
-		err = errors.Wrap(err, "failed to create network configs table")
-		return
-	}
-
-	// Create an internal-only primary key (UUID) for entities.
-	// This keeps index size in control and supporting table schemas simpler.
-	_, err = fact.builder.CreateTable(entityTable).
-		IfNotExists().
-		Column(entPkCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
-		Column(entNidCol).Type(sqorc.ColumnTypeText).EndColumn().
-		Column(entTypeCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
-		Column(entKeyCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
-		Column(entGidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
-		Column(entNameCol).Type(sqorc.ColumnTypeText).EndColumn().
-		Column(entDescCol).Type(sqorc.ColumnTypeText).EndColumn().
-		Column(entPidCol).Type(sqorc.ColumnTypeText).EndColumn().
-		Column(entConfCol).Type(sqorc.ColumnTypeBytes).EndColumn().
-		Column(entVerCol).Type(sqorc.ColumnTypeInt).NotNull().Default(0).EndColumn().
-		Unique(entNidCol, entKeyCol, entTypeCol).
-		ForeignKey(networksTable, map[string]string{entNidCol: nwIDCol}, sqorc.ColumnOnDeleteCascade).
-		RunWith(tx).
-		Exec()
-	if err != nil {
-		err = errors.Wrap(err, "failed to create entities table")
-		return
-	}
-
-	_, err = fact.builder.CreateTable(entityAssocTable).
-		IfNotExists().
-		Column(aFrCol).Type(sqorc.ColumnTypeText).EndColumn().
-		Column(aToCol).Type(sqorc.ColumnTypeText).EndColumn().
-		PrimaryKey(aFrCol, aToCol).
-		ForeignKey(entityTable, map[string]string{aFrCol: entPkCol}, sqorc.ColumnOnDeleteCascade).
-		ForeignKey(entityTable, map[string]string{aToCol: entPkCol}, sqorc.ColumnOnDeleteCascade).
-		RunWith(tx).
-		Exec()
-	if err != nil {
-		err = errors.Wrap(err, "failed to create entity assoc table")
-		return
-	}
-
-	_, err = fact.builder.CreateTable(entityAclTable).
-		IfNotExists().
-		Column(aclIdCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
-		Column(aclEntCol).Type(sqorc.ColumnTypeText).EndColumn().
-		Column(aclScopeCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
-		Column(aclPermCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
-		Column(aclTypeCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
-		Column(aclIdFilterCol).Type(sqorc.ColumnTypeText).EndColumn().
-		Column(aclVerCol).Type(sqorc.ColumnTypeInt).NotNull().Default(0).EndColumn().
-		ForeignKey(entityTable, map[string]string{aclEntCol: entPkCol}, sqorc.ColumnOnDeleteCascade).
-		RunWith(tx).
-		Exec()
-	if err != nil {
-		err = errors.Wrap(err, "failed to create entity acl table")
-		return
-	}
-
-	// Create indexes (index is not implicitly created on a referencing FK)
-	_, err = fact.builder.CreateIndex("graph_id_idx").
-		IfNotExists().
-		On(entityTable).
-		Columns(entGidCol).
-		RunWith(tx).
-		Exec()
-	if err != nil {
-		err = errors.Wrap(err, "failed to create graph ID index")
-		return
-	}
-
-	_, err = fact.builder.CreateIndex("acl_ent_pk_idx").
-		IfNotExists().
-		On(entityAclTable).
-		Columns(aclEntCol).
-		RunWith(tx).
-		Exec()
-	if err != nil {
-		err = errors.Wrap(err, "failed to create acl ent PK index")
-		return
-	}
-
-	// Create internal network(s)
-	_, err = fact.builder.Insert(networksTable).
-		Columns(nwIDCol, nwNameCol, nwDescCol).
-		Values(InternalNetworkID, internalNetworkName, internalNetworkDescription).
-		OnConflict(nil, nwIDCol).
-		RunWith(tx).
-		Exec()
-	if err != nil {
-		err = errors.Wrap(err, "error creating internal networks")
-		return
-	}
-
+	err = createTables(tx, fact.builder)
 	return
 }
 
@@ -378,11 +296,15 @@ func (store *sqlConfiguratorStorage) UpdateNetworks(updates []NetworkUpdateCrite
 	}
 
 	networksToDelete := []string{}
+	versionedNetworksToDelete := []NetworkUpdateCriteria{}
 	networksToUpdate := []NetworkUpdateCriteria{}
 	for _, update := range updates {
-		if update.DeleteNetwork {
+		switch {
+		case update.DeleteNetwork && update.ExpectedVersion != nil:
+			versionedNetworksToDelete = append(versionedNetworksToDelete, update)
+		case update.DeleteNetwork:
 			networksToDelete = append(networksToDelete, update.ID)
-		} else {
+		default:
 			networksToUpdate = append(networksToUpdate, update)
 		}
 	}
@@ -398,6 +320,14 @@ func (store *sqlConfiguratorStorage) UpdateNetworks(updates []NetworkUpdateCrite
 		}
 	}
 
+	// Deletions with a version precondition have to be checked individually
+	for _, update := range versionedNetworksToDelete {
+		err := store.deleteNetworkAtVersion(update.ID, *update.ExpectedVersion)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
 	// Then delete all networks requested for deletion
 	_, err := store.builder.Delete(networksTable).Where(sq.Eq{nwIDCol: networksToDelete}).
 		RunWith(store.tx).
@@ -405,6 +335,25 @@ func (store *sqlConfiguratorStorage) UpdateNetworks(updates []NetworkUpdateCrite
 	if err != nil {
 		return errors.Wrap(err, "failed to delete networks")
 	}
+
+	for _, update := range networksToUpdate {
+		err = store.appendChange(update.ID, ChangeUpdate, nil)
+		if err != nil {
+			return err
+		}
+	}
+	for _, update := range versionedNetworksToDelete {
+		err = store.appendChange(update.ID, ChangeDelete, nil)
+		if err != nil {
+			return err
+		}
+	}
+	for _, networkID := range networksToDelete {
+		err = store.appendChange(networkID, ChangeDelete, nil)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -422,6 +371,21 @@ func (store *sqlConfiguratorStorage) LoadEntities(networkID string, filter Entit
 	// be smart here and only load (type, key) for PKs which we don't know.
 	// Finally, we will update the entity objects to return with their edges.
 
+	// If a single page of entities was requested, first figure out which
+	// entities are in the page so the rest of the load is restricted to them.
+	var pagePks []string
+	if filter.isPaginated() {
+		var err error
+		pagePks, ret.NextPageToken, err = store.loadEntityPage(networkID, filter)
+		if err != nil {
+			return ret, err
+		}
+		if len(pagePks) == 0 {
+			return ret, nil
+		}
+		filter = EntityLoadFilter{pks: pagePks}
+	}
+
 	entsByPk, err := store.loadFromEntitiesTable(networkID, filter, loadCriteria)
 	if err != nil {
 		return ret, err
@@ -440,6 +404,17 @@ func (store *sqlConfiguratorStorage) LoadEntities(networkID string, filter Entit
 		return ret, err
 	}
 
+	// Pages are returned in the order they were loaded in since the next page
+	// token depends on the DB's ordering
+	if pagePks != nil {
+		for _, pk := range pagePks {
+			if ent, exists := entsByPk[pk]; exists {
+				ret.Entities = append(ret.Entities, *ent)
+			}
+		}
+		return ret, nil
+	}
+
 	for _, ent := range entsByPk {
 		ret.Entities = append(ret.Entities, *ent)
 	}
@@ -498,6 +473,12 @@ func (store *sqlConfiguratorStorage) CreateEntity(networkID string, entity Netwo
 	// If we were given duplicate edges, get rid of those
 	createdEntWithPk.Associations = funk.Uniq(createdEntWithPk.Associations).([]storage.TypeAndKey)
 
+	entTk := entity.GetTypeAndKey()
+	err = store.appendChange(networkID, ChangeCreate, &entTk)
+	if err != nil {
+		return NetworkEntity{}, err
+	}
+
 	return createdEntWithPk.NetworkEntity, nil
 }
 
@@ -508,19 +489,44 @@ func (store *sqlConfiguratorStorage) UpdateEntity(networkID string, update Entit
 		return emptyRet, errors.Wrap(err, "failed to load entity being updated")
 	}
 
+	if update.ExpectedVersion != nil && *update.ExpectedVersion != entToUpdate.Version {
+		return emptyRet, errors.Wrapf(
+			ErrVersionConflict,
+			"entity (%s, %s) is at version %d, expected %d",
+			update.Type, update.Key, entToUpdate.Version, *update.ExpectedVersion,
+		)
+	}
+
 	if update.DeleteEntity {
+		// Snapshot the entity before deleting it so that it can be restored
+		// until the tombstone is purged
+		err = store.writeTombstone(networkID, update.GetTypeAndKey())
+		if err != nil {
+			return emptyRet, err
+		}
+
 		// Cascading FK relations in the schema will handle the other tables
-		_, err := store.builder.Delete(entityTable).
-			Where(sq.And{
-				sq.Eq{entNidCol: networkID},
-				sq.Eq{entTypeCol: update.Type},
-				sq.Eq{entKeyCol: update.Key},
-			}).
+		whereClause := sq.And{
+			sq.Eq{entNidCol: networkID},
+			sq.Eq{entTypeCol: update.Type},
+			sq.Eq{entKeyCol: update.Key},
+		}
+		if update.ExpectedVersion != nil {
+			whereClause = append(whereClause, sq.Eq{entVerCol: *update.ExpectedVersion})
+		}
+		res, err := store.builder.Delete(entityTable).
+			Where(whereClause).
 			RunWith(store.tx).
 			Exec()
 		if err != nil {
 			return emptyRet, errors.Wrapf(err, "failed to delete entity (%s, %s)", update.Type, update.Key)
 		}
+		if update.ExpectedVersion != nil {
+			err = checkVersionedWrite(res, fmt.Sprintf("entity (%s, %s)", update.Type, update.Key))
+			if err != nil {
+				return emptyRet, err
+			}
+		}
 
 		// Deleting a node could partition its graph
 		err = store.fixGraph(networkID, entToUpdate.GraphID, &entToUpdate)
@@ -528,6 +534,12 @@ func (store *sqlConfiguratorStorage) UpdateEntity(networkID string, update Entit
 			return emptyRet, errors.Wrap(err, "failed to fix entity graph after deletion")
 		}
 
+		entTk := update.GetTypeAndKey()
+		err = store.appendChange(networkID, ChangeDelete, &entTk)
+		if err != nil {
+			return emptyRet, err
+		}
+
 		return emptyRet, nil
 	}
 
@@ -549,6 +561,12 @@ func (store *sqlConfiguratorStorage) UpdateEntity(networkID string, update Entit
 		return entToUpdate.NetworkEntity, errors.WithStack(err)
 	}
 
+	entTk := update.GetTypeAndKey()
+	err = store.appendChange(networkID, ChangeUpdate, &entTk)
+	if err != nil {
+		return entToUpdate.NetworkEntity, err
+	}
+
 	return entToUpdate.NetworkEntity, nil
 }
 
@@ -609,3 +627,550 @@ func (store *sqlConfiguratorStorage) LoadGraphForEntity(networkID string, entity
 		Edges:        edges,
 	}, nil
 }
+
+func (store *sqlConfiguratorStorage) TraverseGraph(networkID string, traversal GraphTraversal, loadCriteria EntityLoadCriteria) (GraphTraversalResult, error) {
+	if traversal.MaxDepth == 0 {
+		return GraphTraversalResult{}, errors.New("max depth of graph traversal must be positive")
+	}
+	if traversal.MaxDepth > MaxTraversalDepth {
+		return GraphTraversalResult{}, errors.Errorf("max depth of graph traversal must be at most %d", MaxTraversalDepth)
+	}
+
+	startEnts, err := store.loadFromEntitiesTable(networkID, EntityLoadFilter{IDs: []storage.TypeAndKey{traversal.Start}}, EntityLoadCriteria{})
+	if err != nil {
+		return GraphTraversalResult{}, errors.Wrap(err, "failed to load entity for graph traversal")
+	}
+	var startPk string
+	for pk := range startEnts {
+		startPk = pk
+	}
+	if startPk == "" {
+		return GraphTraversalResult{}, errors.Errorf("could not find requested entity (%s) for graph traversal", traversal.Start)
+	}
+
+	var edges []loadedAssoc
+	if store.builder.SupportsRecursiveCTE() {
+		edges, err = store.traverseWithRecursiveCTE(startPk, traversal)
+	} else {
+		edges, err = store.traverseIteratively(startPk, traversal)
+	}
+	if err != nil {
+		return GraphTraversalResult{}, errors.Wrap(err, "failed to traverse graph")
+	}
+	if funk.IsEmpty(edges) {
+		return GraphTraversalResult{Entities: []NetworkEntity{}, Edges: []GraphEdge{}}, nil
+	}
+
+	reachedPks := funk.UniqString(funk.Map(edges, func(edge loadedAssoc) string { return getTraversedPk(edge, traversal.Direction) }).([]string))
+	sort.Strings(reachedPks)
+	loadCriteria.LoadAssocsToThis, loadCriteria.LoadAssocsFromThis = false, false
+	entsByPk, err := store.loadFromEntitiesTable(networkID, EntityLoadFilter{pks: reachedPks}, loadCriteria)
+	if err != nil {
+		return GraphTraversalResult{}, errors.Wrap(err, "failed to load entities reached by graph traversal")
+	}
+
+	tksByPk := map[string]storage.TypeAndKey{startPk: traversal.Start}
+	retEnts := make([]NetworkEntity, 0, len(entsByPk))
+	for pk, ent := range entsByPk {
+		tksByPk[pk] = ent.GetTypeAndKey()
+		retEnts = append(retEnts, *ent)
+	}
+	retEdges := make([]GraphEdge, 0, len(edges))
+	for _, edge := range edges {
+		retEdges = append(retEdges, GraphEdge{From: tksByPk[edge.fromPk], To: tksByPk[edge.toPk]})
+	}
+
+	// To make testing easier, we'll order the returned entities and edges
+	sort.Slice(retEnts, func(i, j int) bool {
+		return storage.IsTKLessThan(retEnts[i].GetTypeAndKey(), retEnts[j].GetTypeAndKey())
+	})
+	sort.Slice(retEdges, func(i, j int) bool {
+		if retEdges[i].From != retEdges[j].From {
+			return storage.IsTKLessThan(retEdges[i].From, retEdges[j].From)
+		}
+		return storage.IsTKLessThan(retEdges[i].To, retEdges[j].To)
+	})
+	return GraphTraversalResult{Entities: retEnts, Edges: retEdges}, nil
+}
+
+func (store *sqlConfiguratorStorage) LoadChanges(networkID string, sinceSeq uint64, limit uint32) ([]Change, error) {
+	ret := []Change{}
+	if limit == 0 {
+		return ret, nil
+	}
+
+	rows, err := store.builder.Select(chSeqCol, chOpCol, chTypeCol, chKeyCol).
+		From(changeTable).
+		Where(sq.And{
+			sq.Eq{chNidCol: networkID},
+			sq.Gt{chSeqCol: sinceSeq},
+		}).
+		OrderBy(chSeqCol).
+		Limit(uint64(limit)).
+		RunWith(store.tx).
+		Query()
+	if err != nil {
+		return ret, errors.Wrap(err, "failed to query for changes")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "LoadChanges")
+
+	for rows.Next() {
+		change := Change{NetworkID: networkID}
+		var entType, entKey sql.NullString
+		err = rows.Scan(&change.Sequence, &change.Operation, &entType, &entKey)
+		if err != nil {
+			return []Change{}, errors.Wrap(err, "failed to scan change row")
+		}
+		if entType.Valid {
+			change.Entity = &storage.TypeAndKey{Type: entType.String, Key: entKey.String}
+		}
+		ret = append(ret, change)
+	}
+	if err := rows.Err(); err != nil {
+		return []Change{}, errors.Wrap(err, "failed to iterate over change rows")
+	}
+
+	// Sequence numbers have no gaps, so a missing change after sinceSeq
+	// has been purged
+	if len(ret) > 0 {
+		if ret[0].Sequence != sinceSeq+1 {
+			return []Change{}, errors.Wrapf(ErrChangesPurged, "changes after %d of network %s", sinceSeq, networkID)
+		}
+		return ret, nil
+	}
+	latestSeq, err := store.GetLatestChangeSeq(networkID)
+	if err != nil {
+		return []Change{}, err
+	}
+	if latestSeq > sinceSeq {
+		return []Change{}, errors.Wrapf(ErrChangesPurged, "changes after %d of network %s", sinceSeq, networkID)
+	}
+	return ret, nil
+}
+
+func (store *sqlConfiguratorStorage) GetLatestChangeSeq(networkID string) (uint64, error) {
+	var seq uint64
+	err := store.builder.Select(chsSeqCol).
+		From(changeSeqTable).
+		Where(sq.Eq{chsNidCol: networkID}).
+		RunWith(store.tx).
+		QueryRow().
+		Scan(&seq)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to load change sequence for network %s", networkID)
+	}
+	return seq, nil
+}
+
+func (store *sqlConfiguratorStorage) PurgeChanges(createdBefore int64) (int64, error) {
+	res, err := store.builder.Delete(changeTable).
+		Where(sq.Lt{chTimeCol: createdBefore}).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to purge changes")
+	}
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get number of purged changes")
+	}
+	return purged, nil
+}
+
+func (store *sqlConfiguratorStorage) LoadTombstones(networkID string, filter EntityLoadFilter) ([]Tombstone, error) {
+	rows, err := store.builder.Select(tsDeletedCol, tsEntCol).
+		From(tombstoneTable).
+		Where(getTombstoneFilterWhereClause(networkID, filter)).
+		OrderBy(tsTypeCol, tsKeyCol).
+		RunWith(store.tx).
+		Query()
+	if err != nil {
+		return []Tombstone{}, errors.Wrap(err, "failed to query for tombstones")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "LoadTombstones")
+
+	ret := []Tombstone{}
+	for rows.Next() {
+		var tombstone Tombstone
+		var entBytes []byte
+		err = rows.Scan(&tombstone.DeletedAt, &entBytes)
+		if err != nil {
+			return []Tombstone{}, errors.Wrap(err, "failed to scan tombstone row")
+		}
+		err = json.Unmarshal(entBytes, &tombstone.Entity)
+		if err != nil {
+			return []Tombstone{}, errors.Wrap(err, "failed to deserialize tombstoned entity")
+		}
+		ret = append(ret, tombstone)
+	}
+	if err := rows.Err(); err != nil {
+		return []Tombstone{}, errors.Wrap(err, "failed to iterate over tombstone rows")
+	}
+	return ret, nil
+}
+
+func (store *sqlConfiguratorStorage) RestoreEntity(networkID string, entityID storage.TypeAndKey) (NetworkEntity, error) {
+	emptyRet := NetworkEntity{Type: entityID.Type, Key: entityID.Key}
+	tombstones, err := store.LoadTombstones(networkID, EntityLoadFilter{IDs: []storage.TypeAndKey{entityID}})
+	if err != nil {
+		return emptyRet, errors.Wrap(err, "failed to load tombstone of entity being restored")
+	}
+	if funk.IsEmpty(tombstones) {
+		return emptyRet, errors.Wrapf(merrors.ErrNotFound, "no tombstone found for entity %s", entityID)
+	}
+	deletedEnt := tombstones[0].Entity
+
+	// Associations to and from entities which were deleted since can't be
+	// restored
+	existingTks, err := store.getExistingTKs(networkID, append(deletedEnt.Associations, deletedEnt.ParentAssociations...))
+	if err != nil {
+		return emptyRet, err
+	}
+	parentAssocs := filterTKs(deletedEnt.ParentAssociations, existingTks)
+	entToCreate := NetworkEntity{
+		Type:         deletedEnt.Type,
+		Key:          deletedEnt.Key,
+		Name:         deletedEnt.Name,
+		Description:  deletedEnt.Description,
+		PhysicalID:   deletedEnt.PhysicalID,
+		Config:       deletedEnt.Config,
+		Associations: filterTKs(deletedEnt.Associations, existingTks),
+	}
+	// ACL IDs and versions are regenerated on creation
+	for _, acl := range deletedEnt.Permissions {
+		acl.ID, acl.Version = "", 0
+		entToCreate.Permissions = append(entToCreate.Permissions, acl)
+	}
+
+	restoredEnt, err := store.CreateEntity(networkID, entToCreate)
+	if err != nil {
+		return emptyRet, errors.Wrap(err, "failed to recreate entity being restored")
+	}
+	for _, parent := range parentAssocs {
+		_, err = store.UpdateEntity(networkID, EntityUpdateCriteria{
+			Type:              parent.Type,
+			Key:               parent.Key,
+			AssociationsToAdd: []storage.TypeAndKey{entityID},
+		})
+		if err != nil {
+			return emptyRet, errors.Wrapf(err, "failed to restore association from %s", parent)
+		}
+	}
+	if !funk.IsEmpty(parentAssocs) {
+		restoredEnt.ParentAssociations = parentAssocs
+	}
+
+	err = store.deleteTombstone(networkID, entityID)
+	if err != nil {
+		return emptyRet, err
+	}
+	return restoredEnt, nil
+}
+
+func (store *sqlConfiguratorStorage) PurgeTombstones(deletedBefore int64) (int64, error) {
+	res, err := store.builder.Delete(tombstoneTable).
+		Where(sq.Lt{tsDeletedCol: deletedBefore}).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to purge tombstones")
+	}
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get number of purged tombstones")
+	}
+	return purged, nil
+}
+
+func (store *sqlConfiguratorStorage) WriteAuditEntry(entry AuditEntry) error {
+	var entType, entKey interface{}
+	if entry.Entity != nil {
+		entType, entKey = entry.Entity.Type, entry.Entity.Key
+	}
+	diffsBytes, err := json.Marshal(entry.Diffs)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize audit entry diffs")
+	}
+
+	// Timestamps have second granularity, so entries are additionally ordered
+	// by the time at which they were written
+	_, err = store.builder.Insert(auditTable).
+		Columns(auIDCol, auNidCol, auTypeCol, auKeyCol, auOpCol, auActorCol, auTimeCol, auSeqCol, auDiffsCol).
+		Values(store.idGenerator.New(), entry.NetworkID, entType, entKey, entry.Operation, entry.Actor, entry.Timestamp, time.Now().UnixNano(), diffsBytes).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "failed to write audit entry")
+	}
+	return nil
+}
+
+func (store *sqlConfiguratorStorage) LoadAuditEntries(filter AuditEntryFilter) ([]AuditEntry, error) {
+	selectBuilder := store.builder.Select(auIDCol, auTypeCol, auKeyCol, auOpCol, auActorCol, auTimeCol, auDiffsCol).
+		From(auditTable).
+		Where(getAuditFilterWhereClause(filter)).
+		OrderBy(auTimeCol, auSeqCol, auIDCol)
+	if filter.Limit > 0 {
+		selectBuilder = selectBuilder.Limit(uint64(filter.Limit))
+	}
+	rows, err := selectBuilder.RunWith(store.tx).Query()
+	if err != nil {
+		return []AuditEntry{}, errors.Wrap(err, "failed to query for audit entries")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "LoadAuditEntries")
+
+	ret := []AuditEntry{}
+	for rows.Next() {
+		entry := AuditEntry{NetworkID: filter.NetworkID}
+		var entType, entKey sql.NullString
+		var diffsBytes []byte
+		err = rows.Scan(&entry.ID, &entType, &entKey, &entry.Operation, &entry.Actor, &entry.Timestamp, &diffsBytes)
+		if err != nil {
+			return []AuditEntry{}, errors.Wrap(err, "failed to scan audit entry row")
+		}
+		if entType.Valid {
+			entry.Entity = &storage.TypeAndKey{Type: entType.String, Key: entKey.String}
+		}
+		if len(diffsBytes) > 0 {
+			err = json.Unmarshal(diffsBytes, &entry.Diffs)
+			if err != nil {
+				return []AuditEntry{}, errors.Wrap(err, "failed to deserialize audit entry diffs")
+			}
+		}
+		ret = append(ret, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return []AuditEntry{}, errors.Wrap(err, "failed to iterate over audit entry rows")
+	}
+	return ret, nil
+}
+
+func getAuditFilterWhereClause(filter AuditEntryFilter) sq.Sqlizer {
+	whereClause := sq.And{sq.Eq{auNidCol: filter.NetworkID}}
+	if filter.Entity != nil {
+		whereClause = append(whereClause, sq.Eq{auTypeCol: filter.Entity.Type, auKeyCol: filter.Entity.Key})
+	}
+	if filter.Actor != nil {
+		whereClause = append(whereClause, sq.Eq{auActorCol: *filter.Actor})
+	}
+	if filter.StartTime != 0 {
+		whereClause = append(whereClause, sq.GtOrEq{auTimeCol: filter.StartTime})
+	}
+	if filter.EndTime != 0 {
+		whereClause = append(whereClause, sq.Lt{auTimeCol: filter.EndTime})
+	}
+	return whereClause
+}
+
+// createTables creates the configurator tables and indexes if they don't
+// exist, and creates the internal network.
+func createTables(tx *sql.Tx, builder sqorc.StatementBuilder) (err error) {
+	_, err = builder.CreateTable(networksTable).
+		IfNotExists().
+		Column(nwIDCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+		Column(nwNameCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(nwDescCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(nwVerCol).Type(sqorc.ColumnTypeInt).NotNull().Default(0).EndColumn().
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create networks table")
+		return
+	}
+
+	_, err = builder.CreateTable(networkConfigTable).
+		IfNotExists().
+		Column(nwcIDCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(nwcTypeCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(nwcValCol).Type(sqorc.ColumnTypeBytes).EndColumn().
+		PrimaryKey(nwcIDCol, nwcTypeCol).
+		ForeignKey(networksTable, map[string]string{nwcIDCol: nwIDCol}, sqorc.ColumnOnDeleteCascade).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create network configs table")
+		return
+	}
+
+	// Create an internal-only primary key (UUID) for entities.
+	// This keeps index size in control and supporting table schemas simpler.
+	_, err = builder.CreateTable(entityTable).
+		IfNotExists().
+		Column(entPkCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+		Column(entNidCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(entTypeCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(entKeyCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(entGidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(entNameCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(entDescCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(entPidCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(entConfCol).Type(sqorc.ColumnTypeBytes).EndColumn().
+		Column(entVerCol).Type(sqorc.ColumnTypeInt).NotNull().Default(0).EndColumn().
+		Unique(entNidCol, entKeyCol, entTypeCol).
+		ForeignKey(networksTable, map[string]string{entNidCol: nwIDCol}, sqorc.ColumnOnDeleteCascade).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create entities table")
+		return
+	}
+
+	_, err = builder.CreateTable(entityAssocTable).
+		IfNotExists().
+		Column(aFrCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(aToCol).Type(sqorc.ColumnTypeText).EndColumn().
+		PrimaryKey(aFrCol, aToCol).
+		ForeignKey(entityTable, map[string]string{aFrCol: entPkCol}, sqorc.ColumnOnDeleteCascade).
+		ForeignKey(entityTable, map[string]string{aToCol: entPkCol}, sqorc.ColumnOnDeleteCascade).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create entity assoc table")
+		return
+	}
+
+	_, err = builder.CreateTable(entityAclTable).
+		IfNotExists().
+		Column(aclIdCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+		Column(aclEntCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(aclScopeCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(aclPermCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		Column(aclTypeCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(aclIdFilterCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(aclVerCol).Type(sqorc.ColumnTypeInt).NotNull().Default(0).EndColumn().
+		ForeignKey(entityTable, map[string]string{aclEntCol: entPkCol}, sqorc.ColumnOnDeleteCascade).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create entity acl table")
+		return
+	}
+
+	// The change log tables intentionally don't reference the networks table
+	// so that the deletion of a network is recorded, and sequence numbers
+	// keep increasing if a network with the same ID is created again.
+	_, err = builder.CreateTable(changeSeqTable).
+		IfNotExists().
+		Column(chsNidCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+		Column(chsSeqCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create change sequence table")
+		return
+	}
+
+	_, err = builder.CreateTable(changeTable).
+		IfNotExists().
+		Column(chNidCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(chSeqCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		Column(chOpCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		Column(chTypeCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(chKeyCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(chTimeCol).Type(sqorc.ColumnTypeBigInt).EndColumn().
+		PrimaryKey(chNidCol, chSeqCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create change log table")
+		return
+	}
+
+	_, err = builder.CreateTable(tombstoneTable).
+		IfNotExists().
+		Column(tsNidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(tsTypeCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(tsKeyCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(tsDeletedCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+		Column(tsEntCol).Type(sqorc.ColumnTypeBytes).NotNull().EndColumn().
+		PrimaryKey(tsNidCol, tsTypeCol, tsKeyCol).
+		ForeignKey(networksTable, map[string]string{tsNidCol: nwIDCol}, sqorc.ColumnOnDeleteCascade).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create tombstone table")
+		return
+	}
+
+	// Like the change log, the audit log doesn't reference the networks
+	// table so that it outlives deleted networks
+	_, err = builder.CreateTable(auditTable).
+		IfNotExists().
+		Column(auIDCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+		Column(auNidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(auTypeCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(auKeyCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(auOpCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		Column(auActorCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(auTimeCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+		Column(auSeqCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+		Column(auDiffsCol).Type(sqorc.ColumnTypeBytes).EndColumn().
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create audit log table")
+		return
+	}
+
+	// Create indexes (index is not implicitly created on a referencing FK)
+	_, err = builder.CreateIndex("graph_id_idx").
+		IfNotExists().
+		On(entityTable).
+		Columns(entGidCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create graph ID index")
+		return
+	}
+
+	_, err = builder.CreateIndex("acl_ent_pk_idx").
+		IfNotExists().
+		On(entityAclTable).
+		Columns(aclEntCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create acl ent PK index")
+		return
+	}
+
+	_, err = builder.CreateIndex("tombstone_deleted_at_idx").
+		IfNotExists().
+		On(tombstoneTable).
+		Columns(tsDeletedCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create tombstone deleted at index")
+		return
+	}
+
+	_, err = builder.CreateIndex("audit_nid_time_idx").
+		IfNotExists().
+		On(auditTable).
+		Columns(auNidCol, auTimeCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create audit log index")
+		return
+	}
+
+	// Create internal network(s)
+	_, err = builder.Insert(networksTable).
+		Columns(nwIDCol, nwNameCol, nwDescCol).
+		Values(InternalNetworkID, internalNetworkName, internalNetworkDescription).
+		OnConflict(nil, nwIDCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "error creating internal networks")
+		return
+	}
+
+	return
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"magma/orc8r/cloud/go/storage"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+// appendChange writes an entry to the change log of a network with the next
+// sequence number for that network.
+func (store *sqlConfiguratorStorage) appendChange(networkID string, op ChangeOperation, entity *storage.TypeAndKey) error {
+	seq, err := store.nextChangeSeq(networkID)
+	if err != nil {
+		return err
+	}
+
+	var entType, entKey interface{}
+	if entity != nil {
+		entType, entKey = entity.Type, entity.Key
+	}
+	_, err = store.builder.Insert(changeTable).
+		Columns(chNidCol, chSeqCol, chOpCol, chTypeCol, chKeyCol, chTimeCol).
+		Values(networkID, seq, op, entType, entKey, time.Now().Unix()).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return errors.Wrapf(err, "failed to write change %d for network %s", seq, networkID)
+	}
+	return nil
+}
+
+// nextChangeSeq increments and returns the change sequence number for a
+// network. Incrementing the counter in place locks the network's counter row
+// until the transaction completes, so concurrent writers to the same network
+// are serialized and changes become visible in sequence order. The counter
+// row is created with an upsert first so that concurrent first writes to a
+// network don't conflict.
+func (store *sqlConfiguratorStorage) nextChangeSeq(networkID string) (uint64, error) {
+	_, err := store.builder.Insert(changeSeqTable).
+		Columns(chsNidCol, chsSeqCol).
+		Values(networkID, 0).
+		OnConflict(nil, chsNidCol).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to initialize change sequence for network %s", networkID)
+	}
+
+	_, err = store.builder.Update(changeSeqTable).
+		Set(chsSeqCol, sq.Expr(fmt.Sprintf("%s+1", chsSeqCol))).
+		Where(sq.Eq{chsNidCol: networkID}).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to increment change sequence for network %s", networkID)
+	}
+
+	var seq uint64
+	err = store.builder.Select(chsSeqCol).
+		From(changeSeqTable).
+		Where(sq.Eq{chsNidCol: networkID}).
+		RunWith(store.tx).
+		QueryRow().
+		Scan(&seq)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to load change sequence for network %s", networkID)
+	}
+	return seq, nil
+}
@@ -10,6 +10,8 @@ package storage
 
 import (
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -59,6 +61,10 @@ func (store *sqlConfiguratorStorage) getLoadEntitiesSelectBuilder(networkID stri
 		selectBuilder = selectBuilder.LeftJoin(fmt.Sprintf("%s AS acl ON acl.%s = ent.%s", entityAclTable, aclEntCol, entPkCol))
 	}
 
+	return selectBuilder.Where(getEntityFilterClause(networkID, filter))
+}
+
+func getEntityFilterClause(networkID string, filter EntityLoadFilter) sq.Sqlizer {
 	// The WHERE has ORs if specific IDs are provided
 	if !funk.IsEmpty(filter.IDs) {
 		orClause := make(sq.Or, 0, len(filter.IDs))
@@ -69,23 +75,116 @@ func (store *sqlConfiguratorStorage) getLoadEntitiesSelectBuilder(networkID stri
 				sq.Eq{fmt.Sprintf("ent.%s", entTypeCol): tk.Type},
 			})
 		})
-		selectBuilder = selectBuilder.Where(orClause)
-	} else {
-		if filter.graphID != nil {
-			selectBuilder = selectBuilder.Where(sq.Eq{fmt.Sprintf("ent.%s", entGidCol): *filter.graphID})
-		} else {
-			andClause := sq.And{sq.Eq{fmt.Sprintf("ent.%s", entNidCol): networkID}}
-			if filter.KeyFilter != nil {
-				andClause = append(andClause, sq.Eq{fmt.Sprintf("ent.%s", entKeyCol): *filter.KeyFilter})
-			}
-			if filter.TypeFilter != nil {
-				andClause = append(andClause, sq.Eq{fmt.Sprintf("ent.%s", entTypeCol): *filter.TypeFilter})
-			}
-			selectBuilder = selectBuilder.Where(andClause)
+		return orClause
+	}
+	if filter.pks != nil {
+		return sq.Eq{fmt.Sprintf("ent.%s", entPkCol): filter.pks}
+	}
+	if filter.graphID != nil {
+		return sq.Eq{fmt.Sprintf("ent.%s", entGidCol): *filter.graphID}
+	}
+
+	andClause := sq.And{sq.Eq{fmt.Sprintf("ent.%s", entNidCol): networkID}}
+	if filter.KeyFilter != nil {
+		andClause = append(andClause, sq.Eq{fmt.Sprintf("ent.%s", entKeyCol): *filter.KeyFilter})
+	}
+	if filter.TypeFilter != nil {
+		andClause = append(andClause, sq.Eq{fmt.Sprintf("ent.%s", entTypeCol): *filter.TypeFilter})
+	}
+	if filter.KeyPrefix != nil {
+		andClause = append(andClause, sqorc.HasPrefix(fmt.Sprintf("ent.%s", entKeyCol), *filter.KeyPrefix))
+	}
+	if filter.PhysicalID != nil {
+		andClause = append(andClause, sq.Eq{fmt.Sprintf("ent.%s", entPidCol): *filter.PhysicalID})
+	}
+	return andClause
+}
+
+// loadEntityPage loads the PKs of a single page of entities matching the
+// filter, in (type, key) order. The token for the next page is also returned,
+// which will be empty if this is the last page.
+func (store *sqlConfiguratorStorage) loadEntityPage(networkID string, filter EntityLoadFilter) ([]string, string, error) {
+	// SELECT ent.pk, ent.type, ent.key FROM cfg_entities AS ent
+	// WHERE ent.network_id = $1 [[ AND ... ]]
+	// [[ AND ((ent.type > $2) OR (ent.type = $3 AND ent.key > $4)) ]]
+	// ORDER BY ent.type, ent.key
+	// LIMIT {page size + 1}
+	typeCol, keyCol := fmt.Sprintf("ent.%s", entTypeCol), fmt.Sprintf("ent.%s", entKeyCol)
+	selectBuilder := store.builder.Select(fmt.Sprintf("ent.%s", entPkCol), typeCol, keyCol).
+		From(fmt.Sprintf("%s AS ent", entityTable)).
+		Where(getEntityFilterClause(networkID, filter)).
+		OrderBy(typeCol, keyCol).
+		// Load one extra row to see if there is a next page
+		Limit(uint64(filter.PageSize) + 1)
+	if filter.PageToken != "" {
+		lastTk, err := decodePageToken(filter.PageToken)
+		if err != nil {
+			return nil, "", err
 		}
+		selectBuilder = selectBuilder.Where(sqorc.TupleGreaterThan([]string{typeCol, keyCol}, []interface{}{lastTk.Type, lastTk.Key}))
 	}
 
-	return selectBuilder
+	rows, err := selectBuilder.RunWith(store.tx).Query()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "error querying for entity page")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "LoadEntities")
+
+	pks := []string{}
+	var lastTk storage.TypeAndKey
+	hasNextPage := false
+	for rows.Next() {
+		if len(pks) == int(filter.PageSize) {
+			hasNextPage = true
+			break
+		}
+		var pk string
+		err = rows.Scan(&pk, &lastTk.Type, &lastTk.Key)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "error scanning entity page row")
+		}
+		pks = append(pks, pk)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", errors.Wrap(err, "error iterating over entity page rows")
+	}
+
+	if !hasNextPage {
+		return pks, "", nil
+	}
+	nextPageToken, err := encodePageToken(lastTk)
+	if err != nil {
+		return nil, "", err
+	}
+	return pks, nextPageToken, nil
+}
+
+// entityPageToken is the decoded form of the opaque page token returned from
+// paginated entity loads. It records the (type, key) of the last entity in
+// the previous page.
+type entityPageToken struct {
+	LastType string `json:"t"`
+	LastKey  string `json:"k"`
+}
+
+func encodePageToken(lastTk storage.TypeAndKey) (string, error) {
+	marshaled, err := json.Marshal(entityPageToken{LastType: lastTk.Type, LastKey: lastTk.Key})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode page token")
+	}
+	return base64.RawURLEncoding.EncodeToString(marshaled), nil
+}
+
+func decodePageToken(token string) (storage.TypeAndKey, error) {
+	marshaled, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return storage.TypeAndKey{}, errors.Wrap(err, "invalid page token")
+	}
+	decoded := entityPageToken{}
+	if err := json.Unmarshal(marshaled, &decoded); err != nil {
+		return storage.TypeAndKey{}, errors.Wrap(err, "invalid page token")
+	}
+	return storage.TypeAndKey{Type: decoded.LastType, Key: decoded.LastKey}, nil
 }
 
 func getLoadEntitiesColumns(criteria EntityLoadCriteria) []string {
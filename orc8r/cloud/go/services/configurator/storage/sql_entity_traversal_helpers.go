@@ -0,0 +1,189 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"magma/orc8r/cloud/go/sqorc"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+const traversalCteName = "traversal"
+
+// traverseWithRecursiveCTE follows edges from the start entity in a single
+// recursive query. The CTE tracks the depth at which each edge was followed
+// so the per-hop type filters can be applied within the query. It also tracks
+// the pks on the path to each edge as a delimited string; an edge back to an
+// entity already on its path is returned but not followed, so cycles in the
+// graph don't get walked around until MaxDepth is reached.
+func (store *sqlConfiguratorStorage) traverseWithRecursiveCTE(startPk string, traversal GraphTraversal) ([]loadedAssoc, error) {
+	curCol, nextCol := getTraversalCols(traversal.Direction)
+
+	// SELECT a.from_pk, a.to_pk, a.to_pk, 1,
+	//   ',' || a.from_pk || ',' || a.to_pk || ',', a.to_pk = a.from_pk
+	// FROM cfg_assocs AS a
+	// JOIN cfg_entities AS e ON e.pk = a.to_pk
+	// WHERE a.from_pk = $1 AND e.type = $2
+	baseCase := sq.Select(
+		fmt.Sprintf("a.%s", aFrCol), fmt.Sprintf("a.%s", aToCol), fmt.Sprintf("a.%s", nextCol), "1",
+		fmt.Sprintf("',' || a.%s || ',' || a.%s || ','", curCol, nextCol),
+		fmt.Sprintf("a.%s = a.%s", nextCol, curCol),
+	).
+		From(fmt.Sprintf("%s AS a", entityAssocTable)).
+		Join(fmt.Sprintf("%s AS e ON e.%s = a.%s", entityTable, entPkCol, nextCol)).
+		Where(sq.Eq{fmt.Sprintf("a.%s", curCol): startPk})
+	if hopType := traversal.getHopType(1); hopType != "" {
+		baseCase = baseCase.Where(sq.Eq{fmt.Sprintf("e.%s", entTypeCol): hopType})
+	}
+
+	// SELECT a.from_pk, a.to_pk, a.to_pk, t.depth + 1,
+	//   t.path || a.to_pk || ',', t.path LIKE ('%,' || a.to_pk || ',%')
+	// FROM traversal AS t
+	// JOIN cfg_assocs AS a ON a.from_pk = t.pk
+	// JOIN cfg_entities AS e ON e.pk = a.to_pk
+	// WHERE NOT t.is_cycle AND t.depth < $3 AND (t.depth <> $4 OR e.type = $5) ...
+	recursiveCase := sq.Select(
+		fmt.Sprintf("a.%s", aFrCol), fmt.Sprintf("a.%s", aToCol), fmt.Sprintf("a.%s", nextCol), "t.depth + 1",
+		fmt.Sprintf("t.path || a.%s || ','", nextCol),
+		fmt.Sprintf("t.path LIKE ('%%,' || a.%s || ',%%')", nextCol),
+	).
+		From(fmt.Sprintf("%s AS t", traversalCteName)).
+		Join(fmt.Sprintf("%s AS a ON a.%s = t.%s", entityAssocTable, curCol, entPkCol)).
+		Join(fmt.Sprintf("%s AS e ON e.%s = a.%s", entityTable, entPkCol, nextCol)).
+		Where("NOT t.is_cycle").
+		Where(sq.Lt{"t.depth": traversal.MaxDepth})
+	for hop := uint32(2); hop <= traversal.MaxDepth && int(hop) <= len(traversal.HopTypes); hop++ {
+		if hopType := traversal.getHopType(hop); hopType != "" {
+			recursiveCase = recursiveCase.Where(sq.Or{
+				sq.NotEq{"t.depth": hop - 1},
+				sq.Eq{fmt.Sprintf("e.%s", entTypeCol): hopType},
+			})
+		}
+	}
+
+	baseSql, baseArgs, err := baseCase.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build base case of traversal query")
+	}
+	recursiveSql, recursiveArgs, err := recursiveCase.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build recursive case of traversal query")
+	}
+	cte := fmt.Sprintf(
+		"WITH RECURSIVE %s(%s, %s, %s, depth, path, is_cycle) AS (%s UNION %s)",
+		traversalCteName, aFrCol, aToCol, entPkCol, baseSql, recursiveSql,
+	)
+
+	rows, err := store.builder.Select(aFrCol, aToCol).
+		Distinct().
+		From(traversalCteName).
+		Prefix(cte, append(baseArgs, recursiveArgs...)...).
+		RunWith(store.tx).
+		Query()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query for traversal")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "TraverseGraph")
+	return scanTraversedEdges(rows)
+}
+
+// traverseIteratively follows edges from the start entity with one query per
+// hop, for dialects which don't support recursive CTEs. Entities which have
+// already been traversed from aren't added to the frontier again.
+func (store *sqlConfiguratorStorage) traverseIteratively(startPk string, traversal GraphTraversal) ([]loadedAssoc, error) {
+	curCol, nextCol := getTraversalCols(traversal.Direction)
+
+	ret := []loadedAssoc{}
+	seenEdges := map[loadedAssoc]bool{}
+	visited := map[string]bool{startPk: true}
+	frontier := []string{startPk}
+	for hop := uint32(1); hop <= traversal.MaxDepth && len(frontier) > 0; hop++ {
+		// SELECT a.from_pk, a.to_pk FROM cfg_assocs AS a
+		// JOIN cfg_entities AS e ON e.pk = a.to_pk
+		// WHERE a.from_pk IN ($1, $2, ...) AND e.type = $3
+		selectBuilder := store.builder.Select(fmt.Sprintf("a.%s", aFrCol), fmt.Sprintf("a.%s", aToCol)).
+			From(fmt.Sprintf("%s AS a", entityAssocTable)).
+			Where(sq.Eq{fmt.Sprintf("a.%s", curCol): frontier})
+		if hopType := traversal.getHopType(hop); hopType != "" {
+			selectBuilder = selectBuilder.
+				Join(fmt.Sprintf("%s AS e ON e.%s = a.%s", entityTable, entPkCol, nextCol)).
+				Where(sq.Eq{fmt.Sprintf("e.%s", entTypeCol): hopType})
+		}
+		hopEdges, err := store.queryTraversalHop(selectBuilder)
+		if err != nil {
+			return nil, err
+		}
+
+		nextFrontier := map[string]bool{}
+		for _, edge := range hopEdges {
+			if !seenEdges[edge] {
+				seenEdges[edge] = true
+				ret = append(ret, edge)
+			}
+			nextPk := getTraversedPk(edge, traversal.Direction)
+			if !visited[nextPk] {
+				visited[nextPk] = true
+				nextFrontier[nextPk] = true
+			}
+		}
+		frontier = make([]string, 0, len(nextFrontier))
+		for pk := range nextFrontier {
+			frontier = append(frontier, pk)
+		}
+		sort.Strings(frontier)
+	}
+	return ret, nil
+}
+
+func (store *sqlConfiguratorStorage) queryTraversalHop(selectBuilder sq.SelectBuilder) ([]loadedAssoc, error) {
+	rows, err := selectBuilder.RunWith(store.tx).Query()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query for traversal hop")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "TraverseGraph")
+	return scanTraversedEdges(rows)
+}
+
+func scanTraversedEdges(rows *sql.Rows) ([]loadedAssoc, error) {
+	ret := []loadedAssoc{}
+	for rows.Next() {
+		var fromPk, toPk string
+		err := rows.Scan(&fromPk, &toPk)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan traversed edge")
+		}
+		ret = append(ret, loadedAssoc{fromPk: fromPk, toPk: toPk})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "sql rows err")
+	}
+	return ret, nil
+}
+
+// getTraversalCols returns the assoc column of the entity being traversed
+// from and the assoc column of the entity being traversed to.
+func getTraversalCols(direction TraversalDirection) (string, string) {
+	if direction == TraverseAssocsToThis {
+		return aToCol, aFrCol
+	}
+	return aFrCol, aToCol
+}
+
+// getTraversedPk returns the pk of the entity reached by following an edge
+func getTraversedPk(edge loadedAssoc, direction TraversalDirection) string {
+	if direction == TraverseAssocsToThis {
+		return edge.fromPk
+	}
+	return edge.toPk
+}
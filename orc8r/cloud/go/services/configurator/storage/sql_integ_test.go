@@ -12,13 +12,16 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/services/configurator/storage"
 	"magma/orc8r/cloud/go/sqorc"
 	storage2 "magma/orc8r/cloud/go/storage"
 
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -689,3 +692,605 @@ func TestSqlConfiguratorStorage_Integration(t *testing.T) {
 		allEnts,
 	)
 }
+
+func TestSqlConfiguratorStorage_VersionPreconditions(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n1"})
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n2"})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "bar"})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "baz"})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	// Network update at the current version succeeds and bumps the version
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	newName := "foo"
+	err = store.UpdateNetworks([]storage.NetworkUpdateCriteria{{ID: "n1", NewName: &newName, ExpectedVersion: uint64Pointer(0)}})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	// Stale network update fails with a version conflict
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	newName = "bar"
+	err = store.UpdateNetworks([]storage.NetworkUpdateCriteria{{ID: "n1", NewName: &newName, ExpectedVersion: uint64Pointer(0)}})
+	assert.True(t, storage.IsVersionConflict(err))
+	assert.NoError(t, store.Rollback())
+
+	// Stale network delete fails, current one succeeds
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	err = store.UpdateNetworks([]storage.NetworkUpdateCriteria{{ID: "n2", DeleteNetwork: true, ExpectedVersion: uint64Pointer(1)}})
+	assert.True(t, storage.IsVersionConflict(err))
+	assert.NoError(t, store.Rollback())
+
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	err = store.UpdateNetworks([]storage.NetworkUpdateCriteria{{ID: "n2", DeleteNetwork: true, ExpectedVersion: uint64Pointer(0)}})
+	assert.NoError(t, err)
+	loadNetworksActual, err := store.LoadNetworks([]string{"n1", "n2"}, storage.FullNetworkLoadCriteria)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		storage.NetworkLoadResult{
+			Networks:           []storage.Network{{ID: "n1", Name: "foo", Configs: map[string][]byte{}, Version: 1}},
+			NetworkIDsNotFound: []string{"n2"},
+		},
+		loadNetworksActual,
+	)
+	assert.NoError(t, store.Commit())
+
+	// Entity update at the current version succeeds
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	newConfig := []byte("hello")
+	updatedEnt, err := store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", NewConfig: &newConfig, ExpectedVersion: uint64Pointer(0)})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), updatedEnt.Version)
+	assert.NoError(t, store.Commit())
+
+	// Stale entity update and delete fail with a version conflict
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	newConfig = []byte("world")
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", NewConfig: &newConfig, ExpectedVersion: uint64Pointer(0)})
+	assert.True(t, storage.IsVersionConflict(err))
+	assert.NoError(t, store.Rollback())
+
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "baz", DeleteEntity: true, ExpectedVersion: uint64Pointer(3)})
+	assert.True(t, storage.IsVersionConflict(err))
+	assert.NoError(t, store.Rollback())
+
+	// Entity delete at the current version succeeds
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "baz", DeleteEntity: true, ExpectedVersion: uint64Pointer(0)})
+	assert.NoError(t, err)
+	actualEntityLoad, err := store.LoadEntities("n1", storage.EntityLoadFilter{}, storage.FullEntityLoadCriteria)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		storage.EntityLoadResult{
+			Entities:         []storage.NetworkEntity{{Type: "foo", Key: "bar", GraphID: "2", Config: []byte("hello"), Version: 1}},
+			EntitiesNotFound: []storage2.TypeAndKey{},
+		},
+		actualEntityLoad,
+	)
+	assert.NoError(t, store.Commit())
+}
+
+func TestSqlConfiguratorStorage_PaginatedLoad(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n1"})
+	assert.NoError(t, err)
+	for _, ent := range []storage.NetworkEntity{
+		{Type: "foo", Key: "b2"},
+		{Type: "foo", Key: "a1", PhysicalID: "p1"},
+		{Type: "bar", Key: "a_1"},
+		{Type: "foo", Key: "a2"},
+		{Type: "bar", Key: "ab"},
+		{Type: "foo", Key: "a3", PhysicalID: "p2"},
+	} {
+		_, err = store.CreateEntity("n1", ent)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, store.Commit())
+
+	// Walk all entities 4 at a time
+	store, err = factory.StartTransaction(context.Background(), &storage.TxOptions{ReadOnly: true})
+	assert.NoError(t, err)
+	actual, err := store.LoadEntities("n1", storage.EntityLoadFilter{PageSize: 4}, storage.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Equal(t, []storage2.TypeAndKey{{Type: "bar", Key: "a_1"}, {Type: "bar", Key: "ab"}, {Type: "foo", Key: "a1"}, {Type: "foo", Key: "a2"}}, getTKs(actual.Entities))
+	assert.NotEmpty(t, actual.NextPageToken)
+
+	actual, err = store.LoadEntities("n1", storage.EntityLoadFilter{PageSize: 4, PageToken: actual.NextPageToken}, storage.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Equal(t, []storage2.TypeAndKey{{Type: "foo", Key: "a3"}, {Type: "foo", Key: "b2"}}, getTKs(actual.Entities))
+	assert.Empty(t, actual.NextPageToken)
+
+	// Exactly filling a page should not return a next page token
+	actual, err = store.LoadEntities("n1", storage.EntityLoadFilter{TypeFilter: stringPointer("bar"), PageSize: 2}, storage.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Equal(t, []storage2.TypeAndKey{{Type: "bar", Key: "a_1"}, {Type: "bar", Key: "ab"}}, getTKs(actual.Entities))
+	assert.Empty(t, actual.NextPageToken)
+
+	// Key prefix filter with a LIKE wildcard in the prefix
+	actual, err = store.LoadEntities("n1", storage.EntityLoadFilter{KeyPrefix: stringPointer("a_")}, storage.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Equal(t, []storage2.TypeAndKey{{Type: "bar", Key: "a_1"}}, getTKs(actual.Entities))
+
+	actual, err = store.LoadEntities("n1", storage.EntityLoadFilter{TypeFilter: stringPointer("foo"), KeyPrefix: stringPointer("a"), PageSize: 2}, storage.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Equal(t, []storage2.TypeAndKey{{Type: "foo", Key: "a1"}, {Type: "foo", Key: "a2"}}, getTKs(actual.Entities))
+	actual, err = store.LoadEntities("n1", storage.EntityLoadFilter{TypeFilter: stringPointer("foo"), KeyPrefix: stringPointer("a"), PageSize: 2, PageToken: actual.NextPageToken}, storage.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Equal(t, []storage2.TypeAndKey{{Type: "foo", Key: "a3"}}, getTKs(actual.Entities))
+	assert.Empty(t, actual.NextPageToken)
+
+	// Physical ID filter
+	actual, err = store.LoadEntities("n1", storage.EntityLoadFilter{PhysicalID: stringPointer("p2")}, storage.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Equal(t, []storage2.TypeAndKey{{Type: "foo", Key: "a3"}}, getTKs(actual.Entities))
+
+	// Malformed page token
+	_, err = store.LoadEntities("n1", storage.EntityLoadFilter{PageSize: 2, PageToken: "?"}, storage.EntityLoadCriteria{})
+	assert.Error(t, err)
+	assert.NoError(t, store.Commit())
+}
+
+func TestSqlConfiguratorStorage_ChangeLog(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n1"})
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n2"})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "bar"})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n2", storage.NetworkEntity{Type: "foo", Key: "bar"})
+	assert.NoError(t, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", NewName: stringPointer("foobar")})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	// Rolled back writes should not show up in the change log
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "baz"})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Rollback())
+
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", DeleteEntity: true})
+	assert.NoError(t, err)
+	err = store.UpdateNetworks([]storage.NetworkUpdateCriteria{{ID: "n1", NewName: stringPointer("network 1")}})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	fooBar := &storage2.TypeAndKey{Type: "foo", Key: "bar"}
+	store, err = factory.StartTransaction(context.Background(), &storage.TxOptions{ReadOnly: true})
+	assert.NoError(t, err)
+	actual, err := store.LoadChanges("n1", 0, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.Change{
+		{NetworkID: "n1", Sequence: 1, Operation: storage.ChangeCreate, Entity: fooBar},
+		{NetworkID: "n1", Sequence: 2, Operation: storage.ChangeUpdate, Entity: fooBar},
+		{NetworkID: "n1", Sequence: 3, Operation: storage.ChangeDelete, Entity: fooBar},
+		{NetworkID: "n1", Sequence: 4, Operation: storage.ChangeUpdate},
+	}, actual)
+
+	actual, err = store.LoadChanges("n1", 2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.Change{{NetworkID: "n1", Sequence: 3, Operation: storage.ChangeDelete, Entity: fooBar}}, actual)
+
+	actual, err = store.LoadChanges("n2", 0, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.Change{{NetworkID: "n2", Sequence: 1, Operation: storage.ChangeCreate, Entity: fooBar}}, actual)
+	assert.NoError(t, store.Commit())
+
+	// Deleting a network is recorded and the sequence continues if the
+	// network is created again
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	err = store.UpdateNetworks([]storage.NetworkUpdateCriteria{{ID: "n2", DeleteNetwork: true}})
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n2"})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n2", storage.NetworkEntity{Type: "foo", Key: "bar"})
+	assert.NoError(t, err)
+	actual, err = store.LoadChanges("n2", 1, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.Change{
+		{NetworkID: "n2", Sequence: 2, Operation: storage.ChangeDelete},
+		{NetworkID: "n2", Sequence: 3, Operation: storage.ChangeCreate, Entity: fooBar},
+	}, actual)
+
+	latestSeq, err := store.GetLatestChangeSeq("n2")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), latestSeq)
+	latestSeq, err = store.GetLatestChangeSeq("n3")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), latestSeq)
+	assert.NoError(t, store.Commit())
+
+	// Purge
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	purged, err := store.PurgeChanges(time.Now().Unix() - 60)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), purged)
+	purged, err = store.PurgeChanges(time.Now().Unix() + 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), purged)
+
+	// Watchers behind the purge can't resume, but up to date watchers can
+	_, err = store.LoadChanges("n2", 1, 100)
+	assert.True(t, storage.IsChangesPurged(err))
+	actual, err = store.LoadChanges("n2", 3, 100)
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+
+	// The sequence isn't reset by the purge
+	_, err = store.CreateEntity("n2", storage.NetworkEntity{Type: "foo", Key: "baz"})
+	assert.NoError(t, err)
+	actual, err = store.LoadChanges("n2", 3, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.Change{
+		{NetworkID: "n2", Sequence: 4, Operation: storage.ChangeCreate, Entity: &storage2.TypeAndKey{Type: "foo", Key: "baz"}},
+	}, actual)
+	assert.NoError(t, store.Commit())
+}
+
+func getTKs(ents []storage.NetworkEntity) []storage2.TypeAndKey {
+	ret := make([]storage2.TypeAndKey, 0, len(ents))
+	for _, ent := range ents {
+		ret = append(ret, ent.GetTypeAndKey())
+	}
+	return ret
+}
+
+func uint64Pointer(val uint64) *uint64 {
+	return &val
+}
+
+func TestSqlConfiguratorStorage_TraverseGraph(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+
+	// t1 -> g1, t1 -> g2
+	// g1 -> e1, g1 -> e2, g1 -> s1
+	// g2 -> e2, g2 -> e3
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n1"})
+	assert.NoError(t, err)
+	for _, ent := range []storage.NetworkEntity{
+		{Type: "enodeb", Key: "e1"},
+		{Type: "enodeb", Key: "e2"},
+		{Type: "enodeb", Key: "e3"},
+		{Type: "subscriber", Key: "s1"},
+		{Type: "gateway", Key: "g1", Associations: []storage2.TypeAndKey{{Type: "enodeb", Key: "e1"}, {Type: "enodeb", Key: "e2"}, {Type: "subscriber", Key: "s1"}}},
+		{Type: "gateway", Key: "g2", Associations: []storage2.TypeAndKey{{Type: "enodeb", Key: "e2"}, {Type: "enodeb", Key: "e3"}}},
+		{Type: "tier", Key: "t1", Associations: []storage2.TypeAndKey{{Type: "gateway", Key: "g1"}, {Type: "gateway", Key: "g2"}}},
+	} {
+		_, err = store.CreateEntity("n1", ent)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, store.Commit())
+
+	tk := func(typ, key string) storage2.TypeAndKey { return storage2.TypeAndKey{Type: typ, Key: key} }
+	edge := func(from, to storage2.TypeAndKey) storage.GraphEdge { return storage.GraphEdge{From: from, To: to} }
+	t1, g1, g2 := tk("tier", "t1"), tk("gateway", "g1"), tk("gateway", "g2")
+	e1, e2, e3, s1 := tk("enodeb", "e1"), tk("enodeb", "e2"), tk("enodeb", "e3"), tk("subscriber", "s1")
+
+	tcs := []struct {
+		traversal     storage.GraphTraversal
+		expectedEnts  []storage2.TypeAndKey
+		expectedEdges []storage.GraphEdge
+	}{
+		{
+			traversal:     storage.GraphTraversal{Start: t1, MaxDepth: 1},
+			expectedEnts:  []storage2.TypeAndKey{g1, g2},
+			expectedEdges: []storage.GraphEdge{edge(t1, g1), edge(t1, g2)},
+		},
+		{
+			traversal:     storage.GraphTraversal{Start: t1, HopTypes: []string{"gateway", "enodeb"}, MaxDepth: 2},
+			expectedEnts:  []storage2.TypeAndKey{e1, e2, e3, g1, g2},
+			expectedEdges: []storage.GraphEdge{edge(g1, e1), edge(g1, e2), edge(g2, e2), edge(g2, e3), edge(t1, g1), edge(t1, g2)},
+		},
+		// Hop type filter excludes the subscriber
+		{
+			traversal:     storage.GraphTraversal{Start: g1, HopTypes: []string{"enodeb"}, MaxDepth: 5},
+			expectedEnts:  []storage2.TypeAndKey{e1, e2},
+			expectedEdges: []storage.GraphEdge{edge(g1, e1), edge(g1, e2)},
+		},
+		// Empty hop type matches anything
+		{
+			traversal:     storage.GraphTraversal{Start: t1, HopTypes: []string{"", "subscriber"}, MaxDepth: 3},
+			expectedEnts:  []storage2.TypeAndKey{g1, g2, s1},
+			expectedEdges: []storage.GraphEdge{edge(g1, s1), edge(t1, g1), edge(t1, g2)},
+		},
+		// Reverse traversal to find the owning tier
+		{
+			traversal:     storage.GraphTraversal{Start: e2, Direction: storage.TraverseAssocsToThis, MaxDepth: 5},
+			expectedEnts:  []storage2.TypeAndKey{g1, g2, t1},
+			expectedEdges: []storage.GraphEdge{edge(g1, e2), edge(g2, e2), edge(t1, g1), edge(t1, g2)},
+		},
+		{
+			traversal:     storage.GraphTraversal{Start: e3, Direction: storage.TraverseAssocsToThis, HopTypes: []string{"", "tier"}, MaxDepth: 2},
+			expectedEnts:  []storage2.TypeAndKey{g2, t1},
+			expectedEdges: []storage.GraphEdge{edge(g2, e3), edge(t1, g2)},
+		},
+		// Leaf entity
+		{
+			traversal:     storage.GraphTraversal{Start: e1, MaxDepth: 3},
+			expectedEnts:  []storage2.TypeAndKey{},
+			expectedEdges: []storage.GraphEdge{},
+		},
+	}
+
+	// Run against both the recursive CTE and the iterative implementations
+	builders := map[string]sqorc.StatementBuilder{
+		"postgres": sqorc.NewPostgresStatementBuilder(),
+		"maria":    sqorc.NewMariaDBStatementBuilder(),
+	}
+	for name, builder := range builders {
+		factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, builder)
+		store, err := factory.StartTransaction(context.Background(), &storage.TxOptions{ReadOnly: true})
+		assert.NoError(t, err)
+		for i, tc := range tcs {
+			actual, err := store.TraverseGraph("n1", tc.traversal, storage.EntityLoadCriteria{})
+			assert.NoError(t, err, "%s case %d", name, i)
+			assert.Equal(t, tc.expectedEnts, getTKs(actual.Entities), "%s case %d", name, i)
+			assert.Equal(t, tc.expectedEdges, actual.Edges, "%s case %d", name, i)
+		}
+
+		_, err = store.TraverseGraph("n1", storage.GraphTraversal{Start: tk("gateway", "g3"), MaxDepth: 1}, storage.EntityLoadCriteria{})
+		assert.Error(t, err)
+		_, err = store.TraverseGraph("n1", storage.GraphTraversal{Start: t1}, storage.EntityLoadCriteria{})
+		assert.Error(t, err)
+		_, err = store.TraverseGraph("n1", storage.GraphTraversal{Start: t1, MaxDepth: storage.MaxTraversalDepth + 1}, storage.EntityLoadCriteria{})
+		assert.Error(t, err)
+		assert.NoError(t, store.Commit())
+	}
+
+	// r1 -> r2 -> r3 -> r1, r3 -> r4
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n2"})
+	assert.NoError(t, err)
+	for _, ent := range []storage.NetworkEntity{
+		{Type: "ring", Key: "r4"},
+		{Type: "ring", Key: "r3", Associations: []storage2.TypeAndKey{{Type: "ring", Key: "r4"}}},
+		{Type: "ring", Key: "r2", Associations: []storage2.TypeAndKey{{Type: "ring", Key: "r3"}}},
+		{Type: "ring", Key: "r1", Associations: []storage2.TypeAndKey{{Type: "ring", Key: "r2"}}},
+	} {
+		_, err = store.CreateEntity("n2", ent)
+		assert.NoError(t, err)
+	}
+	_, err = store.UpdateEntity("n2", storage.EntityUpdateCriteria{
+		Type: "ring", Key: "r3", AssociationsToAdd: []storage2.TypeAndKey{{Type: "ring", Key: "r1"}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	r1, r2, r3, r4 := tk("ring", "r1"), tk("ring", "r2"), tk("ring", "r3"), tk("ring", "r4")
+	for name, builder := range builders {
+		factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, builder)
+		store, err := factory.StartTransaction(context.Background(), &storage.TxOptions{ReadOnly: true})
+		assert.NoError(t, err)
+		actual, err := store.TraverseGraph("n2", storage.GraphTraversal{Start: r1, MaxDepth: storage.MaxTraversalDepth}, storage.EntityLoadCriteria{})
+		assert.NoError(t, err, name)
+		assert.Equal(t, []storage2.TypeAndKey{r1, r2, r3, r4}, getTKs(actual.Entities), name)
+		assert.Equal(t, []storage.GraphEdge{edge(r1, r2), edge(r2, r3), edge(r3, r1), edge(r3, r4)}, actual.Edges, name)
+		assert.NoError(t, store.Commit())
+	}
+}
+
+func TestSqlConfiguratorStorage_Tombstones(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+
+	t1 := storage2.TypeAndKey{Type: "tier", Key: "t1"}
+	g1 := storage2.TypeAndKey{Type: "gateway", Key: "g1"}
+	e1 := storage2.TypeAndKey{Type: "enodeb", Key: "e1"}
+	e2 := storage2.TypeAndKey{Type: "enodeb", Key: "e2"}
+	g1Perm := storage.ACL{
+		Scope:      storage.ACLScope{NetworkIDs: []string{"n1"}},
+		Permission: storage.ReadPermission,
+		Type:       storage.ACLType{EntityType: "enodeb"},
+	}
+
+	// t1 -> g1, g1 -> e1, g1 -> e2
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n1"})
+	assert.NoError(t, err)
+	for _, ent := range []storage.NetworkEntity{
+		{Type: "enodeb", Key: "e1"},
+		{Type: "enodeb", Key: "e2"},
+		{Type: "gateway", Key: "g1", Name: "gw", Config: []byte("cfg"), Associations: []storage2.TypeAndKey{e1, e2}, Permissions: []storage.ACL{g1Perm}},
+		{Type: "tier", Key: "t1", Associations: []storage2.TypeAndKey{g1}},
+	} {
+		_, err = store.CreateEntity("n1", ent)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, store.Commit())
+
+	// Delete g1, then e2
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "gateway", Key: "g1", DeleteEntity: true})
+	assert.NoError(t, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "enodeb", Key: "e2", DeleteEntity: true})
+	assert.NoError(t, err)
+
+	// Tombstoned entities aren't loaded
+	loadResult, err := store.LoadEntities("n1", storage.EntityLoadFilter{IDs: []storage2.TypeAndKey{g1}}, storage.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Empty(t, loadResult.Entities)
+
+	tombstones, err := store.LoadTombstones("n1", storage.EntityLoadFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []storage2.TypeAndKey{e2, g1}, []storage2.TypeAndKey{tombstones[0].Entity.GetTypeAndKey(), tombstones[1].Entity.GetTypeAndKey()})
+	g1Tombstone := tombstones[1]
+	assert.NotZero(t, g1Tombstone.DeletedAt)
+	assert.Equal(t, "gw", g1Tombstone.Entity.Name)
+	assert.Equal(t, []byte("cfg"), g1Tombstone.Entity.Config)
+	assert.Equal(t, []storage2.TypeAndKey{e1, e2}, g1Tombstone.Entity.Associations)
+	assert.Equal(t, []storage2.TypeAndKey{t1}, g1Tombstone.Entity.ParentAssociations)
+	assert.Len(t, g1Tombstone.Entity.Permissions, 1)
+
+	typeFilter := "gateway"
+	tombstones, err = store.LoadTombstones("n1", storage.EntityLoadFilter{TypeFilter: &typeFilter})
+	assert.NoError(t, err)
+	assert.Len(t, tombstones, 1)
+	assert.NoError(t, store.Commit())
+
+	// Restore g1. The assoc to e2 can't be restored since e2 is deleted.
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	restored, err := store.RestoreEntity("n1", g1)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage2.TypeAndKey{e1}, restored.Associations)
+	assert.Equal(t, []storage2.TypeAndKey{t1}, restored.ParentAssociations)
+
+	loadResult, err = store.LoadEntities("n1", storage.EntityLoadFilter{IDs: []storage2.TypeAndKey{g1}}, storage.FullEntityLoadCriteria)
+	assert.NoError(t, err)
+	assert.Len(t, loadResult.Entities, 1)
+	actual := loadResult.Entities[0]
+	assert.Equal(t, "gw", actual.Name)
+	assert.Equal(t, []byte("cfg"), actual.Config)
+	assert.Equal(t, []storage2.TypeAndKey{e1}, actual.Associations)
+	assert.Equal(t, []storage2.TypeAndKey{t1}, actual.ParentAssociations)
+	assert.Len(t, actual.Permissions, 1)
+	assert.Equal(t, g1Perm.Scope, actual.Permissions[0].Scope)
+	assert.Equal(t, g1Perm.Type, actual.Permissions[0].Type)
+	assert.Equal(t, g1Perm.Permission, actual.Permissions[0].Permission)
+
+	// Restored entities are rejoined to their parents' graphs
+	graph, err := store.LoadGraphForEntity("n1", t1, storage.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Equal(t, []storage2.TypeAndKey{e1, g1, t1}, getTKs(graph.Entities))
+
+	// The tombstone is gone once the entity is restored
+	_, err = store.RestoreEntity("n1", g1)
+	assert.Equal(t, merrors.ErrNotFound, errors.Cause(err))
+	tombstones, err = store.LoadTombstones("n1", storage.EntityLoadFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, tombstones, 1)
+	assert.NoError(t, store.Commit())
+
+	// Purge
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	purged, err := store.PurgeTombstones(tombstones[0].DeletedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), purged)
+	purged, err = store.PurgeTombstones(time.Now().Unix() + 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	tombstones, err = store.LoadTombstones("n1", storage.EntityLoadFilter{})
+	assert.NoError(t, err)
+	assert.Empty(t, tombstones)
+	assert.NoError(t, store.Commit())
+}
+
+func TestSqlConfiguratorStorage_AuditLog(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+
+	g1 := storage2.TypeAndKey{Type: "gateway", Key: "g1"}
+	g2 := storage2.TypeAndKey{Type: "gateway", Key: "g2"}
+	op1, op2 := "Id_Operator_op1", "Id_Operator_op2"
+	entries := []storage.AuditEntry{
+		{NetworkID: "n1", Operation: storage.ChangeCreate, Actor: op1, Timestamp: 100, Diffs: []storage.AuditFieldDiff{{Field: "name", After: "network 1"}}},
+		{NetworkID: "n1", Entity: &g1, Operation: storage.ChangeCreate, Actor: op1, Timestamp: 200, Diffs: []storage.AuditFieldDiff{{Field: "config", After: "foo"}}},
+		{NetworkID: "n1", Entity: &g1, Operation: storage.ChangeUpdate, Actor: op2, Timestamp: 300, Diffs: []storage.AuditFieldDiff{{Field: "config", Before: "foo", After: "bar"}}},
+		{NetworkID: "n1", Entity: &g2, Operation: storage.ChangeDelete, Timestamp: 400},
+		{NetworkID: "n2", Operation: storage.ChangeCreate, Actor: op1, Timestamp: 100},
+	}
+
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	for _, entry := range entries {
+		assert.NoError(t, store.WriteAuditEntry(entry))
+	}
+	assert.NoError(t, store.Commit())
+
+	// Fill in expected IDs
+	for i := range entries {
+		entries[i].ID = fmt.Sprintf("%d", i+1)
+	}
+
+	tcs := []struct {
+		filter   storage.AuditEntryFilter
+		expected []storage.AuditEntry
+	}{
+		{filter: storage.AuditEntryFilter{NetworkID: "n1"}, expected: entries[:4]},
+		{filter: storage.AuditEntryFilter{NetworkID: "n2"}, expected: entries[4:]},
+		{filter: storage.AuditEntryFilter{NetworkID: "n3"}, expected: []storage.AuditEntry{}},
+		{filter: storage.AuditEntryFilter{NetworkID: "n1", Entity: &g1}, expected: entries[1:3]},
+		{filter: storage.AuditEntryFilter{NetworkID: "n1", Actor: &op1}, expected: entries[:2]},
+		{filter: storage.AuditEntryFilter{NetworkID: "n1", StartTime: 200, EndTime: 400}, expected: entries[1:3]},
+		{filter: storage.AuditEntryFilter{NetworkID: "n1", StartTime: 300}, expected: entries[2:4]},
+		{filter: storage.AuditEntryFilter{NetworkID: "n1", Limit: 1}, expected: entries[:1]},
+	}
+	store, err = factory.StartTransaction(context.Background(), &storage.TxOptions{ReadOnly: true})
+	assert.NoError(t, err)
+	for i, tc := range tcs {
+		actual, err := store.LoadAuditEntries(tc.filter)
+		assert.NoError(t, err, "case %d", i)
+		assert.Equal(t, tc.expected, actual, "case %d", i)
+	}
+	assert.NoError(t, store.Commit())
+}
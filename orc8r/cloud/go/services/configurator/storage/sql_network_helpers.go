@@ -132,6 +132,9 @@ func validateNetworkUpdates(updates []NetworkUpdateCriteria) error {
 func (store *sqlConfiguratorStorage) updateNetwork(update NetworkUpdateCriteria, stmtCache *sq.StmtCache) error {
 	// Update the network table first
 	updateBuilder := store.builder.Update(networksTable).Where(sq.Eq{nwIDCol: update.ID})
+	if update.ExpectedVersion != nil {
+		updateBuilder = updateBuilder.Where(sq.Eq{nwVerCol: *update.ExpectedVersion})
+	}
 	if update.NewName != nil {
 		updateBuilder = updateBuilder.Set(nwNameCol, stringPtrToVal(update.NewName))
 	}
@@ -139,10 +142,16 @@ func (store *sqlConfiguratorStorage) updateNetwork(update NetworkUpdateCriteria,
 		updateBuilder = updateBuilder.Set(nwDescCol, stringPtrToVal(update.NewDescription))
 	}
 	updateBuilder = updateBuilder.Set(nwVerCol, sq.Expr(fmt.Sprintf("%s.%s+1", networksTable, nwVerCol)))
-	_, err := updateBuilder.RunWith(stmtCache).Exec()
+	res, err := updateBuilder.RunWith(stmtCache).Exec()
 	if err != nil {
 		return errors.Wrapf(err, "error updating network %s", update.ID)
 	}
+	if update.ExpectedVersion != nil {
+		err = checkVersionedWrite(res, fmt.Sprintf("network %s", update.ID))
+		if err != nil {
+			return err
+		}
+	}
 
 	// Sort config keys for deterministic behavior on upserts
 	configUpdateTypes := funk.Keys(update.ConfigsToAddOrUpdate).([]string)
@@ -183,6 +192,30 @@ func (store *sqlConfiguratorStorage) updateNetwork(update NetworkUpdateCriteria,
 	return nil
 }
 
+func (store *sqlConfiguratorStorage) deleteNetworkAtVersion(networkID string, expectedVersion uint64) error {
+	res, err := store.builder.Delete(networksTable).
+		Where(sq.Eq{nwIDCol: networkID, nwVerCol: expectedVersion}).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete network %s", networkID)
+	}
+	return checkVersionedWrite(res, fmt.Sprintf("network %s", networkID))
+}
+
+// checkVersionedWrite returns ErrVersionConflict if a write which was
+// conditioned on a version did not affect any rows.
+func checkVersionedWrite(res sql.Result, target string) error {
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get rows affected for %s", target)
+	}
+	if rowsAffected == 0 {
+		return errors.Wrapf(ErrVersionConflict, "%s does not exist at the expected version", target)
+	}
+	return nil
+}
+
 func stringPtrToVal(in *string) interface{} {
 	if *in == "" {
 		return nil
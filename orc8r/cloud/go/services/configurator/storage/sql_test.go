@@ -355,6 +355,11 @@ func TestSqlConfiguratorStorage_UpdateNetworks(t *testing.T) {
 			m.ExpectExec("DELETE FROM cfg_network_configs").WithArgs("n4", "hello", "n4", "world").WillReturnResult(mockResult)
 
 			m.ExpectExec("DELETE FROM cfg_networks").WithArgs("n1").WillReturnResult(mockResult)
+
+			expectChangeWrite(m, "n2", storage.ChangeUpdate, nil, nil)
+			expectChangeWrite(m, "n3", storage.ChangeUpdate, nil, nil)
+			expectChangeWrite(m, "n4", storage.ChangeUpdate, nil, nil)
+			expectChangeWrite(m, "n1", storage.ChangeDelete, nil, nil)
 		},
 		run: runFactory(
 			[]storage.NetworkUpdateCriteria{
@@ -761,6 +766,7 @@ func TestSqlConfiguratorStorage_CreateEntity(t *testing.T) {
 			m.ExpectExec("INSERT INTO cfg_entities").
 				WithArgs("1", "network", "foo", "bar", "2", "foobar", "foobar ent", nil, nil).
 				WillReturnResult(mockResult)
+			expectChangeWrite(m, "network", storage.ChangeCreate, "foo", "bar")
 		},
 		run: runFactory(
 			"network",
@@ -798,6 +804,7 @@ func TestSqlConfiguratorStorage_CreateEntity(t *testing.T) {
 				WillReturnResult(mockResult)
 
 			expectPermissionCreation(m, "1", 3, perms...)
+			expectChangeWrite(m, "network", storage.ChangeCreate, "foo", "bar")
 		},
 		run: runFactory(
 			"network",
@@ -842,6 +849,7 @@ func TestSqlConfiguratorStorage_CreateEntity(t *testing.T) {
 			expectEdgeQueries(m, assocs, edgesByTk)
 			expectEdgeInsertions(m, assocsToEdges("1", assocs, edgesByTk))
 			expectMergeGraphs(m, [][2]string{{"2", "aaa"}, {"zzz", "aaa"}})
+			expectChangeWrite(m, "network", storage.ChangeCreate, "foo", "bar")
 		},
 		run: runFactory(
 			"network",
@@ -912,8 +920,10 @@ func TestSqlConfiguratorStorage_UpdateEntity(t *testing.T) {
 	deleteCase := &testCase{
 		setup: func(m sqlmock.Sqlmock) {
 			expectBasicEntityQueries(m, expectedFooBarQuery)
+			expectTombstoneWrite(m, expectedFooBarQuery)
 			m.ExpectExec("DELETE FROM cfg_entities").WithArgs("network", "foo", "bar").WillReturnResult(mockResult)
 			expectBulkEntityQuery(m, []driver.Value{"g1"})
+			expectChangeWrite(m, "network", storage.ChangeDelete, "foo", "bar")
 		},
 		run: runFactory("network", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", DeleteEntity: true}),
 
@@ -925,6 +935,7 @@ func TestSqlConfiguratorStorage_UpdateEntity(t *testing.T) {
 	deleteWithPartition := &testCase{
 		setup: func(m sqlmock.Sqlmock) {
 			expectBasicEntityQueries(m, expectedFooBarQuery)
+			expectTombstoneWrite(m, expectedFooBarQuery)
 			m.ExpectExec("DELETE FROM cfg_entities").WithArgs("network", "foo", "bar").WillReturnResult(mockResult)
 			// make foobar the root of a tree so we partition the graph into
 			// 3 components:
@@ -946,6 +957,7 @@ func TestSqlConfiguratorStorage_UpdateEntity(t *testing.T) {
 			)
 			m.ExpectExec("UPDATE cfg_entities").WithArgs("1", "barfoo").WillReturnResult(mockResult)
 			m.ExpectExec("UPDATE cfg_entities").WithArgs("2", "bazbar").WillReturnResult(mockResult)
+			expectChangeWrite(m, "network", storage.ChangeDelete, "foo", "bar")
 		},
 		run:            runFactory("network", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", DeleteEntity: true}),
 		expectedResult: storage.NetworkEntity{Type: "foo", Key: "bar"},
@@ -1158,6 +1170,7 @@ func TestSqlConfiguratorStorage_UpdateEntity(t *testing.T) {
 			)
 			m.ExpectExec("UPDATE cfg_entities").WithArgs("1", "quzbaz").WillReturnResult(mockResult)
 			m.ExpectExec("UPDATE cfg_entities").WithArgs("2", "barfoo", "bazbar").WillReturnResult(mockResult)
+			expectChangeWrite(m, "network", storage.ChangeUpdate, "baz", "quz")
 		},
 		run:            runFactory("network", storage.EntityUpdateCriteria{Type: "baz", Key: "quz", AssociationsToDelete: []storage2.TypeAndKey{{"quz", "baz"}, {"baz", "bar"}}}),
 		expectedResult: storage.NetworkEntity{Type: "baz", Key: "quz", GraphID: "g1", Version: 1},
@@ -1359,6 +1372,93 @@ func TestSqlConfiguratorStorage_LoadGraphForEntity(t *testing.T) {
 	runCase(t, ring)
 }
 
+func TestSqlConfiguratorStorage_LoadChanges(t *testing.T) {
+	runFactory := func(networkID string, sinceSeq uint64, limit uint32) func(store storage.ConfiguratorStorage) (interface{}, error) {
+		return func(store storage.ConfiguratorStorage) (interface{}, error) {
+			return store.LoadChanges(networkID, sinceSeq, limit)
+		}
+	}
+
+	happyPath := &testCase{
+		setup: func(m sqlmock.Sqlmock) {
+			m.ExpectQuery("SELECT seq, operation, type, \"key\" FROM cfg_changes").
+				WithArgs("network", 2).
+				WillReturnRows(
+					sqlmock.NewRows([]string{"seq", "operation", "type", "key"}).
+						AddRow(3, storage.ChangeCreate, "foo", "bar").
+						AddRow(4, storage.ChangeUpdate, nil, nil),
+				)
+		},
+		run: runFactory("network", 2, 10),
+
+		expectedResult: []storage.Change{
+			{NetworkID: "network", Sequence: 3, Operation: storage.ChangeCreate, Entity: &storage2.TypeAndKey{Type: "foo", Key: "bar"}},
+			{NetworkID: "network", Sequence: 4, Operation: storage.ChangeUpdate},
+		},
+	}
+
+	queryError := &testCase{
+		setup: func(m sqlmock.Sqlmock) {
+			m.ExpectQuery("SELECT seq, operation, type, \"key\" FROM cfg_changes").
+				WithArgs("network", 2).
+				WillReturnError(errors.New("mock query error"))
+		},
+		run: runFactory("network", 2, 10),
+
+		expectedError: errors.New("failed to query for changes: mock query error"),
+	}
+
+	// Change 3 was purged
+	purged := &testCase{
+		setup: func(m sqlmock.Sqlmock) {
+			m.ExpectQuery("SELECT seq, operation, type, \"key\" FROM cfg_changes").
+				WithArgs("network", 2).
+				WillReturnRows(
+					sqlmock.NewRows([]string{"seq", "operation", "type", "key"}).
+						AddRow(4, storage.ChangeUpdate, nil, nil),
+				)
+		},
+		run: runFactory("network", 2, 10),
+
+		expectedError: errors.New("changes after 2 of network network: changes purged"),
+	}
+
+	upToDate := &testCase{
+		setup: func(m sqlmock.Sqlmock) {
+			m.ExpectQuery("SELECT seq, operation, type, \"key\" FROM cfg_changes").
+				WithArgs("network", 4).
+				WillReturnRows(sqlmock.NewRows([]string{"seq", "operation", "type", "key"}))
+			m.ExpectQuery("SELECT seq FROM cfg_change_seqs").
+				WithArgs("network").
+				WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(4))
+		},
+		run: runFactory("network", 4, 10),
+
+		expectedResult: []storage.Change{},
+	}
+
+	// All changes after 2 were purged
+	allPurged := &testCase{
+		setup: func(m sqlmock.Sqlmock) {
+			m.ExpectQuery("SELECT seq, operation, type, \"key\" FROM cfg_changes").
+				WithArgs("network", 2).
+				WillReturnRows(sqlmock.NewRows([]string{"seq", "operation", "type", "key"}))
+			m.ExpectQuery("SELECT seq FROM cfg_change_seqs").
+				WithArgs("network").
+				WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(4))
+		},
+		run: runFactory("network", 2, 10),
+
+		expectedError: errors.New("changes after 2 of network network: changes purged"),
+	}
+
+	runCase(t, happyPath)
+	runCase(t, queryError)
+	runCase(t, purged)
+	runCase(t, upToDate)
+	runCase(t, allPurged)
+}
+
 type testCase struct {
 	// setup mock expectations. Transaction start is expected on the mock
 	// generically
@@ -1516,6 +1616,8 @@ func getTestCaseForEntityUpdate(
 				expectBulkEntityQuery(m, []driver.Value{entToUpdate.graphID}, entToUpdate)
 				expectAssocQuery(m, []driver.Value{entToUpdate.pk, entToUpdate.pk})
 			}
+
+			expectChangeWrite(m, "network", storage.ChangeUpdate, update.Type, update.Key)
 		},
 		run: func(store storage.ConfiguratorStorage) (interface{}, error) {
 			return store.UpdateEntity("network", update)
@@ -1566,6 +1668,21 @@ func expectAssocQuery(m sqlmock.Sqlmock, queryArgs []driver.Value, assocPks ...s
 	m.ExpectQuery("SELECT assoc.from_pk, assoc.to_pk FROM cfg_assocs").WithArgs(queryArgs...).WillReturnRows(rows)
 }
 
+// expectTombstoneWrite expects the full load of an entity without
+// permissions or assocs, followed by the write of its tombstone
+func expectTombstoneWrite(m sqlmock.Sqlmock, expect expectedEntQueryResult) {
+	m.ExpectQuery("SELECT .* FROM cfg_entities").
+		WithArgs("network", expect.key, expect.entType).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"pk", "key", "type", "physical_id", "version", "graph_id", "name", "description", "config", "id", "scope", "permission", "type", "id_filter", "acl.version"}).
+				AddRow(expect.pk, expect.key, expect.entType, nil, expect.version, expect.graphID, nil, nil, nil, nil, nil, nil, nil, nil, nil),
+		)
+	expectAssocQuery(m, []driver.Value{expect.pk, expect.pk})
+	m.ExpectExec("INSERT INTO cfg_tombstones").
+		WithArgs("network", expect.entType, expect.key, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(mockResult)
+}
+
 // [(old graph ID, new graph ID)]
 func expectMergeGraphs(m sqlmock.Sqlmock, graphIDChanges [][2]string) {
 	mergeStmt := m.ExpectPrepare("UPDATE cfg_entities").WillBeClosed()
@@ -1599,6 +1716,15 @@ func expectEdgeDeletions(m sqlmock.Sqlmock, edges [][2]string) {
 	m.ExpectExec("DELETE FROM cfg_assocs").WithArgs(args...).WillReturnResult(mockResult)
 }
 
+// expectChangeWrite expects a change log write for a network which has no
+// prior changes
+func expectChangeWrite(m sqlmock.Sqlmock, networkID string, op storage.ChangeOperation, entType, entKey driver.Value) {
+	m.ExpectExec("INSERT INTO cfg_change_seqs").WithArgs(networkID, int64(0)).WillReturnResult(mockResult)
+	m.ExpectExec("UPDATE cfg_change_seqs").WithArgs(networkID).WillReturnResult(mockResult)
+	m.ExpectQuery("SELECT seq FROM cfg_change_seqs").WithArgs(networkID).WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(1))
+	m.ExpectExec("INSERT INTO cfg_changes").WithArgs(networkID, int64(1), int64(op), entType, entKey, sqlmock.AnyArg()).WillReturnResult(mockResult)
+}
+
 func expectPermissionCreation(m sqlmock.Sqlmock, entPk string, startId int, perms ...storage.ACL) {
 	args := make([]driver.Value, 0, len(perms)*6)
 	for _, perm := range perms {
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package storage
+
+import (
+	"encoding/json"
+	"time"
+
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/storage"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"github.com/thoas/go-funk"
+)
+
+// writeTombstone snapshots an entity which is about to be deleted. The
+// snapshot includes everything needed to restore the entity: its fields,
+// its associations in both directions, and its permissions. If the entity
+// was deleted before, the previous tombstone is replaced.
+func (store *sqlConfiguratorStorage) writeTombstone(networkID string, entityID storage.TypeAndKey) error {
+	loadResult, err := store.LoadEntities(networkID, EntityLoadFilter{IDs: []storage.TypeAndKey{entityID}}, FullEntityLoadCriteria)
+	if err != nil {
+		return errors.Wrap(err, "failed to load entity to tombstone")
+	}
+	if len(loadResult.Entities) != 1 {
+		return errors.Errorf("expected to load 1 entity to tombstone, got %d", len(loadResult.Entities))
+	}
+	entBytes, err := json.Marshal(loadResult.Entities[0])
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize entity to tombstone")
+	}
+
+	deletedAt := time.Now().Unix()
+	_, err = store.builder.Insert(tombstoneTable).
+		Columns(tsNidCol, tsTypeCol, tsKeyCol, tsDeletedCol, tsEntCol).
+		Values(networkID, entityID.Type, entityID.Key, deletedAt, entBytes).
+		OnConflict(
+			[]sqorc.UpsertValue{
+				{Column: tsDeletedCol, Value: deletedAt},
+				{Column: tsEntCol, Value: entBytes},
+			},
+			tsNidCol, tsTypeCol, tsKeyCol,
+		).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return errors.Wrapf(err, "failed to write tombstone for entity %s", entityID)
+	}
+	return nil
+}
+
+func (store *sqlConfiguratorStorage) deleteTombstone(networkID string, entityID storage.TypeAndKey) error {
+	_, err := store.builder.Delete(tombstoneTable).
+		Where(sq.Eq{tsNidCol: networkID, tsTypeCol: entityID.Type, tsKeyCol: entityID.Key}).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete tombstone for entity %s", entityID)
+	}
+	return nil
+}
+
+// getExistingTKs returns the set of the given TKs which correspond to
+// entities in the network.
+func (store *sqlConfiguratorStorage) getExistingTKs(networkID string, tks []storage.TypeAndKey) (map[storage.TypeAndKey]bool, error) {
+	ret := map[storage.TypeAndKey]bool{}
+	if funk.IsEmpty(tks) {
+		return ret, nil
+	}
+	entsByPk, err := store.loadFromEntitiesTable(networkID, EntityLoadFilter{IDs: tks}, EntityLoadCriteria{})
+	if err != nil {
+		return ret, errors.Wrap(err, "failed to load associated entities")
+	}
+	for _, ent := range entsByPk {
+		ret[ent.GetTypeAndKey()] = true
+	}
+	return ret, nil
+}
+
+// getTombstoneFilterWhereClause applies the ID, type, and key filters of an
+// EntityLoadFilter to tombstones. Tombstones don't support filtering by
+// physical ID or pagination.
+func getTombstoneFilterWhereClause(networkID string, filter EntityLoadFilter) sq.Sqlizer {
+	if !funk.IsEmpty(filter.IDs) {
+		idsOr := make(sq.Or, 0, len(filter.IDs))
+		for _, id := range filter.IDs {
+			idsOr = append(idsOr, sq.Eq{tsTypeCol: id.Type, tsKeyCol: id.Key})
+		}
+		return sq.And{sq.Eq{tsNidCol: networkID}, idsOr}
+	}
+
+	whereClause := sq.And{sq.Eq{tsNidCol: networkID}}
+	if filter.TypeFilter != nil {
+		whereClause = append(whereClause, sq.Eq{tsTypeCol: *filter.TypeFilter})
+	}
+	if filter.KeyFilter != nil {
+		whereClause = append(whereClause, sq.Eq{tsKeyCol: *filter.KeyFilter})
+	}
+	if filter.KeyPrefix != nil {
+		whereClause = append(whereClause, sqorc.HasPrefix(tsKeyCol, *filter.KeyPrefix))
+	}
+	return whereClause
+}
+
+func filterTKs(tks []storage.TypeAndKey, keep map[storage.TypeAndKey]bool) []storage.TypeAndKey {
+	var ret []storage.TypeAndKey
+	for _, tk := range tks {
+		if keep[tk] {
+			ret = append(ret, tk)
+		}
+	}
+	return ret
+}
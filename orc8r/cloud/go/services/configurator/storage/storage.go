@@ -12,11 +12,39 @@ import (
 	"context"
 	"fmt"
 
+	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/storage"
 
+	"github.com/pkg/errors"
 	"github.com/thoas/go-funk"
 )
 
+// ErrVersionConflict is returned by update operations when the stored version
+// of a network or entity does not match the expected version specified in the
+// update criteria.
+var ErrVersionConflict = errors.New("version conflict")
+
+// IsVersionConflict returns true if the error (or its cause) is
+// ErrVersionConflict.
+func IsVersionConflict(err error) bool {
+	return errors.Cause(err) == ErrVersionConflict
+}
+
+// ErrChangesPurged is returned when loading changes which have already been
+// purged from a network's change log.
+var ErrChangesPurged = errors.New("changes purged")
+
+// IsChangesPurged returns true if the error (or its cause) is
+// ErrChangesPurged.
+func IsChangesPurged(err error) bool {
+	return errors.Cause(err) == ErrChangesPurged
+}
+
+// IsNotFound returns true if the error (or its cause) is ErrNotFound.
+func IsNotFound(err error) bool {
+	return errors.Cause(err) == merrors.ErrNotFound
+}
+
 // ConfiguratorStorageFactory creates ConfiguratorStorage implementations bound
 // to transactions.
 type ConfiguratorStorageFactory interface {
@@ -91,6 +119,66 @@ type ConfiguratorStorage interface {
 	// entity. The load criteria fields on associations are ignored, and the
 	// returned entities will always have both association fields filled out.
 	LoadGraphForEntity(networkID string, entityID storage.TypeAndKey, loadCriteria EntityLoadCriteria) (EntityGraph, error)
+
+	// TraverseGraph follows associations from the traversal's start entity
+	// and returns the entities which were reached and the edges which were
+	// followed to reach them. The start entity is not included in the
+	// returned entities. The load criteria fields on associations are
+	// ignored.
+	TraverseGraph(networkID string, traversal GraphTraversal, loadCriteria EntityLoadCriteria) (GraphTraversalResult, error)
+
+	// =======================================================================
+	// Change Log Operations
+	// =======================================================================
+
+	// LoadChanges returns up to limit entries of a network's change log with
+	// a sequence number greater than sinceSeq, in sequence order.
+	// An error wrapping ErrChangesPurged is returned if the change right
+	// after sinceSeq has been purged.
+	LoadChanges(networkID string, sinceSeq uint64, limit uint32) ([]Change, error)
+
+	// GetLatestChangeSeq returns the sequence number of the latest entry in a
+	// network's change log, or 0 if the network has no changes.
+	GetLatestChangeSeq(networkID string) (uint64, error)
+
+	// PurgeChanges permanently removes all change log entries, across all
+	// networks, written before the given unix timestamp (in seconds). The
+	// number of purged changes is returned. Purging doesn't reset the
+	// sequence numbers of a network.
+	PurgeChanges(createdBefore int64) (int64, error)
+
+	// =======================================================================
+	// Tombstone Operations
+	// =======================================================================
+
+	// LoadTombstones returns the tombstones of deleted entities in a network
+	// which match the filter. Tombstoned entities are never returned from
+	// LoadEntities.
+	LoadTombstones(networkID string, filter EntityLoadFilter) ([]Tombstone, error)
+
+	// RestoreEntity recreates a deleted entity from its tombstone, along with
+	// its permissions and any associations to and from entities which still
+	// exist. The tombstone is removed and the restored entity is returned.
+	// An error wrapping ErrNotFound is returned if there is no tombstone for
+	// the entity.
+	RestoreEntity(networkID string, entityID storage.TypeAndKey) (NetworkEntity, error)
+
+	// PurgeTombstones permanently removes all tombstones, across all
+	// networks, for entities deleted before the given unix timestamp
+	// (in seconds). The number of purged tombstones is returned.
+	PurgeTombstones(deletedBefore int64) (int64, error)
+
+	// =======================================================================
+	// Audit Log Operations
+	// =======================================================================
+
+	// WriteAuditEntry appends an entry to the audit log. The ID of the entry
+	// is system-generated and will be ignored if set.
+	WriteAuditEntry(entry AuditEntry) error
+
+	// LoadAuditEntries returns the audit log entries which match the filter,
+	// ordered by timestamp.
+	LoadAuditEntries(filter AuditEntryFilter) ([]AuditEntry, error)
 }
 
 // A network represents a tenant. Networks can be configured in a hierarchical
@@ -154,6 +242,11 @@ type NetworkUpdateCriteria struct {
 
 	// Config values to delete
 	ConfigsToDelete []string
+
+	// If ExpectedVersion is set, the update (or deletion) will only be
+	// applied if the network's current version matches. Otherwise, the update
+	// will fail with ErrVersionConflict.
+	ExpectedVersion *uint64
 }
 
 // NetworkEntity is the storage representation of a logical component of a
@@ -286,19 +379,42 @@ type EntityLoadFilter struct {
 	// given ID.
 	KeyFilter *string
 
+	// If KeyPrefix is provided, the query will return all entities whose key
+	// starts with the given prefix.
+	KeyPrefix *string
+
+	// If PhysicalID is provided, the query will return all entities matching
+	// the given physical ID.
+	PhysicalID *string
+
 	// If IDs is provided, the query will return all entities matching the
-	// provided TypeAndKeys. TypeFilter and KeyFilter are ignored if IDs is
-	// provided.
+	// provided TypeAndKeys. TypeFilter, KeyFilter, KeyPrefix, PhysicalID, and
+	// pagination are ignored if IDs is provided.
 	IDs []storage.TypeAndKey
 
+	// If PageSize is non-zero, at most PageSize entities ordered by
+	// (type, key) will be returned, along with a token to load the next page.
+	PageSize uint32
+
+	// PageToken is the opaque NextPageToken returned from a previous load.
+	// The filter should otherwise be identical to the previous load.
+	PageToken string
+
 	// Unexported for internal use
 	graphID *string
+	pks     []string
 }
 
 // IsLoadAllEntities return true if the EntityLoadFilter is specifying to load
 // all entities in a network, false if there are any filter conditions.
 func (elf EntityLoadFilter) IsLoadAllEntities() bool {
-	return elf.TypeFilter == nil && elf.KeyFilter == nil && elf.graphID == nil && funk.IsEmpty(elf.IDs)
+	return elf.TypeFilter == nil && elf.KeyFilter == nil && elf.KeyPrefix == nil && elf.PhysicalID == nil &&
+		elf.graphID == nil && elf.pks == nil && elf.PageSize == 0 && funk.IsEmpty(elf.IDs)
+}
+
+// isPaginated returns true if the filter requests a single page of results.
+func (elf EntityLoadFilter) isPaginated() bool {
+	return elf.PageSize > 0 && funk.IsEmpty(elf.IDs)
 }
 
 // EntityLoadCriteria specifies how much of an entity to load
@@ -329,6 +445,10 @@ type EntityLoadResult struct {
 	Entities []NetworkEntity
 	// Entities which were not found
 	EntitiesNotFound []storage.TypeAndKey
+
+	// Token to pass as PageToken to load the next page of a paginated load.
+	// Empty if there are no more entities to load.
+	NextPageToken string
 }
 
 // EntityUpdateCriteria specifies a patch operation on a network entity.
@@ -360,6 +480,11 @@ type EntityUpdateCriteria struct {
 
 	// ACL IDs to delete
 	PermissionsToDelete []string
+
+	// If ExpectedVersion is set, the update (or deletion) will only be
+	// applied if the entity's current version matches. Otherwise, the update
+	// will fail with ErrVersionConflict.
+	ExpectedVersion *uint64
 }
 
 func (euc EntityUpdateCriteria) GetTypeAndKey() storage.TypeAndKey {
@@ -386,3 +511,131 @@ type GraphEdge struct {
 func (edge GraphEdge) String() string {
 	return fmt.Sprintf("%s, %s", edge.From, edge.To)
 }
+
+// TraversalDirection specifies which associations a graph traversal follows
+type TraversalDirection int
+
+const (
+	// TraverseAssocsFromThis follows edges starting at each visited entity
+	TraverseAssocsFromThis TraversalDirection = iota
+	// TraverseAssocsToThis follows edges ending at each visited entity
+	TraverseAssocsToThis
+)
+
+// MaxTraversalDepth is the largest MaxDepth a GraphTraversal may request
+const MaxTraversalDepth = 32
+
+// GraphTraversal specifies a bounded traversal of an entity graph
+type GraphTraversal struct {
+	Start     storage.TypeAndKey
+	Direction TraversalDirection
+
+	// HopTypes optionally restricts the type of the entities reached on each
+	// hop. HopTypes[i] applies to the entities reached on hop i+1. An empty
+	// string, or a hop past the end of the list, matches any type.
+	// Entities which don't match are not traversed any further.
+	HopTypes []string
+
+	// MaxDepth is the maximum number of hops to follow. Must be positive and
+	// no larger than MaxTraversalDepth.
+	MaxDepth uint32
+}
+
+// getHopType returns the type filter for the given 1-indexed hop
+func (traversal GraphTraversal) getHopType(hop uint32) string {
+	if hop == 0 || int(hop) > len(traversal.HopTypes) {
+		return ""
+	}
+	return traversal.HopTypes[hop-1]
+}
+
+// GraphTraversalResult encapsulates the result of a TraverseGraph call
+type GraphTraversalResult struct {
+	// Entities reached by the traversal, ordered by (type, key)
+	Entities []NetworkEntity
+
+	// Edges followed by the traversal, ordered by (from, to)
+	Edges []GraphEdge
+}
+
+// ChangeOperation is the kind of write recorded by a Change
+type ChangeOperation int
+
+const (
+	ChangeCreate ChangeOperation = iota
+	ChangeUpdate
+	ChangeDelete
+)
+
+// Change is an entry in a network's change log. Creating, updating, or
+// deleting an entity and updating or deleting a network appends a change
+// with the next sequence number for the network in the same transaction as
+// the write itself.
+type Change struct {
+	NetworkID string
+	// Sequence is a monotonically increasing number per network, starting
+	// at 1.
+	Sequence  uint64
+	Operation ChangeOperation
+	// Entity is the entity which was written. Nil if the change was to the
+	// network itself.
+	Entity *storage.TypeAndKey
+}
+
+// Tombstone is the snapshot of a deleted entity, kept until it is purged so
+// that the entity can be restored.
+type Tombstone struct {
+	// Entity is the full entity as it was when it was deleted, including its
+	// associations in both directions and its permissions.
+	Entity NetworkEntity
+
+	// DeletedAt is the unix timestamp (in seconds) of the deletion
+	DeletedAt int64
+}
+
+// AuditEntry is a record of a single write to a network or entity, with the
+// identity of the caller which made it and the fields which it changed.
+type AuditEntry struct {
+	ID        string
+	NetworkID string
+	// Entity is the entity which was written. Nil if the write was to the
+	// network itself.
+	Entity    *storage.TypeAndKey
+	Operation ChangeOperation
+	// Actor is the hash string of the identity of the caller which made the
+	// write. Empty if the write was made by another cloud service.
+	Actor string
+	// Timestamp is the unix timestamp (in seconds) of the write
+	Timestamp int64
+	Diffs     []AuditFieldDiff
+}
+
+// AuditFieldDiff is the value of a field before and after a write. Before is
+// empty if the field was set by the write, and After is empty if the field
+// was cleared by the write.
+type AuditFieldDiff struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// AuditEntryFilter specifies which audit log entries to load
+type AuditEntryFilter struct {
+	NetworkID string
+
+	// If Entity is provided, only entries for writes to the entity are
+	// returned.
+	Entity *storage.TypeAndKey
+
+	// If Actor is provided, only entries for writes made by the actor are
+	// returned.
+	Actor *string
+
+	// Only entries with StartTime <= Timestamp < EndTime are returned. Zero
+	// values leave the corresponding end of the range unbounded.
+	StartTime int64
+	EndTime   int64
+
+	// If Limit is non-zero, at most Limit entries are returned
+	Limit uint32
+}
@@ -0,0 +1,94 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package configurator
+
+import (
+	"fmt"
+	"strings"
+
+	"magma/orc8r/cloud/go/serde"
+
+	openapiErrors "github.com/go-openapi/errors"
+)
+
+// ValidatableModel is implemented by config models which can validate their
+// own contents. If the model deserialized from a config implements this
+// interface, ValidateModel is called on every write of the config.
+type ValidatableModel interface {
+	ValidateModel() error
+}
+
+// FieldViolation describes a single invalid field of a config
+type FieldViolation struct {
+	// Field is the dot-separated path to the invalid field. Empty if the
+	// config as a whole is invalid (e.g. it can't be deserialized).
+	Field       string
+	Description string
+}
+
+// ConfigValidationError is returned when a network or entity config write is
+// rejected because the config is invalid.
+type ConfigValidationError struct {
+	Violations []FieldViolation
+}
+
+func (err *ConfigValidationError) Error() string {
+	violations := make([]string, 0, len(err.Violations))
+	for _, violation := range err.Violations {
+		if violation.Field == "" {
+			violations = append(violations, violation.Description)
+		} else {
+			violations = append(violations, fmt.Sprintf("%s: %s", violation.Field, violation.Description))
+		}
+	}
+	return fmt.Sprintf("invalid config: %s", strings.Join(violations, "; "))
+}
+
+// ValidateConfig deserializes a serialized config with the serde registered
+// for its type, then validates the deserialized model if it implements
+// ValidatableModel. Empty configs are always valid.
+// A *ConfigValidationError with field paths relative to the config is
+// returned if the config is invalid.
+func ValidateConfig(configType string, config []byte) error {
+	model, err := serde.Deserialize(SerdeDomain, configType, config)
+	if err != nil {
+		return &ConfigValidationError{
+			Violations: []FieldViolation{{Description: fmt.Sprintf("failed to deserialize config of type %s: %s", configType, err)}},
+		}
+	}
+	validatable, ok := model.(ValidatableModel)
+	if !ok {
+		return nil
+	}
+	err = validatable.ValidateModel()
+	if err != nil {
+		return &ConfigValidationError{Violations: getFieldViolations(err)}
+	}
+	return nil
+}
+
+// getFieldViolations flattens the error returned by ValidateModel. Swagger
+// models report a composite of per-field validation errors; any other error
+// applies to the config as a whole.
+func getFieldViolations(err error) []FieldViolation {
+	switch e := err.(type) {
+	case *ConfigValidationError:
+		return e.Violations
+	case *openapiErrors.CompositeError:
+		ret := []FieldViolation{}
+		for _, childErr := range e.Errors {
+			ret = append(ret, getFieldViolations(childErr)...)
+		}
+		return ret
+	case *openapiErrors.Validation:
+		return []FieldViolation{{Field: e.Name, Description: e.Error()}}
+	default:
+		return []FieldViolation{{Description: err.Error()}}
+	}
+}
@@ -34,7 +34,10 @@ func main() {
 	}
 
 	store := blobstore.NewSQLBlobStorageFactory(device.DBTableName, db, sqorc.GetSqlBuilder())
-	err = store.InitializeFactory()
+	err = sqorc.ApplyServiceMigrations(db, sqorc.GetSqlBuilder(), device.ServiceName)
+	if err == sqorc.ErrMigrationsUnsupported {
+		err = store.InitializeFactory()
+	}
 	if err != nil {
 		glog.Fatalf("Failed to initialize device database: %s", err)
 	}
@@ -34,6 +34,10 @@ func main() {
 	if err != nil {
 		glog.Errorf("Failed to initialize datastore: %s", err)
 	}
+	err = db.ApplyMigrations()
+	if err != nil {
+		glog.Fatalf("Failed to migrate datastore: %s", err)
+	}
 	store := storage.GetDirectorydPersistenceService(db)
 
 	// Create directory gRPC servicer
@@ -22,7 +22,7 @@ const (
 )
 
 type GatewayResponseChannel struct {
-	RespChan chan *protos.GatewayResponse
+	RespChan chan *protos.SyncRPCResponse
 	ReqId    uint32
 }
 
@@ -34,6 +34,17 @@ type GatewayRPCBroker interface {
 	// to a certain gateway, and waits on the response channel for response.
 	// The caller should time out on the response channel.
 	SendRequestToGateway(gwReq *protos.GatewayRequest) (*GatewayResponseChannel, error)
+	// StartGatewayStream sends the first message of a streamed request to a
+	// gateway. If endOfStream is false, the rest of the request is sent with
+	// SendRequestMessage. responseWindow is the number of response messages
+	// the gateway can send before it needs a window update.
+	StartGatewayStream(gwReq *protos.GatewayRequest, endOfStream bool, responseWindow uint32) (*GatewayResponseChannel, error)
+	// SendRequestMessage sends the message with sequence number seq of a
+	// streamed request to the gateway.
+	SendRequestMessage(gwId string, reqId uint32, seq uint32, payload []byte, endOfStream bool) error
+	// UpdateResponseWindow allows the gateway to send increment more response
+	// messages for the request with ID reqId.
+	UpdateResponseWindow(gwId string, reqId uint32, increment uint32) error
 	// ProcessGatewayResponse is called by the SyncRPC servicer. It receives
 	// a SyncRPCResponse from the SyncRPC servicer, and send the corresponding GatewayResponse to the HTTP server
 	ProcessGatewayResponse(response *protos.SyncRPCResponse) error
@@ -49,32 +60,77 @@ type GatewayRPCBroker interface {
 }
 
 // GatewayRPCBrokerImpl implements a GatewayRPCBroker, managing a response table and request queue.
+// The request queue and response table are the broker's storage backend. The
+// in-memory backend only works for gateways with a SyncRPC stream to the same
+// dispatcher instance, while a shared backend (see sqlstore) lets any
+// dispatcher instance forward requests to any gateway.
 type GatewayRPCBrokerImpl struct {
 	responseTable memstore.ResponseTable
 	requests      memstore.RequestQueue
 }
 
+// NewGatewayReqRespBroker returns a broker with an in-memory backend.
 func NewGatewayReqRespBroker() *GatewayRPCBrokerImpl {
 	respTable := memstore.NewResponseTable(processResponseTimeout)
 	requests := memstore.NewRequestQueue(queueLen)
-	return &GatewayRPCBrokerImpl{responseTable: respTable, requests: requests}
+	return NewGatewayRPCBroker(requests, respTable)
+}
+
+// NewGatewayRPCBroker returns a broker with the given backend.
+func NewGatewayRPCBroker(requests memstore.RequestQueue, responseTable memstore.ResponseTable) *GatewayRPCBrokerImpl {
+	return &GatewayRPCBrokerImpl{responseTable: responseTable, requests: requests}
 }
 
 func (broker *GatewayRPCBrokerImpl) SendRequestToGateway(
 	gwReq *protos.GatewayRequest,
 ) (*GatewayResponseChannel, error) {
+	return broker.sendFirstMessage(&protos.SyncRPCRequest{ReqBody: gwReq})
+}
+
+func (broker *GatewayRPCBrokerImpl) StartGatewayStream(
+	gwReq *protos.GatewayRequest,
+	endOfStream bool,
+	responseWindow uint32,
+) (*GatewayResponseChannel, error) {
+	syncRPCReq := &protos.SyncRPCRequest{ReqBody: gwReq, Seq: 1, EndOfStream: endOfStream, WindowUpdate: responseWindow}
+	return broker.sendFirstMessage(syncRPCReq)
+}
+
+func (broker *GatewayRPCBrokerImpl) sendFirstMessage(syncRPCReq *protos.SyncRPCRequest) (*GatewayResponseChannel, error) {
+	gwReq := syncRPCReq.ReqBody
 	if gwReq == nil || len(gwReq.GwId) == 0 {
 		return nil, errors.New("gwReq cannot be nil and gwId cannot be empty string")
 	}
 	respChan, reqId := broker.responseTable.InitializeResponse()
 	// Add request to queue.
-	syncRPCReq := &protos.SyncRPCRequest{ReqId: reqId, ReqBody: gwReq}
+	syncRPCReq.ReqId = reqId
 	if err := broker.requests.Enqueue(syncRPCReq); err != nil {
 		return nil, err
 	}
 	return &GatewayResponseChannel{RespChan: respChan, ReqId: reqId}, nil
 }
 
+func (broker *GatewayRPCBrokerImpl) SendRequestMessage(
+	gwId string,
+	reqId uint32,
+	seq uint32,
+	payload []byte,
+	endOfStream bool,
+) error {
+	syncRPCRequest := &protos.SyncRPCRequest{
+		ReqId:       reqId,
+		ReqBody:     &protos.GatewayRequest{GwId: gwId, Payload: payload},
+		Seq:         seq,
+		EndOfStream: endOfStream,
+	}
+	return broker.requests.Enqueue(syncRPCRequest)
+}
+
+func (broker *GatewayRPCBrokerImpl) UpdateResponseWindow(gwId string, reqId uint32, increment uint32) error {
+	syncRPCRequest := &protos.SyncRPCRequest{ReqId: reqId, ReqBody: &protos.GatewayRequest{GwId: gwId}, WindowUpdate: increment}
+	return broker.requests.Enqueue(syncRPCRequest)
+}
+
 func (broker *GatewayRPCBrokerImpl) ProcessGatewayResponse(response *protos.SyncRPCResponse) error {
 	return broker.responseTable.SendResponse(response)
 }
@@ -87,9 +143,11 @@ func (broker *GatewayRPCBrokerImpl) InitializeGateway(gwId string) chan *protos.
 }
 
 func (broker *GatewayRPCBrokerImpl) CleanupGateway(gwId string) error {
+	// The in-memory queue returns the old queue to cleanup. Receiving from it
+	// would race with the receiver that listens on the queue to send down to
+	// the stream, so its requests just time out. Shared backends hand the
+	// requests off to the dispatcher instance the gateway reconnects to.
 	broker.requests.CleanupQueue(gwId)
-	// todo: the above returns the old queue to cleanup. if receive from the queue, it would race with the receiver
-	// that listens on the queue to send down to the stream, for now forget
 	return nil
 }
 
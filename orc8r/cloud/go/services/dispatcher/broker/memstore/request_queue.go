@@ -23,6 +23,9 @@ type InitializedQueue struct {
 	OldQueue chan *protos.SyncRPCRequest
 }
 
+// RequestQueue holds the SyncRPC requests to be sent to each gateway. Together
+// with ResponseTable it is the backend interface of the SyncRPC broker; see
+// the sqlstore package for a backend shared between dispatcher instances.
 type RequestQueue interface {
 	InitializeQueue(gwId string) InitializedQueue
 	CleanupQueue(gwId string) chan *protos.SyncRPCRequest
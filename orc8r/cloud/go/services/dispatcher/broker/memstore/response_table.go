@@ -20,13 +20,18 @@ import (
 	"github.com/golang/glog"
 )
 
+// ResponseTable routes gateway responses back to the senders of the requests.
+// Together with RequestQueue it is the backend interface of the SyncRPC broker.
 type ResponseTable interface {
-	InitializeResponse() (chan *protos.GatewayResponse, uint32)
+	// InitializeResponse returns the channel the responses to a request are
+	// sent to, along with the ID of the request.
+	InitializeResponse() (chan *protos.SyncRPCResponse, uint32)
+	// SendResponse sends a response to the channel of its request.
 	SendResponse(*protos.SyncRPCResponse) error
 }
 
 type ResponseTableImpl struct {
-	// respChanByReqId is a map: <uint32, chan *protos.GatewayResponse>
+	// respChanByReqId is a map: <uint32, chan *protos.SyncRPCResponse>
 	respChanByReqId *sync.Map
 	// reqIdCounter strictly increases, making sure all reqIds will be unique.
 	reqIdCounter uint32
@@ -37,12 +42,12 @@ func NewResponseTable(timeout time.Duration) *ResponseTableImpl {
 	return &ResponseTableImpl{respChanByReqId: &sync.Map{}, timeout: timeout}
 }
 
-// InitializeResponse creates a request ID to bind to the GatewayResponse
+// InitializeResponse creates a request ID to bind to the SyncRPCResponse
 // channel, so when SyncRPCResponse comes back, it can be written to the
-// corresponding GatewayResponse channel identified by the request id.
-func (table *ResponseTableImpl) InitializeResponse() (chan *protos.GatewayResponse, uint32) {
+// corresponding SyncRPCResponse channel identified by the request id.
+func (table *ResponseTableImpl) InitializeResponse() (chan *protos.SyncRPCResponse, uint32) {
 	reqId := generateReqId(&table.reqIdCounter)
-	respChan := make(chan *protos.GatewayResponse)
+	respChan := make(chan *protos.SyncRPCResponse)
 	table.respChanByReqId.Store(reqId, respChan)
 	return respChan, reqId
 }
@@ -57,12 +62,12 @@ func (table *ResponseTableImpl) SendResponse(resp *protos.SyncRPCResponse) error
 	if !ok {
 		return fmt.Errorf("No response channel found for reqId %v\n", resp.ReqId)
 	}
-	respChan := respChanVal.(chan *protos.GatewayResponse)
-	if resp.RespBody == nil {
+	respChan := respChanVal.(chan *protos.SyncRPCResponse)
+	if resp.RespBody == nil && resp.WindowUpdate == 0 {
 		glog.Errorf("Nil response body received, forward to httpServer anyways\n")
 	}
 	select {
-	case respChan <- resp.RespBody:
+	case respChan <- resp:
 		return nil
 	case <-time.After(table.timeout):
 		// give up sending, close the channel and delete the table entry
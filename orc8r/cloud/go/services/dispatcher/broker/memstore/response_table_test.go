@@ -66,8 +66,8 @@ func mockClient(
 	t *testing.T,
 	table memstore.ResponseTable,
 	expectedGwResp *protos.GatewayResponse) {
-	respChanChan := make(chan chan *protos.GatewayResponse)
-	go func(chan chan *protos.GatewayResponse) {
+	respChanChan := make(chan chan *protos.SyncRPCResponse)
+	go func(chan chan *protos.SyncRPCResponse) {
 		// initialize response
 		respChan, reqId := table.InitializeResponse()
 		respChanChan <- respChan
@@ -77,8 +77,8 @@ func mockClient(
 	}(respChanChan)
 	respChan := <-respChanChan
 	// wait for response
-	gwResp := <-respChan
-	assertGatewayRespEqual(t, gwResp, expectedGwResp)
+	resp := <-respChan
+	assertGatewayRespEqual(t, resp.RespBody, expectedGwResp)
 }
 
 func assertGatewayRespEqual(t *testing.T, resp1 *protos.GatewayResponse, resp2 *protos.GatewayResponse) {
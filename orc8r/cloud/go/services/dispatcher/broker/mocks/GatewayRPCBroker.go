@@ -78,6 +78,20 @@ func (_m *GatewayRPCBroker) ProcessGatewayResponse(response *protos.SyncRPCRespo
 	return r0
 }
 
+// SendRequestMessage provides a mock function with given fields: gwId, reqId, seq, payload, endOfStream
+func (_m *GatewayRPCBroker) SendRequestMessage(gwId string, reqId uint32, seq uint32, payload []byte, endOfStream bool) error {
+	ret := _m.Called(gwId, reqId, seq, payload, endOfStream)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, uint32, uint32, []byte, bool) error); ok {
+		r0 = rf(gwId, reqId, seq, payload, endOfStream)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendRequestToGateway provides a mock function with given fields: gwReq
 func (_m *GatewayRPCBroker) SendRequestToGateway(gwReq *protos.GatewayRequest) (*broker.GatewayResponseChannel, error) {
 	ret := _m.Called(gwReq)
@@ -100,3 +114,40 @@ func (_m *GatewayRPCBroker) SendRequestToGateway(gwReq *protos.GatewayRequest) (
 
 	return r0, r1
 }
+
+// StartGatewayStream provides a mock function with given fields: gwReq, endOfStream, responseWindow
+func (_m *GatewayRPCBroker) StartGatewayStream(gwReq *protos.GatewayRequest, endOfStream bool, responseWindow uint32) (*broker.GatewayResponseChannel, error) {
+	ret := _m.Called(gwReq, endOfStream, responseWindow)
+
+	var r0 *broker.GatewayResponseChannel
+	if rf, ok := ret.Get(0).(func(*protos.GatewayRequest, bool, uint32) *broker.GatewayResponseChannel); ok {
+		r0 = rf(gwReq, endOfStream, responseWindow)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*broker.GatewayResponseChannel)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*protos.GatewayRequest, bool, uint32) error); ok {
+		r1 = rf(gwReq, endOfStream, responseWindow)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateResponseWindow provides a mock function with given fields: gwId, reqId, increment
+func (_m *GatewayRPCBroker) UpdateResponseWindow(gwId string, reqId uint32, increment uint32) error {
+	ret := _m.Called(gwId, reqId, increment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, uint32, uint32) error); ok {
+		r0 = rf(gwId, reqId, increment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,815 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package sqlstore implements a dispatcher broker backend on a SQL database
+// shared by all dispatcher instances.
+//
+// Each gateway has a SyncRPC stream to a single dispatcher instance. Requests
+// for gateways with a stream to the local instance are queued in memory, and
+// requests for all other gateways are written to a shared requests table,
+// which every instance polls for requests to the gateways connected to it.
+// Responses are routed back the same way: responses to requests sent from
+// the local instance are delivered in memory, and all other responses are
+// written to a shared responses table, which every instance polls for
+// responses to the requests sent from it.
+//
+// When a gateway's stream closes, e.g. because its dispatcher instance is
+// shutting down, requests which weren't sent to the gateway yet are handed
+// off to the shared requests table, to be sent by the instance the gateway
+// reconnects to.
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/memstore"
+	"magma/orc8r/cloud/go/services/dispatcher/metrics"
+	"magma/orc8r/cloud/go/sqorc"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	pkgerrors "github.com/pkg/errors"
+)
+
+const (
+	requestTable  = "dispatcher_requests"
+	responseTable = "dispatcher_responses"
+	idTable       = "dispatcher_ids"
+
+	reqIdCol        = "id"
+	reqGwIdCol      = "gateway_id"
+	reqValCol       = "request"
+	reqCreatedAtCol = "created_at"
+
+	respIdCol        = "id"
+	respReqIdCol     = "req_id"
+	respValCol       = "response"
+	respCreatedAtCol = "created_at"
+
+	idNameCol  = "name"
+	idValueCol = "value"
+
+	// idCounterName is the name of the counter row which IDs are allocated
+	// from
+	idCounterName = "ids"
+	// idBlockSize is the number of IDs each instance allocates at a time
+	idBlockSize = 100
+)
+
+// Config configures a SQLStore
+type Config struct {
+	// QueueLen is the length of the in-memory request queue of each gateway
+	// connected to the local instance, and of the in-memory response channel
+	// of each request sent from the local instance
+	QueueLen int
+	// PollInterval is how often the shared tables are polled
+	PollInterval time.Duration
+	// ResponseTimeout is how long to wait on a response channel for the
+	// response to be received before giving up
+	ResponseTimeout time.Duration
+	// RequestTTL is how long a request sent from the local instance waits for
+	// responses. Requests and responses older than this are deleted from the
+	// shared tables.
+	RequestTTL time.Duration
+}
+
+// DefaultConfig returns the default SQLStore config
+func DefaultConfig() Config {
+	return Config{
+		QueueLen:        50,
+		PollInterval:    100 * time.Millisecond,
+		ResponseTimeout: 3 * time.Second,
+		RequestTTL:      time.Minute,
+	}
+}
+
+// SQLStore implements both memstore.RequestQueue and memstore.ResponseTable
+// on a shared SQL database.
+type SQLStore struct {
+	db      *sql.DB
+	builder sqorc.StatementBuilder
+	config  Config
+
+	sync.Mutex
+	// queues are the request queues of gateways connected to this instance
+	queues map[string]*gatewayQueue
+	// pending are the response channels of requests sent from this instance
+	pending map[uint32]*pendingResponse
+
+	// idLock guards nextId and lastId, which are the bounds of the block of
+	// IDs allocated to this instance
+	idLock sync.Mutex
+	nextId uint64
+	lastId uint64
+
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+var (
+	_ memstore.RequestQueue  = &SQLStore{}
+	_ memstore.ResponseTable = &SQLStore{}
+)
+
+// NewSQLStore returns a SQLStore backed by db. Call Initialize to create the
+// shared tables, and Start to start polling them.
+func NewSQLStore(db *sql.DB, builder sqorc.StatementBuilder, config Config) *SQLStore {
+	return &SQLStore{
+		db:      db,
+		builder: builder,
+		config:  config,
+		queues:  map[string]*gatewayQueue{},
+		pending: map[uint32]*pendingResponse{},
+		done:    make(chan struct{}),
+	}
+}
+
+// Initialize creates the shared tables if they don't exist
+func (store *SQLStore) Initialize() error {
+	_, err := sqorc.ExecInTx(
+		store.db,
+		func(*sql.Tx) error { return nil },
+		func(tx *sql.Tx) (interface{}, error) { return nil, CreateTables(tx, store.builder) },
+	)
+	return err
+}
+
+// CreateTables creates the shared tables of the SQL broker backend if they
+// don't exist
+func CreateTables(tx *sql.Tx, builder sqorc.StatementBuilder) error {
+	_, err := builder.CreateTable(requestTable).
+		IfNotExists().
+		Column(reqIdCol).Type(sqorc.ColumnTypeBigInt).PrimaryKey().EndColumn().
+		Column(reqGwIdCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(reqValCol).Type(sqorc.ColumnTypeBytes).NotNull().EndColumn().
+		Column(reqCreatedAtCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to create requests table")
+	}
+	_, err = builder.CreateIndex("dispatcher_requests_gw_idx").
+		IfNotExists().
+		On(requestTable).
+		Columns(reqGwIdCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to create requests gateway ID index")
+	}
+
+	_, err = builder.CreateTable(responseTable).
+		IfNotExists().
+		Column(respIdCol).Type(sqorc.ColumnTypeBigInt).PrimaryKey().EndColumn().
+		Column(respReqIdCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+		Column(respValCol).Type(sqorc.ColumnTypeBytes).NotNull().EndColumn().
+		Column(respCreatedAtCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to create responses table")
+	}
+	_, err = builder.CreateIndex("dispatcher_responses_req_idx").
+		IfNotExists().
+		On(responseTable).
+		Columns(respReqIdCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to create responses request ID index")
+	}
+
+	_, err = builder.CreateTable(idTable).
+		IfNotExists().
+		Column(idNameCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+		Column(idValueCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to create IDs table")
+	}
+	return nil
+}
+
+// Start starts polling the shared tables until Stop is called
+func (store *SQLStore) Start() {
+	go func() {
+		ticker := time.NewTicker(store.config.PollInterval)
+		defer ticker.Stop()
+		lastGC := time.Now()
+		for {
+			select {
+			case <-store.done:
+				return
+			case now := <-ticker.C:
+				store.poll()
+				if now.Sub(lastGC) >= store.config.RequestTTL {
+					store.collectGarbage(now)
+					lastGC = now
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops polling the shared tables. Gateway queues should be cleaned up
+// before the store is stopped, so that their unsent requests are handed off.
+func (store *SQLStore) Stop() {
+	store.stopOnce.Do(func() { close(store.done) })
+}
+
+// InitializeQueue creates the in-memory request queue of a gateway which
+// connected to the local instance. Requests in the gateway's previous queue
+// which weren't sent yet are moved to the new queue.
+func (store *SQLStore) InitializeQueue(gwId string) memstore.InitializedQueue {
+	newQueue := &gatewayQueue{ch: make(chan *protos.SyncRPCRequest, store.config.QueueLen)}
+	store.Lock()
+	oldQueue := store.queues[gwId]
+	store.queues[gwId] = newQueue
+	store.Unlock()
+
+	if oldQueue == nil {
+		return memstore.InitializedQueue{NewQueue: newQueue.ch}
+	}
+	unsent := oldQueue.close()
+	for i, req := range unsent {
+		if !newQueue.enqueue(req) {
+			// Hand off the rest together, so that they stay in order
+			store.handOff(gwId, unsent[i:])
+			newQueue.spillWritten()
+			break
+		}
+	}
+	return memstore.InitializedQueue{NewQueue: newQueue.ch, OldQueue: oldQueue.ch}
+}
+
+// CleanupQueue removes the in-memory request queue of a gateway which
+// disconnected from the local instance, and hands off its unsent requests to
+// the shared requests table.
+func (store *SQLStore) CleanupQueue(gwId string) chan *protos.SyncRPCRequest {
+	store.Lock()
+	queue, ok := store.queues[gwId]
+	delete(store.queues, gwId)
+	store.Unlock()
+	if !ok {
+		return nil
+	}
+	store.handOff(gwId, queue.close())
+	return queue.ch
+}
+
+// Enqueue adds a request to the in-memory queue of the gateway if it's
+// connected to the local instance, and to the shared requests table
+// otherwise. Requests to gateways which aren't connected to any instance
+// stay in the shared table until they expire. Requests which don't fit in the
+// in-memory queue spill over to the shared table, as do the requests after
+// them until the spilled requests are delivered, so that requests are sent
+// in order.
+func (store *SQLStore) Enqueue(req *protos.SyncRPCRequest) error {
+	if req == nil || req.ReqId <= 0 || req.ReqBody == nil || len(req.ReqBody.GwId) == 0 {
+		return errors.New("SyncRPCRequest cannot be nil and gwId of ReqBody has to be valid")
+	}
+	gwId := req.ReqBody.GwId
+	store.Lock()
+	queue, ok := store.queues[gwId]
+	store.Unlock()
+	if !ok {
+		return store.insertRequests(gwId, []*protos.SyncRPCRequest{req})
+	}
+	if queue.enqueue(req) {
+		return nil
+	}
+	err := store.insertRequests(gwId, []*protos.SyncRPCRequest{req})
+	queue.spillWritten()
+	return err
+}
+
+// InitializeResponse allocates a request ID which is unique across all
+// instances, and creates the response channel of the request.
+func (store *SQLStore) InitializeResponse() (chan *protos.SyncRPCResponse, uint32) {
+	respChan := make(chan *protos.SyncRPCResponse, store.config.QueueLen)
+	reqId, err := store.allocateReqId()
+	if err != nil {
+		// Request ID 0 is invalid, so the request will fail to enqueue
+		glog.Errorf("Failed to allocate SyncRPC request ID: %s", err)
+		close(respChan)
+		return respChan, 0
+	}
+	store.Lock()
+	store.pending[reqId] = &pendingResponse{ch: respChan, lastActive: time.Now()}
+	store.Unlock()
+	return respChan, reqId
+}
+
+// SendResponse sends the response to the response channel of the request if
+// it was sent from the local instance, and writes it to the shared responses
+// table otherwise.
+func (store *SQLStore) SendResponse(resp *protos.SyncRPCResponse) error {
+	if resp == nil {
+		return errors.New("cannot send nil SyncRPCResponse")
+	}
+	if resp.RespBody == nil && resp.WindowUpdate == 0 {
+		glog.Errorf("Nil response body received, forward to httpServer anyways\n")
+	}
+	store.Lock()
+	pending, ok := store.pending[resp.ReqId]
+	if ok {
+		pending.lastActive = time.Now()
+	}
+	store.Unlock()
+	if !ok {
+		return store.insertResponse(resp)
+	}
+
+	select {
+	case pending.ch <- resp:
+		return nil
+	case <-time.After(store.config.ResponseTimeout):
+		store.Lock()
+		delete(store.pending, resp.ReqId)
+		store.Unlock()
+		return errors.New("sendResponse timed out as respChan is not being actively waited on")
+	}
+}
+
+// poll delivers requests to the gateways connected to the local instance and
+// responses to the requests sent from it.
+func (store *SQLStore) poll() {
+	err := store.deliverRequests()
+	if err != nil {
+		glog.Errorf("Failed to deliver SyncRPC requests from shared store: %s", err)
+	}
+	err = store.deliverResponses()
+	if err != nil {
+		glog.Errorf("Failed to deliver SyncRPC responses from shared store: %s", err)
+	}
+}
+
+func (store *SQLStore) deliverRequests() error {
+	store.Lock()
+	gwIds := make([]string, 0, len(store.queues))
+	// spills are the spill counts of the queues before querying, so that
+	// queues which spill during the poll stay spilled
+	spills := make(map[string]uint64, len(store.queues))
+	for gwId, queue := range store.queues {
+		gwIds = append(gwIds, gwId)
+		spills[gwId] = queue.spillCount()
+	}
+	store.Unlock()
+	if len(gwIds) == 0 {
+		return nil
+	}
+
+	type requestRow struct {
+		id   uint64
+		gwId string
+		req  *protos.SyncRPCRequest
+	}
+	rows, err := store.builder.Select(reqIdCol, reqGwIdCol, reqValCol).
+		From(requestTable).
+		Where(sq.Eq{reqGwIdCol: gwIds}).
+		OrderBy(reqIdCol).
+		RunWith(store.db).
+		Query()
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to query for requests")
+	}
+	var requests []requestRow
+	for rows.Next() {
+		var row requestRow
+		var val []byte
+		err = rows.Scan(&row.id, &row.gwId, &val)
+		if err != nil {
+			sqorc.CloseRowsLogOnError(rows, "deliverRequests")
+			return pkgerrors.Wrap(err, "failed to scan request row")
+		}
+		row.req = &protos.SyncRPCRequest{}
+		err = proto.Unmarshal(val, row.req)
+		if err != nil {
+			glog.Errorf("Dropping unparseable SyncRPC request %d: %s", row.id, err)
+			row.req = nil
+		}
+		requests = append(requests, row)
+	}
+	err = rows.Err()
+	sqorc.CloseRowsLogOnError(rows, "deliverRequests")
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to iterate over request rows")
+	}
+
+	// Requests are delivered in order, so once a request for a gateway
+	// can't be delivered, its later requests wait for the next poll too
+	blocked := map[string]bool{}
+	for _, row := range requests {
+		if blocked[row.gwId] {
+			continue
+		}
+		store.Lock()
+		queue, ok := store.queues[row.gwId]
+		store.Unlock()
+		if !ok || (row.req != nil && queue.isFull()) {
+			blocked[row.gwId] = true
+			continue
+		}
+		// Deleting the row claims the request, in case another instance
+		// also has a queue for the gateway
+		claimed, err := store.deleteRow(requestTable, reqIdCol, row.id)
+		if err != nil {
+			return err
+		}
+		if !claimed || row.req == nil {
+			continue
+		}
+		if !queue.offer(row.req) {
+			// Put the request back with its ID, so that it keeps its place
+			// ahead of the gateway's later requests
+			blocked[row.gwId] = true
+			err = store.insertRequestsWithIds(row.gwId, []uint64{row.id}, []*protos.SyncRPCRequest{row.req})
+			if err != nil {
+				glog.Errorf("Failed to return SyncRPC request %d for gateway %s: %s", row.id, row.gwId, err)
+			}
+		}
+	}
+
+	// New requests can go straight to the queues of gateways which have no
+	// more requests in the shared table
+	store.Lock()
+	defer store.Unlock()
+	for gwId, count := range spills {
+		if queue, ok := store.queues[gwId]; ok && !blocked[gwId] {
+			queue.clearSpilled(count)
+		}
+	}
+	return nil
+}
+
+func (store *SQLStore) deliverResponses() error {
+	store.Lock()
+	reqIds := make([]uint32, 0, len(store.pending))
+	for reqId := range store.pending {
+		reqIds = append(reqIds, reqId)
+	}
+	store.Unlock()
+	if len(reqIds) == 0 {
+		return nil
+	}
+
+	type responseRow struct {
+		id   uint64
+		resp *protos.SyncRPCResponse
+	}
+	rows, err := store.builder.Select(respIdCol, respValCol).
+		From(responseTable).
+		Where(sq.Eq{respReqIdCol: reqIds}).
+		OrderBy(respIdCol).
+		RunWith(store.db).
+		Query()
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to query for responses")
+	}
+	var responses []responseRow
+	for rows.Next() {
+		var row responseRow
+		var val []byte
+		err = rows.Scan(&row.id, &val)
+		if err != nil {
+			sqorc.CloseRowsLogOnError(rows, "deliverResponses")
+			return pkgerrors.Wrap(err, "failed to scan response row")
+		}
+		row.resp = &protos.SyncRPCResponse{}
+		err = proto.Unmarshal(val, row.resp)
+		if err != nil {
+			glog.Errorf("Dropping unparseable SyncRPC response %d: %s", row.id, err)
+			row.resp = nil
+		}
+		responses = append(responses, row)
+	}
+	err = rows.Err()
+	sqorc.CloseRowsLogOnError(rows, "deliverResponses")
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to iterate over response rows")
+	}
+
+	for _, row := range responses {
+		var pending *pendingResponse
+		if row.resp != nil {
+			store.Lock()
+			pending = store.pending[row.resp.ReqId]
+			store.Unlock()
+			// Leave the response for the next poll if the channel is full
+			if pending != nil && len(pending.ch) == cap(pending.ch) {
+				continue
+			}
+		}
+		claimed, err := store.deleteRow(responseTable, respIdCol, row.id)
+		if err != nil {
+			return err
+		}
+		if !claimed || pending == nil {
+			continue
+		}
+		store.Lock()
+		pending.lastActive = time.Now()
+		store.Unlock()
+		select {
+		case pending.ch <- row.resp:
+		default:
+			glog.Errorf("Dropping SyncRPC response to request %d as its response channel is full", row.resp.ReqId)
+		}
+	}
+	return nil
+}
+
+// collectGarbage deletes expired requests and responses from the shared
+// tables, and forgets about requests from the local instance which haven't
+// received responses in a while.
+func (store *SQLStore) collectGarbage(now time.Time) {
+	expiredBefore := now.Add(-store.config.RequestTTL)
+	store.Lock()
+	for reqId, pending := range store.pending {
+		if pending.lastActive.Before(expiredBefore) {
+			delete(store.pending, reqId)
+		}
+	}
+	store.Unlock()
+
+	for table, col := range map[string]string{requestTable: reqCreatedAtCol, responseTable: respCreatedAtCol} {
+		_, err := store.builder.Delete(table).
+			Where(sq.Lt{col: toMillis(expiredBefore)}).
+			RunWith(store.db).
+			Exec()
+		if err != nil {
+			glog.Errorf("Failed to delete expired rows from %s: %s", table, err)
+		}
+	}
+}
+
+// handOff writes unsent requests to the shared requests table
+func (store *SQLStore) handOff(gwId string, reqs []*protos.SyncRPCRequest) {
+	if len(reqs) == 0 {
+		return
+	}
+	err := store.insertRequests(gwId, reqs)
+	if err != nil {
+		glog.Errorf("Failed to hand off %d SyncRPC requests for gateway %s: %s", len(reqs), gwId, err)
+		return
+	}
+	metrics.RequestsHandedOff.WithLabelValues(gwId).Add(float64(len(reqs)))
+}
+
+func (store *SQLStore) insertRequests(gwId string, reqs []*protos.SyncRPCRequest) error {
+	ids, err := store.allocateIds(len(reqs))
+	if err != nil {
+		return err
+	}
+	return store.insertRequestsWithIds(gwId, ids, reqs)
+}
+
+func (store *SQLStore) insertRequestsWithIds(gwId string, ids []uint64, reqs []*protos.SyncRPCRequest) error {
+	insert := store.builder.Insert(requestTable).
+		Columns(reqIdCol, reqGwIdCol, reqValCol, reqCreatedAtCol)
+	now := toMillis(time.Now())
+	for i, req := range reqs {
+		val, err := proto.Marshal(req)
+		if err != nil {
+			return pkgerrors.Wrap(err, "failed to marshal request")
+		}
+		insert = insert.Values(ids[i], gwId, val, now)
+	}
+	_, err := insert.RunWith(store.db).Exec()
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to insert requests")
+	}
+	return nil
+}
+
+func (store *SQLStore) insertResponse(resp *protos.SyncRPCResponse) error {
+	ids, err := store.allocateIds(1)
+	if err != nil {
+		return err
+	}
+	val, err := proto.Marshal(resp)
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to marshal response")
+	}
+	_, err = store.builder.Insert(responseTable).
+		Columns(respIdCol, respReqIdCol, respValCol, respCreatedAtCol).
+		Values(ids[0], resp.ReqId, val, toMillis(time.Now())).
+		RunWith(store.db).
+		Exec()
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to insert response")
+	}
+	return nil
+}
+
+// deleteRow deletes a row by ID, returning true if the row existed
+func (store *SQLStore) deleteRow(table string, idCol string, id uint64) (bool, error) {
+	res, err := store.builder.Delete(table).
+		Where(sq.Eq{idCol: id}).
+		RunWith(store.db).
+		Exec()
+	if err != nil {
+		return false, pkgerrors.Wrapf(err, "failed to delete row %d from %s", id, table)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, pkgerrors.Wrapf(err, "failed to get rows affected for deleting row %d from %s", id, table)
+	}
+	return rowsAffected == 1, nil
+}
+
+// allocateReqId allocates a request ID which is unique across all instances.
+// Request IDs are the low 32 bits of a row ID, skipping 0.
+func (store *SQLStore) allocateReqId() (uint32, error) {
+	for {
+		ids, err := store.allocateIds(1)
+		if err != nil {
+			return 0, err
+		}
+		if reqId := uint32(ids[0]); reqId != 0 {
+			return reqId, nil
+		}
+	}
+}
+
+// allocateIds allocates IDs which are unique across all instances, and
+// increasing within the local instance.
+func (store *SQLStore) allocateIds(n int) ([]uint64, error) {
+	store.idLock.Lock()
+	defer store.idLock.Unlock()
+	ret := make([]uint64, 0, n)
+	for len(ret) < n {
+		if store.nextId > store.lastId {
+			blockEnd, err := store.allocateIdBlock()
+			if err != nil {
+				return nil, err
+			}
+			store.nextId, store.lastId = blockEnd-idBlockSize+1, blockEnd
+		}
+		ret = append(ret, store.nextId)
+		store.nextId++
+	}
+	return ret, nil
+}
+
+// allocateIdBlock increments the shared ID counter by idBlockSize, returning
+// the new value. The counter row is created if it doesn't exist, ignoring
+// conflicts with concurrent creations. Incrementing the counter in place then
+// locks its row until the transaction completes, so concurrent allocations
+// are serialized.
+func (store *SQLStore) allocateIdBlock() (uint64, error) {
+	ret, err := sqorc.ExecInTx(
+		store.db,
+		func(*sql.Tx) error { return nil },
+		func(tx *sql.Tx) (interface{}, error) {
+			_, err := store.builder.Insert(idTable).
+				Columns(idNameCol, idValueCol).
+				Values(idCounterName, 0).
+				OnConflict(nil, idNameCol).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, pkgerrors.Wrap(err, "failed to initialize ID counter")
+			}
+			_, err = store.builder.Update(idTable).
+				Set(idValueCol, sq.Expr(fmt.Sprintf("%s+%d", idValueCol, idBlockSize))).
+				Where(sq.Eq{idNameCol: idCounterName}).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, pkgerrors.Wrap(err, "failed to increment ID counter")
+			}
+
+			var value uint64
+			err = store.builder.Select(idValueCol).
+				From(idTable).
+				Where(sq.Eq{idNameCol: idCounterName}).
+				RunWith(tx).
+				QueryRow().
+				Scan(&value)
+			if err != nil {
+				return nil, pkgerrors.Wrap(err, "failed to load ID counter")
+			}
+			return value, nil
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return ret.(uint64), nil
+}
+
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// gatewayQueue is the in-memory request queue of a gateway connected to the
+// local instance. It's closed once the gateway disconnects, after which no
+// more requests are offered to it.
+type gatewayQueue struct {
+	sync.Mutex
+	ch     chan *protos.SyncRPCRequest
+	closed bool
+	// spilled is set once a new request didn't fit in the queue and was
+	// written to the shared table. New requests are written to the shared
+	// table too until the spilled requests are delivered.
+	spilled bool
+	// spills counts the requests written to the shared table, so that a
+	// poll which started before a spill was written doesn't clear spilled
+	spills uint64
+}
+
+// enqueue adds a new request to the queue without blocking, returning false
+// if the request must be written to the shared table instead
+func (queue *gatewayQueue) enqueue(req *protos.SyncRPCRequest) bool {
+	queue.Lock()
+	defer queue.Unlock()
+	if queue.closed {
+		return false
+	}
+	if queue.spilled || len(queue.ch) == cap(queue.ch) {
+		queue.spilled = true
+		return false
+	}
+	queue.ch <- req
+	return true
+}
+
+// spillWritten records that spilled requests were written to the shared
+// table
+func (queue *gatewayQueue) spillWritten() {
+	queue.Lock()
+	defer queue.Unlock()
+	queue.spills++
+}
+
+func (queue *gatewayQueue) spillCount() uint64 {
+	queue.Lock()
+	defer queue.Unlock()
+	return queue.spills
+}
+
+// clearSpilled lets new requests into the queue again, unless requests
+// were spilled since the spill count was read
+func (queue *gatewayQueue) clearSpilled(spills uint64) {
+	queue.Lock()
+	defer queue.Unlock()
+	if queue.spills == spills {
+		queue.spilled = false
+	}
+}
+
+// offer adds a request from the shared table to the queue without blocking,
+// returning false if the queue is full or closed
+func (queue *gatewayQueue) offer(req *protos.SyncRPCRequest) bool {
+	queue.Lock()
+	defer queue.Unlock()
+	if queue.closed || len(queue.ch) == cap(queue.ch) {
+		return false
+	}
+	queue.ch <- req
+	return true
+}
+
+func (queue *gatewayQueue) isFull() bool {
+	queue.Lock()
+	defer queue.Unlock()
+	return len(queue.ch) == cap(queue.ch)
+}
+
+// close closes the queue and returns the requests left in it. Requests may
+// still be received from the closed queue concurrently, in which case they
+// aren't returned.
+func (queue *gatewayQueue) close() []*protos.SyncRPCRequest {
+	queue.Lock()
+	defer queue.Unlock()
+	if queue.closed {
+		return nil
+	}
+	queue.closed = true
+	close(queue.ch)
+	var ret []*protos.SyncRPCRequest
+	for req := range queue.ch {
+		if req != nil {
+			ret = append(ret, req)
+		}
+	}
+	return ret
+}
+
+type pendingResponse struct {
+	ch         chan *protos.SyncRPCResponse
+	lastActive time.Time
+}
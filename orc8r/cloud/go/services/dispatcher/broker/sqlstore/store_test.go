@@ -0,0 +1,203 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package sqlstore_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/dispatcher/broker"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/sqlstore"
+	"magma/orc8r/cloud/go/sqorc"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+// Two stores on the same database stand in for two dispatcher instances
+func newTestStores(t *testing.T) (*sqlstore.SQLStore, *sqlstore.SQLStore) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	// Each connection to an in-memory sqlite DB gets its own database
+	db.SetMaxOpenConns(1)
+
+	config := sqlstore.DefaultConfig()
+	config.PollInterval = 10 * time.Millisecond
+	config.ResponseTimeout = 100 * time.Millisecond
+	stores := make([]*sqlstore.SQLStore, 0, 2)
+	for i := 0; i < 2; i++ {
+		store := sqlstore.NewSQLStore(db, sqorc.GetSqlBuilder(), config)
+		assert.NoError(t, store.Initialize())
+		store.Start()
+		stores = append(stores, store)
+	}
+	return stores[0], stores[1]
+}
+
+func TestSQLStore_CrossInstance(t *testing.T) {
+	store1, store2 := newTestStores(t)
+	defer store1.Stop()
+	defer store2.Stop()
+	broker1 := broker.NewGatewayRPCBroker(store1, store1)
+	broker2 := broker.NewGatewayRPCBroker(store2, store2)
+
+	// gw1 is connected to instance 1, the request is sent from instance 2
+	queue := broker1.InitializeGateway("gw1")
+	gwReq := &protos.GatewayRequest{GwId: "gw1", Authority: "magmad", Path: "/foo"}
+	respChan, err := broker2.SendRequestToGateway(gwReq)
+	assert.NoError(t, err)
+	req := receiveRequest(t, queue)
+	assert.Equal(t, respChan.ReqId, req.ReqId)
+	assert.Equal(t, "/foo", req.ReqBody.Path)
+
+	// Responses are routed back to instance 2 in order
+	err = broker1.ProcessGatewayResponse(&protos.SyncRPCResponse{ReqId: req.ReqId, RespBody: &protos.GatewayResponse{Status: "200", KeepConnActive: true}})
+	assert.NoError(t, err)
+	err = broker1.ProcessGatewayResponse(&protos.SyncRPCResponse{ReqId: req.ReqId, RespBody: &protos.GatewayResponse{Status: "200", Payload: []byte("bar")}})
+	assert.NoError(t, err)
+	assert.True(t, receiveResponse(t, respChan.RespChan).RespBody.KeepConnActive)
+	assert.Equal(t, []byte("bar"), receiveResponse(t, respChan.RespChan).RespBody.Payload)
+
+	// Requests to gateways connected to the local instance are delivered in
+	// memory
+	respChan, err = broker1.SendRequestToGateway(gwReq)
+	assert.NoError(t, err)
+	req = receiveRequest(t, queue)
+	assert.Equal(t, respChan.ReqId, req.ReqId)
+	err = broker1.ProcessGatewayResponse(&protos.SyncRPCResponse{ReqId: req.ReqId, RespBody: &protos.GatewayResponse{Status: "200"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "200", receiveResponse(t, respChan.RespChan).RespBody.Status)
+
+	// Request IDs are unique across instances
+	_, reqId1 := store1.InitializeResponse()
+	_, reqId2 := store2.InitializeResponse()
+	assert.NotEqual(t, reqId1, reqId2)
+	assert.NotZero(t, reqId1)
+	assert.NotZero(t, reqId2)
+}
+
+func TestSQLStore_Handoff(t *testing.T) {
+	store1, store2 := newTestStores(t)
+	defer store1.Stop()
+	defer store2.Stop()
+	broker1 := broker.NewGatewayRPCBroker(store1, store1)
+	broker2 := broker.NewGatewayRPCBroker(store2, store2)
+
+	// Requests queued on instance 1 aren't sent before gw1 disconnects
+	broker1.InitializeGateway("gw1")
+	respChan1, err := broker1.SendRequestToGateway(&protos.GatewayRequest{GwId: "gw1", Path: "/1"})
+	assert.NoError(t, err)
+	respChan2, err := broker1.SendRequestToGateway(&protos.GatewayRequest{GwId: "gw1", Path: "/2"})
+	assert.NoError(t, err)
+	assert.NoError(t, broker1.CleanupGateway("gw1"))
+
+	// gw1 reconnects to instance 2, which sends the handed off requests
+	queue := broker2.InitializeGateway("gw1")
+	req := receiveRequest(t, queue)
+	assert.Equal(t, respChan1.ReqId, req.ReqId)
+	assert.Equal(t, "/1", req.ReqBody.Path)
+	req = receiveRequest(t, queue)
+	assert.Equal(t, respChan2.ReqId, req.ReqId)
+	assert.Equal(t, "/2", req.ReqBody.Path)
+
+	err = broker2.ProcessGatewayResponse(&protos.SyncRPCResponse{ReqId: req.ReqId, RespBody: &protos.GatewayResponse{Status: "200"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "200", receiveResponse(t, respChan2.RespChan).RespBody.Status)
+
+	// Requests can't be enqueued without a valid request ID
+	err = store1.Enqueue(&protos.SyncRPCRequest{ReqBody: &protos.GatewayRequest{GwId: "gw1"}})
+	assert.EqualError(t, err, "SyncRPCRequest cannot be nil and gwId of ReqBody has to be valid")
+}
+
+func TestSQLStore_GarbageCollection(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	config := sqlstore.DefaultConfig()
+	config.PollInterval = 10 * time.Millisecond
+	config.RequestTTL = 50 * time.Millisecond
+	store := sqlstore.NewSQLStore(db, sqorc.GetSqlBuilder(), config)
+	assert.NoError(t, store.Initialize())
+	store.Start()
+	defer store.Stop()
+
+	// Requests to gateways which aren't connected anywhere expire
+	_, reqId := store.InitializeResponse()
+	err = store.Enqueue(&protos.SyncRPCRequest{ReqId: reqId, ReqBody: &protos.GatewayRequest{GwId: "gw1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, countRows(t, db, "dispatcher_requests"))
+	assert.NoError(t, store.SendResponse(&protos.SyncRPCResponse{ReqId: 12345}))
+	assert.Equal(t, 1, countRows(t, db, "dispatcher_responses"))
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, 0, countRows(t, db, "dispatcher_requests"))
+	assert.Equal(t, 0, countRows(t, db, "dispatcher_responses"))
+}
+
+func TestSQLStore_FullQueueOrder(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	config := sqlstore.DefaultConfig()
+	config.QueueLen = 2
+	config.PollInterval = 10 * time.Millisecond
+	store := sqlstore.NewSQLStore(db, sqorc.GetSqlBuilder(), config)
+	assert.NoError(t, store.Initialize())
+	store.Start()
+	defer store.Stop()
+
+	// Requests which don't fit in the queue spill over to the shared table,
+	// and are still sent in order
+	queue := store.InitializeQueue("gw1").NewQueue
+	for reqId := uint32(1); reqId <= 5; reqId++ {
+		err = store.Enqueue(&protos.SyncRPCRequest{ReqId: reqId, ReqBody: &protos.GatewayRequest{GwId: "gw1"}})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 3, countRows(t, db, "dispatcher_requests"))
+	for reqId := uint32(1); reqId <= 5; reqId++ {
+		assert.Equal(t, reqId, receiveRequest(t, queue).ReqId)
+	}
+
+	// Requests go straight to the queue once the spilled requests are sent
+	assert.Equal(t, 0, countRows(t, db, "dispatcher_requests"))
+	time.Sleep(3 * config.PollInterval)
+	err = store.Enqueue(&protos.SyncRPCRequest{ReqId: 6, ReqBody: &protos.GatewayRequest{GwId: "gw1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, countRows(t, db, "dispatcher_requests"))
+	assert.Equal(t, uint32(6), receiveRequest(t, queue).ReqId)
+}
+
+func receiveRequest(t *testing.T, queue chan *protos.SyncRPCRequest) *protos.SyncRPCRequest {
+	select {
+	case req := <-queue:
+		return req
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for request")
+		return nil
+	}
+}
+
+func receiveResponse(t *testing.T, respChan chan *protos.SyncRPCResponse) *protos.SyncRPCResponse {
+	select {
+	case resp := <-respChan:
+		return resp
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for response")
+		return nil
+	}
+}
+
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count)
+	assert.NoError(t, err)
+	return count
+}
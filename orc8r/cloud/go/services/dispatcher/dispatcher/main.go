@@ -9,18 +9,26 @@ LICENSE file in the root directory of this source tree.
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/service"
 	"magma/orc8r/cloud/go/services/dispatcher"
 	sync_rpc_broker "magma/orc8r/cloud/go/services/dispatcher/broker"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/sqlstore"
 	"magma/orc8r/cloud/go/services/dispatcher/httpserver"
 	"magma/orc8r/cloud/go/services/dispatcher/servicers"
+	"magma/orc8r/cloud/go/sqorc"
 
 	"github.com/golang/glog"
 	"google.golang.org/grpc"
@@ -28,7 +36,15 @@ import (
 
 const HTTP_SERVER_PORT = 9080
 
+var (
+	brokerBackend    = flag.String("broker_backend", "memory", "SyncRPC broker backend: memory for a single dispatcher instance, or sql to share requests between instances")
+	drainGracePeriod = flag.Duration("drain_grace_period", 5*time.Second, "Time to wait for gateway responses before closing SyncRPC streams on shutdown")
+	drainTimeout     = flag.Duration("drain_timeout", 30*time.Second, "Maximum time to drain SyncRPC streams on shutdown")
+)
+
 func main() {
+	flag.Parse()
+
 	// Set MaxConnectionAge to infinity so Sync RPC stream doesn't restart
 	var keepaliveParams = service.GetDefaultKeepaliveParameters()
 	keepaliveParams.MaxConnectionAge = 0
@@ -45,7 +61,29 @@ func main() {
 	}
 
 	// create a broker
-	broker := sync_rpc_broker.NewGatewayReqRespBroker()
+	var broker *sync_rpc_broker.GatewayRPCBrokerImpl
+	var store *sqlstore.SQLStore
+	switch *brokerBackend {
+	case "memory":
+		broker = sync_rpc_broker.NewGatewayReqRespBroker()
+	case "sql":
+		db, err := sqorc.Open(datastore.SQL_DRIVER, datastore.DATABASE_SOURCE)
+		if err != nil {
+			glog.Fatalf("Failed to connect to database: %s", err)
+		}
+		store = sqlstore.NewSQLStore(db, sqorc.GetSqlBuilder(), sqlstore.DefaultConfig())
+		err = sqorc.ApplyServiceMigrations(db, sqorc.GetSqlBuilder(), dispatcher.ServiceName)
+		if err == sqorc.ErrMigrationsUnsupported {
+			err = store.Initialize()
+		}
+		if err != nil {
+			glog.Fatalf("Failed to initialize SyncRPC broker store: %s", err)
+		}
+		store.Start()
+		broker = sync_rpc_broker.NewGatewayRPCBroker(store, store)
+	default:
+		glog.Fatalf("Unknown broker backend %s", *brokerBackend)
+	}
 
 	// get ec2 public host name
 	hostName := getHostName()
@@ -65,6 +103,25 @@ func main() {
 	// run http server
 	go httpServer.Run(fmt.Sprintf(":%d", HTTP_SERVER_PORT))
 
+	// drain SyncRPC streams on shutdown so that gateways reconnect to other
+	// instances
+	go func() {
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGTERM, os.Interrupt)
+		<-sigs
+		glog.Info("Draining SyncRPC streams")
+		ctx, cancel := context.WithTimeout(context.Background(), *drainTimeout)
+		defer cancel()
+		err := syncRpcServicer.Drain(ctx, *drainGracePeriod)
+		if err != nil {
+			glog.Errorf("Error draining SyncRPC streams: %s", err)
+		}
+		if store != nil {
+			store.Stop()
+		}
+		srv.GrpcServer.GracefulStop()
+	}()
+
 	err = srv.Run()
 	if err != nil {
 		glog.Fatalf("Error running service: %s", err)
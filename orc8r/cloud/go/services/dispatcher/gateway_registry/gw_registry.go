@@ -11,7 +11,9 @@ package gateway_registry
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"testing"
 	"time"
 
 	"magma/orc8r/cloud/go/registry"
@@ -22,7 +24,8 @@ import (
 )
 
 const (
-	// Gateway services
+	// Gateway services. These are registered by the plugins of the modules
+	// which run them on gateways.
 	GwMobilityd       GwServiceType = "mobilityd"
 	GwMagmad          GwServiceType = "magmad"
 	GwEnodebd         GwServiceType = "enodebd"
@@ -45,17 +48,13 @@ type httpServerConfig struct {
 	*sync.RWMutex
 }
 
-var services = []GwServiceType{
-	GwMobilityd,
-	GwMagmad,
-	GwEnodebd,
-	GwPipelined,
-	GwSubscriberDB,
-	GwS6aService,
-	GwSgsService,
-	GwSessiondService,
+type gwServiceRegistry struct {
+	sync.RWMutex
+	services map[GwServiceType]struct{}
 }
 
+var services = gwServiceRegistry{services: map[GwServiceType]struct{}{}}
+
 var config = httpServerConfig{HttpServerAddressPort, &sync.RWMutex{}}
 
 // SetPort sets the port of http_server.
@@ -113,6 +112,56 @@ func GetGatewayConnection(service GwServiceType, hwId string) (*grpc.ClientConn,
 
 }
 
+// RegisterGwServices registers gateway services which can be called over
+// SyncRPC. Registration is all-or-nothing: if any service is already
+// registered, none of the services are registered.
+func RegisterGwServices(gwServices ...GwServiceType) error {
+	services.Lock()
+	defer services.Unlock()
+	newServices := map[GwServiceType]struct{}{}
+	for _, service := range gwServices {
+		if service == "" {
+			return fmt.Errorf("gateway service name must be non-empty")
+		}
+		_, registered := services.services[service]
+		_, duplicate := newServices[service]
+		if registered || duplicate {
+			return fmt.Errorf("gateway service %s is already registered", service)
+		}
+		newServices[service] = struct{}{}
+	}
+	for service := range newServices {
+		services.services[service] = struct{}{}
+	}
+	return nil
+}
+
+// IsGwServiceRegistered returns true if the gateway service has been
+// registered with RegisterGwServices.
+func IsGwServiceRegistered(service GwServiceType) bool {
+	services.RLock()
+	defer services.RUnlock()
+	_, ok := services.services[service]
+	return ok
+}
+
+// ListAllGwServices returns all registered gateway services, sorted by name.
 func ListAllGwServices() []GwServiceType {
-	return services
+	services.RLock()
+	defer services.RUnlock()
+	ret := make([]GwServiceType, 0, len(services.services))
+	for service := range services.services {
+		ret = append(ret, service)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// ClearGwServices unregisters all gateway services. It exists ONLY for
+// testing - thus the required but unused *testing.T param.
+// DO NOT USE IN ANYTHING BUT TESTS
+func ClearGwServices(_ *testing.T) {
+	services.Lock()
+	defer services.Unlock()
+	services.services = map[GwServiceType]struct{}{}
 }
@@ -0,0 +1,45 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package gateway_registry_test
+
+import (
+	"testing"
+
+	"magma/orc8r/cloud/go/services/dispatcher/gateway_registry"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterGwServices(t *testing.T) {
+	gateway_registry.ClearGwServices(t)
+	defer gateway_registry.ClearGwServices(t)
+	assert.Empty(t, gateway_registry.ListAllGwServices())
+
+	err := gateway_registry.RegisterGwServices(gateway_registry.GwMagmad, gateway_registry.GwEnodebd)
+	assert.NoError(t, err)
+	err = gateway_registry.RegisterGwServices("custom_service")
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]gateway_registry.GwServiceType{"custom_service", gateway_registry.GwEnodebd, gateway_registry.GwMagmad},
+		gateway_registry.ListAllGwServices(),
+	)
+	assert.True(t, gateway_registry.IsGwServiceRegistered("custom_service"))
+	assert.False(t, gateway_registry.IsGwServiceRegistered(gateway_registry.GwMobilityd))
+
+	// Duplicates fail the whole batch
+	err = gateway_registry.RegisterGwServices(gateway_registry.GwMobilityd, gateway_registry.GwMagmad)
+	assert.EqualError(t, err, "gateway service magmad is already registered")
+	err = gateway_registry.RegisterGwServices(gateway_registry.GwMobilityd, gateway_registry.GwMobilityd)
+	assert.EqualError(t, err, "gateway service mobilityd is already registered")
+	err = gateway_registry.RegisterGwServices("")
+	assert.EqualError(t, err, "gateway service name must be non-empty")
+	assert.False(t, gateway_registry.IsGwServiceRegistered(gateway_registry.GwMobilityd))
+	assert.Len(t, gateway_registry.ListAllGwServices(), 3)
+}
@@ -17,12 +17,15 @@ LICENSE file in the root directory of this source tree.
 // This httpServer converts httpRequest to GatewayRequest, send it over to grpc
 // servicer using GatewayRPCBroker, waits for a response, and converts the
 // GatewayResponse to a HttpResponse and send it back to the client.
+//
+// Streaming RPCs are relayed message by message: request bodies which don't
+// end right away are sent to the gateway in sequenced SyncRPCRequests, and
+// the gateway streams back sequenced SyncRPCResponses. Both directions are
+// flow controlled with window updates.
 package httpserver
 
 import (
 	"fmt"
-	"io"
-	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
@@ -42,10 +45,15 @@ import (
 const (
 	DefaultHttpResponseStatus = 200
 
-	responseTimeoutSecs = 15
-	maxCancelAttempts   = 5
+	maxCancelAttempts = 5
 )
 
+// ResponseIdleTimeout is how long a request waits for the next message from
+// the gateway. Gateways send a keepalive response every 10 seconds while
+// the service is quiet, so long-lived streams (e.g. TailLogs) only time out
+// if the gateway stops responding.
+var ResponseIdleTimeout = 15 * time.Second
+
 type SyncRPCHttpServer struct {
 	*http2.H2CServer
 	broker broker.GatewayRPCBroker
@@ -65,7 +73,12 @@ func (server *SyncRPCHttpServer) Serve(listener net.Listener) {
 
 func (server *SyncRPCHttpServer) rootHandler(responseWriter http.ResponseWriter, req *http.Request) {
 	http2.LogRequestWithVerbosity(req, 4)
-	respChan, err := server.sendRequest(req)
+	if err := validateGatewayService(req.Host); err != nil {
+		glog.Error(err.Msg)
+		http2.WriteErrResponse(responseWriter, err)
+		return
+	}
+	stream, err := server.startStream(req)
 	if err != nil {
 		glog.Errorf(err.Msg)
 		// Also write to client.
@@ -73,19 +86,30 @@ func (server *SyncRPCHttpServer) rootHandler(responseWriter http.ResponseWriter,
 		return
 	}
 
-	// Wait for response or timeout.
+	// Wait for responses or timeout. Every message from the gateway,
+	// including keepalives and window updates, resets the idle timer.
+	idleTimer := time.NewTimer(ResponseIdleTimeout)
+	defer idleTimer.Stop()
 	for {
 		select {
-		case gwResponse := <-respChan:
-			err := processResponse(responseWriter, gwResponse)
+		case resp := <-stream.responses:
+			if !idleTimer.Stop() {
+				<-idleTimer.C
+			}
+			idleTimer.Reset(ResponseIdleTimeout)
+			endOfStream, err := stream.processResponse(responseWriter, resp)
 			if err != nil {
 				glog.Errorf(err.Msg)
 				http2.WriteErrResponse(responseWriter, err)
 			}
-			if isResponseComplete(responseWriter) {
+			if endOfStream || isResponseComplete(responseWriter) {
 				return
 			}
-		case <-time.After(time.Second * responseTimeoutSecs):
+		case err := <-stream.sendErrs:
+			glog.Error(err.Msg)
+			http2.WriteErrResponse(responseWriter, err)
+			return
+		case <-idleTimer.C:
 			http2.WriteErrResponse(
 				responseWriter,
 				http2.NewHTTPGrpcError("Request timed out", int(codes.DeadlineExceeded), http.StatusRequestTimeout),
@@ -95,19 +119,33 @@ func (server *SyncRPCHttpServer) rootHandler(responseWriter http.ResponseWriter,
 	}
 }
 
-// sendRequest sends a SyncRPCRequest to the gateway and creates
-// a goroutine to notify the gateway when the context is done.
-func (server *SyncRPCHttpServer) sendRequest(req *http.Request) (chan *protos.GatewayResponse, *http2.HTTPGrpcError) {
+// startStream sends the first message of the request to the gateway, and
+// starts relaying the rest of the request body and the responses. It also
+// creates a goroutine to notify the gateway when the context is done.
+func (server *SyncRPCHttpServer) startStream(req *http.Request) (*gatewayStream, *http2.HTTPGrpcError) {
 	gwReq, err := createRequest(req)
 	if err != nil {
 		return nil, err
 	}
-	gwRespChannel, sendReqErr := server.broker.SendRequestToGateway(gwReq)
+	body := newBodyReader(req.Context(), req.Body)
+	payload, endOfStream, readErr := body.nextMessage(req.Context())
+	if readErr != nil {
+		errMsg := fmt.Sprintf("err reading req body: %v", readErr)
+		return nil, http2.NewHTTPGrpcError(errMsg, int(codes.InvalidArgument), http.StatusBadRequest)
+	}
+	gwReq.Payload = payload
+	gwRespChannel, sendReqErr := server.broker.StartGatewayStream(gwReq, endOfStream, responseWindow)
 	if sendReqErr != nil {
 		errMsg := fmt.Sprintf("err sending request %v to gateway: %v", gwReq, sendReqErr)
 		return nil, http2.NewHTTPGrpcError(errMsg, int(codes.Internal), http.StatusInternalServerError)
 	}
 
+	stream := newGatewayStream(server.broker, gwReq.GwId, gwRespChannel.ReqId)
+	go stream.relayResponses(req.Context(), gwRespChannel.RespChan)
+	if !endOfStream {
+		go stream.sendBody(req.Context(), body)
+	}
+
 	// If context is done (connection between client and this HTTP/2 server is closed),
 	// notify the proxy client in gateway to stop receiving frames
 	go func() {
@@ -128,10 +166,11 @@ func (server *SyncRPCHttpServer) sendRequest(req *http.Request) (chan *protos.Ga
 		glog.Errorf("Could not cancel gateway request after %v attempts", maxCancelAttempts)
 	}()
 
-	return gwRespChannel.RespChan, nil
+	return stream, nil
 }
 
-// createRequest converts a HTTP request to a GatewayRequest.
+// createRequest converts a HTTP request to a GatewayRequest, without the
+// payload.
 func createRequest(req *http.Request) (*protos.GatewayRequest, *http2.HTTPGrpcError) {
 	headers := req.Header
 	gwIds := headers[gateway_registry.GatewayIdHeaderKey]
@@ -148,16 +187,11 @@ func createRequest(req *http.Request) (*protos.GatewayRequest, *http2.HTTPGrpcEr
 	if err != nil {
 		return nil, err
 	}
-	body, err := getPayload(req.Body)
-	if err != nil {
-		return nil, err
-	}
 	gwReq := &protos.GatewayRequest{
 		GwId:      gwId,
 		Authority: authority,
 		Path:      path,
 		Headers:   convertHeadersForProto(headers),
-		Payload:   body,
 	}
 	return gwReq, nil
 }
@@ -170,6 +204,20 @@ func getAuthority(host string) (string, *http2.HTTPGrpcError) {
 	}
 }
 
+// validateGatewayService checks that the authority of a request is a gateway
+// service registered by a plugin. The authority of SyncRPC requests is the
+// name of the gateway service they're sent to.
+func validateGatewayService(authority string) *http2.HTTPGrpcError {
+	if len(authority) == 0 {
+		return nil
+	}
+	if !gateway_registry.IsGwServiceRegistered(gateway_registry.GwServiceType(authority)) {
+		errMsg := fmt.Sprintf("unknown gateway service %s; no orchestrator plugin registers it", authority)
+		return http2.NewHTTPGrpcError(errMsg, int(codes.Unimplemented), http.StatusNotFound)
+	}
+	return nil
+}
+
 func getPath(url *url.URL) (string, *http2.HTTPGrpcError) {
 	if url == nil || len(url.Path) == 0 {
 		return "", http2.NewHTTPGrpcError("No url path provided", int(codes.InvalidArgument),
@@ -178,16 +226,6 @@ func getPath(url *url.URL) (string, *http2.HTTPGrpcError) {
 	return url.Path, nil
 }
 
-func getPayload(body io.ReadCloser) ([]byte, *http2.HTTPGrpcError) {
-	payload, err := ioutil.ReadAll(body)
-	defer body.Close()
-	if err != nil {
-		errMsg := fmt.Sprintf("err reading req body: %v", err)
-		return nil, http2.NewHTTPGrpcError(errMsg, int(codes.InvalidArgument), http.StatusBadRequest)
-	}
-	return payload, nil
-}
-
 func convertHeadersForProto(headers http.Header) map[string]string {
 	ret := make(map[string]string)
 	for k, vals := range headers {
@@ -201,23 +239,26 @@ func concatenateHeaders(headers []string) string {
 }
 
 // processResponse converts the GatewayResponse to an HTTP response and sends it
-// back to the client.
-func processResponse(w http.ResponseWriter, gwResp *protos.GatewayResponse) *http2.HTTPGrpcError {
-	if gwResp.KeepConnActive {
-		return nil
-	}
+// back to the client. The status is only written with the first response of a
+// stream, so statusWritten is set for later responses.
+func processResponse(w http.ResponseWriter, gwResp *protos.GatewayResponse, statusWritten bool) *http2.HTTPGrpcError {
 	if gwResp == nil {
 		// Remains for backward compatibility, but it shouldn't get forwarded
 		// a nil GatewayResponse in new versions.
 		return http2.NewHTTPGrpcError("nil GatewayResponse", int(codes.Internal), http.StatusInternalServerError)
 	}
+	if gwResp.KeepConnActive {
+		return nil
+	}
 	if gwResp.Err != "" {
 		return http2.NewHTTPGrpcError(gwResp.Err, int(codes.Internal), http.StatusInternalServerError)
 	}
 	headers := gwResp.GetHeaders()
 	writeHeadersToResponse(headers, w)
 	httpStatus, err := getHttpStatusFromGatewayResponse(gwResp.Status)
-	w.WriteHeader(httpStatus)
+	if !statusWritten {
+		w.WriteHeader(httpStatus)
+	}
 	if gwResp.Payload != nil {
 		w.Write(gwResp.Payload)
 	}
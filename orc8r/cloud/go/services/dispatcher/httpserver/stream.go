@@ -0,0 +1,276 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"magma/orc8r/cloud/go/http2"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/dispatcher/broker"
+
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// responseWindow is the number of response messages a gateway can send
+	// for a request before it needs a window update
+	responseWindow = 16
+
+	// maxRequestMessageSize bounds the payload of the request messages of a
+	// streamed request
+	maxRequestMessageSize = 64 * 1024
+	bodyReadSize          = 32 * 1024
+
+	// bodyCoalesceTimeout is how long body reads are coalesced into one
+	// request message. Request bodies which end within the timeout, such as
+	// the bodies of unary RPCs, are sent to the gateway in a single message.
+	bodyCoalesceTimeout = 20 * time.Millisecond
+)
+
+// gatewayStream relays a request to a gateway and its responses back to the
+// client, message by message.
+type gatewayStream struct {
+	broker broker.GatewayRPCBroker
+	gwId   string
+	reqId  uint32
+
+	// responses buffers the responses from the broker, so that the broker
+	// doesn't block while responses are written to the client
+	responses chan *protos.SyncRPCResponse
+	// sendErrs receives the error if sending the request body fails
+	sendErrs chan *http2.HTTPGrpcError
+
+	sync.Mutex
+	// requestCredits is the number of request messages the gateway allows
+	// to be sent
+	requestCredits uint32
+	// creditsGranted is notified when the gateway grants request credits
+	creditsGranted chan struct{}
+
+	// The fields below are only accessed by the goroutine writing responses.
+	// nextSeq is the expected sequence number of the next response
+	nextSeq uint32
+	// unacked is the number of responses written to the client since the
+	// last window update
+	unacked       uint32
+	statusWritten bool
+}
+
+func newGatewayStream(broker broker.GatewayRPCBroker, gwId string, reqId uint32) *gatewayStream {
+	return &gatewayStream{
+		broker:         broker,
+		gwId:           gwId,
+		reqId:          reqId,
+		responses:      make(chan *protos.SyncRPCResponse, 2*responseWindow),
+		sendErrs:       make(chan *http2.HTTPGrpcError, 1),
+		creditsGranted: make(chan struct{}, 1),
+		nextSeq:        1,
+	}
+}
+
+// relayResponses buffers the responses from respChan until ctx is done.
+func (stream *gatewayStream) relayResponses(ctx context.Context, respChan chan *protos.SyncRPCResponse) {
+	for {
+		select {
+		case resp, ok := <-respChan:
+			if !ok {
+				return
+			}
+			select {
+			case stream.responses <- resp:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// processResponse writes a response message to the client, and keeps the
+// flow control windows of the stream up to date. It returns true once the
+// gateway has sent the last response message.
+func (stream *gatewayStream) processResponse(w http.ResponseWriter, resp *protos.SyncRPCResponse) (bool, *http2.HTTPGrpcError) {
+	if resp == nil {
+		return false, processResponse(w, nil, stream.statusWritten)
+	}
+	if resp.WindowUpdate > 0 {
+		stream.grantRequestCredits(resp.WindowUpdate)
+		if resp.RespBody == nil {
+			return false, nil
+		}
+	}
+	if resp.Seq > 0 {
+		if resp.Seq != stream.nextSeq {
+			errMsg := fmt.Sprintf("received response %d of request %d out of sequence; expected response %d", resp.Seq, stream.reqId, stream.nextSeq)
+			return true, http2.NewHTTPGrpcError(errMsg, int(codes.DataLoss), http.StatusInternalServerError)
+		}
+		stream.nextSeq++
+	}
+
+	err := processResponse(w, resp.RespBody, stream.statusWritten)
+	if err != nil {
+		return resp.EndOfStream, err
+	}
+	if resp.RespBody != nil && !resp.RespBody.KeepConnActive {
+		stream.statusWritten = true
+	}
+
+	// Only gateways which sequence their responses support window updates
+	if resp.Seq > 0 && !resp.EndOfStream {
+		stream.unacked++
+		if stream.unacked >= responseWindow/2 {
+			updateErr := stream.broker.UpdateResponseWindow(stream.gwId, stream.reqId, stream.unacked)
+			if updateErr != nil {
+				errMsg := fmt.Sprintf("err updating response window of request %d: %v", stream.reqId, updateErr)
+				return true, http2.NewHTTPGrpcError(errMsg, int(codes.Internal), http.StatusInternalServerError)
+			}
+			stream.unacked = 0
+		}
+	}
+	return resp.EndOfStream, nil
+}
+
+// sendBody sends the rest of the request body to the gateway, one request
+// message per request credit granted by the gateway.
+func (stream *gatewayStream) sendBody(ctx context.Context, body *bodyReader) {
+	for seq := uint32(2); ; seq++ {
+		if err := stream.awaitRequestCredit(ctx); err != nil {
+			return
+		}
+		payload, endOfStream, err := body.nextMessage(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				errMsg := fmt.Sprintf("err reading req body: %v", err)
+				stream.sendErr(http2.NewHTTPGrpcError(errMsg, int(codes.InvalidArgument), http.StatusBadRequest))
+			}
+			return
+		}
+		err = stream.broker.SendRequestMessage(stream.gwId, stream.reqId, seq, payload, endOfStream)
+		if err != nil {
+			errMsg := fmt.Sprintf("err sending message %d of request %d to gateway: %v", seq, stream.reqId, err)
+			stream.sendErr(http2.NewHTTPGrpcError(errMsg, int(codes.Internal), http.StatusInternalServerError))
+			return
+		}
+		if endOfStream {
+			return
+		}
+	}
+}
+
+func (stream *gatewayStream) sendErr(err *http2.HTTPGrpcError) {
+	select {
+	case stream.sendErrs <- err:
+	default:
+	}
+}
+
+func (stream *gatewayStream) grantRequestCredits(credits uint32) {
+	stream.Lock()
+	stream.requestCredits += credits
+	stream.Unlock()
+	select {
+	case stream.creditsGranted <- struct{}{}:
+	default:
+	}
+}
+
+// awaitRequestCredit takes a request credit, waiting for the gateway to grant
+// one if there are none left. Gateways which don't support streamed requests
+// never grant credits.
+func (stream *gatewayStream) awaitRequestCredit(ctx context.Context) error {
+	for {
+		stream.Lock()
+		if stream.requestCredits > 0 {
+			stream.requestCredits--
+			stream.Unlock()
+			return nil
+		}
+		stream.Unlock()
+		select {
+		case <-stream.creditsGranted:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// bodyReader reads a request body in the background, so that reads can be
+// coalesced into request messages.
+type bodyReader struct {
+	chunks chan []byte
+	// err is the error which ended the body, if it isn't io.EOF. It's set
+	// before chunks is closed.
+	err error
+}
+
+func newBodyReader(ctx context.Context, body io.ReadCloser) *bodyReader {
+	reader := &bodyReader{chunks: make(chan []byte)}
+	go func() {
+		defer body.Close()
+		for {
+			buf := make([]byte, bodyReadSize)
+			n, err := body.Read(buf)
+			if n > 0 {
+				select {
+				case reader.chunks <- buf[:n]:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					reader.err = err
+				}
+				close(reader.chunks)
+				return
+			}
+		}
+	}()
+	return reader
+}
+
+// nextMessage waits for the body to be readable, and returns what's read
+// before the reads pause for bodyCoalesceTimeout, up to about
+// maxRequestMessageSize. It also returns whether the body has ended.
+func (reader *bodyReader) nextMessage(ctx context.Context) ([]byte, bool, error) {
+	var payload []byte
+	select {
+	case chunk, ok := <-reader.chunks:
+		if !ok {
+			return nil, true, reader.err
+		}
+		payload = chunk
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	}
+
+	timer := time.NewTimer(bodyCoalesceTimeout)
+	defer timer.Stop()
+	for len(payload) < maxRequestMessageSize {
+		select {
+		case chunk, ok := <-reader.chunks:
+			if !ok {
+				return payload, true, reader.err
+			}
+			payload = append(payload, chunk...)
+		case <-timer.C:
+			return payload, false, nil
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+	return payload, false, nil
+}
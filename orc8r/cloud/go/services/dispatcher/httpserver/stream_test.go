@@ -0,0 +1,240 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package httpserver_test
+
+import (
+	"crypto/tls"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/dispatcher/broker"
+	"magma/orc8r/cloud/go/services/dispatcher/gateway_registry"
+	"magma/orc8r/cloud/go/services/dispatcher/httpserver"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+)
+
+const testGwId = "gw1"
+
+func startTestServer(t *testing.T) (string, *broker.GatewayRPCBrokerImpl) {
+	gateway_registry.ClearGwServices(t)
+	assert.NoError(t, gateway_registry.RegisterGwServices("test_service"))
+	gwBroker := broker.NewGatewayReqRespBroker()
+	server := httpserver.NewSyncRPCHttpServer(gwBroker)
+	listener, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	go server.Serve(listener)
+	return listener.Addr().String(), gwBroker
+}
+
+func newTestRequest(t *testing.T, addr string, body io.Reader) *http.Request {
+	req, err := http.NewRequest("POST", "http://"+addr+"/magma.TestService/Stream", body)
+	assert.NoError(t, err)
+	req.Host = "test_service"
+	req.Header.Set(gateway_registry.GatewayIdHeaderKey, testGwId)
+	return req
+}
+
+func newH2CClient() *http.Client {
+	return &http.Client{
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		},
+	}
+}
+
+func TestSyncRPCHttpServer_UnaryRequest(t *testing.T) {
+	addr, gwBroker := startTestServer(t)
+	defer gateway_registry.ClearGwServices(t)
+	queue := gwBroker.InitializeGateway(testGwId)
+
+	go func() {
+		req := receiveRequest(t, queue)
+		// Bodies which end right away are sent in a single message
+		assert.Equal(t, uint32(1), req.Seq)
+		assert.True(t, req.EndOfStream)
+		assert.Equal(t, []byte("ping"), req.ReqBody.Payload)
+		err := gwBroker.ProcessGatewayResponse(&protos.SyncRPCResponse{
+			ReqId:       req.ReqId,
+			Seq:         1,
+			EndOfStream: true,
+			RespBody:    &protos.GatewayResponse{Status: "200", Payload: []byte("pong"), Headers: map[string]string{"Grpc-Status": "0"}},
+		})
+		assert.NoError(t, err)
+	}()
+
+	resp, err := newH2CClient().Do(newTestRequest(t, addr, strings.NewReader("ping")))
+	assert.NoError(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "pong", string(body))
+	assert.Equal(t, "0", resp.Trailer.Get("Grpc-Status"))
+}
+
+func TestSyncRPCHttpServer_Streaming(t *testing.T) {
+	addr, gwBroker := startTestServer(t)
+	defer gateway_registry.ClearGwServices(t)
+	queue := gwBroker.InitializeGateway(testGwId)
+
+	// The client streams 3 request messages
+	bodyReader, bodyWriter := io.Pipe()
+	go func() {
+		for _, msg := range []string{"a", "b", "c"} {
+			bodyWriter.Write([]byte(msg))
+			time.Sleep(100 * time.Millisecond)
+		}
+		bodyWriter.Close()
+	}()
+
+	go func() {
+		req := receiveRequest(t, queue)
+		assert.Equal(t, uint32(1), req.Seq)
+		assert.False(t, req.EndOfStream)
+		assert.Equal(t, uint32(16), req.WindowUpdate)
+		assert.Equal(t, []byte("a"), req.ReqBody.Payload)
+		reqId := req.ReqId
+
+		// Request messages are only sent once the gateway grants credits
+		select {
+		case req := <-queue:
+			t.Errorf("received request message %v before granting credits", req)
+		case <-time.After(300 * time.Millisecond):
+		}
+		// Messages may be coalesced, so only the concatenated payload is
+		// deterministic
+		assert.NoError(t, gwBroker.ProcessGatewayResponse(&protos.SyncRPCResponse{ReqId: reqId, WindowUpdate: 10}))
+		payload := "a"
+		for seq := uint32(2); !req.EndOfStream; seq++ {
+			req = receiveRequest(t, queue)
+			assert.Equal(t, seq, req.Seq)
+			payload += string(req.ReqBody.Payload)
+		}
+		assert.Equal(t, "abc", payload)
+
+		// The gateway streams 9 response messages, and gets a window update
+		// after the first half of its window
+		for seq := uint32(1); seq <= 9; seq++ {
+			resp := &protos.SyncRPCResponse{
+				ReqId:    reqId,
+				Seq:      seq,
+				RespBody: &protos.GatewayResponse{Status: "200", Payload: []byte{'0' + byte(seq)}},
+			}
+			if seq == 9 {
+				resp.EndOfStream = true
+				resp.RespBody.Headers = map[string]string{"Grpc-Status": "0"}
+			}
+			assert.NoError(t, gwBroker.ProcessGatewayResponse(resp))
+		}
+		req = receiveRequest(t, queue)
+		assert.Equal(t, reqId, req.ReqId)
+		assert.Equal(t, uint32(0), req.Seq)
+		assert.Equal(t, uint32(8), req.WindowUpdate)
+	}()
+
+	resp, err := newH2CClient().Do(newTestRequest(t, addr, bodyReader))
+	assert.NoError(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789", string(body))
+	assert.Equal(t, "0", resp.Trailer.Get("Grpc-Status"))
+}
+
+func TestSyncRPCHttpServer_OutOfSequence(t *testing.T) {
+	addr, gwBroker := startTestServer(t)
+	defer gateway_registry.ClearGwServices(t)
+	queue := gwBroker.InitializeGateway(testGwId)
+
+	go func() {
+		req := receiveRequest(t, queue)
+		for _, seq := range []uint32{1, 3} {
+			resp := &protos.SyncRPCResponse{ReqId: req.ReqId, Seq: seq, RespBody: &protos.GatewayResponse{Status: "200"}}
+			assert.NoError(t, gwBroker.ProcessGatewayResponse(resp))
+		}
+	}()
+
+	resp, err := newH2CClient().Do(newTestRequest(t, addr, strings.NewReader("ping")))
+	assert.NoError(t, err)
+	_, err = ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "15", resp.Trailer.Get("Grpc-Status"))
+	assert.Contains(t, resp.Trailer.Get("Grpc-Message"), "received response 3 of request 1 out of sequence")
+}
+
+func TestSyncRPCHttpServer_QuietStream(t *testing.T) {
+	httpserver.ResponseIdleTimeout = 300 * time.Millisecond
+	defer func() { httpserver.ResponseIdleTimeout = 15 * time.Second }()
+	addr, gwBroker := startTestServer(t)
+	defer gateway_registry.ClearGwServices(t)
+	queue := gwBroker.InitializeGateway(testGwId)
+
+	// The stream stays quiet for longer than the idle timeout, but the
+	// gateway keeps it alive
+	go func() {
+		req := receiveRequest(t, queue)
+		resp := &protos.SyncRPCResponse{ReqId: req.ReqId, Seq: 1, RespBody: &protos.GatewayResponse{Status: "200", Payload: []byte("1")}}
+		assert.NoError(t, gwBroker.ProcessGatewayResponse(resp))
+		for i := 0; i < 10; i++ {
+			time.Sleep(100 * time.Millisecond)
+			keepalive := &protos.SyncRPCResponse{ReqId: req.ReqId, RespBody: &protos.GatewayResponse{KeepConnActive: true}}
+			assert.NoError(t, gwBroker.ProcessGatewayResponse(keepalive))
+		}
+		resp = &protos.SyncRPCResponse{
+			ReqId:       req.ReqId,
+			Seq:         2,
+			EndOfStream: true,
+			RespBody:    &protos.GatewayResponse{Status: "200", Payload: []byte("2"), Headers: map[string]string{"Grpc-Status": "0"}},
+		}
+		assert.NoError(t, gwBroker.ProcessGatewayResponse(resp))
+	}()
+
+	resp, err := newH2CClient().Do(newTestRequest(t, addr, strings.NewReader("ping")))
+	assert.NoError(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "12", string(body))
+	assert.Equal(t, "0", resp.Trailer.Get("Grpc-Status"))
+
+	// Without keepalives the stream times out
+	go func() {
+		// Skip the cancellation of the first request
+		req := receiveRequest(t, queue)
+		for req.ConnClosed {
+			req = receiveRequest(t, queue)
+		}
+		resp := &protos.SyncRPCResponse{ReqId: req.ReqId, Seq: 1, RespBody: &protos.GatewayResponse{Status: "200", Payload: []byte("1")}}
+		assert.NoError(t, gwBroker.ProcessGatewayResponse(resp))
+	}()
+
+	resp, err = newH2CClient().Do(newTestRequest(t, addr, strings.NewReader("ping")))
+	assert.NoError(t, err)
+	body, err = ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", string(body))
+	assert.Equal(t, "4", resp.Trailer.Get("Grpc-Status"))
+}
+
+func receiveRequest(t *testing.T, queue chan *protos.SyncRPCRequest) *protos.SyncRPCRequest {
+	select {
+	case req := <-queue:
+		return req
+	case <-time.After(3 * time.Second):
+		t.Error("timed out waiting for request")
+		return &protos.SyncRPCRequest{ReqBody: &protos.GatewayRequest{}, EndOfStream: true}
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package metrics contains the dispatcher's per-gateway SyncRPC stream
+// metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// StreamConnected is 1 while a gateway has a SyncRPC stream to this
+	// dispatcher instance
+	StreamConnected = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "dispatcher_gateway_stream_connected",
+			Help: "1 while the gateway has a SyncRPC stream to this dispatcher instance",
+		},
+		[]string{"gatewayId"},
+	)
+	StreamConnects = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dispatcher_gateway_stream_connects",
+			Help: "Number of SyncRPC streams the gateway has established with this dispatcher instance",
+		},
+		[]string{"gatewayId"},
+	)
+	StreamErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dispatcher_gateway_stream_errors",
+			Help: "Number of SyncRPC streams with the gateway which ended with an error",
+		},
+		[]string{"gatewayId"},
+	)
+	RequestsSent = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dispatcher_gateway_requests_sent",
+			Help: "Number of SyncRPC requests sent to the gateway",
+		},
+		[]string{"gatewayId"},
+	)
+	ResponsesReceived = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dispatcher_gateway_responses_received",
+			Help: "Number of SyncRPC responses received from the gateway",
+		},
+		[]string{"gatewayId"},
+	)
+	// RequestsHandedOff counts requests which weren't sent to the gateway
+	// before its stream closed, and were handed off to the shared broker
+	// store for another dispatcher instance to send
+	RequestsHandedOff = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dispatcher_gateway_requests_handed_off",
+			Help: "Number of unsent SyncRPC requests handed off to other dispatcher instances",
+		},
+		[]string{"gatewayId"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		StreamConnected,
+		StreamConnects,
+		StreamErrors,
+		RequestsSent,
+		ResponsesReceived,
+		RequestsHandedOff,
+	)
+}
@@ -0,0 +1,23 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package dispatcher
+
+import (
+	"magma/orc8r/cloud/go/services/dispatcher/broker/sqlstore"
+	"magma/orc8r/cloud/go/sqorc"
+)
+
+func init() {
+	sqorc.MustRegisterMigrations(sqorc.Migration{
+		Service:     ServiceName,
+		Version:     1,
+		Description: "create SyncRPC broker tables",
+		Up:          sqlstore.CreateTables,
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/directoryd"
 	"magma/orc8r/cloud/go/services/dispatcher/broker"
+	"magma/orc8r/cloud/go/services/dispatcher/metrics"
 
 	"github.com/golang/glog"
 	"golang.org/x/net/context"
@@ -32,10 +33,72 @@ type SyncRPCService struct {
 	// hostName is the host at which this service instance is running on
 	hostName string
 	broker   broker.GatewayRPCBroker
+
+	sync.Mutex
+	// streams are the coordinators of the active SyncRPC streams
+	streams map[*streamCoordinator]struct{}
+	// gatewayStreams counts the active streams of each gateway. A gateway
+	// can briefly have two streams while it reconnects.
+	gatewayStreams map[string]int
+	// streamsWg waits for all active SyncRPC streams to close
+	streamsWg sync.WaitGroup
+	// draining is closed once the service starts draining
+	draining  chan struct{}
+	drainOnce sync.Once
 }
 
 func NewSyncRPCService(hostName string, broker broker.GatewayRPCBroker) (*SyncRPCService, error) {
-	return &SyncRPCService{hostName: hostName, broker: broker}, nil
+	return &SyncRPCService{
+		hostName:       hostName,
+		broker:         broker,
+		streams:        map[*streamCoordinator]struct{}{},
+		gatewayStreams: map[string]int{},
+		draining:       make(chan struct{}),
+	}, nil
+}
+
+// Drain gracefully hands off the SyncRPC streams of this dispatcher instance
+// before it shuts down. New streams are rejected and requests stop being sent
+// to gateways. After gracePeriod, to let gateways respond to the requests
+// already sent to them, all streams are closed so that the gateways reconnect
+// to other instances. Requests which weren't sent are handed off to those
+// instances if the broker backend is shared between instances.
+//
+// Drain returns once all streams are closed, or with an error if ctx is done
+// first.
+func (srv *SyncRPCService) Drain(ctx context.Context, gracePeriod time.Duration) error {
+	srv.drainOnce.Do(func() { close(srv.draining) })
+	select {
+	case <-time.After(gracePeriod):
+	case <-ctx.Done():
+	}
+
+	srv.Lock()
+	for coordinator := range srv.streams {
+		coordinator.Cancel()
+	}
+	srv.Unlock()
+
+	closed := make(chan struct{})
+	go func() {
+		srv.streamsWg.Wait()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("SyncRPC streams didn't close before drain deadline: %v", ctx.Err())
+	}
+}
+
+func (srv *SyncRPCService) isDraining() bool {
+	select {
+	case <-srv.draining:
+		return true
+	default:
+		return false
+	}
 }
 
 // SyncRPC exists for backwards compatibility.
@@ -84,19 +147,54 @@ func newStreamCoordinator(gwId string, streamCtx context.Context) *streamCoordin
 // It is called directly by the test service.
 func (srv *SyncRPCService) serveGwId(stream protos.SyncRPCService_EstablishSyncRPCStreamServer, gwId string) error {
 	coordinator := newStreamCoordinator(gwId, stream.Context())
+	srv.Lock()
+	if srv.isDraining() {
+		srv.Unlock()
+		return status.Error(codes.Unavailable, "dispatcher instance is shutting down")
+	}
+	srv.streams[coordinator] = struct{}{}
+	srv.gatewayStreams[gwId]++
+	metrics.StreamConnected.WithLabelValues(gwId).Set(1)
+	srv.streamsWg.Add(1)
+	srv.Unlock()
+	defer func() {
+		srv.Lock()
+		delete(srv.streams, coordinator)
+		// Keep the gateway connected if it already reconnected
+		srv.gatewayStreams[gwId]--
+		if srv.gatewayStreams[gwId] <= 0 {
+			delete(srv.gatewayStreams, gwId)
+			metrics.StreamConnected.DeleteLabelValues(gwId)
+		}
+		srv.Unlock()
+		srv.streamsWg.Done()
+	}()
+
 	queue := srv.broker.InitializeGateway(gwId)
 	glog.V(2).Infof("Initialized gateway for hwId %v\n", gwId)
+	metrics.StreamConnects.WithLabelValues(gwId).Inc()
+
+	// Route requests to this instance right away rather than on the next
+	// heartbeat, in case the gateway moved here from another instance
+	err := directoryd.UpdateHostNameByHwId(gwId, srv.hostName)
+	if err != nil {
+		glog.Errorf("Failed to update hostname of hwId %v: %v\n", gwId, err)
+	}
 	coordinator.Wg.Add(1)
 	go srv.receiveFromStream(stream, coordinator)
 	coordinator.Wg.Add(1)
 	go srv.sendToStream(stream, queue, coordinator)
 
 	// Wait on err returned from either sendToStream or receiveFromStream goroutines.
-	err := <-coordinator.ErrChan
+	err = <-coordinator.ErrChan
 	if err == nil {
 		glog.V(2).Infof("SyncRPC return for %v due to client sending EOF\n", gwId)
+	} else if srv.isDraining() {
+		glog.Infof("SyncRPC stream for %v closed for drain\n", gwId)
+		err = status.Error(codes.Unavailable, "dispatcher instance is shutting down")
 	} else {
 		glog.Infof("SyncRPC error for %v: %v\n", gwId, err)
+		metrics.StreamErrors.WithLabelValues(gwId).Inc()
 	}
 	coordinator.Cancel()
 	coordinator.Wg.Wait()
@@ -114,11 +212,16 @@ func (srv *SyncRPCService) sendToStream(
 	coordinator *streamCoordinator,
 ) {
 	defer coordinator.Wg.Done()
+	draining := srv.draining
 	for {
 		select {
 		case <-coordinator.Ctx.Done():
 			coordinator.sendErrOrLog(fmt.Errorf("context cancelled in sendToStream: %v\n", coordinator.Ctx.Err()))
 			return
+		case <-draining:
+			// Stop sending requests so that they're handed off when the
+			// stream closes. Receiving from nil channels blocks forever.
+			draining, queue = nil, nil
 		case <-time.After(heartBeatInterval):
 			glog.V(2).Infof("sending heartBeat to hwId %v\n", coordinator.GwID)
 			err := stream.Send(&protos.SyncRPCRequest{HeartBeat: true})
@@ -138,6 +241,7 @@ func (srv *SyncRPCService) sendToStream(
 					coordinator.sendErrOrLog(fmt.Errorf("sendToStream err: %v\n", err))
 					return
 				}
+				metrics.RequestsSent.WithLabelValues(coordinator.GwID).Inc()
 			}
 		}
 	}
@@ -183,6 +287,7 @@ func (srv *SyncRPCService) processSyncRPCResp(resp *protos.SyncRPCResponse, hwId
 			return err
 		}
 	} else if resp.ReqId > 0 {
+		metrics.ResponsesReceived.WithLabelValues(hwId).Inc()
 		err := srv.broker.ProcessGatewayResponse(resp)
 		if err != nil {
 			// No need to end the stream, just log the error.
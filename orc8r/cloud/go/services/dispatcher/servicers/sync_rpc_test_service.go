@@ -15,7 +15,7 @@ import (
 
 // A little Go "polymorphism" magic for testing
 type testSyncRPCServer struct {
-	SyncRPCService
+	*SyncRPCService
 }
 
 const TestSyncRPCAgHwId = "Test-AGW-Hw-Id"
@@ -30,5 +30,9 @@ func (srv *testSyncRPCServer) EstablishSyncRPCStream(stream protos.SyncRPCServic
 }
 
 func NewTestSyncRPCServer(hostName string, broker broker.GatewayRPCBroker) (*testSyncRPCServer, error) {
-	return &testSyncRPCServer{SyncRPCService{hostName, broker}}, nil
+	srv, err := NewSyncRPCService(hostName, broker)
+	if err != nil {
+		return nil, err
+	}
+	return &testSyncRPCServer{srv}, nil
 }
@@ -17,6 +17,7 @@ import (
 
 	"github.com/golang/glog"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
 )
 
 func getGWMagmadClient(networkId string, gatewayId string) (protos.MagmadClient, context.Context, error) {
@@ -73,11 +74,16 @@ func GatewayGenericCommand(networkId string, gatewayId string, params *protos.Ge
 	return client.GenericCommand(ctx, params)
 }
 
-func TailGatewayLogs(networkId string, gatewayId string, service string) (protos.Magmad_TailLogsClient, error) {
-	client, ctx, err := getGWMagmadClient(networkId, gatewayId)
+// TailGatewayLogs streams the logs of a gateway service until the stream ends
+// or ctx is cancelled.
+func TailGatewayLogs(ctx context.Context, networkId string, gatewayId string, service string) (protos.Magmad_TailLogsClient, error) {
+	client, gwCtx, err := getGWMagmadClient(networkId, gatewayId)
 	if err != nil {
 		return nil, err
 	}
+	// Keep the gateway routing metadata, but cancel with ctx
+	md, _ := metadata.FromOutgoingContext(gwCtx)
+	ctx = metadata.NewOutgoingContext(ctx, md)
 
 	stream, err := client.TailLogs(ctx, &protos.TailLogsRequest{Service: service})
 	if err != nil {
@@ -34,6 +34,10 @@ func main() {
 	if err != nil {
 		glog.Fatalf("Failed to initialize datastore: %s", err)
 	}
+	err = ds.ApplyMigrations()
+	if err != nil {
+		glog.Fatalf("Failed to migrate datastore: %s", err)
+	}
 
 	// Add servicers to the service
 	magmadServer := servicers.NewMagmadConfigurator(ds)
@@ -9,6 +9,7 @@ LICENSE file in the root directory of this source tree.
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 	"sort"
 
 	"magma/orc8r/cloud/go/datastore"
+	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/obsidian/handlers"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/configurator"
@@ -109,7 +111,7 @@ func registerGateway(c echo.Context) error {
 		return handlers.HttpError(err, http.StatusConflict)
 	}
 
-	err = multiplexGatewayCreateIntoDeviceAndConfigurator(networkId, gatewayId, swaggerRecord)
+	err = multiplexGatewayCreateIntoDeviceAndConfigurator(c.Request().Context(), networkId, gatewayId, swaggerRecord)
 	if err != nil {
 		return handlers.HttpError(fmt.Errorf("Failed to multiplex write into configurator/device %v", err), http.StatusInternalServerError)
 	}
@@ -117,7 +119,7 @@ func registerGateway(c echo.Context) error {
 	return c.JSON(http.StatusCreated, gatewayId)
 }
 
-func multiplexGatewayCreateIntoDeviceAndConfigurator(networkID, gatewayID string, gwRecord *magmad_models.AccessGatewayRecord) error {
+func multiplexGatewayCreateIntoDeviceAndConfigurator(ctx context.Context, networkID, gatewayID string, gwRecord *magmad_models.AccessGatewayRecord) error {
 	err := configurator_utils.CreateNetworkIfNotExists(networkID)
 	if err != nil {
 		return err
@@ -133,7 +135,7 @@ func multiplexGatewayCreateIntoDeviceAndConfigurator(networkID, gatewayID string
 		Id:         gatewayID,
 		PhysicalId: gwRecord.HwID.ID,
 	}
-	_, err = configurator.CreateEntities(networkID, []*configuratorprotos.NetworkEntity{gwEntity})
+	_, err = configurator.CreateEntities(ctx, networkID, []*configuratorprotos.NetworkEntity{gwEntity})
 	if err != nil {
 		return err
 	}
@@ -155,6 +157,13 @@ func getGateway(c echo.Context) error {
 	if err != nil {
 		return err
 	}
+	gwEntity, err := loadGatewayEntity(networkId, lid)
+	if err != nil {
+		return handlers.HttpError(err, http.StatusInternalServerError)
+	}
+	if gwEntity != nil {
+		handlers.SetETag(c, gwEntity.Version)
+	}
 
 	return c.JSON(http.StatusOK, swaggerRecord)
 }
@@ -181,6 +190,10 @@ func updateGateway(c echo.Context) error {
 	if gerr != nil {
 		return gerr
 	}
+	expectedVersion, nerr := handlers.GetIfMatchVersion(c)
+	if nerr != nil {
+		return nerr
+	}
 
 	swaggerRecord := magmad_models.MutableGatewayRecord{}
 	if berr := c.Bind(&swaggerRecord); berr != nil {
@@ -196,12 +209,31 @@ func updateGateway(c echo.Context) error {
 	if berr != nil {
 		return handlers.HttpError(berr, http.StatusUnsupportedMediaType)
 	}
-	err := magmad.UpdateGatewayRecord(networkId, lid, &record)
+
+	// The versioned configurator write goes first so that a conflict leaves
+	// magmad and device untouched
+	entityExists, err := configurator.DoesEntityExist(networkId, configurator.GatewayEntityType, lid)
+	if err != nil {
+		return handlers.HttpError(err, http.StatusInternalServerError)
+	}
+	if entityExists {
+		err = updateGatewayName(c.Request().Context(), networkId, lid, swaggerRecord.Name, expectedVersion)
+		if err == merrors.ErrVersionConflict {
+			return handlers.HttpError(err, http.StatusConflict)
+		}
+		if err != nil {
+			return handlers.HttpError(fmt.Errorf("Failed to update gateway in configurator %v", err), http.StatusInternalServerError)
+		}
+	} else if expectedVersion != nil {
+		return handlers.HttpError(merrors.ErrVersionConflict, http.StatusConflict)
+	}
+
+	err = magmad.UpdateGatewayRecord(networkId, lid, &record)
 	if err != nil {
 		return handlers.HttpError(err, http.StatusConflict)
 	}
 
-	err = multiplexGatewayUpdateIntoDeviceAndConfigurator(networkId, lid, &swaggerRecord)
+	err = multiplexGatewayUpdateIntoDeviceAndConfigurator(c.Request().Context(), networkId, lid, &swaggerRecord, entityExists)
 	if err != nil {
 		return handlers.HttpError(fmt.Errorf("Failed to multiplex update into configurator/device %v", err), http.StatusInternalServerError)
 	}
@@ -209,11 +241,10 @@ func updateGateway(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
-func multiplexGatewayUpdateIntoDeviceAndConfigurator(networkID, gatewayID string, updateRecord *magmad_models.MutableGatewayRecord) error {
-	entityExists, err := configurator.DoesEntityExist(networkID, configurator.GatewayEntityType, gatewayID)
-	if err != nil {
-		return err
-	}
+// multiplexGatewayUpdateIntoDeviceAndConfigurator writes a gateway update to
+// device. The configurator entity is only written here if it doesn't exist
+// yet, as updates to existing entities are written before magmad.
+func multiplexGatewayUpdateIntoDeviceAndConfigurator(ctx context.Context, networkID, gatewayID string, updateRecord *magmad_models.MutableGatewayRecord, entityExists bool) error {
 	if !entityExists {
 		// fetch the existing gw record from magmad to get the HWID since it is needed for the device service
 		storedRecord, err := getSwaggerGWRecordFromMagmad(networkID, gatewayID)
@@ -222,13 +253,9 @@ func multiplexGatewayUpdateIntoDeviceAndConfigurator(networkID, gatewayID string
 		}
 		storedRecord.Name = updateRecord.Name
 		storedRecord.Key = updateRecord.Key
-		return multiplexGatewayCreateIntoDeviceAndConfigurator(networkID, gatewayID, storedRecord)
-	}
-	err = updateChallengeKey(networkID, gatewayID, updateRecord.Key)
-	if err != nil {
-		return err
+		return multiplexGatewayCreateIntoDeviceAndConfigurator(ctx, networkID, gatewayID, storedRecord)
 	}
-	return updateGatewayName(networkID, gatewayID, updateRecord.Name)
+	return updateChallengeKey(networkID, gatewayID, updateRecord.Key)
 }
 
 func updateChallengeKey(networkID, gatewayID string, challengeKey *magmad_models.ChallengeKey) error {
@@ -248,13 +275,14 @@ func updateChallengeKey(networkID, gatewayID string, challengeKey *magmad_models
 	return device.CreateOrUpdate(networkID, device.GatewayInfoType, deviceID, record)
 }
 
-func updateGatewayName(networkID, gatewayID, name string) error {
+func updateGatewayName(ctx context.Context, networkID, gatewayID, name string, expectedVersion *uint64) error {
 	updateRequest := &configuratorprotos.EntityUpdateCriteria{
-		Key:     gatewayID,
-		Type:    configurator.GatewayEntityType,
-		NewName: configuratorprotos.GetStringWrapper(&name),
+		Key:             gatewayID,
+		Type:            configurator.GatewayEntityType,
+		NewName:         configuratorprotos.GetStringWrapper(&name),
+		ExpectedVersion: configuratorprotos.GetUInt64Wrapper(expectedVersion),
 	}
-	_, err := configurator.UpdateEntities(networkID, []*configuratorprotos.EntityUpdateCriteria{updateRequest})
+	_, err := configurator.UpdateEntities(ctx, networkID, []*configuratorprotos.EntityUpdateCriteria{updateRequest})
 	return err
 }
 
@@ -267,30 +295,70 @@ func deleteGateway(c echo.Context) error {
 	if gerr != nil {
 		return gerr
 	}
+	expectedVersion, nerr := handlers.GetIfMatchVersion(c)
+	if nerr != nil {
+		return nerr
+	}
 
-	err := magmad.RemoveGateway(networkId, lid)
+	// The versioned configurator delete goes first so that a conflict leaves
+	// magmad and device untouched
+	gwEntity, err := loadGatewayEntity(networkId, lid)
 	if err != nil {
-		return handlers.HttpError(err, http.StatusNotFound)
+		return handlers.HttpError(err, http.StatusInternalServerError)
+	}
+	if gwEntity != nil {
+		err = deleteGatewayEntity(c.Request().Context(), networkId, lid, expectedVersion)
+		if err == merrors.ErrVersionConflict {
+			return handlers.HttpError(err, http.StatusConflict)
+		}
+		if err != nil {
+			return handlers.HttpError(fmt.Errorf("Failed to delete gateway from configurator %v", err), http.StatusInternalServerError)
+		}
+	} else if expectedVersion != nil {
+		return handlers.HttpError(merrors.ErrVersionConflict, http.StatusConflict)
 	}
 
-	err = multiplexGatewayDeleteIntoDeviceAndConfigurator(networkId, lid)
+	err = magmad.RemoveGateway(networkId, lid)
 	if err != nil {
-		glog.Errorf("Failed to multiplex delete into configurator/device %v", err)
+		return handlers.HttpError(err, http.StatusNotFound)
+	}
+
+	if gwEntity != nil {
+		err = device.DeleteDevices(networkId, []*deviceprotos.DeviceID{{DeviceID: gwEntity.PhysicalId, Type: device.GatewayInfoType}})
+		if err != nil {
+			glog.Errorf("Failed to multiplex delete into device %v", err)
+		}
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
-func multiplexGatewayDeleteIntoDeviceAndConfigurator(networkID, gatewayID string) error {
-	physicalID, err := configurator.GetPhysicalIDOfEntity(networkID, configurator.GatewayEntityType, gatewayID)
-	if err != nil {
-		return err
+func deleteGatewayEntity(ctx context.Context, networkID, gatewayID string, expectedVersion *uint64) error {
+	deleteRequest := &configuratorprotos.EntityUpdateCriteria{
+		Key:             gatewayID,
+		Type:            configurator.GatewayEntityType,
+		DeleteEntity:    true,
+		ExpectedVersion: configuratorprotos.GetUInt64Wrapper(expectedVersion),
 	}
-	err = device.DeleteDevices(networkID, []*deviceprotos.DeviceID{{DeviceID: physicalID, Type: device.GatewayInfoType}})
-	if err != nil {
-		return err
-	}
-	return configurator.DeleteEntities(networkID, []*configuratorprotos.EntityID{{Id: gatewayID, Type: configurator.GatewayEntityType}})
+	_, err := configurator.UpdateEntities(ctx, networkID, []*configuratorprotos.EntityUpdateCriteria{deleteRequest})
+	return err
+}
+
+// loadGatewayEntity loads the gateway's configurator entity, whose version is
+// used as the gateway's ETag. nil is returned if the gateway hasn't been
+// written to configurator.
+func loadGatewayEntity(networkID, gatewayID string) (*configuratorprotos.NetworkEntity, error) {
+	entities, _, err := configurator.LoadEntities(
+		networkID,
+		nil,
+		nil,
+		[]*configuratorprotos.EntityID{{Type: configurator.GatewayEntityType, Id: gatewayID}},
+		&configuratorprotos.EntityLoadCriteria{LoadMetadata: true},
+	)
+	if err != nil || len(entities) != 1 {
+		return nil, err
+	}
+	return entities[0], nil
 }
 
 func rebootGateway(c echo.Context) error {
@@ -426,14 +494,12 @@ func tailGatewayLogs(c echo.Context) error {
 		return handlers.HttpError(err, http.StatusBadRequest)
 	}
 
-	stream, err := magmad.TailGatewayLogs(networkId, gatewayId, request.Service)
+	// The stream is cancelled through the dispatcher when the client goes away
+	stream, err := magmad.TailGatewayLogs(c.Request().Context(), networkId, gatewayId, request.Service)
 	if err != nil {
 		return handlers.HttpError(err, http.StatusInternalServerError)
 	}
 
-	go func() {
-		<-c.Request().Context().Done()
-	}()
 	// https://echo.labstack.com/cookbook/streaming-response
 	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextPlainCharsetUTF8)
 	c.Response().Header().Set(echo.HeaderXContentTypeOptions, "nosniff")
@@ -11,8 +11,11 @@ package handlers_test
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/obsidian/handlers"
 	"magma/orc8r/cloud/go/obsidian/tests"
 	"magma/orc8r/cloud/go/plugin"
@@ -210,6 +213,48 @@ func TestMagmad(t *testing.T) {
 	}
 	tests.RunTest(t, getAGRecordTestCase)
 
+	// Test optimistic concurrency with ETag/If-Match
+	agUrl := fmt.Sprintf("%s/%s/gateways/TestAGHwId00002", testUrlRoot, networkId)
+	resp := doGatewayRequest(t, "GET", agUrl, "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	updatePayload := `{"name": "Tower 2", "key": {"key_type": "ECHO"}}`
+	resp = doGatewayRequest(t, "PUT", agUrl, updatePayload, etag)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = doGatewayRequest(t, "PUT", agUrl, `{"name": "Tower 3", "key": {"key_type": "ECHO"}}`, etag)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp = doGatewayRequest(t, "DELETE", agUrl, "", etag)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp = doGatewayRequest(t, "PUT", agUrl, updatePayload, "invalid")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	getAGRecordTestCase = tests.Testcase{
+		Name:     "Get AG Record After Conflicting Writes",
+		Method:   "GET",
+		Url:      agUrl,
+		Payload:  "",
+		Expected: `{"hw_id":{"id":"TestAGHwId00002"}, "key": {"key_type": "ECHO"}, "name": "Tower 2"}`,
+	}
+	tests.RunTest(t, getAGRecordTestCase)
+
+	// Deleting with the current ETag succeeds
+	tests.RunTest(t, tests.Testcase{
+		Name:     "Register AG For Conditional Delete",
+		Method:   "POST",
+		Url:      fmt.Sprintf("%s/%s/gateways", testUrlRoot, networkId),
+		Payload:  `{"hw_id":{"id":"TestAGHwIdDelete"}, "key": {"key_type": "ECHO"}}`,
+		Expected: `"TestAGHwIdDelete"`,
+	})
+	deleteUrl := fmt.Sprintf("%s/%s/gateways/TestAGHwIdDelete", testUrlRoot, networkId)
+	resp = doGatewayRequest(t, "GET", deleteUrl, "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = doGatewayRequest(t, "DELETE", deleteUrl, "", resp.Header.Get("ETag"))
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp = doGatewayRequest(t, "GET", deleteUrl, "", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
 	// Test Listing All Registered AGs
 	listAGsTestCase := tests.Testcase{
 		Name:                      "List Registered AGs",
@@ -440,3 +485,17 @@ func newDefaultGatewayConfig() *protos.MagmadGatewayConfig {
 		DynamicServices:         []string{},
 	}
 }
+
+func doGatewayRequest(t *testing.T, method, url, payload, ifMatch string) *http.Response {
+	req, err := http.NewRequest(method, url, strings.NewReader(payload))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(access.CLIENT_CERT_SN_KEY, tests.TestOperatorSerialNumber)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	return resp
+}
@@ -12,6 +12,7 @@ import (
 	"context"
 	"encoding/json"
 	"sync"
+	"time"
 
 	"magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/protos"
@@ -29,7 +30,19 @@ type StateValue struct {
 	Time uint64
 	// Cert expiration Time
 	CertExpirationTime int64
-	ReportedValue      []byte
+	// Time (in ms since epoch) after which the state is expired. 0 if the
+	// state never expires.
+	ExpirationTime uint64
+	ReportedValue  []byte
+}
+
+// IsExpired returns true if the state has a TTL which has elapsed at the
+// given time.
+func (value *StateValue) IsExpired(now time.Time) bool {
+	if value.ExpirationTime == 0 {
+		return false
+	}
+	return value.ExpirationTime <= uint64(now.UnixNano())/uint64(time.Millisecond)
 }
 
 // StateID contains the identifying information of a state
@@ -38,6 +51,12 @@ type StateID struct {
 	DeviceID string
 }
 
+// ReportedState is a state along with its identifying information
+type ReportedState struct {
+	ID    StateID
+	Value StateValue
+}
+
 // Global clientconn that can be reused for this service
 var connSingleton = (*grpc.ClientConn)(nil)
 var connGuard = sync.Mutex{}
@@ -117,6 +136,39 @@ func GetStates(networkID string, stateIDs []StateID) (map[StateID]StateValue, er
 	return idToValue, nil
 }
 
+// ListStates returns a page of the unexpired states of a type in a network,
+// optionally filtered to the states reported by reporterID. Pass an empty
+// pageToken to list the first page. The returned states are ordered by device
+// ID, and the returned token is empty once there are no more states to list.
+func ListStates(networkID string, typeVal string, reporterID string, pageSize uint32, pageToken string) ([]ReportedState, string, error) {
+	client, err := getStateClient()
+	if err != nil {
+		return nil, "", err
+	}
+
+	res, err := client.ListStates(
+		context.Background(),
+		&protos.ListStatesRequest{
+			NetworkID:      networkID,
+			TypeFilter:     typeVal,
+			ReporterFilter: reporterID,
+			PageSize:       pageSize,
+			PageToken:      pageToken,
+		},
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	states := make([]ReportedState, 0, len(res.States))
+	for _, state := range res.States {
+		reportedState := ReportedState{ID: StateID{Type: state.Type, DeviceID: state.DeviceID}}
+		json.Unmarshal(state.Value, &reportedState.Value)
+		states = append(states, reportedState)
+	}
+	return states, res.NextPageToken, nil
+}
+
 // DeleteStates deletes states specified by the networkID and a list of type and key
 func DeleteStates(networkID string, stateIDs []StateID) error {
 	client, err := getStateClient()
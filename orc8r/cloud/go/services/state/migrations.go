@@ -0,0 +1,18 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package state
+
+import (
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/sqorc"
+)
+
+func init() {
+	sqorc.MustRegisterMigrations(blobstore.NewSQLBlobStorageMigrations(ServiceName, DBTableName)...)
+}
@@ -9,17 +9,24 @@ LICENSE file in the root directory of this source tree.
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"magma/orc8r/cloud/go/obsidian/handlers"
 	"magma/orc8r/cloud/go/serde"
 	checkind_models "magma/orc8r/cloud/go/services/checkind/obsidian/models"
 	stateservice "magma/orc8r/cloud/go/services/state"
+	"magma/orc8r/cloud/go/services/state/obsidian/models"
 
 	"github.com/labstack/echo"
 )
 
-const AgStatusUrl = handlers.NETWORKS_ROOT + "/:network_id/gateways/:device_id/gateway_status"
+const (
+	AgStatusUrl   = handlers.NETWORKS_ROOT + "/:network_id/gateways/:device_id/gateway_status"
+	ListStatesUrl = handlers.NETWORKS_ROOT + "/:network_id/states"
+)
 
 // GetObsidianHandlers returns all handlers for state
 func GetObsidianHandlers() []handlers.Handler {
@@ -29,6 +36,11 @@ func GetObsidianHandlers() []handlers.Handler {
 			Methods:     handlers.GET,
 			HandlerFunc: AGStatusByDeviceIDHandler,
 		},
+		{
+			Path:        ListStatesUrl,
+			Methods:     handlers.GET,
+			HandlerFunc: ListStatesHandler,
+		},
 	}
 }
 
@@ -66,3 +78,58 @@ func GetGWStatus(networkID string, deviceID string) (*checkind_models.GatewaySta
 	gwStatus.FillDeprecatedFields()
 	return &gwStatus, nil
 }
+
+// ListStatesHandler lists a page of the states of a type in a network
+func ListStatesHandler(c echo.Context) error {
+	networkID, nerr := handlers.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+	stateType := c.QueryParam("type")
+	if stateType == "" {
+		return handlers.HttpError(fmt.Errorf("type query parameter must be specified"), http.StatusBadRequest)
+	}
+	pageSize := uint64(0)
+	if pageSizeParam := c.QueryParam("page_size"); pageSizeParam != "" {
+		var err error
+		pageSize, err = strconv.ParseUint(pageSizeParam, 10, 32)
+		if err != nil {
+			return handlers.HttpError(fmt.Errorf("invalid page_size parameter %s", pageSizeParam), http.StatusBadRequest)
+		}
+	}
+
+	states, nextPageToken, err := stateservice.ListStates(
+		networkID,
+		stateType,
+		c.QueryParam("reporter_id"),
+		uint32(pageSize),
+		c.QueryParam("page_token"),
+	)
+	if err != nil {
+		return handlers.HttpError(err, http.StatusInternalServerError)
+	}
+	ret := &models.StateList{States: make([]*models.ReportedState, 0, len(states)), NextPageToken: nextPageToken}
+	for _, state := range states {
+		ret.States = append(ret.States, toReportedStateModel(state))
+	}
+	return c.JSON(http.StatusOK, ret)
+}
+
+func toReportedStateModel(state stateservice.ReportedState) *models.ReportedState {
+	ret := &models.ReportedState{
+		Type:               state.ID.Type,
+		DeviceID:           state.ID.DeviceID,
+		ReporterID:         state.Value.ReporterID,
+		Time:               state.Value.Time,
+		CertExpirationTime: state.Value.CertExpirationTime,
+		ExpirationTime:     state.Value.ExpirationTime,
+	}
+	// Reported values are JSON-serialized, but fall back to the raw string
+	// rather than failing the whole listing on one malformed value
+	var value interface{}
+	if err := json.Unmarshal(state.Value.ReportedValue, &value); err != nil {
+		value = string(state.Value.ReportedValue)
+	}
+	ret.Value = value
+	return ret
+}
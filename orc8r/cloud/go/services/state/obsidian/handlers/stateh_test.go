@@ -8,6 +8,7 @@
 package handlers_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -20,6 +21,7 @@ import (
 	"magma/orc8r/cloud/go/services/magmad"
 	magmadProtos "magma/orc8r/cloud/go/services/magmad/protos"
 	magmadTestInit "magma/orc8r/cloud/go/services/magmad/test_init"
+	"magma/orc8r/cloud/go/services/state/obsidian/models"
 	stateTestInit "magma/orc8r/cloud/go/services/state/test_init"
 	"magma/orc8r/cloud/go/services/state/test_utils"
 
@@ -57,6 +59,7 @@ func TestState(t *testing.T) {
 
 	getStateNoError(t, restPort, testNetworkId)
 	getStateNotFoundError(t, restPort, testNetworkId)
+	listStatesNoError(t, restPort, testNetworkId)
 }
 
 func getURL(restPort int, networkID string, hwID string) string {
@@ -79,3 +82,23 @@ func getStateNotFoundError(t *testing.T, restPort int, networkID string) {
 	url := getURL(restPort, networkID, "should-not-exist")
 	test_utils.GetGWStatusExpectNotFound(t, url)
 }
+
+func listStatesNoError(t *testing.T, restPort int, networkID string) {
+	url := fmt.Sprintf("http://localhost:%d%s/networks/%s/states?type=gw_state", restPort, handlers.REST_ROOT, networkID)
+	status, response, err := tests.SendHttpRequest("GET", url, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 200, status)
+	stateList := &models.StateList{}
+	assert.NoError(t, json.Unmarshal([]byte(response), stateList))
+	assert.Empty(t, stateList.NextPageToken)
+	assert.Len(t, stateList.States, 1)
+	assert.Equal(t, "gw_state", stateList.States[0].Type)
+	assert.Equal(t, testAgHwId, stateList.States[0].DeviceID)
+	assert.Equal(t, testAgHwId, stateList.States[0].ReporterID)
+	assert.NotNil(t, stateList.States[0].Value)
+
+	url = fmt.Sprintf("http://localhost:%d%s/networks/%s/states", restPort, handlers.REST_ROOT, networkID)
+	status, _, err = tests.SendHttpRequest("GET", url, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 400, status)
+}
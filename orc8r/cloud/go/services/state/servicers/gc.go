@@ -0,0 +1,32 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"fmt"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+)
+
+// GarbageCollectStates deletes every stored state which has expired at the
+// given time, and returns the number of states deleted.
+func GarbageCollectStates(factory blobstore.BlobStorageFactory, now time.Time) (int64, error) {
+	store, err := factory.StartTransaction()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := store.DeleteExpired(now)
+	if err != nil {
+		store.Rollback()
+		return 0, fmt.Errorf("Error deleting expired states: %s", err)
+	}
+	return deleted, store.Commit()
+}
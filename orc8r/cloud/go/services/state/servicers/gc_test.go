@@ -0,0 +1,110 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/state"
+	"magma/orc8r/cloud/go/services/state/servicers"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestStateServicer_GetStatesExpired(t *testing.T) {
+	factory := blobstore.NewMemoryBlobStorageFactory()
+	now := time.Now()
+	writeStates(t, factory, "n1",
+		makeStateBlob(t, "t1", "k1", 0),
+		makeStateBlob(t, "t1", "k2", toMillis(now.Add(time.Hour))),
+		makeStateBlob(t, "t1", "k3", toMillis(now.Add(-time.Hour))),
+	)
+
+	srv, err := servicers.NewStateServicer(factory)
+	assert.NoError(t, err)
+	res, err := srv.GetStates(context.Background(), &protos.GetStatesRequest{
+		NetworkID: "n1",
+		Ids: []*protos.StateID{
+			{Type: "t1", DeviceID: "k1"},
+			{Type: "t1", DeviceID: "k2"},
+			{Type: "t1", DeviceID: "k3"},
+		},
+	})
+	assert.NoError(t, err)
+	actualKeys := []string{}
+	for _, st := range res.States {
+		actualKeys = append(actualKeys, st.DeviceID)
+	}
+	assert.ElementsMatch(t, []string{"k1", "k2"}, actualKeys)
+}
+
+func TestGarbageCollectStates(t *testing.T) {
+	factory := blobstore.NewMemoryBlobStorageFactory()
+	now := time.Now()
+	writeStates(t, factory, "n1",
+		makeStateBlob(t, "t1", "k1", 0),
+		makeStateBlob(t, "t1", "k2", toMillis(now.Add(-time.Minute))),
+		makeStateBlob(t, "t2", "k1", toMillis(now.Add(time.Minute))),
+	)
+	writeStates(t, factory, "n2",
+		makeStateBlob(t, "t1", "k1", toMillis(now.Add(-time.Hour))),
+	)
+
+	deleted, err := servicers.GarbageCollectStates(factory, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	store, err := factory.StartTransaction()
+	assert.NoError(t, err)
+	n1States, err := store.Search("n1", blobstore.SearchFilter{}, nil, 0)
+	assert.NoError(t, err)
+	n2States, err := store.Search("n2", blobstore.SearchFilter{}, nil, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+	assert.Equal(t, []string{"t1-k1", "t2-k1"}, getStateIDs(n1States))
+	assert.Empty(t, n2States)
+
+	// Nothing left to collect until the remaining TTL elapses
+	deleted, err = servicers.GarbageCollectStates(factory, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+	deleted, err = servicers.GarbageCollectStates(factory, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func makeStateBlob(t *testing.T, stateType string, key string, expirationTime uint64) blobstore.Blob {
+	value, err := json.Marshal(state.StateValue{ReporterID: "hw1", ExpirationTime: expirationTime, ReportedValue: []byte("{}")})
+	assert.NoError(t, err)
+	return blobstore.Blob{Type: stateType, Key: key, Value: value, ExpirationTime: expirationTime}
+}
+
+func writeStates(t *testing.T, factory blobstore.BlobStorageFactory, networkID string, blobs ...blobstore.Blob) {
+	store, err := factory.StartTransaction()
+	assert.NoError(t, err)
+	assert.NoError(t, store.CreateOrUpdate(networkID, blobs))
+	assert.NoError(t, store.Commit())
+}
+
+func getStateIDs(blobs []blobstore.Blob) []string {
+	ret := []string{}
+	for _, blob := range blobs {
+		ret = append(ret, blob.Type+"-"+blob.Key)
+	}
+	return ret
+}
+
+func toMillis(t time.Time) uint64 {
+	return uint64(t.UnixNano()) / uint64(time.Millisecond)
+}
@@ -10,6 +10,7 @@ package servicers
 
 import (
 	"errors"
+	"fmt"
 
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/serde"
@@ -24,9 +25,45 @@ func ValidateGetStatesRequest(req *protos.GetStatesRequest) error {
 	return nil
 }
 
-// ValidateReportStatesRequest checks that all required fields exist
+// ValidateListStatesRequest checks that all required fields exist
+func ValidateListStatesRequest(req *protos.ListStatesRequest) error {
+	if len(req.GetNetworkID()) == 0 {
+		return errors.New("Network ID must be specified")
+	}
+	if len(req.GetTypeFilter()) == 0 {
+		return errors.New("Type filter must be specified")
+	}
+	return nil
+}
+
+// ValidateReportStatesRequest checks that all required fields exist.
+// Individual states are validated by PartitionStatesBySerializability.
 func ValidateReportStatesRequest(req *protos.ReportStatesRequest) error {
-	return validateStates(req)
+	states := req.GetStates()
+	if states == nil || len(states) == 0 {
+		return errors.New("States value must be specified and non-empty")
+	}
+	return nil
+}
+
+// PartitionStatesBySerializability validates each reported state against the
+// serde registered for its type. It returns the valid states, and the IDs of
+// the invalid states along with the reason they're invalid.
+func PartitionStatesBySerializability(req *protos.ReportStatesRequest) ([]*protos.State, []*protos.IDAndError) {
+	validStates := []*protos.State{}
+	invalidStates := []*protos.IDAndError{}
+	for _, state := range req.GetStates() {
+		if err := validateState(state); err != nil {
+			invalidStates = append(invalidStates, &protos.IDAndError{
+				Type:     state.GetType(),
+				DeviceID: state.GetDeviceID(),
+				Error:    err.Error(),
+			})
+			continue
+		}
+		validStates = append(validStates, state)
+	}
+	return validStates, invalidStates
 }
 
 // ValidateDeleteStatesRequest checks that all required fields exist
@@ -37,20 +74,34 @@ func ValidateDeleteStatesRequest(req *protos.DeleteStatesRequest) error {
 	return nil
 }
 
-func validateStates(req *protos.ReportStatesRequest) error {
-	states := req.GetStates()
-	if states == nil || len(states) == 0 {
-		return errors.New("States value must be specified and non-empty")
+// validateState checks that the state has a registered type, and that its
+// value deserializes with the type's serde. If the deserialized model can
+// validate itself, it must also be valid.
+func validateState(state *protos.State) error {
+	if len(state.GetType()) == 0 || len(state.GetDeviceID()) == 0 {
+		return errors.New("State type and device ID must be specified")
+	}
+	if len(state.GetValue()) == 0 {
+		return errors.New("State value must be specified")
+	}
+	model, err := serde.Deserialize(stateservice.SerdeDomain, state.GetType(), state.GetValue())
+	if err != nil {
+		return fmt.Errorf("Failed to deserialize state of type %s: %s", state.GetType(), err)
 	}
-	for _, state := range states {
-		_, err := serde.Deserialize(stateservice.SerdeDomain, state.GetType(), state.GetValue())
-		if err != nil {
-			return err
+	if validatable, ok := model.(validatableModel); ok {
+		if err := validatable.ValidateModel(); err != nil {
+			return fmt.Errorf("Invalid state of type %s: %s", state.GetType(), err)
 		}
 	}
 	return nil
 }
 
+// validatableModel is implemented by state models which can validate their
+// own contents
+type validatableModel interface {
+	ValidateModel() error
+}
+
 func checkNonEmptyInput(networkID string, ids []*protos.StateID) error {
 	if len(networkID) == 0 {
 		return errors.New("Network ID must be specified")
@@ -9,6 +9,7 @@ LICENSE file in the root directory of this source tree.
 package servicers
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -16,25 +17,55 @@ import (
 	"magma/orc8r/cloud/go/blobstore"
 	"magma/orc8r/cloud/go/protos"
 	stateservice "magma/orc8r/cloud/go/services/state"
+	"magma/orc8r/cloud/go/storage"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	defaultListStatesPageSize = 100
+	maxListStatesPageSize     = 1000
+)
+
 type stateServicer struct {
 	factory blobstore.BlobStorageFactory
+	ttls    TTLConfig
+}
+
+// TTLConfig specifies the TTLs applied to reported states which don't
+// specify their own. A zero TTL means that states never expire.
+type TTLConfig struct {
+	// Default is the TTL for states of types which aren't in ByType
+	Default time.Duration
+	// ByType maps state types to their default TTL
+	ByType map[string]time.Duration
+}
+
+func (config TTLConfig) getTTL(stateType string) time.Duration {
+	if ttl, ok := config.ByType[stateType]; ok {
+		return ttl
+	}
+	return config.Default
 }
 
-// NewStateServicer returns a state server backed by storage passed in
+// NewStateServicer returns a state server backed by storage passed in.
+// Reported states only expire if the reporter specifies a TTL.
 func NewStateServicer(factory blobstore.BlobStorageFactory) (protos.StateServiceServer, error) {
+	return NewStateServicerWithTTLs(factory, TTLConfig{})
+}
+
+// NewStateServicerWithTTLs returns a state server backed by storage passed in,
+// which applies the given default TTLs to reported states.
+func NewStateServicerWithTTLs(factory blobstore.BlobStorageFactory, ttls TTLConfig) (protos.StateServiceServer, error) {
 	if factory == nil {
 		return nil, fmt.Errorf("Storage factory is nil")
 	}
-	return &stateServicer{factory}, nil
+	return &stateServicer{factory: factory, ttls: ttls}, nil
 }
 
-// GetStates retrieves states from blobstorage
+// GetStates retrieves states from blobstorage. Expired states are omitted.
 func (srv *stateServicer) GetStates(context context.Context, req *protos.GetStatesRequest) (*protos.GetStatesResponse, error) {
 	if err := ValidateGetStatesRequest(req); err != nil {
 		return nil, err
@@ -48,12 +79,70 @@ func (srv *stateServicer) GetStates(context context.Context, req *protos.GetStat
 	}
 	states, err := store.GetMany(req.GetNetworkID(), ids)
 	store.Commit()
+	states = filterExpiredStates(states, time.Now())
 	return &protos.GetStatesResponse{States: protos.BlobsToStates(states)}, nil
 }
 
-// ReportStates saves states into blobstorage
-func (srv *stateServicer) ReportStates(context context.Context, req *protos.ReportStatesRequest) (*protos.Void, error) {
-	ret := &protos.Void{}
+// ListStates lists the unexpired states of a type in a network, ordered by
+// device ID, one page at a time
+func (srv *stateServicer) ListStates(context context.Context, req *protos.ListStatesRequest) (*protos.ListStatesResponse, error) {
+	if err := ValidateListStatesRequest(req); err != nil {
+		return nil, err
+	}
+	lastKey, err := decodeListStatesPageToken(req.GetPageToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pageSize := getListStatesPageSize(req.GetPageSize())
+
+	store, err := srv.factory.StartTransaction()
+	if err != nil {
+		return nil, err
+	}
+	// Expired states don't count towards the page, so keep reading batches
+	// until the page is full or the states run out
+	typeFilter := req.GetTypeFilter()
+	filter := blobstore.SearchFilter{Type: &typeFilter}
+	if reporterFilter := req.GetReporterFilter(); reporterFilter != "" {
+		filter.Owner = &reporterFilter
+	}
+	var startAfter *storage.TypeAndKey
+	if lastKey != "" {
+		startAfter = &storage.TypeAndKey{Type: typeFilter, Key: lastKey}
+	}
+	now := time.Now()
+	states := []*protos.State{}
+	exhausted := false
+	for !exhausted && len(states) < pageSize {
+		blobs, err := store.Search(req.GetNetworkID(), filter, startAfter, uint64(pageSize))
+		if err != nil {
+			store.Rollback()
+			return nil, err
+		}
+		exhausted = len(blobs) < pageSize
+		for i, blob := range blobs {
+			startAfter = &storage.TypeAndKey{Type: blob.Type, Key: blob.Key}
+			if !isExpiredState(blob, now) {
+				states = append(states, protos.BlobsToStates([]blobstore.Blob{blob})...)
+			}
+			if len(states) == pageSize {
+				exhausted = exhausted && i == len(blobs)-1
+				break
+			}
+		}
+	}
+
+	ret := &protos.ListStatesResponse{States: states}
+	if !exhausted {
+		ret.NextPageToken = encodeListStatesPageToken(startAfter.Key)
+	}
+	return ret, store.Commit()
+}
+
+// ReportStates saves states into blobstorage. States which fail validation
+// are returned in the response rather than failing the whole request.
+func (srv *stateServicer) ReportStates(context context.Context, req *protos.ReportStatesRequest) (*protos.ReportStatesResponse, error) {
+	ret := &protos.ReportStatesResponse{}
 	if err := ValidateReportStatesRequest(req); err != nil {
 		return nil, err
 	}
@@ -71,7 +160,12 @@ func (srv *stateServicer) ReportStates(context context.Context, req *protos.Repo
 	certExpiry := protos.GetClientCertExpiration(context)
 	time := uint64(time.Now().UnixNano()) / uint64(time.Millisecond)
 
-	states, err := addWrapperAndMakeBlobs(req.States, hwID, time, certExpiry)
+	validStates, invalidStates := PartitionStatesBySerializability(req)
+	ret.UnreportedStates = invalidStates
+	if len(validStates) == 0 {
+		return ret, nil
+	}
+	states, err := srv.addWrapperAndMakeBlobs(validStates, hwID, time, certExpiry)
 	if err != nil {
 		return nil, err
 	}
@@ -109,25 +203,91 @@ func (srv *stateServicer) DeleteStates(context context.Context, req *protos.Dele
 	return ret, store.Commit()
 }
 
-func addAdditionalInfo(state *protos.State, hwID string, time uint64, certExpiry int64) ([]byte, error) {
+func addAdditionalInfo(state *protos.State, hwID string, reportTime uint64, certExpiry int64, expirationTime uint64) ([]byte, error) {
 	wrap := stateservice.StateValue{
 		ReporterID:         hwID,
-		Time:               time,
+		Time:               reportTime,
 		CertExpirationTime: certExpiry,
+		ExpirationTime:     expirationTime,
 		ReportedValue:      state.Value,
 	}
 	return json.Marshal(wrap)
 }
 
-func addWrapperAndMakeBlobs(states []*protos.State, hwID string, time uint64, certExpiry int64) ([]blobstore.Blob, error) {
+// getExpirationTime returns the time in ms at which a state reported at
+// reportTime expires, or 0 if it never expires. The TTL reported with the
+// state takes precedence over the default TTL for its type.
+func (srv *stateServicer) getExpirationTime(state *protos.State, reportTime uint64) uint64 {
+	ttl := time.Duration(state.GetTtlSec()) * time.Second
+	if ttl == 0 {
+		ttl = srv.ttls.getTTL(state.GetType())
+	}
+	if ttl <= 0 {
+		return 0
+	}
+	return reportTime + uint64(ttl/time.Millisecond)
+}
+
+func (srv *stateServicer) addWrapperAndMakeBlobs(states []*protos.State, hwID string, time uint64, certExpiry int64) ([]blobstore.Blob, error) {
 	blobs := []blobstore.Blob{}
 	for _, state := range states {
-		wrappedValue, err := addAdditionalInfo(state, hwID, time, certExpiry)
+		// The expiration time and reporter are also stored with the blob, so
+		// that states can be garbage collected and listed by reporter without
+		// unwrapping them
+		expirationTime := srv.getExpirationTime(state, time)
+		wrappedValue, err := addAdditionalInfo(state, hwID, time, certExpiry, expirationTime)
 		if err != nil {
 			return nil, err
 		}
 		state.Value = wrappedValue
-		blobs = append(blobs, state.ToBlob())
+		blob := state.ToBlob()
+		blob.ExpirationTime = expirationTime
+		blob.Owner = hwID
+		blobs = append(blobs, blob)
 	}
 	return blobs, nil
 }
+
+// filterExpiredStates returns the state blobs which haven't expired at the
+// given time. Blobs which can't be unwrapped are kept.
+func filterExpiredStates(blobs []blobstore.Blob, now time.Time) []blobstore.Blob {
+	ret := make([]blobstore.Blob, 0, len(blobs))
+	for _, blob := range blobs {
+		if !isExpiredState(blob, now) {
+			ret = append(ret, blob)
+		}
+	}
+	return ret
+}
+
+func isExpiredState(blob blobstore.Blob, now time.Time) bool {
+	value := &stateservice.StateValue{}
+	if err := json.Unmarshal(blob.Value, value); err != nil {
+		return false
+	}
+	return value.IsExpired(now)
+}
+
+func getListStatesPageSize(requested uint32) int {
+	switch {
+	case requested == 0:
+		return defaultListStatesPageSize
+	case requested > maxListStatesPageSize:
+		return maxListStatesPageSize
+	default:
+		return int(requested)
+	}
+}
+
+// List page tokens are the encoded device ID of the last state examined
+func encodeListStatesPageToken(lastKey string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(lastKey))
+}
+
+func decodeListStatesPageToken(token string) (string, error) {
+	lastKey, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", fmt.Errorf("Invalid page token %s", token)
+	}
+	return string(lastKey), nil
+}
@@ -10,12 +10,15 @@ package main
 
 import (
 	"database/sql"
+	"flag"
+	"time"
 
 	"magma/orc8r/cloud/go/blobstore"
 	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/service"
+	"magma/orc8r/cloud/go/service/config"
 	"magma/orc8r/cloud/go/services/state"
 	"magma/orc8r/cloud/go/services/state/servicers"
 	"magma/orc8r/cloud/go/sqorc"
@@ -23,6 +26,15 @@ import (
 	"github.com/golang/glog"
 )
 
+const (
+	defaultTTLParam = "default_ttl_sec"
+	ttlsByTypeParam = "ttl_sec_by_type"
+)
+
+var (
+	gcMinutes = flag.Int64("gc-minutes", 10, "Expired state garbage collection time interval (in minutes)")
+)
+
 func main() {
 	srv, err := service.NewOrchestratorService(orc8r.ModuleName, state.ServiceName)
 	if err != nil {
@@ -34,18 +46,61 @@ func main() {
 		glog.Fatalf("Failed to connect to database: %s", err)
 	}
 	store := blobstore.NewSQLBlobStorageFactory(state.DBTableName, db, sqorc.GetSqlBuilder())
-	err = store.InitializeFactory()
+	err = sqorc.ApplyServiceMigrations(db, sqorc.GetSqlBuilder(), state.ServiceName)
+	if err == sqorc.ErrMigrationsUnsupported {
+		err = store.InitializeFactory()
+	}
 	if err != nil {
 		glog.Fatalf("Error initializing state database: %s", err)
 	}
 
-	server, err := servicers.NewStateServicer(store)
+	server, err := servicers.NewStateServicerWithTTLs(store, getTTLConfig(srv.Config))
 	if err != nil {
 		glog.Fatalf("Error creating state server: %s", err)
 	}
 	protos.RegisterStateServiceServer(srv.GrpcServer, server)
+
+	// Start expired state garbage collector ticker
+	gc := time.Tick(time.Minute * time.Duration(*gcMinutes))
+	go func() {
+		for now := range gc {
+			deleted, err := servicers.GarbageCollectStates(store, now)
+			if err != nil {
+				glog.Errorf("Failed to garbage collect expired states: %s", err)
+				continue
+			}
+			glog.V(2).Infof("%v - Deleted %d expired states", now, deleted)
+		}
+	}()
+
 	err = srv.Run()
 	if err != nil {
 		glog.Fatalf("Error running service: %s", err)
 	}
 }
+
+// getTTLConfig reads the default state TTLs from the service config. States
+// never expire by default.
+func getTTLConfig(serviceConfig *config.ConfigMap) servicers.TTLConfig {
+	ret := servicers.TTLConfig{ByType: map[string]time.Duration{}}
+	if serviceConfig == nil {
+		return ret
+	}
+	if defaultTTL, err := serviceConfig.GetIntParam(defaultTTLParam); err == nil {
+		ret.Default = time.Duration(defaultTTL) * time.Second
+	}
+	ttlsByType, ok := serviceConfig.RawMap[ttlsByTypeParam].(map[interface{}]interface{})
+	if !ok {
+		return ret
+	}
+	for stateType, ttl := range ttlsByType {
+		stateTypeStr, typeOK := stateType.(string)
+		ttlInt, ttlOK := ttl.(int)
+		if !typeOK || !ttlOK {
+			glog.Errorf("Invalid state TTL %v: %v", stateType, ttl)
+			continue
+		}
+		ret.ByType[stateTypeStr] = time.Duration(ttlInt) * time.Second
+	}
+	return ret
+}
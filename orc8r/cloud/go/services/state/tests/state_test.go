@@ -89,6 +89,48 @@ func TestStateService(t *testing.T) {
 	assert.NoError(t, err)
 	testGetStatesResponse(t, states, bundle2)
 
+	// Report a batch with an unknown type and a malformed value. The valid
+	// state in the batch is still saved.
+	bundle3 := makeStateBundle(typeName, "key3", Name{Name: "name3"})
+	unknownTypeBundle := makeStateBundle("unknown_type", "key4", value0)
+	malformedBundle := stateBundle{
+		state: &protos.State{Type: typeName, DeviceID: "key5", Value: []byte("{not json")},
+		ID:    state.StateID{Type: typeName, DeviceID: "key5"},
+	}
+	res, err := reportStatesWithResponse(ctx, bundle3, unknownTypeBundle, malformedBundle)
+	assert.NoError(t, err)
+	assert.Len(t, res.UnreportedStates, 2)
+	assert.Equal(t, "unknown_type", res.UnreportedStates[0].Type)
+	assert.Equal(t, "key4", res.UnreportedStates[0].DeviceID)
+	assert.NotEmpty(t, res.UnreportedStates[0].Error)
+	assert.Equal(t, typeName, res.UnreportedStates[1].Type)
+	assert.Equal(t, "key5", res.UnreportedStates[1].DeviceID)
+	assert.NotEmpty(t, res.UnreportedStates[1].Error)
+	states, err = state.GetStates(networkID, []state.StateID{bundle3.ID, unknownTypeBundle.ID, malformedBundle.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(states))
+	testGetStatesResponse(t, states, bundle3)
+	err = state.DeleteStates(networkID, []state.StateID{bundle3.ID})
+	assert.NoError(t, err)
+
+	// List a page at a time
+	listed, nextPageToken, err := state.ListStates(networkID, typeName, "", 2, "")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, nextPageToken)
+	testListStatesResponse(t, listed, bundle0, bundle1)
+	listed, nextPageToken, err = state.ListStates(networkID, typeName, "", 2, nextPageToken)
+	assert.NoError(t, err)
+	assert.Empty(t, nextPageToken)
+	testListStatesResponse(t, listed, bundle2)
+
+	// Filter by reporter
+	listed, _, err = state.ListStates(networkID, typeName, testAgHwId, 0, "")
+	assert.NoError(t, err)
+	testListStatesResponse(t, listed, bundle0, bundle1, bundle2)
+	listed, _, err = state.ListStates(networkID, typeName, "other-hw-id", 0, "")
+	assert.NoError(t, err)
+	assert.Empty(t, listed)
+
 	// Delete and read back
 	err = state.DeleteStates(networkID, []state.StateID{bundle0.ID, bundle2.ID})
 	assert.NoError(t, err)
@@ -143,12 +185,16 @@ func getClient() (protos.StateServiceClient, error) {
 }
 
 func reportStates(ctx context.Context, bundles ...stateBundle) error {
+	_, err := reportStatesWithResponse(ctx, bundles...)
+	return err
+}
+
+func reportStatesWithResponse(ctx context.Context, bundles ...stateBundle) (*protos.ReportStatesResponse, error) {
 	client, err := getClient()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	_, err = client.ReportStates(ctx, makeReportStatesRequest(bundles))
-	return err
+	return client.ReportStates(ctx, makeReportStatesRequest(bundles))
 }
 
 func testGetStatesResponse(t *testing.T, states map[state.StateID]state.StateValue, bundles ...stateBundle) {
@@ -158,6 +204,15 @@ func testGetStatesResponse(t *testing.T, states map[state.StateID]state.StateVal
 	}
 }
 
+func testListStatesResponse(t *testing.T, states []state.ReportedState, bundles ...stateBundle) {
+	assert.Equal(t, len(bundles), len(states))
+	for i, bundle := range bundles {
+		assert.Equal(t, bundle.ID, states[i].ID)
+		assert.Equal(t, testAgHwId, states[i].Value.ReporterID)
+		assert.Equal(t, bundle.state.Value, states[i].Value.ReportedValue)
+	}
+}
+
 func makeReportStatesRequest(bundles []stateBundle) *protos.ReportStatesRequest {
 	res := protos.ReportStatesRequest{}
 	res.States = makeStates(bundles)
@@ -9,14 +9,18 @@ LICENSE file in the root directory of this source tree.
 package factory
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"sort"
 	"sync"
 	"testing"
 	"time"
 
 	"magma/orc8r/cloud/go/protos"
 
-	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/any"
 )
 
@@ -79,7 +83,7 @@ func CreateMconfig(networkId string, gatewayId string) (*protos.GatewayConfigs,
 				return nil, fmt.Errorf("mconfig builder returned result for duplicate key %s", k)
 			}
 
-			vAny, err := ptypes.MarshalAny(v)
+			vAny, err := marshalAnyDeterministic(v)
 			if err != nil {
 				return nil, fmt.Errorf("Error marshaling builder value to Any: %s", err)
 			}
@@ -90,10 +94,46 @@ func CreateMconfig(networkId string, gatewayId string) (*protos.GatewayConfigs,
 		ConfigsByKey: ret,
 		Metadata: &protos.GatewayConfigsMetadata{
 			CreatedAt: uint64(factory.clock.Now().Unix()),
+			Digest:    &protos.GatewayConfigsDigest{HexDigest: GetConfigsDigest(ret)},
 		},
 	}, nil
 }
 
+// GetConfigsDigest returns a stable hex-encoded SHA-256 digest of a gateway's
+// configs, which only changes when the content of the configs changes.
+func GetConfigsDigest(configsByKey map[string]*any.Any) string {
+	keys := make([]string, 0, len(configsByKey))
+	for key := range configsByKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Length-prefix each field so the encoding is unambiguous
+	hash := sha256.New()
+	lenBuf := make([]byte, 8)
+	for _, key := range keys {
+		config := configsByKey[key]
+		for _, field := range [][]byte{[]byte(key), []byte(config.GetTypeUrl()), config.GetValue()} {
+			binary.BigEndian.PutUint64(lenBuf, uint64(len(field)))
+			hash.Write(lenBuf)
+			hash.Write(field)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// marshalAnyDeterministic is ptypes.MarshalAny, except that map fields are
+// marshaled in key order so the same config always has the same bytes
+func marshalAnyDeterministic(msg proto.Message) (*any.Any, error) {
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	err := buf.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &any.Any{TypeUrl: "type.googleapis.com/" + proto.MessageName(msg), Value: buf.Bytes()}, nil
+}
+
 // Methods below exist ONLY for testing - thus the required but unused *testing.T param
 // DO NOT USE IN ANYTHING BUT TESTS
 func ClearMconfigBuilders(_ *testing.T) {
@@ -75,9 +75,34 @@ func TestCreateMconfig(t *testing.T) {
 		ConfigsByKey: expectedAny,
 		Metadata: &protos.GatewayConfigsMetadata{
 			CreatedAt: 1551916956,
+			Digest:    &protos.GatewayConfigsDigest{HexDigest: GetConfigsDigest(expectedAny)},
 		},
 	}
 	assert.Equal(t, *expected, *actual)
+
+	// Digest only depends on the content of the configs
+	factory.clock = &mockClock{now: time.Unix(1551917000, 0)}
+	rebuilt, err := CreateMconfig("foo", "bar")
+	assert.NoError(t, err)
+	assert.Equal(t, actual.Metadata.Digest, rebuilt.Metadata.Digest)
+
+	builder2.result = map[string]proto.Message{
+		"builder2_1": &test_protos.Message1{Field: "bar"},
+	}
+	changed, err := CreateMconfig("foo", "bar")
+	assert.NoError(t, err)
+	assert.NotEqual(t, actual.Metadata.Digest, changed.Metadata.Digest)
+}
+
+func TestGetConfigsDigest(t *testing.T) {
+	empty := GetConfigsDigest(map[string]*any.Any{})
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", empty)
+
+	// Moving bytes between the key, type URL and value changes the digest
+	digest1 := GetConfigsDigest(map[string]*any.Any{"ab": {TypeUrl: "c", Value: []byte("d")}})
+	digest2 := GetConfigsDigest(map[string]*any.Any{"a": {TypeUrl: "bc", Value: []byte("d")}})
+	assert.NotEqual(t, digest1, digest2)
+	assert.NotEqual(t, empty, digest1)
 }
 
 func TestCreateMconfig_DuplicateKey(t *testing.T) {
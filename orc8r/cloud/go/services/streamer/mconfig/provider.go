@@ -14,6 +14,7 @@ import (
 	"magma/orc8r/cloud/go/services/streamer/mconfig/factory"
 	"magma/orc8r/cloud/go/services/streamer/providers"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
 )
 
@@ -22,12 +23,18 @@ func GetProvider() providers.StreamProvider {
 	return &ConfigProvider{}
 }
 
+// ConfigProvider streams the mconfig of a gateway. Mconfigs are rebuilt on
+// every request since the builders read from sources other than configurator
+// which have no version to cache against.
 type ConfigProvider struct{}
 
 func (provider *ConfigProvider) GetStreamName() string {
 	return "configs"
 }
 
+// GetUpdates returns the gateway's mconfig. If extraArgs holds the
+// GatewayConfigsDigest of the mconfig the gateway already has and the mconfig
+// hasn't changed, no updates are returned.
 func (provider *ConfigProvider) GetUpdates(gatewayId string, extraArgs *any.Any) ([]*protos.DataUpdate, error) {
 	networkId, err := magmad.FindGatewayNetworkId(gatewayId)
 	if err != nil {
@@ -43,6 +50,14 @@ func (provider *ConfigProvider) GetUpdates(gatewayId string, extraArgs *any.Any)
 		return nil, err
 	}
 
+	if extraArgs != nil {
+		gatewayDigest := &protos.GatewayConfigsDigest{}
+		if err := ptypes.UnmarshalAny(extraArgs, gatewayDigest); err == nil &&
+			gatewayDigest.HexDigest == gatewayConfig.GetMetadata().GetDigest().GetHexDigest() {
+			return []*protos.DataUpdate{}, nil
+		}
+	}
+
 	marshaledConfig, err := protos.MarshalIntern(gatewayConfig)
 	if err != nil {
 		return nil, err
@@ -114,6 +114,7 @@ func TestMconfigStreamer(t *testing.T) {
 		ConfigsByKey: expected,
 		Metadata: &protos.GatewayConfigsMetadata{
 			CreatedAt: 1551916956,
+			Digest:    &protos.GatewayConfigsDigest{HexDigest: factory.GetConfigsDigest(expected)},
 		},
 	})
 	assert.NoError(t, err)
@@ -146,3 +147,58 @@ func TestMconfigStreamer(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "rpc error: code = Aborted desc = Error while streaming updates: MOCK ERROR", err.Error())
 }
+
+func TestMconfigProvider_Digest(t *testing.T) {
+	magmad_test_init.StartTestService(t)
+
+	testNetworkId, err := magmad.RegisterNetwork(
+		&magmad_protos.MagmadNetworkRecord{Name: "Test Network 2"},
+		"mconfig_digest_test_network")
+	assert.NoError(t, err)
+	hwId := protos.AccessGatewayID{Id: testAgHwId + "digest"}
+	gwId, err := magmad.RegisterGateway(testNetworkId, &magmad_protos.AccessGatewayRecord{HwId: &hwId, Name: "bla"})
+	assert.NoError(t, err)
+
+	builder := &mockMconfigBuilder{
+		retVal: map[string]proto.Message{
+			"builder1_1": &test_protos.Message1{Field: "hello"},
+		},
+	}
+	factory.ClearMconfigBuilders(t)
+	factory.RegisterMconfigBuilder(builder)
+	provider := &mconfig_provider.ConfigProvider{}
+
+	updates, err := provider.GetUpdates(hwId.Id, nil)
+	assert.NoError(t, err)
+	assert.Len(t, updates, 1)
+	assert.Equal(t, gwId, updates[0].Key)
+	config := &protos.GatewayConfigs{}
+	assert.NoError(t, protos.Unmarshal(updates[0].Value, config))
+	digest := config.Metadata.Digest
+	assert.NotEmpty(t, digest.HexDigest)
+
+	// Gateway already has the current config
+	digestArgs, err := ptypes.MarshalAny(digest)
+	assert.NoError(t, err)
+	updates, err = provider.GetUpdates(hwId.Id, digestArgs)
+	assert.NoError(t, err)
+	assert.Empty(t, updates)
+
+	// Gateway has an outdated config
+	staleArgs, err := ptypes.MarshalAny(&protos.GatewayConfigsDigest{HexDigest: "stale"})
+	assert.NoError(t, err)
+	updates, err = provider.GetUpdates(hwId.Id, staleArgs)
+	assert.NoError(t, err)
+	assert.Len(t, updates, 1)
+
+	// The gateway gets the new config as soon as a builder's output changes
+	builder.retVal = map[string]proto.Message{
+		"builder1_1": &test_protos.Message1{Field: "world"},
+	}
+	updates, err = provider.GetUpdates(hwId.Id, digestArgs)
+	assert.NoError(t, err)
+	assert.Len(t, updates, 1)
+	config = &protos.GatewayConfigs{}
+	assert.NoError(t, protos.Unmarshal(updates[0].Value, config))
+	assert.NotEqual(t, digest.HexDigest, config.Metadata.Digest.HexDigest)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package providers
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"sort"
+	"sync"
+
+	"magma/orc8r/cloud/go/protos"
+
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+// DeltaStreamProvider is an optional interface for stream providers which
+// can stream incremental updates to gateways which request them.
+type DeltaStreamProvider interface {
+	StreamProvider
+
+	// GetUpdatesSince returns the changes to a gateway's stream since the
+	// version of the data last received by the gateway. lastVersion is empty
+	// if the gateway has no data yet.
+	GetUpdatesSince(gatewayId string, lastVersion string, extraArgs *any.Any) (*StreamDelta, error)
+}
+
+// StreamDelta is the set of changes to a stream since a version
+type StreamDelta struct {
+	// Updates are the added and changed keys, or all keys if Resync is true
+	Updates []*protos.DataUpdate
+	// DeletedKeys are the keys deleted since the version
+	DeletedKeys []string
+	// Version is the version of the data after applying this delta
+	Version string
+	// Resync is true if the changes since the version couldn't be computed,
+	// in which case Updates holds the full dataset
+	Resync bool
+}
+
+// IsEmpty returns true if the delta contains no changes
+func (delta *StreamDelta) IsEmpty() bool {
+	return !delta.Resync && len(delta.Updates) == 0 && len(delta.DeletedKeys) == 0
+}
+
+// MaxDeltaSnapshots is the max number of datasets kept in memory per
+// provider returned by NewDigestDeltaProvider. The datasets of the gateways
+// which least recently requested updates are evicted first.
+var MaxDeltaSnapshots = 10000
+
+// NewDigestDeltaProvider returns a DeltaStreamProvider which computes deltas
+// for a provider that only returns full datasets. The version of a dataset is
+// its digest, and the dataset last sent to each gateway is kept in memory to
+// diff against, up to MaxDeltaSnapshots datasets. If that dataset is
+// unavailable, e.g. after a restart or an eviction, a full resync is sent
+// unless the dataset hasn't changed.
+func NewDigestDeltaProvider(provider StreamProvider) DeltaStreamProvider {
+	return &digestDeltaProvider{
+		StreamProvider: provider,
+		snapshots:      map[string]*list.Element{},
+		lru:            list.New(),
+	}
+}
+
+type digestDeltaProvider struct {
+	StreamProvider
+
+	sync.Mutex
+	// snapshots holds the element of lru with the last dataset sent to each
+	// gateway, keyed by gateway hardware ID
+	snapshots map[string]*list.Element
+	// lru orders the snapshots from most to least recently used
+	lru *list.List
+}
+
+type streamSnapshot struct {
+	gatewayId string
+	version   string
+	values    map[string][]byte
+}
+
+func (provider *digestDeltaProvider) GetUpdatesSince(gatewayId string, lastVersion string, extraArgs *any.Any) (*StreamDelta, error) {
+	updates, err := provider.GetUpdates(gatewayId, extraArgs)
+	if err != nil {
+		return nil, err
+	}
+	current := newStreamSnapshot(gatewayId, updates)
+	previous, hasPrevious := provider.swapSnapshot(current)
+
+	switch {
+	case lastVersion != "" && lastVersion == current.version:
+		return &StreamDelta{Version: current.version}, nil
+	case lastVersion != "" && hasPrevious && lastVersion == previous.version:
+		delta := diffSnapshots(previous, current)
+		delta.Version = current.version
+		return delta, nil
+	default:
+		return &StreamDelta{Updates: updates, Version: current.version, Resync: true}, nil
+	}
+}
+
+// swapSnapshot stores the gateway's current snapshot and returns the previous
+// one, evicting the least recently used snapshots beyond MaxDeltaSnapshots
+func (provider *digestDeltaProvider) swapSnapshot(current *streamSnapshot) (*streamSnapshot, bool) {
+	provider.Lock()
+	defer provider.Unlock()
+
+	var previous *streamSnapshot
+	elem, hasPrevious := provider.snapshots[current.gatewayId]
+	if hasPrevious {
+		previous = elem.Value.(*streamSnapshot)
+		elem.Value = current
+		provider.lru.MoveToFront(elem)
+	} else {
+		provider.snapshots[current.gatewayId] = provider.lru.PushFront(current)
+	}
+	for provider.lru.Len() > MaxDeltaSnapshots {
+		evicted := provider.lru.Remove(provider.lru.Back()).(*streamSnapshot)
+		delete(provider.snapshots, evicted.gatewayId)
+	}
+	return previous, hasPrevious
+}
+
+func newStreamSnapshot(gatewayId string, updates []*protos.DataUpdate) *streamSnapshot {
+	values := make(map[string][]byte, len(updates))
+	for _, update := range updates {
+		values[update.Key] = update.Value
+	}
+	keys := getSortedKeys(values)
+
+	// Length-prefix keys and values so the encoding is unambiguous
+	hash := sha256.New()
+	lenBuf := make([]byte, 8)
+	for _, key := range keys {
+		for _, field := range [][]byte{[]byte(key), values[key]} {
+			binary.BigEndian.PutUint64(lenBuf, uint64(len(field)))
+			hash.Write(lenBuf)
+			hash.Write(field)
+		}
+	}
+	return &streamSnapshot{gatewayId: gatewayId, version: hex.EncodeToString(hash.Sum(nil)), values: values}
+}
+
+// diffSnapshots returns the changed and deleted keys between two snapshots,
+// ordered by key
+func diffSnapshots(previous *streamSnapshot, current *streamSnapshot) *StreamDelta {
+	delta := &StreamDelta{Updates: []*protos.DataUpdate{}, DeletedKeys: []string{}}
+	for _, key := range getSortedKeys(current.values) {
+		previousValue, existed := previous.values[key]
+		if !existed || !bytes.Equal(previousValue, current.values[key]) {
+			delta.Updates = append(delta.Updates, &protos.DataUpdate{Key: key, Value: current.values[key]})
+		}
+	}
+	for _, key := range getSortedKeys(previous.values) {
+		if _, exists := current.values[key]; !exists {
+			delta.DeletedKeys = append(delta.DeletedKeys, key)
+		}
+	}
+	return delta
+}
+
+func getSortedKeys(values map[string][]byte) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
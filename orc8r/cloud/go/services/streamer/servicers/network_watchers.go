@@ -0,0 +1,141 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"magma/orc8r/cloud/go/services/configurator"
+	configuratorprotos "magma/orc8r/cloud/go/services/configurator/protos"
+
+	"github.com/golang/glog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchRetryInterval is how long to wait before watching the change log of a
+// network again after the watch failed
+var watchRetryInterval = 10 * time.Second
+
+// networkWatchers holds the configurator change log watches of this process.
+// All the open streams of a network's gateways share one watch.
+var networkWatchers = &watcherRegistry{watchers: map[string]*networkWatcher{}}
+
+type watcherRegistry struct {
+	sync.Mutex
+	watchers map[string]*networkWatcher
+}
+
+type networkWatcher struct {
+	networkId string
+	cancel    context.CancelFunc
+	// ready is closed once the watcher has loaded the sequence number it
+	// watches from
+	ready chan struct{}
+	// subscribers is guarded by the registry's lock
+	subscribers map[chan struct{}]bool
+}
+
+// subscribe returns a channel which receives a value when the change log of
+// the network advances, and a function which must be called once the caller
+// stops listening. The first subscriber of a network starts its watch and the
+// last one to unsubscribe stops it.
+func (registry *watcherRegistry) subscribe(ctx context.Context, networkId string) (<-chan struct{}, func()) {
+	changed := make(chan struct{}, 1)
+	registry.Lock()
+	watcher, ok := registry.watchers[networkId]
+	if !ok {
+		watchCtx, cancel := context.WithCancel(context.Background())
+		watcher = &networkWatcher{
+			networkId:   networkId,
+			cancel:      cancel,
+			ready:       make(chan struct{}),
+			subscribers: map[chan struct{}]bool{},
+		}
+		registry.watchers[networkId] = watcher
+		go registry.watch(watchCtx, watcher)
+	}
+	watcher.subscribers[changed] = true
+	registry.Unlock()
+
+	unsubscribe := func() {
+		registry.Lock()
+		defer registry.Unlock()
+		delete(watcher.subscribers, changed)
+		if len(watcher.subscribers) == 0 && registry.watchers[networkId] == watcher {
+			watcher.cancel()
+			delete(registry.watchers, networkId)
+		}
+	}
+
+	// Changes written before the watcher loaded its starting sequence number
+	// wouldn't be signalled
+	select {
+	case <-watcher.ready:
+	case <-ctx.Done():
+	}
+	return changed, unsubscribe
+}
+
+// watch follows the change log of the watcher's network until ctx is done.
+// If the change log can't be followed from where the watcher left off, it
+// restarts from the latest change and signals every subscriber, since changes
+// may have been missed.
+func (registry *watcherRegistry) watch(ctx context.Context, watcher *networkWatcher) {
+	networkId := watcher.networkId
+	sinceSeq, err := configurator.GetLatestChangeSeq(networkId)
+	close(watcher.ready)
+	resync := err != nil
+	for {
+		if err != nil {
+			glog.Errorf("Failed to watch changes of network %s: %s", networkId, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watchRetryInterval):
+			}
+		}
+		if resync {
+			sinceSeq, err = configurator.GetLatestChangeSeq(networkId)
+			if err != nil {
+				continue
+			}
+			resync = false
+			registry.notify(watcher)
+		}
+
+		err = configurator.WatchChanges(ctx, networkId, sinceSeq, func(change *configuratorprotos.Change) error {
+			sinceSeq = change.Sequence
+			registry.notify(watcher)
+			return nil
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if status.Code(err) == codes.OutOfRange {
+			glog.Warningf("Changes of network %s after %d were purged, resyncing its streams", networkId, sinceSeq)
+			resync, err = true, nil
+		}
+	}
+}
+
+// notify signals every subscriber of the watcher. Signals are coalesced for
+// subscribers which haven't received the previous one yet.
+func (registry *watcherRegistry) notify(watcher *networkWatcher) {
+	registry.Lock()
+	defer registry.Unlock()
+	for changed := range watcher.subscribers {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/services/configurator"
+	configuratorprotos "magma/orc8r/cloud/go/services/configurator/protos"
+	configurator_test_init "magma/orc8r/cloud/go/services/configurator/test_init"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcherRegistry_Subscribe(t *testing.T) {
+	configurator_test_init.StartTestService(t)
+	_, err := configurator.CreateNetworks([]*configuratorprotos.Network{{Id: "watched_network"}})
+	assert.NoError(t, err)
+	registry := &watcherRegistry{watchers: map[string]*networkWatcher{}}
+
+	// Subscribers of the same network share a watcher
+	changed1, unsubscribe1 := registry.subscribe(context.Background(), "watched_network")
+	changed2, unsubscribe2 := registry.subscribe(context.Background(), "watched_network")
+	registry.Lock()
+	assert.Len(t, registry.watchers, 1)
+	registry.Unlock()
+
+	_, err = configurator.CreateEntities(context.Background(), "watched_network", []*configuratorprotos.NetworkEntity{{Type: "foo", Id: "bar"}})
+	assert.NoError(t, err)
+	for _, changed := range []<-chan struct{}{changed1, changed2} {
+		select {
+		case <-changed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("subscriber was not notified of the change")
+		}
+	}
+
+	// The watcher is stopped once its last subscriber leaves
+	unsubscribe1()
+	registry.Lock()
+	assert.Len(t, registry.watchers, 1)
+	registry.Unlock()
+	unsubscribe2()
+	registry.Lock()
+	assert.Empty(t, registry.watchers)
+	registry.Unlock()
+}
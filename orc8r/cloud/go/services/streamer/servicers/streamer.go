@@ -16,14 +16,26 @@ the updates would be pushed through that channel.
 package servicers
 
 import (
+	"context"
+	"time"
+
 	"magma/orc8r/cloud/go/identity"
 	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/magmad"
 	"magma/orc8r/cloud/go/services/streamer/providers"
 
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// DeltaResyncInterval is the longest an open delta stream waits for the
+// configurator change log of its network to advance before asking its
+// provider for changes anyway. Providers may read data which is stored
+// outside of configurator and so never shows up in the change log.
+var DeltaResyncInterval = time.Minute
+
 type StreamingServer struct{}
 
 func GetUpdatesUnverified(
@@ -34,7 +46,20 @@ func GetUpdatesUnverified(
 	if err != nil {
 		return status.Errorf(codes.Unavailable, "Stream %s does not exist", request.GetStreamName())
 	}
-	updates, err := streamProvider.GetUpdates(request.GetGatewayId(), request.ExtraArgs)
+	extraArgs := request.ExtraArgs
+	if extraArgs != nil && ptypes.Is(extraArgs, &protos.DeltaStreamArgs{}) {
+		deltaArgs := &protos.DeltaStreamArgs{}
+		if err := ptypes.UnmarshalAny(extraArgs, deltaArgs); err != nil {
+			return status.Errorf(codes.InvalidArgument, "Invalid delta stream args: %s", err)
+		}
+		if deltaProvider, ok := streamProvider.(providers.DeltaStreamProvider); ok {
+			return streamDeltas(request.GetGatewayId(), deltaProvider, deltaArgs, stream)
+		}
+		// Providers which don't support deltas always resync
+		extraArgs = deltaArgs.ExtraArgs
+	}
+
+	updates, err := streamProvider.GetUpdates(request.GetGatewayId(), extraArgs)
 	if err != nil {
 		return status.Errorf(codes.Aborted, "Error while streaming updates: %s", err)
 	}
@@ -67,3 +92,63 @@ func (srv *StreamingServer) GetUpdates(
 	request.GatewayId = gwIdentity.HardwareId
 	return GetUpdatesUnverified(request, stream)
 }
+
+// streamDeltas sends the changes since the gateway's last version, then keeps
+// the stream open and sends new changes until the gateway closes the stream.
+// New changes are computed when the change log of the gateway's network
+// advances, and at least every DeltaResyncInterval.
+func streamDeltas(
+	gatewayId string,
+	provider providers.DeltaStreamProvider,
+	args *protos.DeltaStreamArgs,
+	stream protos.Streamer_GetUpdatesServer,
+) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	// Start watching before the first delta is computed, so that changes
+	// written while it's computed aren't missed
+	changed, stopWatching := watchNetworkChanges(ctx, gatewayId)
+	defer stopWatching()
+
+	version := args.GetVersion()
+	for first := true; ; first = false {
+		delta, err := provider.GetUpdatesSince(gatewayId, version, args.GetExtraArgs())
+		if err != nil {
+			return status.Errorf(codes.Aborted, "Error while streaming updates: %s", err)
+		}
+		// Always send the first batch so the gateway knows it's up to date
+		if first || !delta.IsEmpty() {
+			err = stream.Send(&protos.DataUpdateBatch{
+				Updates:     delta.Updates,
+				Resync:      delta.Resync,
+				Version:     delta.Version,
+				DeletedKeys: delta.DeletedKeys,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		version = delta.Version
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		case <-time.After(DeltaResyncInterval):
+		}
+	}
+}
+
+// watchNetworkChanges subscribes to the configurator change log watch of the
+// gateway's network. The returned channel receives a value when the change
+// log advances; changes written in a burst are coalesced. The returned
+// function ends the subscription. If the gateway's network can't be found,
+// the channel never receives.
+func watchNetworkChanges(ctx context.Context, gatewayId string) (<-chan struct{}, func()) {
+	networkId, err := magmad.FindGatewayNetworkId(gatewayId)
+	if err != nil {
+		glog.Warningf("Failed to find network of gateway %s, falling back to periodic resync: %s", gatewayId, err)
+		return make(chan struct{}), func() {}
+	}
+	return networkWatchers.subscribe(ctx, networkId)
+}
@@ -10,14 +10,24 @@ package servicers_test
 
 import (
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/registry"
+	"magma/orc8r/cloud/go/services/configurator"
+	configurator_protos "magma/orc8r/cloud/go/services/configurator/protos"
+	configurator_test_init "magma/orc8r/cloud/go/services/configurator/test_init"
+	"magma/orc8r/cloud/go/services/magmad"
+	magmad_protos "magma/orc8r/cloud/go/services/magmad/protos"
+	magmad_test_init "magma/orc8r/cloud/go/services/magmad/test_init"
 	"magma/orc8r/cloud/go/services/streamer"
 	"magma/orc8r/cloud/go/services/streamer/providers"
+	"magma/orc8r/cloud/go/services/streamer/servicers"
 	streamer_test_init "magma/orc8r/cloud/go/services/streamer/test_init"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
@@ -84,3 +94,147 @@ func TestStreamingServer_GetUpdates(t *testing.T) {
 	_, err = streamerClient.Recv()
 	assert.Error(t, err, "Stream stream_dne does not exist", codes.Unavailable)
 }
+
+// mutableStreamProvider returns updates which can be changed while streaming
+type mutableStreamProvider struct {
+	sync.Mutex
+	name   string
+	retVal []*protos.DataUpdate
+}
+
+func (m *mutableStreamProvider) GetStreamName() string {
+	return m.name
+}
+
+func (m *mutableStreamProvider) GetUpdates(gatewayId string, extraArgs *any.Any) ([]*protos.DataUpdate, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.retVal, nil
+}
+
+func (m *mutableStreamProvider) setUpdates(updates []*protos.DataUpdate) {
+	m.Lock()
+	defer m.Unlock()
+	m.retVal = updates
+}
+
+func TestStreamingServer_GetUpdatesDelta(t *testing.T) {
+	magmad_test_init.StartTestService(t)
+	configurator_test_init.StartTestService(t)
+	streamer_test_init.StartTestService(t)
+	conn, err := registry.GetConnection(streamer.ServiceName)
+	assert.NoError(t, err)
+	grpcClient := protos.NewStreamerClient(conn)
+
+	// Changes should only be pushed when the change log advances
+	servicers.DeltaResyncInterval = time.Hour
+	defer func() { servicers.DeltaResyncInterval = time.Minute }()
+	providers.MaxDeltaSnapshots = 1
+	defer func() { providers.MaxDeltaSnapshots = 10000 }()
+
+	networkId, err := magmad.RegisterNetwork(&magmad_protos.MagmadNetworkRecord{Name: "Delta Network"}, "delta_network")
+	assert.NoError(t, err)
+	hwId := &protos.AccessGatewayID{Id: "deltaHwId"}
+	_, err = magmad.RegisterGateway(networkId, &magmad_protos.AccessGatewayRecord{HwId: hwId, Name: "gw1"})
+	assert.NoError(t, err)
+	_, err = configurator.CreateNetworks([]*configurator_protos.Network{{Id: networkId}})
+	assert.NoError(t, err)
+
+	mock := &mutableStreamProvider{
+		name: "delta1",
+		retVal: []*protos.DataUpdate{
+			{Key: "a", Value: []byte("123")},
+			{Key: "b", Value: []byte("456")},
+		},
+	}
+	providers.RegisterStreamProvider(providers.NewDigestDeltaProvider(mock))
+	getUpdates := func(ctx context.Context, gatewayId string, version string) protos.Streamer_GetUpdatesClient {
+		extraArgs, err := ptypes.MarshalAny(&protos.DeltaStreamArgs{Version: version})
+		assert.NoError(t, err)
+		streamerClient, err := grpcClient.GetUpdates(ctx, &protos.StreamRequest{GatewayId: gatewayId, StreamName: "delta1", ExtraArgs: extraArgs})
+		assert.NoError(t, err)
+		return streamerClient
+	}
+
+	// First request without a version resyncs
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	streamerClient := getUpdates(ctx, hwId.Id, "")
+	batch, err := streamerClient.Recv()
+	assert.NoError(t, err)
+	assert.True(t, batch.Resync)
+	assert.Len(t, batch.Updates, 2)
+	assert.NotEmpty(t, batch.Version)
+	initialVersion := batch.Version
+
+	// Changes are pushed down the open stream once the network changes
+	mock.setUpdates([]*protos.DataUpdate{
+		{Key: "a", Value: []byte("789")},
+		{Key: "c", Value: []byte("000")},
+	})
+	_, err = configurator.CreateEntities(context.Background(), networkId, []*configurator_protos.NetworkEntity{{Type: "foo", Id: "bar"}})
+	assert.NoError(t, err)
+	batch, err = streamerClient.Recv()
+	assert.NoError(t, err)
+	assert.False(t, batch.Resync)
+	assert.Equal(t, protos.TestMarshal(&protos.DataUpdateBatch{
+		Updates: []*protos.DataUpdate{
+			{Key: "a", Value: []byte("789")},
+			{Key: "c", Value: []byte("000")},
+		},
+		Version:     batch.Version,
+		DeletedKeys: []string{"b"},
+	}), protos.TestMarshal(batch))
+	assert.NotEqual(t, initialVersion, batch.Version)
+	latestVersion := batch.Version
+	cancel()
+
+	// Reconnecting with the latest version sends an empty batch
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	streamerClient = getUpdates(ctx, hwId.Id, latestVersion)
+	batch, err = streamerClient.Recv()
+	assert.NoError(t, err)
+	assert.False(t, batch.Resync)
+	assert.Empty(t, batch.Updates)
+	assert.Empty(t, batch.DeletedKeys)
+	assert.Equal(t, latestVersion, batch.Version)
+	cancel()
+
+	// Reconnecting with an unknown version resyncs
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	streamerClient = getUpdates(ctx, hwId.Id, initialVersion)
+	batch, err = streamerClient.Recv()
+	assert.NoError(t, err)
+	assert.True(t, batch.Resync)
+	assert.Len(t, batch.Updates, 2)
+	cancel()
+
+	// Once the gateway's snapshot is evicted, a changed dataset is resynced
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	streamerClient = getUpdates(ctx, "otherHwId", "")
+	_, err = streamerClient.Recv()
+	assert.NoError(t, err)
+	cancel()
+	mock.setUpdates([]*protos.DataUpdate{{Key: "a", Value: []byte("789")}})
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	streamerClient = getUpdates(ctx, hwId.Id, latestVersion)
+	batch, err = streamerClient.Recv()
+	assert.NoError(t, err)
+	assert.True(t, batch.Resync)
+	assert.Len(t, batch.Updates, 1)
+
+	// Providers without delta support resync and close the stream
+	providers.RegisterStreamProvider(&mockStreamProvider{name: "mock3", retVal: []*protos.DataUpdate{{Key: "a", Value: []byte("123")}}})
+	extraArgs, err := ptypes.MarshalAny(&protos.DeltaStreamArgs{Version: initialVersion})
+	assert.NoError(t, err)
+	streamerClient, err = grpcClient.GetUpdates(context.Background(), &protos.StreamRequest{GatewayId: hwId.Id, StreamName: "mock3", ExtraArgs: extraArgs})
+	assert.NoError(t, err)
+	batch, err = streamerClient.Recv()
+	assert.NoError(t, err)
+	assert.True(t, batch.Resync)
+	assert.Len(t, batch.Updates, 1)
+}
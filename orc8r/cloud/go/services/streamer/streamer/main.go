@@ -9,7 +9,9 @@ LICENSE file in the root directory of this source tree.
 package main
 
 import (
+	"flag"
 	"log"
+	"time"
 
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/protos"
@@ -18,6 +20,10 @@ import (
 	"magma/orc8r/cloud/go/services/streamer/servicers"
 )
 
+var (
+	deltaResyncSec = flag.Int64("delta-resync-sec", 60, "Longest interval at which incremental update streams are checked for changes (in seconds)")
+)
+
 func main() {
 	// Create the service
 	srv, err := service.NewOrchestratorService(orc8r.ModuleName, streamer.ServiceName)
@@ -25,6 +31,8 @@ func main() {
 		log.Fatalf("Error creating service: %s", err)
 	}
 
+	servicers.DeltaResyncInterval = time.Duration(*deltaResyncSec) * time.Second
+
 	// Add servicers to the service
 	servicer := &servicers.StreamingServer{}
 	protos.RegisterStreamerServer(srv.GrpcServer, servicer)
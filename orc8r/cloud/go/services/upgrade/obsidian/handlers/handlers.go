@@ -9,6 +9,7 @@ LICENSE file in the root directory of this source tree.
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -248,7 +249,7 @@ func createTierHandler(c echo.Context) error {
 		return handlers.HttpError(err, http.StatusInternalServerError)
 	}
 
-	err = multiplexCreateTierIntoConfigurator(networkId, restTier)
+	err = multiplexCreateTierIntoConfigurator(c.Request().Context(), networkId, restTier)
 	if err != nil {
 		return handlers.HttpError(fmt.Errorf("Failed to multiplex create into configurator : %v", err))
 	}
@@ -256,7 +257,7 @@ func createTierHandler(c echo.Context) error {
 	return c.JSON(http.StatusCreated, restTier.ID)
 }
 
-func multiplexCreateTierIntoConfigurator(networkID string, tier *models.Tier) error {
+func multiplexCreateTierIntoConfigurator(ctx context.Context, networkID string, tier *models.Tier) error {
 	serializedTier, err := serde.Serialize(configurator.SerdeDomain, upgrade_client.NetworkTierType, tier)
 	if err != nil {
 		return err
@@ -266,7 +267,7 @@ func multiplexCreateTierIntoConfigurator(networkID string, tier *models.Tier) er
 		Id:     tier.ID,
 		Config: serializedTier,
 	}
-	_, err = configurator.CreateEntities(networkID, []*configuratorp.NetworkEntity{entity})
+	_, err = configurator.CreateEntities(ctx, networkID, []*configuratorp.NetworkEntity{entity})
 	return err
 }
 
@@ -328,7 +329,7 @@ func updateTierHandler(c echo.Context) error {
 		return handlers.HttpError(err, http.StatusInternalServerError)
 	}
 
-	err = multiplexUpdateTierIntoConfigurator(networkId, tierId, restTier)
+	err = multiplexUpdateTierIntoConfigurator(c.Request().Context(), networkId, tierId, restTier)
 	if err != nil {
 		return handlers.HttpError(fmt.Errorf("Failed to multiplex update into configurator : %v", err))
 	}
@@ -336,8 +337,8 @@ func updateTierHandler(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
-func multiplexUpdateTierIntoConfigurator(networkID, tierID string, tier *models.Tier) error {
-	err := configurator_utils.CreateNetworkEntityIfNotExists(networkID, upgrade_client.NetworkTierType, tierID)
+func multiplexUpdateTierIntoConfigurator(ctx context.Context, networkID, tierID string, tier *models.Tier) error {
+	err := configurator_utils.CreateNetworkEntityIfNotExists(ctx, networkID, upgrade_client.NetworkTierType, tierID)
 	if err != nil {
 		return err
 	}
@@ -350,7 +351,7 @@ func multiplexUpdateTierIntoConfigurator(networkID, tierID string, tier *models.
 		Key:       tierID,
 		NewConfig: configuratorp.GetBytesWrapper(serializedTier),
 	}
-	_, err = configurator.UpdateEntities(networkID, []*configuratorp.EntityUpdateCriteria{entity})
+	_, err = configurator.UpdateEntities(ctx, networkID, []*configuratorp.EntityUpdateCriteria{entity})
 	return err
 }
 
@@ -369,7 +370,7 @@ func deleteTierHandler(c echo.Context) error {
 		return handlers.HttpError(err, http.StatusInternalServerError)
 	}
 
-	err = configurator.DeleteEntities(networkId, []*configuratorp.EntityID{{Type: upgrade_client.NetworkTierType, Id: tierId}})
+	err = configurator.DeleteEntities(c.Request().Context(), networkId, []*configuratorp.EntityID{{Type: upgrade_client.NetworkTierType, Id: tierId}})
 	if err != nil {
 		glog.Errorf("Failed to multiplex delete into configurator: %v", err)
 	}
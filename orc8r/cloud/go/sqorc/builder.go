@@ -45,6 +45,13 @@ func GetSqlBuilder() StatementBuilder {
 	}
 }
 
+// IsPostgres returns true if the builder builds statements for the
+// PostgreSQL dialect.
+func IsPostgres(builder StatementBuilder) bool {
+	_, ok := builder.(postgresStatementBuilder)
+	return ok
+}
+
 // StatementBuilder is an interface which tracks squirrel's
 // StatementBuilderType with the difference that Insert returns this package's
 // InsertBuilder interface type.
@@ -72,6 +79,10 @@ type StatementBuilder interface {
 	// RunWith on this StatementBuilder due to a reflection bug that's
 	// tricky to chase down.
 	CreateIndex(name string) CreateIndexBuilder
+
+	// SupportsRecursiveCTE returns true if queries built for this dialect can
+	// use recursive common table expressions (WITH RECURSIVE).
+	SupportsRecursiveCTE() bool
 }
 
 // NewPostgresStatementBuilder returns an implementation of StatementBuilder
@@ -113,6 +124,10 @@ func (psb postgresStatementBuilder) CreateIndex(name string) CreateIndexBuilder
 		Name(name)
 }
 
+func (psb postgresStatementBuilder) SupportsRecursiveCTE() bool {
+	return true
+}
+
 type mariaDBStatementBuilder struct {
 	squirrel.StatementBuilderType
 }
@@ -135,6 +150,12 @@ func (msb mariaDBStatementBuilder) CreateIndex(name string) CreateIndexBuilder {
 		Name(name)
 }
 
+func (msb mariaDBStatementBuilder) SupportsRecursiveCTE() bool {
+	// Recursive CTEs are only supported on MariaDB 10.2.2+ so we can't rely
+	// on them being available
+	return false
+}
+
 // InsertBuilder is an interface which tracks squirrel's InsertBuilder
 // struct but returns InsertBuilder on all self-referencing returns and adds
 // an OnConflict method to support upserts.
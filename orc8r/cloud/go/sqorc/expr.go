@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sqorc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// likeEscapeChar is the escape character used in LIKE expressions built by
+// this package. We avoid the backslash default because PostgreSQL and MariaDB
+// disagree on how backslashes in string literals are interpreted.
+const likeEscapeChar = "!"
+
+var likeEscaper = strings.NewReplacer(
+	likeEscapeChar, likeEscapeChar+likeEscapeChar,
+	"%", likeEscapeChar+"%",
+	"_", likeEscapeChar+"_",
+)
+
+// HasPrefix returns an expression which matches rows where the value of the
+// given column starts with the given prefix. LIKE wildcards in the prefix are
+// escaped, so the prefix is always matched literally.
+// The generated SQL is valid for both PostgreSQL and MariaDB.
+func HasPrefix(column string, prefix string) squirrel.Sqlizer {
+	return squirrel.Expr(
+		fmt.Sprintf("%s LIKE ? ESCAPE '%s'", column, likeEscapeChar),
+		likeEscaper.Replace(prefix)+"%",
+	)
+}
+
+// TupleGreaterThan returns an expression which matches rows where the tuple of
+// the given columns is lexicographically greater than the tuple of the given
+// values, i.e. (col1, col2, ...) > (val1, val2, ...).
+// Row value comparisons are not consistently supported (or indexed) across
+// dialects, so this is expanded into an equivalent OR of ANDs.
+func TupleGreaterThan(columns []string, values []interface{}) squirrel.Sqlizer {
+	if len(columns) != len(values) {
+		panic("number of columns and values in tuple comparison must match")
+	}
+
+	// (a, b, c) > (x, y, z) is expanded to
+	// (a > x) OR (a = x AND b > y) OR (a = x AND b = y AND c > z)
+	orClause := squirrel.Or{}
+	for i := range columns {
+		andClause := squirrel.And{}
+		for j := 0; j < i; j++ {
+			andClause = append(andClause, squirrel.Eq{columns[j]: values[j]})
+		}
+		andClause = append(andClause, squirrel.Gt{columns[i]: values[i]})
+		orClause = append(orClause, andClause)
+	}
+	return orClause
+}
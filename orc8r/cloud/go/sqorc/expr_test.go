@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sqorc
+
+import (
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasPrefix(t *testing.T) {
+	psb := postgresStatementBuilder{squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)}
+	actualSql, actualArgs, err := psb.Select("foo").From("table").Where(HasPrefix("bar", "a_b%c!")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT foo FROM table WHERE bar LIKE $1 ESCAPE '!'", actualSql)
+	assert.Equal(t, []interface{}{"a!_b!%c!!%"}, actualArgs)
+
+	msb := mariaDBStatementBuilder{squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question)}
+	actualSql, actualArgs, err = msb.Select("foo").From("table").Where(HasPrefix("bar", "abc")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT foo FROM table WHERE bar LIKE ? ESCAPE '!'", actualSql)
+	assert.Equal(t, []interface{}{"abc%"}, actualArgs)
+}
+
+func TestTupleGreaterThan(t *testing.T) {
+	psb := postgresStatementBuilder{squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)}
+	actualSql, actualArgs, err := psb.Select("foo").From("table").
+		Where(TupleGreaterThan([]string{"a", "b"}, []interface{}{1, "x"})).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT foo FROM table WHERE ((a > $1) OR (a = $2 AND b > $3))", actualSql)
+	assert.Equal(t, []interface{}{1, 1, "x"}, actualArgs)
+
+	msb := mariaDBStatementBuilder{squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question)}
+	actualSql, actualArgs, err = msb.Select("foo").From("table").
+		Where(TupleGreaterThan([]string{"a"}, []interface{}{1})).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT foo FROM table WHERE ((a > ?))", actualSql)
+	assert.Equal(t, []interface{}{1}, actualArgs)
+
+	assert.Panics(t, func() { TupleGreaterThan([]string{"a"}, []interface{}{}) })
+}
@@ -0,0 +1,422 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sqorc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MigrationsTable is the name of the table which tracks applied
+	// migrations
+	MigrationsTable = "schema_migrations"
+
+	migServiceCol   = "service"
+	migVersionCol   = "version"
+	migDescCol      = "description"
+	migAppliedAtCol = "applied_at"
+
+	// migrationLockName identifies the lock held while migrating on MariaDB.
+	// Postgres advisory locks are keyed by integer instead.
+	migrationLockName    = "orc8r_schema_migrations"
+	migrationLockKey     = 0x6f7263385f6d6967
+	migrationLockTimeout = 60 * time.Second
+)
+
+// Migration is a single versioned schema change for a service. Migrations
+// for a service are applied in ascending version order, each in its own
+// transaction.
+type Migration struct {
+	// Service is the name of the service which owns the migration
+	Service string
+	// Version orders the migrations of a service. Versions start at 1.
+	Version uint64
+	// Description is a short human-readable summary of the migration
+	Description string
+	// Up applies the migration within the given transaction
+	Up func(tx *sql.Tx, builder StatementBuilder) error
+}
+
+// MigrationStatus is a migration along with whether it has been applied
+type MigrationStatus struct {
+	Migration
+	Applied bool
+	// AppliedAt is the unix time in seconds at which the migration was
+	// applied, or 0 if it hasn't been
+	AppliedAt int64
+}
+
+// ErrMigrationsUnsupported is returned by ApplyServiceMigrations for sqlite3
+// DBs, which have neither the session locks nor the information_schema that
+// migrations rely on. sqlite3 is only used for tests and local development,
+// where services create their tables directly instead.
+var ErrMigrationsUnsupported = errors.New("schema migrations are not supported on sqlite3")
+
+var registry = struct {
+	sync.RWMutex
+	migrations map[string]map[uint64]Migration
+}{migrations: map[string]map[uint64]Migration{}}
+
+// RegisterMigrations registers migrations to be applied by ApplyMigrations.
+// Services should register their migrations on init. An error is returned if
+// a migration is invalid or its version is already registered for its
+// service, in which case none of the migrations are registered.
+func RegisterMigrations(migrations ...Migration) error {
+	registry.Lock()
+	defer registry.Unlock()
+
+	seen := map[string]map[uint64]bool{}
+	for _, migration := range migrations {
+		if err := validateMigration(migration); err != nil {
+			return err
+		}
+		_, registered := registry.migrations[migration.Service][migration.Version]
+		if registered || seen[migration.Service][migration.Version] {
+			return fmt.Errorf("migration %d for service %s is already registered", migration.Version, migration.Service)
+		}
+		if _, ok := seen[migration.Service]; !ok {
+			seen[migration.Service] = map[uint64]bool{}
+		}
+		seen[migration.Service][migration.Version] = true
+	}
+
+	for _, migration := range migrations {
+		if _, ok := registry.migrations[migration.Service]; !ok {
+			registry.migrations[migration.Service] = map[uint64]Migration{}
+		}
+		registry.migrations[migration.Service][migration.Version] = migration
+	}
+	return nil
+}
+
+// MustRegisterMigrations calls RegisterMigrations and panics on error
+func MustRegisterMigrations(migrations ...Migration) {
+	if err := RegisterMigrations(migrations...); err != nil {
+		panic(err)
+	}
+}
+
+// GetRegisteredMigrations returns all registered migrations, ordered by
+// service then version.
+func GetRegisteredMigrations() []Migration {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	ret := []Migration{}
+	for _, serviceMigrations := range registry.migrations {
+		for _, migration := range serviceMigrations {
+			ret = append(ret, migration)
+		}
+	}
+	sortMigrations(ret)
+	return ret
+}
+
+// ListMigrations returns the status of each of the given migrations, ordered
+// by service then version. The migrations table is created if it doesn't
+// exist.
+func ListMigrations(db *sql.DB, builder StatementBuilder, migrations []Migration) ([]MigrationStatus, error) {
+	var applied map[string]map[uint64]int64
+	err := execInMigrationTx(context.Background(), db, func(tx *sql.Tx) error {
+		var err error
+		if err = createMigrationsTable(tx, builder); err != nil {
+			return err
+		}
+		applied, err = getAppliedMigrations(tx, builder)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := append([]Migration{}, migrations...)
+	sortMigrations(sorted)
+	ret := make([]MigrationStatus, 0, len(sorted))
+	for _, migration := range sorted {
+		appliedAt, ok := applied[migration.Service][migration.Version]
+		ret = append(ret, MigrationStatus{Migration: migration, Applied: ok, AppliedAt: appliedAt})
+	}
+	return ret, nil
+}
+
+// ApplyMigrations applies the given migrations which haven't been applied
+// yet, and returns the migrations which were applied. If dryRun is true, the
+// pending migrations are returned without being applied.
+//
+// A database lock is held while migrating so that concurrent callers, e.g.
+// multiple replicas of a service starting at once, apply each migration only
+// once. An error is returned if a pending migration is older than a migration
+// already applied for its service, since migrations must be applied in
+// order.
+func ApplyMigrations(db *sql.DB, builder StatementBuilder, migrations []Migration, dryRun bool) ([]Migration, error) {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get DB connection")
+	}
+	defer conn.Close()
+
+	lock, err := getMigrationLock(builder)
+	if err != nil {
+		return nil, err
+	}
+	if err := lock.acquire(ctx, conn); err != nil {
+		return nil, errors.Wrap(err, "failed to acquire migration lock")
+	}
+	defer func() {
+		if err := lock.release(ctx, conn); err != nil {
+			glog.Errorf("failed to release migration lock: %s", err)
+		}
+	}()
+
+	var pending []Migration
+	err = execInMigrationConnTx(ctx, conn, func(tx *sql.Tx) error {
+		if err := createMigrationsTable(tx, builder); err != nil {
+			return err
+		}
+		applied, err := getAppliedMigrations(tx, builder)
+		if err != nil {
+			return err
+		}
+		pending, err = getPendingMigrations(migrations, applied)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return pending, nil
+	}
+
+	ret := make([]Migration, 0, len(pending))
+	for _, migration := range pending {
+		glog.Infof("Applying migration %d for service %s: %s", migration.Version, migration.Service, migration.Description)
+		err = execInMigrationConnTx(ctx, conn, func(tx *sql.Tx) error {
+			if err := migration.Up(tx, builder); err != nil {
+				return err
+			}
+			_, err := builder.Insert(MigrationsTable).
+				Columns(migServiceCol, migVersionCol, migDescCol, migAppliedAtCol).
+				Values(migration.Service, migration.Version, migration.Description, time.Now().Unix()).
+				RunWith(tx).
+				Exec()
+			return err
+		})
+		if err != nil {
+			return ret, errors.Wrapf(err, "failed to apply migration %d for service %s", migration.Version, migration.Service)
+		}
+		ret = append(ret, migration)
+	}
+	return ret, nil
+}
+
+// ApplyServiceMigrations applies the pending registered migrations of the
+// given services. Services should call this on startup, before using their
+// tables, so that their schema is only ever changed by migrations.
+// ErrMigrationsUnsupported is returned if the DB is backed by sqlite3.
+func ApplyServiceMigrations(db *sql.DB, builder StatementBuilder, services ...string) error {
+	if isSqlite(db) {
+		return ErrMigrationsUnsupported
+	}
+
+	isServiceMigration := map[string]bool{}
+	for _, service := range services {
+		isServiceMigration[service] = true
+	}
+	var migrations []Migration
+	for _, migration := range GetRegisteredMigrations() {
+		if isServiceMigration[migration.Service] {
+			migrations = append(migrations, migration)
+		}
+	}
+
+	applied, err := ApplyMigrations(db, builder, migrations, false)
+	if len(applied) > 0 {
+		glog.Infof("Applied %d migrations for services %v", len(applied), services)
+	}
+	return err
+}
+
+func validateMigration(migration Migration) error {
+	switch {
+	case migration.Service == "":
+		return errors.New("migration service must be non-empty")
+	case migration.Version == 0:
+		return fmt.Errorf("migration version for service %s must be positive", migration.Service)
+	case migration.Up == nil:
+		return fmt.Errorf("migration %d for service %s must have an up function", migration.Version, migration.Service)
+	}
+	return nil
+}
+
+func sortMigrations(migrations []Migration) {
+	sort.Slice(migrations, func(i, j int) bool {
+		if migrations[i].Service != migrations[j].Service {
+			return migrations[i].Service < migrations[j].Service
+		}
+		return migrations[i].Version < migrations[j].Version
+	})
+}
+
+// getPendingMigrations returns the migrations which haven't been applied,
+// ordered by service then version
+func getPendingMigrations(migrations []Migration, applied map[string]map[uint64]int64) ([]Migration, error) {
+	latestApplied := map[string]uint64{}
+	for service, versions := range applied {
+		for version := range versions {
+			if version > latestApplied[service] {
+				latestApplied[service] = version
+			}
+		}
+	}
+
+	sorted := append([]Migration{}, migrations...)
+	sortMigrations(sorted)
+	ret := []Migration{}
+	for _, migration := range sorted {
+		if _, ok := applied[migration.Service][migration.Version]; ok {
+			continue
+		}
+		if migration.Version < latestApplied[migration.Service] {
+			return nil, fmt.Errorf(
+				"migration %d for service %s is older than applied migration %d",
+				migration.Version, migration.Service, latestApplied[migration.Service],
+			)
+		}
+		ret = append(ret, migration)
+	}
+	return ret, nil
+}
+
+func createMigrationsTable(tx *sql.Tx, builder StatementBuilder) error {
+	_, err := builder.CreateTable(MigrationsTable).
+		IfNotExists().
+		Column(migServiceCol).Type(ColumnTypeText).NotNull().EndColumn().
+		Column(migVersionCol).Type(ColumnTypeBigInt).NotNull().EndColumn().
+		Column(migDescCol).Type(ColumnTypeText).EndColumn().
+		Column(migAppliedAtCol).Type(ColumnTypeBigInt).NotNull().EndColumn().
+		PrimaryKey(migServiceCol, migVersionCol).
+		RunWith(tx).
+		Exec()
+	return errors.Wrap(err, "failed to create migrations table")
+}
+
+// getAppliedMigrations returns the application time of each applied
+// migration, keyed by service then version
+func getAppliedMigrations(tx *sql.Tx, builder StatementBuilder) (map[string]map[uint64]int64, error) {
+	rows, err := builder.Select(migServiceCol, migVersionCol, migAppliedAtCol).
+		From(MigrationsTable).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query applied migrations")
+	}
+	defer CloseRowsLogOnError(rows, "getAppliedMigrations")
+
+	ret := map[string]map[uint64]int64{}
+	for rows.Next() {
+		var service string
+		var version uint64
+		var appliedAt int64
+		if err := rows.Scan(&service, &version, &appliedAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan applied migration")
+		}
+		if _, ok := ret[service]; !ok {
+			ret[service] = map[uint64]int64{}
+		}
+		ret[service][version] = appliedAt
+	}
+	return ret, rows.Err()
+}
+
+func execInMigrationTx(ctx context.Context, db *sql.DB, txFn func(*sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	return finishMigrationTx(tx, txFn(tx))
+}
+
+func execInMigrationConnTx(ctx context.Context, conn *sql.Conn, txFn func(*sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	return finishMigrationTx(tx, txFn(tx))
+}
+
+func finishMigrationTx(tx *sql.Tx, err error) error {
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			glog.Errorf("error rolling back migration tx: %s", rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// migrationLock is a session-level lock on a DB connection. The lock is
+// held across the transactions run on the connection.
+type migrationLock interface {
+	acquire(ctx context.Context, conn *sql.Conn) error
+	release(ctx context.Context, conn *sql.Conn) error
+}
+
+func getMigrationLock(builder StatementBuilder) (migrationLock, error) {
+	switch builder.(type) {
+	case postgresStatementBuilder:
+		return postgresMigrationLock{}, nil
+	case mariaDBStatementBuilder:
+		return mariaMigrationLock{}, nil
+	default:
+		return nil, fmt.Errorf("migrations are not supported for statement builder %T", builder)
+	}
+}
+
+// postgresMigrationLock uses a session-level advisory lock
+type postgresMigrationLock struct{}
+
+func (postgresMigrationLock) acquire(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey)
+	return err
+}
+
+func (postgresMigrationLock) release(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockKey)
+	return err
+}
+
+// mariaMigrationLock uses a named lock. Named locks are held by the
+// connection, so they survive the implicit commits caused by DDL statements.
+type mariaMigrationLock struct{}
+
+func (mariaMigrationLock) acquire(ctx context.Context, conn *sql.Conn) error {
+	var acquired sql.NullInt64
+	err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", migrationLockName, int(migrationLockTimeout/time.Second)).Scan(&acquired)
+	if err != nil {
+		return err
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		return fmt.Errorf("timed out waiting for lock %s", migrationLockName)
+	}
+	return nil
+}
+
+func (mariaMigrationLock) release(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", migrationLockName)
+	return err
+}
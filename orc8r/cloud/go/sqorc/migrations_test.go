@@ -0,0 +1,197 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package sqorc
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestRegisterMigrations(t *testing.T) {
+	defer clearRegisteredMigrations()
+	up := func(*sql.Tx, StatementBuilder) error { return nil }
+
+	err := RegisterMigrations(
+		Migration{Service: "svc2", Version: 1, Up: up},
+		Migration{Service: "svc1", Version: 2, Up: up},
+		Migration{Service: "svc1", Version: 1, Up: up},
+	)
+	assert.NoError(t, err)
+	actual := GetRegisteredMigrations()
+	assert.Len(t, actual, 3)
+	assert.Equal(t, "svc1", actual[0].Service)
+	assert.Equal(t, uint64(1), actual[0].Version)
+	assert.Equal(t, "svc1", actual[1].Service)
+	assert.Equal(t, uint64(2), actual[1].Version)
+	assert.Equal(t, "svc2", actual[2].Service)
+
+	// Duplicates and invalid migrations fail the whole batch
+	err = RegisterMigrations(Migration{Service: "svc3", Version: 1, Up: up}, Migration{Service: "svc1", Version: 2, Up: up})
+	assert.EqualError(t, err, "migration 2 for service svc1 is already registered")
+	err = RegisterMigrations(Migration{Service: "svc3", Version: 1, Up: up}, Migration{Service: "svc3", Version: 1, Up: up})
+	assert.EqualError(t, err, "migration 1 for service svc3 is already registered")
+	err = RegisterMigrations(Migration{Service: "svc3", Version: 0, Up: up})
+	assert.EqualError(t, err, "migration version for service svc3 must be positive")
+	err = RegisterMigrations(Migration{Service: "svc3", Version: 1})
+	assert.EqualError(t, err, "migration 1 for service svc3 must have an up function")
+	assert.Len(t, GetRegisteredMigrations(), 3)
+}
+
+func TestApplyMigrations(t *testing.T) {
+	createTableRegex := regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")
+	selectAppliedRegex := regexp.QuoteMeta("SELECT service, version, applied_at FROM schema_migrations")
+	migrations := []Migration{
+		{
+			Service:     "svc1",
+			Version:     2,
+			Description: "create bar",
+			Up: func(tx *sql.Tx, builder StatementBuilder) error {
+				_, err := tx.Exec("CREATE TABLE bar (id TEXT)")
+				return err
+			},
+		},
+		{
+			Service:     "svc1",
+			Version:     1,
+			Description: "create foo",
+			Up: func(tx *sql.Tx, builder StatementBuilder) error {
+				_, err := tx.Exec("CREATE TABLE foo (id TEXT)")
+				return err
+			},
+		},
+	}
+
+	// Happy path: v1 is applied, v2 is pending
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WithArgs(migrationLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	mock.ExpectExec(createTableRegex).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(selectAppliedRegex).WillReturnRows(sqlmock.NewRows([]string{"service", "version", "applied_at"}).AddRow("svc1", 1, 42))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE bar (id TEXT)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (service,version,description,applied_at) VALUES ($1,$2,$3,$4)")).
+		WithArgs("svc1", 2, "create bar", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).WithArgs(migrationLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := ApplyMigrations(db, NewPostgresStatementBuilder(), migrations, false)
+	assert.NoError(t, err)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, uint64(2), applied[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Dry run doesn't apply anything
+	db, mock, err = sqlmock.New()
+	assert.NoError(t, err)
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	mock.ExpectExec(createTableRegex).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(selectAppliedRegex).WillReturnRows(sqlmock.NewRows([]string{"service", "version", "applied_at"}))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	pending, err := ApplyMigrations(db, NewPostgresStatementBuilder(), migrations, true)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 2)
+	assert.Equal(t, uint64(1), pending[0].Version)
+	assert.Equal(t, uint64(2), pending[1].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// MariaDB lock times out
+	db, mock, err = sqlmock.New()
+	assert.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT GET_LOCK(?, ?)")).
+		WithArgs(migrationLockName, 60).
+		WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(0))
+
+	_, err = ApplyMigrations(db, NewMariaDBStatementBuilder(), migrations, false)
+	assert.EqualError(t, err, "failed to acquire migration lock: timed out waiting for lock orc8r_schema_migrations")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApplyServiceMigrations(t *testing.T) {
+	defer clearRegisteredMigrations()
+	MustRegisterMigrations(
+		Migration{
+			Service:     "svc1",
+			Version:     1,
+			Description: "create foo",
+			Up: func(tx *sql.Tx, builder StatementBuilder) error {
+				_, err := tx.Exec("CREATE TABLE foo (id TEXT)")
+				return err
+			},
+		},
+		Migration{
+			Service:     "svc2",
+			Version:     1,
+			Description: "create bar",
+			Up: func(tx *sql.Tx, builder StatementBuilder) error {
+				_, err := tx.Exec("CREATE TABLE bar (id TEXT)")
+				return err
+			},
+		},
+	)
+
+	// Only the migrations of the given services are applied
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT service, version, applied_at FROM schema_migrations")).
+		WillReturnRows(sqlmock.NewRows([]string{"service", "version", "applied_at"}))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE foo (id TEXT)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("svc1", 1, "create foo", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = ApplyServiceMigrations(db, NewPostgresStatementBuilder(), "svc1")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// sqlite3 is unsupported
+	sqliteDB, err := Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	defer sqliteDB.Close()
+	err = ApplyServiceMigrations(sqliteDB, NewPostgresStatementBuilder(), "svc1")
+	assert.Equal(t, ErrMigrationsUnsupported, err)
+}
+
+func TestGetPendingMigrations(t *testing.T) {
+	migrations := []Migration{
+		{Service: "svc1", Version: 1},
+		{Service: "svc1", Version: 2},
+		{Service: "svc2", Version: 1},
+	}
+
+	actual, err := getPendingMigrations(migrations, map[string]map[uint64]int64{"svc1": {1: 42}})
+	assert.NoError(t, err)
+	assert.Equal(t, []Migration{{Service: "svc1", Version: 2}, {Service: "svc2", Version: 1}}, actual)
+
+	// Migrations can't be applied out of order
+	_, err = getPendingMigrations(migrations, map[string]map[uint64]int64{"svc1": {2: 42}})
+	assert.EqualError(t, err, "migration 1 for service svc1 is older than applied migration 2")
+}
+
+func clearRegisteredMigrations() {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.migrations = map[string]map[uint64]Migration{}
+}
@@ -3,6 +3,8 @@ package sqorc
 import (
 	"database/sql"
 	"strings"
+
+	"github.com/mattn/go-sqlite3"
 )
 
 // Open is a wrapper for sql.Open which sets the max open connections to 1
@@ -21,3 +23,15 @@ func Open(driver string, source string) (*sql.DB, error) {
 	}
 	return db, nil
 }
+
+// SupportsRowLocking returns false if the DB is backed by sqlite3, which
+// doesn't support SELECT ... FOR UPDATE. sqlite3 locks the whole database
+// for writes instead.
+func SupportsRowLocking(db *sql.DB) bool {
+	return !isSqlite(db)
+}
+
+func isSqlite(db *sql.DB) bool {
+	_, ok := db.Driver().(*sqlite3.SQLiteDriver)
+	return ok
+}
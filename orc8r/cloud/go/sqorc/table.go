@@ -41,6 +41,8 @@ var postgresColumnTypeMap = map[ColumnType]string{
 	// BYTEA is effectively limited to 1GB
 	ColumnTypeBytes: "BYTEA",
 	ColumnTypeBool:  "BOOLEAN",
+	// 64 bits, for values such as unix timestamps
+	ColumnTypeBigInt: "BIGINT",
 }
 
 var mariaColumnTypeMap = map[ColumnType]string{
@@ -49,8 +51,9 @@ var mariaColumnTypeMap = map[ColumnType]string{
 	ColumnTypeInt:  "INT",
 	// LONGBLOB stores up to 4GB and the cost is a flat extra 2 bytes of
 	// storage over BLOB, which is limited to 64KB
-	ColumnTypeBytes: "LONGBLOB",
-	ColumnTypeBool:  "BOOLEAN",
+	ColumnTypeBytes:  "LONGBLOB",
+	ColumnTypeBool:   "BOOLEAN",
+	ColumnTypeBigInt: "BIGINT",
 }
 
 // ColumnOnDeleteOption is an enum type to specify ON DELETE behavior for
@@ -71,6 +74,7 @@ const (
 	ColumnTypeInt
 	ColumnTypeBytes
 	ColumnTypeBool
+	ColumnTypeBigInt
 	// Fill in other types as needed
 )
 
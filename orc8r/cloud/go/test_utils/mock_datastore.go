@@ -9,7 +9,10 @@ LICENSE file in the root directory of this source tree.
 package test_utils
 
 import (
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"magma/orc8r/cloud/go/datastore"
 )
@@ -19,6 +22,9 @@ type mockDatastoreTable map[string][]byte
 // Datastore backed by a golang map
 type MockDatastore struct {
 	store map[string]mockDatastoreTable
+	// expirations holds the expiration time of each key put with a TTL,
+	// keyed by table then key
+	expirations map[string]map[string]time.Time
 }
 
 var instance *MockDatastore
@@ -36,6 +42,7 @@ func GetMockDatastoreInstance() *MockDatastore {
 func NewMockDatastore() *MockDatastore {
 	ds := new(MockDatastore)
 	ds.store = make(map[string]mockDatastoreTable, 0)
+	ds.expirations = map[string]map[string]time.Time{}
 	return ds
 }
 
@@ -48,6 +55,17 @@ func (m *MockDatastore) initTable(table string) {
 func (m *MockDatastore) Put(table string, key string, value []byte) error {
 	m.initTable(table)
 	m.store[table][key] = value
+	delete(m.expirations[table], key)
+	return nil
+}
+
+func (m *MockDatastore) PutWithTTL(table string, key string, value []byte, ttl time.Duration) error {
+	m.initTable(table)
+	m.store[table][key] = value
+	if _, ok := m.expirations[table]; !ok {
+		m.expirations[table] = map[string]time.Time{}
+	}
+	m.expirations[table][key] = time.Now().Add(ttl)
 	return nil
 }
 
@@ -55,24 +73,37 @@ func (m *MockDatastore) PutMany(table string, valuesToPut map[string][]byte) (ma
 	m.initTable(table)
 	for k, v := range valuesToPut {
 		m.store[table][k] = v
+		delete(m.expirations[table], k)
 	}
 	return map[string]error{}, nil
 }
 
 func (m *MockDatastore) Get(table string, key string) ([]byte, uint64, error) {
 	m.initTable(table)
-	value, ok := m.store[table][key]
+	value, ok := m.get(table, key)
 	if ok {
 		return value, 0, nil
 	}
 	return nil, 0, datastore.ErrNotFound
 }
 
+// get returns the value of the key if it exists and hasn't expired
+func (m *MockDatastore) get(table string, key string) ([]byte, bool) {
+	value, ok := m.store[table][key]
+	if !ok {
+		return nil, false
+	}
+	if expiration, ok := m.expirations[table][key]; ok && !time.Now().Before(expiration) {
+		return nil, false
+	}
+	return value, true
+}
+
 func (m *MockDatastore) GetMany(table string, keys []string) (map[string]datastore.ValueWrapper, error) {
 	m.initTable(table)
 	ret := make(map[string]datastore.ValueWrapper, len(keys))
 	for _, k := range keys {
-		val, ok := m.store[table][k]
+		val, ok := m.get(table, k)
 		if ok {
 			ret[k] = datastore.ValueWrapper{
 				Value:      val,
@@ -87,6 +118,7 @@ func (m *MockDatastore) Delete(table string, key string) error {
 	m.initTable(table)
 
 	delete(m.store[table], key)
+	delete(m.expirations[table], key)
 	return nil
 }
 
@@ -94,6 +126,7 @@ func (m *MockDatastore) DeleteMany(table string, keys []string) (map[string]erro
 	m.initTable(table)
 	for _, k := range keys {
 		delete(m.store[table], k)
+		delete(m.expirations[table], k)
 	}
 	return map[string]error{}, nil
 }
@@ -102,20 +135,57 @@ func (m *MockDatastore) ListKeys(table string) ([]string, error) {
 	m.initTable(table)
 	keys := make([]string, 0, len(m.store[table]))
 	for key := range m.store[table] {
+		if _, ok := m.get(table, key); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (m *MockDatastore) ListKeysPaginated(table string, startAfter string, limit uint64) ([]string, error) {
+	keys := []string{}
+	for _, key := range m.getSortedKeys(table) {
+		if key <= startAfter {
+			continue
+		}
+		if limit > 0 && uint64(len(keys)) >= limit {
+			break
+		}
 		keys = append(keys, key)
 	}
 	return keys, nil
 }
 
+func (m *MockDatastore) GetRange(table string, prefix string, limit uint64) (map[string]datastore.ValueWrapper, error) {
+	ret := map[string]datastore.ValueWrapper{}
+	for _, key := range m.getSortedKeys(table) {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if limit > 0 && uint64(len(ret)) >= limit {
+			break
+		}
+		ret[key] = datastore.ValueWrapper{Value: m.store[table][key], Generation: 0}
+	}
+	return ret, nil
+}
+
+func (m *MockDatastore) getSortedKeys(table string) []string {
+	keys, _ := m.ListKeys(table)
+	sort.Strings(keys)
+	return keys
+}
+
 func (m *MockDatastore) DeleteTable(table string) error {
 	m.initTable(table)
 	delete(m.store, table)
+	delete(m.expirations, table)
 	return nil
 }
 
 func (m *MockDatastore) DoesKeyExist(table string, key string) (bool, error) {
 	m.initTable(table)
-	_, ok := m.store[table][key]
+	_, ok := m.get(table, key)
 	if ok {
 		return true, nil
 	} else {
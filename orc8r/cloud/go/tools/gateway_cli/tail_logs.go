@@ -9,6 +9,7 @@ LICENSE file in the root directory of this source tree.
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -37,7 +38,9 @@ func tailLogsCmd(cmd *cobra.Command, args []string) {
 	if len(args) == 1 {
 		service = args[0]
 	}
-	stream, err := magmad.TailGatewayLogs(networkId, gatewayId, service)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := magmad.TailGatewayLogs(ctx, networkId, gatewayId, service)
 	if err != nil {
 		glog.Error(err)
 		os.Exit(1)
@@ -48,6 +51,7 @@ func tailLogsCmd(cmd *cobra.Command, args []string) {
 	signal.Notify(term, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-term
+		cancel()
 	}()
 	for {
 		line, err := stream.Recv()
@@ -240,7 +240,7 @@ func main() {
 
 	template.IsCA = *isCA
 	if *isCA {
-		template.KeyUsage |= x509.KeyUsageCertSign
+		template.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
 	}
 
 	ski := make([]byte, 32)
@@ -0,0 +1,14 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package migrations
+
+// LegacyConfigsServiceName identifies the registered migrations of the legacy
+// magmad config tables to the config service. Legacy configs were only ever
+// stored in PostgreSQL, so these migrations are no-ops on other dialects.
+const LegacyConfigsServiceName = "legacy_configs"
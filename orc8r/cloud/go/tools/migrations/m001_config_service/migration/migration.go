@@ -39,6 +39,11 @@ const MeshConfigTable = "mesh_config"
 
 const NewConfigTable = "configurations"
 
+// Statements to write a migrated config. Configs are only overwritten when the
+// migration is run by hand.
+const upsertConfigQueryFormat = "INSERT INTO %s (type, key, value) VALUES ($1, $2, $3) ON CONFLICT (type, key) DO UPDATE SET value=$4"
+const insertConfigQueryFormat = "INSERT INTO %s (type, key, value) VALUES ($1, $2, $3) ON CONFLICT (type, key) DO NOTHING"
+
 // Redeclare config types and old config keys here for the same reason
 const CellularNetworkType = "cellular_network"
 const CellularGatewayType = "cellular_gateway"
@@ -93,32 +98,46 @@ func Migrate(dbDriver string, dbSource string) error {
 		return fmt.Errorf("Error setting transaction mode to serializable: %s", err)
 	}
 
-	glog.Error("Migrating network configs...")
-	if err := MigrateNetworkConfigs(tx); err != nil {
+	if err := migrateAll(tx, true); err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	glog.Error("Migrating gateway configs...")
-	if err := MigrateGatewayConfigs(tx); err != nil {
-		tx.Rollback()
-		return err
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("Error committing transaction: %s", err)
 	}
+	return nil
+}
 
-	glog.Error("Migrating mesh configs...")
-	if err := MigrateMeshConfigs(tx); err != nil {
-		tx.Rollback()
+func migrateAll(tx *sql.Tx, overwrite bool) error {
+	glog.Error("Migrating network configs...")
+	if err := migrateNetworkConfigs(tx, overwrite); err != nil {
 		return err
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		return fmt.Errorf("Error committing transaction: %s", err)
+	glog.Error("Migrating gateway configs...")
+	if err := migrateGatewayConfigs(tx, overwrite); err != nil {
+		return err
 	}
-	return nil
+
+	glog.Error("Migrating mesh configs...")
+	return migrateMeshConfigs(tx, overwrite)
 }
 
 func MigrateNetworkConfigs(tx *sql.Tx) error {
+	return migrateNetworkConfigs(tx, true)
+}
+
+func MigrateGatewayConfigs(tx *sql.Tx) error {
+	return migrateGatewayConfigs(tx, true)
+}
+
+func MigrateMeshConfigs(tx *sql.Tx) error {
+	return migrateMeshConfigs(tx, true)
+}
+
+func migrateNetworkConfigs(tx *sql.Tx, overwrite bool) error {
 	networkConfigs, err := getAllConfigs(tx, NetworkConfigTable)
 	if err != nil {
 		return fmt.Errorf("Error getting existing network configs: %s", err)
@@ -126,52 +145,52 @@ func MigrateNetworkConfigs(tx *sql.Tx) error {
 
 	sortedNetworkIds := getSortedConfigIds(networkConfigs)
 	for _, networkId := range sortedNetworkIds {
-		if err := migrateMagmadConfigs(tx, networkId, newNetworkTypesByOldKey, map[string]*Config{networkId: networkConfigs[networkId]}); err != nil {
+		if err := migrateMagmadConfigs(tx, networkId, newNetworkTypesByOldKey, map[string]*Config{networkId: networkConfigs[networkId]}, overwrite); err != nil {
 			return fmt.Errorf("Error migrating network config for network %s: %s", networkId, err)
 		}
 	}
 	return nil
 }
 
-func MigrateGatewayConfigs(tx *sql.Tx) error {
+func migrateGatewayConfigs(tx *sql.Tx, overwrite bool) error {
 	networkIds, err := getAllNetworkIds(tx)
 	if err != nil {
 		return fmt.Errorf("Error getting existing network ids: %s", err)
 	}
 
 	for _, networkId := range networkIds {
-		if err := migrateGatewayConfigsForNetwork(tx, networkId); err != nil {
+		if err := migrateGatewayConfigsForNetwork(tx, networkId, overwrite); err != nil {
 			return fmt.Errorf("Error migrating gateway configs for network %s: %s", networkId, err)
 		}
 	}
 	return nil
 }
 
-func MigrateMeshConfigs(tx *sql.Tx) error {
+func migrateMeshConfigs(tx *sql.Tx, overwrite bool) error {
 	networkIds, err := getAllNetworkIds(tx)
 	if err != nil {
 		return fmt.Errorf("Error getting existing network ids: %s", err)
 	}
 
 	for _, networkId := range networkIds {
-		if err := migrateMeshConfigsForNetwork(tx, networkId); err != nil {
+		if err := migrateMeshConfigsForNetwork(tx, networkId, overwrite); err != nil {
 			return fmt.Errorf("Error migrating mesh configs for network %s: %s", networkId, err)
 		}
 	}
 	return nil
 }
 
-func migrateGatewayConfigsForNetwork(tx *sql.Tx, networkId string) error {
+func migrateGatewayConfigsForNetwork(tx *sql.Tx, networkId string, overwrite bool) error {
 	gatewayConfigTable := migrations.GetTableName(networkId, GatewayConfigTable)
 	gatewayConfigs, err := getAllConfigs(tx, gatewayConfigTable)
 	if err != nil {
 		return err
 	}
 
-	return migrateMagmadConfigs(tx, networkId, newGatewayTypesByOldKey, gatewayConfigs)
+	return migrateMagmadConfigs(tx, networkId, newGatewayTypesByOldKey, gatewayConfigs, overwrite)
 }
 
-func migrateMeshConfigsForNetwork(tx *sql.Tx, networkId string) error {
+func migrateMeshConfigsForNetwork(tx *sql.Tx, networkId string, overwrite bool) error {
 	err := initConfigTable(tx, networkId)
 	if err != nil {
 		return fmt.Errorf("Error initializing new config table: %s", err)
@@ -179,8 +198,7 @@ func migrateMeshConfigsForNetwork(tx *sql.Tx, networkId string) error {
 
 	oldTable := migrations.GetTableName(networkId, MeshConfigTable)
 	newTable := migrations.GetTableName(networkId, NewConfigTable)
-	queryFormat := "INSERT INTO %s (type, key, value) VALUES($1, $2, $3) ON CONFLICT (type, key) DO UPDATE SET value=$4"
-	stmt, err := tx.Prepare(fmt.Sprintf(queryFormat, newTable))
+	stmt, err := prepareConfigWrite(tx, newTable, overwrite)
 	if err != nil {
 		return fmt.Errorf("Error preparing upsert statement: %s", err)
 	}
@@ -191,7 +209,7 @@ func migrateMeshConfigsForNetwork(tx *sql.Tx, networkId string) error {
 	}
 	sortedMeshIds := getSortedMeshIds(meshConfigs)
 	for _, meshId := range sortedMeshIds {
-		_, err = stmt.Exec(MeshType, meshId, meshConfigs[meshId], meshConfigs[meshId])
+		_, err = stmt.Exec(getConfigWriteArgs(MeshType, meshId, meshConfigs[meshId], overwrite)...)
 		if err != nil {
 			return err
 		}
@@ -199,15 +217,14 @@ func migrateMeshConfigsForNetwork(tx *sql.Tx, networkId string) error {
 	return nil
 }
 
-func migrateMagmadConfigs(tx *sql.Tx, networkId string, newTypesByOldKey map[string]string, configsByKey map[string]*Config) error {
+func migrateMagmadConfigs(tx *sql.Tx, networkId string, newTypesByOldKey map[string]string, configsByKey map[string]*Config, overwrite bool) error {
 	err := initConfigTable(tx, networkId)
 	if err != nil {
 		return fmt.Errorf("Error initializing new config table: %s", err)
 	}
 
 	table := migrations.GetTableName(networkId, NewConfigTable)
-	queryFormat := "INSERT INTO %s (type, key, value) VALUES ($1, $2, $3) ON CONFLICT (type, key) DO UPDATE SET value=$4"
-	stmt, err := tx.Prepare(fmt.Sprintf(queryFormat, table))
+	stmt, err := prepareConfigWrite(tx, table, overwrite)
 	if err != nil {
 		return fmt.Errorf("Error preparing upsert statement: %s", err)
 	}
@@ -215,7 +232,7 @@ func migrateMagmadConfigs(tx *sql.Tx, networkId string, newTypesByOldKey map[str
 
 	sortedKeys := getSortedConfigIds(configsByKey)
 	for _, newKey := range sortedKeys {
-		err = migrateMagmadConfig(stmt, newKey, newTypesByOldKey, configsByKey[newKey])
+		err = migrateMagmadConfig(stmt, newKey, newTypesByOldKey, configsByKey[newKey], overwrite)
 		if err != nil {
 			return fmt.Errorf("Error migrating magmad config for %s in network %s: %s", newKey, networkId, err)
 		}
@@ -223,7 +240,7 @@ func migrateMagmadConfigs(tx *sql.Tx, networkId string, newTypesByOldKey map[str
 	return nil
 }
 
-func migrateMagmadConfig(stmt *sql.Stmt, newKey string, newTypesByOldKey map[string]string, config *Config) error {
+func migrateMagmadConfig(stmt *sql.Stmt, newKey string, newTypesByOldKey map[string]string, config *Config, overwrite bool) error {
 	sortedConfigKeys := getSortedConfigKeys(config)
 	for _, configKey := range sortedConfigKeys {
 		newConfigType, ok := newTypesByOldKey[configKey]
@@ -237,7 +254,7 @@ func migrateMagmadConfig(stmt *sql.Stmt, newKey string, newTypesByOldKey map[str
 			continue
 		}
 
-		_, err := stmt.Exec(newConfigType, newKey, val, val)
+		_, err := stmt.Exec(getConfigWriteArgs(newConfigType, newKey, val, overwrite)...)
 		if err != nil {
 			return err
 		}
@@ -245,6 +262,21 @@ func migrateMagmadConfig(stmt *sql.Stmt, newKey string, newTypesByOldKey map[str
 	return nil
 }
 
+func prepareConfigWrite(tx *sql.Tx, table string, overwrite bool) (*sql.Stmt, error) {
+	queryFormat := insertConfigQueryFormat
+	if overwrite {
+		queryFormat = upsertConfigQueryFormat
+	}
+	return tx.Prepare(fmt.Sprintf(queryFormat, table))
+}
+
+func getConfigWriteArgs(configType string, key string, value []byte, overwrite bool) []interface{} {
+	if overwrite {
+		return []interface{}{configType, key, value, value}
+	}
+	return []interface{}{configType, key, value}
+}
+
 func getAllNetworkIds(tx *sql.Tx) ([]string, error) {
 	return migrations.GetAllKeysFromTable(tx, NetworkConfigTable)
 }
@@ -12,8 +12,11 @@ import (
 	"bytes"
 	"database/sql"
 	"errors"
+	"regexp"
 	"testing"
 
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/tools/migrations"
 	"magma/orc8r/cloud/go/tools/migrations/m001_config_service/migration"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -200,6 +203,66 @@ func TestMigrateNetworkConfigs_Errors(t *testing.T) {
 	assert.EqualError(t, err, "Error migrating network config for network network1: Error migrating magmad config for network1 in network network1: Mock upsert error")
 }
 
+func TestRegisteredMigration(t *testing.T) {
+	var up func(*sql.Tx, sqorc.StatementBuilder) error
+	for _, mig := range sqorc.GetRegisteredMigrations() {
+		if mig.Service == migrations.LegacyConfigsServiceName && mig.Version == 1 {
+			up = mig.Up
+		}
+	}
+	if up == nil {
+		t.Fatal("Legacy config migration isn't registered")
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening stub DB conn: %s", err)
+	}
+	defer db.Close()
+
+	// No legacy tables
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT EXISTS").WithArgs("networks").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	tx := openMockDBTx(t, db)
+	err = up(tx, sqorc.NewPostgresStatementBuilder())
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Existing configs aren't overwritten
+	insertRegex := regexp.QuoteMeta("INSERT INTO network1_configurations (type, key, value) VALUES ($1, $2, $3) ON CONFLICT (type, key) DO NOTHING")
+	mock.ExpectBegin()
+	expectSelectExists(mock, "networks")
+	expectSelectExists(mock, "networks")
+	mock.ExpectQuery("SELECT key, value FROM networks").
+		WillReturnRows(sqlmock.NewRows([]string{"key", "value"}).AddRow("network1", getConfigFixture(t, map[string][]byte{"magmad": []byte("hello")})))
+	expectCreateTable(mock, "network1_configurations")
+	prepare := mock.ExpectPrepare(insertRegex)
+	prepare.ExpectExec().WithArgs("magmad_network", "network1", []byte("hello")).WillReturnResult(mockResult)
+	prepare.WillBeClosed()
+
+	mock.ExpectQuery("SELECT key FROM networks").WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("network1"))
+	mock.ExpectQuery("SELECT EXISTS").WithArgs("network1_configs").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	expectCreateTable(mock, "network1_configurations")
+	mock.ExpectPrepare(insertRegex).WillBeClosed()
+
+	mock.ExpectQuery("SELECT key FROM networks").WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("network1"))
+	expectCreateTable(mock, "network1_configurations")
+	mock.ExpectPrepare(insertRegex)
+	mock.ExpectQuery("SELECT EXISTS").WithArgs("network1_mesh_config").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	tx = openMockDBTx(t, db)
+	err = up(tx, sqorc.NewPostgresStatementBuilder())
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Legacy configs were never stored in MariaDB
+	mock.ExpectBegin()
+	tx = openMockDBTx(t, db)
+	err = up(tx, sqorc.NewMariaDBStatementBuilder())
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func openMockDBTx(t *testing.T, db *sql.DB) *sql.Tx {
 	tx, err := db.Begin()
 	if err != nil {
@@ -0,0 +1,40 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package migration
+
+import (
+	"database/sql"
+
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/tools/migrations"
+)
+
+func init() {
+	sqorc.MustRegisterMigrations(sqorc.Migration{
+		Service:     migrations.LegacyConfigsServiceName,
+		Version:     1,
+		Description: "move magmad and mesh configs to the config service",
+		Up:          migrateRegistered,
+	})
+}
+
+// migrateRegistered runs the migration as a registered schema migration.
+// Unlike Migrate, configs already in the config service tables are left
+// alone, since the migration may have been run by hand and the configs
+// updated since.
+func migrateRegistered(tx *sql.Tx, builder sqorc.StatementBuilder) error {
+	if !sqorc.IsPostgres(builder) {
+		return nil
+	}
+	exists, err := migrations.DoesTableExist(tx, NetworkConfigTable)
+	if err != nil || !exists {
+		return err
+	}
+	return migrateAll(tx, false)
+}
@@ -55,14 +55,7 @@ func Migrate(dbDriver string, dbSource string, shouldDropTables bool) error {
 		return fmt.Errorf("Error setting transaction mode to serializable: %s", err)
 	}
 
-	glog.Error("Migrating network records...")
-	if err := MigrateNetworkConfigsToRecords(tx); err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	glog.Error("Deleting magmad network configs...")
-	if err := DeleteMagmadNetworkConfigs(tx); err != nil {
+	if err := migrateNetworkRecords(tx); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -88,6 +81,16 @@ func Migrate(dbDriver string, dbSource string, shouldDropTables bool) error {
 	return nil
 }
 
+func migrateNetworkRecords(tx *sql.Tx) error {
+	glog.Error("Migrating network records...")
+	if err := MigrateNetworkConfigsToRecords(tx); err != nil {
+		return err
+	}
+
+	glog.Error("Deleting magmad network configs...")
+	return DeleteMagmadNetworkConfigs(tx)
+}
+
 func MigrateNetworkConfigsToRecords(tx *sql.Tx) error {
 	marshaledLegacyConfigs, err := migrations.GetAllValuesFromTable(tx, NetworkTable)
 	if err != nil {
@@ -124,7 +127,15 @@ func DeleteMagmadNetworkConfigs(tx *sql.Tx) error {
 	queryFormat := "DELETE FROM %s WHERE type = $1 AND key = $2"
 	for _, networkId := range networkIds {
 		tableName := migrations.GetTableName(networkId, NewConfigTable)
-		_, err := tx.Exec(fmt.Sprintf(queryFormat, tableName), MagmadNetworkType, networkId)
+		// Networks without configs don't have a config table
+		exists, err := migrations.DoesTableExist(tx, tableName)
+		if err != nil {
+			return fmt.Errorf("Error checking if table %s exists: %s", tableName, err)
+		}
+		if !exists {
+			continue
+		}
+		_, err = tx.Exec(fmt.Sprintf(queryFormat, tableName), MagmadNetworkType, networkId)
 		if err != nil {
 			return fmt.Errorf("Failed to delete magmad network configs for network %s: %s", networkId, err)
 		}
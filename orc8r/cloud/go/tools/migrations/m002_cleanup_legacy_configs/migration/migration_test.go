@@ -11,6 +11,7 @@ package migration_test
 import (
 	"bytes"
 	"database/sql"
+	"regexp"
 	"testing"
 
 	"magma/orc8r/cloud/go/tools/migrations/m002_cleanup_legacy_configs/migration"
@@ -52,6 +53,29 @@ func TestMigrateNetworkConfigsToRecords(t *testing.T) {
 	mock.ExpectationsWereMet()
 }
 
+func TestDeleteMagmadNetworkConfigs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening stub DB conn: %s", err)
+	}
+	defer db.Close()
+
+	// Networks without a config table are skipped
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT key FROM networks").
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("network1").AddRow("network2"))
+	expectSelectExists(mock, "network1_configurations")
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM network1_configurations WHERE type = $1 AND key = $2")).
+		WithArgs("magmad_network", "network1").
+		WillReturnResult(mockResult)
+	mock.ExpectQuery("SELECT EXISTS").WithArgs("network2_configurations").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	tx := openMockDBTx(t, db)
+	err = migration.DeleteMagmadNetworkConfigs(tx)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func openMockDBTx(t *testing.T, db *sql.DB) *sql.Tx {
 	tx, err := db.Begin()
 	if err != nil {
@@ -0,0 +1,39 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package migration
+
+import (
+	"database/sql"
+
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/tools/migrations"
+)
+
+func init() {
+	sqorc.MustRegisterMigrations(sqorc.Migration{
+		Service:     migrations.LegacyConfigsServiceName,
+		Version:     2,
+		Description: "migrate legacy network configs to network records",
+		Up:          migrateRegistered,
+	})
+}
+
+// migrateRegistered runs the migration as a registered schema migration.
+// The old gateway and mesh config tables are never dropped; run the migration
+// by hand with -dropTables to clean them up.
+func migrateRegistered(tx *sql.Tx, builder sqorc.StatementBuilder) error {
+	if !sqorc.IsPostgres(builder) {
+		return nil
+	}
+	exists, err := migrations.DoesTableExist(tx, NetworkTable)
+	if err != nil || !exists {
+		return err
+	}
+	return migrateNetworkRecords(tx)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/tools/commands"
+)
+
+var dryRun bool
+
+// Apply command - applies all pending migrations in order
+func init() {
+	cmd := commandRegistry.Add(
+		"apply",
+		"Apply all pending migrations",
+		apply)
+	f := cmd.Flags()
+	f.BoolVar(&dryRun, "dry_run", false, "Print the pending migrations without applying them")
+	f.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\tUsage: %s %s [OPTIONS]\n", os.Args[0], cmd.Name())
+		f.PrintDefaults()
+	}
+}
+
+func apply(cmd *commands.Command, args []string) int {
+	db := openDB()
+	defer db.Close()
+
+	migrations, err := sqorc.ApplyMigrations(db, sqorc.GetSqlBuilder(), sqorc.GetRegisteredMigrations(), dryRun)
+	for _, migration := range migrations {
+		verb := "Applied"
+		if dryRun {
+			verb = "Pending"
+		}
+		fmt.Printf("%s %s\t%d\t%s\n", verb, migration.Service, migration.Version, migration.Description)
+	}
+	if err != nil {
+		log.Fatalf("Apply Migrations Error: %s", err)
+	}
+	if len(migrations) == 0 {
+		fmt.Println("No pending migrations")
+	}
+	return 0
+}
+
+func openDB() *sql.DB {
+	db, err := sqorc.Open(datastore.SQL_DRIVER, datastore.DATABASE_SOURCE)
+	if err != nil {
+		log.Fatalf("Could not open DB connection: %s", err)
+	}
+	return db
+}
@@ -0,0 +1,49 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/tools/commands"
+)
+
+// List command - prints out all registered migrations and whether they've
+// been applied
+func init() {
+	cmd := commandRegistry.Add(
+		"list",
+		"List all registered migrations and whether they have been applied",
+		list)
+	cmd.Flags().Usage = func() {
+		fmt.Fprintf(os.Stderr, "\tUsage: %s %s\n", os.Args[0], cmd.Name())
+	}
+}
+
+func list(cmd *commands.Command, args []string) int {
+	db := openDB()
+	defer db.Close()
+
+	statuses, err := sqorc.ListMigrations(db, sqorc.GetSqlBuilder(), sqorc.GetRegisteredMigrations())
+	if err != nil {
+		log.Fatalf("List Migrations Error: %s", err)
+	}
+	for _, status := range statuses {
+		applied := "pending"
+		if status.Applied {
+			applied = fmt.Sprintf("applied %s", time.Unix(status.AppliedAt, 0).UTC().Format(time.RFC3339))
+		}
+		fmt.Printf("%s\t%d\t%s\t%s\n", status.Service, status.Version, applied, status.Description)
+	}
+	return 0
+}
@@ -0,0 +1,67 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Command Line Tool to list and apply the registered SQL schema migrations
+// of orchestrator services. The database is configured by the SQL_DRIVER,
+// DATABASE_SOURCE and SQL_DIALECT env vars.
+//
+// The lte, feg and cwf services store their tables in the datastore, so their
+// schema is migrated by the datastore migrations registered here. Services
+// also apply their own pending migrations on startup.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"magma/orc8r/cloud/go/tools/commands"
+
+	// Packages register their migrations on init
+	_ "magma/orc8r/cloud/go/datastore"
+	_ "magma/orc8r/cloud/go/services/bootstrapper"
+	_ "magma/orc8r/cloud/go/services/configurator"
+	_ "magma/orc8r/cloud/go/services/device"
+	_ "magma/orc8r/cloud/go/services/dispatcher"
+	_ "magma/orc8r/cloud/go/services/state"
+	_ "magma/orc8r/cloud/go/tools/migrations/m001_config_service/migration"
+	_ "magma/orc8r/cloud/go/tools/migrations/m002_cleanup_legacy_configs/migration"
+)
+
+var commandRegistry = new(commands.Map)
+
+func main() {
+	flag.Parse()
+
+	// Init help for all commands
+	flag.Usage = func() {
+		cmd := os.Args[0]
+		fmt.Printf(
+			"\nUsage: \033[1m%s command [OPTIONS]\033[0m\n\n",
+			filepath.Base(cmd))
+		flag.PrintDefaults()
+		fmt.Println("\nCommands:")
+		commandRegistry.Usage()
+	}
+	cmdName := flag.Arg(0)
+	if len(flag.Args()) < 1 || cmdName == "" || cmdName == "help" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	cmd := commandRegistry.Get(cmdName)
+	if cmd == nil {
+		fmt.Println("\nInvalid Command: ", cmdName)
+		flag.Usage()
+		os.Exit(1)
+	}
+	args := os.Args[2:]
+	cmd.Flags().Parse(args)
+	os.Exit(cmd.Handle(args))
+}
@@ -25,7 +25,7 @@ func GetTableName(networkId string, baseName string) string {
 func GetAllValuesFromTable(tx *sql.Tx, table string) (map[string][]byte, error) {
 	// Not every network may have gateways or meshes, in which case the
 	// corresponding tables won't exist. Check and return early if so.
-	exists, err := doesTableExist(tx, table)
+	exists, err := DoesTableExist(tx, table)
 	if err != nil {
 		return nil, fmt.Errorf("Error checking if table %s exists: %s", table, err)
 	}
@@ -55,8 +55,9 @@ func GetAllValuesFromTable(tx *sql.Tx, table string) (map[string][]byte, error)
 	return ret, nil
 }
 
+// DoesTableExist returns true if the table exists.
 // IMPORTANT: This is NOT portable, and ONLY works on postgres!
-func doesTableExist(tx *sql.Tx, table string) (bool, error) {
+func DoesTableExist(tx *sql.Tx, table string) (bool, error) {
 	row := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM information_schema.tables WHERE table_name=$1)", table)
 	ret := false
 	err := row.Scan(&ret)
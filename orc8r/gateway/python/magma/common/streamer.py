@@ -16,7 +16,7 @@ import abc
 import grpc
 import snowflake
 from google.protobuf import any_pb2
-from orc8r.protos.streamer_pb2 import StreamRequest
+from orc8r.protos.streamer_pb2 import DeltaStreamArgs, StreamRequest
 from orc8r.protos.streamer_pb2_grpc import StreamerStub
 
 from magma.common import serialization_utils
@@ -68,6 +68,31 @@ class StreamerClient(threading.Thread):
             """
             raise NotImplementedError()
 
+        def supports_deltas(self, stream_name: str) -> bool:
+            """
+            Override to return True to request incremental updates for the
+            stream. Incremental update streams are kept open by the cloud,
+            which sends a new batch whenever the data changes. Batches which
+            aren't resyncs only hold the added and changed keys, and the
+            deleted keys are passed to process_deletes.
+
+            Args:
+                stream_name: Name of the stream
+            """
+            return False
+
+        def process_deletes(self, stream_name, deleted_keys):
+            """
+            Called with the keys deleted since the previous batch of an
+            incremental update stream, after process_update was called with
+            the rest of the batch.
+
+            Args:
+                stream_name (string): Name of the stream
+                deleted_keys (string[]): Deleted keys
+            """
+            raise NotImplementedError()
+
     def __init__(self, stream_callbacks, loop):
         """
         Args:
@@ -78,6 +103,12 @@ class StreamerClient(threading.Thread):
         threading.Thread.__init__(self)
         self._stream_callbacks = stream_callbacks
         self._loop = loop
+        # Version of the last batch applied for each incremental update
+        # stream, sent back to the cloud on reconnect
+        self._versions = {}
+        # Incremental update streams which failed to apply a batch since
+        # they were last opened
+        self._failed_streams = set()
         # Set this thread as daemon thread. We can kill this background
         # thread abruptly since we handle all updates (and database
         # transactions) in the asyncio event loop.
@@ -124,6 +155,10 @@ class StreamerClient(threading.Thread):
 
     def process_stream_updates(self, client, stream_name, callback):
         extra_args = self._get_extra_args_any(callback, stream_name)
+        if callback.supports_deltas(stream_name):
+            self._process_delta_stream_updates(
+                client, stream_name, callback, extra_args)
+            return
         request = StreamRequest(gatewayId=snowflake.snowflake(),
                                 stream_name=stream_name,
                                 extra_args=extra_args)
@@ -136,15 +171,67 @@ class StreamerClient(threading.Thread):
                 update_batch.resync,
             )
 
+    def _process_delta_stream_updates(self, client, stream_name, callback,
+                                      extra_args):
+        """
+        Stream incremental updates, starting from the version of the last
+        batch applied. The cloud keeps the stream open, so the stream is
+        reopened once it times out.
+        """
+        self._failed_streams.discard(stream_name)
+        delta_args = DeltaStreamArgs(
+            version=self._versions.get(stream_name, ''),
+            extra_args=extra_args,
+        )
+        request = StreamRequest(gatewayId=snowflake.snowflake(),
+                                stream_name=stream_name,
+                                extra_args=self._pack_any(delta_args))
+        try:
+            for update_batch in client.GetUpdates(
+                    request, timeout=self._stream_timeout):
+                self._loop.call_soon_threadsafe(
+                    self._apply_delta_batch,
+                    callback,
+                    stream_name,
+                    update_batch,
+                )
+        except grpc.RpcError as err:
+            if err.code() != grpc.StatusCode.DEADLINE_EXCEEDED:
+                raise
+
+    def _apply_delta_batch(self, callback, stream_name, update_batch):
+        if stream_name in self._failed_streams:
+            return
+        try:
+            if update_batch.resync or update_batch.updates:
+                callback.process_update(
+                    stream_name, update_batch.updates, update_batch.resync)
+            if update_batch.deleted_keys:
+                callback.process_deletes(
+                    stream_name, update_batch.deleted_keys)
+        except Exception as exp:  # pylint: disable=broad-except
+            # Later batches build on this one, so skip them and resync
+            # on reconnect
+            logging.error("Error applying updates of stream %s: %s",
+                          stream_name, exp)
+            self._failed_streams.add(stream_name)
+            self._versions.pop(stream_name, None)
+            return
+        self._versions[stream_name] = update_batch.version
+
     @staticmethod
     def _get_extra_args_any(callback, stream_name):
         extra_args = callback.get_request_args(stream_name)
         if extra_args is None:
             return None
         else:
-            extra_any = any_pb2.Any()
-            extra_any.Pack(extra_args)
-            return extra_any
+            return StreamerClient._pack_any(extra_args)
+
+    @staticmethod
+    def _pack_any(message):
+        extra_any = any_pb2.Any()
+        extra_any.Pack(message)
+        return extra_any
 
 
 def get_stream_serialize_filename(stream_name):
@@ -22,10 +22,13 @@ from cryptography.hazmat.primitives.asymmetric import ec
 from cryptography.hazmat.primitives.asymmetric.utils import \
     decode_dss_signature
 from google.protobuf.duration_pb2 import Duration
-from orc8r.protos.bootstrapper_pb2 import ChallengeKey, Response
+from orc8r.protos.bootstrapper_pb2 import ChallengeKey, \
+    RegistrationRequest, Response
 from orc8r.protos.bootstrapper_pb2_grpc import BootstrapperStub
 from orc8r.protos.certifier_pb2 import CSR
+from orc8r.protos.common_pb2 import Void
 from orc8r.protos.identity_pb2 import AccessGatewayID, Identity
+from orc8r.protos.magmad_pb2_grpc import CheckindStub
 
 import magma.common.cert_utils as cert_utils
 from magma.common.cert_validity import cert_is_invalid
@@ -58,6 +61,10 @@ class BootstrapManager(SDWatchdogTask):
     gateways' session certs would be written to /var/opt/magma/certs.
     Before the session certs expire, bootstrap would make sure we
     fetch new certs by maintaining a timer internally.
+
+    If a registration token file is configured and present, the gateway
+    first registers itself with the token instead of answering a challenge.
+    The token file is removed once the token has been used.
     """
     # delay in asyncio should not exceed one day
     PERIODIC_BOOTSTRAP_CHECK_INTERVAL = datetime.timedelta(hours=1)
@@ -75,6 +82,8 @@ class BootstrapManager(SDWatchdogTask):
 
         self._challenge_key_file \
             = service.config['bootstrap_config']['challenge_key']
+        self._registration_token_file \
+            = service.config['bootstrap_config'].get('registration_token')
         self._hw_id = snowflake.snowflake()
         self._gateway_key_file = control_proxy_config['gateway_key']
         self._gateway_cert_file = control_proxy_config['gateway_cert']
@@ -183,11 +192,45 @@ class BootstrapManager(SDWatchdogTask):
                 'Certificate is not valid until %s', cert.not_valid_before)
             await self._bootstrap_now()
             return
+        if await self._renewal_advised():
+            await self._bootstrap_now()
+            return
 
         # no need to restart control_proxy
         await self._bootstrap_success_cb(False)
         self._schedule_next_bootstrap_check()
 
+    async def _renewal_advised(self):
+        """Ask the cloud whether the current certificate should be renewed
+
+        The cloud advises renewal once the certificate is past its renewal
+        time, or after it was superseded by a newer certificate. If the
+        cloud can't be reached, renewal falls back to the local expiry check.
+        """
+        try:
+            chan = ServiceRegistry.get_rpc_channel(
+                'checkind', ServiceRegistry.CLOUD)
+        except ValueError as exp:
+            logging.error('Failed to get rpc channel: %s', exp)
+            return False
+        client = CheckindStub(chan)
+        try:
+            advice = await grpc_async_wrapper(
+                client.GetRenewalAdvice.future(Void()),
+                self._loop
+            )
+        except grpc.RpcError as err:
+            logging.error(
+                'GetRenewalAdvice error! [%s] %s', err.code(), err.details())
+            return False
+        if advice.superseded:
+            logging.info('Certificate was superseded, start bootstrapping')
+            return True
+        if advice.renew_now:
+            logging.info('Certificate renewal advised, start bootstrapping')
+            return True
+        return False
+
     async def _bootstrap_now(self):
         """Main entrance to bootstrapping
 
@@ -208,6 +251,11 @@ class BootstrapManager(SDWatchdogTask):
             return
 
         client = BootstrapperStub(chan)
+        token = self._load_registration_token()
+        if token:
+            await self._register_with_token(client, token)
+            return
+
         try:
             result = await grpc_async_wrapper(
                 client.GetChallenge.future(AccessGatewayID(id=self._hw_id)),
@@ -248,6 +296,66 @@ class BootstrapManager(SDWatchdogTask):
             return
         await self._request_sign(response)
 
+    def _load_registration_token(self):
+        """Load the registration token of the gateway, if it has one
+
+        Returns:
+            token string, None if no token is configured or present
+        """
+        if not self._registration_token_file or \
+                not os.path.exists(self._registration_token_file):
+            return None
+        try:
+            with open(self._registration_token_file) as f:
+                return f.read().strip() or None
+        except IOError as exp:
+            logging.error('Failed to read registration token: %s', exp)
+            return None
+
+    def _remove_registration_token(self):
+        try:
+            os.remove(self._registration_token_file)
+        except OSError as exp:
+            logging.error('Failed to remove registration token: %s', exp)
+
+    async def _register_with_token(self, client, token):
+        """Register the gateway with a registration token
+
+        On success the signed certificate is handled like the result of
+        RequestSign. If the registration fails, it is retried with the same
+        token, which resumes the registration in the cloud. Tokens which were
+        rejected are removed, so that the next bootstrap answers a challenge.
+        """
+        try:
+            self._gateway_key = ec.generate_private_key(
+                ec.SECP384R1(), default_backend())
+            csr = self._create_csr()
+            request = self._construct_registration_request(token, csr)
+        except (InternalError, BootstrapError) as exp:
+            logging.error('Fail to create registration request: %s', exp)
+            BOOTSTRAP_EXCEPTION.labels(
+                cause='RegisterWithTokenCreateRequest').inc()
+            self._schedule_next_bootstrap(hard_failure=True)
+            return
+
+        try:
+            result = await grpc_async_wrapper(
+                client.RegisterWithToken.future(request),
+                self._loop
+            )
+        except grpc.RpcError as err:
+            logging.error('RegisterWithToken error! [%s] %s',
+                          err.code(), err.details())
+            BOOTSTRAP_EXCEPTION.labels(cause='RegisterWithTokenResp').inc()
+            if err.code() in (grpc.StatusCode.PERMISSION_DENIED,
+                              grpc.StatusCode.ALREADY_EXISTS):
+                self._remove_registration_token()
+            self._schedule_next_bootstrap(hard_failure=False)
+            return
+
+        self._remove_registration_token()
+        await self._request_sign_done_success(result)
+
     def _get_challenge_done_fail(self, err):
         err = 'GetChallenge error! [%s] %s' % (err.code(), err.details())
         logging.error(err)
@@ -379,6 +487,42 @@ class BootstrapManager(SDWatchdogTask):
             raise BootstrapError('Unknown key type: %s' % challenge.key_type)
         return response
 
+    def _construct_registration_request(self, token, csr):
+        """Construct a registration request, which proves possession of the
+        challenge key by signing the token
+
+        Args:
+            token: registration token string
+            csr: CSR object returned by create_csr
+
+        Returns:
+             protobuf RegistrationRequest object
+
+        Raises:
+            BootstrapError: cannot load challenge key, or wrong type of
+             challenge key
+        """
+        try:
+            challenge_key = cert_utils.load_key(self._challenge_key_file)
+        except (IOError, ValueError, TypeError) as e:
+            raise BootstrapError(
+                'Gateway does not have a proper challenge key: %s' % e)
+        public_key = challenge_key.public_key().public_bytes(
+            serialization.Encoding.DER,
+            serialization.PublicFormat.SubjectPublicKeyInfo,
+        )
+        r_bytes, s_bytes = self._ecdsa_sha256_response(token.encode())
+        return RegistrationRequest(
+            token=token,
+            hw_id=AccessGatewayID(id=self._hw_id),
+            challenge_key=ChallengeKey(
+                key_type=ChallengeKey.SOFTWARE_ECDSA_SHA256,
+                key=public_key,
+            ),
+            ecdsa_response=Response.ECDSA(r=r_bytes, s=s_bytes),
+            csr=csr,
+        )
+
     def _is_valid_certificate(self, cert):
         """Check whether certificate is usable
 
@@ -17,6 +17,7 @@ from magma.configuration.mconfig_managers import MconfigManager, \
     load_service_mconfig
 from magma.magmad.service_manager import ServiceManager
 from orc8r.protos.mconfig import mconfigs_pb2
+from orc8r.protos.mconfig_pb2 import GatewayConfigsDigest
 
 CONFIG_STREAM_NAME = 'configs'
 
@@ -51,7 +52,12 @@ class ConfigManager(StreamerClient.Callback):
         self._mconfig = self._mconfig_manager.load_mconfig()
 
     def get_request_args(self, stream_name: str) -> Any:
-        return None
+        # Send the digest of the current config so the cloud doesn't stream
+        # the config again if it hasn't changed
+        digest = self._mconfig.metadata.digest.hex_digest
+        if not digest:
+            return None
+        return GatewayConfigsDigest(hex_digest=digest)
 
     def process_update(self, stream_name, updates, resync):
         """
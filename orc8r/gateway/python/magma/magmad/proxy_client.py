@@ -17,6 +17,32 @@ from orc8r.protos.sync_rpc_service_pb2 import GatewayResponse, SyncRPCResponse
 from magma.common.service_registry import ServiceRegistry
 
 
+class _Stream(object):
+    """
+    _Stream holds the flow control state of a streamed request. Request
+    messages after the first one are queued until they're sent to the
+    service, and responses are sent while the cloud grants window.
+    """
+
+    def __init__(self, sequenced, end_of_stream, response_window):
+        # Only requests sequenced by the cloud get sequenced responses
+        self.sequenced = sequenced
+        self.request_messages = asyncio.Queue()
+        self.request_ended = end_of_stream
+        self.next_request_seq = 2
+        # A window of 0 means the responses aren't flow controlled
+        self.flow_controlled = response_window > 0
+        self.response_credits = response_window
+        self.credits_granted = asyncio.Event()
+        self.next_response_seq = 1
+        # Task sending the request messages after the first one
+        self.forwarder = None
+
+    def grant_response_credits(self, credits):
+        self.response_credits += credits
+        self.credits_granted.set()
+
+
 class ControlProxyHttpClient(object):
     """
     ControlProxyHttpClient is a httpclient sending request
@@ -24,11 +50,17 @@ class ControlProxyHttpClient(object):
     for forwarding GatewayRequests from the cloud, and gets a GatewayResponse.
     """
 
+    # Number of request messages of a streamed request the cloud can send
+    # before it needs a window update
+    REQUEST_WINDOW = 8
+
     def __init__(self):
         self._connection_table = {}  # map req id -> client
+        self._stream_table = {}  # map req id -> _Stream
 
     async def send(self, gateway_request, req_id, sync_rpc_response_queue,
-                   conn_closed_table):
+                   conn_closed_table, seq=0, end_of_stream=True,
+                   response_window=0):
         """
         Forwards the given request to the service provided
         in :authority and awaits a response. If a exception is
@@ -43,10 +75,24 @@ class ControlProxyHttpClient(object):
             sync_rpc_response_queue: the response queue that responses
         will be put in
             conn_closed_table: table that maps req ids to if the conn is closed
+            seq: sequence number of the request message, 0 if the cloud
+        doesn't sequence requests
+            end_of_stream: whether the request has no more messages. The
+        rest of the messages are passed to handle_stream_message.
+            response_window: number of responses which can be sent before
+        the cloud updates the window, 0 if the responses aren't flow
+        controlled
 
         Returns: None.
 
         """
+        # Register the stream before awaiting anything, so that the next
+        # messages of the request find it
+        if req_id not in self._stream_table:
+            self._stream_table[req_id] = _Stream(seq > 0,
+                                                 end_of_stream or seq == 0,
+                                                 response_window)
+        stream = self._stream_table[req_id]
         client = await self._get_client(gateway_request.authority)
 
         # Small hack to set PingReceived to no-op because the log gets spammed
@@ -85,7 +131,7 @@ class ControlProxyHttpClient(object):
             stream_id = await client.start_request(req_headers)
             await self._await_gateway_response(client, stream_id, body,
                                                req_id, sync_rpc_response_queue,
-                                               conn_closed_table)
+                                               conn_closed_table, stream)
         except ConnectionAbortedError:
             logging.error("[SyncRPC] proxy_client connection "
                           "terminated by cloud")
@@ -93,15 +139,51 @@ class ControlProxyHttpClient(object):
             logging.error("[SyncRPC] Exception in proxy_client: %s", e)
             sync_rpc_response_queue.put(
                 SyncRPCResponse(heartBeat=False, reqId=req_id,
+                                seq=stream.next_response_seq
+                                if stream.sequenced else 0,
+                                endOfStream=stream.sequenced,
                                 respBody=GatewayResponse(err=str(e))))
         finally:
             del self._connection_table[req_id]
+            self._stream_table.pop(req_id, None)
+            if stream.forwarder is not None:
+                stream.forwarder.cancel()
             client.close_connection()
 
+    async def handle_stream_message(self, request):
+        """
+        Handles a SyncRPCRequest after the first one of a streamed request:
+        either the next request message, or a response window update.
+
+        Args:
+            request: the SyncRPCRequest from the cloud
+
+        Returns: None.
+        """
+        stream = self._stream_table.get(request.reqId)
+        if stream is None:
+            logging.error("[SyncRPC] Got a stream message for unknown "
+                          "request ID %s", request.reqId)
+            return
+        if request.windowUpdate > 0:
+            stream.grant_response_credits(request.windowUpdate)
+        if request.seq == 0:
+            return
+        if request.seq != stream.next_request_seq or stream.request_ended:
+            logging.error("[SyncRPC] Got request message %s of request "
+                          "ID %s out of sequence", request.seq,
+                          request.reqId)
+            return
+        stream.next_request_seq += 1
+        stream.request_ended = request.endOfStream
+        stream.request_messages.put_nowait(
+            (request.reqBody.payload, request.endOfStream))
+
     def close_all_connections(self):
         for _, client in self._connection_table.items():
             client.close_connection()
         self._connection_table.clear()
+        self._stream_table.clear()
 
     @staticmethod
     async def _get_client(service):
@@ -110,18 +192,23 @@ class ControlProxyHttpClient(object):
 
     async def _await_gateway_response(self, client, stream_id, body,
                                       req_id, response_queue,
-                                      conn_closed_table):
-        await client.send_data(stream_id, body, end_stream=True)
+                                      conn_closed_table, stream):
+        await client.send_data(stream_id, body,
+                               end_stream=stream.request_ended)
+        if not stream.request_ended:
+            stream.forwarder = asyncio.ensure_future(
+                self._forward_request_messages(client, stream_id, req_id,
+                                               response_queue, stream))
 
         resp_headers = await client.recv_response(stream_id)
         status = self._get_resp_status(resp_headers)
 
         curr_payload = await self._read_stream(client, stream_id, req_id,
                                                response_queue,
-                                               conn_closed_table)
+                                               conn_closed_table, stream)
         next_payload = await self._read_stream(client, stream_id, req_id,
                                                response_queue,
-                                               conn_closed_table)
+                                               conn_closed_table, stream)
 
         while True:
             trailers = await client.recv_trailers(stream_id) \
@@ -129,15 +216,72 @@ class ControlProxyHttpClient(object):
             headers = self._get_resp_headers(resp_headers, trailers)
             res = GatewayResponse(status=status, headers=headers,
                                   payload=curr_payload)
-            response_queue.put(
-                SyncRPCResponse(heartBeat=False, reqId=req_id, respBody=res))
+            await self._put_response(stream, req_id, res, not next_payload,
+                                     response_queue, conn_closed_table)
             if not next_payload:
                 break
 
             curr_payload = next_payload
             next_payload = await self._read_stream(client, stream_id, req_id,
                                                    response_queue,
-                                                   conn_closed_table)
+                                                   conn_closed_table, stream)
+
+    @staticmethod
+    async def _forward_request_messages(client, stream_id, req_id,
+                                        response_queue, stream):
+        """
+        Sends the request messages after the first one to the service as
+        they arrive, granting the cloud a window to send more.
+        """
+        response_queue.put(SyncRPCResponse(
+            heartBeat=False, reqId=req_id,
+            windowUpdate=ControlProxyHttpClient.REQUEST_WINDOW))
+        try:
+            while True:
+                payload, end_of_stream = await stream.request_messages.get()
+                await client.send_data(stream_id, payload,
+                                       end_stream=end_of_stream)
+                if end_of_stream:
+                    return
+                response_queue.put(SyncRPCResponse(heartBeat=False,
+                                                   reqId=req_id,
+                                                   windowUpdate=1))
+        except asyncio.CancelledError:
+            pass
+        except Exception as e:  # pylint: disable=broad-except
+            logging.error("[SyncRPC] Error forwarding request messages of "
+                          "request ID %s: %s", req_id, e)
+
+    @staticmethod
+    async def _put_response(stream, req_id, gateway_response, end_of_stream,
+                            response_queue, conn_closed_table):
+        """
+        Puts a response in the response queue, sequenced if the cloud
+        sequences the request. If the responses are flow controlled, waits
+        for the cloud to grant window first.
+        """
+        if not stream.sequenced:
+            response_queue.put(SyncRPCResponse(heartBeat=False, reqId=req_id,
+                                               respBody=gateway_response))
+            return
+        while stream.flow_controlled and stream.response_credits == 0:
+            if conn_closed_table.get(req_id, False):
+                raise ConnectionAbortedError
+            stream.credits_granted.clear()
+            try:
+                await asyncio.wait_for(stream.credits_granted.wait(),
+                                       timeout=10.0)
+            except asyncio.TimeoutError:
+                # Keep the cloud from timing out the request while it's
+                # catching up
+                response_queue.put(SyncRPCResponse(
+                    heartBeat=False, reqId=req_id,
+                    respBody=GatewayResponse(keepConnActive=True)))
+        stream.response_credits -= 1
+        response_queue.put(SyncRPCResponse(
+            heartBeat=False, reqId=req_id, seq=stream.next_response_seq,
+            endOfStream=end_of_stream, respBody=gateway_response))
+        stream.next_response_seq += 1
 
     @staticmethod
     def _get_req_headers(raw_req_headers, path, authority):
@@ -168,11 +312,13 @@ class ControlProxyHttpClient(object):
 
     @staticmethod
     async def _read_stream(client, stream_id, req_id, response_queue,
-                           conn_closed_table):
+                           conn_closed_table, stream):
         """
         Attempt to read from the stream. If it times out, send a keepConnActive
         response to the response queue. If it continues to time out after a
-        very long period of time, raise asyncio.TimeoutError. If the connection
+        very long period of time, raise asyncio.TimeoutError, unless the
+        request is sequenced: streamed responses such as TailLogs can be quiet
+        for as long as the client keeps the request open. If the connection
         is closed by the client, raise ConnectionAbortedError.
         """
         async def try_read_stream():
@@ -194,4 +340,6 @@ class ControlProxyHttpClient(object):
                         )
                     )
 
+        if stream.sequenced:
+            return await try_read_stream()
         return await asyncio.wait_for(try_read_stream(), timeout=120.0)
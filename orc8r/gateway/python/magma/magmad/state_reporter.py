@@ -96,7 +96,7 @@ class StateReporter(SDWatchdogTask):
         )
         state_client = StateServiceStub(chan)
         try:
-            await grpc_async_wrapper(
+            response = await grpc_async_wrapper(
                 state_client.ReportStates.future(
                     request,
                     self.GET_STATE_TIMEOUT,
@@ -104,6 +104,11 @@ class StateReporter(SDWatchdogTask):
                 self._loop)
         except Exception as err:
             logging.error("Failed to make a ReportStates request: %s", err)
+            return
+        for unreported in response.unreportedStates:
+            logging.error("State %s/%s was rejected by the cloud: %s",
+                          unreported.type, unreported.deviceID,
+                          unreported.error)
 
     def _get_gw_state(self) -> Optional[State]:
         gw_state = self._checkin_manager.get_latest_gw_state()
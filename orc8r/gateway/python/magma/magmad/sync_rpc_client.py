@@ -137,12 +137,22 @@ class SyncRPCClient(threading.Thread):
             self._conn_closed_table[request.reqId] = True
             return
 
+        # Later messages of streamed requests, and window updates
+        if request.seq > 1 or (request.seq == 0 and request.windowUpdate > 0):
+            asyncio.run_coroutine_threadsafe(
+                self._proxy_client.handle_stream_message(request),
+                self._loop)
+            return
+
         logging.debug("[SyncRPC] Got a request")
         asyncio.run_coroutine_threadsafe(
             self._proxy_client.send(request.reqBody,
                                     request.reqId,
                                     self._response_queue,
-                                    self._conn_closed_table),
+                                    self._conn_closed_table,
+                                    seq=request.seq,
+                                    end_of_stream=request.endOfStream,
+                                    response_window=request.windowUpdate),
             self._loop)
 
     def _retry_connect_sleep(self):
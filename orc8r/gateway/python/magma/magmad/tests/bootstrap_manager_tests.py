@@ -9,6 +9,7 @@ of patent rights can be found in the PATENTS file in the same directory.
 
 import asyncio
 import datetime
+import os
 from concurrent import futures
 from unittest import TestCase
 from unittest.mock import ANY, MagicMock, call, patch
@@ -23,9 +24,9 @@ from cryptography.hazmat.primitives.asymmetric import ec, rsa
 from cryptography.hazmat.primitives.asymmetric.utils import \
     encode_dss_signature
 from google.protobuf.timestamp_pb2 import Timestamp
-from orc8r.protos import bootstrapper_pb2_grpc
+from orc8r.protos import bootstrapper_pb2_grpc, magmad_pb2_grpc
 from orc8r.protos.bootstrapper_pb2 import Challenge, ChallengeKey
-from orc8r.protos.certifier_pb2 import CSR, Certificate
+from orc8r.protos.certifier_pb2 import CSR, Certificate, RenewalAdvice
 
 # Allow access to protected variables for unit testing
 # pylint: disable=protected-access
@@ -67,6 +68,22 @@ class DummpyBootstrapperServer(bootstrapper_pb2_grpc.BootstrapperServicer):
     def RequestSign(self, request, context):
         return create_cert_message()
 
+    def RegisterWithToken(self, request, context):
+        return create_cert_message()
+
+
+class DummyCheckindServer(magmad_pb2_grpc.CheckindServicer):
+    def __init__(self):
+        self.advice = RenewalAdvice()
+
+    def add_to_server(self, server):
+        magmad_pb2_grpc.add_CheckindServicer_to_server(self, server)
+
+    def GetRenewalAdvice(self, request, context):
+        if self.advice is None:
+            context.abort(grpc.StatusCode.NOT_FOUND, 'Unknown certificate')
+        return self.advice
+
 
 class BootstrapManagerTest(TestCase):
     @patch('magma.common.cert_utils.write_key')
@@ -117,6 +134,8 @@ class BootstrapManagerTest(TestCase):
         # Add the servicer
         self._servicer = DummpyBootstrapperServer()
         self._servicer.add_to_server(self._rpc_server)
+        self._checkind_servicer = DummyCheckindServer()
+        self._checkind_servicer.add_to_server(self._rpc_server)
         self._rpc_server.start()
         # Create a rpc stub
         self.channel = grpc.insecure_channel('0.0.0.0:{}'.format(port))
@@ -159,15 +178,20 @@ class BootstrapManagerTest(TestCase):
         self.loop.run_until_complete(test())
 
     @patch('magma.common.cert_utils.load_cert')
+    @patch('%s.BootstrapManager._renewal_advised' % BM)
     @patch('%s.BootstrapManager._bootstrap_now' % BM)
     @patch('%s.BootstrapManager._schedule_next_bootstrap_check' % BM)
     def test__bootstrap_check(self,
                               schedule_bootstrap_check_mock,
                               bootstrap_now_mock,
+                              renewal_advised_mock,
                               load_cert_mock):
         async def test():
             make_awaitable(self.manager._bootstrap_now)
             make_awaitable(self.manager._bootstrap_success_cb)
+            future = asyncio.Future()
+            future.set_result(False)
+            renewal_advised_mock.return_value = future
 
             # cannot load cert
             load_cert_mock.side_effect = IOError
@@ -201,6 +225,38 @@ class BootstrapManagerTest(TestCase):
             await self.manager._bootstrap_check()
             schedule_bootstrap_check_mock.assert_has_calls([call()])
 
+            # cert is valid, but the cloud advises renewal
+            bootstrap_now_mock.reset_mock()
+            schedule_bootstrap_check_mock.reset_mock()
+            future = asyncio.Future()
+            future.set_result(True)
+            renewal_advised_mock.return_value = future
+            await self.manager._bootstrap_check()
+            bootstrap_now_mock.assert_has_calls([call()])
+            schedule_bootstrap_check_mock.assert_not_called()
+
+        # Cancel the loop so that there's no periodic bootstrap/bootstrap_check
+        self.manager._task.cancel()
+        self.loop.run_until_complete(test())
+
+    @patch('%s.ServiceRegistry.get_rpc_channel' % BM)
+    def test__renewal_advised(self, get_rpc_channel_mock):
+        async def test():
+            get_rpc_channel_mock.return_value = self.channel
+
+            self._checkind_servicer.advice = RenewalAdvice()
+            self.assertFalse(await self.manager._renewal_advised())
+
+            self._checkind_servicer.advice = RenewalAdvice(renew_now=True)
+            self.assertTrue(await self.manager._renewal_advised())
+
+            self._checkind_servicer.advice = RenewalAdvice(superseded=True)
+            self.assertTrue(await self.manager._renewal_advised())
+
+            # errors fall back to the local expiry check
+            self._checkind_servicer.advice = None
+            self.assertFalse(await self.manager._renewal_advised())
+
         # Cancel the loop so that there's no periodic bootstrap/bootstrap_check
         self.manager._task.cancel()
         self.loop.run_until_complete(test())
@@ -478,6 +534,63 @@ class BootstrapManagerTest(TestCase):
                 msg='Unknown key type: %s' % challenge.key_type):
             self.manager._construct_response(challenge, CSR())
 
+    @patch('%s.BootstrapManager._schedule_next_bootstrap_check' % BM)
+    @patch('%s.ServiceRegistry.get_bootstrap_rpc_channel' % BM)
+    @patch('%s.cert_utils.write_cert' % BM)
+    @patch('magma.common.cert_utils.write_key')
+    @patch('magma.common.cert_utils.load_key')
+    def test__bootstrap_now_with_token(self,
+                                       load_key_mock,
+                                       write_key_mock,
+                                       write_cert_mock,
+                                       bootstrap_channel_mock,
+                                       schedule_mock):
+        token_file = '__test_registration_token'
+        with open(token_file, 'w') as f:
+            f.write('test_token\n')
+        self.manager._registration_token_file = token_file
+        load_key_mock.return_value = ec.generate_private_key(
+            ec.SECP384R1(), default_backend())
+
+        async def test():
+            make_awaitable(self.manager._bootstrap_success_cb)
+            bootstrap_channel_mock.return_value = self.channel
+
+            await self.manager._bootstrap_now()
+            write_cert_mock.assert_has_calls(
+                [call(ANY, self.manager._gateway_cert_file)])
+            self.manager._bootstrap_success_cb.assert_has_calls([call(True)])
+            # Tokens can only be used once
+            self.assertFalse(os.path.exists(token_file))
+            self.assertIsNone(self.manager._load_registration_token())
+
+        # Cancel the loop so that there's no periodic bootstrap/bootstrap_check
+        self.manager._task.cancel()
+        self.loop.run_until_complete(test())
+
+    @patch('magma.common.cert_utils.load_key')
+    def test__construct_registration_request(self, load_key_mock):
+        private_key = ec.generate_private_key(ec.SECP384R1(), default_backend())
+        load_key_mock.return_value = private_key
+        request = self.manager._construct_registration_request(
+            'test_token', CSR())
+        self.assertEqual(request.token, 'test_token')
+        self.assertEqual(request.hw_id.id, self.hw_id)
+        self.assertEqual(request.challenge_key.key_type,
+                         ChallengeKey.SOFTWARE_ECDSA_SHA256)
+        public_key = serialization.load_der_public_key(
+            request.challenge_key.key, default_backend())
+        signature = encode_dss_signature(
+            int.from_bytes(request.ecdsa_response.r, 'big'),
+            int.from_bytes(request.ecdsa_response.s, 'big'),
+        )
+        # The token is signed with the challenge key
+        public_key.verify(signature, b'test_token', ec.ECDSA(hashes.SHA256()))
+
+        load_key_mock.side_effect = IOError
+        with self.assertRaises(bm.BootstrapError):
+            self.manager._construct_registration_request('test_token', CSR())
+
     @patch('magma.common.cert_utils.load_key')
     def test__ecdsa_sha256_response(self, load_key_mock):
         challenge = b'challenge'
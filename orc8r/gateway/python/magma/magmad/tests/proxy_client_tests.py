@@ -12,7 +12,7 @@ import asyncio
 import queue
 import unittest.mock
 
-from orc8r.protos.sync_rpc_service_pb2 import GatewayRequest
+from orc8r.protos.sync_rpc_service_pb2 import GatewayRequest, SyncRPCRequest
 
 from magma.common.service_registry import ServiceRegistry
 from magma.magmad.proxy_client import ControlProxyHttpClient
@@ -92,6 +92,15 @@ class MockStreamingClient(object):
         return
 
 
+class MockRecordingClient(MockStreamingClient):
+    def __init__(self, payload, headers, trailers, expected_req):
+        super().__init__(payload, headers, trailers, expected_req)
+        self.sent_data = []
+
+    async def send_data(self, stream_id, body, end_stream=False):
+        self.sent_data.append((body, end_stream))
+
+
 class ProxyClientTests(unittest.TestCase):
     """
     Tests for the ProxyClient.
@@ -217,6 +226,59 @@ class ProxyClientTests(unittest.TestCase):
         self.assertEqual(res_2.respBody.headers['grpc-status'], '0')
         self._loop.close()
 
+    @unittest.mock.patch('aioh2.open_connection')
+    def test_http_client_streamed_request(self, mock_conn):
+        expected_payload = \
+            b'\x00\x00\x00\x00\n\n\x08\x12\x04\xc0\xa8\x80\x00\x18\x18'
+        expected_header = [(':status', '200'),
+                           ('content-type', 'application/grpc')]
+        expected_trailers = [('grpc-status', '0'), ('grpc-message', '')]
+        client = MockRecordingClient(expected_payload, expected_header,
+                                     expected_trailers, self._req_body)
+        mock_conn.side_effect = asyncio.coroutine(
+            unittest.mock.MagicMock(return_value=client))
+
+        response_queue = queue.Queue()
+        conn_closed_table = {
+            1234: False
+        }
+
+        # The request has 2 messages, and the cloud allows 1 response before
+        # updating the window
+        future = asyncio.ensure_future(
+            self._proxy_client.send(self._req_body,
+                                    1234,
+                                    response_queue,
+                                    conn_closed_table,
+                                    seq=1,
+                                    end_of_stream=False,
+                                    response_window=1))
+        asyncio.ensure_future(self._proxy_client.handle_stream_message(
+            SyncRPCRequest(reqId=1234, seq=2, endOfStream=True,
+                           reqBody=GatewayRequest(payload=b'\x01'))))
+        asyncio.ensure_future(self._proxy_client.handle_stream_message(
+            SyncRPCRequest(reqId=1234, windowUpdate=1)))
+
+        self._loop.run_until_complete(future)
+
+        self.assertEqual(client.sent_data,
+                         [(bytes.fromhex('0000000000'), False),
+                          (b'\x01', True)])
+        self.assertEqual(response_queue.qsize(), 3)
+        window_update = response_queue.get(timeout=0)
+        self.assertEqual(window_update.reqId, 1234)
+        self.assertEqual(window_update.windowUpdate,
+                         ControlProxyHttpClient.REQUEST_WINDOW)
+        res_1 = response_queue.get(timeout=0)
+        self.assertEqual(res_1.seq, 1)
+        self.assertFalse(res_1.endOfStream)
+        self.assertEqual(res_1.respBody.payload, expected_payload)
+        res_2 = response_queue.get(timeout=0)
+        self.assertEqual(res_2.seq, 2)
+        self.assertTrue(res_2.endOfStream)
+        self.assertEqual(res_2.respBody.headers['grpc-status'], '0')
+        self._loop.close()
+
 
 if __name__ == "__main__":
     unittest.main()